@@ -212,6 +212,7 @@ type GlobalAcceleratorSpec struct {
 	// After you bring an address range to AWS, it appears in your account as an address pool. When you create an accelerator, you can assign one IPv4 address from your range to it.
 	// Global Accelerator assigns you a second static IPv4 address from an Amazon IP address range. If you bring two IPv4 address ranges to AWS, you can assign one IPv4 address from each range to your accelerator.
 	// Note that you can't update IP addresses for an existing accelerator. To change them, you must create a new accelerator with the new addresses.
+	// The controller replaces the accelerator to assign addresses that differ from its current addresses, once confirmed with the aga.k8s.aws/confirm-replacement annotation.
 	// For more information, see Bring your own IP addresses (BYOIP) in the AWS Global Accelerator Developer Guide.
 	// https://docs.aws.amazon.com/global-accelerator/latest/dg/using-byoip.html
 	// +kubebuilder:validation:MinItems=1
@@ -81,14 +81,54 @@ type ALBTargetControlConfigSpec struct {
 	// Resources specifies the resource requirements for the ALB target control agent sidecar
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// RolloutOnUpdate specifies whether the controller restarts the workloads whose pods run an outdated agent configuration.
+	// Deployments, StatefulSets and DaemonSets are restarted by updating their pod template, other pods are only reported in the status.
+	// +optional
+	RolloutOnUpdate *bool `json:"rolloutOnUpdate,omitempty"`
+}
+
+// ALBTargetControlConfigStatus defines the observed state of ALBTargetControlConfig
+type ALBTargetControlConfigStatus struct {
+	// ObservedGeneration is the generation of the spec the status was computed for.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigHash is the hash of the agent configuration injected into new pods.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// InjectedPods is the number of pods running an agent injected from this configuration.
+	// +optional
+	InjectedPods int32 `json:"injectedPods,omitempty"`
+
+	// OutdatedPods is the number of pods running an agent injected from a previous version of this configuration.
+	// +optional
+	OutdatedPods int32 `json:"outdatedPods,omitempty"`
+
+	// FailedPods is the number of pods whose agent fails to start or keeps restarting.
+	// +optional
+	FailedPods int32 `json:"failedPods,omitempty"`
+
+	// OutdatedPodNames lists the outdated pods in the namespace/name format, truncated to 100 entries.
+	// +optional
+	OutdatedPodNames []string `json:"outdatedPodNames,omitempty"`
+
+	// FailedPodNames lists the failed pods in the namespace/name format, truncated to 100 entries.
+	// +optional
+	FailedPodNames []string `json:"failedPodNames,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:object:generate=true
 // +kubebuilder:resource:scope=Namespaced
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.image",description="The ALB target control agent sidecar image"
 // +kubebuilder:printcolumn:name="DESTINATION",type="string",JSONPath=".spec.destinationAddress",description="Application destination address"
+// +kubebuilder:printcolumn:name="INJECTED",type="integer",JSONPath=".status.injectedPods",description="The number of pods running the agent"
+// +kubebuilder:printcolumn:name="OUTDATED",type="integer",JSONPath=".status.outdatedPods",description="The number of pods running an outdated agent configuration"
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failedPods",description="The number of pods whose agent is failing"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ALBTargetControlConfig is the Schema for the albtargetcontrolconfigs API
@@ -96,7 +136,8 @@ type ALBTargetControlConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ALBTargetControlConfigSpec `json:"spec,omitempty"`
+	Spec   ALBTargetControlConfigSpec   `json:"spec,omitempty"`
+	Status ALBTargetControlConfigStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
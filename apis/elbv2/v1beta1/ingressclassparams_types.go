@@ -103,8 +103,71 @@ type Listener struct {
 
 // Information about a load balancer capacity reservation.
 type MinimumLoadBalancerCapacity struct {
-	// The Capacity Units Value.
+	// The Capacity Units Value, applies outside of the scheduled windows.
 	CapacityUnits int32 `json:"capacityUnits"`
+
+	// Schedules define recurring windows during which a different capacity reservation applies.
+	// When windows overlap, the largest capacity reservation applies.
+	// +optional
+	Schedules []CapacityReservationSchedule `json:"schedules,omitempty"`
+
+	// Calendar defines dated windows during which a different capacity reservation applies, such as known events.
+	// +optional
+	Calendar []CapacityReservationCalendarEntry `json:"calendar,omitempty"`
+
+	// TimeZone is the IANA time zone in which the Schedules and the Calendar are evaluated, defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CapacityReservationSchedule defines a recurring capacity reservation window.
+type CapacityReservationSchedule struct {
+	// Name of the window.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Start is the standard cron expression (minute hour day-of-month month day-of-week) at which the window opens.
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open, such as 10h.
+	Duration metav1.Duration `json:"duration"`
+
+	// The Capacity Units Value while the window is open.
+	// +kubebuilder:validation:Minimum=0
+	CapacityUnits int32 `json:"capacityUnits"`
+}
+
+// CapacityReservationCalendarEntry defines a dated capacity reservation window.
+type CapacityReservationCalendarEntry struct {
+	// Name of the window.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Start is the local date and time at which the window opens, in the YYYY-MM-DDTHH:MM format.
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}$"
+	Start string `json:"start"`
+
+	// End is the local date and time at which the window closes, in the YYYY-MM-DDTHH:MM format.
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}$"
+	End string `json:"end"`
+
+	// The Capacity Units Value while the window is open.
+	// +kubebuilder:validation:Minimum=0
+	CapacityUnits int32 `json:"capacityUnits"`
+}
+
+// CapacityReservationStatus reports the scheduled capacity reservation.
+type CapacityReservationStatus struct {
+	// CurrentCapacityUnits is the Capacity Units Value in effect.
+	CurrentCapacityUnits int32 `json:"currentCapacityUnits"`
+
+	// NextCapacityUnits is the Capacity Units Value after the next scheduled transition.
+	// +optional
+	NextCapacityUnits *int32 `json:"nextCapacityUnits,omitempty"`
+
+	// NextTransitionTime is the time of the next scheduled transition.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
 }
 
 // IPAMConfiguration defines the IPAM configuration for an Ingress.
@@ -114,6 +177,75 @@ type IPAMConfiguration struct {
 	IPv4IPAMPoolId *string `json:"ipv4IPAMPoolId,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=FirstCome;Shared
+// HostOwnershipPolicy defines how hosts are shared between the namespaces of an IngressGroup.
+type HostOwnershipPolicy string
+
+const (
+	// HostOwnershipFirstCome assigns a host to the namespace of the oldest Ingress using it.
+	HostOwnershipFirstCome HostOwnershipPolicy = "FirstCome"
+	// HostOwnershipShared lets Ingresses from any namespace use the same host.
+	HostOwnershipShared HostOwnershipPolicy = "Shared"
+)
+
+// GroupOrderRange defines an inclusive range of IngressGroup orders.
+type GroupOrderRange struct {
+	// Min is the lowest allowed order.
+	// +kubebuilder:validation:Minimum=-1000
+	// +kubebuilder:validation:Maximum=1000
+	Min int32 `json:"min"`
+
+	// Max is the highest allowed order.
+	// +kubebuilder:validation:Minimum=-1000
+	// +kubebuilder:validation:Maximum=1000
+	Max int32 `json:"max"`
+}
+
+// IngressGroupNamespaceRule defines what the Ingresses from a set of namespaces can claim on the shared load balancer.
+type IngressGroupNamespaceRule struct {
+	// Namespaces are the names of the namespaces this rule applies to.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector selects the namespaces this rule applies to by label.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// AllowedHosts are the hosts Ingresses can use, either exact hosts or wildcards like `*.team-a.example.com`.
+	// * if absent, any host can be used.
+	// +optional
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+
+	// AllowedPathPrefixes are the path prefixes Ingress paths must start with.
+	// * if absent, any path can be used.
+	// +optional
+	AllowedPathPrefixes []string `json:"allowedPathPrefixes,omitempty"`
+
+	// MaxRules is the maximum number of rules, counted as Ingress paths, across the Ingresses of a namespace.
+	// * if absent, there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRules *int32 `json:"maxRules,omitempty"`
+
+	// AllowedGroupOrder is the range of `group.order` Ingresses can use.
+	// * if absent, any order can be used.
+	// +optional
+	AllowedGroupOrder *GroupOrderRange `json:"allowedGroupOrder,omitempty"`
+}
+
+// IngressGroupOwnership defines ownership rules isolating the namespaces sharing an IngressGroup.
+type IngressGroupOwnership struct {
+	// NamespaceRules define what the Ingresses of each namespace can claim. The first rule matching the namespace of an Ingress applies.
+	// * if absent, Ingresses from any namespace can claim any host and path.
+	// * if present, Ingresses from namespaces without a matching rule are rejected.
+	// +optional
+	NamespaceRules []IngressGroupNamespaceRule `json:"namespaceRules,omitempty"`
+
+	// HostOwnership defines whether a host can be used by Ingresses from several namespaces.
+	// +optional
+	HostOwnership *HostOwnershipPolicy `json:"hostOwnership,omitempty"`
+}
+
 // IngressClassParamsSpec defines the desired state of IngressClassParams
 // +kubebuilder:validation:XValidation:rule="!(has(self.prefixListsIDs) && has(self.PrefixListsIDs))", message="cannot specify both 'prefixListsIDs' and 'PrefixListsIDs' fields"
 type IngressClassParamsSpec struct {
@@ -197,10 +329,90 @@ type IngressClassParamsSpec struct {
 	// WAFv2ACLName specifies name of the Amazon WAFv2 web ACL.
 	// +optional
 	WAFv2ACLName string `json:"wafv2AclName"`
+
+	// WAFv2ACLRef specifies name of the WAFv2WebACL whose web ACL is associated with the load balancer.
+	// +optional
+	WAFv2ACLRef string `json:"wafv2AclRef,omitempty"`
+
+	// ShieldAdvanced defines the AWS Shield Advanced protection of the load balancer.
+	// When specified, it is authoritative for the protection groups, health check and application-layer automatic response.
+	// +optional
+	ShieldAdvanced *ShieldAdvancedConfiguration `json:"shieldAdvanced,omitempty"`
+
+	// GroupOwnership defines ownership rules for the Ingresses that belong to IngressClass with this IngressClassParams
+	// and join an IngressGroup shared with other namespaces.
+	// +optional
+	GroupOwnership *IngressGroupOwnership `json:"groupOwnership,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=SUM;MEAN;MAX
+// ShieldProtectionGroupAggregation defines how the metrics of the protection group members are combined.
+type ShieldProtectionGroupAggregation string
+
+const (
+	ShieldProtectionGroupAggregationSum  ShieldProtectionGroupAggregation = "SUM"
+	ShieldProtectionGroupAggregationMean ShieldProtectionGroupAggregation = "MEAN"
+	ShieldProtectionGroupAggregationMax  ShieldProtectionGroupAggregation = "MAX"
+)
+
+// ShieldProtectionGroup defines a Shield Advanced protection group.
+type ShieldProtectionGroup struct {
+	// ID is the ID of the protection group.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9\\-]{1,36}$"
+	ID string `json:"id"`
+
+	// Aggregation defines how the metrics of the protection group members are combined, defaults to SUM.
+	// Only applied to protection groups created by the controller.
+	// +optional
+	Aggregation *ShieldProtectionGroupAggregation `json:"aggregation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Block;Count
+// ShieldApplicationLayerAutomaticResponseAction defines the action of the rules added by the automatic application-layer DDoS mitigation.
+type ShieldApplicationLayerAutomaticResponseAction string
+
+const (
+	ShieldApplicationLayerAutomaticResponseActionBlock ShieldApplicationLayerAutomaticResponseAction = "Block"
+	ShieldApplicationLayerAutomaticResponseActionCount ShieldApplicationLayerAutomaticResponseAction = "Count"
+)
+
+// ShieldApplicationLayerAutomaticResponse defines the automatic application-layer DDoS mitigation.
+type ShieldApplicationLayerAutomaticResponse struct {
+	// Action is the action of the rules added to mitigate an attack.
+	Action ShieldApplicationLayerAutomaticResponseAction `json:"action"`
+}
+
+// ShieldAdvancedConfiguration defines the AWS Shield Advanced protection of the load balancer.
+type ShieldAdvancedConfiguration struct {
+	// Enabled specifies whether the load balancer is protected, it overrides the shield-advanced-protection annotation.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ProtectionGroups are the protection groups the load balancer belongs to.
+	// Missing protection groups are created with the ARBITRARY pattern.
+	// +optional
+	ProtectionGroups []ShieldProtectionGroup `json:"protectionGroups,omitempty"`
+
+	// HealthCheckARN is the ARN of the Route 53 health check associated with the protection, for health-based detection.
+	// +optional
+	HealthCheckARN *string `json:"healthCheckARN,omitempty"`
+
+	// ApplicationLayerAutomaticResponse enables the automatic application-layer DDoS mitigation.
+	// A web ACL must be associated with the load balancer.
+	// +optional
+	ApplicationLayerAutomaticResponse *ShieldApplicationLayerAutomaticResponse `json:"applicationLayerAutomaticResponse,omitempty"`
+}
+
+// IngressClassParamsStatus defines the observed state of IngressClassParams
+type IngressClassParamsStatus struct {
+	// CapacityReservation reports the scheduled capacity reservation, set when MinimumLoadBalancerCapacity has Schedules or a Calendar.
+	// +optional
+	CapacityReservation *CapacityReservationStatus `json:"capacityReservation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,singular=ingressclassparam
+// +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="GROUP-NAME",type="string",JSONPath=".spec.group.name",description="The Ingress Group name"
 // +kubebuilder:printcolumn:name="SCHEME",type="string",JSONPath=".spec.scheme",description="The AWS Load Balancer scheme"
@@ -211,7 +423,8 @@ type IngressClassParams struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec IngressClassParamsSpec `json:"spec,omitempty"`
+	Spec   IngressClassParamsSpec   `json:"spec,omitempty"`
+	Status IngressClassParamsStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,106 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// QUICServerIDClaimPhase is the allocation phase of a QUIC server ID.
+type QUICServerIDClaimPhase string
+
+const (
+	// QUICServerIDClaimPhasePending means the server ID was allocated at admission and no pod uses it yet.
+	QUICServerIDClaimPhasePending QUICServerIDClaimPhase = "Pending"
+	// QUICServerIDClaimPhaseBound means a pod uses the server ID.
+	QUICServerIDClaimPhaseBound QUICServerIDClaimPhase = "Bound"
+	// QUICServerIDClaimPhaseRetained means the pod using the server ID is gone,
+	// the server ID is kept for the StatefulSet pod with the same name.
+	QUICServerIDClaimPhaseRetained QUICServerIDClaimPhase = "Retained"
+)
+
+// QUICServerIDClaimSpec defines the desired state of QUICServerIDClaim
+type QUICServerIDClaimSpec struct {
+	// ServerID is the allocated QUIC server ID, in the hex format used for target registration.
+	// +kubebuilder:validation:Pattern=`^0x[0-9a-f]+$`
+	ServerID string `json:"serverID"`
+
+	// ContainerName is the container the server ID was injected into.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// PodName is the name of the pod the server ID was allocated for, only known at admission for pods without generateName.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// StatefulSetName is the StatefulSet controlling the pod, the server ID is reused when the pod is recreated.
+	// +optional
+	StatefulSetName string `json:"statefulSetName,omitempty"`
+}
+
+// QUICServerIDClaimStatus defines the observed state of QUICServerIDClaim
+type QUICServerIDClaimStatus struct {
+	// Phase is the allocation phase of the server ID.
+	// +optional
+	Phase QUICServerIDClaimPhase `json:"phase,omitempty"`
+
+	// PodName is the name of the pod using the server ID.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// PodUID is the UID of the pod using the server ID.
+	// +optional
+	PodUID types.UID `json:"podUID,omitempty"`
+
+	// CollidingPodNames lists the other pods using the same server ID.
+	// +optional
+	CollidingPodNames []string `json:"collidingPodNames,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +kubebuilder:resource:scope=Namespaced,shortName=qsidclaim
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SERVER-ID",type="string",JSONPath=".spec.serverID",description="The allocated QUIC server ID"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The allocation phase"
+// +kubebuilder:printcolumn:name="POD",type="string",JSONPath=".status.podName",description="The pod using the server ID"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// QUICServerIDClaim is the Schema for the quicserveridclaims API.
+// A claim is named after its server ID, so that a server ID is allocated at most once per namespace.
+type QUICServerIDClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QUICServerIDClaimSpec   `json:"spec,omitempty"`
+	Status QUICServerIDClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QUICServerIDClaimList contains a list of QUICServerIDClaim
+type QUICServerIDClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QUICServerIDClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QUICServerIDClaim{}, &QUICServerIDClaimList{})
+}
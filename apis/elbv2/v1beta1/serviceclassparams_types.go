@@ -0,0 +1,107 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=prefer-service;prefer-service-class-params
+// ServiceClassParamsMergeMode is the merging behavior when both the ServiceClassParams and the Service annotations specify a setting.
+type ServiceClassParamsMergeMode string
+
+const (
+	// MergeModePreferServiceClassParams gives precedence to the settings in the ServiceClassParams.
+	MergeModePreferServiceClassParams ServiceClassParamsMergeMode = "prefer-service-class-params"
+	// MergeModePreferService gives precedence to the settings in the Service annotations.
+	MergeModePreferService ServiceClassParamsMergeMode = "prefer-service"
+)
+
+// ServiceClassParamsSpec defines the desired state of ServiceClassParams
+type ServiceClassParamsSpec struct {
+	// LoadBalancerClass selects the Services with this spec.loadBalancerClass.
+	// At most one ServiceClassParams may select a load balancer class.
+	// +kubebuilder:validation:MinLength=1
+	LoadBalancerClass string `json:"loadBalancerClass"`
+
+	// MergingMode defines the precedence when both the ServiceClassParams and the Service annotations specify a setting.
+	// Scalar settings are taken from the preferred side, tags and load balancer attributes are merged key by key.
+	// Defaults to prefer-service-class-params.
+	// +optional
+	MergingMode *ServiceClassParamsMergeMode `json:"mergingMode,omitempty"`
+
+	// NamespaceSelector restrict the namespaces of Services that are allowed to use the load balancer class.
+	// * if absent or present but empty, it selects all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Scheme defines the scheme for all Services with the load balancer class.
+	// +optional
+	Scheme *LoadBalancerScheme `json:"scheme,omitempty"`
+
+	// IPAddressType defines the ip address type for all Services with the load balancer class.
+	// +optional
+	IPAddressType *IPAddressType `json:"ipAddressType,omitempty"`
+
+	// Subnets defines the subnets for all Services with the load balancer class.
+	// +optional
+	Subnets *SubnetSelector `json:"subnets,omitempty"`
+
+	// SSLPolicy specifies the SSL Policy of TLS listeners for all Services with the load balancer class.
+	// +optional
+	SSLPolicy *string `json:"sslPolicy,omitempty"`
+
+	// InboundCIDRs specifies the CIDRs that are allowed to access the Services with the load balancer class.
+	// +optional
+	InboundCIDRs []string `json:"inboundCIDRs,omitempty"`
+
+	// Tags defines list of Tags on AWS resources provisioned for Services with the load balancer class.
+	// +optional
+	Tags []Tag `json:"tags,omitempty"`
+
+	// LoadBalancerAttributes define the custom attributes to LoadBalancers for all Services with the load balancer class.
+	// +optional
+	LoadBalancerAttributes []Attribute `json:"loadBalancerAttributes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,singular=serviceclassparam
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="LOAD-BALANCER-CLASS",type="string",JSONPath=".spec.loadBalancerClass",description="The load balancer class of the Services"
+// +kubebuilder:printcolumn:name="SCHEME",type="string",JSONPath=".spec.scheme",description="The AWS Load Balancer scheme"
+// +kubebuilder:printcolumn:name="MERGING-MODE",type="string",JSONPath=".spec.mergingMode",description="The merging mode with Service annotations"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// ServiceClassParams is the Schema for the ServiceClassParams API
+type ServiceClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceClassParamsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceClassParamsList contains a list of ServiceClassParams
+type ServiceClassParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceClassParams `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceClassParams{}, &ServiceClassParamsList{})
+}
@@ -122,6 +122,13 @@ type TargetGroupBindingNetworking struct {
 	Ingress []NetworkingIngressRule `json:"ingress,omitempty"`
 }
 
+// WeightedRegistration defines the proportional registration of endpoints for TargetGroupBindings sharing a TargetGroup.
+type WeightedRegistration struct {
+	// weight is the relative share of targets this TargetGroupBinding registers into the TargetGroup.
+	// +kubebuilder:validation:Minimum=0
+	Weight int32 `json:"weight"`
+}
+
 // TargetGroupBindingSpec defines the desired state of TargetGroupBinding
 type TargetGroupBindingSpec struct {
 	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
@@ -136,6 +143,11 @@ type TargetGroupBindingSpec struct {
 	// +optional
 	MultiClusterTargetGroup bool `json:"multiClusterTargetGroup,omitempty"`
 
+	// weightedRegistration registers a share of the Service endpoints proportional to weight, relative to the other
+	// TargetGroupBindings with weightedRegistration for the same TargetGroup. Requires multiClusterTargetGroup.
+	// +optional
+	WeightedRegistration *WeightedRegistration `json:"weightedRegistration,omitempty"`
+
 	// targetType is the TargetType of TargetGroup. If unspecified, it will be automatically inferred.
 	// +optional
 	TargetType *TargetType `json:"targetType,omitempty"`
@@ -182,6 +194,47 @@ type TargetGroupBindingStatus struct {
 	// Conditions describe the current conditions of the TargetGroupBinding.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// TargetHealth summarizes the health of targets in the TargetGroup, as observed during the latest reconcile.
+	// +optional
+	TargetHealth *TargetHealthSummary `json:"targetHealth,omitempty"`
+
+	// EndpointSecurityGroups lists the securityGroups of targets whose inbound rules are managed for this TargetGroupBinding's networking,
+	// including the securityGroups of pods using SecurityGroups for pods.
+	// +optional
+	EndpointSecurityGroups []string `json:"endpointSecurityGroups,omitempty"`
+}
+
+// TargetHealthSummary defines the aggregated health of targets in the TargetGroup.
+type TargetHealthSummary struct {
+	// Healthy is the number of targets in healthy state.
+	Healthy int32 `json:"healthy"`
+
+	// Unhealthy is the number of targets in unhealthy or unavailable state.
+	Unhealthy int32 `json:"unhealthy"`
+
+	// Draining is the number of targets that are being deregistered.
+	Draining int32 `json:"draining"`
+
+	// Initial is the number of targets that are being registered.
+	Initial int32 `json:"initial"`
+
+	// UnhealthyReasons lists the most frequent reasons of unhealthy targets, in descending order of target count.
+	// +optional
+	UnhealthyReasons []TargetHealthReason `json:"unhealthyReasons,omitempty"`
+}
+
+// TargetHealthReason defines the number of unhealthy targets sharing the same reason.
+type TargetHealthReason struct {
+	// Reason is the reason code reported by ELBv2, such as Target.ResponseCodeMismatch.
+	Reason string `json:"reason"`
+
+	// Count is the number of unhealthy targets with this reason.
+	Count int32 `json:"count"`
+
+	// Description is the description reported for one of these targets.
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -190,6 +243,8 @@ type TargetGroupBindingStatus struct {
 // +kubebuilder:printcolumn:name="SERVICE-NAME",type="string",JSONPath=".spec.serviceRef.name",description="The Kubernetes Service's name"
 // +kubebuilder:printcolumn:name="SERVICE-PORT",type="string",JSONPath=".spec.serviceRef.port",description="The Kubernetes Service's port"
 // +kubebuilder:printcolumn:name="TARGET-TYPE",type="string",JSONPath=".spec.targetType",description="The AWS TargetGroup's TargetType"
+// +kubebuilder:printcolumn:name="HEALTHY",type="integer",JSONPath=".status.targetHealth.healthy",description="The number of healthy targets"
+// +kubebuilder:printcolumn:name="UNHEALTHY",type="integer",JSONPath=".status.targetHealth.unhealthy",description="The number of unhealthy targets"
 // +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".spec.targetGroupARN",description="The AWS TargetGroup's Amazon Resource Name",priority=1
 // +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.targetGroupName",description="The AWS TargetGroup's Name",priority=2
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
@@ -0,0 +1,219 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+// TrafficRolloutTargetKind is the kind of resource whose traffic is rolled out.
+type TrafficRolloutTargetKind string
+
+const (
+	TrafficRolloutTargetKindIngress   TrafficRolloutTargetKind = "Ingress"
+	TrafficRolloutTargetKindHTTPRoute TrafficRolloutTargetKind = "HTTPRoute"
+)
+
+// TrafficRolloutPhase is the phase of a TrafficRollout.
+type TrafficRolloutPhase string
+
+const (
+	// TrafficRolloutPhaseProgressing means the rollout is stepping the canary weight.
+	TrafficRolloutPhaseProgressing TrafficRolloutPhase = "Progressing"
+	// TrafficRolloutPhasePaused means the rollout is paused through spec.paused, and keeps the current canary weight.
+	TrafficRolloutPhasePaused TrafficRolloutPhase = "Paused"
+	// TrafficRolloutPhaseSucceeded means all steps completed, and the weight of the last step is kept.
+	TrafficRolloutPhaseSucceeded TrafficRolloutPhase = "Succeeded"
+	// TrafficRolloutPhaseRolledBack means analysis failed, and all traffic is sent back to the stable backend.
+	TrafficRolloutPhaseRolledBack TrafficRolloutPhase = "RolledBack"
+)
+
+// TrafficRolloutTargetReference references the resource whose traffic is rolled out.
+type TrafficRolloutTargetReference struct {
+	// Kind is the kind of the resource, either Ingress or HTTPRoute.
+	Kind TrafficRolloutTargetKind `json:"kind"`
+
+	// Name is the name of the resource, in the namespace of the TrafficRollout.
+	Name string `json:"name"`
+
+	// SectionName is the name of the HTTPRoute rule to roll out.
+	// * if absent, all rules of the HTTPRoute forwarding to the stable backend are rolled out.
+	// +optional
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// TrafficRolloutBackend references a Service port used as backend.
+type TrafficRolloutBackend struct {
+	// Name is the name of the Service.
+	Name string `json:"name"`
+
+	// Port is the port of the Service, either the port number or the port name.
+	Port intstr.IntOrString `json:"port"`
+}
+
+// TrafficRolloutStep defines one step of a rollout.
+type TrafficRolloutStep struct {
+	// Weight is the percentage of traffic sent to the canary backend during this step.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause is how long the step lasts before moving to the next step, once analysis passes.
+	// * if absent, the rollout moves to the next step as soon as analysis passes.
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// TrafficRolloutHeaderRouting defines requests always sent to the canary backend while the rollout is progressing.
+type TrafficRolloutHeaderRouting struct {
+	// Name is the name of the HTTP header.
+	Name string `json:"name"`
+
+	// Values are the values of the HTTP header. Wildcards `*` and `?` are supported.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// TargetHealthAnalysis gates steps on the health of the canary targets.
+type TargetHealthAnalysis struct {
+	// MinHealthyPercent is the minimum percentage of healthy canary targets.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MinHealthyPercent int32 `json:"minHealthyPercent"`
+}
+
+// PrometheusAnalysis gates steps on the result of a Prometheus query.
+type PrometheusAnalysis struct {
+	// Address is the URL of the Prometheus server, such as http://prometheus.monitoring:9090.
+	// It must be one of the addresses allowed by the controller --traffic-rollout-prometheus-addresses flag.
+	Address string `json:"address"`
+
+	// Query is a PromQL query returning a scalar, or a vector whose first sample is used.
+	Query string `json:"query"`
+
+	// Min is the minimum value of the query result.
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty"`
+
+	// Max is the maximum value of the query result.
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty"`
+}
+
+// TrafficRolloutAnalysis defines the checks gating each step.
+type TrafficRolloutAnalysis struct {
+	// TargetHealth gates steps on the health of the canary targets.
+	// +optional
+	TargetHealth *TargetHealthAnalysis `json:"targetHealth,omitempty"`
+
+	// Prometheus gates steps on the result of a Prometheus query.
+	// +optional
+	Prometheus *PrometheusAnalysis `json:"prometheus,omitempty"`
+
+	// Interval is how often the checks run.
+	// +kubebuilder:default="30s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// FailureLimit is the number of consecutive failed checks tolerated within a step, before rolling back.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailureLimit int32 `json:"failureLimit,omitempty"`
+}
+
+// TrafficRolloutSpec defines the desired state of TrafficRollout
+type TrafficRolloutSpec struct {
+	// TargetRef references the Ingress or HTTPRoute whose traffic is rolled out.
+	TargetRef TrafficRolloutTargetReference `json:"targetRef"`
+
+	// StableBackend is the backend currently serving traffic.
+	StableBackend TrafficRolloutBackend `json:"stableBackend"`
+
+	// CanaryBackend is the backend traffic is shifted to.
+	CanaryBackend TrafficRolloutBackend `json:"canaryBackend"`
+
+	// Steps are the canary weights the rollout goes through, in order.
+	// +kubebuilder:validation:MinItems=1
+	Steps []TrafficRolloutStep `json:"steps"`
+
+	// HeaderRouting sends matching requests to the canary backend while the rollout is progressing.
+	// Only supported for Ingress targets, HTTPRoutes can match headers natively.
+	// +optional
+	HeaderRouting *TrafficRolloutHeaderRouting `json:"headerRouting,omitempty"`
+
+	// Analysis defines the checks gating each step.
+	// * if absent, steps only wait for their pause.
+	// +optional
+	Analysis *TrafficRolloutAnalysis `json:"analysis,omitempty"`
+
+	// Paused pauses the rollout at the current step.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TrafficRolloutStatus defines the observed state of TrafficRollout
+type TrafficRolloutStatus struct {
+	// ObservedGeneration is the generation of the spec observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// RolloutHash is the hash of the targetRef, backends and steps the rollout is running for.
+	// The rollout restarts from the first step when they change.
+	// +optional
+	RolloutHash string `json:"rolloutHash,omitempty"`
+
+	// Phase is the phase of the rollout.
+	// +optional
+	Phase TrafficRolloutPhase `json:"phase,omitempty"`
+
+	// CurrentStep is the index of the current step.
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// CanaryWeight is the percentage of traffic currently sent to the canary backend.
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+
+	// StepStartTime is when the current step started.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+
+	// FailedChecks is the number of consecutive failed checks in the current step.
+	// +optional
+	FailedChecks int32 `json:"failedChecks,omitempty"`
+
+	// Message is a human-readable message about the latest check.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="TARGET-KIND",type="string",JSONPath=".spec.targetRef.kind",description="The kind of the rolled out resource"
+// +kubebuilder:printcolumn:name="TARGET-NAME",type="string",JSONPath=".spec.targetRef.name",description="The name of the rolled out resource"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="The phase of the rollout"
+// +kubebuilder:printcolumn:name="CANARY-WEIGHT",type="integer",JSONPath=".status.canaryWeight",description="The percentage of traffic sent to the canary backend"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// TrafficRollout is the Schema for the TrafficRollout API
+type TrafficRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrafficRolloutSpec   `json:"spec,omitempty"`
+	Status TrafficRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TrafficRolloutList contains a list of TrafficRollout
+type TrafficRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrafficRollout{}, &TrafficRolloutList{})
+}
@@ -0,0 +1,275 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Allow;Block
+// WAFv2DefaultAction is the action applied to requests that don't match any rule.
+type WAFv2DefaultAction string
+
+const (
+	WAFv2DefaultActionAllow WAFv2DefaultAction = "Allow"
+	WAFv2DefaultActionBlock WAFv2DefaultAction = "Block"
+)
+
+// +kubebuilder:validation:Enum=Allow;Block;Count;Captcha;Challenge
+// WAFv2RuleAction is the action applied to requests matching a rule.
+type WAFv2RuleAction string
+
+const (
+	WAFv2RuleActionAllow     WAFv2RuleAction = "Allow"
+	WAFv2RuleActionBlock     WAFv2RuleAction = "Block"
+	WAFv2RuleActionCount     WAFv2RuleAction = "Count"
+	WAFv2RuleActionCaptcha   WAFv2RuleAction = "Captcha"
+	WAFv2RuleActionChallenge WAFv2RuleAction = "Challenge"
+)
+
+// +kubebuilder:validation:Enum=None;Count
+// WAFv2OverrideAction overrides the actions of a managed rule group.
+type WAFv2OverrideAction string
+
+const (
+	// WAFv2OverrideActionNone applies the actions of the rules in the rule group.
+	WAFv2OverrideActionNone WAFv2OverrideAction = "None"
+	// WAFv2OverrideActionCount only counts requests matching the rule group.
+	WAFv2OverrideActionCount WAFv2OverrideAction = "Count"
+)
+
+// +kubebuilder:validation:Enum=IP;FORWARDED_IP
+// WAFv2RateBasedAggregateKeyType is how requests are aggregated by a rate-based rule.
+type WAFv2RateBasedAggregateKeyType string
+
+const (
+	WAFv2RateBasedAggregateKeyTypeIP          WAFv2RateBasedAggregateKeyType = "IP"
+	WAFv2RateBasedAggregateKeyTypeForwardedIP WAFv2RateBasedAggregateKeyType = "FORWARDED_IP"
+)
+
+// +kubebuilder:validation:Enum=IPV4;IPV6
+// WAFv2IPAddressVersion is the IP address version of an IP set.
+type WAFv2IPAddressVersion string
+
+const (
+	WAFv2IPAddressVersionIPV4 WAFv2IPAddressVersion = "IPV4"
+	WAFv2IPAddressVersionIPV6 WAFv2IPAddressVersion = "IPV6"
+)
+
+// WAFv2RuleActionOverride overrides the action of a single rule in a managed rule group.
+type WAFv2RuleActionOverride struct {
+	// Name is the name of the rule in the rule group.
+	Name string `json:"name"`
+
+	// Action is the action to use instead of the action of the rule.
+	Action WAFv2RuleAction `json:"action"`
+}
+
+// WAFv2ManagedRuleGroup references a managed rule group, e.g. AWSManagedRulesCommonRuleSet.
+type WAFv2ManagedRuleGroup struct {
+	// VendorName is the name of the rule group vendor, e.g. AWS.
+	VendorName string `json:"vendorName"`
+
+	// Name is the name of the managed rule group.
+	Name string `json:"name"`
+
+	// Version is the version of the managed rule group, the default version is used if absent.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// RuleActionOverrides overrides the actions of individual rules in the rule group.
+	// +optional
+	RuleActionOverrides []WAFv2RuleActionOverride `json:"ruleActionOverrides,omitempty"`
+
+	// OverrideAction overrides the actions of all rules in the rule group, defaults to None.
+	// +optional
+	OverrideAction *WAFv2OverrideAction `json:"overrideAction,omitempty"`
+}
+
+// WAFv2RateBasedRule limits the rate of requests per aggregation key.
+type WAFv2RateBasedRule struct {
+	// Limit is the maximum number of requests per aggregation key during the evaluation window.
+	// +kubebuilder:validation:Minimum=10
+	Limit int64 `json:"limit"`
+
+	// EvaluationWindowSec is the evaluation window in seconds, defaults to 300.
+	// +kubebuilder:validation:Enum=60;120;300;600
+	// +optional
+	EvaluationWindowSec *int64 `json:"evaluationWindowSec,omitempty"`
+
+	// AggregateKeyType is how requests are aggregated, defaults to IP.
+	// +optional
+	AggregateKeyType *WAFv2RateBasedAggregateKeyType `json:"aggregateKeyType,omitempty"`
+
+	// ForwardedIPHeaderName is the header carrying the client IP when aggregateKeyType is FORWARDED_IP, defaults to X-Forwarded-For.
+	// +optional
+	ForwardedIPHeaderName *string `json:"forwardedIPHeaderName,omitempty"`
+
+	// ScopeDownIPSet restricts the rule to requests from the IP set with this name in spec.ipSets.
+	// +optional
+	ScopeDownIPSet *string `json:"scopeDownIPSet,omitempty"`
+}
+
+// WAFv2IPSetReference matches requests from an IP set.
+type WAFv2IPSetReference struct {
+	// Name is the name of the IP set in spec.ipSets.
+	Name string `json:"name"`
+}
+
+// WAFv2Rule defines a rule of the web ACL.
+// Exactly one of managedRuleGroup, rateBased and ipSetReference must be specified.
+// +kubebuilder:validation:XValidation:rule="[has(self.managedRuleGroup), has(self.rateBased), has(self.ipSetReference)].filter(x, x).size() == 1",message="exactly one of managedRuleGroup, rateBased and ipSetReference must be specified"
+type WAFv2Rule struct {
+	// Name is the name of the rule, it's also used as CloudWatch metric name.
+	// +kubebuilder:validation:Pattern="^[\\w-]{1,128}$"
+	Name string `json:"name"`
+
+	// Priority is the order in which rules are evaluated, lower priorities first. Priorities must be unique.
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority"`
+
+	// Action is the action of rateBased and ipSetReference rules, defaults to Block.
+	// Managed rule groups use overrideAction and ruleActionOverrides instead.
+	// +optional
+	Action *WAFv2RuleAction `json:"action,omitempty"`
+
+	// ManagedRuleGroup evaluates a managed rule group.
+	// +optional
+	ManagedRuleGroup *WAFv2ManagedRuleGroup `json:"managedRuleGroup,omitempty"`
+
+	// RateBased limits the rate of requests.
+	// +optional
+	RateBased *WAFv2RateBasedRule `json:"rateBased,omitempty"`
+
+	// IPSetReference matches requests from an IP set.
+	// +optional
+	IPSetReference *WAFv2IPSetReference `json:"ipSetReference,omitempty"`
+}
+
+// WAFv2IPSet defines an IP set managed along with the web ACL.
+type WAFv2IPSet struct {
+	// Name is the name of the IP set, referenced by rules.
+	// +kubebuilder:validation:Pattern="^[\\w-]{1,64}$"
+	Name string `json:"name"`
+
+	// IPAddressVersion is the IP address version of the addresses, defaults to IPV4.
+	// +optional
+	IPAddressVersion *WAFv2IPAddressVersion `json:"ipAddressVersion,omitempty"`
+
+	// Addresses are the CIDRs of the IP set.
+	Addresses []string `json:"addresses"`
+}
+
+// WAFv2WebACLSpec defines the desired state of WAFv2WebACL
+type WAFv2WebACLSpec struct {
+	// WebACLName is the name of the web ACL in AWS.
+	// * if absent, the name is derived from the cluster name and the name of the WAFv2WebACL.
+	// +kubebuilder:validation:Pattern="^[\\w-]{1,128}$"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="webACLName is immutable"
+	// +optional
+	WebACLName *string `json:"webACLName,omitempty"`
+
+	// Description is the description of the web ACL.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// DefaultAction is the action applied to requests that don't match any rule.
+	// +kubebuilder:default=Allow
+	// +optional
+	DefaultAction WAFv2DefaultAction `json:"defaultAction,omitempty"`
+
+	// Rules are the rules of the web ACL.
+	// +optional
+	Rules []WAFv2Rule `json:"rules,omitempty"`
+
+	// IPSets are the IP sets managed along with the web ACL, referenced by rules by name.
+	// +optional
+	IPSets []WAFv2IPSet `json:"ipSets,omitempty"`
+
+	// CloudWatchMetricsEnabled enables CloudWatch metrics for the web ACL and its rules, defaults to true.
+	// +optional
+	CloudWatchMetricsEnabled *bool `json:"cloudWatchMetricsEnabled,omitempty"`
+
+	// SampledRequestsEnabled enables sampling of requests matching the rules, defaults to true.
+	// +optional
+	SampledRequestsEnabled *bool `json:"sampledRequestsEnabled,omitempty"`
+
+	// Tags are additional tags applied to the web ACL and its IP sets.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// WAFv2IPSetStatus is the observed state of an IP set of the web ACL.
+type WAFv2IPSetStatus struct {
+	// Name is the name of the IP set in spec.ipSets.
+	Name string `json:"name"`
+
+	// ID is the ID of the IP set in AWS.
+	ID string `json:"id"`
+
+	// ARN is the ARN of the IP set in AWS.
+	ARN string `json:"arn"`
+}
+
+// WAFv2WebACLStatus defines the observed state of WAFv2WebACL
+type WAFv2WebACLStatus struct {
+	// ObservedGeneration is the generation of the spec applied to the web ACL.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// WebACLARN is the ARN of the web ACL, used by load balancers referencing the WAFv2WebACL.
+	// +optional
+	WebACLARN *string `json:"webACLARN,omitempty"`
+
+	// WebACLID is the ID of the web ACL.
+	// +optional
+	WebACLID *string `json:"webACLID,omitempty"`
+
+	// Capacity is the web ACL capacity units (WCUs) used by the rules.
+	// +optional
+	Capacity *int64 `json:"capacity,omitempty"`
+
+	// IPSets are the IP sets of the web ACL.
+	// +optional
+	IPSets []WAFv2IPSetStatus `json:"ipSets,omitempty"`
+
+	// AssociatedLoadBalancers are the ARNs of the load balancers associated with the web ACL.
+	// +optional
+	AssociatedLoadBalancers []string `json:"associatedLoadBalancers,omitempty"`
+
+	// Conditions describe the state of the web ACL.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// WAFv2WebACLConditionReady indicates whether the web ACL is in sync with the spec.
+	WAFv2WebACLConditionReady = "Ready"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="WEB-ACL-ARN",type="string",JSONPath=".status.webACLARN",description="The ARN of the web ACL"
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".status.capacity",description="The web ACL capacity units used by the rules"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// WAFv2WebACL is the Schema for the WAFv2WebACL API
+type WAFv2WebACL struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WAFv2WebACLSpec   `json:"spec,omitempty"`
+	Status WAFv2WebACLStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WAFv2WebACLList contains a list of WAFv2WebACL
+type WAFv2WebACLList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WAFv2WebACL `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WAFv2WebACL{}, &WAFv2WebACLList{})
+}
@@ -34,6 +34,7 @@ func (in *ALBTargetControlConfig) DeepCopyInto(out *ALBTargetControlConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ALBTargetControlConfig.
@@ -119,6 +120,11 @@ func (in *ALBTargetControlConfigSpec) DeepCopyInto(out *ALBTargetControlConfigSp
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RolloutOnUpdate != nil {
+		in, out := &in.RolloutOnUpdate, &out.RolloutOnUpdate
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ALBTargetControlConfigSpec.
@@ -131,6 +137,36 @@ func (in *ALBTargetControlConfigSpec) DeepCopy() *ALBTargetControlConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ALBTargetControlConfigStatus) DeepCopyInto(out *ALBTargetControlConfigStatus) {
+	*out = *in
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OutdatedPodNames != nil {
+		in, out := &in.OutdatedPodNames, &out.OutdatedPodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedPodNames != nil {
+		in, out := &in.FailedPodNames, &out.FailedPodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ALBTargetControlConfigStatus.
+func (in *ALBTargetControlConfigStatus) DeepCopy() *ALBTargetControlConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ALBTargetControlConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attribute) DeepCopyInto(out *Attribute) {
 	*out = *in
@@ -146,6 +182,76 @@ func (in *Attribute) DeepCopy() *Attribute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationCalendarEntry) DeepCopyInto(out *CapacityReservationCalendarEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationCalendarEntry.
+func (in *CapacityReservationCalendarEntry) DeepCopy() *CapacityReservationCalendarEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationCalendarEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationSchedule) DeepCopyInto(out *CapacityReservationSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationSchedule.
+func (in *CapacityReservationSchedule) DeepCopy() *CapacityReservationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationStatus) DeepCopyInto(out *CapacityReservationStatus) {
+	*out = *in
+	if in.NextCapacityUnits != nil {
+		in, out := &in.NextCapacityUnits, &out.NextCapacityUnits
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationStatus.
+func (in *CapacityReservationStatus) DeepCopy() *CapacityReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOrderRange) DeepCopyInto(out *GroupOrderRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOrderRange.
+func (in *GroupOrderRange) DeepCopy() *GroupOrderRange {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOrderRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMConfiguration) DeepCopyInto(out *IPAMConfiguration) {
 	*out = *in
@@ -187,6 +293,7 @@ func (in *IngressClassParams) DeepCopyInto(out *IngressClassParams) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParams.
@@ -297,7 +404,7 @@ func (in *IngressClassParamsSpec) DeepCopyInto(out *IngressClassParamsSpec) {
 	if in.MinimumLoadBalancerCapacity != nil {
 		in, out := &in.MinimumLoadBalancerCapacity, &out.MinimumLoadBalancerCapacity
 		*out = new(MinimumLoadBalancerCapacity)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.IPAMConfiguration != nil {
 		in, out := &in.IPAMConfiguration, &out.IPAMConfiguration
@@ -314,6 +421,16 @@ func (in *IngressClassParamsSpec) DeepCopyInto(out *IngressClassParamsSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ShieldAdvanced != nil {
+		in, out := &in.ShieldAdvanced, &out.ShieldAdvanced
+		*out = new(ShieldAdvancedConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupOwnership != nil {
+		in, out := &in.GroupOwnership, &out.GroupOwnership
+		*out = new(IngressGroupOwnership)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParamsSpec.
@@ -326,6 +443,26 @@ func (in *IngressClassParamsSpec) DeepCopy() *IngressClassParamsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassParamsStatus) DeepCopyInto(out *IngressClassParamsStatus) {
+	*out = *in
+	if in.CapacityReservation != nil {
+		in, out := &in.CapacityReservation, &out.CapacityReservation
+		*out = new(CapacityReservationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParamsStatus.
+func (in *IngressClassParamsStatus) DeepCopy() *IngressClassParamsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassParamsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressGroup) DeepCopyInto(out *IngressGroup) {
 	*out = *in
@@ -341,6 +478,78 @@ func (in *IngressGroup) DeepCopy() *IngressGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGroupNamespaceRule) DeepCopyInto(out *IngressGroupNamespaceRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedPathPrefixes != nil {
+		in, out := &in.AllowedPathPrefixes, &out.AllowedPathPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxRules != nil {
+		in, out := &in.MaxRules, &out.MaxRules
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedGroupOrder != nil {
+		in, out := &in.AllowedGroupOrder, &out.AllowedGroupOrder
+		*out = new(GroupOrderRange)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGroupNamespaceRule.
+func (in *IngressGroupNamespaceRule) DeepCopy() *IngressGroupNamespaceRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGroupNamespaceRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGroupOwnership) DeepCopyInto(out *IngressGroupOwnership) {
+	*out = *in
+	if in.NamespaceRules != nil {
+		in, out := &in.NamespaceRules, &out.NamespaceRules
+		*out = make([]IngressGroupNamespaceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostOwnership != nil {
+		in, out := &in.HostOwnership, &out.HostOwnership
+		*out = new(HostOwnershipPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGroupOwnership.
+func (in *IngressGroupOwnership) DeepCopy() *IngressGroupOwnership {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGroupOwnership)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Listener) DeepCopyInto(out *Listener) {
 	*out = *in
@@ -364,6 +573,16 @@ func (in *Listener) DeepCopy() *Listener {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MinimumLoadBalancerCapacity) DeepCopyInto(out *MinimumLoadBalancerCapacity) {
 	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]CapacityReservationSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Calendar != nil {
+		in, out := &in.Calendar, &out.Calendar
+		*out = make([]CapacityReservationCalendarEntry, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinimumLoadBalancerCapacity.
@@ -456,108 +675,159 @@ func (in *NetworkingPort) DeepCopy() *NetworkingPort {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+func (in *PrometheusAnalysis) DeepCopyInto(out *PrometheusAnalysis) {
 	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
-func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAnalysis.
+func (in *PrometheusAnalysis) DeepCopy() *PrometheusAnalysis {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroup)
+	out := new(PrometheusAnalysis)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+func (in *QUICServerIDClaim) DeepCopyInto(out *QUICServerIDClaim) {
 	*out = *in
-	out.Port = in.Port
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
-func (in *ServiceReference) DeepCopy() *ServiceReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QUICServerIDClaim.
+func (in *QUICServerIDClaim) DeepCopy() *QUICServerIDClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceReference)
+	out := new(QUICServerIDClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QUICServerIDClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetSelector) DeepCopyInto(out *SubnetSelector) {
+func (in *QUICServerIDClaimList) DeepCopyInto(out *QUICServerIDClaimList) {
 	*out = *in
-	if in.IDs != nil {
-		in, out := &in.IDs, &out.IDs
-		*out = make([]SubnetID, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QUICServerIDClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSelector.
-func (in *SubnetSelector) DeepCopy() *SubnetSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QUICServerIDClaimList.
+func (in *QUICServerIDClaimList) DeepCopy() *QUICServerIDClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetSelector)
+	out := new(QUICServerIDClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QUICServerIDClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Tag) DeepCopyInto(out *Tag) {
+func (in *QUICServerIDClaimSpec) DeepCopyInto(out *QUICServerIDClaimSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tag.
-func (in *Tag) DeepCopy() *Tag {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QUICServerIDClaimSpec.
+func (in *QUICServerIDClaimSpec) DeepCopy() *QUICServerIDClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Tag)
+	out := new(QUICServerIDClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TargetGroupBinding) DeepCopyInto(out *TargetGroupBinding) {
+func (in *QUICServerIDClaimStatus) DeepCopyInto(out *QUICServerIDClaimStatus) {
+	*out = *in
+	if in.CollidingPodNames != nil {
+		in, out := &in.CollidingPodNames, &out.CollidingPodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QUICServerIDClaimStatus.
+func (in *QUICServerIDClaimStatus) DeepCopy() *QUICServerIDClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QUICServerIDClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceClassParams) DeepCopyInto(out *ServiceClassParams) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBinding.
-func (in *TargetGroupBinding) DeepCopy() *TargetGroupBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceClassParams.
+func (in *ServiceClassParams) DeepCopy() *ServiceClassParams {
 	if in == nil {
 		return nil
 	}
-	out := new(TargetGroupBinding)
+	out := new(ServiceClassParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
+func (in *ServiceClassParams) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -565,31 +835,31 @@ func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TargetGroupBindingList) DeepCopyInto(out *TargetGroupBindingList) {
+func (in *ServiceClassParamsList) DeepCopyInto(out *ServiceClassParamsList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TargetGroupBinding, len(*in))
+		*out = make([]ServiceClassParams, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingList.
-func (in *TargetGroupBindingList) DeepCopy() *TargetGroupBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceClassParamsList.
+func (in *ServiceClassParamsList) DeepCopy() *ServiceClassParamsList {
 	if in == nil {
 		return nil
 	}
-	out := new(TargetGroupBindingList)
+	out := new(ServiceClassParamsList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TargetGroupBindingList) DeepCopyObject() runtime.Object {
+func (in *ServiceClassParamsList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -597,19 +867,277 @@ func (in *TargetGroupBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TargetGroupBindingNetworking) DeepCopyInto(out *TargetGroupBindingNetworking) {
+func (in *ServiceClassParamsSpec) DeepCopyInto(out *ServiceClassParamsSpec) {
 	*out = *in
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = make([]NetworkingIngressRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MergingMode != nil {
+		in, out := &in.MergingMode, &out.MergingMode
+		*out = new(ServiceClassParamsMergeMode)
+		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingNetworking.
-func (in *TargetGroupBindingNetworking) DeepCopy() *TargetGroupBindingNetworking {
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(LoadBalancerScheme)
+		**out = **in
+	}
+	if in.IPAddressType != nil {
+		in, out := &in.IPAddressType, &out.IPAddressType
+		*out = new(IPAddressType)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = new(SubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSLPolicy != nil {
+		in, out := &in.SSLPolicy, &out.SSLPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.InboundCIDRs != nil {
+		in, out := &in.InboundCIDRs, &out.InboundCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerAttributes != nil {
+		in, out := &in.LoadBalancerAttributes, &out.LoadBalancerAttributes
+		*out = make([]Attribute, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceClassParamsSpec.
+func (in *ServiceClassParamsSpec) DeepCopy() *ServiceClassParamsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceClassParamsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldAdvancedConfiguration) DeepCopyInto(out *ShieldAdvancedConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProtectionGroups != nil {
+		in, out := &in.ProtectionGroups, &out.ProtectionGroups
+		*out = make([]ShieldProtectionGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthCheckARN != nil {
+		in, out := &in.HealthCheckARN, &out.HealthCheckARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApplicationLayerAutomaticResponse != nil {
+		in, out := &in.ApplicationLayerAutomaticResponse, &out.ApplicationLayerAutomaticResponse
+		*out = new(ShieldApplicationLayerAutomaticResponse)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldAdvancedConfiguration.
+func (in *ShieldAdvancedConfiguration) DeepCopy() *ShieldAdvancedConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ShieldAdvancedConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldApplicationLayerAutomaticResponse) DeepCopyInto(out *ShieldApplicationLayerAutomaticResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldApplicationLayerAutomaticResponse.
+func (in *ShieldApplicationLayerAutomaticResponse) DeepCopy() *ShieldApplicationLayerAutomaticResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ShieldApplicationLayerAutomaticResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldProtectionGroup) DeepCopyInto(out *ShieldProtectionGroup) {
+	*out = *in
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(ShieldProtectionGroupAggregation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldProtectionGroup.
+func (in *ShieldProtectionGroup) DeepCopy() *ShieldProtectionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ShieldProtectionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSelector) DeepCopyInto(out *SubnetSelector) {
+	*out = *in
+	if in.IDs != nil {
+		in, out := &in.IDs, &out.IDs
+		*out = make([]SubnetID, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSelector.
+func (in *SubnetSelector) DeepCopy() *SubnetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tag) DeepCopyInto(out *Tag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tag.
+func (in *Tag) DeepCopy() *Tag {
+	if in == nil {
+		return nil
+	}
+	out := new(Tag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBinding) DeepCopyInto(out *TargetGroupBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBinding.
+func (in *TargetGroupBinding) DeepCopy() *TargetGroupBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBindingList) DeepCopyInto(out *TargetGroupBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TargetGroupBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingList.
+func (in *TargetGroupBindingList) DeepCopy() *TargetGroupBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TargetGroupBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBindingNetworking) DeepCopyInto(out *TargetGroupBindingNetworking) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkingIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingNetworking.
+func (in *TargetGroupBindingNetworking) DeepCopy() *TargetGroupBindingNetworking {
 	if in == nil {
 		return nil
 	}
@@ -621,6 +1149,11 @@ func (in *TargetGroupBindingNetworking) DeepCopy() *TargetGroupBindingNetworking
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 	*out = *in
+	if in.WeightedRegistration != nil {
+		in, out := &in.WeightedRegistration, &out.WeightedRegistration
+		*out = new(WeightedRegistration)
+		**out = **in
+	}
 	if in.TargetType != nil {
 		in, out := &in.TargetType, &out.TargetType
 		*out = new(TargetType)
@@ -674,6 +1207,16 @@ func (in *TargetGroupBindingStatus) DeepCopyInto(out *TargetGroupBindingStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TargetHealth != nil {
+		in, out := &in.TargetHealth, &out.TargetHealth
+		*out = new(TargetHealthSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointSecurityGroups != nil {
+		in, out := &in.EndpointSecurityGroups, &out.EndpointSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingStatus.
@@ -685,3 +1228,625 @@ func (in *TargetGroupBindingStatus) DeepCopy() *TargetGroupBindingStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealthAnalysis) DeepCopyInto(out *TargetHealthAnalysis) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealthAnalysis.
+func (in *TargetHealthAnalysis) DeepCopy() *TargetHealthAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealthAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealthReason) DeepCopyInto(out *TargetHealthReason) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealthReason.
+func (in *TargetHealthReason) DeepCopy() *TargetHealthReason {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealthReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealthSummary) DeepCopyInto(out *TargetHealthSummary) {
+	*out = *in
+	if in.UnhealthyReasons != nil {
+		in, out := &in.UnhealthyReasons, &out.UnhealthyReasons
+		*out = make([]TargetHealthReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealthSummary.
+func (in *TargetHealthSummary) DeepCopy() *TargetHealthSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealthSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRollout) DeepCopyInto(out *TrafficRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRollout.
+func (in *TrafficRollout) DeepCopy() *TrafficRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutAnalysis) DeepCopyInto(out *TrafficRolloutAnalysis) {
+	*out = *in
+	if in.TargetHealth != nil {
+		in, out := &in.TargetHealth, &out.TargetHealth
+		*out = new(TargetHealthAnalysis)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutAnalysis.
+func (in *TrafficRolloutAnalysis) DeepCopy() *TrafficRolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutBackend) DeepCopyInto(out *TrafficRolloutBackend) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutBackend.
+func (in *TrafficRolloutBackend) DeepCopy() *TrafficRolloutBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutHeaderRouting) DeepCopyInto(out *TrafficRolloutHeaderRouting) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutHeaderRouting.
+func (in *TrafficRolloutHeaderRouting) DeepCopy() *TrafficRolloutHeaderRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutHeaderRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutList) DeepCopyInto(out *TrafficRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrafficRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutList.
+func (in *TrafficRolloutList) DeepCopy() *TrafficRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutSpec) DeepCopyInto(out *TrafficRolloutSpec) {
+	*out = *in
+	in.TargetRef.DeepCopyInto(&out.TargetRef)
+	out.StableBackend = in.StableBackend
+	out.CanaryBackend = in.CanaryBackend
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TrafficRolloutStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HeaderRouting != nil {
+		in, out := &in.HeaderRouting, &out.HeaderRouting
+		*out = new(TrafficRolloutHeaderRouting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(TrafficRolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutSpec.
+func (in *TrafficRolloutSpec) DeepCopy() *TrafficRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutStatus) DeepCopyInto(out *TrafficRolloutStatus) {
+	*out = *in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutStatus.
+func (in *TrafficRolloutStatus) DeepCopy() *TrafficRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutStep) DeepCopyInto(out *TrafficRolloutStep) {
+	*out = *in
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutStep.
+func (in *TrafficRolloutStep) DeepCopy() *TrafficRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRolloutTargetReference) DeepCopyInto(out *TrafficRolloutTargetReference) {
+	*out = *in
+	if in.SectionName != nil {
+		in, out := &in.SectionName, &out.SectionName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRolloutTargetReference.
+func (in *TrafficRolloutTargetReference) DeepCopy() *TrafficRolloutTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRolloutTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2IPSet) DeepCopyInto(out *WAFv2IPSet) {
+	*out = *in
+	if in.IPAddressVersion != nil {
+		in, out := &in.IPAddressVersion, &out.IPAddressVersion
+		*out = new(WAFv2IPAddressVersion)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2IPSet.
+func (in *WAFv2IPSet) DeepCopy() *WAFv2IPSet {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2IPSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2IPSetReference) DeepCopyInto(out *WAFv2IPSetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2IPSetReference.
+func (in *WAFv2IPSetReference) DeepCopy() *WAFv2IPSetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2IPSetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2IPSetStatus) DeepCopyInto(out *WAFv2IPSetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2IPSetStatus.
+func (in *WAFv2IPSetStatus) DeepCopy() *WAFv2IPSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2IPSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2ManagedRuleGroup) DeepCopyInto(out *WAFv2ManagedRuleGroup) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.RuleActionOverrides != nil {
+		in, out := &in.RuleActionOverrides, &out.RuleActionOverrides
+		*out = make([]WAFv2RuleActionOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverrideAction != nil {
+		in, out := &in.OverrideAction, &out.OverrideAction
+		*out = new(WAFv2OverrideAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2ManagedRuleGroup.
+func (in *WAFv2ManagedRuleGroup) DeepCopy() *WAFv2ManagedRuleGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2ManagedRuleGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2RateBasedRule) DeepCopyInto(out *WAFv2RateBasedRule) {
+	*out = *in
+	if in.EvaluationWindowSec != nil {
+		in, out := &in.EvaluationWindowSec, &out.EvaluationWindowSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AggregateKeyType != nil {
+		in, out := &in.AggregateKeyType, &out.AggregateKeyType
+		*out = new(WAFv2RateBasedAggregateKeyType)
+		**out = **in
+	}
+	if in.ForwardedIPHeaderName != nil {
+		in, out := &in.ForwardedIPHeaderName, &out.ForwardedIPHeaderName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScopeDownIPSet != nil {
+		in, out := &in.ScopeDownIPSet, &out.ScopeDownIPSet
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2RateBasedRule.
+func (in *WAFv2RateBasedRule) DeepCopy() *WAFv2RateBasedRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2RateBasedRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2Rule) DeepCopyInto(out *WAFv2Rule) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(WAFv2RuleAction)
+		**out = **in
+	}
+	if in.ManagedRuleGroup != nil {
+		in, out := &in.ManagedRuleGroup, &out.ManagedRuleGroup
+		*out = new(WAFv2ManagedRuleGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateBased != nil {
+		in, out := &in.RateBased, &out.RateBased
+		*out = new(WAFv2RateBasedRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPSetReference != nil {
+		in, out := &in.IPSetReference, &out.IPSetReference
+		*out = new(WAFv2IPSetReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2Rule.
+func (in *WAFv2Rule) DeepCopy() *WAFv2Rule {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2Rule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2RuleActionOverride) DeepCopyInto(out *WAFv2RuleActionOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2RuleActionOverride.
+func (in *WAFv2RuleActionOverride) DeepCopy() *WAFv2RuleActionOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2RuleActionOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2WebACL) DeepCopyInto(out *WAFv2WebACL) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2WebACL.
+func (in *WAFv2WebACL) DeepCopy() *WAFv2WebACL {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2WebACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WAFv2WebACL) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2WebACLList) DeepCopyInto(out *WAFv2WebACLList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WAFv2WebACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2WebACLList.
+func (in *WAFv2WebACLList) DeepCopy() *WAFv2WebACLList {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2WebACLList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WAFv2WebACLList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2WebACLSpec) DeepCopyInto(out *WAFv2WebACLSpec) {
+	*out = *in
+	if in.WebACLName != nil {
+		in, out := &in.WebACLName, &out.WebACLName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]WAFv2Rule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPSets != nil {
+		in, out := &in.IPSets, &out.IPSets
+		*out = make([]WAFv2IPSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudWatchMetricsEnabled != nil {
+		in, out := &in.CloudWatchMetricsEnabled, &out.CloudWatchMetricsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SampledRequestsEnabled != nil {
+		in, out := &in.SampledRequestsEnabled, &out.SampledRequestsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2WebACLSpec.
+func (in *WAFv2WebACLSpec) DeepCopy() *WAFv2WebACLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2WebACLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFv2WebACLStatus) DeepCopyInto(out *WAFv2WebACLStatus) {
+	*out = *in
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WebACLARN != nil {
+		in, out := &in.WebACLARN, &out.WebACLARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.WebACLID != nil {
+		in, out := &in.WebACLID, &out.WebACLID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IPSets != nil {
+		in, out := &in.IPSets, &out.IPSets
+		*out = make([]WAFv2IPSetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssociatedLoadBalancers != nil {
+		in, out := &in.AssociatedLoadBalancers, &out.AssociatedLoadBalancers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFv2WebACLStatus.
+func (in *WAFv2WebACLStatus) DeepCopy() *WAFv2WebACLStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFv2WebACLStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedRegistration) DeepCopyInto(out *WeightedRegistration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedRegistration.
+func (in *WeightedRegistration) DeepCopy() *WeightedRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
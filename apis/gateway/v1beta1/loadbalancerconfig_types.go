@@ -144,12 +144,68 @@ type MutualAuthenticationAttributes struct {
 type ShieldConfiguration struct {
 	// Enabled whether Shield Advanced should be configured with the Gateway
 	Enabled bool `json:"enabled,omitempty"`
+
+	// ProtectionGroups the Shield Advanced protection groups the load balancer belongs to.
+	// Missing protection groups are created with the ARBITRARY pattern.
+	// +optional
+	ProtectionGroups []ShieldProtectionGroup `json:"protectionGroups,omitempty"`
+
+	// HealthCheckARN the ARN of the Route 53 health check associated with the protection, for health-based detection.
+	// +optional
+	HealthCheckARN *string `json:"healthCheckARN,omitempty"`
+
+	// ApplicationLayerAutomaticResponse enables the automatic application-layer DDoS mitigation.
+	// A web ACL must be associated with the load balancer.
+	// +optional
+	ApplicationLayerAutomaticResponse *ShieldApplicationLayerAutomaticResponse `json:"applicationLayerAutomaticResponse,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=SUM;MEAN;MAX
+// ShieldProtectionGroupAggregation defines how the metrics of the protection group members are combined.
+type ShieldProtectionGroupAggregation string
+
+const (
+	ShieldProtectionGroupAggregationSum  ShieldProtectionGroupAggregation = "SUM"
+	ShieldProtectionGroupAggregationMean ShieldProtectionGroupAggregation = "MEAN"
+	ShieldProtectionGroupAggregationMax  ShieldProtectionGroupAggregation = "MAX"
+)
+
+// ShieldProtectionGroup defines a Shield Advanced protection group
+type ShieldProtectionGroup struct {
+	// ID the ID of the protection group
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9\\-]{1,36}$"
+	ID string `json:"id"`
+
+	// Aggregation defines how the metrics of the protection group members are combined, defaults to SUM.
+	// Only applied to protection groups created by the controller.
+	// +optional
+	Aggregation *ShieldProtectionGroupAggregation `json:"aggregation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Block;Count
+// ShieldApplicationLayerAutomaticResponseAction defines the action of the rules added by the automatic application-layer DDoS mitigation.
+type ShieldApplicationLayerAutomaticResponseAction string
+
+const (
+	ShieldApplicationLayerAutomaticResponseActionBlock ShieldApplicationLayerAutomaticResponseAction = "Block"
+	ShieldApplicationLayerAutomaticResponseActionCount ShieldApplicationLayerAutomaticResponseAction = "Count"
+)
+
+// ShieldApplicationLayerAutomaticResponse configuration parameters of the automatic application-layer DDoS mitigation
+type ShieldApplicationLayerAutomaticResponse struct {
+	// Action the action of the rules added to mitigate an attack
+	Action ShieldApplicationLayerAutomaticResponseAction `json:"action"`
 }
 
 // WAFv2Configuration configuration parameters used to configure WAFv2
 type WAFv2Configuration struct {
 	// ACL The WebACL to configure with the Gateway
-	ACL string `json:"webACL"`
+	// +optional
+	ACL string `json:"webACL,omitempty"`
+
+	// WebACLRef The name of the WAFv2WebACL whose web ACL is configured with the Gateway, takes precedence over webACL.
+	// +optional
+	WebACLRef string `json:"webACLRef,omitempty"`
 }
 
 // +kubebuilder:validation:Pattern="^(HTTP|HTTPS|TLS|TCP|UDP|TCP_UDP)?:(6553[0-5]|655[0-2]\\d|65[0-4]\\d{2}|6[0-4]\\d{3}|[1-5]\\d{4}|[1-9]\\d{0,3})?$"
@@ -295,6 +351,61 @@ type LoadBalancerConfigurationSpec struct {
 	// Service-level TGCs override these defaults on a per-field basis.
 	// +optional
 	DefaultTargetGroupConfiguration *DefaultTargetGroupConfigurationReference `json:"defaultTargetGroupConfiguration,omitempty"`
+
+	// policies restrict how the configuration attached to a Gateway can set individual fields, e.g. to lock the scheme
+	// or to require tags, while leaving the other fields to the Gateway.
+	// This field is only honored for the configuration attached to the GatewayClass.
+	// +optional
+	// +listType=map
+	// +listMapKey=field
+	Policies []LoadBalancerConfigurationPolicy `json:"policies,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=scheme;ipAddressType;enforceSecurityGroupInboundRulesOnPrivateLinkTraffic;customerOwnedIpv4Pool;ipv4IPAMPoolId;securityGroups;sourceRanges;wafV2;shieldConfiguration;sslPolicy;tags
+// LoadBalancerConfigurationPolicyField is the field of the LoadBalancerConfiguration a policy applies to.
+type LoadBalancerConfigurationPolicyField string
+
+const (
+	PolicyFieldScheme                                               LoadBalancerConfigurationPolicyField = "scheme"
+	PolicyFieldIpAddressType                                        LoadBalancerConfigurationPolicyField = "ipAddressType"
+	PolicyFieldEnforceSecurityGroupInboundRulesOnPrivateLinkTraffic LoadBalancerConfigurationPolicyField = "enforceSecurityGroupInboundRulesOnPrivateLinkTraffic"
+	PolicyFieldCustomerOwnedIpv4Pool                                LoadBalancerConfigurationPolicyField = "customerOwnedIpv4Pool"
+	PolicyFieldIPv4IPAMPoolId                                       LoadBalancerConfigurationPolicyField = "ipv4IPAMPoolId"
+	PolicyFieldSecurityGroups                                       LoadBalancerConfigurationPolicyField = "securityGroups"
+	PolicyFieldSourceRanges                                         LoadBalancerConfigurationPolicyField = "sourceRanges"
+	// PolicyFieldWAFv2 applies to the web ACL of the wafV2 configuration, i.e. the webACLRef if webACL is absent.
+	PolicyFieldWAFv2 LoadBalancerConfigurationPolicyField = "wafV2"
+	// PolicyFieldShieldAdvanced applies to whether Shield Advanced is enabled, as "true" or "false".
+	PolicyFieldShieldAdvanced LoadBalancerConfigurationPolicyField = "shieldConfiguration"
+	// PolicyFieldSSLPolicy applies to the sslPolicy of all HTTPS and TLS listener configurations.
+	PolicyFieldSSLPolicy LoadBalancerConfigurationPolicyField = "sslPolicy"
+	PolicyFieldTags      LoadBalancerConfigurationPolicyField = "tags"
+)
+
+// LoadBalancerConfigurationPolicy defines how the configuration attached to a Gateway can set a field.
+type LoadBalancerConfigurationPolicy struct {
+	// field is the field the policy applies to.
+	Field LoadBalancerConfigurationPolicyField `json:"field"`
+
+	// locked enforces the value of the GatewayClass configuration regardless of mergingMode. The Gateway configuration must not set
+	// a different value. For tags, the Gateway configuration can add tags, but not change the tags of the GatewayClass configuration.
+	// Not supported for sslPolicy.
+	// +optional
+	Locked bool `json:"locked,omitempty"`
+
+	// default is the value used when neither configuration sets the field.
+	// Not supported for securityGroups, sourceRanges and tags.
+	// +optional
+	Default *string `json:"default,omitempty"`
+
+	// allowedValues are the values the field can be set to. Each element of securityGroups and sourceRanges must be allowed.
+	// Not supported for tags.
+	// +optional
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// requiredKeys are the tags keys that must be set by either configuration. Only supported for tags.
+	// +optional
+	RequiredKeys []string `json:"requiredKeys,omitempty"`
 }
 
 // DefaultTargetGroupConfigurationReference is a reference to a TargetGroupConfiguration in the same namespace.
@@ -313,6 +424,9 @@ type LoadBalancerConfigurationStatus struct {
 	// The generation of the Gateway Configuration attached to the GatewayClass object.
 	// +optional
 	ObservedGatewayClassConfigurationGeneration *int64 `json:"observedGatewayClassConfigurationGeneration,omitempty"`
+	// CapacityReservation reports the scheduled capacity reservation, set when MinimumLoadBalancerCapacity has Schedules or a Calendar.
+	// +optional
+	CapacityReservation *CapacityReservationStatus `json:"capacityReservation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -339,8 +453,71 @@ type LoadBalancerConfigurationList struct {
 
 // MinimumLoadBalancerCapacity Information about a load balancer capacity reservation.
 type MinimumLoadBalancerCapacity struct {
-	// The Capacity Units Value.
+	// The Capacity Units Value, applies outside of the scheduled windows.
 	CapacityUnits int32 `json:"capacityUnits"`
+
+	// Schedules define recurring windows during which a different capacity reservation applies.
+	// When windows overlap, the largest capacity reservation applies.
+	// +optional
+	Schedules []CapacityReservationSchedule `json:"schedules,omitempty"`
+
+	// Calendar defines dated windows during which a different capacity reservation applies, such as known events.
+	// +optional
+	Calendar []CapacityReservationCalendarEntry `json:"calendar,omitempty"`
+
+	// TimeZone is the IANA time zone in which the Schedules and the Calendar are evaluated, defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CapacityReservationSchedule defines a recurring capacity reservation window.
+type CapacityReservationSchedule struct {
+	// Name of the window.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Start is the standard cron expression (minute hour day-of-month month day-of-week) at which the window opens.
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open, such as 10h.
+	Duration metav1.Duration `json:"duration"`
+
+	// The Capacity Units Value while the window is open.
+	// +kubebuilder:validation:Minimum=0
+	CapacityUnits int32 `json:"capacityUnits"`
+}
+
+// CapacityReservationCalendarEntry defines a dated capacity reservation window.
+type CapacityReservationCalendarEntry struct {
+	// Name of the window.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Start is the local date and time at which the window opens, in the YYYY-MM-DDTHH:MM format.
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}$"
+	Start string `json:"start"`
+
+	// End is the local date and time at which the window closes, in the YYYY-MM-DDTHH:MM format.
+	// +kubebuilder:validation:Pattern="^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}$"
+	End string `json:"end"`
+
+	// The Capacity Units Value while the window is open.
+	// +kubebuilder:validation:Minimum=0
+	CapacityUnits int32 `json:"capacityUnits"`
+}
+
+// CapacityReservationStatus reports the scheduled capacity reservation.
+type CapacityReservationStatus struct {
+	// CurrentCapacityUnits is the Capacity Units Value in effect.
+	CurrentCapacityUnits int32 `json:"currentCapacityUnits"`
+
+	// NextCapacityUnits is the Capacity Units Value after the next scheduled transition.
+	// +optional
+	NextCapacityUnits *int32 `json:"nextCapacityUnits,omitempty"`
+
+	// NextTransitionTime is the time of the next scheduled transition.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
 }
 
 func init() {
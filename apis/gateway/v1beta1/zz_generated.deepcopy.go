@@ -172,6 +172,61 @@ func (in *AuthenticateOidcActionConfig) DeepCopy() *AuthenticateOidcActionConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationCalendarEntry) DeepCopyInto(out *CapacityReservationCalendarEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationCalendarEntry.
+func (in *CapacityReservationCalendarEntry) DeepCopy() *CapacityReservationCalendarEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationCalendarEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationSchedule) DeepCopyInto(out *CapacityReservationSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationSchedule.
+func (in *CapacityReservationSchedule) DeepCopy() *CapacityReservationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityReservationStatus) DeepCopyInto(out *CapacityReservationStatus) {
+	*out = *in
+	if in.NextCapacityUnits != nil {
+		in, out := &in.NextCapacityUnits, &out.NextCapacityUnits
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityReservationStatus.
+func (in *CapacityReservationStatus) DeepCopy() *CapacityReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultTargetGroupConfigurationReference) DeepCopyInto(out *DefaultTargetGroupConfigurationReference) {
 	*out = *in
@@ -662,6 +717,36 @@ func (in *LoadBalancerConfigurationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerConfigurationPolicy) DeepCopyInto(out *LoadBalancerConfigurationPolicy) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredKeys != nil {
+		in, out := &in.RequiredKeys, &out.RequiredKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerConfigurationPolicy.
+func (in *LoadBalancerConfigurationPolicy) DeepCopy() *LoadBalancerConfigurationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerConfigurationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerConfigurationSpec) DeepCopyInto(out *LoadBalancerConfigurationSpec) {
 	*out = *in
@@ -803,7 +888,7 @@ func (in *LoadBalancerConfigurationSpec) DeepCopyInto(out *LoadBalancerConfigura
 	if in.MinimumLoadBalancerCapacity != nil {
 		in, out := &in.MinimumLoadBalancerCapacity, &out.MinimumLoadBalancerCapacity
 		*out = new(MinimumLoadBalancerCapacity)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.WAFv2 != nil {
 		in, out := &in.WAFv2, &out.WAFv2
@@ -813,13 +898,20 @@ func (in *LoadBalancerConfigurationSpec) DeepCopyInto(out *LoadBalancerConfigura
 	if in.ShieldAdvanced != nil {
 		in, out := &in.ShieldAdvanced, &out.ShieldAdvanced
 		*out = new(ShieldConfiguration)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.DefaultTargetGroupConfiguration != nil {
 		in, out := &in.DefaultTargetGroupConfiguration, &out.DefaultTargetGroupConfiguration
 		*out = new(DefaultTargetGroupConfigurationReference)
 		**out = **in
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]LoadBalancerConfigurationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerConfigurationSpec.
@@ -845,6 +937,11 @@ func (in *LoadBalancerConfigurationStatus) DeepCopyInto(out *LoadBalancerConfigu
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CapacityReservation != nil {
+		in, out := &in.CapacityReservation, &out.CapacityReservation
+		*out = new(CapacityReservationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerConfigurationStatus.
@@ -860,6 +957,16 @@ func (in *LoadBalancerConfigurationStatus) DeepCopy() *LoadBalancerConfiguration
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MinimumLoadBalancerCapacity) DeepCopyInto(out *MinimumLoadBalancerCapacity) {
 	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]CapacityReservationSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Calendar != nil {
+		in, out := &in.Calendar, &out.Calendar
+		*out = make([]CapacityReservationCalendarEntry, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinimumLoadBalancerCapacity.
@@ -994,9 +1101,41 @@ func (in *Secret) DeepCopy() *Secret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldApplicationLayerAutomaticResponse) DeepCopyInto(out *ShieldApplicationLayerAutomaticResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldApplicationLayerAutomaticResponse.
+func (in *ShieldApplicationLayerAutomaticResponse) DeepCopy() *ShieldApplicationLayerAutomaticResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ShieldApplicationLayerAutomaticResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShieldConfiguration) DeepCopyInto(out *ShieldConfiguration) {
 	*out = *in
+	if in.ProtectionGroups != nil {
+		in, out := &in.ProtectionGroups, &out.ProtectionGroups
+		*out = make([]ShieldProtectionGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthCheckARN != nil {
+		in, out := &in.HealthCheckARN, &out.HealthCheckARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApplicationLayerAutomaticResponse != nil {
+		in, out := &in.ApplicationLayerAutomaticResponse, &out.ApplicationLayerAutomaticResponse
+		*out = new(ShieldApplicationLayerAutomaticResponse)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldConfiguration.
@@ -1009,6 +1148,26 @@ func (in *ShieldConfiguration) DeepCopy() *ShieldConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldProtectionGroup) DeepCopyInto(out *ShieldProtectionGroup) {
+	*out = *in
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(ShieldProtectionGroupAggregation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldProtectionGroup.
+func (in *ShieldProtectionGroup) DeepCopy() *ShieldProtectionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ShieldProtectionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceIPConditionConfig) DeepCopyInto(out *SourceIPConditionConfig) {
 	*out = *in
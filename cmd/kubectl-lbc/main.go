@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inspect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwalpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwbeta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const defaultNamespace = "default"
+
+func main() {
+	rootCmd := newRootCommand()
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubectl-lbc",
+		Short: "kubectl plugin for the AWS Load Balancer Controller",
+		// invoked as "kubectl lbc" when installed on PATH as kubectl-lbc.
+		Annotations: map[string]string{
+			cobra.CommandDisplayNameAnnotation: "kubectl lbc",
+		},
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newInspectCommand())
+	return cmd
+}
+
+// InspectOptions holds the flags for the inspect command.
+type InspectOptions struct {
+	Namespace    string
+	OutputFormat string
+	// ControllerConfig is the configuration of the controller, so that the desired stack is built the same way.
+	ControllerConfig config.ControllerConfig
+}
+
+func newInspectCommand() *cobra.Command {
+	opts := &InspectOptions{
+		ControllerConfig: config.ControllerConfig{
+			AWSConfig: aws.CloudConfig{
+				ThrottleConfig: throttle.NewDefaultServiceOperationsThrottleConfig(),
+			},
+			FeatureGates: config.NewFeatureGates(),
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "inspect <ingress|ingressgroup|service|gateway|globalaccelerator> <name>",
+		Short: "Inspect the AWS resources behind a Kubernetes object",
+		Long: `inspect prints the AWS resources the controller manages for an Ingress, IngressGroup, Service, Gateway
+or GlobalAccelerator as a tree of load balancer -> listeners -> rules -> target groups -> targets, with target health.
+
+Live resources are discovered via the tracking tags of the controller. The desired stack is rebuilt with the same
+model builders as the controller and any drift is reported.
+Controller flags, such as --cluster-name, --default-tags and --feature-gates, should match the controller deployment.`,
+		Example: `  kubectl lbc inspect ingress my-ingress -n my-namespace
+  kubectl lbc inspect ingressgroup my-group --cluster-name my-cluster
+  kubectl lbc inspect svc my-service -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := validateInspectArgs(opts, args)
+			if err != nil {
+				return err
+			}
+			return runInspect(cmd, opts, ref)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", defaultNamespace,
+		"Namespace of the object, ignored for ingressgroup")
+	cmd.Flags().StringVarP(&opts.OutputFormat, "output", "o", string(inspect.OutputFormatTree),
+		"Output format: tree or json")
+
+	controllerFlags := pflag.NewFlagSet("controller", pflag.ContinueOnError)
+	opts.ControllerConfig.BindFlags(controllerFlags)
+	cmd.Flags().AddFlagSet(controllerFlags)
+	return cmd
+}
+
+// validateInspectArgs validates flags and args, and returns the object to inspect.
+func validateInspectArgs(opts *InspectOptions, args []string) (inspect.ObjectRef, error) {
+	kind, err := inspect.ParseObjectKind(args[0])
+	if err != nil {
+		return inspect.ObjectRef{}, err
+	}
+	if args[1] == "" {
+		return inspect.ObjectRef{}, fmt.Errorf("name must not be empty")
+	}
+	if opts.OutputFormat != string(inspect.OutputFormatTree) && opts.OutputFormat != string(inspect.OutputFormatJSON) {
+		return inspect.ObjectRef{}, fmt.Errorf("--output must be tree or json, got %q", opts.OutputFormat)
+	}
+	if err := opts.ControllerConfig.Validate(); err != nil {
+		return inspect.ObjectRef{}, err
+	}
+	ref := inspect.ObjectRef{Kind: kind, Namespace: opts.Namespace, Name: args[1]}
+	if kind == inspect.ObjectKindIngressGroup {
+		ref.Namespace = ""
+	}
+	return ref, nil
+}
+
+func runInspect(cmd *cobra.Command, opts *InspectOptions, ref inspect.ObjectRef) error {
+	printer, err := inspect.NewPrinter(inspect.OutputFormat(opts.OutputFormat))
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = agaapi.AddToScheme(scheme)
+	_ = elbv2api.AddToScheme(scheme)
+	_ = elbv2gw.AddToScheme(scheme)
+	_ = gwv1.Install(scheme)
+	_ = gwalpha2.Install(scheme)
+	_ = gwbeta1.Install(scheme)
+
+	restConfig, err := buildRestConfig(opts.ControllerConfig.RuntimeConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	// logs of the model builders are discarded, failures are surfaced as warnings in the report.
+	logger := logr.Discard()
+	cloud, err := aws.NewCloud(opts.ControllerConfig.AWSConfig, opts.ControllerConfig.ClusterName, nil, logger, nil, aws.DefaultLbStabilizationTime)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AWS cloud: %w", err)
+	}
+
+	inspector := inspect.NewDefaultInspector(cloud, k8sClient, opts.ControllerConfig, logger)
+	report, err := inspector.Inspect(cmd.Context(), ref)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", ref, err)
+	}
+	return printer.Print(cmd.OutOrStdout(), report)
+}
+
+// buildRestConfig uses the kubeconfig flag when specified, otherwise follows the kubectl loading rules.
+func buildRestConfig(rtCfg config.RuntimeConfig) (*rest.Config, error) {
+	if rtCfg.KubeConfig != "" {
+		return config.BuildRestConfig(rtCfg)
+	}
+	return ctrlconfig.GetConfig()
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inspect"
+)
+
+func TestValidateInspectArgs(t *testing.T) {
+	validOpts := func() *InspectOptions {
+		opts := &InspectOptions{
+			Namespace:    "ns",
+			OutputFormat: "tree",
+			ControllerConfig: config.ControllerConfig{
+				FeatureGates: config.NewFeatureGates(),
+			},
+		}
+		fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+		opts.ControllerConfig.BindFlags(fs)
+		require.NoError(t, fs.Parse([]string{"--cluster-name=cluster"}))
+		return opts
+	}
+	tests := []struct {
+		name    string
+		opts    *InspectOptions
+		args    []string
+		want    inspect.ObjectRef
+		wantErr string
+	}{
+		{
+			name: "ingress",
+			opts: validOpts(),
+			args: []string{"ing", "my-ingress"},
+			want: inspect.ObjectRef{Kind: inspect.ObjectKindIngress, Namespace: "ns", Name: "my-ingress"},
+		},
+		{
+			name: "ingressgroup is cluster scoped",
+			opts: validOpts(),
+			args: []string{"ingressgroup", "my-group"},
+			want: inspect.ObjectRef{Kind: inspect.ObjectKindIngressGroup, Name: "my-group"},
+		},
+		{
+			name:    "unsupported kind",
+			opts:    validOpts(),
+			args:    []string{"deployment", "my-app"},
+			wantErr: `unsupported kind "deployment"`,
+		},
+		{
+			name:    "empty name",
+			opts:    validOpts(),
+			args:    []string{"svc", ""},
+			wantErr: "name must not be empty",
+		},
+		{
+			name: "unsupported output format",
+			opts: func() *InspectOptions {
+				opts := validOpts()
+				opts.OutputFormat = "yaml"
+				return opts
+			}(),
+			args:    []string{"svc", "my-service"},
+			wantErr: `--output must be tree or json, got "yaml"`,
+		},
+		{
+			name: "cluster name is required",
+			opts: func() *InspectOptions {
+				opts := validOpts()
+				opts.ControllerConfig.ClusterName = ""
+				return opts
+			}(),
+			args:    []string{"svc", "my-service"},
+			wantErr: "kubernetes cluster name must be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateInspectArgs(tt.opts, tt.args)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
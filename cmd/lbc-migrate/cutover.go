@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	epresolver "sigs.k8s.io/aws-load-balancer-controller/pkg/aws/endpoints"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/provider"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress2gateway/cutover"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const defaultCutoverStateFile = "./lbc-migrate-cutover.json"
+
+// cutoverFlags holds the flags of the cutover subcommand.
+type cutoverFlags struct {
+	Ingress      string
+	Gateway      string
+	Files        []string
+	InputDir     string
+	IngressClass string
+
+	Route53Record               string
+	Route53HostedZoneID         string
+	AcceleratorEndpointGroupARN string
+
+	Steps        []int32
+	StepInterval time.Duration
+	Timeout      time.Duration
+	StateFile    string
+	Rollback     bool
+
+	Kubeconfig string
+	AWSRegion  string
+}
+
+func newCutoverCommand() *cobra.Command {
+	flags := &cutoverFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "cutover",
+		Short: "Shift traffic from an Ingress to its generated Gateway in weighted steps",
+		Long: `cutover applies the generated Gateway manifests without the dry-run annotation, waits until the
+Gateway is Programmed and its targets are as healthy as the Ingress targets, then shifts traffic to the
+Gateway load balancer in weighted steps, via Route53 weighted records or GlobalAccelerator endpoint weights.
+
+The Gateway is re-checked after each step, and traffic is shifted back to the Ingress if it degrades.
+Progress is persisted in a state file, rerun the same command to resume an interrupted cutover,
+or pass --rollback to shift all traffic back to the Ingress at any step.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateCutoverFlags(flags); err != nil {
+				return err
+			}
+			return runCutover(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Ingress, "ingress", "",
+		"Ingress whose traffic is cut over, as namespace/name. Its IngressGroup is compared with the Gateway")
+	cmd.Flags().StringVar(&flags.Gateway, "gateway", "",
+		"Gateway that replaces the Ingress, as namespace/name")
+	cmd.Flags().StringSliceVarP(&flags.Files, "file", "f", nil,
+		"Comma-separated generated manifest files to apply (e.g. -f gateway.yaml)")
+	cmd.Flags().StringVar(&flags.InputDir, "input-dir", "",
+		"Directory of generated manifest files to apply, including per-namespace subdirectories")
+	cmd.Flags().StringVar(&flags.IngressClass, "ingress-class", "alb",
+		"Ingress class the controller manages, used to resolve the IngressGroup")
+
+	cmd.Flags().StringVar(&flags.Route53Record, "route53-record", "",
+		"DNS name of the Route53 weighted alias records to shift traffic with")
+	cmd.Flags().StringVar(&flags.Route53HostedZoneID, "route53-hosted-zone-id", "",
+		"Hosted zone of --route53-record, discovered from the record name when empty")
+	cmd.Flags().StringVar(&flags.AcceleratorEndpointGroupARN, "accelerator-endpoint-group-arn", "",
+		"GlobalAccelerator endpoint group containing the Ingress load balancer to shift traffic with")
+
+	cmd.Flags().Int32SliceVar(&flags.Steps, "steps", []int32{10, 25, 50, 100},
+		"Comma-separated percentages of traffic shifted to the Gateway at each step, ending at 100")
+	cmd.Flags().DurationVar(&flags.StepInterval, "step-interval", 5*time.Minute,
+		"How long each step is observed before moving to the next one")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 15*time.Minute,
+		"How long to wait for the Gateway to be Programmed with healthy targets")
+	cmd.Flags().StringVar(&flags.StateFile, "state-file", defaultCutoverStateFile,
+		"File persisting the cutover progress, used to resume or roll back")
+	cmd.Flags().BoolVar(&flags.Rollback, "rollback", false,
+		"Shift all traffic back to the Ingress")
+
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", "",
+		"Path to kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&flags.AWSRegion, "aws-region", "",
+		"AWS region of the load balancers (defaults to the AWS SDK configuration)")
+
+	return cmd
+}
+
+func validateCutoverFlags(flags *cutoverFlags) error {
+	if _, err := parseNamespacedName("--ingress", flags.Ingress); err != nil {
+		return err
+	}
+	if _, err := parseNamespacedName("--gateway", flags.Gateway); err != nil {
+		return err
+	}
+
+	hasRoute53 := flags.Route53Record != ""
+	hasAccelerator := flags.AcceleratorEndpointGroupARN != ""
+	if hasRoute53 == hasAccelerator {
+		return fmt.Errorf("must specify exactly one of: --route53-record or --accelerator-endpoint-group-arn")
+	}
+	if flags.Route53HostedZoneID != "" && !hasRoute53 {
+		return fmt.Errorf("--route53-hosted-zone-id can only be used with --route53-record")
+	}
+
+	if flags.Rollback && (len(flags.Files) > 0 || flags.InputDir != "") {
+		return fmt.Errorf("--rollback cannot be used with --file or --input-dir")
+	}
+	if flags.StepInterval < 0 {
+		return fmt.Errorf("--step-interval must not be negative")
+	}
+	if flags.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	if flags.StateFile == "" {
+		return fmt.Errorf("--state-file must not be empty")
+	}
+	return nil
+}
+
+func parseNamespacedName(flagName string, value string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("%s must be in the form namespace/name, got %q", flagName, value)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+func buildCutoverOptions(flags *cutoverFlags) (cutover.Options, error) {
+	ingKey, _ := parseNamespacedName("--ingress", flags.Ingress)
+	gwKey, _ := parseNamespacedName("--gateway", flags.Gateway)
+	files := flags.Files
+	if flags.InputDir != "" {
+		dirFiles, err := findManifestFiles(flags.InputDir)
+		if err != nil {
+			return cutover.Options{}, err
+		}
+		files = append(files, dirFiles...)
+	}
+
+	trafficTarget := cutover.TrafficTarget{
+		Type:             cutover.TrafficTargetTypeGlobalAccelerator,
+		EndpointGroupARN: flags.AcceleratorEndpointGroupARN,
+	}
+	if flags.Route53Record != "" {
+		trafficTarget = cutover.TrafficTarget{
+			Type:         cutover.TrafficTargetTypeRoute53,
+			RecordName:   flags.Route53Record,
+			HostedZoneID: flags.Route53HostedZoneID,
+		}
+	}
+	return cutover.Options{
+		Ingress:          ingKey,
+		Gateway:          gwKey,
+		ManifestFiles:    files,
+		TrafficTarget:    trafficTarget,
+		Steps:            flags.Steps,
+		StepInterval:     flags.StepInterval,
+		ReadinessTimeout: flags.Timeout,
+		StateFile:        flags.StateFile,
+	}, nil
+}
+
+// findManifestFiles finds the .yaml/.yml/.json files under dir, recursively, since --split=namespace
+// writes the generated manifests into per-namespace subdirectories.
+func findManifestFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext == ".yaml" || ext == ".yml" || ext == ".json" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+func runCutover(ctx context.Context, flags *cutoverFlags) error {
+	opts, err := buildCutoverOptions(flags)
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = elbv2api.AddToScheme(scheme)
+	_ = gwv1.Install(scheme)
+
+	restConfig, err := buildCutoverRestConfig(flags.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	var loadOpts []func(*awsconfig.LoadOptions) error
+	if flags.AWSRegion != "" {
+		loadOpts = append(loadOpts, awsconfig.WithRegion(flags.AWSRegion))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	awsClientsProvider, err := provider.NewDefaultAWSClientsProvider(awsConfig, epresolver.NewResolver(nil))
+	if err != nil {
+		return fmt.Errorf("failed to create AWS clients: %w", err)
+	}
+
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, &record.FakeRecorder{},
+		annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress),
+		ingress.NewDefaultClassLoader(k8sClient, true),
+		ingress.NewDefaultClassAnnotationMatcher(flags.IngressClass), false)
+	c := cutover.NewDefaultCutover(k8sClient, groupLoader,
+		services.NewELBV2(awsClientsProvider, nil, 0),
+		services.NewRoute53(awsClientsProvider),
+		services.NewGlobalAccelerator(awsClientsProvider),
+		os.Stderr)
+
+	if flags.Rollback {
+		return c.Rollback(ctx, opts)
+	}
+	return c.Run(ctx, opts)
+}
+
+func buildCutoverRestConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return config.GetConfig()
+}
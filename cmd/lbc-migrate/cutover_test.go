@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCutoverFlags(t *testing.T) {
+	validFlags := func() *cutoverFlags {
+		return &cutoverFlags{
+			Ingress:       "ns/ing",
+			Gateway:       "ns/gw",
+			Route53Record: "app.example.com",
+			Steps:         []int32{10, 100},
+			StepInterval:  5 * time.Minute,
+			Timeout:       15 * time.Minute,
+			StateFile:     defaultCutoverStateFile,
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(flags *cutoverFlags)
+		wantErr string
+	}{
+		{
+			name:   "route53 traffic target",
+			mutate: func(flags *cutoverFlags) {},
+		},
+		{
+			name: "globalaccelerator traffic target",
+			mutate: func(flags *cutoverFlags) {
+				flags.Route53Record = ""
+				flags.AcceleratorEndpointGroupARN = "arn:aws:globalaccelerator::123456789012:accelerator/abc/listener/l1/endpoint-group/eg1"
+			},
+		},
+		{
+			name:    "ingress without namespace",
+			mutate:  func(flags *cutoverFlags) { flags.Ingress = "ing" },
+			wantErr: `--ingress must be in the form namespace/name, got "ing"`,
+		},
+		{
+			name:    "gateway missing",
+			mutate:  func(flags *cutoverFlags) { flags.Gateway = "" },
+			wantErr: `--gateway must be in the form namespace/name, got ""`,
+		},
+		{
+			name:    "no traffic target",
+			mutate:  func(flags *cutoverFlags) { flags.Route53Record = "" },
+			wantErr: "must specify exactly one of: --route53-record or --accelerator-endpoint-group-arn",
+		},
+		{
+			name: "both traffic targets",
+			mutate: func(flags *cutoverFlags) {
+				flags.AcceleratorEndpointGroupARN = "arn:aws:globalaccelerator::123456789012:accelerator/abc/listener/l1/endpoint-group/eg1"
+			},
+			wantErr: "must specify exactly one of: --route53-record or --accelerator-endpoint-group-arn",
+		},
+		{
+			name: "hosted zone without route53 record",
+			mutate: func(flags *cutoverFlags) {
+				flags.Route53Record = ""
+				flags.Route53HostedZoneID = "Z123"
+				flags.AcceleratorEndpointGroupARN = "arn:aws:globalaccelerator::123456789012:accelerator/abc/listener/l1/endpoint-group/eg1"
+			},
+			wantErr: "--route53-hosted-zone-id can only be used with --route53-record",
+		},
+		{
+			name: "rollback with manifests",
+			mutate: func(flags *cutoverFlags) {
+				flags.Rollback = true
+				flags.Files = []string{"gateway.yaml"}
+			},
+			wantErr: "--rollback cannot be used with --file or --input-dir",
+		},
+		{
+			name:    "zero timeout",
+			mutate:  func(flags *cutoverFlags) { flags.Timeout = 0 },
+			wantErr: "--timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := validFlags()
+			tt.mutate(flags)
+			err := validateCutoverFlags(flags)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFindManifestFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ns-a"), 0755))
+	for _, file := range []string{"gatewayclass-resources.yaml", "README.md", filepath.Join("ns-a", "gateway-resources.json")} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("{}"), 0644))
+	}
+
+	files, err := findManifestFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "gatewayclass-resources.yaml"),
+		filepath.Join(dir, "ns-a", "gateway-resources.json"),
+	}, files)
+}
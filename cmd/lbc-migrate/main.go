@@ -98,6 +98,8 @@ Use --console to launch a local web UI that compares ingress and gateway dry-run
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", true,
 		"Add gateway.k8s.aws/dry-run annotation to generated Gateway manifests so LBC previews the generated AWS resources without creating them. Pass --dry-run=false to generate live Gateway manifests.")
 
+	cmd.AddCommand(newCutoverCommand())
+
 	return cmd
 }
 
@@ -0,0 +1,78 @@
+package offline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/gateway-api/conformance"
+	"sigs.k8s.io/gateway-api/conformance/utils/flags"
+	"sigs.k8s.io/gateway-api/conformance/utils/roundtripper"
+	"sigs.k8s.io/gateway-api/conformance/utils/suite"
+)
+
+// TestOfflineConformance runs the Gateway API conformance suite against the ALB Gateway controller backed by the fake AWS cloud.
+// It requires the envtest binaries in KUBEBUILDER_ASSETS and the Gateway API experimental CRDs in GATEWAY_API_CRD_DIR,
+// see `make conformance-offline`.
+func TestOfflineConformance(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" || os.Getenv("GATEWAY_API_CRD_DIR") == "" {
+		t.Skip("KUBEBUILDER_ASSETS and GATEWAY_API_CRD_DIR must be set to run the offline conformance suite")
+	}
+	ctx := context.Background()
+	env, err := StartEnvironment(ctx, Options{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			filepath.Join("..", "..", "config", "crd", "gateway", "gateway-crds.yaml"),
+			os.Getenv("GATEWAY_API_CRD_DIR"),
+		},
+		ALBGatewayClassName: *flags.GatewayClassName,
+	})
+	require.NoError(t, err)
+	defer env.Stop()
+
+	kubeconfig, err := env.Kubeconfig()
+	require.NoError(t, err)
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, kubeconfig, 0600))
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	options := conformance.DefaultOptions(t)
+	options.RoundTripper = env.Proxy.RoundTripper(&roundtripper.DefaultRoundTripper{
+		Debug:         options.Debug,
+		TimeoutConfig: options.TimeoutConfig,
+	})
+	grpcClient := env.Proxy.GRPCClient()
+	defer grpcClient.Close()
+	options.GRPCClient = grpcClient
+
+	// Configure skip tests and supported features.
+	if len(options.SkipTests) == 0 {
+		options.SkipTests = []string{
+			// The proxy doesn't terminate TLS, these tests need an HTTPS or TLS listener.
+			"GatewayInvalidTLSConfiguration",
+			"GatewaySecretInvalidReferenceGrant",
+			"GatewaySecretMissingReferenceGrant",
+			"GatewaySecretReferenceGrantAllInNamespace",
+			"GatewaySecretReferenceGrantSpecific",
+			"GatewayWithAttachedRoutes",
+			"HTTPRouteHTTPSListener",
+			"ListenerSetReferenceGrant",
+			// These tests don't pass against ELB, they're skipped by the online suite as well.
+			"HTTPRouteBackendRequestHeaderModifier",
+			"HTTPRouteRequestHeaderModifier",
+			"HTTPRouteHostnameIntersection",
+			"HTTPRouteServiceTypes",
+		}
+	}
+	if len(options.SupportedFeatures) == 0 {
+		options.SupportedFeatures = suite.ParseSupportedFeatures("Gateway,HTTPRoute,GRPCRoute,ReferenceGrant,HTTPRoutePortRedirect,HTTPRouteMethodMatching,HTTPRouteParentRefPort,HTTPRouteDestinationPortMatching,ListenerSet")
+	}
+
+	// The fake cloud provisions load balancers immediately, the default timeouts of the suite apply.
+	options.TimeoutConfig.DefaultTestTimeout = 2 * time.Minute
+
+	conformance.RunConformanceWithOptions(t, options)
+}
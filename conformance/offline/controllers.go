@@ -0,0 +1,129 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
+	elbv2controller "sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/controllers/gateway"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/referencecounter"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/routeutils"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/aws"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	metricsutil "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/util"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// setupControllers sets up the TargetGroupBinding and Gateway API controllers with mgr, wired the same way as in main.go.
+// Controllers for Ingress, Service and GlobalAccelerator aren't needed by the Gateway API conformance suite and aren't set up.
+func setupControllers(ctx context.Context, mgr ctrl.Manager, cloud services.Cloud, controllerCFG config.ControllerConfig) error {
+	logger := ctrl.Log.WithName("offline")
+	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	registry := prometheus.NewRegistry()
+	reconcileCounters := metricsutil.NewReconcileCounters()
+	lbcMetricsCollector := lbcmetrics.NewCollector(registry, mgr, reconcileCounters, logger.WithName("controller_metrics"))
+	targetGroupCollector := awsmetrics.NewTargetGroupCollector(registry)
+
+	podInfoRepo := k8s.NewDefaultPodInfoRepo(clientSet.CoreV1().RESTClient(), controllerCFG.RuntimeConfig.WatchNamespace, controllerCFG.ServerIDInjectionConfig.EnvironmentVariableName, logger)
+	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient(), logger)
+	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), logger)
+	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, logger)
+	azInfoProvider := networking.NewDefaultAZInfoProvider(cloud.EC2(), logger.WithName("az-info-provider"))
+	vpcInfoProvider := networking.NewDefaultVPCInfoProvider(cloud.EC2(), logger.WithName("vpc-info-provider"))
+	subnetResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName,
+		controllerCFG.FeatureGates.Enabled(config.SubnetsClusterTagCheck),
+		controllerCFG.FeatureGates.Enabled(config.ALBSingleSubnet),
+		controllerCFG.FeatureGates.Enabled(config.SubnetDiscoveryByReachability),
+		logger.WithName("subnets-resolver"))
+	multiClusterManager := targetgroupbinding.NewMultiClusterManager(mgr.GetClient(), mgr.GetAPIReader(), logger)
+	nodeInfoProvider := networking.NewDefaultNodeInfoProvider(cloud.EC2(), logger)
+	podENIResolver := networking.NewDefaultPodENIInfoResolver(mgr.GetClient(), cloud.EC2(), nodeInfoProvider, cloud.VpcID(), logger)
+	nodeENIResolver := networking.NewDefaultNodeENIInfoResolver(nodeInfoProvider, logger)
+	networkingManager := networking.NewDefaultNetworkingManager(mgr.GetClient(), podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, controllerCFG.ServiceTargetENISGTags, logger, controllerCFG.DisableRestrictedSGRules)
+	tgArnMapper := shared_utils.NewTargetGroupNameToArnMapper(cloud.ELBV2())
+	backendSGProvider := networking.NewBackendSGProvider(controllerCFG.ClusterName, controllerCFG.BackendSecurityGroup,
+		cloud.VpcID(), cloud.EC2(), mgr.GetClient(), controllerCFG.DefaultTags, true, logger.WithName("backend-sg-provider"))
+	sgResolver := networking.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID())
+	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), controllerCFG.FeatureGates, cloud.RGT(), logger)
+
+	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
+		podInfoRepo, networkingManager, vpcInfoProvider, multiClusterManager, lbcMetricsCollector,
+		targetGroupCollector, cloud.VpcID(), controllerCFG.FeatureGates.Enabled(config.EndpointsFailOpen), controllerCFG.EnableEndpointSlices,
+		mgr.GetEventRecorderFor("targetGroupBinding"), logger, controllerCFG.MaxTargetsPerTargetGroup, controllerCFG.TargetGroupBindingRequeueDuration,
+		controllerCFG.PodTerminationGateConfig.PodTerminationGateTimeout, controllerCFG.TargetHealthRefreshInterval)
+	deferredTGBQueue := elbv2controller.NewDeferredTargetGroupBindingReconciler(workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+		Name: "delayed-target-group-binding",
+	}), controllerCFG.RuntimeConfig.SyncPeriod, mgr.GetClient(), logger.WithName("deferredTGBQueue"))
+	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
+		finalizerManager, tgbResManager, controllerCFG, deferredTGBQueue, logger.WithName("targetGroupBinding"), lbcMetricsCollector, reconcileCounters, podInfoRepo.GetInformer())
+	if err := tgbReconciler.SetupWithManager(ctx, mgr); err != nil {
+		return fmt.Errorf("unable to create TargetGroupBinding controller: %w", err)
+	}
+
+	routeReconciler := gateway.NewRouteReconciler(workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+		Name: "gateway-route-status-update-reconciler",
+	}), mgr.GetClient(), logger.WithName("routeReconciler"))
+	listenerSetReconciler := gateway.NewListenerSetStatusReconciler(workqueue.NewTypedDelayingQueueWithConfig[routeutils.ListenerSetStatusData](workqueue.TypedDelayingQueueConfig[routeutils.ListenerSetStatusData]{
+		Name: "gateway-listenerset-status-update-reconciler",
+	}), mgr.GetClient(), logger.WithName("listenerSetReconciler"))
+	serviceReferenceCounter := referencecounter.NewServiceReferenceCounter()
+	certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerCFG.IngressConfig.AllowedCertificateAuthorityARNs, false, controllerCFG.CertDiscoveryConfig.SelectionPolicy(), logger.WithName("gateway-cert-discovery"))
+	routeLoader := routeutils.NewLoader(mgr.GetClient(), routeReconciler, controllerCFG.FeatureGates, logger.WithName("gateway-route-loader"))
+
+	gatewayReconcilers := map[string]gateway.Reconciler{
+		gateway_constants.NLBGatewayController: gateway.NewNLBGatewayReconciler(routeLoader, serviceReferenceCounter, cloud, mgr.GetClient(), certDiscovery,
+			mgr.GetEventRecorderFor(gateway_constants.NLBGatewayController), controllerCFG, finalizerManager, networkingManager, sgReconciler, sgManager,
+			elbv2TaggingManager, subnetResolver, vpcInfoProvider, backendSGProvider, sgResolver, logger.WithName(gateway_constants.NLBGatewayController),
+			lbcMetricsCollector, reconcileCounters, targetGroupCollector, tgArnMapper, listenerSetReconciler, routeReconciler),
+		gateway_constants.ALBGatewayController: gateway.NewALBGatewayReconciler(routeLoader, cloud, mgr.GetClient(), certDiscovery, serviceReferenceCounter,
+			mgr.GetEventRecorderFor(gateway_constants.ALBGatewayController), controllerCFG, finalizerManager, networkingManager, sgReconciler, sgManager,
+			elbv2TaggingManager, subnetResolver, vpcInfoProvider, backendSGProvider, sgResolver, logger.WithName(gateway_constants.ALBGatewayController),
+			lbcMetricsCollector, reconcileCounters, targetGroupCollector, tgArnMapper, listenerSetReconciler),
+	}
+	for controllerType, reconciler := range gatewayReconcilers {
+		controller, err := reconciler.SetupWithManager(ctx, mgr)
+		if err != nil {
+			return fmt.Errorf("unable to create %s controller: %w", controllerType, err)
+		}
+		if err := reconciler.SetupWatches(ctx, controller, mgr, clientSet); err != nil {
+			return fmt.Errorf("unable to setup watches for %s controller: %w", controllerType, err)
+		}
+	}
+
+	gatewayClassReconciler := gateway.NewGatewayClassReconciler(mgr.GetClient(), mgr.GetEventRecorderFor(gateway_constants.GatewayClassController),
+		controllerCFG, finalizerManager, sets.New(gateway_constants.NLBGatewayController, gateway_constants.ALBGatewayController), logger.WithName("gatewayclass-controller"))
+	gatewayClassController, err := gatewayClassReconciler.SetupWithManager(ctx, mgr)
+	if err != nil {
+		return fmt.Errorf("unable to create GatewayClass controller: %w", err)
+	}
+	if err := gatewayClassReconciler.SetupWatches(ctx, gatewayClassController, mgr, nil); err != nil {
+		return fmt.Errorf("unable to setup watches for GatewayClass controller: %w", err)
+	}
+
+	go func() {
+		if err := podInfoRepo.Start(ctx); err != nil {
+			logger.Error(err, "problem running podInfo repo")
+		}
+	}()
+	go deferredTGBQueue.Run()
+	go routeReconciler.Run()
+	go listenerSetReconciler.Run()
+	return podInfoRepo.WaitForCacheSync(ctx)
+}
@@ -0,0 +1,313 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwalpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwbeta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	defaultRegion      = "us-west-2"
+	defaultAccountID   = "123456789012"
+	defaultClusterName = "offline-conformance"
+	defaultVPCID       = "vpc-0000000000000offl"
+	defaultVPCCIDR     = "192.168.0.0/16"
+	simulatedNodeName  = "offline-node"
+)
+
+// Options configures an Environment.
+type Options struct {
+	// CRDDirectoryPaths are the directories with the CRDs installed into the API server.
+	CRDDirectoryPaths []string
+	// ControllerArgs are extra command line flags of the controller, e.g. "--feature-gates=ALBGatewayAPI=true".
+	ControllerArgs []string
+	// ALBGatewayClassName and NLBGatewayClassName are the GatewayClasses created for the ALB and NLB Gateway controllers.
+	ALBGatewayClassName string
+	NLBGatewayClassName string
+}
+
+// Environment runs the Gateway API controllers against an envtest API server and a stateful fake of the AWS services,
+// so that the Gateway API conformance suite can run without an AWS account or a cluster.
+type Environment struct {
+	RestConfig *rest.Config
+	Client     client.Client
+	Cloud      *fake.Cloud
+	Proxy      *Proxy
+
+	testEnv *envtest.Environment
+	cancel  context.CancelFunc
+}
+
+// StartEnvironment starts the API server, seeds the fake VPC and starts the controllers.
+// envtest locates the kube-apiserver and etcd binaries with the KUBEBUILDER_ASSETS environment variable.
+func StartEnvironment(ctx context.Context, opts Options) (*Environment, error) {
+	controllerCFG, err := buildControllerConfig(opts.ControllerArgs)
+	if err != nil {
+		return nil, err
+	}
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     opts.CRDDirectoryPaths,
+		ErrorIfCRDPathMissing: true,
+	}
+	restCFG, err := testEnv.Start()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start envtest")
+	}
+	env := &Environment{
+		RestConfig: restCFG,
+		Cloud:      fake.NewCloud(defaultRegion, defaultAccountID, defaultVPCID),
+		testEnv:    testEnv,
+	}
+	if err := env.start(ctx, opts, controllerCFG); err != nil {
+		_ = env.Stop()
+		return nil, err
+	}
+	return env, nil
+}
+
+func (e *Environment) start(ctx context.Context, opts Options, controllerCFG config.ControllerConfig) error {
+	scheme := buildScheme()
+	k8sClient, err := client.New(e.RestConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	e.Client = k8sClient
+
+	node := seedNetwork(e.Cloud.FakeEC2(), controllerCFG.ClusterName)
+	if err := createNode(ctx, k8sClient, node); err != nil {
+		return err
+	}
+	if err := createGatewayClasses(ctx, k8sClient, opts); err != nil {
+		return err
+	}
+
+	mgr, err := ctrl.NewManager(e.RestConfig, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		return err
+	}
+	mgrCtx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	if err := setupControllers(mgrCtx, mgr, e.Cloud, controllerCFG); err != nil {
+		return err
+	}
+	workloads := newWorkloadSimulator(mgr.GetClient(), e.Cloud.FakeEC2(), node, ctrl.Log.WithName("offline-workloads"))
+	if err := workloads.SetupWithManager(mgr); err != nil {
+		return err
+	}
+	e.Proxy = NewProxy(e.Cloud.FakeELBV2(), workloads.LookupPodByIP)
+	e.Proxy.Start()
+
+	go func() {
+		if err := mgr.Start(mgrCtx); err != nil {
+			ctrl.Log.WithName("offline").Error(err, "problem running manager")
+		}
+	}()
+	return nil
+}
+
+// Stop stops the controllers, the proxy and the API server.
+func (e *Environment) Stop() error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	if e.Proxy != nil {
+		_ = e.Proxy.Close()
+	}
+	return e.testEnv.Stop()
+}
+
+// Kubeconfig returns a kubeconfig with admin access to the API server, for tools that load their configuration from a kubeconfig
+// such as the conformance suite.
+func (e *Environment) Kubeconfig() ([]byte, error) {
+	user, err := e.testEnv.AddUser(envtest.User{Name: "offline-conformance", Groups: []string{"system:masters"}}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add envtest user")
+	}
+	return user.KubeConfig()
+}
+
+func buildScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = elbv2api.AddToScheme(scheme)
+	_ = elbv2gw.AddToScheme(scheme)
+	_ = gwv1.AddToScheme(scheme)
+	_ = gwalpha2.AddToScheme(scheme)
+	_ = gwbeta1.AddToScheme(scheme)
+	return scheme
+}
+
+// buildControllerConfig parses the controller flags the same way as the controller binary.
+// Targets default to IP, as the simulated pods aren't reachable through node ports, and the addons backed by services the fake cloud
+// doesn't simulate are disabled.
+func buildControllerConfig(args []string) (config.ControllerConfig, error) {
+	controllerCFG := config.ControllerConfig{
+		AWSConfig: aws.CloudConfig{
+			ThrottleConfig: throttle.NewDefaultServiceOperationsThrottleConfig(),
+		},
+		FeatureGates: config.NewFeatureGates(),
+	}
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	controllerCFG.BindFlags(fs)
+	defaultArgs := []string{
+		"--cluster-name=" + defaultClusterName,
+		"--default-target-type=ip",
+		"--enable-endpoint-slices=true",
+		"--enable-waf=false",
+		"--enable-wafv2=false",
+		"--enable-shield=false",
+		"--feature-gates=NLBGatewayAPI=true,ALBGatewayAPI=true,GatewayListenerSet=true,EnableRGTAPI=false,EnableCertificateManagement=false",
+	}
+	if err := fs.Parse(append(defaultArgs, args...)); err != nil {
+		return config.ControllerConfig{}, err
+	}
+	if err := controllerCFG.Validate(); err != nil {
+		return config.ControllerConfig{}, err
+	}
+	return controllerCFG, nil
+}
+
+// seedNetwork seeds a VPC with a public and a private subnet in each availability zone, and an instance for the simulated node.
+func seedNetwork(ec2Fake *fake.EC2, clusterName string) simulatedNode {
+	vpc := ec2Fake.AddVpc(ec2types.Vpc{
+		VpcId:     awssdk.String(defaultVPCID),
+		CidrBlock: awssdk.String(defaultVPCCIDR),
+	})
+	clusterTag := ec2types.Tag{Key: awssdk.String("kubernetes.io/cluster/" + clusterName), Value: awssdk.String("shared")}
+	igwRouteTable := ec2types.RouteTable{
+		VpcId: vpc.VpcId,
+		Routes: []ec2types.Route{
+			{DestinationCidrBlock: awssdk.String(defaultVPCCIDR), GatewayId: awssdk.String("local")},
+			{DestinationCidrBlock: awssdk.String("0.0.0.0/0"), GatewayId: awssdk.String("igw-0000000000000offl")},
+		},
+	}
+	var privateSubnets []ec2types.Subnet
+	for i, az := range []string{"a", "b", "c"} {
+		publicSubnet := ec2Fake.AddSubnet(ec2types.Subnet{
+			VpcId:            vpc.VpcId,
+			AvailabilityZone: awssdk.String(defaultRegion + az),
+			CidrBlock:        awssdk.String(fmt.Sprintf("192.168.%d.0/24", i)),
+			Tags:             []ec2types.Tag{clusterTag, {Key: awssdk.String("kubernetes.io/role/elb"), Value: awssdk.String("1")}},
+		})
+		igwRouteTable.Associations = append(igwRouteTable.Associations, ec2types.RouteTableAssociation{SubnetId: publicSubnet.SubnetId})
+		privateSubnets = append(privateSubnets, ec2Fake.AddSubnet(ec2types.Subnet{
+			VpcId:            vpc.VpcId,
+			AvailabilityZone: awssdk.String(defaultRegion + az),
+			CidrBlock:        awssdk.String(fmt.Sprintf("192.168.%d.0/20", (i+1)*16)),
+			Tags:             []ec2types.Tag{clusterTag, {Key: awssdk.String("kubernetes.io/role/internal-elb"), Value: awssdk.String("1")}},
+		}))
+	}
+	ec2Fake.AddRouteTable(igwRouteTable)
+	ec2Fake.AddRouteTable(ec2types.RouteTable{
+		VpcId:        vpc.VpcId,
+		Associations: []ec2types.RouteTableAssociation{{Main: awssdk.Bool(true)}},
+		Routes:       []ec2types.Route{{DestinationCidrBlock: awssdk.String(defaultVPCCIDR), GatewayId: awssdk.String("local")}},
+	})
+
+	nodeSubnet := privateSubnets[0]
+	nodeSG := ec2Fake.AddSecurityGroup(ec2types.SecurityGroup{
+		VpcId:     vpc.VpcId,
+		GroupName: awssdk.String(clusterName + "-node"),
+		Tags:      []ec2types.Tag{clusterTag},
+	})
+	instance := ec2Fake.AddInstance(ec2types.Instance{
+		VpcId:            vpc.VpcId,
+		SubnetId:         nodeSubnet.SubnetId,
+		PrivateIpAddress: awssdk.String("192.168.16.10"),
+		Placement:        &ec2types.Placement{AvailabilityZone: nodeSubnet.AvailabilityZone},
+	})
+	eni := ec2Fake.AddNetworkInterface(ec2types.NetworkInterface{
+		VpcId:            vpc.VpcId,
+		SubnetId:         nodeSubnet.SubnetId,
+		AvailabilityZone: nodeSubnet.AvailabilityZone,
+		PrivateIpAddress: instance.PrivateIpAddress,
+		Groups:           []ec2types.GroupIdentifier{{GroupId: nodeSG.GroupId, GroupName: nodeSG.GroupName}},
+		TagSet:           []ec2types.Tag{clusterTag},
+		Attachment: &ec2types.NetworkInterfaceAttachment{
+			AttachmentId: awssdk.String("eni-attach-0000000000000offl"),
+			InstanceId:   instance.InstanceId,
+			DeviceIndex:  awssdk.Int32(0),
+		},
+	})
+	return simulatedNode{
+		name:               simulatedNodeName,
+		instanceID:         awssdk.ToString(instance.InstanceId),
+		availabilityZone:   awssdk.ToString(nodeSubnet.AvailabilityZone),
+		nodeIP:             awssdk.ToString(instance.PrivateIpAddress),
+		networkInterfaceID: awssdk.ToString(eni.NetworkInterfaceId),
+		podCIDR:            netip.MustParsePrefix("192.168.24.0/21"),
+	}
+}
+
+// createNode registers the simulated node, backed by the instance seeded into the fake EC2.
+func createNode(ctx context.Context, k8sClient client.Client, node simulatedNode) error {
+	k8sNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: node.name,
+			Labels: map[string]string{
+				corev1.LabelTopologyZone:   node.availabilityZone,
+				corev1.LabelTopologyRegion: defaultRegion,
+			},
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: fmt.Sprintf("aws:///%s/%s", node.availabilityZone, node.instanceID),
+		},
+	}
+	if err := k8sClient.Create(ctx, k8sNode); err != nil {
+		return err
+	}
+	k8sNodeOld := k8sNode.DeepCopy()
+	k8sNode.Status = corev1.NodeStatus{
+		Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: node.nodeIP}},
+		Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.Now(), LastTransitionTime: metav1.Now()}},
+	}
+	return k8sClient.Status().Patch(ctx, k8sNode, client.MergeFrom(k8sNodeOld))
+}
+
+func createGatewayClasses(ctx context.Context, k8sClient client.Client, opts Options) error {
+	gatewayClasses := map[string]string{
+		opts.ALBGatewayClassName: gateway_constants.ALBGatewayController,
+		opts.NLBGatewayClassName: gateway_constants.NLBGatewayController,
+	}
+	for name, controllerName := range gatewayClasses {
+		if name == "" {
+			continue
+		}
+		gwClass := &gwv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gwv1.GatewayClassSpec{ControllerName: gwv1.GatewayController(controllerName)},
+		}
+		if err := k8sClient.Create(ctx, gwClass); err != nil {
+			return err
+		}
+	}
+	return nil
+}
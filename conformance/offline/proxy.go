@@ -0,0 +1,275 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	"sigs.k8s.io/gateway-api/conformance/utils/roundtripper"
+)
+
+// PodResolver returns the pod with IP address ip.
+type PodResolver func(ip string) (types.NamespacedName, bool)
+
+type proxyTargetContextKey struct{}
+
+// proxyTarget is the load balancer address a connection was dialed to.
+type proxyTarget struct {
+	dnsName string
+	port    int32
+}
+
+// Proxy stands in for the load balancers of the fake ELBV2.
+// Connections dialed to a load balancer DNS name are served in-process: each request is routed with the rules of the listener,
+// and forwarded requests are answered on behalf of the target pod the same way the conformance echo servers do.
+// Connections speak HTTP/1.1, or HTTP/2 with prior knowledge as used by gRPC clients.
+type Proxy struct {
+	elbv2       *fake.ELBV2
+	podResolver PodResolver
+	listener    *pipeListener
+	server      *http.Server
+	grpcServer  *grpc.Server
+}
+
+// NewProxy constructs a new Proxy for the load balancers of elbv2.
+func NewProxy(elbv2 *fake.ELBV2, podResolver PodResolver) *Proxy {
+	p := &Proxy{
+		elbv2:       elbv2,
+		podResolver: podResolver,
+		listener:    newPipeListener(),
+		grpcServer:  newGRPCEchoServer(),
+	}
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	p.server = &http.Server{
+		Protocols: protocols,
+		Handler:   p,
+		ConnContext: func(ctx context.Context, conn net.Conn) context.Context {
+			if targetConn, ok := conn.(*proxyTargetConn); ok {
+				return context.WithValue(ctx, proxyTargetContextKey{}, targetConn.target)
+			}
+			return ctx
+		},
+	}
+	return p
+}
+
+// Start serves the connections dialed with DialContext until Close is called.
+func (p *Proxy) Start() {
+	go func() {
+		_ = p.server.Serve(p.listener)
+	}()
+}
+
+// Close stops serving connections.
+func (p *Proxy) Close() error {
+	return p.server.Close()
+}
+
+// DialContext connects to a listener of the load balancer at addr, it can be used as the dialer of an HTTP transport.
+// Dialing a port without listener fails with connection refused, like an actual load balancer.
+func (p *Proxy) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port in address %s", addr)
+	}
+	if _, exists := p.elbv2.ListenerPorts(host)[int32(port)]; !exists {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+	}
+	clientConn, serverConn := net.Pipe()
+	targetConn := &proxyTargetConn{
+		Conn:   serverConn,
+		target: proxyTarget{dnsName: host, port: int32(port)},
+	}
+	if err := p.listener.push(ctx, targetConn); err != nil {
+		clientConn.Close()
+		serverConn.Close()
+		return nil, err
+	}
+	return clientConn, nil
+}
+
+// RoundTripper returns a conformance RoundTripper that sends requests through the proxy.
+func (p *Proxy) RoundTripper(rt *roundtripper.DefaultRoundTripper) *roundtripper.DefaultRoundTripper {
+	rt.CustomDialContext = p.DialContext
+	return rt
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	target, ok := req.Context().Value(proxyTargetContextKey{}).(proxyTarget)
+	if !ok {
+		http.Error(w, "connection was not dialed to a load balancer", http.StatusBadGateway)
+		return
+	}
+	result, err := p.elbv2.RouteHTTPRequest(target.dnsName, target.port, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	switch result.ActionType {
+	case elbv2types.ActionTypeEnumForward:
+		p.serveForward(w, req, target, result)
+	case elbv2types.ActionTypeEnumRedirect:
+		http.Redirect(w, req, result.Location, result.StatusCode)
+	case elbv2types.ActionTypeEnumFixedResponse:
+		if result.ContentType != "" {
+			w.Header().Set("Content-Type", result.ContentType)
+		}
+		w.WriteHeader(result.StatusCode)
+		_, _ = w.Write([]byte(result.Body))
+	default:
+		http.Error(w, "unsupported action "+string(result.ActionType), http.StatusBadGateway)
+	}
+}
+
+// serveForward answers a forwarded request the way the echo server running in the target pod does,
+// with the request as the target receives it after the transforms of the rule and the X-Forwarded headers added by ALB.
+func (p *Proxy) serveForward(w http.ResponseWriter, req *http.Request, target proxyTarget, result fake.RouteResult) {
+	if result.Target == nil {
+		http.Error(w, "target group has no registered targets", http.StatusServiceUnavailable)
+		return
+	}
+	podKey, exists := p.podResolver(awssdk.ToString(result.Target.Id))
+	if !exists {
+		http.Error(w, "target is not a pod", http.StatusBadGateway)
+		return
+	}
+	if isGRPCRequest(req) {
+		p.grpcServer.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), podContextKey{}, podKey)))
+		return
+	}
+
+	headers := req.Header.Clone()
+	remoteHost, _, _ := net.SplitHostPort(req.RemoteAddr)
+	if remoteHost == "" {
+		remoteHost = "127.0.0.1"
+	}
+	headers.Set("X-Forwarded-For", remoteHost)
+	headers.Set("X-Forwarded-Proto", "http")
+	headers.Set("X-Forwarded-Port", strconv.Itoa(int(target.port)))
+	host := req.Host
+	if result.Host != stripPort(req.Host) {
+		host = result.Host
+	}
+	path := result.Path
+	if result.RawQuery != "" {
+		path = path + "?" + result.RawQuery
+	}
+	captured := roundtripper.CapturedRequest{
+		Path:      path,
+		Host:      host,
+		Method:    req.Method,
+		Protocol:  req.Proto,
+		Headers:   headers,
+		HTTPPort:  strconv.Itoa(int(awssdk.ToInt32(result.Target.Port))),
+		Namespace: podKey.Namespace,
+		Pod:       podKey.Name,
+	}
+	body, err := json.MarshalIndent(captured, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeEchoResponseHeaders(w, req.Header)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, _ = w.Write(body)
+}
+
+// writeEchoResponseHeaders sets the response headers requested with X-Echo-Set-Header, as the echo server does.
+func writeEchoResponseHeaders(w http.ResponseWriter, headers http.Header) {
+	for _, headerKVList := range headers["X-Echo-Set-Header"] {
+		for _, headerKV := range strings.Split(headerKVList, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(headerKV), ":")
+			if len(w.Header()[name]) == 0 {
+				w.Header()[name] = []string{value}
+			} else {
+				w.Header()[name][0] += "," + strings.TrimSpace(value)
+			}
+		}
+	}
+}
+
+func stripPort(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return hostport
+}
+
+// proxyTargetConn is the server side of a connection dialed to a load balancer.
+type proxyTargetConn struct {
+	net.Conn
+	target proxyTarget
+}
+
+// pipeListener is a net.Listener accepting in-memory connections.
+type pipeListener struct {
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *pipeListener) push(ctx context.Context, conn net.Conn) error {
+	select {
+	case l.conns <- conn:
+		return nil
+	case <-l.closed:
+		return net.ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string {
+	return "pipe"
+}
+
+func (pipeAddr) String() string {
+	return "pipe"
+}
@@ -0,0 +1,162 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	pb "sigs.k8s.io/gateway-api/conformance/echo-basic/grpcechoserver"
+	grpcutils "sigs.k8s.io/gateway-api/conformance/utils/grpc"
+)
+
+type podContextKey struct{}
+
+// grpcEchoServer answers RPCs on behalf of the target pod the same way as the gRPC conformance echo server.
+type grpcEchoServer struct {
+	pb.UnimplementedGrpcEchoServer
+}
+
+func (s *grpcEchoServer) Echo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	return s.doEcho(ctx, in)
+}
+
+func (s *grpcEchoServer) EchoTwo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	return s.doEcho(ctx, in)
+}
+
+func (s *grpcEchoServer) doEcho(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to retrieve metadata from incoming request")
+	}
+	podKey, _ := ctx.Value(podContextKey{}).(types.NamespacedName)
+	fullMethod, _ := grpc.Method(ctx)
+	assertions := &pb.Assertions{
+		FullyQualifiedMethod: fullMethod,
+		Context: &pb.Context{
+			Namespace: podKey.Namespace,
+			Pod:       podKey.Name,
+		},
+	}
+	for key, values := range md {
+		for _, value := range values {
+			if key == ":authority" {
+				assertions.Authority = value
+			}
+			assertions.Headers = append(assertions.Headers, &pb.Header{Key: key, Value: value})
+		}
+	}
+	return &pb.EchoResponse{Assertions: assertions, Request: in}, nil
+}
+
+func newGRPCEchoServer() *grpc.Server {
+	server := grpc.NewServer()
+	pb.RegisterGrpcEchoServer(server, &grpcEchoServer{})
+	return server
+}
+
+func isGRPCRequest(req *http.Request) bool {
+	return req.ProtoMajor == 2 && strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+}
+
+var _ grpcutils.Client = &grpcClient{}
+
+// grpcClient is a conformance gRPC client that sends RPCs through the proxy.
+// Unlike the upstream client, it keeps a connection per address and authority, since load balancer DNS names can't be resolved.
+type grpcClient struct {
+	proxy *Proxy
+
+	mutex sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// GRPCClient returns a conformance gRPC client that sends RPCs through the proxy.
+func (p *Proxy) GRPCClient() grpcutils.Client {
+	return &grpcClient{
+		proxy: p,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+func (c *grpcClient) SendRPC(t *testing.T, address string, expected grpcutils.ExpectedResponse, timeout time.Duration) (*grpcutils.Response, error) {
+	t.Helper()
+	authority := ""
+	if expected.RequestMetadata != nil {
+		authority = expected.RequestMetadata.Authority
+	}
+	conn, err := c.connect(address, authority)
+	if err != nil {
+		return &grpcutils.Response{}, err
+	}
+
+	resp := &grpcutils.Response{
+		Headers:  &metadata.MD{},
+		Trailers: &metadata.MD{},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if expected.RequestMetadata != nil && len(expected.RequestMetadata.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(expected.RequestMetadata.Metadata))
+	}
+
+	stub := pb.NewGrpcEchoClient(conn)
+	switch {
+	case expected.EchoRequest != nil:
+		resp.Response, err = stub.Echo(ctx, expected.EchoRequest, grpc.Header(resp.Headers), grpc.Trailer(resp.Trailers))
+	case expected.EchoTwoRequest != nil:
+		resp.Response, err = stub.EchoTwo(ctx, expected.EchoTwoRequest, grpc.Header(resp.Headers), grpc.Trailer(resp.Trailers))
+	case expected.EchoThreeRequest != nil:
+		resp.Response, err = stub.EchoThree(ctx, expected.EchoThreeRequest, grpc.Header(resp.Headers), grpc.Trailer(resp.Trailers))
+	default:
+		return resp, fmt.Errorf("no request specified")
+	}
+	resp.Code = codes.OK
+	if err != nil {
+		resp.Code = status.Code(err)
+	}
+	return resp, nil
+}
+
+func (c *grpcClient) connect(address string, authority string) (*grpc.ClientConn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	connKey := address + "/" + authority
+	if conn, exists := c.conns[connKey]; exists {
+		return conn, nil
+	}
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return c.proxy.DialContext(ctx, "tcp", addr)
+		}),
+	}
+	if authority != "" {
+		dialOpts = append(dialOpts, grpc.WithAuthority(authority))
+	}
+	conn, err := grpc.NewClient("passthrough:///"+address, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[connKey] = conn
+	return conn, nil
+}
+
+func (c *grpcClient) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for connKey, conn := range c.conns {
+		conn.Close()
+		delete(c.conns, connKey)
+	}
+}
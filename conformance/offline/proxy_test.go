@@ -0,0 +1,217 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	pb "sigs.k8s.io/gateway-api/conformance/echo-basic/grpcechoserver"
+	grpcutils "sigs.k8s.io/gateway-api/conformance/utils/grpc"
+	"sigs.k8s.io/gateway-api/conformance/utils/roundtripper"
+)
+
+// newTestProxy creates an ALB with a listener on port 80, forwarding /echo and gRPC requests to a pod,
+// redirecting /redirect and answering 404 otherwise.
+func newTestProxy(t *testing.T) (*Proxy, string) {
+	ctx := context.Background()
+	cloud := fake.NewCloud(defaultRegion, defaultAccountID, defaultVPCID)
+	node := seedNetwork(cloud.FakeEC2(), defaultClusterName)
+	podKey := types.NamespacedName{Namespace: "gateway-conformance-infra", Name: "infra-backend-v1-abcde"}
+	require.NoError(t, cloud.FakeEC2().AssignPrivateIPAddresses(node.networkInterfaceID, "192.168.24.1"))
+
+	subnets, err := cloud.EC2().DescribeSubnetsAsList(ctx, &ec2sdk.DescribeSubnetsInput{})
+	require.NoError(t, err)
+	subnetIDByAZ := make(map[string]string)
+	for _, subnet := range subnets {
+		subnetIDByAZ[awssdk.ToString(subnet.AvailabilityZone)] = awssdk.ToString(subnet.SubnetId)
+	}
+	var subnetIDs []string
+	for _, subnetID := range subnetIDByAZ {
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	lbOutput, err := cloud.ELBV2().CreateLoadBalancerWithContext(ctx, &elbv2sdk.CreateLoadBalancerInput{
+		Name:    awssdk.String("k8s-infra-gw"),
+		Type:    elbv2types.LoadBalancerTypeEnumApplication,
+		Subnets: subnetIDs,
+	})
+	require.NoError(t, err)
+	lb := lbOutput.LoadBalancers[0]
+	tgOutput, err := cloud.ELBV2().CreateTargetGroupWithContext(ctx, &elbv2sdk.CreateTargetGroupInput{
+		Name:       awssdk.String("k8s-infra-backend"),
+		Protocol:   elbv2types.ProtocolEnumHttp,
+		Port:       awssdk.Int32(3000),
+		TargetType: elbv2types.TargetTypeEnumIp,
+		VpcId:      awssdk.String(defaultVPCID),
+	})
+	require.NoError(t, err)
+	tgARN := tgOutput.TargetGroups[0].TargetGroupArn
+	_, err = cloud.ELBV2().RegisterTargetsWithContext(ctx, &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: tgARN,
+		Targets:        []elbv2types.TargetDescription{{Id: awssdk.String("192.168.24.1"), Port: awssdk.Int32(3000)}},
+	})
+	require.NoError(t, err)
+
+	lsOutput, err := cloud.ELBV2().CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Protocol:        elbv2types.ProtocolEnumHttp,
+		Port:            awssdk.Int32(80),
+		DefaultActions: []elbv2types.Action{
+			{
+				Type:                elbv2types.ActionTypeEnumFixedResponse,
+				FixedResponseConfig: &elbv2types.FixedResponseActionConfig{StatusCode: awssdk.String("404"), ContentType: awssdk.String("text/plain")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	rules := []struct {
+		pathPatterns []string
+		action       elbv2types.Action
+	}{
+		{
+			pathPatterns: []string{"/echo", "/gateway_api_conformance.echo_basic.grpcecho.GrpcEcho/*"},
+			action:       elbv2types.Action{Type: elbv2types.ActionTypeEnumForward, TargetGroupArn: tgARN},
+		},
+		{
+			pathPatterns: []string{"/redirect"},
+			action: elbv2types.Action{
+				Type:           elbv2types.ActionTypeEnumRedirect,
+				RedirectConfig: &elbv2types.RedirectActionConfig{Path: awssdk.String("/echo"), StatusCode: elbv2types.RedirectActionStatusCodeEnumHttp301},
+			},
+		},
+	}
+	for i, rule := range rules {
+		_, err = cloud.ELBV2().CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+			ListenerArn: lsOutput.Listeners[0].ListenerArn,
+			Priority:    awssdk.Int32(int32(i + 1)),
+			Conditions: []elbv2types.RuleCondition{
+				{Field: awssdk.String("path-pattern"), PathPatternConfig: &elbv2types.PathPatternConditionConfig{Values: rule.pathPatterns}},
+			},
+			Actions: []elbv2types.Action{rule.action},
+		})
+		require.NoError(t, err)
+	}
+
+	proxy := NewProxy(cloud.FakeELBV2(), func(ip string) (types.NamespacedName, bool) {
+		return podKey, ip == "192.168.24.1"
+	})
+	proxy.Start()
+	t.Cleanup(func() {
+		_ = proxy.Close()
+	})
+	return proxy, awssdk.ToString(lb.DNSName)
+}
+
+func TestProxy_RoundTripper(t *testing.T) {
+	proxy, dnsName := newTestProxy(t)
+	rt := proxy.RoundTripper(&roundtripper.DefaultRoundTripper{})
+	rt.TimeoutConfig.RequestTimeout = 10 * time.Second
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantRedirect *roundtripper.RedirectRequest
+		wantRequest  *roundtripper.CapturedRequest
+	}{
+		{
+			name:       "forwarded to the target pod",
+			path:       "/echo?x=1",
+			wantStatus: http.StatusOK,
+			wantRequest: &roundtripper.CapturedRequest{
+				Path:      "/echo?x=1",
+				Host:      "example.com",
+				Method:    http.MethodGet,
+				Protocol:  "HTTP/1.1",
+				HTTPPort:  "3000",
+				Namespace: "gateway-conformance-infra",
+				Pod:       "infra-backend-v1-abcde",
+			},
+		},
+		{
+			name:       "redirected",
+			path:       "/redirect",
+			wantStatus: http.StatusMovedPermanently,
+			wantRedirect: &roundtripper.RedirectRequest{
+				Scheme: "http",
+				Host:   "example.com",
+				Path:   "/echo",
+			},
+		},
+		{
+			name:       "answered by the default action",
+			path:       "/missing",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, rawQuery, _ := strings.Cut(tt.path, "?")
+			cReq, cResp, err := rt.CaptureRoundTrip(roundtripper.Request{
+				T:                t,
+				URL:              url.URL{Scheme: "http", Host: dnsName, Path: path, RawQuery: rawQuery},
+				Host:             "example.com",
+				UnfollowRedirect: true,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, cResp.StatusCode)
+			assert.Equal(t, tt.wantRedirect, cResp.RedirectRequest)
+			if tt.wantRequest != nil {
+				headers := cReq.Headers
+				cReq.Headers = nil
+				assert.Equal(t, tt.wantRequest, cReq)
+				assert.Equal(t, []string{"80"}, headers["X-Forwarded-Port"])
+			}
+		})
+	}
+}
+
+func TestProxy_DialContext(t *testing.T) {
+	proxy, dnsName := newTestProxy(t)
+	client := &http.Client{Transport: &http.Transport{DialContext: proxy.DialContext}}
+
+	_, err := client.Get("http://" + dnsName + ":8080/echo")
+	assert.ErrorContains(t, err, "connection refused")
+
+	resp, err := client.Get("http://" + dnsName + "/echo")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var captured roundtripper.CapturedRequest
+	assert.NoError(t, json.Unmarshal(body, &captured))
+	assert.Equal(t, "infra-backend-v1-abcde", captured.Pod)
+}
+
+func TestProxy_GRPCClient(t *testing.T) {
+	proxy, dnsName := newTestProxy(t)
+	grpcClient := proxy.GRPCClient()
+	defer grpcClient.Close()
+
+	resp, err := grpcClient.SendRPC(t, dnsName+":80", grpcutils.ExpectedResponse{
+		EchoRequest:     &pb.EchoRequest{},
+		RequestMetadata: &grpcutils.RequestMetadata{Authority: "grpc.example.com"},
+	}, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, codes.OK, resp.Code)
+	assert.Equal(t, "/gateway_api_conformance.echo_basic.grpcecho.GrpcEcho/Echo", resp.Response.GetAssertions().GetFullyQualifiedMethod())
+	assert.Equal(t, "grpc.example.com", resp.Response.GetAssertions().GetAuthority())
+	assert.Equal(t, "infra-backend-v1-abcde", resp.Response.GetAssertions().GetContext().GetPod())
+
+	resp, err = grpcClient.SendRPC(t, dnsName+":80", grpcutils.ExpectedResponse{EchoThreeRequest: &pb.EchoRequest{}}, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, codes.Unimplemented, resp.Code)
+}
@@ -0,0 +1,405 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// labelDeploymentName is the label of the pods created for a Deployment.
+	labelDeploymentName = "offline.conformance.k8s.aws/deployment"
+	// endpointSliceManagedBy identifies the EndpointSlices maintained for Services.
+	endpointSliceManagedBy = "offline.conformance.k8s.aws"
+)
+
+// workloadSimulator stands in for kube-controller-manager and kubelet, which envtest doesn't run.
+// Pods are created for Deployments and become ready right away, with an IP address assigned to the network interface of the node,
+// and EndpointSlices are maintained for Services with a selector.
+type workloadSimulator struct {
+	k8sClient client.Client
+	ec2       *fake.EC2
+	node      simulatedNode
+	logger    logr.Logger
+
+	mutex    sync.Mutex
+	podIPs   *ipAllocator
+	podsByIP map[string]types.NamespacedName
+}
+
+// simulatedNode is the node pods are scheduled to, backed by an instance of the fake EC2.
+type simulatedNode struct {
+	name               string
+	instanceID         string
+	availabilityZone   string
+	nodeIP             string
+	networkInterfaceID string
+	podCIDR            netip.Prefix
+}
+
+func newWorkloadSimulator(k8sClient client.Client, ec2 *fake.EC2, node simulatedNode, logger logr.Logger) *workloadSimulator {
+	return &workloadSimulator{
+		k8sClient: k8sClient,
+		ec2:       ec2,
+		node:      node,
+		logger:    logger,
+		podIPs:    newIPAllocator(node.podCIDR),
+		podsByIP:  make(map[string]types.NamespacedName),
+	}
+}
+
+// LookupPodByIP returns the pod with IP address ip.
+func (s *workloadSimulator) LookupPodByIP(ip string) (types.NamespacedName, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	podKey, exists := s.podsByIP[ip]
+	return podKey, exists
+}
+
+func (s *workloadSimulator) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named("offline-deployment").
+		For(&appsv1.Deployment{}).
+		Complete(reconcile.Func(s.reconcileDeployment)); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("offline-endpointslice").
+		For(&corev1.Service{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(s.mapPodToServices)).
+		Complete(reconcile.Func(s.reconcileService))
+}
+
+func (s *workloadSimulator) reconcileDeployment(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	deployment := &appsv1.Deployment{}
+	if err := s.k8sClient.Get(ctx, req.NamespacedName, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, s.scalePods(ctx, req.Namespace, req.Name, nil, 0)
+		}
+		return reconcile.Result{}, err
+	}
+	if !deployment.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, s.scalePods(ctx, req.Namespace, req.Name, nil, 0)
+	}
+	replicas := int(awssdk.ToInt32(deployment.Spec.Replicas))
+	if deployment.Spec.Replicas == nil {
+		replicas = 1
+	}
+	if err := s.scalePods(ctx, deployment.Namespace, deployment.Name, deployment, replicas); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	deploymentOld := deployment.DeepCopy()
+	deployment.Status.ObservedGeneration = deployment.Generation
+	deployment.Status.Replicas = int32(replicas)
+	deployment.Status.UpdatedReplicas = int32(replicas)
+	deployment.Status.ReadyReplicas = int32(replicas)
+	deployment.Status.AvailableReplicas = int32(replicas)
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:               appsv1.DeploymentAvailable,
+			Status:             corev1.ConditionTrue,
+			Reason:             "MinimumReplicasAvailable",
+			LastUpdateTime:     metav1.Now(),
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+	return reconcile.Result{}, s.k8sClient.Status().Patch(ctx, deployment, client.MergeFrom(deploymentOld))
+}
+
+// scalePods creates or deletes the pods of a Deployment until there are replicas of them.
+func (s *workloadSimulator) scalePods(ctx context.Context, namespace string, deploymentName string, deployment *appsv1.Deployment, replicas int) error {
+	podList := &corev1.PodList{}
+	if err := s.k8sClient.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabels{labelDeploymentName: deploymentName}); err != nil {
+		return err
+	}
+	for i := replicas; i < len(podList.Items); i++ {
+		if err := s.deletePod(ctx, &podList.Items[i]); err != nil {
+			return err
+		}
+	}
+	for i := len(podList.Items); i < replicas; i++ {
+		if err := s.createPod(ctx, deployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *workloadSimulator) createPod(ctx context.Context, deployment *appsv1.Deployment) error {
+	template := deployment.Spec.Template.DeepCopy()
+	pod := &corev1.Pod{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	pod.Namespace = deployment.Namespace
+	pod.Name = fmt.Sprintf("%s-%s", deployment.Name, rand.String(10))
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[labelDeploymentName] = deployment.Name
+	pod.Spec.NodeName = s.node.name
+	if err := controllerutil.SetControllerReference(deployment, pod, s.k8sClient.Scheme()); err != nil {
+		return err
+	}
+	if err := s.k8sClient.Create(ctx, pod); err != nil {
+		return err
+	}
+
+	podIP, err := s.assignPodIP(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	if err != nil {
+		return err
+	}
+	podOld := pod.DeepCopy()
+	pod.Status = buildReadyPodStatus(pod, podIP)
+	return s.k8sClient.Status().Patch(ctx, pod, client.MergeFrom(podOld))
+}
+
+func (s *workloadSimulator) deletePod(ctx context.Context, pod *corev1.Pod) error {
+	if err := s.k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0)); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if pod.Status.PodIP == "" {
+		return nil
+	}
+	return s.releasePodIP(pod.Status.PodIP)
+}
+
+func (s *workloadSimulator) assignPodIP(podKey types.NamespacedName) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ip, err := s.podIPs.allocate()
+	if err != nil {
+		return "", err
+	}
+	if err := s.ec2.AssignPrivateIPAddresses(s.node.networkInterfaceID, ip); err != nil {
+		s.podIPs.release(ip)
+		return "", err
+	}
+	s.podsByIP[ip] = podKey
+	return ip, nil
+}
+
+func (s *workloadSimulator) releasePodIP(ip string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.podsByIP, ip)
+	s.podIPs.release(ip)
+	return s.ec2.UnassignPrivateIPAddresses(s.node.networkInterfaceID, ip)
+}
+
+// mapPodToServices enqueues the Services in the namespace of a pod, any of them might select it.
+func (s *workloadSimulator) mapPodToServices(ctx context.Context, obj client.Object) []reconcile.Request {
+	svcList := &corev1.ServiceList{}
+	if err := s.k8sClient.List(ctx, svcList, client.InNamespace(obj.GetNamespace())); err != nil {
+		s.logger.Error(err, "failed to list services", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, svc := range svcList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}})
+	}
+	return requests
+}
+
+func (s *workloadSimulator) reconcileService(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	svc := &corev1.Service{}
+	if err := s.k8sClient.Get(ctx, req.NamespacedName, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, s.deleteEndpointSlice(ctx, req.NamespacedName)
+		}
+		return reconcile.Result{}, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return reconcile.Result{}, nil
+	}
+	podList := &corev1.PodList{}
+	if err := s.k8sClient.List(ctx, podList, client.InNamespace(svc.Namespace), client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(svc.Spec.Selector)}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	eps := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: svc.Namespace,
+			Name:      buildEndpointSliceName(svc.Name),
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, s.k8sClient, eps, func() error {
+		eps.Labels = map[string]string{
+			discoveryv1.LabelServiceName: svc.Name,
+			discoveryv1.LabelManagedBy:   endpointSliceManagedBy,
+		}
+		eps.AddressType = discoveryv1.AddressTypeIPv4
+		eps.Endpoints = buildEndpoints(podList.Items)
+		eps.Ports = buildEndpointPorts(svc, podList.Items)
+		return controllerutil.SetControllerReference(svc, eps, s.k8sClient.Scheme())
+	})
+	return reconcile.Result{}, err
+}
+
+func (s *workloadSimulator) deleteEndpointSlice(ctx context.Context, svcKey types.NamespacedName) error {
+	eps := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: svcKey.Namespace,
+			Name:      buildEndpointSliceName(svcKey.Name),
+		},
+	}
+	return client.IgnoreNotFound(s.k8sClient.Delete(ctx, eps))
+}
+
+func buildEndpointSliceName(svcName string) string {
+	return fmt.Sprintf("%s-offline", svcName)
+}
+
+func buildEndpoints(pods []corev1.Pod) []discoveryv1.Endpoint {
+	var endpoints []discoveryv1.Endpoint
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.PodIP == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		endpoints = append(endpoints, discoveryv1.Endpoint{
+			Addresses: []string{pod.Status.PodIP},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       awssdk.Bool(true),
+				Serving:     awssdk.Bool(true),
+				Terminating: awssdk.Bool(false),
+			},
+			NodeName: awssdk.String(pod.Spec.NodeName),
+			TargetRef: &corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+		})
+	}
+	return endpoints
+}
+
+// buildEndpointPorts resolves the targetPort of each Service port, named targetPorts are looked up in the containers of the first pod.
+func buildEndpointPorts(svc *corev1.Service, pods []corev1.Pod) []discoveryv1.EndpointPort {
+	var ports []discoveryv1.EndpointPort
+	for _, svcPort := range svc.Spec.Ports {
+		portNumber := svcPort.TargetPort.IntVal
+		if svcPort.TargetPort.Type == intstr.String {
+			portNumber = 0
+			if len(pods) != 0 {
+				portNumber = lookupContainerPort(&pods[0], svcPort.TargetPort.StrVal)
+			}
+		}
+		if portNumber == 0 {
+			portNumber = svcPort.Port
+		}
+		ports = append(ports, discoveryv1.EndpointPort{
+			Name:        awssdk.String(svcPort.Name),
+			Protocol:    &svcPort.Protocol,
+			Port:        awssdk.Int32(portNumber),
+			AppProtocol: svcPort.AppProtocol,
+		})
+	}
+	return ports
+}
+
+func lookupContainerPort(pod *corev1.Pod, portName string) int32 {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == portName {
+				return port.ContainerPort
+			}
+		}
+	}
+	return 0
+}
+
+func buildReadyPodStatus(pod *corev1.Pod, podIP string) corev1.PodStatus {
+	now := metav1.Now()
+	status := corev1.PodStatus{
+		Phase:     corev1.PodRunning,
+		PodIP:     podIP,
+		PodIPs:    []corev1.PodIP{{IP: podIP}},
+		StartTime: &now,
+	}
+	for _, conditionType := range []corev1.PodConditionType{corev1.PodScheduled, corev1.PodInitialized, corev1.ContainersReady, corev1.PodReady} {
+		status.Conditions = append(status.Conditions, corev1.PodCondition{
+			Type:               conditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+		})
+	}
+	for _, readinessGate := range pod.Spec.ReadinessGates {
+		status.Conditions = append(status.Conditions, corev1.PodCondition{
+			Type:               readinessGate.ConditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+		})
+	}
+	for _, container := range pod.Spec.Containers {
+		status.ContainerStatuses = append(status.ContainerStatuses, corev1.ContainerStatus{
+			Name:    container.Name,
+			Image:   container.Image,
+			Ready:   true,
+			Started: awssdk.Bool(true),
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{StartedAt: now},
+			},
+		})
+	}
+	return status
+}
+
+// ipAllocator hands out the addresses of a CIDR, skipping the network address.
+type ipAllocator struct {
+	prefix    netip.Prefix
+	next      netip.Addr
+	available []netip.Addr
+}
+
+func newIPAllocator(prefix netip.Prefix) *ipAllocator {
+	return &ipAllocator{
+		prefix: prefix,
+		next:   prefix.Masked().Addr().Next(),
+	}
+}
+
+func (a *ipAllocator) allocate() (string, error) {
+	if len(a.available) != 0 {
+		ip := a.available[0]
+		a.available = a.available[1:]
+		return ip.String(), nil
+	}
+	if !a.prefix.Contains(a.next) {
+		return "", errors.Errorf("no IP address available in %s", a.prefix)
+	}
+	ip := a.next
+	a.next = a.next.Next()
+	return ip.String(), nil
+}
+
+func (a *ipAllocator) release(ipStr string) {
+	ip, err := netip.ParseAddr(ipStr)
+	if err != nil || !a.prefix.Contains(ip) {
+		return
+	}
+	a.available = append(a.available, ip)
+}
@@ -325,7 +325,7 @@ func (r *globalAcceleratorReconciler) reconcileGlobalAcceleratorResources(ctx co
 		r.eventRecorder.Event(ga, corev1.EventTypeWarning, k8s.GlobalAcceleratorEventReasonFailedDeploy, fmt.Sprintf("Failed to deploy stack due to %v", err))
 		r.logger.Error(err, fmt.Sprintf("Failed to deploy stack for: %v", k8s.NamespacedName(ga)))
 		// Update status to indicate deployment failure
-		if statusErr := r.statusUpdater.UpdateStatusFailure(ctx, ga, agadeploy.DeploymentFailed, fmt.Sprintf("Failed to deploy stack: %v", err)); statusErr != nil {
+		if statusErr := r.statusUpdater.UpdateStatusFailure(ctx, ga, deploymentFailureReason(err), fmt.Sprintf("Failed to deploy stack: %v", err)); statusErr != nil {
 			r.logger.Error(statusErr, "Failed to update GlobalAccelerator status after deployment failure")
 		}
 
@@ -372,6 +372,23 @@ func (r *globalAcceleratorReconciler) reconcileGlobalAcceleratorResources(ctx co
 	return nil
 }
 
+// deploymentFailureReason returns the status reason of a stack deployment failure.
+func deploymentFailureReason(err error) string {
+	var errWithMetrics *ctrlerrors.ErrorWithMetrics
+	if errors.As(err, &errWithMetrics) {
+		err = errWithMetrics.Err
+	}
+	var replacementNotConfirmedErr *agadeploy.ReplacementNotConfirmedError
+	if errors.As(err, &replacementNotConfirmedErr) {
+		return agadeploy.ReplacementNotConfirmed
+	}
+	var invalidBYOIPAddressesErr *agadeploy.InvalidBYOIPAddressesError
+	if errors.As(err, &invalidBYOIPAddressesErr) {
+		return agadeploy.InvalidBYOIPAddresses
+	}
+	return agadeploy.DeploymentFailed
+}
+
 func (r *globalAcceleratorReconciler) cleanupGlobalAcceleratorResources(ctx context.Context, ga *agaapi.GlobalAccelerator) error {
 	r.logger.Info("Cleaning up GlobalAccelerator resources", "globalAccelerator", k8s.NamespacedName(ga))
 
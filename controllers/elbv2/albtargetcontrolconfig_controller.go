@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/albtargetcontrol"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	albTargetControlConfigControllerName = "albTargetControlConfig"
+
+	// albTargetControlConfigStatusRefreshInterval is the interval to refresh the status, as pod changes aren't watched.
+	albTargetControlConfigStatusRefreshInterval = 1 * time.Minute
+	// albTargetControlConfigMaxStatusPodNames is the maximum number of pod names listed in the status.
+	albTargetControlConfigMaxStatusPodNames = 100
+)
+
+// NewALBTargetControlConfigReconciler constructs new albTargetControlConfigReconciler
+func NewALBTargetControlConfigReconciler(k8sClient client.Client, apiReader client.Reader, podInfoRepo k8s.PodInfoRepo,
+	eventRecorder record.EventRecorder, metricsCollector lbcmetrics.MetricCollector, logger logr.Logger) *albTargetControlConfigReconciler {
+	return &albTargetControlConfigReconciler{
+		k8sClient:        k8sClient,
+		apiReader:        apiReader,
+		podInfoRepo:      podInfoRepo,
+		eventRecorder:    eventRecorder,
+		metricsCollector: metricsCollector,
+		logger:           logger,
+	}
+}
+
+// albTargetControlConfigReconciler reconciles an ALBTargetControlConfig object.
+// It reports the pods running an outdated or failing agent, and restarts the workloads of outdated pods when RolloutOnUpdate is set.
+type albTargetControlConfigReconciler struct {
+	k8sClient        client.Client
+	apiReader        client.Reader
+	podInfoRepo      k8s.PodInfoRepo
+	eventRecorder    record.EventRecorder
+	metricsCollector lbcmetrics.MetricCollector
+	logger           logr.Logger
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=albtargetcontrolconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=albtargetcontrolconfigs/status,verbs=update;patch
+
+// The permissions to get replicasets, and to get and patch deployments, statefulsets and daemonsets, are only needed to restart
+// workloads when RolloutOnUpdate is set. They aren't granted by default, see the clusterWorkloadPermissions helm value.
+
+func (r *albTargetControlConfigReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	r.logger.V(1).Info("Reconcile request", "name", req.NamespacedName)
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+}
+
+func (r *albTargetControlConfigReconciler) reconcile(ctx context.Context, req reconcile.Request) error {
+	// agents are only injected from the ALBTargetControlConfig with the well-known name.
+	if req.Name != albtargetcontrol.ConfigName {
+		return nil
+	}
+	cfg := &elbv2api.ALBTargetControlConfig{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.metricsCollector.DeleteALBTargetControlAgentPods(req.Namespace, req.Name)
+		}
+		return client.IgnoreNotFound(err)
+	}
+	if !cfg.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	configHash := albtargetcontrol.ComputeConfigHash(cfg.Spec)
+	status, outdatedPods, err := r.buildStatus(ctx, cfg, configHash)
+	if err != nil {
+		return err
+	}
+	r.metricsCollector.ObserveALBTargetControlAgentPods(cfg.Namespace, cfg.Name,
+		int(status.InjectedPods), int(status.OutdatedPods), int(status.FailedPods))
+	if err := r.updateStatus(ctx, cfg, status); err != nil {
+		r.eventRecorder.Event(cfg, corev1.EventTypeWarning, k8s.ALBTargetControlConfigEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	if awssdk.ToBool(cfg.Spec.RolloutOnUpdate) && len(outdatedPods) != 0 {
+		if err := r.rolloutOutdatedPods(ctx, cfg, outdatedPods, configHash); err != nil {
+			if apierrors.IsForbidden(err) {
+				r.eventRecorder.Event(cfg, corev1.EventTypeWarning, k8s.ALBTargetControlConfigEventReasonFailedRollout,
+					fmt.Sprintf("Failed rollout as the controller isn't allowed to restart workloads, grant it with the clusterWorkloadPermissions.allowALBTargetControlRollout helm value: %v", err))
+				return ctrlerrors.NewRequeueNeededAfter("refresh agent pods status", albTargetControlConfigStatusRefreshInterval)
+			}
+			r.eventRecorder.Event(cfg, corev1.EventTypeWarning, k8s.ALBTargetControlConfigEventReasonFailedRollout, fmt.Sprintf("Failed rollout due to %v", err))
+			return err
+		}
+	}
+	return ctrlerrors.NewRequeueNeededAfter("refresh agent pods status", albTargetControlConfigStatusRefreshInterval)
+}
+
+// buildStatus computes the status of the pods whose agent was injected from the ALBTargetControlConfig,
+// and returns the outdated pods.
+func (r *albTargetControlConfigReconciler) buildStatus(ctx context.Context, cfg *elbv2api.ALBTargetControlConfig, configHash string) (elbv2api.ALBTargetControlConfigStatus, []types.NamespacedName, error) {
+	status := elbv2api.ALBTargetControlConfigStatus{
+		ObservedGeneration: awssdk.Int64(cfg.Generation),
+		ConfigHash:         configHash,
+	}
+	var outdatedPods []types.NamespacedName
+	podKeys := r.podInfoRepo.ListKeys(ctx)
+	sort.Slice(podKeys, func(i, j int) bool {
+		return podKeys[i].String() < podKeys[j].String()
+	})
+	for _, podKey := range podKeys {
+		pod, exists, err := r.podInfoRepo.Get(ctx, podKey)
+		if err != nil {
+			return elbv2api.ALBTargetControlConfigStatus{}, nil, err
+		}
+		if !exists || pod.TargetControlAgent == nil || pod.TargetControlAgent.ConfigNamespace != cfg.Namespace {
+			continue
+		}
+		status.InjectedPods++
+		if pod.TargetControlAgent.ConfigHash != configHash {
+			status.OutdatedPods++
+			outdatedPods = append(outdatedPods, podKey)
+			if len(status.OutdatedPodNames) < albTargetControlConfigMaxStatusPodNames {
+				status.OutdatedPodNames = append(status.OutdatedPodNames, podKey.String())
+			}
+		}
+		if pod.TargetControlAgent.Failed {
+			status.FailedPods++
+			if len(status.FailedPodNames) < albTargetControlConfigMaxStatusPodNames {
+				status.FailedPodNames = append(status.FailedPodNames, podKey.String())
+			}
+		}
+	}
+	return status, outdatedPods, nil
+}
+
+// rolloutOutdatedPods restarts the workloads of outdated pods by setting the config hash on their pod template,
+// the recreated pods are injected with the current configuration.
+func (r *albTargetControlConfigReconciler) rolloutOutdatedPods(ctx context.Context, cfg *elbv2api.ALBTargetControlConfig,
+	outdatedPods []types.NamespacedName, configHash string) error {
+	visitedWorkloads := sets.New[string]()
+	for _, podKey := range outdatedPods {
+		workload, err := r.findPodWorkload(ctx, podKey)
+		if err != nil {
+			return err
+		}
+		if workload == nil {
+			r.logger.V(1).Info("outdated pod isn't managed by a Deployment, StatefulSet or DaemonSet, skipping rollout", "pod", podKey)
+			continue
+		}
+		workloadRef := fmt.Sprintf("%s %s", workload.GetObjectKind().GroupVersionKind().Kind, k8s.NamespacedName(workload))
+		if visitedWorkloads.Has(workloadRef) {
+			continue
+		}
+		visitedWorkloads.Insert(workloadRef)
+		restarted, err := r.restartWorkload(ctx, workload, configHash)
+		if err != nil {
+			return err
+		}
+		if restarted {
+			r.logger.Info("restarted workload running outdated ALB target control agent", "workload", workloadRef, "configHash", configHash)
+			r.eventRecorder.Event(cfg, corev1.EventTypeNormal, k8s.ALBTargetControlConfigEventReasonRolloutTriggered, fmt.Sprintf("Restarted %s", workloadRef))
+		}
+	}
+	return nil
+}
+
+// findPodWorkload returns the Deployment, StatefulSet or DaemonSet controlling the pod, or nil if there is none.
+// The returned object has its kind set.
+func (r *albTargetControlConfigReconciler) findPodWorkload(ctx context.Context, podKey types.NamespacedName) (client.Object, error) {
+	pod := &corev1.Pod{}
+	if err := r.apiReader.Get(ctx, podKey, pod); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.APIVersion != appsv1.SchemeGroupVersion.String() {
+		return nil, nil
+	}
+	var workload client.Object
+	switch owner.Kind {
+	case "ReplicaSet":
+		rs := &appsv1.ReplicaSet{}
+		if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: podKey.Namespace, Name: owner.Name}, rs); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		rsOwner := metav1.GetControllerOf(rs)
+		if rsOwner == nil || rsOwner.APIVersion != appsv1.SchemeGroupVersion.String() || rsOwner.Kind != "Deployment" {
+			return nil, nil
+		}
+		owner = rsOwner
+		workload = &appsv1.Deployment{}
+	case "StatefulSet":
+		workload = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		workload = &appsv1.DaemonSet{}
+	default:
+		return nil, nil
+	}
+	if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: podKey.Namespace, Name: owner.Name}, workload); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	workload.GetObjectKind().SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind(owner.Kind))
+	return workload, nil
+}
+
+// restartWorkload sets the config hash on the pod template of the workload, returns whether the workload was restarted.
+func (r *albTargetControlConfigReconciler) restartWorkload(ctx context.Context, workload client.Object, configHash string) (bool, error) {
+	workloadOld := workload.DeepCopyObject().(client.Object)
+	var template *corev1.PodTemplateSpec
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		template = &w.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &w.Spec.Template
+	case *appsv1.DaemonSet:
+		template = &w.Spec.Template
+	default:
+		return false, nil
+	}
+	if template.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigHash] == configHash {
+		return false, nil
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigHash] = configHash
+	if err := r.k8sClient.Patch(ctx, workload, client.MergeFrom(workloadOld)); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return true, nil
+}
+
+func (r *albTargetControlConfigReconciler) updateStatus(ctx context.Context, cfg *elbv2api.ALBTargetControlConfig, status elbv2api.ALBTargetControlConfigStatus) error {
+	if equality.Semantic.DeepEqual(cfg.Status, status) {
+		return nil
+	}
+	cfgOld := cfg.DeepCopy()
+	cfg.Status = status
+	return r.k8sClient.Status().Patch(ctx, cfg, client.MergeFrom(cfgOld))
+}
+
+func (r *albTargetControlConfigReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.ALBTargetControlConfig{}).
+		Named(albTargetControlConfigControllerName).
+		Complete(r)
+}
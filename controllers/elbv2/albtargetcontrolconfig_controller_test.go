@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/albtargetcontrol"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+)
+
+func Test_albTargetControlConfigReconciler_reconcile(t *testing.T) {
+	cfgSpec := elbv2api.ALBTargetControlConfigSpec{
+		Image:              "public.ecr.aws/aws-elb/target-optimizer/target-control-agent:v2",
+		DataAddress:        "0.0.0.0:80",
+		ControlAddress:     "0.0.0.0:3000",
+		DestinationAddress: "127.0.0.1:8080",
+		MaxConcurrency:     1,
+	}
+	configHash := albtargetcontrol.ComputeConfigHash(cfgSpec)
+	isController := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "web-5d4f",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: &isController},
+			},
+		},
+	}
+	outdatedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "web-5d4f-abcde",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d4f", Controller: &isController},
+			},
+		},
+	}
+	standalonePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "standalone"},
+	}
+	podInfos := map[types.NamespacedName]k8s.PodInfo{
+		{Namespace: "app", Name: "web-5d4f-abcde"}: {
+			Key: types.NamespacedName{Namespace: "app", Name: "web-5d4f-abcde"},
+			TargetControlAgent: &k8s.PodTargetControlAgentInfo{
+				ConfigNamespace: "app",
+				ConfigHash:      "previous-hash",
+				Ready:           true,
+			},
+		},
+		{Namespace: "app", Name: "standalone"}: {
+			Key: types.NamespacedName{Namespace: "app", Name: "standalone"},
+			TargetControlAgent: &k8s.PodTargetControlAgentInfo{
+				ConfigNamespace: "app",
+				ConfigHash:      "previous-hash",
+				Failed:          true,
+			},
+		},
+		{Namespace: "app", Name: "current"}: {
+			Key: types.NamespacedName{Namespace: "app", Name: "current"},
+			TargetControlAgent: &k8s.PodTargetControlAgentInfo{
+				ConfigNamespace: "app",
+				ConfigHash:      configHash,
+				Ready:           true,
+			},
+		},
+		{Namespace: "other", Name: "other-config"}: {
+			Key: types.NamespacedName{Namespace: "other", Name: "other-config"},
+			TargetControlAgent: &k8s.PodTargetControlAgentInfo{
+				ConfigNamespace: "other",
+				ConfigHash:      "other-hash",
+			},
+		},
+		{Namespace: "app", Name: "no-agent"}: {
+			Key: types.NamespacedName{Namespace: "app", Name: "no-agent"},
+		},
+	}
+	wantStatus := elbv2api.ALBTargetControlConfigStatus{
+		ObservedGeneration: awssdk.Int64(0),
+		ConfigHash:         configHash,
+		InjectedPods:       3,
+		OutdatedPods:       2,
+		FailedPods:         1,
+		OutdatedPodNames:   []string{"app/standalone", "app/web-5d4f-abcde"},
+		FailedPodNames:     []string{"app/standalone"},
+	}
+
+	tests := []struct {
+		name                   string
+		rolloutOnUpdate        *bool
+		workloadsForbidden     bool
+		wantTemplateAnnotation string
+		wantEvents             []string
+	}{
+		{
+			name:                   "reports pods without rollout",
+			rolloutOnUpdate:        nil,
+			wantTemplateAnnotation: "",
+		},
+		{
+			name:                   "reports pods and restarts workloads of outdated pods",
+			rolloutOnUpdate:        awssdk.Bool(true),
+			wantTemplateAnnotation: configHash,
+			wantEvents:             []string{"Normal RolloutTriggered Restarted Deployment app/web"},
+		},
+		{
+			name:                   "reports pods and rollout failure without permissions on workloads",
+			rolloutOnUpdate:        awssdk.Bool(true),
+			workloadsForbidden:     true,
+			wantTemplateAnnotation: "",
+			wantEvents: []string{"Warning FailedRollout Failed rollout as the controller isn't allowed to restart workloads, " +
+				"grant it with the clusterWorkloadPermissions.allowALBTargetControlRollout helm value: " +
+				`replicasets.apps "web-5d4f" is forbidden: not allowed`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithStatusSubresource(&elbv2api.ALBTargetControlConfig{}).Build()
+			apiReader := client.Reader(k8sClient)
+			if tt.workloadsForbidden {
+				apiReader = interceptor.NewClient(k8sClient, interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if _, ok := obj.(*appsv1.ReplicaSet); ok {
+							return apierrors.NewForbidden(appsv1.Resource("replicasets"), key.Name, errors.New("not allowed"))
+						}
+						return c.Get(ctx, key, obj, opts...)
+					},
+				})
+			}
+
+			spec := *cfgSpec.DeepCopy()
+			spec.RolloutOnUpdate = tt.rolloutOnUpdate
+			cfg := &elbv2api.ALBTargetControlConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: albtargetcontrol.ConfigName},
+				Spec:       spec,
+			}
+			for _, obj := range []client.Object{cfg, deployment.DeepCopy(), replicaSet.DeepCopy(), outdatedPod.DeepCopy(), standalonePod.DeepCopy()} {
+				assert.NoError(t, k8sClient.Create(ctx, obj))
+			}
+
+			podInfoRepo := k8s.NewMockPodInfoRepo(ctrl)
+			var podKeys []types.NamespacedName
+			for podKey := range podInfos {
+				podKeys = append(podKeys, podKey)
+			}
+			podInfoRepo.EXPECT().ListKeys(gomock.Any()).Return(podKeys)
+			podInfoRepo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key types.NamespacedName) (k8s.PodInfo, bool, error) {
+				podInfo, exists := podInfos[key]
+				return podInfo, exists, nil
+			}).AnyTimes()
+			metricsCollector := lbcmetrics.NewMockCollector()
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := NewALBTargetControlConfigReconciler(k8sClient, apiReader, podInfoRepo, eventRecorder,
+				metricsCollector, log.Log)
+			err := r.reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: albtargetcontrol.ConfigName}})
+			var requeueNeededAfter *ctrlerrors.RequeueNeededAfter
+			assert.ErrorAs(t, err, &requeueNeededAfter)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+
+			gotCfg := &elbv2api.ALBTargetControlConfig{}
+			assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(cfg), gotCfg))
+			assert.Equal(t, wantStatus, gotCfg.Status)
+
+			gotDeployment := &appsv1.Deployment{}
+			assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), gotDeployment))
+			assert.Equal(t, tt.wantTemplateAnnotation, gotDeployment.Spec.Template.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigHash])
+
+			mockCollector := metricsCollector.(*lbcmetrics.MockCollector)
+			assert.Equal(t, []interface{}{
+				lbcmetrics.MockALBTargetControlAgentPodsMetric{
+					Namespace: "app",
+					Name:      albtargetcontrol.ConfigName,
+					Injected:  3,
+					Outdated:  2,
+					Failed:    1,
+				},
+			}, mockCollector.Invocations[lbcmetrics.MetricALBTargetControlAgentPods])
+		})
+	}
+}
+
+func Test_albTargetControlConfigReconciler_reconcile_deleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	metricsCollector := lbcmetrics.NewMockCollector()
+
+	r := NewALBTargetControlConfigReconciler(k8sClient, k8sClient, k8s.NewMockPodInfoRepo(ctrl), record.NewFakeRecorder(10),
+		metricsCollector, log.Log)
+	err := r.reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: albtargetcontrol.ConfigName}})
+	assert.NoError(t, err)
+
+	mockCollector := metricsCollector.(*lbcmetrics.MockCollector)
+	assert.Equal(t, []interface{}{
+		lbcmetrics.MockALBTargetControlAgentPodsMetric{
+			Namespace: "app",
+			Name:      albtargetcontrol.ConfigName,
+			Deleted:   true,
+		},
+	}, mockCollector.Invocations[lbcmetrics.MetricALBTargetControlAgentPods])
+}
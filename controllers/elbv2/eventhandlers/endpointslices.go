@@ -96,5 +96,15 @@ func (h *enqueueRequestsForEndpointSlicesEvent) enqueueImpactedTargetGroupBindin
 				Name:      tgb.Name,
 			},
 		})
+
+		// the registration quota of weighted peers depends on the endpoints of this TargetGroupBinding as well.
+		peers, err := targetgroupbinding.ListWeightedRegistrationPeers(ctx, h.k8sClient, &tgb)
+		if err != nil {
+			h.logger.Error(err, "failed to fetch weighted registration peers", "targetGroupBinding", k8s.NamespacedName(&tgb))
+			continue
+		}
+		for _, peer := range peers {
+			queue.Add(reconcile.Request{NamespacedName: k8s.NamespacedName(peer)})
+		}
 	}
 }
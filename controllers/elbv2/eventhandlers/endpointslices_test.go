@@ -148,6 +148,106 @@ func Test_enqueueRequestsForEndpointSlicesEvent_enqueueImpactedTargetGroupBindin
 				},
 			},
 		},
+		{
+			name: "service event should enqueue weighted registration peers of impacted TGBs",
+			fields: fields{
+				tgbListCalls: []tgbListCall{
+					{
+						opts: []client.ListOption{
+							client.InNamespace("awesome-ns"),
+							client.MatchingFields{"spec.serviceRef.name": "awesome-svc"},
+						},
+						tgbs: []*elbv2api.TargetGroupBinding{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "tgb-1",
+									UID:       "uid-1",
+								},
+								Spec: elbv2api.TargetGroupBindingSpec{
+									TargetGroupARN:          "tg-1",
+									TargetType:              &ipTargetType,
+									MultiClusterTargetGroup: true,
+									WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 90},
+								},
+							},
+						},
+					},
+					{
+						tgbs: []*elbv2api.TargetGroupBinding{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "tgb-1",
+									UID:       "uid-1",
+								},
+								Spec: elbv2api.TargetGroupBindingSpec{
+									TargetGroupARN:          "tg-1",
+									TargetType:              &ipTargetType,
+									MultiClusterTargetGroup: true,
+									WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 90},
+								},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "other-ns",
+									Name:      "tgb-2",
+									UID:       "uid-2",
+								},
+								Spec: elbv2api.TargetGroupBindingSpec{
+									TargetGroupARN:          "tg-1",
+									TargetType:              &ipTargetType,
+									MultiClusterTargetGroup: true,
+									WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 10},
+								},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "other-ns",
+									Name:      "tgb-3",
+									UID:       "uid-3",
+								},
+								Spec: elbv2api.TargetGroupBindingSpec{
+									TargetGroupARN:          "tg-2",
+									TargetType:              &ipTargetType,
+									MultiClusterTargetGroup: true,
+									WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 10},
+								},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "other-ns",
+									Name:      "tgb-4",
+									UID:       "uid-4",
+								},
+								Spec: elbv2api.TargetGroupBindingSpec{
+									TargetGroupARN:          "tg-1",
+									TargetType:              &ipTargetType,
+									MultiClusterTargetGroup: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				epslice: &discv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-svc",
+						Labels:    map[string]string{"kubernetes.io/service-name": "awesome-svc"},
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"},
+				},
+				{
+					NamespacedName: types.NamespacedName{Namespace: "other-ns", Name: "tgb-2"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,4 +71,18 @@ func (h *enqueueRequestsForPodEvent) enqueueImpactedTargetGroupBindings(_ contex
 			}
 		}
 	}
+	for _, cond := range pod.Conditions {
+		condType := string(cond.Type)
+		if strings.HasPrefix(condType, targetgroupbinding.TargetDrainPodConditionTypePrefix+"/") {
+			tgb := types.NamespacedName{
+				Namespace: pod.Key.Namespace,
+				Name:      condType[len(targetgroupbinding.TargetDrainPodConditionTypePrefix)+1:],
+			}
+
+			h.logger.V(1).Info("enqueue targetGroupBinding for pod termination", "pod", pod.Key.Name, "targetGroupBinding", tgb)
+			queue.Add(reconcile.Request{
+				NamespacedName: tgb,
+			})
+		}
+	}
 }
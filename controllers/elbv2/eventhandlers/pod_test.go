@@ -71,6 +71,26 @@ func Test_enqueueRequestsForPodEvent_enqueueImpactedTargetGroupBindings(t *testi
 			},
 			wantRequests: nil,
 		},
+		{
+			name: "pod event should enqueue TGBs used as target drain conditions",
+			args: args{
+				pod: &k8s.PodInfo{
+					Key: types.NamespacedName{
+						Namespace: "awesome-ns",
+						Name:      "awesome-pod",
+					},
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+						{Type: "target-drain.elbv2.k8s.aws/tgb-4", Status: corev1.ConditionFalse},
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-4"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,10 +69,11 @@ func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder reco
 		metricsCollector:                     metricsCollector,
 		reconcileCounters:                    reconcileCounters,
 
-		maxConcurrentReconciles:    config.TargetGroupBindingMaxConcurrentReconciles,
-		maxExponentialBackoffDelay: config.TargetGroupBindingMaxExponentialBackoffDelay,
-		enableEndpointSlices:       config.EnableEndpointSlices,
-		podInformer:                podInformer,
+		maxConcurrentReconciles:     config.TargetGroupBindingMaxConcurrentReconciles,
+		maxExponentialBackoffDelay:  config.TargetGroupBindingMaxExponentialBackoffDelay,
+		enableEndpointSlices:        config.EnableEndpointSlices,
+		targetHealthRefreshInterval: config.TargetHealthRefreshInterval,
+		podInformer:                 podInformer,
 	}
 }
 
@@ -88,14 +89,15 @@ type targetGroupBindingReconciler struct {
 	reconcileCounters                    *metricsutil.ReconcileCounters
 	podInformer                          cache.Informer
 
-	maxConcurrentReconciles    int
-	maxExponentialBackoffDelay time.Duration
-	enableEndpointSlices       bool
+	maxConcurrentReconciles     int
+	maxExponentialBackoffDelay  time.Duration
+	enableEndpointSlices        bool
+	targetHealthRefreshInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings/status,verbs=update;patch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
@@ -152,7 +154,7 @@ func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.C
 
 	if deferred {
 		r.deferredTargetGroupBindingReconciler.Enqueue(tgb)
-		return nil
+		return r.requeueForTargetHealthRefresh()
 	} else {
 		r.deferredTargetGroupBindingReconciler.MarkProcessed(tgb)
 	}
@@ -167,7 +169,16 @@ func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.C
 	}
 
 	r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
-	return nil
+	return r.requeueForTargetHealthRefresh()
+}
+
+// requeueForTargetHealthRefresh requeues the TargetGroupBinding so that the target health in its status is refreshed
+// even when its targets are unchanged.
+func (r *targetGroupBindingReconciler) requeueForTargetHealthRefresh() error {
+	if r.targetHealthRefreshInterval <= 0 {
+		return nil
+	}
+	return ctrlerrors.NewRequeueNeededAfter("refresh target health", r.targetHealthRefreshInterval)
 }
 
 func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
@@ -57,8 +57,19 @@ func (m *mockMetricCollector) ObserveControllerReconcileLatency(controller strin
 }
 func (m *mockMetricCollector) ObserveWebhookValidationError(webhookName string, errorType string) {}
 func (m *mockMetricCollector) ObserveWebhookMutationError(webhookName string, errorType string)   {}
-func (m *mockMetricCollector) StartCollectTopTalkers(ctx context.Context)                         {}
-func (m *mockMetricCollector) StartCollectCacheSize(ctx context.Context)                          {}
+func (m *mockMetricCollector) ObserveCapacityReservation(lbARN string, capacityUnits int32, nextCapacityUnits *int32, nextTransitionTime *time.Time) {
+}
+func (m *mockMetricCollector) DeleteCapacityReservation(lbARN string) {}
+func (m *mockMetricCollector) ObserveALBTargetControlAgentPods(namespace string, name string, injected int, outdated int, failed int) {
+}
+func (m *mockMetricCollector) DeleteALBTargetControlAgentPods(namespace string, name string)       {}
+func (m *mockMetricCollector) ObserveQUICTargetDuplicateServerId(namespace string, tgbName string) {}
+func (m *mockMetricCollector) ObserveQUICServerIDAllocationConflict(namespace string)              {}
+func (m *mockMetricCollector) ObserveQUICServerIDClaims(namespace string, pending int, bound int, retained int, collidingPods int) {
+}
+func (m *mockMetricCollector) DeleteQUICServerIDClaims(namespace string)  {}
+func (m *mockMetricCollector) StartCollectTopTalkers(ctx context.Context) {}
+func (m *mockMetricCollector) StartCollectCacheSize(ctx context.Context)  {}
 
 // --- Test ---
 
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/rollout"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	trafficRolloutControllerName = "trafficRollout"
+)
+
+// NewTrafficRolloutReconciler constructs new trafficRolloutReconciler
+func NewTrafficRolloutReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, rolloutManager rollout.Manager, logger logr.Logger) *trafficRolloutReconciler {
+	return &trafficRolloutReconciler{
+		k8sClient:      k8sClient,
+		eventRecorder:  eventRecorder,
+		rolloutManager: rolloutManager,
+		logger:         logger,
+	}
+}
+
+// trafficRolloutReconciler reconciles a TrafficRollout object.
+// It only progresses the rollout status, the Ingress and Gateway controllers apply the resulting weights.
+type trafficRolloutReconciler struct {
+	k8sClient      client.Client
+	eventRecorder  record.EventRecorder
+	rolloutManager rollout.Manager
+	logger         logr.Logger
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=trafficrollouts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=trafficrollouts/status,verbs=update;patch
+
+func (r *trafficRolloutReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	r.logger.V(1).Info("Reconcile request", "name", req.Name)
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+}
+
+func (r *trafficRolloutReconciler) reconcile(ctx context.Context, req reconcile.Request) error {
+	tr := &elbv2api.TrafficRollout{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, tr); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !tr.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	requeueAfter, err := r.rolloutManager.Reconcile(ctx, tr)
+	if err != nil {
+		r.eventRecorder.Event(tr, corev1.EventTypeWarning, k8s.TrafficRolloutEventReasonFailedReconcile, fmt.Sprintf("Failed reconcile due to %v", err))
+		return err
+	}
+	if requeueAfter > 0 {
+		return ctrlerrors.NewRequeueNeededAfter("rollout in progress", requeueAfter)
+	}
+	return nil
+}
+
+func (r *trafficRolloutReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.TrafficRollout{}).
+		Named(trafficRolloutControllerName).
+		Complete(r)
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webacl"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	wafv2WebACLControllerName = "wafv2WebACL"
+
+	// wafv2WebACLStatusRefreshInterval is the interval to refresh the status, such as the associated load balancers.
+	wafv2WebACLStatusRefreshInterval = 10 * time.Minute
+	// wafv2WebACLDeletionRetryInterval is the interval to retry deletion while load balancers are associated with the web ACL.
+	wafv2WebACLDeletionRetryInterval = 1 * time.Minute
+
+	wafv2WebACLReasonReconciled       = "Reconciled"
+	wafv2WebACLReasonFailedReconcile  = "FailedReconcile"
+	wafv2WebACLReasonDeletionBlocked  = "DeletionBlocked"
+	wafv2WebACLDeletionBlockedMessage = "web ACL is still associated with load balancers: %v"
+)
+
+// NewWAFv2WebACLReconciler constructs new wafv2WebACLReconciler
+func NewWAFv2WebACLReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
+	webACLManager webacl.Manager, logger logr.Logger) *wafv2WebACLReconciler {
+	return &wafv2WebACLReconciler{
+		k8sClient:        k8sClient,
+		eventRecorder:    eventRecorder,
+		finalizerManager: finalizerManager,
+		webACLManager:    webACLManager,
+		logger:           logger,
+	}
+}
+
+// wafv2WebACLReconciler reconciles a WAFv2WebACL object.
+// The web ACL is only deleted once no load balancer is associated with it.
+type wafv2WebACLReconciler struct {
+	k8sClient        client.Client
+	eventRecorder    record.EventRecorder
+	finalizerManager k8s.FinalizerManager
+	webACLManager    webacl.Manager
+	logger           logr.Logger
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=wafv2webacls,verbs=get;list;watch;patch;update
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=wafv2webacls/status,verbs=update;patch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=wafv2webacls/finalizers,verbs=update;patch
+
+func (r *wafv2WebACLReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	r.logger.V(1).Info("Reconcile request", "name", req.Name)
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+}
+
+func (r *wafv2WebACLReconciler) reconcile(ctx context.Context, req reconcile.Request) error {
+	webACL := &elbv2api.WAFv2WebACL{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, webACL); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !webACL.DeletionTimestamp.IsZero() {
+		return r.cleanupWebACL(ctx, webACL)
+	}
+	return r.reconcileWebACL(ctx, webACL)
+}
+
+func (r *wafv2WebACLReconciler) reconcileWebACL(ctx context.Context, webACL *elbv2api.WAFv2WebACL) error {
+	if !k8s.HasFinalizer(webACL, shared_constants.WAFv2WebACLFinalizer) {
+		if err := r.finalizerManager.AddFinalizers(ctx, webACL, shared_constants.WAFv2WebACLFinalizer); err != nil {
+			r.eventRecorder.Event(webACL, corev1.EventTypeWarning, k8s.WAFv2WebACLEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+			return err
+		}
+	}
+
+	status, err := r.webACLManager.Reconcile(ctx, webACL)
+	if err != nil {
+		r.eventRecorder.Event(webACL, corev1.EventTypeWarning, k8s.WAFv2WebACLEventReasonFailedReconcile, fmt.Sprintf("Failed reconcile due to %v", err))
+		status = *webACL.Status.DeepCopy()
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               elbv2api.WAFv2WebACLConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             wafv2WebACLReasonFailedReconcile,
+			Message:            err.Error(),
+			ObservedGeneration: webACL.Generation,
+		})
+		if statusErr := r.updateStatus(ctx, webACL, status); statusErr != nil {
+			r.logger.Error(statusErr, "failed to update WAFv2WebACL status", "webACL", webACL.Name)
+		}
+		return err
+	}
+	status.Conditions = webACL.Status.DeepCopy().Conditions
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               elbv2api.WAFv2WebACLConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             wafv2WebACLReasonReconciled,
+		ObservedGeneration: webACL.Generation,
+	})
+	if awssdk.ToInt64(webACL.Status.ObservedGeneration) != webACL.Generation {
+		r.eventRecorder.Event(webACL, corev1.EventTypeNormal, k8s.WAFv2WebACLEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	}
+	if err := r.updateStatus(ctx, webACL, status); err != nil {
+		return err
+	}
+	return ctrlerrors.NewRequeueNeededAfter("refresh web ACL status", wafv2WebACLStatusRefreshInterval)
+}
+
+func (r *wafv2WebACLReconciler) cleanupWebACL(ctx context.Context, webACL *elbv2api.WAFv2WebACL) error {
+	if !k8s.HasFinalizer(webACL, shared_constants.WAFv2WebACLFinalizer) {
+		return nil
+	}
+	associatedLBs, err := r.webACLManager.Delete(ctx, webACL)
+	if err != nil {
+		r.eventRecorder.Event(webACL, corev1.EventTypeWarning, k8s.WAFv2WebACLEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+		return err
+	}
+	if len(associatedLBs) != 0 {
+		message := fmt.Sprintf(wafv2WebACLDeletionBlockedMessage, associatedLBs)
+		r.eventRecorder.Event(webACL, corev1.EventTypeWarning, k8s.WAFv2WebACLEventReasonDeletionBlocked, message)
+		status := *webACL.Status.DeepCopy()
+		status.AssociatedLoadBalancers = associatedLBs
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               elbv2api.WAFv2WebACLConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             wafv2WebACLReasonDeletionBlocked,
+			Message:            message,
+			ObservedGeneration: webACL.Generation,
+		})
+		if err := r.updateStatus(ctx, webACL, status); err != nil {
+			return err
+		}
+		return ctrlerrors.NewRequeueNeededAfter("web ACL is still associated with load balancers", wafv2WebACLDeletionRetryInterval)
+	}
+	if err := r.finalizerManager.RemoveFinalizers(ctx, webACL, shared_constants.WAFv2WebACLFinalizer); err != nil {
+		r.eventRecorder.Event(webACL, corev1.EventTypeWarning, k8s.WAFv2WebACLEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+		return err
+	}
+	return nil
+}
+
+func (r *wafv2WebACLReconciler) updateStatus(ctx context.Context, webACL *elbv2api.WAFv2WebACL, status elbv2api.WAFv2WebACLStatus) error {
+	if equality.Semantic.DeepEqual(webACL.Status, status) {
+		return nil
+	}
+	webACLOld := webACL.DeepCopy()
+	webACL.Status = status
+	return r.k8sClient.Status().Patch(ctx, webACL, client.MergeFrom(webACLOld))
+}
+
+func (r *wafv2WebACLReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.WAFv2WebACL{}).
+		Named(wafv2WebACLControllerName).
+		Complete(r)
+}
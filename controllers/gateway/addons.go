@@ -14,7 +14,7 @@ import (
 )
 
 var (
-	albAddons = []addon.Addon{addon.WAFv2, addon.Shield, addon.ProvisionedCapacity}
+	albAddons = []addon.Addon{addon.WAFv2, addon.Shield, addon.ProvisionedCapacity, addon.ShieldProtectionGroups, addon.ShieldHealthCheck, addon.ShieldApplicationLayerAutomaticResponse}
 	nlbAddons = []addon.Addon{addon.ProvisionedCapacity}
 )
 
@@ -23,6 +23,7 @@ type gatewayConfigResolver interface {
 
 type gatewayConfigResolverImpl struct {
 	configMergeFn       func(gwClassLbConfig elbv2gw.LoadBalancerConfiguration, gwLbConfig elbv2gw.LoadBalancerConfiguration) elbv2gw.LoadBalancerConfiguration
+	policyEnforcer      gateway.LoadBalancerConfigPolicyEnforcer
 	configResolverFn    func(ctx context.Context, k8sClient client.Client, reference *gwv1.ParametersReference) (*elbv2gw.LoadBalancerConfiguration, error)
 	tgConfigConstructor gateway.TargetGroupConfigConstructor
 	logger              logr.Logger
@@ -31,6 +32,7 @@ type gatewayConfigResolverImpl struct {
 func newGatewayConfigResolver(logger logr.Logger) gatewayConfigResolver {
 	return &gatewayConfigResolverImpl{
 		configMergeFn:       gateway.NewLoadBalancerConfigMerger().Merge,
+		policyEnforcer:      gateway.NewLoadBalancerConfigPolicyEnforcer(),
 		configResolverFn:    gatewayutils.ResolveLoadBalancerConfig,
 		tgConfigConstructor: gateway.NewTargetGroupConfigConstructor(),
 		logger:              logger,
@@ -110,6 +112,15 @@ func (resolver *gatewayConfigResolverImpl) getLoadBalancerConfigForGateway(ctx c
 		mergedLBConfig = resolver.configMergeFn(*gatewayClassLBConfig, *gatewayLBConfig)
 	}
 
+	// The policies of the GatewayClass configuration apply even when the Gateway has no configuration, e.g. for defaults.
+	// They're not enforced on a Gateway being deleted, so that a policy violation can't block the cleanup of its resources.
+	if gatewayClassLBConfig != nil && !isGatewayDeleting(gw) {
+		mergedLBConfig, err = resolver.policyEnforcer.Enforce(*gatewayClassLBConfig, gatewayLBConfig, mergedLBConfig)
+		if err != nil {
+			return elbv2gw.LoadBalancerConfiguration{}, nil, err
+		}
+	}
+
 	return mergedLBConfig, resolvedDefaultTGC, nil
 }
 
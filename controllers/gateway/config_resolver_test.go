@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
@@ -29,6 +30,65 @@ func Test_getLoadBalancerConfigForGateway(t *testing.T) {
 	k8sClient := mock_client.NewMockClient(ctrl)
 	k8sFinalizerManager := k8s.NewMockFinalizerManager(ctrl)
 
+	internalScheme := elbv2gw.LoadBalancerSchemeInternal
+	internetFacingScheme := elbv2gw.LoadBalancerSchemeInternetFacing
+	policyGatewayClass := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: gwClassName,
+			Annotations: map[string]string{
+				"elbv2.k8s.aws/last-processed-config": "1",
+			},
+		},
+		Status: gwv1.GatewayClassStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(gwv1.GatewayClassReasonAccepted),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+		Spec: gwv1.GatewayClassSpec{
+			ParametersRef: &gwv1.ParametersReference{
+				Name: gwClassName,
+			},
+		},
+	}
+	policyGateway := func(deletionTimestamp *metav1.Time) *gwv1.Gateway {
+		return &gwv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              gwName,
+				Namespace:         "ns",
+				DeletionTimestamp: deletionTimestamp,
+			},
+			Spec: gwv1.GatewaySpec{
+				Infrastructure: &gwv1.GatewayInfrastructure{
+					ParametersRef: &gwv1.LocalParametersReference{
+						Name: gwName,
+					},
+				},
+			},
+		}
+	}
+	policyConfigResolverFn := func(ctx context.Context, k8sClient client.Client, reference *gwv1.ParametersReference) (*elbv2gw.LoadBalancerConfiguration, error) {
+		if reference.Name == gwClassName {
+			return &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: gwClassName, ResourceVersion: "1"},
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Scheme: &internalScheme,
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldScheme, Locked: true},
+					},
+				},
+			}, nil
+		}
+		return &elbv2gw.LoadBalancerConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: gwName, ResourceVersion: "1"},
+			Spec: elbv2gw.LoadBalancerConfigurationSpec{
+				Scheme: &internetFacingScheme,
+			},
+		}, nil
+	}
+
 	testCases := []struct {
 		name             string
 		configMergeFn    func(gwClassLbConfig elbv2gw.LoadBalancerConfiguration, gwLbConfig elbv2gw.LoadBalancerConfiguration) elbv2gw.LoadBalancerConfiguration
@@ -420,6 +480,32 @@ func Test_getLoadBalancerConfigForGateway(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:              "gw class accepted -- gw config violates gwclass policy",
+			inputGatewayClass: policyGatewayClass,
+			inputGateway:      policyGateway(nil),
+			configResolverFn:  policyConfigResolverFn,
+			setupMocks: func() {
+				k8sFinalizerManager.EXPECT().
+					AddFinalizers(context.Background(), gomock.Any(), shared_constants.LoadBalancerConfigurationFinalizer).
+					Return(nil).Times(2)
+			},
+			expectErr: true,
+		},
+		{
+			name:              "gw class accepted -- gw config violates gwclass policy while gw is deleting",
+			inputGatewayClass: policyGatewayClass,
+			inputGateway:      policyGateway(&metav1.Time{Time: time.Now()}),
+			configResolverFn:  policyConfigResolverFn,
+			expected: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: mergedConfigName},
+			},
+			setupMocks: func() {
+				k8sFinalizerManager.EXPECT().
+					AddFinalizers(context.Background(), gomock.Any(), shared_constants.LoadBalancerConfigurationFinalizer).
+					Return(nil).Times(2)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -432,6 +518,7 @@ func Test_getLoadBalancerConfigForGateway(t *testing.T) {
 					return mergedConfig
 				},
 				configResolverFn:    tc.configResolverFn,
+				policyEnforcer:      gateway.NewLoadBalancerConfigPolicyEnforcer(),
 				tgConfigConstructor: gateway.NewTargetGroupConfigConstructor(),
 				logger:              logr.Discard(),
 			}
@@ -0,0 +1,84 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// NewEnqueueRequestsForTrafficRolloutEvent creates handler for TrafficRollout resources
+func NewEnqueueRequestsForTrafficRolloutEvent(httpRouteEventChan chan<- event.TypedGenericEvent[*gatewayv1.HTTPRoute],
+	k8sClient client.Client, logger logr.Logger) handler.TypedEventHandler[*elbv2api.TrafficRollout, reconcile.Request] {
+	return &enqueueRequestsForTrafficRolloutEvent{
+		httpRouteEventChan: httpRouteEventChan,
+		k8sClient:          k8sClient,
+		logger:             logger,
+	}
+}
+
+var _ handler.TypedEventHandler[*elbv2api.TrafficRollout, reconcile.Request] = (*enqueueRequestsForTrafficRolloutEvent)(nil)
+
+// enqueueRequestsForTrafficRolloutEvent handles TrafficRollout events
+type enqueueRequestsForTrafficRolloutEvent struct {
+	httpRouteEventChan chan<- event.TypedGenericEvent[*gatewayv1.HTTPRoute]
+	k8sClient          client.Client
+	logger             logr.Logger
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Create(ctx context.Context, e event.TypedCreateEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.logger.V(1).Info("enqueue trafficrollout create event", "trafficrollout", e.Object.Name)
+	h.enqueueImpactedRoute(ctx, e.Object)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Update(ctx context.Context, e event.TypedUpdateEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	rolloutOld := e.ObjectOld
+	rolloutNew := e.ObjectNew
+	// only spec updates and status updates that change the traffic split impact routes.
+	if equality.Semantic.DeepEqual(rolloutOld.Spec, rolloutNew.Spec) &&
+		rolloutOld.Status.RolloutHash == rolloutNew.Status.RolloutHash &&
+		rolloutOld.Status.Phase == rolloutNew.Status.Phase &&
+		rolloutOld.Status.CanaryWeight == rolloutNew.Status.CanaryWeight {
+		return
+	}
+	h.logger.V(1).Info("enqueue trafficrollout update event", "trafficrollout", rolloutNew.Name)
+	if rolloutOld.Spec.TargetRef != rolloutNew.Spec.TargetRef {
+		h.enqueueImpactedRoute(ctx, rolloutOld)
+	}
+	h.enqueueImpactedRoute(ctx, rolloutNew)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Delete(ctx context.Context, e event.TypedDeleteEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.logger.V(1).Info("enqueue trafficrollout delete event", "trafficrollout", e.Object.Name)
+	h.enqueueImpactedRoute(ctx, e.Object)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Generic(ctx context.Context, e event.TypedGenericEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.logger.V(1).Info("enqueue trafficrollout generic event", "trafficrollout", e.Object.Name)
+	h.enqueueImpactedRoute(ctx, e.Object)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) enqueueImpactedRoute(ctx context.Context, rollout *elbv2api.TrafficRollout) {
+	if rollout.Spec.TargetRef.Kind != elbv2api.TrafficRolloutTargetKindHTTPRoute {
+		return
+	}
+	route := &gatewayv1.HTTPRoute{}
+	routeKey := types.NamespacedName{Namespace: rollout.Namespace, Name: rollout.Spec.TargetRef.Name}
+	if err := h.k8sClient.Get(ctx, routeKey, route); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			h.logger.Error(err, "failed to fetch httproute", "httproute", routeKey)
+		}
+		return
+	}
+	h.httpRouteEventChan <- event.TypedGenericEvent[*gatewayv1.HTTPRoute]{
+		Object: route,
+	}
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,7 +47,7 @@ func buildListenerSetStatus(listenerSetNamespacedName types.NamespacedName, resu
 	acceptedMessage := string(gwv1.ListenerSetReasonAccepted)
 	if results.HasErrors {
 		acceptedReason = string(gwv1.ListenerSetReasonListenersNotValid)
-		acceptedMessage = "Some listeners are not valid"
+		acceptedMessage = fmt.Sprintf("Some listeners are not valid: %s", describeInvalidListeners(results))
 	}
 
 	hasSuccess := false
@@ -86,6 +87,18 @@ func buildListenerSetStatus(listenerSetNamespacedName types.NamespacedName, resu
 	}, buildListenerStatus(results.Generation, results, programmed, generateListenerEntryStatus)
 }
 
+// describeInvalidListeners lists the invalid listeners with their reason, sorted by listener name.
+func describeInvalidListeners(results routeutils.ListenerValidationResults) string {
+	var invalidListeners []string
+	for listenerName, result := range results.Results {
+		if !result.IsValid {
+			invalidListeners = append(invalidListeners, fmt.Sprintf("%s (%s)", listenerName, result.Reason))
+		}
+	}
+	sort.Strings(invalidListeners)
+	return strings.Join(invalidListeners, ", ")
+}
+
 func buildRejectedListenerSetStatus(rejectedListenerSet gwv1.ListenerSet) (routeutils.ListenerSetStatusData, []gwv1.ListenerEntryStatus) {
 	return routeutils.ListenerSetStatusData{
 		ListenerSetMetadata: routeutils.ListenerSetMetadata{
@@ -129,7 +142,7 @@ func getListenerConditions(generation int64, listenerValidationResult routeutils
 
 	// Build ResolvedRefs Conditions
 	switch listenerReason {
-	case gwv1.ListenerReasonInvalidRouteKinds, gwv1.ListenerReasonRefNotPermitted:
+	case gwv1.ListenerReasonInvalidRouteKinds, gwv1.ListenerReasonRefNotPermitted, gwv1.ListenerReasonInvalidCertificateRef:
 		conditions = append(conditions, buildResolvedRefsCondition(generation, listenerReason, listenerErrMessage))
 	default:
 		conditions = append(conditions, buildResolvedRefsCondition(generation, gwv1.ListenerReasonResolvedRefs, gateway_constants.ListenerResolvedRefMessage))
@@ -883,7 +883,7 @@ func Test_buildListenerSetStatus(t *testing.T) {
 			isGatewayProgrammed:       true,
 			expectedAccepted:          true,
 			expectedAcceptedReason:    string(gwv1.ListenerSetReasonListenersNotValid),
-			expectedAcceptedMessage:   "Some listeners are not valid",
+			expectedAcceptedMessage:   "Some listeners are not valid: listener2 (InvalidRouteKinds)",
 			expectedProgrammed:        true,
 			expectedProgrammedReason:  string(gwv1.ListenerSetReasonProgrammed),
 			expectedProgrammedMessage: string(gwv1.ListenerSetReasonProgrammed),
@@ -910,7 +910,7 @@ func Test_buildListenerSetStatus(t *testing.T) {
 			isGatewayProgrammed:       true,
 			expectedAccepted:          false,
 			expectedAcceptedReason:    string(gwv1.ListenerSetReasonListenersNotValid),
-			expectedAcceptedMessage:   "Some listeners are not valid",
+			expectedAcceptedMessage:   "Some listeners are not valid: listener1 (InvalidRouteKinds)",
 			expectedProgrammed:        false,
 			expectedProgrammedReason:  string(gwv1.ListenerSetReasonListenersNotValid),
 			expectedProgrammedMessage: "No valid listeners to materialize",
@@ -965,7 +965,7 @@ func Test_buildListenerSetStatus(t *testing.T) {
 			isGatewayProgrammed:       false,
 			expectedAccepted:          false,
 			expectedAcceptedReason:    string(gwv1.ListenerSetReasonListenersNotValid),
-			expectedAcceptedMessage:   "Some listeners are not valid",
+			expectedAcceptedMessage:   "Some listeners are not valid: listener1 (InvalidRouteKinds)",
 			expectedProgrammed:        false,
 			expectedProgrammedReason:  string(gwv1.ListenerSetReasonPending),
 			expectedProgrammedMessage: "Parent gateway not yet programmed",
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -290,9 +291,42 @@ func (d *routeReconcilerImpl) setConditionsWithRouteStatusInfo(route client.Obje
 		})
 	}
 
+	if backendStrategyCondition := buildBackendStrategyCondition(route, parentStatus.Conditions, info, timeNow); backendStrategyCondition != nil {
+		conditions = append(conditions, *backendStrategyCondition)
+	}
+
 	parentStatus.Conditions = conditions
 }
 
+// buildBackendStrategyCondition builds the condition reporting the backend strategy of L4 routes.
+// Status updates issued before the model is built don't know the strategy, they keep the existing condition so that it doesn't flap.
+func buildBackendStrategyCondition(route client.Object, existingConditions []metav1.Condition, info routeutils.RouteStatusInfo, timeNow metav1.Time) *metav1.Condition {
+	if !info.Accepted {
+		return nil
+	}
+	if info.BackendStrategy == "" {
+		existing := meta.FindStatusCondition(existingConditions, routeutils.RouteConditionBackendStrategy)
+		if existing == nil {
+			return nil
+		}
+		condition := *existing
+		return &condition
+	}
+
+	message := "Each backend is forwarded to its own target group, using the backendRef weights"
+	if info.BackendStrategy == routeutils.BackendStrategyAggregatedTargetGroup {
+		message = "Backends share a single target group, each backend registers a share of its endpoints proportional to the backendRef weight"
+	}
+	return &metav1.Condition{
+		Type:               routeutils.RouteConditionBackendStrategy,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(info.BackendStrategy),
+		Message:            message,
+		LastTransitionTime: timeNow,
+		ObservedGeneration: route.GetGeneration(),
+	}
+}
+
 func (d *routeReconcilerImpl) setConditionsBasedOnResolveRefGateway(route client.Object, parentStatus *gwv1.RouteParentStatus, resolveErr error) {
 	timeNow := metav1.NewTime(time.Now())
 	parentStatus.Conditions = []metav1.Condition{
@@ -893,10 +893,18 @@ func Test_setConditionsWithRouteStatusInfo(t *testing.T) {
 		},
 	}
 
+	backendStrategyCondition := metav1.Condition{
+		Type:               routeutils.RouteConditionBackendStrategy,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(routeutils.BackendStrategyAggregatedTargetGroup),
+		ObservedGeneration: 1,
+	}
+
 	tests := []struct {
-		name           string
-		info           routeutils.RouteStatusInfo
-		validateResult func(t *testing.T, conditions []metav1.Condition)
+		name               string
+		info               routeutils.RouteStatusInfo
+		existingConditions []metav1.Condition
+		validateResult     func(t *testing.T, conditions []metav1.Condition)
 	}{
 		{
 			name: "accepted true and resolvedRef true",
@@ -957,6 +965,50 @@ func Test_setConditionsWithRouteStatusInfo(t *testing.T) {
 				assert.Equal(t, metav1.ConditionFalse, resolvedRefCondition.Status)
 			},
 		},
+		{
+			name: "accepted with backend strategy",
+			info: routeutils.RouteStatusInfo{
+				Accepted:        true,
+				ResolvedRefs:    true,
+				Reason:          string(gwv1.RouteConditionAccepted),
+				BackendStrategy: routeutils.BackendStrategyWeightedTargetGroups,
+			},
+			validateResult: func(t *testing.T, conditions []metav1.Condition) {
+				assert.Len(t, conditions, 3)
+				strategyCondition := findCondition(conditions, routeutils.RouteConditionBackendStrategy)
+				assert.NotNil(t, strategyCondition)
+				assert.Equal(t, metav1.ConditionTrue, strategyCondition.Status)
+				assert.Equal(t, string(routeutils.BackendStrategyWeightedTargetGroups), strategyCondition.Reason)
+			},
+		},
+		{
+			name: "accepted without backend strategy keeps existing backend strategy",
+			info: routeutils.RouteStatusInfo{
+				Accepted:     true,
+				ResolvedRefs: true,
+				Reason:       string(gwv1.RouteConditionAccepted),
+			},
+			existingConditions: []metav1.Condition{backendStrategyCondition},
+			validateResult: func(t *testing.T, conditions []metav1.Condition) {
+				assert.Len(t, conditions, 3)
+				strategyCondition := findCondition(conditions, routeutils.RouteConditionBackendStrategy)
+				assert.NotNil(t, strategyCondition)
+				assert.Equal(t, backendStrategyCondition, *strategyCondition)
+			},
+		},
+		{
+			name: "not accepted drops existing backend strategy",
+			info: routeutils.RouteStatusInfo{
+				Accepted:     false,
+				ResolvedRefs: true,
+				Reason:       string(gwv1.RouteReasonNotAllowedByListeners),
+			},
+			existingConditions: []metav1.Condition{backendStrategyCondition},
+			validateResult: func(t *testing.T, conditions []metav1.Condition) {
+				assert.Len(t, conditions, 2)
+				assert.Nil(t, findCondition(conditions, routeutils.RouteConditionBackendStrategy))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -965,7 +1017,7 @@ func Test_setConditionsWithRouteStatusInfo(t *testing.T) {
 			reconciler := &routeReconcilerImpl{
 				logger: logger,
 			}
-			parentStatus := &gwv1.RouteParentStatus{}
+			parentStatus := &gwv1.RouteParentStatus{Conditions: tt.existingConditions}
 			reconciler.setConditionsWithRouteStatusInfo(route, parentStatus, tt.info)
 			if tt.validateResult != nil {
 				tt.validateResult(t, parentStatus.Conditions)
@@ -0,0 +1,61 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// diffShieldAttributes determines the Shield protection attributes to be tracked as enabled and the ones that are no longer enabled,
+// when comparing active (the tracked attributes of the previous reconcile run) and desired (the attributes of the current reconcile run).
+func diffShieldAttributes(active sets.Set[addon.Addon], desired []addon.AddonMetadata) ([]addon.Addon, []addon.Addon) {
+	var additions, removals []addon.Addon
+	for _, d := range desired {
+		if d.Enabled {
+			// every member is expected to track the attribute, including the ones that just joined the group.
+			additions = append(additions, d.Name)
+		} else if active.Has(d.Name) {
+			removals = append(removals, d.Name)
+		}
+	}
+	return additions, removals
+}
+
+// persistShieldAttributes tracks the enablement of the Shield protection attributes on every member of the IngressGroup.
+// The patch is skipped for members that are already up to date.
+func persistShieldAttributes(ctx context.Context, k8sClient client.Client, ingGroup ingress.Group, changes []addon.Addon, remove bool) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	annotationValue := strconv.FormatBool(!remove)
+	for _, member := range ingGroup.Members {
+		ing := member.Ing
+		ingOld := ing.DeepCopy()
+		updated := false
+		for _, a := range changes {
+			key := ingress.GenerateAddonKey(a)
+			// members that never tracked the attribute are left untouched on removal.
+			if current, ok := ing.Annotations[key]; current == annotationValue || (remove && !ok) {
+				continue
+			}
+			if ing.Annotations == nil {
+				ing.Annotations = map[string]string{}
+			}
+			ing.Annotations[key] = annotationValue
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+		if err := k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); err != nil {
+			return fmt.Errorf("failed to persist shield attributes on ingress %s: %w", k8s.NamespacedName(ing), err)
+		}
+	}
+	return nil
+}
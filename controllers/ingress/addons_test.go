@@ -0,0 +1,132 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_diffShieldAttributes(t *testing.T) {
+	tests := []struct {
+		name          string
+		active        sets.Set[addon.Addon]
+		desired       []addon.AddonMetadata
+		wantAdditions []addon.Addon
+		wantRemovals  []addon.Addon
+	}{
+		{
+			name:   "nothing active or desired",
+			active: sets.New[addon.Addon](),
+			desired: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: false},
+				{Name: addon.ShieldHealthCheck, Enabled: false},
+			},
+		},
+		{
+			name:   "enabled attributes are always added",
+			active: sets.New(addon.ShieldProtectionGroups),
+			desired: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: true},
+				{Name: addon.ShieldHealthCheck, Enabled: true},
+			},
+			wantAdditions: []addon.Addon{addon.ShieldProtectionGroups, addon.ShieldHealthCheck},
+		},
+		{
+			name:   "disabled attributes are removed only when active",
+			active: sets.New(addon.ShieldProtectionGroups),
+			desired: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: false},
+				{Name: addon.ShieldHealthCheck, Enabled: false},
+				{Name: addon.ShieldApplicationLayerAutomaticResponse, Enabled: true},
+			},
+			wantAdditions: []addon.Addon{addon.ShieldApplicationLayerAutomaticResponse},
+			wantRemovals:  []addon.Addon{addon.ShieldProtectionGroups},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			additions, removals := diffShieldAttributes(tt.active, tt.desired)
+			assert.Equal(t, tt.wantAdditions, additions)
+			assert.Equal(t, tt.wantRemovals, removals)
+		})
+	}
+}
+
+func Test_persistShieldAttributes(t *testing.T) {
+	tests := []struct {
+		name            string
+		ingAnnotations  []map[string]string
+		changes         []addon.Addon
+		remove          bool
+		wantAnnotations []map[string]string
+	}{
+		{
+			name: "tracks additions on every member",
+			ingAnnotations: []map[string]string{
+				nil,
+				{"ingress.k8s.aws.addon.shieldhealthcheck": "true"},
+			},
+			changes: []addon.Addon{addon.ShieldHealthCheck},
+			wantAnnotations: []map[string]string{
+				{"ingress.k8s.aws.addon.shieldhealthcheck": "true"},
+				{"ingress.k8s.aws.addon.shieldhealthcheck": "true"},
+			},
+		},
+		{
+			name: "tracks removals only on members that tracked the attribute",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/scheme": "internal"},
+				{"ingress.k8s.aws.addon.shieldprotectiongroups": "true"},
+			},
+			changes: []addon.Addon{addon.ShieldProtectionGroups},
+			remove:  true,
+			wantAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/scheme": "internal"},
+				{"ingress.k8s.aws.addon.shieldprotectiongroups": "false"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			var ingGroup ingress.Group
+			for i, annotations := range tt.ingAnnotations {
+				ing := &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        []string{"ing-0", "ing-1"}[i],
+						Namespace:   "default",
+						Annotations: annotations,
+					},
+				}
+				builder = builder.WithObjects(ing)
+				ingGroup.Members = append(ingGroup.Members, ingress.ClassifiedIngress{Ing: ing})
+			}
+			k8sClient := builder.Build()
+
+			err := persistShieldAttributes(context.Background(), k8sClient, ingGroup, tt.changes, tt.remove)
+			require.NoError(t, err)
+
+			for i, member := range ingGroup.Members {
+				updatedIng := &networking.Ingress{}
+				require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{
+					Name:      member.Ing.Name,
+					Namespace: "default",
+				}, updatedIng))
+				assert.Equal(t, tt.wantAnnotations[i], updatedIng.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForTrafficRolloutEvent constructs new enqueueRequestsForTrafficRolloutEvent.
+func NewEnqueueRequestsForTrafficRolloutEvent(ingEventChan chan<- event.TypedGenericEvent[*networking.Ingress],
+	k8sClient client.Client, eventRecorder record.EventRecorder, logger logr.Logger) handler.TypedEventHandler[*elbv2api.TrafficRollout, reconcile.Request] {
+	return &enqueueRequestsForTrafficRolloutEvent{
+		ingEventChan:  ingEventChan,
+		k8sClient:     k8sClient,
+		eventRecorder: eventRecorder,
+		logger:        logger,
+	}
+}
+
+var _ handler.TypedEventHandler[*elbv2api.TrafficRollout, reconcile.Request] = (*enqueueRequestsForTrafficRolloutEvent)(nil)
+
+type enqueueRequestsForTrafficRolloutEvent struct {
+	ingEventChan  chan<- event.TypedGenericEvent[*networking.Ingress]
+	k8sClient     client.Client
+	eventRecorder record.EventRecorder
+	logger        logr.Logger
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Create(ctx context.Context, e event.TypedCreateEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueImpactedIngress(ctx, e.Object)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Update(ctx context.Context, e event.TypedUpdateEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	rolloutOld := e.ObjectOld
+	rolloutNew := e.ObjectNew
+
+	// we only care below update event:
+	//	1. TrafficRollout spec updates
+	//	2. TrafficRollout status updates that change the traffic split
+	if equality.Semantic.DeepEqual(rolloutOld.Spec, rolloutNew.Spec) &&
+		rolloutOld.Status.RolloutHash == rolloutNew.Status.RolloutHash &&
+		rolloutOld.Status.Phase == rolloutNew.Status.Phase &&
+		rolloutOld.Status.CanaryWeight == rolloutNew.Status.CanaryWeight {
+		return
+	}
+	if rolloutOld.Spec.TargetRef != rolloutNew.Spec.TargetRef {
+		h.enqueueImpactedIngress(ctx, rolloutOld)
+	}
+	h.enqueueImpactedIngress(ctx, rolloutNew)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Delete(ctx context.Context, e event.TypedDeleteEvent[*elbv2api.TrafficRollout], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueImpactedIngress(ctx, e.Object)
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) Generic(context.Context, event.TypedGenericEvent[*elbv2api.TrafficRollout], workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// we don't have any generic event for trafficRollouts.
+}
+
+func (h *enqueueRequestsForTrafficRolloutEvent) enqueueImpactedIngress(ctx context.Context, rollout *elbv2api.TrafficRollout) {
+	if rollout.Spec.TargetRef.Kind != elbv2api.TrafficRolloutTargetKindIngress {
+		return
+	}
+	ing := &networking.Ingress{}
+	ingKey := types.NamespacedName{Namespace: rollout.Namespace, Name: rollout.Spec.TargetRef.Name}
+	if err := h.k8sClient.Get(ctx, ingKey, ing); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			h.logger.Error(err, "failed to fetch ingress", "ingress", ingKey)
+		}
+		return
+	}
+	h.logger.V(1).Info("enqueue ingress for trafficRollout event",
+		"trafficRollout", rollout.GetName(),
+		"ingress", ingKey)
+	h.ingEventChan <- event.TypedGenericEvent[*networking.Ingress]{
+		Object: ing,
+	}
+}
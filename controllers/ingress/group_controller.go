@@ -11,10 +11,12 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
@@ -22,6 +24,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/ingress/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
@@ -49,6 +52,9 @@ const (
 	// the groupVersion of used Ingress & IngressClass resource.
 	ingressResourcesGroupVersion = "networking.k8s.io/v1"
 	ingressClassKind             = "IngressClass"
+
+	// the domain of the port errors reported in the status of rejected IngressGroup members.
+	rejectedMemberStatusErrorDomain = "ingress.k8s.aws"
 )
 
 // NewGroupReconciler constructs new GroupReconciler
@@ -64,7 +70,7 @@ func NewGroupReconciler(cloud services.Cloud, k8sClient client.Client, eventReco
 	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder, controllerConfig.IngressConfig.TolerateNonExistentBackendService, controllerConfig.IngressConfig.TolerateNonExistentBackendAction)
 	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
 	trackingProvider := tracking.NewDefaultProvider(ingressTagPrefix, controllerConfig.ClusterName)
-	certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerConfig.IngressConfig.AllowedCertificateAuthorityARNs, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), logger)
+	certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerConfig.IngressConfig.AllowedCertificateAuthorityARNs, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.CertDiscoveryConfig.SelectionPolicy(), logger)
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
 		cloud.EC2(), cloud.ELBV2(), cloud.WAFv2(), cloud.ACM(),
 		annotationParser, subnetsResolver,
@@ -125,6 +131,7 @@ type groupReconciler struct {
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=ingressclassparams/status,verbs=update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
@@ -194,6 +201,17 @@ func (r *groupReconciler) reconcile(ctx context.Context, req reconcile.Request)
 		}
 	}
 
+	if len(ingGroup.RejectedMembers) > 0 {
+		var statusErr error
+		updateRejectedMembersStatusFn := func() {
+			statusErr = r.updateRejectedMembersStatus(ctx, ingGroup, listenerPorts)
+		}
+		r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "update_rejected_members_status", updateRejectedMembersStatusFn)
+		if statusErr != nil {
+			return ctrlerrors.NewErrorWithMetrics(controllerName, "update_rejected_members_status_error", statusErr, r.metricsCollector)
+		}
+	}
+
 	if len(ingGroup.InactiveMembers) > 0 {
 		removeGroupFinalizerFn := func() {
 			err = r.groupFinalizerManager.RemoveGroupFinalizer(ctx, ingGroupID, ingGroup.InactiveMembers)
@@ -206,7 +224,7 @@ func (r *groupReconciler) reconcile(ctx context.Context, req reconcile.Request)
 	}
 
 	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled, "Successfully reconciled")
-	return nil
+	return capacityreservation.RequeueAtNextTransition(lb)
 }
 
 func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, *elbv2model.LoadBalancer, []int32, error) {
@@ -256,6 +274,16 @@ func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingr
 		}
 	}
 
+	// the enabled shield attributes are tracked before deploy, and the disabled ones only once they're reverted.
+	shieldAttributes, err := ingress.BuildShieldAttributeAddons(stack)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	shieldAttributeAdditions, shieldAttributeRemovals := diffShieldAttributes(ingress.GetActiveShieldAttributes(ingGroup.Members), shieldAttributes)
+	if err := persistShieldAttributes(ctx, r.k8sClient, ingGroup, shieldAttributeAdditions, false); err != nil {
+		return nil, nil, nil, nil, ctrlerrors.NewErrorWithMetrics(controllerName, "persist_shield_attributes_error", err, r.metricsCollector)
+	}
+
 	deployModelFn := func() {
 		err = r.stackDeployer.Deploy(ctx, stack, r.metricsCollector, "ingress")
 	}
@@ -269,9 +297,15 @@ func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingr
 		return nil, nil, nil, nil, ctrlerrors.NewErrorWithMetrics(controllerName, "deploy_model_error", err, r.metricsCollector)
 	}
 	r.logger.Info("successfully deployed model", "ingressGroup", ingGroup.ID)
+	if err := persistShieldAttributes(ctx, r.k8sClient, ingGroup, shieldAttributeRemovals, true); err != nil {
+		return nil, nil, nil, nil, ctrlerrors.NewErrorWithMetrics(controllerName, "persist_shield_attributes_error", err, r.metricsCollector)
+	}
 	r.secretsManager.MonitorSecrets(ingGroup.ID.String(), secrets)
 	var inactiveResources []types.NamespacedName
 	inactiveResources = append(inactiveResources, k8s.ToSliceOfNamespacedNames(ingGroup.InactiveMembers)...)
+	for _, rejected := range ingGroup.RejectedMembers {
+		inactiveResources = append(inactiveResources, k8s.NamespacedName(rejected.Ing))
+	}
 	if !backendSGRequired {
 		inactiveResources = append(inactiveResources, k8s.ToSliceOfNamespacedNames(ingGroup.Members)...)
 	}
@@ -334,6 +368,39 @@ func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string,
 	return nil
 }
 
+// updateRejectedMembersStatus reports the rejection of each rejected member through an Event and the Ingress status.
+// The status of a rejected member carries no address, so that its hosts aren't resolved to the shared LoadBalancer,
+// and reports the rejection reason as the error of each listener port.
+func (r *groupReconciler) updateRejectedMembersStatus(ctx context.Context, ingGroup ingress.Group, ports []int32) error {
+	for _, rejected := range ingGroup.RejectedMembers {
+		r.eventRecorder.Event(rejected.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonGroupMemberRejected,
+			fmt.Sprintf("Rejected from IngressGroup %v: %v", ingGroup.ID, rejected.Message))
+
+		portErr := fmt.Sprintf("%s/%s", rejectedMemberStatusErrorDomain, rejected.Reason)
+		var lbIngresses []networking.IngressLoadBalancerIngress
+		if len(ports) > 0 {
+			ingressPorts := make([]networking.IngressPortStatus, len(ports))
+			for i, port := range ports {
+				ingressPorts[i] = networking.IngressPortStatus{
+					Port:     port,
+					Protocol: corev1.ProtocolTCP,
+					Error:    awssdk.String(portErr),
+				}
+			}
+			lbIngresses = []networking.IngressLoadBalancerIngress{{Ports: ingressPorts}}
+		}
+
+		ingOld := rejected.Ing.DeepCopy()
+		rejected.Ing.Status.LoadBalancer.Ingress = lbIngresses
+		if !equality.Semantic.DeepEqual(ingOld.Status.LoadBalancer.Ingress, rejected.Ing.Status.LoadBalancer.Ingress) {
+			if err := r.k8sClient.Status().Patch(ctx, rejected.Ing, client.MergeFrom(ingOld)); err != nil {
+				return errors.Wrapf(err, "failed to update ingress status: %v", k8s.NamespacedName(rejected.Ing))
+			}
+		}
+	}
+	return nil
+}
+
 func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, clientSet *kubernetes.Clientset) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
@@ -439,6 +506,13 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 			return err
 		}
 	}
+	if r.featureGates.Enabled(config.TrafficRollout) {
+		rolloutEventHandler := eventhandlers.NewEnqueueRequestsForTrafficRolloutEvent(ingEventChan, r.k8sClient, r.eventRecorder,
+			r.logger.WithName("eventHandlers").WithName("trafficRollout"))
+		if err := c.Watch(source.Kind(mgr.GetCache(), &elbv2api.TrafficRollout{}, rolloutEventHandler)); err != nil {
+			return err
+		}
+	}
 	r.secretsManager = k8s.NewSecretsManager(clientSet, secretEventsChan, ctrl.Log.WithName("secrets-manager"))
 	return nil
 }
@@ -1,9 +1,21 @@
 package ingress
 
 import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
-	"testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestIsIngressStatusEqual(t *testing.T) {
@@ -288,3 +300,75 @@ func TestIsIngressStatusEqual(t *testing.T) {
 		})
 	}
 }
+
+func Test_groupReconciler_updateRejectedMembersStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		ports     []int32
+		oldStatus []networking.IngressLoadBalancerIngress
+		want      []networking.IngressLoadBalancerIngress
+	}{
+		{
+			name:  "reports the rejection on each listener port without an address",
+			ports: []int32{80, 443},
+			oldStatus: []networking.IngressLoadBalancerIngress{
+				{Hostname: "my-alb.us-west-2.elb.amazonaws.com"},
+			},
+			want: []networking.IngressLoadBalancerIngress{
+				{
+					Ports: []networking.IngressPortStatus{
+						{Port: 80, Protocol: corev1.ProtocolTCP, Error: awssdk.String("ingress.k8s.aws/HostOwnedByAnotherNamespace")},
+						{Port: 443, Protocol: corev1.ProtocolTCP, Error: awssdk.String("ingress.k8s.aws/HostOwnedByAnotherNamespace")},
+					},
+				},
+			},
+		},
+		{
+			name:  "clears the status when the group has no listeners",
+			ports: nil,
+			oldStatus: []networking.IngressLoadBalancerIngress{
+				{Hostname: "my-alb.us-west-2.elb.amazonaws.com"},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "ing"},
+				Status: networking.IngressStatus{
+					LoadBalancer: networking.IngressLoadBalancerStatus{Ingress: tt.oldStatus},
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(ing).
+				WithStatusSubresource(ing).
+				Build()
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &groupReconciler{
+				k8sClient:     k8sClient,
+				eventRecorder: eventRecorder,
+			}
+
+			ingGroup := ingress.Group{
+				ID: ingress.NewGroupIDForExplicitGroup("shared"),
+				RejectedMembers: []ingress.RejectedIngress{
+					{
+						Ing:     ing.DeepCopy(),
+						Reason:  ingress.GroupMemberRejectedReasonHostOwnedByAnotherNamespace,
+						Message: "host \"app.example.com\" is owned by namespace team-a",
+					},
+				},
+			}
+			require.NoError(t, r.updateRejectedMembersStatus(context.Background(), ingGroup, tt.ports))
+
+			updatedIng := &networking.Ingress{}
+			require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "team-b", Name: "ing"}, updatedIng))
+			assert.Equal(t, tt.want, updatedIng.Status.LoadBalancer.Ingress)
+			assert.Equal(t, "Warning GroupMemberRejected Rejected from IngressGroup shared: host \"app.example.com\" is owned by namespace team-a", <-eventRecorder.Events)
+		})
+	}
+}
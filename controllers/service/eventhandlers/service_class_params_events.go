@@ -0,0 +1,91 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	svcpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForServiceClassParamsEvent constructs new enqueueRequestsForServiceClassParamsEvent.
+func NewEnqueueRequestsForServiceClassParamsEvent(k8sClient client.Client, serviceUtils svcpkg.ServiceUtils,
+	groupLoader svcpkg.GroupLoader, logger logr.Logger) handler.TypedEventHandler[*elbv2api.ServiceClassParams, reconcile.Request] {
+	return &enqueueRequestsForServiceClassParamsEvent{
+		k8sClient:    k8sClient,
+		serviceUtils: serviceUtils,
+		groupLoader:  groupLoader,
+		logger:       logger,
+	}
+}
+
+var _ handler.TypedEventHandler[*elbv2api.ServiceClassParams, reconcile.Request] = (*enqueueRequestsForServiceClassParamsEvent)(nil)
+
+type enqueueRequestsForServiceClassParamsEvent struct {
+	k8sClient    client.Client
+	serviceUtils svcpkg.ServiceUtils
+	groupLoader  svcpkg.GroupLoader
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForServiceClassParamsEvent) Create(ctx context.Context, e event.TypedCreateEvent[*elbv2api.ServiceClassParams], queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueImpactedServices(ctx, queue, e.Object.Spec.LoadBalancerClass)
+}
+
+func (h *enqueueRequestsForServiceClassParamsEvent) Update(ctx context.Context, e event.TypedUpdateEvent[*elbv2api.ServiceClassParams], queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	svcClassParamsOld := e.ObjectOld
+	svcClassParamsNew := e.ObjectNew
+	if equality.Semantic.DeepEqual(svcClassParamsOld.Spec, svcClassParamsNew.Spec) {
+		return
+	}
+	h.enqueueImpactedServices(ctx, queue, svcClassParamsNew.Spec.LoadBalancerClass)
+	if svcClassParamsOld.Spec.LoadBalancerClass != svcClassParamsNew.Spec.LoadBalancerClass {
+		h.enqueueImpactedServices(ctx, queue, svcClassParamsOld.Spec.LoadBalancerClass)
+	}
+}
+
+func (h *enqueueRequestsForServiceClassParamsEvent) Delete(ctx context.Context, e event.TypedDeleteEvent[*elbv2api.ServiceClassParams], queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueImpactedServices(ctx, queue, e.Object.Spec.LoadBalancerClass)
+}
+
+func (h *enqueueRequestsForServiceClassParamsEvent) Generic(context.Context, event.TypedGenericEvent[*elbv2api.ServiceClassParams], workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// we don't have any generic event for ServiceClassParams.
+}
+
+func (h *enqueueRequestsForServiceClassParamsEvent) enqueueImpactedServices(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request], lbClass string) {
+	svcList := &corev1.ServiceList{}
+	if err := h.k8sClient.List(ctx, svcList); err != nil {
+		h.logger.Error(err, "failed to fetch services")
+		return
+	}
+	for index := range svcList.Items {
+		svc := &svcList.Items[index]
+		if svc.Spec.LoadBalancerClass == nil || *svc.Spec.LoadBalancerClass != lbClass || !h.serviceUtils.IsServiceSupported(svc) {
+			continue
+		}
+		if groupName, err := h.groupLoader.LoadGroupNameIfAny(svc); err == nil && groupName != "" {
+			h.logger.V(1).Info("enqueue service group for serviceClassParams event",
+				"loadBalancerClass", lbClass,
+				"serviceGroup", groupName)
+			queue.Add(svcpkg.EncodeGroupNameToReconcileRequest(groupName))
+			continue
+		}
+		h.logger.V(1).Info("enqueue service for serviceClassParams event",
+			"loadBalancerClass", lbClass,
+			"service", svc.Name)
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: svc.Namespace,
+				Name:      svc.Name,
+			},
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	svcpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/service"
@@ -17,10 +18,11 @@ import (
 
 // NewEnqueueRequestForServiceEvent constructs new enqueueRequestsForServiceEvent.
 func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder,
-	serviceUtils svcpkg.ServiceUtils, logger logr.Logger) *enqueueRequestsForServiceEvent {
+	serviceUtils svcpkg.ServiceUtils, groupLoader svcpkg.GroupLoader, logger logr.Logger) *enqueueRequestsForServiceEvent {
 	return &enqueueRequestsForServiceEvent{
 		eventRecorder: eventRecorder,
 		serviceUtils:  serviceUtils,
+		groupLoader:   groupLoader,
 		logger:        logger,
 	}
 }
@@ -30,6 +32,7 @@ var _ handler.EventHandler = (*enqueueRequestsForServiceEvent)(nil)
 type enqueueRequestsForServiceEvent struct {
 	eventRecorder record.EventRecorder
 	serviceUtils  svcpkg.ServiceUtils
+	groupLoader   svcpkg.GroupLoader
 	logger        logr.Logger
 }
 
@@ -62,8 +65,22 @@ func (h *enqueueRequestsForServiceEvent) Generic(ctx context.Context, e event.Ge
 }
 
 func (h *enqueueRequestsForServiceEvent) enqueueManagedService(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request], service *corev1.Service) {
+	// Service groups the svc joins or leaves need to be handled as well
+	// an invalid group name is reported when reconciling the svc itself
+	groupName, _ := h.groupLoader.LoadGroupNameIfAny(service)
+	groupNames := sets.New(h.groupLoader.LoadGroupNamesPendingFinalization(service)...)
+	if groupName != "" {
+		groupNames.Insert(groupName)
+	}
+	for _, name := range sets.List(groupNames) {
+		h.logger.V(1).Info("enqueue service group for service event",
+			"service", service.Name,
+			"serviceGroup", name)
+		queue.Add(svcpkg.EncodeGroupNameToReconcileRequest(name))
+	}
+
 	// Check if the svc needs to be handled
-	if !h.serviceUtils.IsServicePendingFinalization(service) && !h.serviceUtils.IsServiceSupported(service) {
+	if !h.serviceUtils.IsServicePendingFinalization(service) && (!h.serviceUtils.IsServiceSupported(service) || groupName != "") {
 		return
 	}
 	queue.Add(reconcile.Request{
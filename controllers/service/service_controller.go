@@ -15,9 +15,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
@@ -34,6 +37,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -51,12 +55,17 @@ func NewServiceReconciler(cloud services.Cloud, k8sClient client.Client, eventRe
 
 	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
 	trackingProvider := tracking.NewDefaultProvider(serviceTagPrefix, controllerConfig.ClusterName)
-	serviceUtils := service.NewServiceUtils(annotationParser, shared_constants.ServiceFinalizer, controllerConfig.ServiceConfig.LoadBalancerClass, controllerConfig.FeatureGates)
+	serviceUtils := service.NewServiceUtils(annotationParser, shared_constants.ServiceFinalizer, controllerConfig.ServiceConfig.LoadBalancerClasses(), controllerConfig.FeatureGates)
 	enhancedBackendBuilder := service.NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, logger)
+	certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerConfig.IngressConfig.AllowedCertificateAuthorityARNs, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.CertDiscoveryConfig.SelectionPolicy(), logger)
 	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, vpcInfoProvider, cloud.VpcID(), trackingProvider,
 		elbv2TaggingManager, cloud.EC2(), controllerConfig.FeatureGates, controllerConfig.ClusterName, controllerConfig.DefaultTags, controllerConfig.ExternalManagedTags,
 		controllerConfig.DefaultSSLPolicy, controllerConfig.DefaultTargetType, controllerConfig.DefaultLoadBalancerScheme, controllerConfig.FeatureGates.Enabled(config.EnableIPTargetType), serviceUtils,
-		backendSGProvider, sgResolver, controllerConfig.EnableBackendSecurityGroup, controllerConfig.EnableManageBackendSecurityGroupRules, controllerConfig.DisableRestrictedSGRules, logger, metricsCollector, controllerConfig.FeatureGates.Enabled(config.EnableTCPUDPListenerType), enhancedBackendBuilder)
+		backendSGProvider, sgResolver, controllerConfig.EnableBackendSecurityGroup, controllerConfig.EnableManageBackendSecurityGroupRules, controllerConfig.DisableRestrictedSGRules, logger, metricsCollector, controllerConfig.FeatureGates.Enabled(config.EnableTCPUDPListenerType), enhancedBackendBuilder,
+		service.NewDefaultClassParamsLoader(k8sClient), service.NewDefaultClassParamsMerger(serviceAnnotationPrefix, annotationParser),
+		certDiscovery, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.IngressConfig.DefaultPCAArn)
+	groupLoader := service.NewDefaultGroupLoader(k8sClient, annotationParser, serviceUtils)
+	groupFinalizerManager := service.NewDefaultFinalizerManager(finalizerManager)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingManager, networkingSGManager, networkingSGReconciler, elbv2TaggingManager, controllerConfig, serviceTagPrefix, logger, metricsCollector, controllerName, controllerConfig.FeatureGates.Enabled(config.EnhancedDefaultBehavior), targetGroupCollector, false)
 	return &serviceReconciler{
@@ -68,6 +77,9 @@ func NewServiceReconciler(cloud services.Cloud, k8sClient client.Client, eventRe
 		serviceUtils:      serviceUtils,
 		backendSGProvider: backendSGProvider,
 
+		groupLoader:           groupLoader,
+		groupFinalizerManager: groupFinalizerManager,
+
 		modelBuilder:    modelBuilder,
 		stackMarshaller: stackMarshaller,
 		stackDeployer:   stackDeployer,
@@ -88,6 +100,9 @@ type serviceReconciler struct {
 	serviceUtils      service.ServiceUtils
 	backendSGProvider networking.BackendSGProvider
 
+	groupLoader           service.GroupLoader
+	groupFinalizerManager service.FinalizerManager
+
 	modelBuilder      service.ModelBuilder
 	stackMarshaller   deploy.StackMarshaller
 	stackDeployer     deploy.StackDeployer
@@ -101,6 +116,7 @@ type serviceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=services/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=serviceclassparams,verbs=get;list;watch
 
 func (r *serviceReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
 	r.reconcileCounters.IncrementService(req.NamespacedName)
@@ -108,6 +124,9 @@ func (r *serviceReconciler) Reconcile(ctx context.Context, req reconcile.Request
 }
 
 func (r *serviceReconciler) reconcile(ctx context.Context, req reconcile.Request) error {
+	if groupName, isGroup := service.DecodeGroupNameFromReconcileRequest(req); isGroup {
+		return r.reconcileGroup(ctx, groupName)
+	}
 	svc := &corev1.Service{}
 	var err error
 	fetchServiceFn := func() {
@@ -118,6 +137,21 @@ func (r *serviceReconciler) reconcile(ctx context.Context, req reconcile.Request
 		return client.IgnoreNotFound(err)
 	}
 
+	groupName, err := r.groupLoader.LoadGroupNameIfAny(svc)
+	if err != nil {
+		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "load_service_group_error", err, r.metricsCollector)
+	}
+	if groupName != "" {
+		// the Service joined a service group, its own load balancer (if any) is no longer needed.
+		// the status is owned by the service group from now on.
+		emptyStack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(svc)))
+		if err := r.cleanupLoadBalancerResources(ctx, svc, emptyStack, false); err != nil {
+			return ctrlerrors.NewErrorWithMetrics(controllerName, "cleanup_load_balancer_error", err, r.metricsCollector)
+		}
+		return nil
+	}
+
 	var stack core.Stack
 	var lb *elbv2model.LoadBalancer
 	var backendSGRequired bool
@@ -131,7 +165,7 @@ func (r *serviceReconciler) reconcile(ctx context.Context, req reconcile.Request
 
 	if lb == nil {
 		cleanupLoadBalancerFn := func() {
-			err = r.cleanupLoadBalancerResources(ctx, svc, stack)
+			err = r.cleanupLoadBalancerResources(ctx, svc, stack, true)
 		}
 		r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "cleanup_load_balancer", cleanupLoadBalancerFn)
 		if err != nil {
@@ -218,10 +252,10 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		return ctrlerrors.NewErrorWithMetrics(controllerName, "update_status_error", err, r.metricsCollector)
 	}
 	r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
-	return nil
+	return capacityreservation.RequeueAtNextTransition(lb)
 }
 
-func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service, stack core.Stack) error {
+func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service, stack core.Stack, cleanupStatus bool) error {
 	if k8s.HasFinalizer(svc, shared_constants.ServiceFinalizer) {
 		err := r.deployModel(ctx, svc, stack)
 		if err != nil {
@@ -230,9 +264,11 @@ func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, sv
 		if err := r.backendSGProvider.Release(ctx, networking.ResourceTypeService, []types.NamespacedName{k8s.NamespacedName(svc)}); err != nil {
 			return err
 		}
-		if err = r.cleanupServiceStatus(ctx, svc); err != nil {
-			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedCleanupStatus, fmt.Sprintf("Failed update status due to %v", err))
-			return err
+		if cleanupStatus {
+			if err = r.cleanupServiceStatus(ctx, svc); err != nil {
+				r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedCleanupStatus, fmt.Sprintf("Failed update status due to %v", err))
+				return err
+			}
 		}
 		if err := r.finalizerManager.RemoveFinalizers(ctx, svc, shared_constants.ServiceFinalizer); err != nil {
 			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
@@ -242,6 +278,181 @@ func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, sv
 	return nil
 }
 
+func (r *serviceReconciler) reconcileGroup(ctx context.Context, groupName string) error {
+	var group service.ServiceGroup
+	var err error
+	loadGroupFn := func() {
+		group, err = r.groupLoader.Load(ctx, groupName)
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "load_service_group", loadGroupFn)
+	if err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "load_service_group_error", err, r.metricsCollector)
+	}
+	for _, conflicted := range group.ConflictedMembers {
+		r.eventRecorder.Event(conflicted.Service, corev1.EventTypeWarning, k8s.ServiceEventReasonConflictingGroupPort,
+			fmt.Sprintf("Port %v is already used by Service %v in service group %v", conflicted.Port, conflicted.ConflictsWith, groupName))
+	}
+
+	addFinalizersFn := func() {
+		err = r.groupFinalizerManager.AddGroupFinalizer(ctx, groupName, group.Members)
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "add_group_finalizers", addFinalizersFn)
+	if err != nil {
+		r.recordGroupEvent(group, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "add_group_finalizers_error", err, r.metricsCollector)
+	}
+
+	var stack core.Stack
+	var lb *elbv2model.LoadBalancer
+	var backendSGRequired bool
+	buildModelFn := func() {
+		stack, lb, backendSGRequired, err = r.buildGroupModel(ctx, group)
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "build_model", buildModelFn)
+	if err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "build_model_error", err, r.metricsCollector)
+	}
+
+	deployModelFn := func() {
+		err = r.deployGroupModel(ctx, group, stack)
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "deploy_model", deployModelFn)
+	if err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "deploy_model_error", err, r.metricsCollector)
+	}
+
+	var lbDNS string
+	if lb != nil {
+		dnsResolveFn := func() {
+			lbDNS, err = lb.DNSName().Resolve(ctx)
+		}
+		r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "DNS_resolve", dnsResolveFn)
+		if err != nil {
+			return ctrlerrors.NewErrorWithMetrics(controllerName, "dns_resolve_error", err, r.metricsCollector)
+		}
+		lbDNS = strings.ToLower(lbDNS)
+	}
+
+	memberKeys := make([]types.NamespacedName, 0, len(group.Members))
+	for _, member := range group.Members {
+		memberKeys = append(memberKeys, k8s.NamespacedName(member))
+	}
+	if !backendSGRequired && len(memberKeys) > 0 {
+		if err := r.backendSGProvider.Release(ctx, networking.ResourceTypeService, memberKeys); err != nil {
+			return ctrlerrors.NewErrorWithMetrics(controllerName, "release_auto_generated_backend_sg_error", err, r.metricsCollector)
+		}
+	}
+
+	updateStatusFn := func() {
+		for _, member := range group.Members {
+			if err = r.updateServiceStatus(ctx, lbDNS, member); err != nil {
+				r.eventRecorder.Event(member, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+				return
+			}
+		}
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "update_status", updateStatusFn)
+	if err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "update_status_error", err, r.metricsCollector)
+	}
+
+	releaseMembersFn := func() {
+		err = r.releaseGroupMembers(ctx, group)
+	}
+	r.metricsCollector.ObserveControllerReconcileLatency(controllerName, "release_group_members", releaseMembersFn)
+	if err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "release_group_members_error", err, r.metricsCollector)
+	}
+	r.recordGroupEvent(group, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	return nil
+}
+
+func (r *serviceReconciler) buildGroupModel(ctx context.Context, group service.ServiceGroup) (core.Stack, *elbv2model.LoadBalancer, bool, error) {
+	stack, lb, backendSGRequired, err := r.modelBuilder.BuildGroup(ctx, group, r.metricsCollector)
+	if err != nil {
+		r.recordGroupEvent(group, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		return nil, nil, false, err
+	}
+	stackJSON, err := r.stackMarshaller.Marshal(stack)
+	if err != nil {
+		r.recordGroupEvent(group, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		return nil, nil, false, err
+	}
+	r.logger.Info("successfully built model", "serviceGroup", group.Name, "model", stackJSON)
+	return stack, lb, backendSGRequired, nil
+}
+
+func (r *serviceReconciler) deployGroupModel(ctx context.Context, group service.ServiceGroup, stack core.Stack) error {
+	if err := r.stackDeployer.Deploy(ctx, stack, r.metricsCollector, "service"); err != nil {
+		var requeueNeededAfter *ctrlerrors.RequeueNeededAfter
+		if errors.As(err, &requeueNeededAfter) {
+			return err
+		}
+		r.recordGroupEvent(group, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+		return err
+	}
+	r.logger.Info("successfully deployed model", "serviceGroup", group.Name)
+	return nil
+}
+
+// releaseGroupMembers detaches the Services that no longer have listeners on the service group load balancer.
+// Their status is only cleared when no other load balancer publishes into it.
+func (r *serviceReconciler) releaseGroupMembers(ctx context.Context, group service.ServiceGroup) error {
+	finalizer := service.BuildGroupFinalizer(group.Name)
+	releasedMembers := append([]*corev1.Service{}, group.InactiveMembers...)
+	for _, conflicted := range group.ConflictedMembers {
+		if k8s.HasFinalizer(conflicted.Service, finalizer) {
+			releasedMembers = append(releasedMembers, conflicted.Service)
+		}
+	}
+	if len(releasedMembers) == 0 {
+		return nil
+	}
+	releasedKeys := make([]types.NamespacedName, 0, len(releasedMembers))
+	for _, svc := range releasedMembers {
+		releasedKeys = append(releasedKeys, k8s.NamespacedName(svc))
+	}
+	if err := r.backendSGProvider.Release(ctx, networking.ResourceTypeService, releasedKeys); err != nil {
+		return err
+	}
+	for _, svc := range releasedMembers {
+		if hasOtherLoadBalancerFinalizer(svc, finalizer) {
+			continue
+		}
+		if err := r.cleanupServiceStatus(ctx, svc); err != nil {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedCleanupStatus, fmt.Sprintf("Failed update status due to %v", err))
+			return err
+		}
+	}
+	if err := r.groupFinalizerManager.RemoveGroupFinalizer(ctx, group.Name, releasedMembers); err != nil {
+		for _, svc := range releasedMembers {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+		}
+		return err
+	}
+	return nil
+}
+
+// recordGroupEvent records an event on every member of the service group.
+func (r *serviceReconciler) recordGroupEvent(group service.ServiceGroup, eventType string, reason string, message string) {
+	for _, member := range group.Members {
+		r.eventRecorder.Event(member, eventType, reason, message)
+	}
+}
+
+// hasOtherLoadBalancerFinalizer checks whether Service is hosted by a load balancer other than the one guarded by finalizer.
+func hasOtherLoadBalancerFinalizer(svc *corev1.Service, finalizer string) bool {
+	for _, f := range svc.GetFinalizers() {
+		if f == finalizer {
+			continue
+		}
+		if f == shared_constants.ServiceFinalizer || strings.HasPrefix(f, shared_constants.ServiceGroupFinalizerPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS string, svc *corev1.Service) error {
 	if len(svc.Status.LoadBalancer.Ingress) != 1 ||
 		svc.Status.LoadBalancer.Ingress[0].IP != "" ||
@@ -319,11 +530,14 @@ func (r *serviceReconciler) cleanupServiceStatus(ctx context.Context, svc *corev
 
 func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.eventRecorder,
-		r.serviceUtils, r.logger.WithName("eventHandlers").WithName("service"))
+		r.serviceUtils, r.groupLoader, r.logger.WithName("eventHandlers").WithName("service"))
+	svcClassParamsEventHandler := eventhandlers.NewEnqueueRequestsForServiceClassParamsEvent(r.k8sClient,
+		r.serviceUtils, r.groupLoader, r.logger.WithName("eventHandlers").WithName("serviceClassParams"))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		Watches(&corev1.Service{}, svcEventHandler).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &elbv2api.ServiceClassParams{}, svcClassParamsEventHandler)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.maxConcurrentReconciles,
 		}).
@@ -7,15 +7,22 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -28,12 +35,19 @@ type mockModelBuilder struct {
 	lb        *elbv2model.LoadBalancer
 	backendSG bool
 	err       error
+
+	builtGroups []service.ServiceGroup
 }
 
 func (m *mockModelBuilder) Build(_ context.Context, _ *corev1.Service, _ lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error) {
 	return m.stack, m.lb, m.backendSG, m.err
 }
 
+func (m *mockModelBuilder) BuildGroup(_ context.Context, group service.ServiceGroup, _ lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error) {
+	m.builtGroups = append(m.builtGroups, group)
+	return m.stack, m.lb, m.backendSG, m.err
+}
+
 type mockStackDeployer struct {
 	err           error
 	deployedCount int
@@ -68,25 +82,45 @@ func (m *mockMetricsCollector) ObserveControllerReconcileError(_ string, _ strin
 func (m *mockMetricsCollector) ObserveControllerReconcileLatency(_ string, _ string, fn func())  { fn() }
 func (m *mockMetricsCollector) ObserveWebhookValidationError(_ string, _ string)                 {}
 func (m *mockMetricsCollector) ObserveWebhookMutationError(_ string, _ string)                   {}
-func (m *mockMetricsCollector) StartCollectTopTalkers(_ context.Context)                         {}
-func (m *mockMetricsCollector) StartCollectCacheSize(_ context.Context)                          {}
+func (m *mockMetricsCollector) ObserveCapacityReservation(_ string, _ int32, _ *int32, _ *time.Time) {
+}
+func (m *mockMetricsCollector) DeleteCapacityReservation(_ string) {}
+func (m *mockMetricsCollector) ObserveALBTargetControlAgentPods(_ string, _ string, _ int, _ int, _ int) {
+}
+func (m *mockMetricsCollector) DeleteALBTargetControlAgentPods(_ string, _ string)    {}
+func (m *mockMetricsCollector) ObserveQUICTargetDuplicateServerId(_ string, _ string) {}
+func (m *mockMetricsCollector) ObserveQUICServerIDAllocationConflict(_ string)        {}
+func (m *mockMetricsCollector) ObserveQUICServerIDClaims(_ string, _ int, _ int, _ int, _ int) {
+}
+func (m *mockMetricsCollector) DeleteQUICServerIDClaims(_ string)        {}
+func (m *mockMetricsCollector) StartCollectTopTalkers(_ context.Context) {}
+func (m *mockMetricsCollector) StartCollectCacheSize(_ context.Context)  {}
 
 // buildTestReconciler wires up a serviceReconciler with the given mocks and a real fake k8s client
-// pre-populated with svc.
-func buildTestReconciler(svc *corev1.Service, mb *mockModelBuilder, sd *mockStackDeployer) *serviceReconciler {
+// pre-populated with svcs.
+func buildTestReconciler(mb *mockModelBuilder, sd *mockStackDeployer, svcs ...*corev1.Service) *serviceReconciler {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
-	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build()
+	objects := make([]client.Object, 0, len(svcs))
+	for _, svc := range svcs {
+		objects = append(objects, svc)
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&corev1.Service{}).Build()
+	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+	serviceUtils := service.NewServiceUtils(annotationParser, shared_constants.ServiceFinalizer, []string{"service.k8s.aws/nlb"}, config.NewFeatureGates())
 
 	return &serviceReconciler{
-		k8sClient:        k8sClient,
-		eventRecorder:    record.NewFakeRecorder(10),
-		finalizerManager: &mockFinalizerManager{},
-		modelBuilder:     mb,
-		stackMarshaller:  &mockStackMarshaller{},
-		stackDeployer:    sd,
-		logger:           logr.Discard(),
-		metricsCollector: &mockMetricsCollector{},
+		k8sClient:             k8sClient,
+		eventRecorder:         record.NewFakeRecorder(10),
+		finalizerManager:      &mockFinalizerManager{},
+		serviceUtils:          serviceUtils,
+		groupLoader:           service.NewDefaultGroupLoader(k8sClient, annotationParser, serviceUtils),
+		groupFinalizerManager: service.NewDefaultFinalizerManager(k8s.NewDefaultFinalizerManager(k8sClient, logr.Discard())),
+		modelBuilder:          mb,
+		stackMarshaller:       &mockStackMarshaller{},
+		stackDeployer:         sd,
+		logger:                logr.Discard(),
+		metricsCollector:      &mockMetricsCollector{},
 	}
 }
 
@@ -144,7 +178,7 @@ func TestReconcile(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mb := &mockModelBuilder{stack: stack, lb: tt.lb}
 			sd := &mockStackDeployer{err: tt.deployErr}
-			r := buildTestReconciler(tt.svc, mb, sd)
+			r := buildTestReconciler(mb, sd, tt.svc)
 
 			err := r.reconcile(context.Background(), reconcile.Request{
 				NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-svc"},
@@ -160,6 +194,141 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcile_serviceGroup(t *testing.T) {
+	nlbClass := "service.k8s.aws/nlb"
+	groupedSvc := func(name string, port int32, finalizers ...string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"},
+				Finalizers:  finalizers,
+			},
+			Spec: corev1.ServiceSpec{
+				Type:              corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass: &nlbClass,
+				Ports:             []corev1.ServicePort{{Protocol: corev1.ProtocolTCP, Port: port}},
+			},
+		}
+	}
+	leftSvc := func(name string, finalizers ...string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Finalizers: finalizers},
+			Spec: corev1.ServiceSpec{
+				Type:              corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass: &nlbClass,
+				Ports:             []corev1.ServicePort{{Protocol: corev1.ProtocolTCP, Port: 22}},
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{Hostname: "shared-nlb.elb.amazonaws.com"}},
+				},
+			},
+		}
+	}
+
+	stack := core.NewDefaultStack(service.NewGroupStackID("shared"))
+	lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+	lb.Status = &elbv2model.LoadBalancerStatus{DNSName: "Shared-NLB.elb.amazonaws.com"}
+	mb := &mockModelBuilder{stack: stack, lb: lb}
+	sd := &mockStackDeployer{}
+	r := buildTestReconciler(mb, sd,
+		groupedSvc("svc-a", 80),
+		groupedSvc("svc-b", 443, "group.service.k8s.aws/shared"),
+		groupedSvc("svc-c", 80, "group.service.k8s.aws/shared"),
+		leftSvc("svc-d", "group.service.k8s.aws/shared"),
+		leftSvc("svc-e", "group.service.k8s.aws/shared", "service.k8s.aws/resources"),
+	)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backendSGProvider := networking.NewMockBackendSGProvider(ctrl)
+	backendSGProvider.EXPECT().Release(gomock.Any(), networking.ResourceType(networking.ResourceTypeService), []types.NamespacedName{
+		{Namespace: "default", Name: "svc-a"}, {Namespace: "default", Name: "svc-b"},
+	}).Return(nil)
+	backendSGProvider.EXPECT().Release(gomock.Any(), networking.ResourceType(networking.ResourceTypeService), []types.NamespacedName{
+		{Namespace: "default", Name: "svc-d"}, {Namespace: "default", Name: "svc-e"}, {Namespace: "default", Name: "svc-c"},
+	}).Return(nil)
+	r.backendSGProvider = backendSGProvider
+
+	err := r.reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "shared"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sd.deployedCount)
+	assert.Len(t, mb.builtGroups, 1)
+
+	getSvc := func(name string) *corev1.Service {
+		svc := &corev1.Service{}
+		assert.NoError(t, r.k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, svc))
+		return svc
+	}
+	for _, name := range []string{"svc-a", "svc-b"} {
+		svc := getSvc(name)
+		assert.Equal(t, []string{"group.service.k8s.aws/shared"}, svc.Finalizers, name)
+		assert.Equal(t, "shared-nlb.elb.amazonaws.com", svc.Status.LoadBalancer.Ingress[0].Hostname, name)
+	}
+	conflicted := getSvc("svc-c")
+	assert.Empty(t, conflicted.Finalizers)
+	assert.Empty(t, conflicted.Status.LoadBalancer.Ingress)
+	left := getSvc("svc-d")
+	assert.Empty(t, left.Finalizers)
+	assert.Empty(t, left.Status.LoadBalancer.Ingress)
+	leftToStandalone := getSvc("svc-e")
+	assert.Equal(t, []string{"service.k8s.aws/resources"}, leftToStandalone.Finalizers)
+	assert.Equal(t, "shared-nlb.elb.amazonaws.com", leftToStandalone.Status.LoadBalancer.Ingress[0].Hostname)
+
+	events := r.eventRecorder.(*record.FakeRecorder).Events
+	assert.Contains(t, drainEvents(events), "Warning ConflictingGroupPort Port 80 is already used by Service default/svc-a in service group shared")
+}
+
+func TestReconcile_serviceJoinsGroup(t *testing.T) {
+	nlbClass := "service.k8s.aws/nlb"
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "my-svc",
+			Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"},
+			Finalizers:  []string{"service.k8s.aws/resources", "group.service.k8s.aws/shared"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:              corev1.ServiceTypeLoadBalancer,
+			LoadBalancerClass: &nlbClass,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{Hostname: "shared-nlb.elb.amazonaws.com"}},
+			},
+		},
+	}
+	mb := &mockModelBuilder{}
+	sd := &mockStackDeployer{}
+	r := buildTestReconciler(mb, sd, svc)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backendSGProvider := networking.NewMockBackendSGProvider(ctrl)
+	backendSGProvider.EXPECT().Release(gomock.Any(), networking.ResourceType(networking.ResourceTypeService), []types.NamespacedName{
+		{Namespace: "default", Name: "my-svc"},
+	}).Return(nil)
+	r.backendSGProvider = backendSGProvider
+
+	err := r.reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-svc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sd.deployedCount)
+	got := &corev1.Service{}
+	assert.NoError(t, r.k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "my-svc"}, got))
+	assert.Equal(t, "shared-nlb.elb.amazonaws.com", got.Status.LoadBalancer.Ingress[0].Hostname)
+}
+
+func drainEvents(events chan string) []string {
+	var got []string
+	for {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
 func TestBuildPortsForStatus(t *testing.T) {
 	tests := []struct {
 		name     string
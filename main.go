@@ -19,10 +19,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
 
@@ -35,6 +38,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/referencecounter"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/routeutils"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/pod_readiness"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/pod_termination"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/quic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -62,18 +66,24 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/albtargetcontrol"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/aws"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	metricsutil "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/util"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/rollout"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	svcpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/service"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webacl"
 	agawebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/aga"
 	corewebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/core"
 	elbv2webhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/elbv2"
+	gatewaywebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/gateway"
 	networkingwebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/networking"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -121,6 +131,7 @@ type gatewayControllerConfig struct {
 	targetGroupARNMapper     shared_utils.TargetGroupARNMapper
 	certDiscovery            certs.CertDiscovery
 	listenerSetStatusUpdater gateway.ListenerSetStatusSubmitter
+	routeStatusSubmitter     routeutils.RouteReconcilerSubmitter
 }
 
 func main() {
@@ -210,8 +221,9 @@ func main() {
 
 	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
 		podInfoRepo, networkingManager, vpcInfoProvider, multiClusterManager, lbcMetricsCollector,
-		cloud.VpcID(), controllerCFG.FeatureGates.Enabled(config.EndpointsFailOpen), controllerCFG.EnableEndpointSlices,
-		mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log, controllerCFG.MaxTargetsPerTargetGroup, controllerCFG.TargetGroupBindingRequeueDuration)
+		targetGroupCollector, cloud.VpcID(), controllerCFG.FeatureGates.Enabled(config.EndpointsFailOpen), controllerCFG.EnableEndpointSlices,
+		mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log, controllerCFG.MaxTargetsPerTargetGroup, controllerCFG.TargetGroupBindingRequeueDuration,
+		controllerCFG.PodTerminationGateConfig.PodTerminationGateTimeout, controllerCFG.TargetHealthRefreshInterval)
 	backendSGProvider := networking.NewBackendSGProvider(controllerCFG.ClusterName, controllerCFG.BackendSecurityGroup,
 		cloud.VpcID(), cloud.EC2(), mgr.GetClient(), controllerCFG.DefaultTags, nlbGatewayEnabled || albGatewayEnabled, ctrl.Log.WithName("backend-sg-provider"))
 	sgResolver := networking.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID())
@@ -252,6 +264,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup TrafficRollout controller only if enabled
+	if controllerCFG.FeatureGates.Enabled(config.TrafficRollout) {
+		rolloutAnalyzer := rollout.NewDefaultAnalyzer(mgr.GetClient(), &http.Client{Timeout: 10 * time.Second},
+			controllerCFG.TrafficRolloutPrometheusAddresses)
+		rolloutManager := rollout.NewDefaultManager(mgr.GetClient(), rolloutAnalyzer, mgr.GetEventRecorderFor("trafficRollout"),
+			ctrl.Log.WithName("trafficRollout"))
+		rolloutReconciler := elbv2controller.NewTrafficRolloutReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("trafficRollout"),
+			rolloutManager, ctrl.Log.WithName("controllers").WithName("trafficRollout"))
+		if err := rolloutReconciler.SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TrafficRollout")
+			os.Exit(1)
+		}
+	}
+
+	// Setup WAFv2WebACL controller only if enabled
+	if controllerCFG.FeatureGates.Enabled(config.WAFv2WebACLManagement) {
+		webACLManager := webacl.NewDefaultManager(cloud.WAFv2(), tracking.NewDefaultProvider(webacl.TagPrefix, controllerCFG.ClusterName),
+			controllerCFG.ClusterName, controllerCFG.DefaultTags, controllerCFG.ExternalManagedTags, ctrl.Log.WithName("wafv2WebACL"))
+		webACLReconciler := elbv2controller.NewWAFv2WebACLReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("wafv2WebACL"),
+			finalizerManager, webACLManager, ctrl.Log.WithName("controllers").WithName("wafv2WebACL"))
+		if err := webACLReconciler.SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "WAFv2WebACL")
+			os.Exit(1)
+		}
+	}
+
 	// Setup GlobalAccelerator controller only if enabled
 	if aga.IsGlobalAcceleratorControllerEnabled(controllerCFG.FeatureGates, cloud.Region()) {
 		agaReconciler := agacontroller.NewGlobalAcceleratorReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("globalAccelerator"),
@@ -287,7 +325,7 @@ func main() {
 
 		routeReconciler := gateway.NewRouteReconciler(routeReconcilerQueue, mgr.GetClient(), ctrl.Log.WithName("routeReconciler"))
 		serviceReferenceCounter := referencecounter.NewServiceReferenceCounter()
-		certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerCFG.IngressConfig.AllowedCertificateAuthorityARNs, false, ctrl.Log.WithName("gateway-cert-discovery"))
+		certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerCFG.IngressConfig.AllowedCertificateAuthorityARNs, false, controllerCFG.CertDiscoveryConfig.SelectionPolicy(), ctrl.Log.WithName("gateway-cert-discovery"))
 
 		gwControllerConfig := &gatewayControllerConfig{
 			cloud:                    cloud,
@@ -309,6 +347,7 @@ func main() {
 			targetGroupARNMapper:     tgArnMapper,
 			certDiscovery:            certDiscovery,
 			listenerSetStatusUpdater: listenerSetStatusUpdater,
+			routeStatusSubmitter:     routeReconciler,
 		}
 
 		enabledControllers := sets.Set[string]{}
@@ -339,6 +378,8 @@ func main() {
 			enabledControllers.Insert(gateway_constants.ALBGatewayController)
 		}
 
+		gatewaywebhook.NewLoadBalancerConfigurationValidator(mgr.GetClient(), enabledControllers, lbcMetricsCollector).SetupWithManager(mgr)
+
 		gatewayClassReconciler := gateway.NewGatewayClassReconciler(
 			mgr.GetClient(),
 			mgr.GetEventRecorderFor(gateway_constants.GatewayClassController),
@@ -433,6 +474,38 @@ func main() {
 			}
 		}()
 	}
+	// Setup orphaned resource garbage collector only if enabled
+	if controllerCFG.FeatureGates.Enabled(config.OrphanedResourceGC) {
+		orphanedResourceCollector := gc.NewOrphanedResourceCollector(cloud, mgr.GetClient(), mgr.GetAPIReader(),
+			networkingManager, sgManager, sgReconciler, elbv2TaggingManager, controllerCFG, lbcMetricsCollector,
+			lbcmetrics.NewOrphanedResourceMetricsCollector(metrics.Registry), targetGroupCollector, ctrl.Log.WithName("orphaned-resource-gc"))
+		if err := mgr.Add(orphanedResourceCollector); err != nil {
+			setupLog.Error(err, "unable to add orphaned resource garbage collector")
+			os.Exit(1)
+		}
+	}
+
+	// Setup certificate expiry monitor only if enabled
+	if controllerCFG.FeatureGates.Enabled(config.CertificateExpiryMonitor) {
+		certExpiryMonitor := certs.NewCertExpiryMonitor(cloud.RGT(), cloud.ELBV2(), cloud.ACM(), controllerCFG.ClusterName,
+			controllerCFG.CertDiscoveryConfig.ExpiryMonitorInterval, lbcmetrics.NewCertificateExpiryMetricsCollector(metrics.Registry),
+			ctrl.Log.WithName("cert-expiry-monitor"))
+		if err := mgr.Add(certExpiryMonitor); err != nil {
+			setupLog.Error(err, "unable to add certificate expiry monitor")
+			os.Exit(1)
+		}
+	}
+
+	// Setup capacity reservation status reporter only if enabled
+	if controllerCFG.FeatureGates.Enabled(config.LBCapacityReservation) {
+		capacityReservationStatusReporter := capacityreservation.NewStatusReporter(mgr.GetClient(), nlbGatewayEnabled || albGatewayEnabled,
+			ctrl.Log.WithName("capacity-reservation-status-reporter"))
+		if err := mgr.Add(capacityReservationStatusReporter); err != nil {
+			setupLog.Error(err, "unable to add capacity reservation status reporter")
+			os.Exit(1)
+		}
+	}
+
 	// Add liveness probe
 	err = mgr.AddHealthzCheck("health-ping", healthz.Ping)
 	setupLog.Info("adding health check for controller")
@@ -452,28 +525,57 @@ func main() {
 	podReadinessGateInjector := pod_readiness.NewPodReadinessGate(controllerCFG.PodWebhookConfig,
 		mgr.GetClient(), ctrl.Log.WithName("pod-readiness-gate-injector"))
 
-	quicServerIDInjector := quic.NewQUICServerIDInjector(controllerCFG.ServerIDInjectionConfig, mgr.GetClient(), mgr.GetAPIReader(), ctrl.Log.WithName("quic-server-id-injector"))
+	// Setup QUIC server ID registry only if enabled
+	quicServerIDRegistryEnabled := controllerCFG.FeatureGates.Enabled(config.QUICServerIDRegistry)
+	if quicServerIDRegistryEnabled {
+		if err := mgr.GetFieldIndexer().IndexField(ctx, &elbv2api.QUICServerIDClaim{}, quic.IndexKeyServerIDClaimPodName,
+			quic.IndexFuncServerIDClaimPodName); err != nil {
+			setupLog.Error(err, "unable to setup QUIC server ID claim index")
+			os.Exit(1)
+		}
+		quicServerIDClaimReconciler := quic.NewServerIDClaimReconciler(mgr.GetClient(), mgr.GetAPIReader(), podInfoRepo,
+			mgr.GetEventRecorderFor("quicServerIDClaim"), lbcMetricsCollector, controllerCFG.RuntimeConfig.WatchNamespace,
+			controllerCFG.ServerIDInjectionConfig.CollisionRepair, ctrl.Log.WithName("quic-server-id-claim-reconciler"))
+		if err := mgr.Add(quicServerIDClaimReconciler); err != nil {
+			setupLog.Error(err, "unable to add QUIC server ID claim reconciler")
+			os.Exit(1)
+		}
+	}
+	quicServerIDInjector := quic.NewQUICServerIDInjector(controllerCFG.ServerIDInjectionConfig, mgr.GetClient(), mgr.GetAPIReader(),
+		quicServerIDRegistryEnabled, lbcMetricsCollector, ctrl.Log.WithName("quic-server-id-injector"))
 
 	corewebhook.NewPodReadinessGateMutator(podReadinessGateInjector, lbcMetricsCollector).SetupWithManager(mgr)
 	corewebhook.NewPodServerIDMutator(quicServerIDInjector, lbcMetricsCollector).SetupWithManager(mgr)
+	if controllerCFG.PodTerminationGateConfig.EnablePodTerminationGate {
+		podTerminationGate := pod_termination.NewPodTerminationGate(controllerCFG.PodTerminationGateConfig,
+			mgr.GetClient(), ctrl.Log.WithName("pod-termination-gate"))
+		corewebhook.NewPodTerminationGateValidator(podTerminationGate, mgr.GetAPIReader(), lbcMetricsCollector).SetupWithManager(mgr)
+	}
 
 	// Setup ALB target control agent sidecar injector if enabled
 	var targetControlAgentInjector albtargetcontrol.ALBTargetControlAgentInjector
 	if controllerCFG.FeatureGates.Enabled(config.ALBTargetControlAgent) {
 		targetControlAgentInjector = albtargetcontrol.NewALBTargetControlAgentInjector(
 			mgr.GetClient(),
-			mgr.GetAPIReader(),
 			ctrl.Log.WithName(albtargetcontrol.LoggerName),
 			albtargetcontrol.DefaultControllerNamespace,
 		)
+		targetControlConfigReconciler := elbv2controller.NewALBTargetControlConfigReconciler(mgr.GetClient(), mgr.GetAPIReader(), podInfoRepo,
+			mgr.GetEventRecorderFor("albTargetControlConfig"), lbcMetricsCollector, ctrl.Log.WithName("controllers").WithName("albTargetControlConfig"))
+		if err := targetControlConfigReconciler.SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ALBTargetControlConfig")
+			os.Exit(1)
+		}
 	}
 
 	// Setup ALB sidecar injection webhook mutator
 	if targetControlAgentInjector != nil {
 		corewebhook.NewALBTargetControlAgentMutator(targetControlAgentInjector, lbcMetricsCollector).SetupWithManager(mgr)
 	}
-	corewebhook.NewServiceMutator(controllerCFG.ServiceConfig.LoadBalancerClass, ctrl.Log, lbcMetricsCollector).SetupWithManager(mgr)
+	corewebhook.NewServiceMutator(controllerCFG.ServiceConfig.LoadBalancerClass, controllerCFG.ServiceConfig.AdditionalLoadBalancerClasses,
+		svcpkg.NewDefaultClassParamsLoader(mgr.GetClient()), ctrl.Log, lbcMetricsCollector).SetupWithManager(mgr)
 	elbv2webhook.NewIngressClassParamsValidator(lbcMetricsCollector).SetupWithManager(mgr)
+	elbv2webhook.NewServiceClassParamsValidator(mgr.GetClient(), lbcMetricsCollector).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log, lbcMetricsCollector).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingValidator(mgr.GetClient(), cloud.ELBV2(), cloud.VpcID(), ctrl.Log, lbcMetricsCollector).SetupWithManager(mgr)
 	networkingwebhook.NewIngressValidator(mgr.GetClient(), controllerCFG.IngressConfig, ctrl.Log, lbcMetricsCollector).SetupWithManager(mgr)
@@ -548,6 +650,7 @@ func setupGatewayController(ctx context.Context, mgr ctrl.Manager, cfg *gatewayC
 			cfg.targetGroupCollector,
 			cfg.targetGroupARNMapper,
 			cfg.listenerSetStatusUpdater,
+			cfg.routeStatusSubmitter,
 		)
 	case gateway_constants.ALBGatewayController:
 		reconciler = gateway.NewALBGatewayReconciler(
@@ -6,10 +6,15 @@ const (
 	WAFv2               Addon = "WAFv2"
 	Shield              Addon = "Shield"
 	ProvisionedCapacity Addon = "ProvisionedCapacity"
+
+	// The attributes of the Shield protection are tracked separately, so that they're reverted when removed from the configuration.
+	ShieldProtectionGroups                  Addon = "ShieldProtectionGroups"
+	ShieldHealthCheck                       Addon = "ShieldHealthCheck"
+	ShieldApplicationLayerAutomaticResponse Addon = "ShieldApplicationLayerAutomaticResponse"
 )
 
 var (
-	AllAddons = []Addon{WAFv2, Shield, ProvisionedCapacity}
+	AllAddons = []Addon{WAFv2, Shield, ProvisionedCapacity, ShieldProtectionGroups, ShieldHealthCheck, ShieldApplicationLayerAutomaticResponse}
 )
 
 type AddonMetadata struct {
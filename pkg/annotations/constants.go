@@ -8,6 +8,10 @@ const (
 	// AnnotationCheckPointTimestamp is the annotation used to store the last checkpointed time. The value is stored in seconds.
 	AnnotationCheckPointTimestamp = AnnotationCheckPoint + "-timestamp"
 
+	// AnnotationConfirmAcceleratorReplacement is the annotation used to confirm that a GlobalAccelerator change releasing static IP addresses can proceed.
+	// It must contain the ARN of the accelerator whose addresses are released, so that a confirmation only applies once.
+	AnnotationConfirmAcceleratorReplacement = "aga.k8s.aws/confirm-replacement"
+
 	// IngressClass
 	IngressClass = "kubernetes.io/ingress.class"
 
@@ -24,6 +28,7 @@ const (
 	IngressSuffixLoadBalancerAttributes                        = "load-balancer-attributes"
 	IngressSuffixWAFv2ACLARN                                   = "wafv2-acl-arn"
 	IngressSuffixWAFv2ACLName                                  = "wafv2-acl-name"
+	IngressSuffixWAFv2ACLRef                                   = "wafv2-acl-ref"
 	IngressSuffixWAFACLID                                      = "waf-acl-id"
 	IngressSuffixWebACLID                                      = "web-acl-id" // deprecated, use "waf-acl-id" instead.
 	IngressSuffixShieldAdvancedProtection                      = "shield-advanced-protection"
@@ -128,10 +133,21 @@ const (
 	SvcLBSuffixEnablePrefixForIpv6SourceNat              = "aws-load-balancer-enable-prefix-for-ipv6-source-nat"
 	SvcLBSuffixSourceNatIpv6Prefixes                     = "aws-load-balancer-source-nat-ipv6-prefixes"
 	SvcLBSuffixLoadBalancerCapacityReservation           = "aws-load-balancer-minimum-load-balancer-capacity"
+	SvcLBSuffixLoadBalancerCapacitySchedule              = "aws-load-balancer-minimum-load-balancer-capacity-schedule"
 	SvcLBSuffixEnableIcmpForPathMtuDiscovery             = "aws-load-balancer-enable-icmp-for-path-mtu-discovery"
 	SvcLBSuffixEnableTCPUDPListener                      = "aws-load-balancer-enable-tcp-udp-listener"
 	SvcLBSuffixDisableNLBSG                              = "aws-load-balancer-disable-nlb-sg"
 	SvcLBSuffixQUICEnabledPorts                          = "aws-load-balancer-quic-enabled-ports"
+	SvcLBSuffixGroupName                                 = "aws-load-balancer-group-name"
+	SvcLBSuffixGroupOrder                                = "aws-load-balancer-group-order"
+	SvcLBSuffixSSLCertificateHosts                       = "aws-load-balancer-ssl-cert-hosts"
+	SvcLBSuffixCreateCertificate                         = "aws-load-balancer-create-acm-cert"
+	SvcLBSuffixACMCaARN                                  = "aws-load-balancer-acm-pca-arn"
+)
+
+const (
+	// ExternalDNSHostname is the hostname annotation of external-dns, Services reuse it to look up or create ACM certificates.
+	ExternalDNSHostname = "external-dns.alpha.kubernetes.io/hostname"
 )
 
 const (
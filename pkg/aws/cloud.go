@@ -99,16 +99,17 @@ func NewCloud(cfg CloudConfig, clusterName string, metricsCollector *aws_metrics
 	cfg.VpcID = vpcID
 
 	thisObj := &defaultCloud{
-		cfg:               cfg,
-		clusterName:       clusterName,
-		ec2:               ec2Service,
-		route53:           services.NewRoute53(awsClientsProvider),
-		acm:               services.NewACM(awsClientsProvider),
-		wafv2:             services.NewWAFv2(awsClientsProvider),
-		wafRegional:       services.NewWAFRegional(awsClientsProvider, cfg.Region),
-		shield:            services.NewShield(awsClientsProvider),
-		rgt:               services.NewRGT(awsClientsProvider),
-		globalAccelerator: services.NewGlobalAccelerator(awsClientsProvider),
+		cfg:                  cfg,
+		clusterName:          clusterName,
+		ec2:                  ec2Service,
+		route53:              services.NewRoute53(awsClientsProvider),
+		acm:                  services.NewACM(awsClientsProvider),
+		wafv2:                services.NewWAFv2(awsClientsProvider),
+		wafRegional:          services.NewWAFRegional(awsClientsProvider, cfg.Region),
+		shield:               services.NewShield(awsClientsProvider),
+		rgt:                  services.NewRGT(awsClientsProvider),
+		globalAcceleratorRGT: services.NewGlobalAcceleratorRGT(awsClientsProvider),
+		globalAccelerator:    services.NewGlobalAccelerator(awsClientsProvider),
 
 		awsConfigGenerator: awsConfigGenerator,
 
@@ -205,15 +206,16 @@ var _ services.Cloud = &defaultCloud{}
 type defaultCloud struct {
 	cfg CloudConfig
 
-	route53           services.Route53
-	ec2               services.EC2
-	elbv2             services.ELBV2
-	acm               services.ACM
-	wafv2             services.WAFv2
-	wafRegional       services.WAFRegional
-	shield            services.Shield
-	rgt               services.RGT
-	globalAccelerator services.GlobalAccelerator
+	route53              services.Route53
+	ec2                  services.EC2
+	elbv2                services.ELBV2
+	acm                  services.ACM
+	wafv2                services.WAFv2
+	wafRegional          services.WAFRegional
+	shield               services.Shield
+	rgt                  services.RGT
+	globalAcceleratorRGT services.RGT
+	globalAccelerator    services.GlobalAccelerator
 
 	clusterName string
 
@@ -318,6 +320,10 @@ func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) GlobalAcceleratorRGT() services.RGT {
+	return c.globalAcceleratorRGT
+}
+
 func (c *defaultCloud) Route53() services.Route53 {
 	return c.route53
 }
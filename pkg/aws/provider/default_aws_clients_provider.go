@@ -17,6 +17,9 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/endpoints"
 )
 
+// Global Accelerator is a global service that requires us-west-2
+const globalAcceleratorRegion = "us-west-2"
+
 type defaultAWSClientsProvider struct {
 	ec2Client               *ec2.Client
 	elbv2Client             *elasticloadbalancingv2.Client
@@ -29,6 +32,9 @@ type defaultAWSClientsProvider struct {
 	route53Client           *route53.Client
 	globalAcceleratorClient *globalaccelerator.Client
 
+	// used for tags of Global Accelerator resources
+	globalAcceleratorRGTClient *resourcegroupstaggingapi.Client
+
 	// used for dynamic creation of ELBv2 client
 	elbv2CustomEndpoint *string
 }
@@ -87,8 +93,14 @@ func NewDefaultAWSClientsProvider(cfg aws.Config, endpointsResolver *endpoints.R
 		}
 	})
 
+	// Global Accelerator resources are only tagged in the Global Accelerator region,
+	// the custom RGT endpoint is for the cluster region and thus isn't applied.
+	globalAcceleratorRGTClient := resourcegroupstaggingapi.NewFromConfig(cfg, func(o *resourcegroupstaggingapi.Options) {
+		o.Region = globalAcceleratorRegion
+	})
+
 	globalAcceleratorClient := globalaccelerator.NewFromConfig(cfg, func(o *globalaccelerator.Options) {
-		o.Region = "us-west-2" // Global Accelerator is a global service that requires us-west-2
+		o.Region = globalAcceleratorRegion
 		if globalAcceleratorCustomEndpoint != nil {
 			o.BaseEndpoint = globalAcceleratorCustomEndpoint
 		}
@@ -112,6 +124,8 @@ func NewDefaultAWSClientsProvider(cfg aws.Config, endpointsResolver *endpoints.R
 		route53Client:           route53Client,
 		globalAcceleratorClient: globalAcceleratorClient,
 
+		globalAcceleratorRGTClient: globalAcceleratorRGTClient,
+
 		elbv2CustomEndpoint: elbv2CustomEndpoint,
 	}, nil
 }
@@ -159,6 +173,10 @@ func (p *defaultAWSClientsProvider) GetGlobalAcceleratorClient(ctx context.Conte
 	return p.globalAcceleratorClient, nil
 }
 
+func (p *defaultAWSClientsProvider) GetGlobalAcceleratorRGTClient(ctx context.Context, operationName string) (*resourcegroupstaggingapi.Client, error) {
+	return p.globalAcceleratorRGTClient, nil
+}
+
 func (p *defaultAWSClientsProvider) GenerateNewELBv2Client(cfg aws.Config) *elasticloadbalancingv2.Client {
 	return generateNewELBv2ClientHelper(cfg, p.elbv2CustomEndpoint)
 }
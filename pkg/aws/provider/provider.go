@@ -27,5 +27,6 @@ type AWSClientsProvider interface {
 	GetRGTClient(ctx context.Context, operationName string) (*resourcegroupstaggingapi.Client, error)
 	GetSTSClient(ctx context.Context, operationName string) (*sts.Client, error)
 	GetGlobalAcceleratorClient(ctx context.Context, operationName string) (*globalaccelerator.Client, error)
+	GetGlobalAcceleratorRGTClient(ctx context.Context, operationName string) (*resourcegroupstaggingapi.Client, error)
 	GenerateNewELBv2Client(cfg aws.Config) *elasticloadbalancingv2.Client
 }
@@ -27,6 +27,9 @@ type Cloud interface {
 	// RGT provides API to AWS RGT
 	RGT() RGT
 
+	// GlobalAcceleratorRGT provides API to AWS RGT in the region of GlobalAccelerator resources
+	GlobalAcceleratorRGT() RGT
+
 	// GlobalAccelerator provides API to AWS GlobalAccelerator
 	GlobalAccelerator() GlobalAccelerator
 
@@ -0,0 +1,352 @@
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	acmsdk "github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.ACM = &ACM{}
+
+const (
+	maxSubjectAlternativeNames = 10
+	certificateValidity        = 395 * 24 * time.Hour
+	certificateWaitInterval    = 50 * time.Millisecond
+)
+
+// ACM is a stateful in-memory implementation of services.ACM.
+// Requested public certificates are issued once the CNAME records for their DNS validation exist in route53,
+// private certificates are issued immediately. Existing certificates are seeded with AddCertificate.
+type ACM struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+	region     string
+	accountID  string
+	route53    *Route53
+
+	certificates map[string]*certificateState
+
+	// certificateInUse returns the resources using a certificate, such as load balancers.
+	certificateInUse func(certARN string) []string
+}
+
+type certificateState struct {
+	certificate acmtypes.CertificateDetail
+	tags        map[string]string
+}
+
+// NewACM constructs a new fake ACM, which validates certificates with the records of route53.
+func NewACM(region string, accountID string, route53 *Route53) *ACM {
+	return &ACM{
+		ids:          newIDGenerator(),
+		simulation:   NewSimulation(),
+		region:       region,
+		accountID:    accountID,
+		route53:      route53,
+		certificates: make(map[string]*certificateState),
+	}
+}
+
+// AddCertificate seeds a certificate, generating its ARN unless set. Unless specified, the certificate is an issued
+// imported RSA_2048 certificate valid from now on.
+func (f *ACM) AddCertificate(certificate acmtypes.CertificateDetail, tags map[string]string) acmtypes.CertificateDetail {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	now := time.Now()
+	if certificate.CertificateArn == nil {
+		certificate.CertificateArn = awssdk.String(f.buildCertificateARN())
+	}
+	if certificate.Status == "" {
+		certificate.Status = acmtypes.CertificateStatusIssued
+	}
+	if certificate.Type == "" {
+		certificate.Type = acmtypes.CertificateTypeImported
+	}
+	if certificate.KeyAlgorithm == "" {
+		certificate.KeyAlgorithm = acmtypes.KeyAlgorithmRsa2048
+	}
+	if len(certificate.SubjectAlternativeNames) == 0 && certificate.DomainName != nil {
+		certificate.SubjectAlternativeNames = []string{awssdk.ToString(certificate.DomainName)}
+	}
+	if certificate.NotBefore == nil {
+		certificate.NotBefore = awssdk.Time(now)
+	}
+	if certificate.NotAfter == nil {
+		certificate.NotAfter = awssdk.Time(certificate.NotBefore.Add(certificateValidity))
+	}
+	if certificate.Type == acmtypes.CertificateTypeImported && certificate.ImportedAt == nil {
+		certificate.ImportedAt = awssdk.Time(now)
+	} else if certificate.CreatedAt == nil {
+		certificate.CreatedAt = awssdk.Time(now)
+	}
+	f.certificates[awssdk.ToString(certificate.CertificateArn)] = &certificateState{
+		certificate: certificate,
+		tags:        copyTags(tags),
+	}
+	return certificate
+}
+
+func (f *ACM) ListCertificatesAsList(ctx context.Context, input *acmsdk.ListCertificatesInput) ([]acmtypes.CertificateSummary, error) {
+	if err := f.simulation.call(ServiceACM, "ListCertificates"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	// ListCertificates only returns RSA_2048 certificates unless other key types are requested.
+	keyTypes := []acmtypes.KeyAlgorithm{acmtypes.KeyAlgorithmRsa2048}
+	if input.Includes != nil && len(input.Includes.KeyTypes) != 0 {
+		keyTypes = input.Includes.KeyTypes
+	}
+	var summaries []acmtypes.CertificateSummary
+	for _, certARN := range sortedKeys(f.certificates) {
+		certState := f.certificates[certARN]
+		f.refreshValidation(certState)
+		certificate := certState.certificate
+		if len(input.CertificateStatuses) != 0 && !slices.Contains(input.CertificateStatuses, certificate.Status) {
+			continue
+		}
+		if !slices.Contains(keyTypes, certificate.KeyAlgorithm) {
+			continue
+		}
+		summaries = append(summaries, acmtypes.CertificateSummary{
+			CertificateArn:                  certificate.CertificateArn,
+			DomainName:                      certificate.DomainName,
+			SubjectAlternativeNameSummaries: certificate.SubjectAlternativeNames,
+			Status:                          certificate.Status,
+			Type:                            certificate.Type,
+			KeyAlgorithm:                    certificate.KeyAlgorithm,
+			InUse:                           awssdk.Bool(len(f.buildInUseBy(certARN)) != 0),
+			NotBefore:                       certificate.NotBefore,
+			NotAfter:                        certificate.NotAfter,
+			CreatedAt:                       certificate.CreatedAt,
+			ImportedAt:                      certificate.ImportedAt,
+			IssuedAt:                        certificate.IssuedAt,
+		})
+	}
+	return summaries, nil
+}
+
+func (f *ACM) DescribeCertificateWithContext(ctx context.Context, req *acmsdk.DescribeCertificateInput) (*acmsdk.DescribeCertificateOutput, error) {
+	if err := f.simulation.call(ServiceACM, "DescribeCertificate"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	certARN := awssdk.ToString(req.CertificateArn)
+	certState, exists := f.certificates[certARN]
+	if !exists {
+		return nil, newCertificateNotFoundError(certARN)
+	}
+	f.refreshValidation(certState)
+	certificate := certState.certificate
+	certificate.InUseBy = f.buildInUseBy(certARN)
+	// the validation records of requested certificates are populated asynchronously.
+	if !f.simulation.visible(certARN) {
+		certificate.DomainValidationOptions = slices.Clone(certificate.DomainValidationOptions)
+		for i := range certificate.DomainValidationOptions {
+			certificate.DomainValidationOptions[i].ResourceRecord = nil
+		}
+	}
+	return &acmsdk.DescribeCertificateOutput{Certificate: &certificate}, nil
+}
+
+func (f *ACM) ListTagsForCertificate(ctx context.Context, input *acmsdk.ListTagsForCertificateInput) (*acmsdk.ListTagsForCertificateOutput, error) {
+	if err := f.simulation.call(ServiceACM, "ListTagsForCertificate"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	certARN := awssdk.ToString(input.CertificateArn)
+	certState, exists := f.certificates[certARN]
+	if !exists {
+		return nil, newCertificateNotFoundError(certARN)
+	}
+	var tags []acmtypes.Tag
+	for _, key := range sortedKeys(certState.tags) {
+		tags = append(tags, acmtypes.Tag{Key: awssdk.String(key), Value: awssdk.String(certState.tags[key])})
+	}
+	return &acmsdk.ListTagsForCertificateOutput{Tags: tags}, nil
+}
+
+func (f *ACM) RequestCertificateWithContext(ctx context.Context, input *acmsdk.RequestCertificateInput) (*acmsdk.RequestCertificateOutput, error) {
+	if err := f.simulation.call(ServiceACM, "RequestCertificate"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	domainName := awssdk.ToString(input.DomainName)
+	if domainName == "" {
+		return nil, &acmtypes.InvalidParameterException{Message: awssdk.String("DomainName must be specified")}
+	}
+	domainNames := []string{domainName}
+	for _, san := range input.SubjectAlternativeNames {
+		if !slices.Contains(domainNames, san) {
+			domainNames = append(domainNames, san)
+		}
+	}
+	if len(domainNames) > maxSubjectAlternativeNames {
+		return nil, &acmtypes.LimitExceededException{Message: awssdk.String(fmt.Sprintf("Cannot request a certificate with more than %d domain names", maxSubjectAlternativeNames))}
+	}
+	if len(f.certificates) >= f.simulation.Quotas().Certificates {
+		return nil, &acmtypes.LimitExceededException{Message: awssdk.String("The quota for the number of certificates has been reached")}
+	}
+	keyAlgorithm := input.KeyAlgorithm
+	if keyAlgorithm == "" {
+		keyAlgorithm = acmtypes.KeyAlgorithmRsa2048
+	}
+
+	certARN := f.buildCertificateARN()
+	now := time.Now()
+	certificate := acmtypes.CertificateDetail{
+		CertificateArn:          awssdk.String(certARN),
+		DomainName:              input.DomainName,
+		SubjectAlternativeNames: domainNames,
+		KeyAlgorithm:            keyAlgorithm,
+		CreatedAt:               awssdk.Time(now),
+		CertificateAuthorityArn: input.CertificateAuthorityArn,
+	}
+	if input.CertificateAuthorityArn != nil {
+		certificate.Type = acmtypes.CertificateTypePrivate
+		f.issue(&certificate, now)
+	} else {
+		validationMethod := input.ValidationMethod
+		if validationMethod == "" {
+			validationMethod = acmtypes.ValidationMethodEmail
+		}
+		certificate.Type = acmtypes.CertificateTypeAmazonIssued
+		certificate.Status = acmtypes.CertificateStatusPendingValidation
+		for _, name := range domainNames {
+			option := acmtypes.DomainValidation{
+				DomainName:       awssdk.String(name),
+				ValidationDomain: awssdk.String(name),
+				ValidationMethod: validationMethod,
+				ValidationStatus: acmtypes.DomainStatusPendingValidation,
+			}
+			if validationMethod == acmtypes.ValidationMethodDns {
+				option.ResourceRecord = buildValidationRecord(name)
+			}
+			certificate.DomainValidationOptions = append(certificate.DomainValidationOptions, option)
+		}
+	}
+	tags := make(map[string]string)
+	for _, tag := range input.Tags {
+		tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+	}
+	f.certificates[certARN] = &certificateState{
+		certificate: certificate,
+		tags:        tags,
+	}
+	f.simulation.created(certARN)
+	return &acmsdk.RequestCertificateOutput{CertificateArn: awssdk.String(certARN)}, nil
+}
+
+func (f *ACM) DeleteCertificateWithContext(ctx context.Context, input *acmsdk.DeleteCertificateInput) (*acmsdk.DeleteCertificateOutput, error) {
+	if err := f.simulation.call(ServiceACM, "DeleteCertificate"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	certARN := awssdk.ToString(input.CertificateArn)
+	if _, exists := f.certificates[certARN]; !exists {
+		return nil, newCertificateNotFoundError(certARN)
+	}
+	if inUseBy := f.buildInUseBy(certARN); len(inUseBy) != 0 {
+		return nil, &acmtypes.ResourceInUseException{Message: awssdk.String(fmt.Sprintf("Certificate %s in use by %v", certARN, inUseBy))}
+	}
+	delete(f.certificates, certARN)
+	return &acmsdk.DeleteCertificateOutput{}, nil
+}
+
+// WaitForCertificateIssuedWithContext waits until the certificate is issued, failing if its validation failed or waitTime elapsed.
+func (f *ACM) WaitForCertificateIssuedWithContext(ctx context.Context, arn string, waitTime time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, certificateWaitInterval, waitTime, true, func(ctx context.Context) (bool, error) {
+		output, err := f.DescribeCertificateWithContext(ctx, &acmsdk.DescribeCertificateInput{CertificateArn: awssdk.String(arn)})
+		if err != nil {
+			return false, err
+		}
+		switch output.Certificate.Status {
+		case acmtypes.CertificateStatusIssued:
+			return true, nil
+		case acmtypes.CertificateStatusFailed, acmtypes.CertificateStatusValidationTimedOut, acmtypes.CertificateStatusRevoked:
+			return false, fmt.Errorf("certificate %s is %s", arn, output.Certificate.Status)
+		}
+		return false, nil
+	})
+}
+
+// refreshValidation issues a certificate pending DNS validation once the validation records of all its domains exist.
+func (f *ACM) refreshValidation(certState *certificateState) {
+	certificate := &certState.certificate
+	if certificate.Status != acmtypes.CertificateStatusPendingValidation || f.route53 == nil {
+		return
+	}
+	validated := true
+	for i, option := range certificate.DomainValidationOptions {
+		record := option.ResourceRecord
+		if option.ValidationMethod != acmtypes.ValidationMethodDns || record == nil {
+			validated = false
+			continue
+		}
+		if f.route53.hasRecord(awssdk.ToString(record.Name), string(record.Type), awssdk.ToString(record.Value)) {
+			certificate.DomainValidationOptions[i].ValidationStatus = acmtypes.DomainStatusSuccess
+		} else {
+			validated = false
+		}
+	}
+	if validated {
+		f.issue(certificate, time.Now())
+	}
+}
+
+func (f *ACM) issue(certificate *acmtypes.CertificateDetail, now time.Time) {
+	certificate.Status = acmtypes.CertificateStatusIssued
+	certificate.IssuedAt = awssdk.Time(now)
+	certificate.NotBefore = awssdk.Time(now)
+	certificate.NotAfter = awssdk.Time(now.Add(certificateValidity))
+}
+
+func (f *ACM) buildInUseBy(certARN string) []string {
+	if f.certificateInUse == nil {
+		return nil
+	}
+	return f.certificateInUse(certARN)
+}
+
+func (f *ACM) buildCertificateARN() string {
+	count := f.ids.nextCount("certificate")
+	return fmt.Sprintf("arn:aws:acm:%s:%s:certificate/%08x-0000-4000-8000-%012x", f.region, f.accountID, count, count)
+}
+
+// buildValidationRecord builds the CNAME record validating domainName, deterministic like the records ACM generates.
+func buildValidationRecord(domainName string) *acmtypes.ResourceRecord {
+	hash := sha256.Sum256([]byte(domainName))
+	token := hex.EncodeToString(hash[:16])
+	return &acmtypes.ResourceRecord{
+		Name:  awssdk.String(fmt.Sprintf("_%s.%s.", token[:16], domainName)),
+		Type:  acmtypes.RecordTypeCname,
+		Value: awssdk.String(fmt.Sprintf("_%s.acm-validations.aws.", token[16:])),
+	}
+}
+
+func newCertificateNotFoundError(certARN string) error {
+	return &acmtypes.ResourceNotFoundException{Message: awssdk.String(fmt.Sprintf("Could not find certificate %s.", certARN))}
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	copied := make(map[string]string, len(tags))
+	for key, value := range tags {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,98 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	acmsdk "github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	route53sdk "github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACM_dnsValidation(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	acmClient := network.cloud.ACM()
+	route53Client := network.cloud.Route53()
+	network.cloud.FakeRoute53().AddHostedZone("example.com", false)
+
+	requestOutput, err := acmClient.RequestCertificateWithContext(ctx, &acmsdk.RequestCertificateInput{
+		DomainName:       awssdk.String("app.example.com"),
+		ValidationMethod: acmtypes.ValidationMethodDns,
+	})
+	assert.NoError(t, err)
+	certARN := requestOutput.CertificateArn
+	assert.Equal(t, "arn:aws:acm:us-west-2:123456789012:certificate/00000001-0000-4000-8000-000000000001", awssdk.ToString(certARN))
+
+	describeOutput, err := acmClient.DescribeCertificateWithContext(ctx, &acmsdk.DescribeCertificateInput{CertificateArn: certARN})
+	assert.NoError(t, err)
+	assert.Equal(t, acmtypes.CertificateStatusPendingValidation, describeOutput.Certificate.Status)
+	record := describeOutput.Certificate.DomainValidationOptions[0].ResourceRecord
+	if !assert.NotNil(t, record) {
+		return
+	}
+	assert.Error(t, acmClient.WaitForCertificateIssuedWithContext(ctx, awssdk.ToString(certARN), 100*time.Millisecond))
+
+	zoneID, err := route53Client.GetHostedZoneID(ctx, "app.example.com")
+	assert.NoError(t, err)
+	_, err = route53Client.ChangeRecordsWithContext(ctx, &route53sdk.ChangeResourceRecordSetsInput{
+		HostedZoneId: zoneID,
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: []route53types.Change{
+				{
+					Action: route53types.ChangeActionUpsert,
+					ResourceRecordSet: &route53types.ResourceRecordSet{
+						Name:            record.Name,
+						Type:            route53types.RRTypeCname,
+						TTL:             awssdk.Int64(300),
+						ResourceRecords: []route53types.ResourceRecord{{Value: record.Value}},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, acmClient.WaitForCertificateIssuedWithContext(ctx, awssdk.ToString(certARN), time.Second))
+
+	summaries, err := acmClient.ListCertificatesAsList(ctx, &acmsdk.ListCertificatesInput{
+		CertificateStatuses: []acmtypes.CertificateStatus{acmtypes.CertificateStatusIssued},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+}
+
+func TestACM_DeleteCertificateWithContext(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	cert := network.cloud.FakeACM().AddCertificate(acmtypes.CertificateDetail{DomainName: awssdk.String("app.example.com")}, nil)
+	lb := network.createALB(t, "k8s-default-tls")
+	tgARN := network.createTargetGroup(t, "k8s-default-tls", elbv2types.ProtocolEnumHttp)
+	_, err := network.cloud.ELBV2().CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Protocol:        elbv2types.ProtocolEnumHttps,
+		Port:            awssdk.Int32(443),
+		Certificates:    []elbv2types.Certificate{{CertificateArn: cert.CertificateArn}},
+		DefaultActions:  forwardTo(tgARN),
+	})
+	assert.NoError(t, err)
+
+	describeOutput, err := network.cloud.ACM().DescribeCertificateWithContext(ctx, &acmsdk.DescribeCertificateInput{CertificateArn: cert.CertificateArn})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{awssdk.ToString(lb.LoadBalancerArn)}, describeOutput.Certificate.InUseBy)
+
+	_, err = network.cloud.ACM().DeleteCertificateWithContext(ctx, &acmsdk.DeleteCertificateInput{CertificateArn: cert.CertificateArn})
+	var inUseErr *acmtypes.ResourceInUseException
+	assert.True(t, errors.As(err, &inUseErr))
+
+	_, err = network.cloud.ELBV2().DeleteLoadBalancerWithContext(ctx, &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: lb.LoadBalancerArn})
+	assert.NoError(t, err)
+	_, err = network.cloud.ACM().DeleteCertificateWithContext(ctx, &acmsdk.DeleteCertificateInput{CertificateArn: cert.CertificateArn})
+	assert.NoError(t, err)
+}
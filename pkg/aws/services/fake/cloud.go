@@ -0,0 +1,171 @@
+package fake
+
+import (
+	"context"
+
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.Cloud = &Cloud{}
+
+// Cloud is an in-memory implementation of services.Cloud, backed by stateful fakes of the AWS services.
+// The fakes share a Simulation, and see each other's resources, e.g. ACM reports the load balancers using a certificate.
+// WAFRegional isn't simulated and returns nil, so the features using it must be disabled.
+type Cloud struct {
+	region            string
+	vpcID             string
+	simulation        *Simulation
+	ec2               *EC2
+	elbv2             *ELBV2
+	acm               *ACM
+	route53           *Route53
+	wafv2             *WAFv2
+	shield            *Shield
+	rgt               *RGT
+	gaRGT             *RGT
+	globalAccelerator *GlobalAccelerator
+}
+
+// NewCloud constructs a new fake Cloud for the load balancer resources of vpcID.
+func NewCloud(region string, accountID string, vpcID string) *Cloud {
+	ec2Fake := NewEC2(region)
+	elbv2Fake := NewELBV2(region, accountID, ec2Fake)
+	route53Fake := NewRoute53()
+	acmFake := NewACM(region, accountID, route53Fake)
+	wafv2Fake := NewWAFv2(region, accountID)
+	shieldFake := NewShield(accountID)
+	rgtFake := NewRGT()
+	gaRGTFake := NewRGT()
+	gaFake := NewGlobalAccelerator(accountID)
+
+	simulation := ec2Fake.simulation
+	route53Fake.simulation = simulation
+	acmFake.simulation = simulation
+	wafv2Fake.simulation = simulation
+	shieldFake.simulation = simulation
+	rgtFake.simulation = simulation
+	gaRGTFake.simulation = simulation
+	gaFake.simulation = simulation
+
+	acmFake.certificateInUse = elbv2Fake.loadBalancersUsingCertificate
+	wafv2Fake.resourceExists = elbv2Fake.loadBalancerExists
+	gaFake.endpointExists = elbv2Fake.loadBalancerExists
+	rgtFake.addSource(elbv2Fake.taggedResources)
+	rgtFake.addSource(func() []taggedResource {
+		return ec2Fake.taggedSecurityGroups(region, accountID)
+	})
+	rgtFake.addSource(gaFake.taggedResources)
+	gaRGTFake.addSource(gaFake.taggedResources)
+
+	return &Cloud{
+		region:            region,
+		vpcID:             vpcID,
+		simulation:        simulation,
+		ec2:               ec2Fake,
+		elbv2:             elbv2Fake,
+		acm:               acmFake,
+		route53:           route53Fake,
+		wafv2:             wafv2Fake,
+		shield:            shieldFake,
+		rgt:               rgtFake,
+		gaRGT:             gaRGTFake,
+		globalAccelerator: gaFake,
+	}
+}
+
+func (c *Cloud) EC2() services.EC2 {
+	return c.ec2
+}
+
+func (c *Cloud) ELBV2() services.ELBV2 {
+	return c.elbv2
+}
+
+func (c *Cloud) ACM() services.ACM {
+	return c.acm
+}
+
+func (c *Cloud) Route53() services.Route53 {
+	return c.route53
+}
+
+func (c *Cloud) WAFv2() services.WAFv2 {
+	return c.wafv2
+}
+
+func (c *Cloud) WAFRegional() services.WAFRegional {
+	return nil
+}
+
+func (c *Cloud) Shield() services.Shield {
+	return c.shield
+}
+
+func (c *Cloud) RGT() services.RGT {
+	return c.rgt
+}
+
+func (c *Cloud) GlobalAcceleratorRGT() services.RGT {
+	return c.gaRGT
+}
+
+func (c *Cloud) GlobalAccelerator() services.GlobalAccelerator {
+	return c.globalAccelerator
+}
+
+func (c *Cloud) Region() string {
+	return c.region
+}
+
+func (c *Cloud) VpcID() string {
+	return c.vpcID
+}
+
+func (c *Cloud) GetAssumedRoleELBV2(ctx context.Context, assumeRoleArn string, externalId string) (services.ELBV2, error) {
+	return c.elbv2, nil
+}
+
+// FakeEC2 returns the EC2 fake, to seed resources and inspect state.
+func (c *Cloud) FakeEC2() *EC2 {
+	return c.ec2
+}
+
+// FakeELBV2 returns the ELBV2 fake, to route requests and inspect state.
+func (c *Cloud) FakeELBV2() *ELBV2 {
+	return c.elbv2
+}
+
+// FakeACM returns the ACM fake, to seed certificates and inspect state.
+func (c *Cloud) FakeACM() *ACM {
+	return c.acm
+}
+
+// FakeRoute53 returns the Route53 fake, to seed hosted zones and inspect records.
+func (c *Cloud) FakeRoute53() *Route53 {
+	return c.route53
+}
+
+// FakeWAFv2 returns the WAFv2 fake, to seed web ACLs and inspect associations.
+func (c *Cloud) FakeWAFv2() *WAFv2 {
+	return c.wafv2
+}
+
+// FakeShield returns the Shield fake, to subscribe to Shield Advanced and inspect protections.
+func (c *Cloud) FakeShield() *Shield {
+	return c.shield
+}
+
+// FakeRGT returns the RGT fake.
+func (c *Cloud) FakeRGT() *RGT {
+	return c.rgt
+}
+
+// FakeGlobalAccelerator returns the GlobalAccelerator fake, to inspect accelerators.
+func (c *Cloud) FakeGlobalAccelerator() *GlobalAccelerator {
+	return c.globalAccelerator
+}
+
+// Simulation returns the Simulation shared by the fakes, to inject faults, set quotas and delay consistency.
+func (c *Cloud) Simulation() *Simulation {
+	return c.simulation
+}
@@ -0,0 +1,94 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go"
+)
+
+// idGenerator generates deterministic resource IDs, so that test failures are reproducible.
+type idGenerator struct {
+	mutex    sync.Mutex
+	counters map[string]int64
+}
+
+func newIDGenerator() *idGenerator {
+	return &idGenerator{
+		counters: make(map[string]int64),
+	}
+}
+
+// next returns the next ID with prefix in the EC2 format, e.g. "sg-00000000000000001".
+func (g *idGenerator) next(prefix string) string {
+	return fmt.Sprintf("%s-%017x", prefix, g.nextCount(prefix))
+}
+
+// nextHex returns the next 16 character hex ID for prefix, as used in ELBV2 ARNs.
+func (g *idGenerator) nextHex(prefix string) string {
+	return fmt.Sprintf("%016x", g.nextCount(prefix))
+}
+
+func (g *idGenerator) nextCount(prefix string) int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.counters[prefix]++
+	return g.counters[prefix]
+}
+
+// newAPIError builds an AWS API error the same way the SDK surfaces service errors.
+func newAPIError(code string, format string, args ...any) error {
+	return &smithy.GenericAPIError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Fault:   smithy.FaultClient,
+	}
+}
+
+// matchGlob matches value against an AWS wildcard pattern, where "*" matches any sequence of characters and "?" matches a single character.
+func matchGlob(pattern string, value string) bool {
+	if pattern == "" {
+		return value == ""
+	}
+	switch pattern[0] {
+	case '*':
+		for i := 0; i <= len(value); i++ {
+			if matchGlob(pattern[1:], value[i:]) {
+				return true
+			}
+		}
+		return false
+	case '?':
+		return len(value) > 0 && matchGlob(pattern[1:], value[1:])
+	default:
+		return len(value) > 0 && pattern[0] == value[0] && matchGlob(pattern[1:], value[1:])
+	}
+}
+
+// matchAnyGlob checks whether value matches any of patterns.
+func matchAnyGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in ascending order, so that describe calls return resources in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// resourceIDPrefix returns the prefix of an EC2 resource ID, e.g. "sg" for "sg-00000000000000001".
+func resourceIDPrefix(resourceID string) string {
+	prefix, _, _ := strings.Cut(resourceID, "-")
+	return prefix
+}
@@ -0,0 +1,857 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.EC2 = &EC2{}
+
+// EC2 is a stateful in-memory implementation of services.EC2.
+// Resources the controller only reads, such as VPCs, subnets and instances, are seeded with the Add* methods.
+type EC2 struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+
+	availabilityZones []ec2types.AvailabilityZone
+	vpcs              map[string]ec2types.Vpc
+	subnets           map[string]ec2types.Subnet
+	routeTables       map[string]ec2types.RouteTable
+	securityGroups    map[string]ec2types.SecurityGroup
+	instances         map[string]ec2types.Instance
+	networkInterfaces map[string]ec2types.NetworkInterface
+
+	// securityGroupInUse reports whether a securityGroup is used by resources outside of EC2, such as load balancers.
+	securityGroupInUse func(groupID string) bool
+}
+
+// NewEC2 constructs a new fake EC2 with three availability zones in region.
+func NewEC2(region string) *EC2 {
+	ec2Fake := &EC2{
+		ids:               newIDGenerator(),
+		simulation:        NewSimulation(),
+		vpcs:              make(map[string]ec2types.Vpc),
+		subnets:           make(map[string]ec2types.Subnet),
+		routeTables:       make(map[string]ec2types.RouteTable),
+		securityGroups:    make(map[string]ec2types.SecurityGroup),
+		instances:         make(map[string]ec2types.Instance),
+		networkInterfaces: make(map[string]ec2types.NetworkInterface),
+	}
+	for i, suffix := range []string{"a", "b", "c"} {
+		ec2Fake.availabilityZones = append(ec2Fake.availabilityZones, ec2types.AvailabilityZone{
+			RegionName: awssdk.String(region),
+			ZoneName:   awssdk.String(region + suffix),
+			ZoneId:     awssdk.String(fmt.Sprintf("%s-az%d", strings.ReplaceAll(region, "-", ""), i+1)),
+			ZoneType:   awssdk.String("availability-zone"),
+			State:      ec2types.AvailabilityZoneStateAvailable,
+		})
+	}
+	return ec2Fake
+}
+
+// AddVpc seeds a VPC, generating its ID unless set.
+func (f *EC2) AddVpc(vpc ec2types.Vpc) ec2types.Vpc {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if vpc.VpcId == nil {
+		vpc.VpcId = awssdk.String(f.ids.next("vpc"))
+	}
+	vpc.State = ec2types.VpcStateAvailable
+	if len(vpc.CidrBlockAssociationSet) == 0 && vpc.CidrBlock != nil {
+		vpc.CidrBlockAssociationSet = []ec2types.VpcCidrBlockAssociation{
+			{
+				AssociationId:  awssdk.String(f.ids.next("vpc-cidr-assoc")),
+				CidrBlock:      vpc.CidrBlock,
+				CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated},
+			},
+		}
+	}
+	f.vpcs[awssdk.ToString(vpc.VpcId)] = vpc
+	return vpc
+}
+
+// AddSubnet seeds a subnet, generating its ID unless set.
+func (f *EC2) AddSubnet(subnet ec2types.Subnet) ec2types.Subnet {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if subnet.SubnetId == nil {
+		subnet.SubnetId = awssdk.String(f.ids.next("subnet"))
+	}
+	if subnet.AvailabilityZoneId == nil {
+		for _, az := range f.availabilityZones {
+			if awssdk.ToString(az.ZoneName) == awssdk.ToString(subnet.AvailabilityZone) {
+				subnet.AvailabilityZoneId = az.ZoneId
+			}
+		}
+	}
+	if subnet.AvailableIpAddressCount == nil {
+		subnet.AvailableIpAddressCount = awssdk.Int32(250)
+	}
+	subnet.State = ec2types.SubnetStateAvailable
+	f.subnets[awssdk.ToString(subnet.SubnetId)] = subnet
+	return subnet
+}
+
+// AddRouteTable seeds a route table, generating its ID unless set.
+func (f *EC2) AddRouteTable(routeTable ec2types.RouteTable) ec2types.RouteTable {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if routeTable.RouteTableId == nil {
+		routeTable.RouteTableId = awssdk.String(f.ids.next("rtb"))
+	}
+	f.routeTables[awssdk.ToString(routeTable.RouteTableId)] = routeTable
+	return routeTable
+}
+
+// AddSecurityGroup seeds a securityGroup, generating its ID unless set.
+func (f *EC2) AddSecurityGroup(sg ec2types.SecurityGroup) ec2types.SecurityGroup {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if sg.GroupId == nil {
+		sg.GroupId = awssdk.String(f.ids.next("sg"))
+	}
+	f.securityGroups[awssdk.ToString(sg.GroupId)] = sg
+	return sg
+}
+
+// AddInstance seeds an instance, generating its ID unless set.
+// The network interfaces of the instance are reported from the network interfaces attached to it, see AddNetworkInterface.
+func (f *EC2) AddInstance(instance ec2types.Instance) ec2types.Instance {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if instance.InstanceId == nil {
+		instance.InstanceId = awssdk.String(f.ids.next("i"))
+	}
+	instance.State = &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}
+	f.instances[awssdk.ToString(instance.InstanceId)] = instance
+	return instance
+}
+
+// AddNetworkInterface seeds a network interface, generating its ID unless set.
+// The primary private IP address is added to the private IP addresses of the network interface.
+func (f *EC2) AddNetworkInterface(eni ec2types.NetworkInterface) ec2types.NetworkInterface {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if eni.NetworkInterfaceId == nil {
+		eni.NetworkInterfaceId = awssdk.String(f.ids.next("eni"))
+	}
+	if eni.PrivateIpAddress != nil && !slices.ContainsFunc(eni.PrivateIpAddresses, func(address ec2types.NetworkInterfacePrivateIpAddress) bool {
+		return awssdk.ToString(address.PrivateIpAddress) == awssdk.ToString(eni.PrivateIpAddress)
+	}) {
+		eni.PrivateIpAddresses = append([]ec2types.NetworkInterfacePrivateIpAddress{
+			{PrivateIpAddress: eni.PrivateIpAddress, Primary: awssdk.Bool(true)},
+		}, eni.PrivateIpAddresses...)
+	}
+	eni.Status = ec2types.NetworkInterfaceStatusInUse
+	f.networkInterfaces[awssdk.ToString(eni.NetworkInterfaceId)] = eni
+	return eni
+}
+
+// AssignPrivateIPAddresses assigns secondary private IP addresses to a network interface, the way the VPC CNI does for pods.
+func (f *EC2) AssignPrivateIPAddresses(networkInterfaceID string, ips ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	eni, exists := f.networkInterfaces[networkInterfaceID]
+	if !exists {
+		return newAPIError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '%s' does not exist", networkInterfaceID)
+	}
+	addresses := slices.Clone(eni.PrivateIpAddresses)
+	for _, ip := range ips {
+		addresses = append(addresses, ec2types.NetworkInterfacePrivateIpAddress{PrivateIpAddress: awssdk.String(ip), Primary: awssdk.Bool(false)})
+	}
+	eni.PrivateIpAddresses = addresses
+	f.networkInterfaces[networkInterfaceID] = eni
+	return nil
+}
+
+// UnassignPrivateIPAddresses removes secondary private IP addresses from a network interface.
+func (f *EC2) UnassignPrivateIPAddresses(networkInterfaceID string, ips ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	eni, exists := f.networkInterfaces[networkInterfaceID]
+	if !exists {
+		return newAPIError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '%s' does not exist", networkInterfaceID)
+	}
+	eni.PrivateIpAddresses = slices.DeleteFunc(slices.Clone(eni.PrivateIpAddresses), func(address ec2types.NetworkInterfacePrivateIpAddress) bool {
+		return !awssdk.ToBool(address.Primary) && slices.Contains(ips, awssdk.ToString(address.PrivateIpAddress))
+	})
+	f.networkInterfaces[networkInterfaceID] = eni
+	return nil
+}
+
+func (f *EC2) DescribeInstancesAsList(ctx context.Context, input *ec2.DescribeInstancesInput) ([]ec2types.Instance, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeInstances"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, instanceID := range input.InstanceIds {
+		if _, exists := f.instances[instanceID]; !exists {
+			return nil, newAPIError("InvalidInstanceID.NotFound", "The instance ID '%s' does not exist", instanceID)
+		}
+	}
+	var instances []ec2types.Instance
+	for _, instanceID := range sortedKeys(f.instances) {
+		if len(input.InstanceIds) != 0 && !slices.Contains(input.InstanceIds, instanceID) {
+			continue
+		}
+		instance := f.buildInstanceView(f.instances[instanceID])
+		matches, err := matchEC2Filters(input.Filters, instance.Tags, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "instance-id":
+				return []string{instanceID}, true
+			case "vpc-id":
+				return []string{awssdk.ToString(instance.VpcId)}, true
+			case "subnet-id":
+				return []string{awssdk.ToString(instance.SubnetId)}, true
+			case "private-ip-address":
+				return []string{awssdk.ToString(instance.PrivateIpAddress)}, true
+			case "instance-state-name":
+				return []string{string(instance.State.Name)}, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
+}
+
+func (f *EC2) DescribeInstancesWithContext(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	instances, err := f.DescribeInstancesAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	output := &ec2.DescribeInstancesOutput{}
+	if len(instances) != 0 {
+		output.Reservations = []ec2types.Reservation{{Instances: instances}}
+	}
+	return output, nil
+}
+
+// buildInstanceView returns instance with the network interfaces attached to it.
+func (f *EC2) buildInstanceView(instance ec2types.Instance) ec2types.Instance {
+	var instanceENIs []ec2types.InstanceNetworkInterface
+	for _, eniID := range sortedKeys(f.networkInterfaces) {
+		eni := f.networkInterfaces[eniID]
+		if eni.Attachment == nil || awssdk.ToString(eni.Attachment.InstanceId) != awssdk.ToString(instance.InstanceId) {
+			continue
+		}
+		instanceENI := ec2types.InstanceNetworkInterface{
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+			PrivateIpAddress:   eni.PrivateIpAddress,
+			SubnetId:           eni.SubnetId,
+			VpcId:              eni.VpcId,
+			Status:             ec2types.NetworkInterfaceStatusInUse,
+			Attachment: &ec2types.InstanceNetworkInterfaceAttachment{
+				AttachmentId: eni.Attachment.AttachmentId,
+				DeviceIndex:  eni.Attachment.DeviceIndex,
+			},
+		}
+		for _, group := range eni.Groups {
+			instanceENI.Groups = append(instanceENI.Groups, ec2types.GroupIdentifier{GroupId: group.GroupId, GroupName: group.GroupName})
+		}
+		for _, address := range eni.PrivateIpAddresses {
+			instanceENI.PrivateIpAddresses = append(instanceENI.PrivateIpAddresses, ec2types.InstancePrivateIpAddress{
+				PrivateIpAddress: address.PrivateIpAddress,
+				Primary:          address.Primary,
+			})
+		}
+		for _, prefix := range eni.Ipv4Prefixes {
+			instanceENI.Ipv4Prefixes = append(instanceENI.Ipv4Prefixes, ec2types.InstanceIpv4Prefix{Ipv4Prefix: prefix.Ipv4Prefix})
+		}
+		instanceENIs = append(instanceENIs, instanceENI)
+	}
+	instance.NetworkInterfaces = instanceENIs
+	return instance
+}
+
+func (f *EC2) DescribeNetworkInterfacesAsList(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput) ([]ec2types.NetworkInterface, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeNetworkInterfaces"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, eniID := range input.NetworkInterfaceIds {
+		if _, exists := f.networkInterfaces[eniID]; !exists {
+			return nil, newAPIError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '%s' does not exist", eniID)
+		}
+	}
+	var enis []ec2types.NetworkInterface
+	for _, eniID := range sortedKeys(f.networkInterfaces) {
+		if len(input.NetworkInterfaceIds) != 0 && !slices.Contains(input.NetworkInterfaceIds, eniID) {
+			continue
+		}
+		eni := f.networkInterfaces[eniID]
+		matches, err := matchEC2Filters(input.Filters, eni.TagSet, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "network-interface-id":
+				return []string{eniID}, true
+			case "vpc-id":
+				return []string{awssdk.ToString(eni.VpcId)}, true
+			case "subnet-id":
+				return []string{awssdk.ToString(eni.SubnetId)}, true
+			case "addresses.private-ip-address", "private-ip-address":
+				var ips []string
+				for _, address := range eni.PrivateIpAddresses {
+					ips = append(ips, awssdk.ToString(address.PrivateIpAddress))
+				}
+				return ips, true
+			case "attachment.instance-id":
+				if eni.Attachment == nil {
+					return nil, true
+				}
+				return []string{awssdk.ToString(eni.Attachment.InstanceId)}, true
+			case "group-id":
+				var groupIDs []string
+				for _, group := range eni.Groups {
+					groupIDs = append(groupIDs, awssdk.ToString(group.GroupId))
+				}
+				return groupIDs, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			enis = append(enis, eni)
+		}
+	}
+	return enis, nil
+}
+
+func (f *EC2) DescribeSecurityGroupsAsList(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) ([]ec2types.SecurityGroup, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeSecurityGroups"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, groupID := range input.GroupIds {
+		if _, exists := f.securityGroups[groupID]; !exists {
+			return nil, newAPIError("InvalidGroup.NotFound", "The security group '%s' does not exist", groupID)
+		}
+	}
+	var sgs []ec2types.SecurityGroup
+	for _, groupID := range sortedKeys(f.securityGroups) {
+		sg := f.securityGroups[groupID]
+		if !f.simulation.visible(groupID) {
+			if slices.Contains(input.GroupIds, groupID) {
+				return nil, newAPIError("InvalidGroup.NotFound", "The security group '%s' does not exist", groupID)
+			}
+			continue
+		}
+		if len(input.GroupIds) != 0 && !slices.Contains(input.GroupIds, groupID) {
+			continue
+		}
+		if len(input.GroupNames) != 0 && !slices.Contains(input.GroupNames, awssdk.ToString(sg.GroupName)) {
+			continue
+		}
+		matches, err := matchEC2Filters(input.Filters, sg.Tags, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "group-id":
+				return []string{groupID}, true
+			case "group-name":
+				return []string{awssdk.ToString(sg.GroupName)}, true
+			case "vpc-id":
+				return []string{awssdk.ToString(sg.VpcId)}, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			sgs = append(sgs, sg)
+		}
+	}
+	return sgs, nil
+}
+
+func (f *EC2) DescribeSubnetsAsList(ctx context.Context, input *ec2.DescribeSubnetsInput) ([]ec2types.Subnet, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeSubnets"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, subnetID := range input.SubnetIds {
+		if _, exists := f.subnets[subnetID]; !exists {
+			return nil, newAPIError("InvalidSubnetID.NotFound", "The subnet ID '%s' does not exist", subnetID)
+		}
+	}
+	var subnets []ec2types.Subnet
+	for _, subnetID := range sortedKeys(f.subnets) {
+		if len(input.SubnetIds) != 0 && !slices.Contains(input.SubnetIds, subnetID) {
+			continue
+		}
+		subnet := f.subnets[subnetID]
+		matches, err := matchEC2Filters(input.Filters, subnet.Tags, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "subnet-id":
+				return []string{subnetID}, true
+			case "vpc-id":
+				return []string{awssdk.ToString(subnet.VpcId)}, true
+			case "availability-zone":
+				return []string{awssdk.ToString(subnet.AvailabilityZone)}, true
+			case "availability-zone-id":
+				return []string{awssdk.ToString(subnet.AvailabilityZoneId)}, true
+			case "cidr-block":
+				return []string{awssdk.ToString(subnet.CidrBlock)}, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
+func (f *EC2) DescribeVPCsAsList(ctx context.Context, input *ec2.DescribeVpcsInput) ([]ec2types.Vpc, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeVPCs"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, vpcID := range input.VpcIds {
+		if _, exists := f.vpcs[vpcID]; !exists {
+			return nil, newAPIError("InvalidVpcID.NotFound", "The vpc ID '%s' does not exist", vpcID)
+		}
+	}
+	var vpcs []ec2types.Vpc
+	for _, vpcID := range sortedKeys(f.vpcs) {
+		if len(input.VpcIds) != 0 && !slices.Contains(input.VpcIds, vpcID) {
+			continue
+		}
+		vpc := f.vpcs[vpcID]
+		matches, err := matchEC2Filters(input.Filters, vpc.Tags, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "vpc-id":
+				return []string{vpcID}, true
+			case "cidr":
+				return []string{awssdk.ToString(vpc.CidrBlock)}, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			vpcs = append(vpcs, vpc)
+		}
+	}
+	return vpcs, nil
+}
+
+func (f *EC2) DescribeVpcsWithContext(ctx context.Context, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	vpcs, err := f.DescribeVPCsAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &ec2.DescribeVpcsOutput{Vpcs: vpcs}, nil
+}
+
+func (f *EC2) DescribeRouteTablesAsList(ctx context.Context, input *ec2.DescribeRouteTablesInput) ([]ec2types.RouteTable, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeRouteTables"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var routeTables []ec2types.RouteTable
+	for _, routeTableID := range sortedKeys(f.routeTables) {
+		if len(input.RouteTableIds) != 0 && !slices.Contains(input.RouteTableIds, routeTableID) {
+			continue
+		}
+		routeTable := f.routeTables[routeTableID]
+		matches, err := matchEC2Filters(input.Filters, routeTable.Tags, func(filterName string) ([]string, bool) {
+			switch filterName {
+			case "route-table-id":
+				return []string{routeTableID}, true
+			case "vpc-id":
+				return []string{awssdk.ToString(routeTable.VpcId)}, true
+			case "association.subnet-id":
+				var subnetIDs []string
+				for _, association := range routeTable.Associations {
+					if association.SubnetId != nil {
+						subnetIDs = append(subnetIDs, awssdk.ToString(association.SubnetId))
+					}
+				}
+				return subnetIDs, true
+			}
+			return nil, false
+		})
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			routeTables = append(routeTables, routeTable)
+		}
+	}
+	return routeTables, nil
+}
+
+func (f *EC2) DescribeAvailabilityZonesWithContext(ctx context.Context, input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "DescribeAvailabilityZones"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var azs []ec2types.AvailabilityZone
+	for _, az := range f.availabilityZones {
+		if len(input.ZoneNames) != 0 && !slices.Contains(input.ZoneNames, awssdk.ToString(az.ZoneName)) {
+			continue
+		}
+		if len(input.ZoneIds) != 0 && !slices.Contains(input.ZoneIds, awssdk.ToString(az.ZoneId)) {
+			continue
+		}
+		azs = append(azs, az)
+	}
+	if len(azs) == 0 && (len(input.ZoneNames) != 0 || len(input.ZoneIds) != 0) {
+		return nil, newAPIError("InvalidParameterValue", "Invalid availability zone: %v", append(input.ZoneNames, input.ZoneIds...))
+	}
+	return &ec2.DescribeAvailabilityZonesOutput{AvailabilityZones: azs}, nil
+}
+
+func (f *EC2) CreateTagsWithContext(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "CreateTags"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, resourceID := range input.Resources {
+		if err := f.updateTags(resourceID, func(tags []ec2types.Tag) []ec2types.Tag {
+			return mergeEC2Tags(tags, input.Tags)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (f *EC2) DeleteTagsWithContext(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "DeleteTags"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, resourceID := range input.Resources {
+		if err := f.updateTags(resourceID, func(tags []ec2types.Tag) []ec2types.Tag {
+			return removeEC2Tags(tags, input.Tags)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+// updateTags updates the tags of an EC2 resource by ID.
+func (f *EC2) updateTags(resourceID string, update func([]ec2types.Tag) []ec2types.Tag) error {
+	switch resourceIDPrefix(resourceID) {
+	case "vpc":
+		if vpc, exists := f.vpcs[resourceID]; exists {
+			vpc.Tags = update(vpc.Tags)
+			f.vpcs[resourceID] = vpc
+			return nil
+		}
+	case "subnet":
+		if subnet, exists := f.subnets[resourceID]; exists {
+			subnet.Tags = update(subnet.Tags)
+			f.subnets[resourceID] = subnet
+			return nil
+		}
+	case "rtb":
+		if routeTable, exists := f.routeTables[resourceID]; exists {
+			routeTable.Tags = update(routeTable.Tags)
+			f.routeTables[resourceID] = routeTable
+			return nil
+		}
+	case "sg":
+		if sg, exists := f.securityGroups[resourceID]; exists {
+			sg.Tags = update(sg.Tags)
+			f.securityGroups[resourceID] = sg
+			return nil
+		}
+	case "i":
+		if instance, exists := f.instances[resourceID]; exists {
+			instance.Tags = update(instance.Tags)
+			f.instances[resourceID] = instance
+			return nil
+		}
+	case "eni":
+		if eni, exists := f.networkInterfaces[resourceID]; exists {
+			eni.TagSet = update(eni.TagSet)
+			f.networkInterfaces[resourceID] = eni
+			return nil
+		}
+	}
+	return newAPIError("InvalidID", "The ID '%s' is not valid", resourceID)
+}
+
+func (f *EC2) CreateSecurityGroupWithContext(ctx context.Context, input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "CreateSecurityGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	vpcID := awssdk.ToString(input.VpcId)
+	if _, exists := f.vpcs[vpcID]; !exists {
+		return nil, newAPIError("InvalidVpcID.NotFound", "The vpc ID '%s' does not exist", vpcID)
+	}
+	for _, sg := range f.securityGroups {
+		if awssdk.ToString(sg.VpcId) == vpcID && awssdk.ToString(sg.GroupName) == awssdk.ToString(input.GroupName) {
+			return nil, newAPIError("InvalidGroup.Duplicate", "The security group '%s' already exists for VPC '%s'", awssdk.ToString(input.GroupName), vpcID)
+		}
+	}
+	if f.countSecurityGroups(vpcID) >= f.simulation.Quotas().SecurityGroupsPerVPC {
+		return nil, newAPIError("SecurityGroupLimitExceeded", "The maximum number of security groups for VPC '%s' has been reached", vpcID)
+	}
+	sg := ec2types.SecurityGroup{
+		GroupId:     awssdk.String(f.ids.next("sg")),
+		GroupName:   input.GroupName,
+		Description: input.Description,
+		VpcId:       input.VpcId,
+		Tags:        buildEC2TagsFromSpecifications(input.TagSpecifications, ec2types.ResourceTypeSecurityGroup),
+		IpPermissionsEgress: []ec2types.IpPermission{
+			{
+				IpProtocol: awssdk.String("-1"),
+				IpRanges:   []ec2types.IpRange{{CidrIp: awssdk.String("0.0.0.0/0")}},
+			},
+		},
+	}
+	f.securityGroups[awssdk.ToString(sg.GroupId)] = sg
+	f.simulation.created(awssdk.ToString(sg.GroupId))
+	return &ec2.CreateSecurityGroupOutput{GroupId: sg.GroupId, Tags: sg.Tags}, nil
+}
+
+func (f *EC2) DeleteSecurityGroupWithContext(ctx context.Context, input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "DeleteSecurityGroup"); err != nil {
+		return nil, err
+	}
+	groupID := awssdk.ToString(input.GroupId)
+	// securityGroupInUse is evaluated before acquiring the lock, since it calls back into other fakes that may call into EC2.
+	usedOutsideEC2 := f.securityGroupInUse != nil && f.securityGroupInUse(groupID)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, exists := f.securityGroups[groupID]; !exists {
+		return nil, newAPIError("InvalidGroup.NotFound", "The security group '%s' does not exist", groupID)
+	}
+	if usedOutsideEC2 || f.isSecurityGroupInUse(groupID) {
+		return nil, newAPIError("DependencyViolation", "resource %s has a dependent object", groupID)
+	}
+	delete(f.securityGroups, groupID)
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+// isSecurityGroupInUse checks whether a securityGroup is attached to a network interface or referenced by another securityGroup.
+func (f *EC2) isSecurityGroupInUse(groupID string) bool {
+	for _, eni := range f.networkInterfaces {
+		for _, group := range eni.Groups {
+			if awssdk.ToString(group.GroupId) == groupID {
+				return true
+			}
+		}
+	}
+	for otherGroupID, sg := range f.securityGroups {
+		if otherGroupID == groupID {
+			continue
+		}
+		for _, permission := range sg.IpPermissions {
+			for _, pair := range permission.UserIdGroupPairs {
+				if awssdk.ToString(pair.GroupId) == groupID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (f *EC2) AuthorizeSecurityGroupIngressWithContext(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "AuthorizeSecurityGroupIngress"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	groupID := awssdk.ToString(input.GroupId)
+	sg, exists := f.securityGroups[groupID]
+	if !exists {
+		return nil, newAPIError("InvalidGroup.NotFound", "The security group '%s' does not exist", groupID)
+	}
+	permissions := slices.Clone(sg.IpPermissions)
+	for _, permission := range expandIPPermissions(input.IpPermissions) {
+		if slices.ContainsFunc(permissions, func(existing ec2types.IpPermission) bool {
+			return buildIPPermissionKey(existing) == buildIPPermissionKey(permission)
+		}) {
+			return nil, newAPIError("InvalidPermission.Duplicate", "the specified rule %q already exists", buildIPPermissionKey(permission))
+		}
+		permissions = append(permissions, permission)
+	}
+	if len(permissions) > f.simulation.Quotas().InboundRulesPerSecurityGroup {
+		return nil, newAPIError("RulesPerSecurityGroupLimitExceeded", "The maximum number of rules per security group has been reached")
+	}
+	sg.IpPermissions = permissions
+	f.securityGroups[groupID] = sg
+	return &ec2.AuthorizeSecurityGroupIngressOutput{Return: awssdk.Bool(true)}, nil
+}
+
+func (f *EC2) RevokeSecurityGroupIngressWithContext(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	if err := f.simulation.call(ServiceEC2, "RevokeSecurityGroupIngress"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	groupID := awssdk.ToString(input.GroupId)
+	sg, exists := f.securityGroups[groupID]
+	if !exists {
+		return nil, newAPIError("InvalidGroup.NotFound", "The security group '%s' does not exist", groupID)
+	}
+	permissions := slices.Clone(sg.IpPermissions)
+	for _, permission := range expandIPPermissions(input.IpPermissions) {
+		index := slices.IndexFunc(permissions, func(existing ec2types.IpPermission) bool {
+			return buildIPPermissionKey(existing) == buildIPPermissionKey(permission)
+		})
+		if index < 0 {
+			return nil, newAPIError("InvalidPermission.NotFound", "The specified rule %q does not exist in this security group", buildIPPermissionKey(permission))
+		}
+		permissions = slices.Delete(permissions, index, index+1)
+	}
+	sg.IpPermissions = permissions
+	f.securityGroups[groupID] = sg
+	return &ec2.RevokeSecurityGroupIngressOutput{Return: awssdk.Bool(true)}, nil
+}
+
+// expandIPPermissions splits permissions so that each permission has a single source, the way EC2 stores them as securityGroup rules.
+func expandIPPermissions(permissions []ec2types.IpPermission) []ec2types.IpPermission {
+	var expanded []ec2types.IpPermission
+	for _, permission := range permissions {
+		base := ec2types.IpPermission{
+			IpProtocol: permission.IpProtocol,
+			FromPort:   permission.FromPort,
+			ToPort:     permission.ToPort,
+		}
+		for _, ipRange := range permission.IpRanges {
+			expandedPermission := base
+			expandedPermission.IpRanges = []ec2types.IpRange{ipRange}
+			expanded = append(expanded, expandedPermission)
+		}
+		for _, ipv6Range := range permission.Ipv6Ranges {
+			expandedPermission := base
+			expandedPermission.Ipv6Ranges = []ec2types.Ipv6Range{ipv6Range}
+			expanded = append(expanded, expandedPermission)
+		}
+		for _, prefixListID := range permission.PrefixListIds {
+			expandedPermission := base
+			expandedPermission.PrefixListIds = []ec2types.PrefixListId{prefixListID}
+			expanded = append(expanded, expandedPermission)
+		}
+		for _, groupPair := range permission.UserIdGroupPairs {
+			expandedPermission := base
+			expandedPermission.UserIdGroupPairs = []ec2types.UserIdGroupPair{groupPair}
+			expanded = append(expanded, expandedPermission)
+		}
+	}
+	return expanded
+}
+
+// buildIPPermissionKey identifies a single source permission regardless of its description.
+func buildIPPermissionKey(permission ec2types.IpPermission) string {
+	var source string
+	switch {
+	case len(permission.IpRanges) != 0:
+		source = awssdk.ToString(permission.IpRanges[0].CidrIp)
+	case len(permission.Ipv6Ranges) != 0:
+		source = awssdk.ToString(permission.Ipv6Ranges[0].CidrIpv6)
+	case len(permission.PrefixListIds) != 0:
+		source = awssdk.ToString(permission.PrefixListIds[0].PrefixListId)
+	case len(permission.UserIdGroupPairs) != 0:
+		source = awssdk.ToString(permission.UserIdGroupPairs[0].GroupId)
+	}
+	return fmt.Sprintf("%s/%d-%d/%s", awssdk.ToString(permission.IpProtocol), awssdk.ToInt32(permission.FromPort), awssdk.ToInt32(permission.ToPort), source)
+}
+
+// lookupSubnet returns a subnet by ID.
+func (f *EC2) lookupSubnet(subnetID string) (ec2types.Subnet, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	subnet, exists := f.subnets[subnetID]
+	return subnet, exists
+}
+
+// lookupSecurityGroup returns a securityGroup by ID.
+func (f *EC2) lookupSecurityGroup(groupID string) (ec2types.SecurityGroup, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	sg, exists := f.securityGroups[groupID]
+	return sg, exists
+}
+
+// lookupInstance returns an instance by ID.
+func (f *EC2) lookupInstance(instanceID string) (ec2types.Instance, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	instance, exists := f.instances[instanceID]
+	return instance, exists
+}
+
+// isAddressInVPC checks whether an IP address is within the CIDRs of the VPC.
+func (f *EC2) isAddressInVPC(vpcID string, ip netip.Addr) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	vpc, exists := f.vpcs[vpcID]
+	if !exists {
+		return false
+	}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		prefix, err := netip.ParsePrefix(awssdk.ToString(association.CidrBlock))
+		if err == nil && prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *EC2) countSecurityGroups(vpcID string) int {
+	count := 0
+	for _, sg := range f.securityGroups {
+		if awssdk.ToString(sg.VpcId) == vpcID {
+			count++
+		}
+	}
+	return count
+}
+
+// taggedSecurityGroups returns the security groups with their tags, identified by their ARNs in region for accountID.
+func (f *EC2) taggedSecurityGroups(region string, accountID string) []taggedResource {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var resources []taggedResource
+	for _, groupID := range sortedKeys(f.securityGroups) {
+		tags := make(map[string]string)
+		for _, tag := range f.securityGroups[groupID].Tags {
+			tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+		}
+		resources = append(resources, taggedResource{
+			arn:          fmt.Sprintf("arn:aws:ec2:%s:%s:security-group/%s", region, accountID, groupID),
+			resourceType: services.ResourceTypeEC2SecurityGroup,
+			tags:         tags,
+		})
+	}
+	return resources
+}
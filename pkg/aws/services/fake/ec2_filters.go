@@ -0,0 +1,100 @@
+package fake
+
+import (
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ec2FieldValuesFunc returns the values of a resource for an EC2 filter name, and whether the filter name is supported.
+type ec2FieldValuesFunc func(filterName string) ([]string, bool)
+
+// matchEC2Filters checks whether a resource matches all filters, the way EC2 describe APIs evaluate them:
+// filters are ANDed, values within a filter are ORed, and values may contain "*" and "?" wildcards.
+func matchEC2Filters(filters []ec2types.Filter, tags []ec2types.Tag, fieldValues ec2FieldValuesFunc) (bool, error) {
+	for _, filter := range filters {
+		filterName := awssdk.ToString(filter.Name)
+		var resourceValues []string
+		switch {
+		case strings.HasPrefix(filterName, "tag:"):
+			tagKey := strings.TrimPrefix(filterName, "tag:")
+			for _, tag := range tags {
+				if awssdk.ToString(tag.Key) == tagKey {
+					resourceValues = append(resourceValues, awssdk.ToString(tag.Value))
+				}
+			}
+		case filterName == "tag-key":
+			for _, tag := range tags {
+				resourceValues = append(resourceValues, awssdk.ToString(tag.Key))
+			}
+		default:
+			values, supported := fieldValues(filterName)
+			if !supported {
+				return false, newAPIError("InvalidParameterValue", "The filter '%s' is invalid", filterName)
+			}
+			resourceValues = values
+		}
+		if !matchAnyFilterValue(filter.Values, resourceValues) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchAnyFilterValue(filterValues []string, resourceValues []string) bool {
+	for _, resourceValue := range resourceValues {
+		if matchAnyGlob(filterValues, resourceValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildEC2TagsFromSpecifications returns the tags of tagSpecifications that apply to resourceType.
+func buildEC2TagsFromSpecifications(tagSpecifications []ec2types.TagSpecification, resourceType ec2types.ResourceType) []ec2types.Tag {
+	var tags []ec2types.Tag
+	for _, tagSpecification := range tagSpecifications {
+		if tagSpecification.ResourceType == resourceType {
+			tags = append(tags, tagSpecification.Tags...)
+		}
+	}
+	return tags
+}
+
+// mergeEC2Tags returns tags with newTags added, replacing the values of existing keys.
+func mergeEC2Tags(tags []ec2types.Tag, newTags []ec2types.Tag) []ec2types.Tag {
+	merged := make([]ec2types.Tag, 0, len(tags)+len(newTags))
+	indexByKey := make(map[string]int, len(tags)+len(newTags))
+	for _, tag := range append(append([]ec2types.Tag{}, tags...), newTags...) {
+		key := awssdk.ToString(tag.Key)
+		if index, exists := indexByKey[key]; exists {
+			merged[index] = tag
+			continue
+		}
+		indexByKey[key] = len(merged)
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
+// removeEC2Tags returns tags without the tags to remove. A tag to remove without value removes the key regardless of its value.
+func removeEC2Tags(tags []ec2types.Tag, tagsToRemove []ec2types.Tag) []ec2types.Tag {
+	remaining := make([]ec2types.Tag, 0, len(tags))
+	for _, tag := range tags {
+		removed := false
+		for _, tagToRemove := range tagsToRemove {
+			if awssdk.ToString(tag.Key) != awssdk.ToString(tagToRemove.Key) {
+				continue
+			}
+			if tagToRemove.Value == nil || awssdk.ToString(tagToRemove.Value) == awssdk.ToString(tag.Value) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			remaining = append(remaining, tag)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,212 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchEC2Filters(t *testing.T) {
+	tags := []ec2types.Tag{
+		{Key: awssdk.String("kubernetes.io/role/elb"), Value: awssdk.String("1")},
+		{Key: awssdk.String("Name"), Value: awssdk.String("public-a")},
+	}
+	fieldValues := func(filterName string) ([]string, bool) {
+		if filterName == "vpc-id" {
+			return []string{"vpc-1"}, true
+		}
+		return nil, false
+	}
+	tests := []struct {
+		name    string
+		filters []ec2types.Filter
+		want    bool
+		wantErr string
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name: "field and tag filters match",
+			filters: []ec2types.Filter{
+				{Name: awssdk.String("vpc-id"), Values: []string{"vpc-2", "vpc-1"}},
+				{Name: awssdk.String("tag:kubernetes.io/role/elb"), Values: []string{"", "1"}},
+			},
+			want: true,
+		},
+		{
+			name: "wildcard tag value",
+			filters: []ec2types.Filter{
+				{Name: awssdk.String("tag:Name"), Values: []string{"public-*"}},
+			},
+			want: true,
+		},
+		{
+			name: "tag-key",
+			filters: []ec2types.Filter{
+				{Name: awssdk.String("tag-key"), Values: []string{"kubernetes.io/cluster/my-cluster"}},
+			},
+			want: false,
+		},
+		{
+			name: "field mismatch",
+			filters: []ec2types.Filter{
+				{Name: awssdk.String("vpc-id"), Values: []string{"vpc-2"}},
+			},
+			want: false,
+		},
+		{
+			name: "unsupported filter",
+			filters: []ec2types.Filter{
+				{Name: awssdk.String("owner-id"), Values: []string{"123"}},
+			},
+			wantErr: "api error InvalidParameterValue: The filter 'owner-id' is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchEC2Filters(tt.filters, tags, fieldValues)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{pattern: "abc", value: "abc", want: true},
+		{pattern: "abc", value: "abcd", want: false},
+		{pattern: "a*", value: "abcd", want: true},
+		{pattern: "*.example.com", value: "foo.example.com", want: true},
+		{pattern: "*.example.com", value: "example.com", want: false},
+		{pattern: "a?c", value: "abc", want: true},
+		{pattern: "a?c", value: "ac", want: false},
+		{pattern: "*", value: "", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchGlob(tt.pattern, tt.value))
+		})
+	}
+}
+
+func TestEC2_securityGroupLifecycle(t *testing.T) {
+	ctx := context.Background()
+	ec2Fake := NewEC2("us-west-2")
+	vpc := ec2Fake.AddVpc(ec2types.Vpc{CidrBlock: awssdk.String("192.168.0.0/16")})
+
+	createOutput, err := ec2Fake.CreateSecurityGroupWithContext(ctx, &ec2.CreateSecurityGroupInput{
+		GroupName:   awssdk.String("k8s-traffic"),
+		Description: awssdk.String("managed"),
+		VpcId:       vpc.VpcId,
+		TagSpecifications: []ec2types.TagSpecification{
+			{ResourceType: ec2types.ResourceTypeSecurityGroup, Tags: []ec2types.Tag{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")}}},
+		},
+	})
+	assert.NoError(t, err)
+	groupID := awssdk.ToString(createOutput.GroupId)
+
+	_, err = ec2Fake.CreateSecurityGroupWithContext(ctx, &ec2.CreateSecurityGroupInput{
+		GroupName: awssdk.String("k8s-traffic"),
+		VpcId:     vpc.VpcId,
+	})
+	assertAPIErrorCode(t, "InvalidGroup.Duplicate", err)
+
+	permission := ec2types.IpPermission{
+		IpProtocol: awssdk.String("tcp"),
+		FromPort:   awssdk.Int32(80),
+		ToPort:     awssdk.Int32(80),
+		IpRanges: []ec2types.IpRange{
+			{CidrIp: awssdk.String("0.0.0.0/0")},
+			{CidrIp: awssdk.String("10.0.0.0/8")},
+		},
+	}
+	_, err = ec2Fake.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       awssdk.String(groupID),
+		IpPermissions: []ec2types.IpPermission{permission},
+	})
+	assert.NoError(t, err)
+	_, err = ec2Fake.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       awssdk.String(groupID),
+		IpPermissions: []ec2types.IpPermission{permission},
+	})
+	assertAPIErrorCode(t, "InvalidPermission.Duplicate", err)
+
+	sgs, err := ec2Fake.DescribeSecurityGroupsAsList(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{Name: awssdk.String("tag:elbv2.k8s.aws/cluster"), Values: []string{"my-cluster"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, sgs, 1)
+	assert.Len(t, sgs[0].IpPermissions, 2)
+
+	_, err = ec2Fake.RevokeSecurityGroupIngressWithContext(ctx, &ec2.RevokeSecurityGroupIngressInput{
+		GroupId:       awssdk.String(groupID),
+		IpPermissions: []ec2types.IpPermission{permission},
+	})
+	assert.NoError(t, err)
+	_, err = ec2Fake.RevokeSecurityGroupIngressWithContext(ctx, &ec2.RevokeSecurityGroupIngressInput{
+		GroupId:       awssdk.String(groupID),
+		IpPermissions: []ec2types.IpPermission{permission},
+	})
+	assertAPIErrorCode(t, "InvalidPermission.NotFound", err)
+
+	ec2Fake.securityGroupInUse = func(id string) bool { return id == groupID }
+	_, err = ec2Fake.DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{GroupId: awssdk.String(groupID)})
+	assertAPIErrorCode(t, "DependencyViolation", err)
+
+	ec2Fake.securityGroupInUse = nil
+	_, err = ec2Fake.DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{GroupId: awssdk.String(groupID)})
+	assert.NoError(t, err)
+	_, err = ec2Fake.DescribeSecurityGroupsAsList(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: []string{groupID}})
+	assertAPIErrorCode(t, "InvalidGroup.NotFound", err)
+}
+
+func TestEC2_DescribeNetworkInterfacesAsList(t *testing.T) {
+	ctx := context.Background()
+	ec2Fake := NewEC2("us-west-2")
+	vpc := ec2Fake.AddVpc(ec2types.Vpc{CidrBlock: awssdk.String("192.168.0.0/16")})
+	eni := ec2Fake.AddNetworkInterface(ec2types.NetworkInterface{
+		VpcId:            vpc.VpcId,
+		PrivateIpAddress: awssdk.String("192.168.1.10"),
+	})
+	assert.NoError(t, ec2Fake.AssignPrivateIPAddresses(awssdk.ToString(eni.NetworkInterfaceId), "192.168.1.11"))
+
+	byPodIP := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{Name: awssdk.String("vpc-id"), Values: []string{awssdk.ToString(vpc.VpcId)}},
+			{Name: awssdk.String("addresses.private-ip-address"), Values: []string{"192.168.1.11"}},
+		},
+	}
+	enis, err := ec2Fake.DescribeNetworkInterfacesAsList(ctx, byPodIP)
+	assert.NoError(t, err)
+	assert.Len(t, enis, 1)
+
+	assert.NoError(t, ec2Fake.UnassignPrivateIPAddresses(awssdk.ToString(eni.NetworkInterfaceId), "192.168.1.11"))
+	enis, err = ec2Fake.DescribeNetworkInterfacesAsList(ctx, byPodIP)
+	assert.NoError(t, err)
+	assert.Empty(t, enis)
+}
+
+func assertAPIErrorCode(t *testing.T, wantCode string, err error) {
+	t.Helper()
+	var apiErr smithy.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, wantCode, apiErr.ErrorCode())
+	}
+}
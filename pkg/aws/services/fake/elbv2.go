@@ -0,0 +1,577 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.ELBV2 = &ELBV2{}
+
+const (
+	albCanonicalHostedZoneID = "Z1H1FL5HABSF5"
+	nlbCanonicalHostedZoneID = "Z18D5FSROUN65G"
+
+	defaultRulePriority = "default"
+)
+
+var loadBalancerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,30}[a-zA-Z0-9])?$`)
+
+// ELBV2 is a stateful in-memory implementation of services.ELBV2.
+type ELBV2 struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+	region     string
+	accountID  string
+	ec2        *EC2
+
+	loadBalancers map[string]*loadBalancerState
+	listeners     map[string]*listenerState
+	rules         map[string]*ruleState
+	targetGroups  map[string]*targetGroupState
+	tags          map[string]map[string]string
+}
+
+type loadBalancerState struct {
+	loadBalancer    elbv2types.LoadBalancer
+	attributes      map[string]string
+	minimumCapacity *elbv2types.MinimumLoadBalancerCapacity
+}
+
+type listenerState struct {
+	listener   elbv2types.Listener
+	attributes map[string]string
+	// certificates are the certificates of the listener besides its default certificate.
+	certificates []elbv2types.Certificate
+}
+
+type ruleState struct {
+	listenerARN string
+	rule        elbv2types.Rule
+}
+
+type targetGroupState struct {
+	targetGroup elbv2types.TargetGroup
+	attributes  map[string]string
+	targets     map[string]elbv2types.TargetDescription
+}
+
+// NewELBV2 constructs a new fake ELBV2, which resolves subnets and securityGroups from ec2.
+func NewELBV2(region string, accountID string, ec2 *EC2) *ELBV2 {
+	elbv2Fake := &ELBV2{
+		ids:           newIDGenerator(),
+		simulation:    ec2.simulation,
+		region:        region,
+		accountID:     accountID,
+		ec2:           ec2,
+		loadBalancers: make(map[string]*loadBalancerState),
+		listeners:     make(map[string]*listenerState),
+		rules:         make(map[string]*ruleState),
+		targetGroups:  make(map[string]*targetGroupState),
+		tags:          make(map[string]map[string]string),
+	}
+	ec2.securityGroupInUse = elbv2Fake.isSecurityGroupInUse
+	return elbv2Fake
+}
+
+func (f *ELBV2) AssumeRole(ctx context.Context, assumeRoleArn string, externalId string) (services.ELBV2, error) {
+	return f, nil
+}
+
+func (f *ELBV2) DescribeLoadBalancersAsList(ctx context.Context, input *elbv2sdk.DescribeLoadBalancersInput) ([]elbv2types.LoadBalancer, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeLoadBalancers"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, lbARN := range input.LoadBalancerArns {
+		if _, exists := f.loadBalancers[lbARN]; !exists {
+			return nil, newLoadBalancerNotFoundError(lbARN)
+		}
+	}
+	var lbs []elbv2types.LoadBalancer
+	foundNames := make(map[string]bool)
+	for _, lbARN := range sortedKeys(f.loadBalancers) {
+		lb := f.loadBalancers[lbARN].loadBalancer
+		if !f.simulation.visible(lbARN) {
+			if slices.Contains(input.LoadBalancerArns, lbARN) {
+				return nil, newLoadBalancerNotFoundError(lbARN)
+			}
+			continue
+		}
+		if len(input.LoadBalancerArns) != 0 && !slices.Contains(input.LoadBalancerArns, lbARN) {
+			continue
+		}
+		if len(input.Names) != 0 && !slices.Contains(input.Names, awssdk.ToString(lb.LoadBalancerName)) {
+			continue
+		}
+		foundNames[awssdk.ToString(lb.LoadBalancerName)] = true
+		lbs = append(lbs, lb)
+	}
+	for _, name := range input.Names {
+		if !foundNames[name] {
+			return nil, newLoadBalancerNotFoundError(name)
+		}
+	}
+	return lbs, nil
+}
+
+func (f *ELBV2) DescribeLoadBalancersWithContext(ctx context.Context, input *elbv2sdk.DescribeLoadBalancersInput) (*elbv2sdk.DescribeLoadBalancersOutput, error) {
+	lbs, err := f.DescribeLoadBalancersAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeLoadBalancersOutput{LoadBalancers: lbs}, nil
+}
+
+func (f *ELBV2) WaitUntilLoadBalancerAvailableWithContext(ctx context.Context, input *elbv2sdk.DescribeLoadBalancersInput) error {
+	lbs, err := f.DescribeLoadBalancersAsList(ctx, input)
+	if err != nil {
+		return err
+	}
+	for _, lb := range lbs {
+		if lb.State == nil || lb.State.Code != elbv2types.LoadBalancerStateEnumActive {
+			return fmt.Errorf("load balancer %s is not available", awssdk.ToString(lb.LoadBalancerArn))
+		}
+	}
+	return nil
+}
+
+func (f *ELBV2) CreateLoadBalancerWithContext(ctx context.Context, input *elbv2sdk.CreateLoadBalancerInput) (*elbv2sdk.CreateLoadBalancerOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "CreateLoadBalancer"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbName := awssdk.ToString(input.Name)
+	if !loadBalancerNamePattern.MatchString(lbName) || strings.HasPrefix(lbName, "internal-") {
+		return nil, newValidationError("The load balancer name '%s' is not valid", lbName)
+	}
+	for _, lbState := range f.loadBalancers {
+		if awssdk.ToString(lbState.loadBalancer.LoadBalancerName) == lbName {
+			return nil, &elbv2types.DuplicateLoadBalancerNameException{Message: awssdk.String(fmt.Sprintf("A load balancer with the same name '%s' exists, but with different settings", lbName))}
+		}
+	}
+	if len(f.loadBalancers) >= f.simulation.Quotas().LoadBalancers {
+		return nil, &elbv2types.TooManyLoadBalancersException{Message: awssdk.String("The quota for the number of load balancers has been reached")}
+	}
+
+	lbType := input.Type
+	if lbType == "" {
+		lbType = elbv2types.LoadBalancerTypeEnumApplication
+	}
+	scheme := input.Scheme
+	if scheme == "" {
+		scheme = elbv2types.LoadBalancerSchemeEnumInternetFacing
+	}
+	ipAddressType := input.IpAddressType
+	if ipAddressType == "" {
+		ipAddressType = elbv2types.IpAddressTypeIpv4
+	}
+	subnetMappings := input.SubnetMappings
+	for _, subnetID := range input.Subnets {
+		subnetMappings = append(subnetMappings, elbv2types.SubnetMapping{SubnetId: awssdk.String(subnetID)})
+	}
+	vpcID, azs, err := f.buildAvailabilityZones(lbType, subnetMappings)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.validateSecurityGroups(vpcID, input.SecurityGroups); err != nil {
+		return nil, err
+	}
+
+	lbID := f.ids.nextHex("loadbalancer")
+	lb := elbv2types.LoadBalancer{
+		LoadBalancerArn:       awssdk.String(fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/%s/%s/%s", f.region, f.accountID, buildLoadBalancerTypeARNPart(lbType), lbName, lbID)),
+		LoadBalancerName:      input.Name,
+		DNSName:               awssdk.String(f.buildLoadBalancerDNSName(lbName, lbID, lbType, scheme)),
+		CanonicalHostedZoneId: awssdk.String(buildCanonicalHostedZoneID(lbType)),
+		Type:                  lbType,
+		Scheme:                scheme,
+		IpAddressType:         ipAddressType,
+		VpcId:                 awssdk.String(vpcID),
+		AvailabilityZones:     azs,
+		SecurityGroups:        input.SecurityGroups,
+		CustomerOwnedIpv4Pool: input.CustomerOwnedIpv4Pool,
+		IpamPools:             input.IpamPools,
+		CreatedTime:           awssdk.Time(time.Now()),
+		State:                 &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive},
+	}
+	f.loadBalancers[awssdk.ToString(lb.LoadBalancerArn)] = &loadBalancerState{
+		loadBalancer: lb,
+		attributes:   buildDefaultLoadBalancerAttributes(lbType),
+	}
+	f.tags[awssdk.ToString(lb.LoadBalancerArn)] = buildTagMap(input.Tags)
+	f.simulation.created(awssdk.ToString(lb.LoadBalancerArn))
+	return &elbv2sdk.CreateLoadBalancerOutput{LoadBalancers: []elbv2types.LoadBalancer{lb}}, nil
+}
+
+func (f *ELBV2) DeleteLoadBalancerWithContext(ctx context.Context, input *elbv2sdk.DeleteLoadBalancerInput) (*elbv2sdk.DeleteLoadBalancerOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DeleteLoadBalancer"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbARN := awssdk.ToString(input.LoadBalancerArn)
+	lbState, exists := f.loadBalancers[lbARN]
+	if !exists {
+		return &elbv2sdk.DeleteLoadBalancerOutput{}, nil
+	}
+	if lbState.attributes["deletion_protection.enabled"] == "true" {
+		return nil, &elbv2types.OperationNotPermittedException{Message: awssdk.String(fmt.Sprintf("Load balancer '%s' cannot be deleted because deletion protection is enabled", lbARN))}
+	}
+	for listenerARN, lsState := range f.listeners {
+		if awssdk.ToString(lsState.listener.LoadBalancerArn) == lbARN {
+			f.deleteListener(listenerARN)
+		}
+	}
+	delete(f.loadBalancers, lbARN)
+	delete(f.tags, lbARN)
+	return &elbv2sdk.DeleteLoadBalancerOutput{}, nil
+}
+
+func (f *ELBV2) SetIpAddressTypeWithContext(ctx context.Context, input *elbv2sdk.SetIpAddressTypeInput) (*elbv2sdk.SetIpAddressTypeOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "SetIpAddressType"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	lbState.loadBalancer.IpAddressType = input.IpAddressType
+	return &elbv2sdk.SetIpAddressTypeOutput{IpAddressType: input.IpAddressType}, nil
+}
+
+func (f *ELBV2) SetSubnetsWithContext(ctx context.Context, input *elbv2sdk.SetSubnetsInput) (*elbv2sdk.SetSubnetsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "SetSubnets"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	subnetMappings := input.SubnetMappings
+	for _, subnetID := range input.Subnets {
+		subnetMappings = append(subnetMappings, elbv2types.SubnetMapping{SubnetId: awssdk.String(subnetID)})
+	}
+	vpcID, azs, err := f.buildAvailabilityZones(lbState.loadBalancer.Type, subnetMappings)
+	if err != nil {
+		return nil, err
+	}
+	if vpcID != awssdk.ToString(lbState.loadBalancer.VpcId) {
+		return nil, &elbv2types.InvalidSubnetException{Message: awssdk.String("subnets must be in the VPC of the load balancer")}
+	}
+	lbState.loadBalancer.AvailabilityZones = azs
+	if input.IpAddressType != "" {
+		lbState.loadBalancer.IpAddressType = input.IpAddressType
+	}
+	return &elbv2sdk.SetSubnetsOutput{AvailabilityZones: azs, IpAddressType: lbState.loadBalancer.IpAddressType}, nil
+}
+
+func (f *ELBV2) SetSecurityGroupsWithContext(ctx context.Context, input *elbv2sdk.SetSecurityGroupsInput) (*elbv2sdk.SetSecurityGroupsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "SetSecurityGroups"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	if err := f.validateSecurityGroups(awssdk.ToString(lbState.loadBalancer.VpcId), input.SecurityGroups); err != nil {
+		return nil, err
+	}
+	lbState.loadBalancer.SecurityGroups = slices.Clone(input.SecurityGroups)
+	if input.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic != "" {
+		lbState.loadBalancer.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic = awssdk.String(string(input.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic))
+	}
+	return &elbv2sdk.SetSecurityGroupsOutput{SecurityGroupIds: lbState.loadBalancer.SecurityGroups}, nil
+}
+
+func (f *ELBV2) ModifyLoadBalancerAttributesWithContext(ctx context.Context, input *elbv2sdk.ModifyLoadBalancerAttributesInput) (*elbv2sdk.ModifyLoadBalancerAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyLoadBalancerAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	for _, attribute := range input.Attributes {
+		lbState.attributes[awssdk.ToString(attribute.Key)] = awssdk.ToString(attribute.Value)
+	}
+	return &elbv2sdk.ModifyLoadBalancerAttributesOutput{Attributes: buildLoadBalancerAttributes(lbState.attributes)}, nil
+}
+
+func (f *ELBV2) DescribeLoadBalancerAttributesWithContext(ctx context.Context, input *elbv2sdk.DescribeLoadBalancerAttributesInput) (*elbv2sdk.DescribeLoadBalancerAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeLoadBalancerAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	return &elbv2sdk.DescribeLoadBalancerAttributesOutput{Attributes: buildLoadBalancerAttributes(lbState.attributes)}, nil
+}
+
+func (f *ELBV2) ModifyCapacityReservationWithContext(ctx context.Context, input *elbv2sdk.ModifyCapacityReservationInput) (*elbv2sdk.ModifyCapacityReservationOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyCapacityReservation"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	if awssdk.ToBool(input.ResetCapacityReservation) {
+		lbState.minimumCapacity = nil
+	} else {
+		lbState.minimumCapacity = input.MinimumLoadBalancerCapacity
+	}
+	return &elbv2sdk.ModifyCapacityReservationOutput{
+		CapacityReservationState:    buildCapacityReservationState(lbState),
+		MinimumLoadBalancerCapacity: lbState.minimumCapacity,
+		LastModifiedTime:            awssdk.Time(time.Now()),
+	}, nil
+}
+
+func (f *ELBV2) DescribeCapacityReservationWithContext(ctx context.Context, input *elbv2sdk.DescribeCapacityReservationInput) (*elbv2sdk.DescribeCapacityReservationOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeCapacityReservation"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	return &elbv2sdk.DescribeCapacityReservationOutput{
+		CapacityReservationState:    buildCapacityReservationState(lbState),
+		MinimumLoadBalancerCapacity: lbState.minimumCapacity,
+	}, nil
+}
+
+func (f *ELBV2) ModifyIPPoolsWithContext(ctx context.Context, input *elbv2sdk.ModifyIpPoolsInput) (*elbv2sdk.ModifyIpPoolsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyIPPools"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbState, exists := f.loadBalancers[awssdk.ToString(input.LoadBalancerArn)]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(awssdk.ToString(input.LoadBalancerArn))
+	}
+	if len(input.RemoveIpamPools) != 0 {
+		lbState.loadBalancer.IpamPools = nil
+	}
+	if input.IpamPools != nil {
+		lbState.loadBalancer.IpamPools = input.IpamPools
+	}
+	return &elbv2sdk.ModifyIpPoolsOutput{IpamPools: lbState.loadBalancer.IpamPools}, nil
+}
+
+// buildAvailabilityZones resolves the VPC and availability zones of a load balancer from its subnet mappings.
+func (f *ELBV2) buildAvailabilityZones(lbType elbv2types.LoadBalancerTypeEnum, subnetMappings []elbv2types.SubnetMapping) (string, []elbv2types.AvailabilityZone, error) {
+	if len(subnetMappings) == 0 {
+		return "", nil, newValidationError("At least one subnet must be specified")
+	}
+	var vpcID string
+	var azs []elbv2types.AvailabilityZone
+	for _, mapping := range subnetMappings {
+		subnetID := awssdk.ToString(mapping.SubnetId)
+		subnet, exists := f.ec2.lookupSubnet(subnetID)
+		if !exists {
+			return "", nil, &elbv2types.SubnetNotFoundException{Message: awssdk.String(fmt.Sprintf("The subnet ID '%s' is not valid", subnetID))}
+		}
+		if vpcID != "" && vpcID != awssdk.ToString(subnet.VpcId) {
+			return "", nil, &elbv2types.InvalidSubnetException{Message: awssdk.String("All subnets must belong to the same VPC")}
+		}
+		vpcID = awssdk.ToString(subnet.VpcId)
+		if slices.ContainsFunc(azs, func(az elbv2types.AvailabilityZone) bool {
+			return awssdk.ToString(az.ZoneName) == awssdk.ToString(subnet.AvailabilityZone)
+		}) {
+			return "", nil, &elbv2types.InvalidConfigurationRequestException{Message: awssdk.String(fmt.Sprintf("A load balancer cannot be attached to multiple subnets in the same Availability Zone '%s'", awssdk.ToString(subnet.AvailabilityZone)))}
+		}
+		az := elbv2types.AvailabilityZone{
+			ZoneName: subnet.AvailabilityZone,
+			SubnetId: subnet.SubnetId,
+		}
+		if mapping.AllocationId != nil || mapping.PrivateIPv4Address != nil || mapping.IPv6Address != nil {
+			az.LoadBalancerAddresses = []elbv2types.LoadBalancerAddress{
+				{
+					AllocationId:       mapping.AllocationId,
+					PrivateIPv4Address: mapping.PrivateIPv4Address,
+					IPv6Address:        mapping.IPv6Address,
+				},
+			}
+		}
+		azs = append(azs, az)
+	}
+	if lbType == elbv2types.LoadBalancerTypeEnumApplication && len(azs) < 2 {
+		return "", nil, &elbv2types.InvalidConfigurationRequestException{Message: awssdk.String("At least two subnets in two different Availability Zones must be specified")}
+	}
+	return vpcID, azs, nil
+}
+
+func (f *ELBV2) validateSecurityGroups(vpcID string, groupIDs []string) error {
+	for _, groupID := range groupIDs {
+		sg, exists := f.ec2.lookupSecurityGroup(groupID)
+		if !exists || awssdk.ToString(sg.VpcId) != vpcID {
+			return &elbv2types.InvalidSecurityGroupException{Message: awssdk.String(fmt.Sprintf("One or more security groups are invalid: %s", groupID))}
+		}
+	}
+	return nil
+}
+
+// isSecurityGroupInUse checks whether a securityGroup is attached to a load balancer.
+func (f *ELBV2) isSecurityGroupInUse(groupID string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, lbState := range f.loadBalancers {
+		if slices.Contains(lbState.loadBalancer.SecurityGroups, groupID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ELBV2) buildLoadBalancerDNSName(lbName string, lbID string, lbType elbv2types.LoadBalancerTypeEnum, scheme elbv2types.LoadBalancerSchemeEnum) string {
+	prefix := ""
+	if scheme == elbv2types.LoadBalancerSchemeEnumInternal {
+		prefix = "internal-"
+	}
+	if lbType == elbv2types.LoadBalancerTypeEnumApplication {
+		return fmt.Sprintf("%s%s-%s.%s.elb.amazonaws.com", prefix, lbName, lbID[len(lbID)-10:], f.region)
+	}
+	return fmt.Sprintf("%s%s-%s.elb.%s.amazonaws.com", prefix, lbName, lbID, f.region)
+}
+
+func buildLoadBalancerTypeARNPart(lbType elbv2types.LoadBalancerTypeEnum) string {
+	switch lbType {
+	case elbv2types.LoadBalancerTypeEnumNetwork:
+		return "net"
+	case elbv2types.LoadBalancerTypeEnumGateway:
+		return "gwy"
+	default:
+		return "app"
+	}
+}
+
+func buildCanonicalHostedZoneID(lbType elbv2types.LoadBalancerTypeEnum) string {
+	if lbType == elbv2types.LoadBalancerTypeEnumApplication {
+		return albCanonicalHostedZoneID
+	}
+	return nlbCanonicalHostedZoneID
+}
+
+func buildDefaultLoadBalancerAttributes(lbType elbv2types.LoadBalancerTypeEnum) map[string]string {
+	attributes := map[string]string{
+		"deletion_protection.enabled": "false",
+		"access_logs.s3.enabled":      "false",
+		"access_logs.s3.bucket":       "",
+		"access_logs.s3.prefix":       "",
+	}
+	switch lbType {
+	case elbv2types.LoadBalancerTypeEnumApplication:
+		attributes["idle_timeout.timeout_seconds"] = "60"
+		attributes["routing.http2.enabled"] = "true"
+		attributes["routing.http.drop_invalid_header_fields.enabled"] = "false"
+		attributes["waf.fail_open.enabled"] = "false"
+	case elbv2types.LoadBalancerTypeEnumNetwork:
+		attributes["load_balancing.cross_zone.enabled"] = "false"
+	}
+	return attributes
+}
+
+func buildLoadBalancerAttributes(attributes map[string]string) []elbv2types.LoadBalancerAttribute {
+	var sdkAttributes []elbv2types.LoadBalancerAttribute
+	for _, key := range sortedKeys(attributes) {
+		sdkAttributes = append(sdkAttributes, elbv2types.LoadBalancerAttribute{Key: awssdk.String(key), Value: awssdk.String(attributes[key])})
+	}
+	return sdkAttributes
+}
+
+func buildCapacityReservationState(lbState *loadBalancerState) []elbv2types.ZonalCapacityReservationState {
+	if lbState.minimumCapacity == nil {
+		return nil
+	}
+	var states []elbv2types.ZonalCapacityReservationState
+	capacityPerZone := float64(awssdk.ToInt32(lbState.minimumCapacity.CapacityUnits)) / float64(len(lbState.loadBalancer.AvailabilityZones))
+	for _, az := range lbState.loadBalancer.AvailabilityZones {
+		states = append(states, elbv2types.ZonalCapacityReservationState{
+			AvailabilityZone:       az.ZoneName,
+			EffectiveCapacityUnits: awssdk.Float64(capacityPerZone),
+			State:                  &elbv2types.CapacityReservationStatus{Code: elbv2types.CapacityReservationStateEnumProvisioned},
+		})
+	}
+	return states
+}
+
+func newLoadBalancerNotFoundError(lbRef string) error {
+	return &elbv2types.LoadBalancerNotFoundException{Message: awssdk.String(fmt.Sprintf("One or more load balancers not found: %s", lbRef))}
+}
+
+func newValidationError(format string, args ...any) error {
+	return newAPIError("ValidationError", format, args...)
+}
+
+// loadBalancerExists checks whether the load balancer lbARN exists.
+func (f *ELBV2) loadBalancerExists(lbARN string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	_, exists := f.loadBalancers[lbARN]
+	return exists
+}
+
+// loadBalancersUsingCertificate returns the load balancers with listeners using certARN.
+func (f *ELBV2) loadBalancersUsingCertificate(certARN string) []string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var lbARNs []string
+	for _, lsARN := range sortedKeys(f.listeners) {
+		lsState := f.listeners[lsARN]
+		lbARN := awssdk.ToString(lsState.listener.LoadBalancerArn)
+		if slices.Contains(lbARNs, lbARN) {
+			continue
+		}
+		certificates := append(slices.Clone(lsState.listener.Certificates), lsState.certificates...)
+		if slices.ContainsFunc(certificates, func(cert elbv2types.Certificate) bool {
+			return awssdk.ToString(cert.CertificateArn) == certARN
+		}) {
+			lbARNs = append(lbARNs, lbARN)
+		}
+	}
+	return lbARNs
+}
+
+// taggedResources returns the load balancers and target groups, with their tags.
+func (f *ELBV2) taggedResources() []taggedResource {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var resources []taggedResource
+	for _, lbARN := range sortedKeys(f.loadBalancers) {
+		resources = append(resources, taggedResource{arn: lbARN, resourceType: services.ResourceTypeELBLoadBalancer, tags: copyTags(f.tags[lbARN])})
+	}
+	for _, tgARN := range sortedKeys(f.targetGroups) {
+		resources = append(resources, taggedResource{arn: tgARN, resourceType: services.ResourceTypeELBTargetGroup, tags: copyTags(f.tags[tgARN])})
+	}
+	return resources
+}
@@ -0,0 +1,660 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+const (
+	minRulePriority = 1
+	maxRulePriority = 50000
+)
+
+func (f *ELBV2) DescribeListenersAsList(ctx context.Context, input *elbv2sdk.DescribeListenersInput) ([]elbv2types.Listener, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeListeners"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lbARN := awssdk.ToString(input.LoadBalancerArn)
+	if lbARN != "" {
+		if _, exists := f.loadBalancers[lbARN]; !exists {
+			return nil, newLoadBalancerNotFoundError(lbARN)
+		}
+	}
+	for _, listenerARN := range input.ListenerArns {
+		if _, exists := f.listeners[listenerARN]; !exists {
+			return nil, newListenerNotFoundError(listenerARN)
+		}
+	}
+	var listeners []elbv2types.Listener
+	for _, listenerARN := range sortedKeys(f.listeners) {
+		listener := f.listeners[listenerARN].listener
+		if lbARN != "" && awssdk.ToString(listener.LoadBalancerArn) != lbARN {
+			continue
+		}
+		if len(input.ListenerArns) != 0 && !slices.Contains(input.ListenerArns, listenerARN) {
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+func (f *ELBV2) DescribeListenersWithContext(ctx context.Context, input *elbv2sdk.DescribeListenersInput) (*elbv2sdk.DescribeListenersOutput, error) {
+	listeners, err := f.DescribeListenersAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeListenersOutput{Listeners: listeners}, nil
+}
+
+func (f *ELBV2) CreateListenerWithContext(ctx context.Context, input *elbv2sdk.CreateListenerInput) (*elbv2sdk.CreateListenerOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "CreateListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lbARN := awssdk.ToString(input.LoadBalancerArn)
+	lbState, exists := f.loadBalancers[lbARN]
+	if !exists {
+		return nil, newLoadBalancerNotFoundError(lbARN)
+	}
+	for _, lsState := range f.listeners {
+		if awssdk.ToString(lsState.listener.LoadBalancerArn) == lbARN && awssdk.ToInt32(lsState.listener.Port) == awssdk.ToInt32(input.Port) {
+			return nil, &elbv2types.DuplicateListenerException{Message: awssdk.String(fmt.Sprintf("A listener already exists on port %d", awssdk.ToInt32(input.Port)))}
+		}
+	}
+	if f.countListeners(lbARN) >= f.simulation.Quotas().ListenersPerLoadBalancer {
+		return nil, &elbv2types.TooManyListenersException{Message: awssdk.String(fmt.Sprintf("The load balancer '%s' already has the maximum number of listeners", lbARN))}
+	}
+	if err := validateListenerProtocol(lbState.loadBalancer.Type, input.Protocol, input.Certificates); err != nil {
+		return nil, err
+	}
+	if err := f.validateActions(lbARN, input.DefaultActions); err != nil {
+		return nil, err
+	}
+
+	listenerID := f.ids.nextHex("listener")
+	lbPath := strings.TrimPrefix(arnResource(lbARN), "loadbalancer/")
+	listener := elbv2types.Listener{
+		ListenerArn:          awssdk.String(fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:listener/%s/%s", f.region, f.accountID, lbPath, listenerID)),
+		LoadBalancerArn:      input.LoadBalancerArn,
+		Port:                 input.Port,
+		Protocol:             input.Protocol,
+		Certificates:         buildDefaultCertificates(input.Certificates),
+		SslPolicy:            input.SslPolicy,
+		AlpnPolicy:           input.AlpnPolicy,
+		MutualAuthentication: input.MutualAuthentication,
+		DefaultActions:       slices.Clone(input.DefaultActions),
+	}
+	if listener.SslPolicy == nil && (listener.Protocol == elbv2types.ProtocolEnumHttps || listener.Protocol == elbv2types.ProtocolEnumTls) {
+		listener.SslPolicy = awssdk.String("ELBSecurityPolicy-2016-08")
+	}
+	listenerARN := awssdk.ToString(listener.ListenerArn)
+	f.listeners[listenerARN] = &listenerState{
+		listener:   listener,
+		attributes: buildDefaultListenerAttributes(listener.Protocol),
+	}
+	f.tags[listenerARN] = buildTagMap(input.Tags)
+
+	defaultRuleARN := fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:listener-rule/%s/%s/%s", f.region, f.accountID, lbPath, listenerID, f.ids.nextHex("rule"))
+	f.rules[defaultRuleARN] = &ruleState{
+		listenerARN: listenerARN,
+		rule: elbv2types.Rule{
+			RuleArn:   awssdk.String(defaultRuleARN),
+			Priority:  awssdk.String(defaultRulePriority),
+			IsDefault: awssdk.Bool(true),
+			Actions:   listener.DefaultActions,
+		},
+	}
+	f.tags[defaultRuleARN] = make(map[string]string)
+	return &elbv2sdk.CreateListenerOutput{Listeners: []elbv2types.Listener{listener}}, nil
+}
+
+func (f *ELBV2) ModifyListenerWithContext(ctx context.Context, input *elbv2sdk.ModifyListenerInput) (*elbv2sdk.ModifyListenerOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	lsState, exists := f.listeners[listenerARN]
+	if !exists {
+		return nil, newListenerNotFoundError(listenerARN)
+	}
+	listener := lsState.listener
+	lbARN := awssdk.ToString(listener.LoadBalancerArn)
+	if input.Port != nil && awssdk.ToInt32(input.Port) != awssdk.ToInt32(listener.Port) {
+		for otherListenerARN, otherLSState := range f.listeners {
+			if otherListenerARN != listenerARN && awssdk.ToString(otherLSState.listener.LoadBalancerArn) == lbARN &&
+				awssdk.ToInt32(otherLSState.listener.Port) == awssdk.ToInt32(input.Port) {
+				return nil, &elbv2types.DuplicateListenerException{Message: awssdk.String(fmt.Sprintf("A listener already exists on port %d", awssdk.ToInt32(input.Port)))}
+			}
+		}
+		listener.Port = input.Port
+	}
+	if input.Protocol != "" {
+		listener.Protocol = input.Protocol
+	}
+	if input.Certificates != nil {
+		listener.Certificates = buildDefaultCertificates(input.Certificates)
+	}
+	if err := validateListenerProtocol(f.loadBalancers[lbARN].loadBalancer.Type, listener.Protocol, listener.Certificates); err != nil {
+		return nil, err
+	}
+	if input.DefaultActions != nil {
+		if err := f.validateActions(lbARN, input.DefaultActions); err != nil {
+			return nil, err
+		}
+		listener.DefaultActions = slices.Clone(input.DefaultActions)
+		for _, rState := range f.rules {
+			if rState.listenerARN == listenerARN && awssdk.ToBool(rState.rule.IsDefault) {
+				rState.rule.Actions = listener.DefaultActions
+			}
+		}
+	}
+	if input.SslPolicy != nil {
+		listener.SslPolicy = input.SslPolicy
+	}
+	if input.AlpnPolicy != nil {
+		listener.AlpnPolicy = input.AlpnPolicy
+	}
+	if input.MutualAuthentication != nil {
+		listener.MutualAuthentication = input.MutualAuthentication
+	}
+	lsState.listener = listener
+	return &elbv2sdk.ModifyListenerOutput{Listeners: []elbv2types.Listener{listener}}, nil
+}
+
+func (f *ELBV2) DeleteListenerWithContext(ctx context.Context, input *elbv2sdk.DeleteListenerInput) (*elbv2sdk.DeleteListenerOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DeleteListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	if _, exists := f.listeners[listenerARN]; !exists {
+		return nil, newListenerNotFoundError(listenerARN)
+	}
+	f.deleteListener(listenerARN)
+	return &elbv2sdk.DeleteListenerOutput{}, nil
+}
+
+// deleteListener deletes a listener together with its rules.
+func (f *ELBV2) deleteListener(listenerARN string) {
+	for ruleARN, rState := range f.rules {
+		if rState.listenerARN == listenerARN {
+			delete(f.rules, ruleARN)
+			delete(f.tags, ruleARN)
+		}
+	}
+	delete(f.listeners, listenerARN)
+	delete(f.tags, listenerARN)
+}
+
+func (f *ELBV2) DescribeListenerCertificatesAsList(ctx context.Context, input *elbv2sdk.DescribeListenerCertificatesInput) ([]elbv2types.Certificate, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeListenerCertificates"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lsState, exists := f.listeners[awssdk.ToString(input.ListenerArn)]
+	if !exists {
+		return nil, newListenerNotFoundError(awssdk.ToString(input.ListenerArn))
+	}
+	certificates := slices.Clone(lsState.listener.Certificates)
+	return append(certificates, lsState.certificates...), nil
+}
+
+func (f *ELBV2) AddListenerCertificatesWithContext(ctx context.Context, input *elbv2sdk.AddListenerCertificatesInput) (*elbv2sdk.AddListenerCertificatesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "AddListenerCertificates"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lsState, exists := f.listeners[awssdk.ToString(input.ListenerArn)]
+	if !exists {
+		return nil, newListenerNotFoundError(awssdk.ToString(input.ListenerArn))
+	}
+	for _, cert := range input.Certificates {
+		if slices.ContainsFunc(lsState.certificates, func(existing elbv2types.Certificate) bool {
+			return awssdk.ToString(existing.CertificateArn) == awssdk.ToString(cert.CertificateArn)
+		}) {
+			continue
+		}
+		lsState.certificates = append(lsState.certificates, elbv2types.Certificate{CertificateArn: cert.CertificateArn, IsDefault: awssdk.Bool(false)})
+	}
+	return &elbv2sdk.AddListenerCertificatesOutput{Certificates: lsState.certificates}, nil
+}
+
+func (f *ELBV2) RemoveListenerCertificatesWithContext(ctx context.Context, input *elbv2sdk.RemoveListenerCertificatesInput) (*elbv2sdk.RemoveListenerCertificatesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "RemoveListenerCertificates"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lsState, exists := f.listeners[awssdk.ToString(input.ListenerArn)]
+	if !exists {
+		return nil, newListenerNotFoundError(awssdk.ToString(input.ListenerArn))
+	}
+	for _, cert := range input.Certificates {
+		for _, defaultCert := range lsState.listener.Certificates {
+			if awssdk.ToString(defaultCert.CertificateArn) == awssdk.ToString(cert.CertificateArn) {
+				return nil, &elbv2types.OperationNotPermittedException{Message: awssdk.String("The default certificate cannot be removed from a listener")}
+			}
+		}
+	}
+	lsState.certificates = slices.DeleteFunc(lsState.certificates, func(existing elbv2types.Certificate) bool {
+		return slices.ContainsFunc(input.Certificates, func(cert elbv2types.Certificate) bool {
+			return awssdk.ToString(existing.CertificateArn) == awssdk.ToString(cert.CertificateArn)
+		})
+	})
+	return &elbv2sdk.RemoveListenerCertificatesOutput{}, nil
+}
+
+func (f *ELBV2) DescribeListenerAttributesWithContext(ctx context.Context, input *elbv2sdk.DescribeListenerAttributesInput) (*elbv2sdk.DescribeListenerAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeListenerAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lsState, exists := f.listeners[awssdk.ToString(input.ListenerArn)]
+	if !exists {
+		return nil, newListenerNotFoundError(awssdk.ToString(input.ListenerArn))
+	}
+	return &elbv2sdk.DescribeListenerAttributesOutput{Attributes: buildListenerAttributes(lsState.attributes)}, nil
+}
+
+func (f *ELBV2) ModifyListenerAttributesWithContext(ctx context.Context, input *elbv2sdk.ModifyListenerAttributesInput) (*elbv2sdk.ModifyListenerAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyListenerAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	lsState, exists := f.listeners[awssdk.ToString(input.ListenerArn)]
+	if !exists {
+		return nil, newListenerNotFoundError(awssdk.ToString(input.ListenerArn))
+	}
+	for _, attribute := range input.Attributes {
+		lsState.attributes[awssdk.ToString(attribute.Key)] = awssdk.ToString(attribute.Value)
+	}
+	return &elbv2sdk.ModifyListenerAttributesOutput{Attributes: buildListenerAttributes(lsState.attributes)}, nil
+}
+
+func (f *ELBV2) DescribeTrustStoresWithContext(ctx context.Context, input *elbv2sdk.DescribeTrustStoresInput) (*elbv2sdk.DescribeTrustStoresOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeTrustStores"); err != nil {
+		return nil, err
+	}
+	if len(input.Names) != 0 || len(input.TrustStoreArns) != 0 {
+		return nil, &elbv2types.TrustStoreNotFoundException{Message: awssdk.String("One or more trust stores not found")}
+	}
+	return &elbv2sdk.DescribeTrustStoresOutput{}, nil
+}
+
+func (f *ELBV2) DescribeRulesAsList(ctx context.Context, input *elbv2sdk.DescribeRulesInput) ([]elbv2types.Rule, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeRules"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	if listenerARN != "" {
+		if _, exists := f.listeners[listenerARN]; !exists {
+			return nil, newListenerNotFoundError(listenerARN)
+		}
+	}
+	for _, ruleARN := range input.RuleArns {
+		if _, exists := f.rules[ruleARN]; !exists {
+			return nil, newRuleNotFoundError(ruleARN)
+		}
+	}
+	var rules []elbv2types.Rule
+	for _, rState := range f.sortedRules() {
+		if listenerARN != "" && rState.listenerARN != listenerARN {
+			continue
+		}
+		if len(input.RuleArns) != 0 && !slices.Contains(input.RuleArns, awssdk.ToString(rState.rule.RuleArn)) {
+			continue
+		}
+		rules = append(rules, rState.rule)
+	}
+	return rules, nil
+}
+
+func (f *ELBV2) DescribeRulesWithContext(ctx context.Context, input *elbv2sdk.DescribeRulesInput) (*elbv2sdk.DescribeRulesOutput, error) {
+	rules, err := f.DescribeRulesAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeRulesOutput{Rules: rules}, nil
+}
+
+func (f *ELBV2) CreateRuleWithContext(ctx context.Context, input *elbv2sdk.CreateRuleInput) (*elbv2sdk.CreateRuleOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "CreateRule"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	lsState, exists := f.listeners[listenerARN]
+	if !exists {
+		return nil, newListenerNotFoundError(listenerARN)
+	}
+	priority := awssdk.ToInt32(input.Priority)
+	if err := f.validateRulePriority(listenerARN, "", priority); err != nil {
+		return nil, err
+	}
+	ruleCount := 0
+	for _, rState := range f.rules {
+		if rState.listenerARN == listenerARN && !awssdk.ToBool(rState.rule.IsDefault) {
+			ruleCount++
+		}
+	}
+	if ruleCount >= f.simulation.Quotas().RulesPerListener {
+		return nil, &elbv2types.TooManyRulesException{Message: awssdk.String(fmt.Sprintf("The listener '%s' already has the maximum number of rules", listenerARN))}
+	}
+	if len(input.Conditions) == 0 {
+		return nil, newValidationError("At least one condition must be specified")
+	}
+	if err := f.validateActions(awssdk.ToString(lsState.listener.LoadBalancerArn), input.Actions); err != nil {
+		return nil, err
+	}
+
+	ruleARN := fmt.Sprintf("%s/%s", strings.Replace(listenerARN, ":listener/", ":listener-rule/", 1), f.ids.nextHex("rule"))
+	rule := elbv2types.Rule{
+		RuleArn:    awssdk.String(ruleARN),
+		Priority:   awssdk.String(strconv.Itoa(int(priority))),
+		IsDefault:  awssdk.Bool(false),
+		Conditions: slices.Clone(input.Conditions),
+		Actions:    slices.Clone(input.Actions),
+		Transforms: slices.Clone(input.Transforms),
+	}
+	f.rules[ruleARN] = &ruleState{listenerARN: listenerARN, rule: rule}
+	f.tags[ruleARN] = buildTagMap(input.Tags)
+	return &elbv2sdk.CreateRuleOutput{Rules: []elbv2types.Rule{rule}}, nil
+}
+
+func (f *ELBV2) ModifyRuleWithContext(ctx context.Context, input *elbv2sdk.ModifyRuleInput) (*elbv2sdk.ModifyRuleOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyRule"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	ruleARN := awssdk.ToString(input.RuleArn)
+	rState, exists := f.rules[ruleARN]
+	if !exists {
+		return nil, newRuleNotFoundError(ruleARN)
+	}
+	if input.Actions != nil {
+		lbARN := awssdk.ToString(f.listeners[rState.listenerARN].listener.LoadBalancerArn)
+		if err := f.validateActions(lbARN, input.Actions); err != nil {
+			return nil, err
+		}
+		rState.rule.Actions = slices.Clone(input.Actions)
+	}
+	if input.Conditions != nil {
+		if awssdk.ToBool(rState.rule.IsDefault) {
+			return nil, &elbv2types.OperationNotPermittedException{Message: awssdk.String("Conditions cannot be specified for the default rule")}
+		}
+		rState.rule.Conditions = slices.Clone(input.Conditions)
+	}
+	if awssdk.ToBool(input.ResetTransforms) {
+		rState.rule.Transforms = nil
+	} else if input.Transforms != nil {
+		rState.rule.Transforms = slices.Clone(input.Transforms)
+	}
+	return &elbv2sdk.ModifyRuleOutput{Rules: []elbv2types.Rule{rState.rule}}, nil
+}
+
+func (f *ELBV2) DeleteRuleWithContext(ctx context.Context, input *elbv2sdk.DeleteRuleInput) (*elbv2sdk.DeleteRuleOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DeleteRule"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	ruleARN := awssdk.ToString(input.RuleArn)
+	rState, exists := f.rules[ruleARN]
+	if !exists {
+		return nil, newRuleNotFoundError(ruleARN)
+	}
+	if awssdk.ToBool(rState.rule.IsDefault) {
+		return nil, &elbv2types.OperationNotPermittedException{Message: awssdk.String("The default rule cannot be deleted")}
+	}
+	delete(f.rules, ruleARN)
+	delete(f.tags, ruleARN)
+	return &elbv2sdk.DeleteRuleOutput{}, nil
+}
+
+func (f *ELBV2) SetRulePrioritiesWithContext(ctx context.Context, input *elbv2sdk.SetRulePrioritiesInput) (*elbv2sdk.SetRulePrioritiesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "SetRulePriorities"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	// priorities are swapped atomically, so a rule may take the priority another rule of the same request gives up.
+	newPriorities := make(map[string]int32, len(input.RulePriorities))
+	for _, pair := range input.RulePriorities {
+		ruleARN := awssdk.ToString(pair.RuleArn)
+		rState, exists := f.rules[ruleARN]
+		if !exists {
+			return nil, newRuleNotFoundError(ruleARN)
+		}
+		if awssdk.ToBool(rState.rule.IsDefault) {
+			return nil, &elbv2types.OperationNotPermittedException{Message: awssdk.String("The priority of the default rule cannot be changed")}
+		}
+		newPriorities[ruleARN] = awssdk.ToInt32(pair.Priority)
+	}
+	prioritiesByListener := make(map[string]map[int32]string)
+	for _, ruleARN := range sortedKeys(f.rules) {
+		rState := f.rules[ruleARN]
+		if awssdk.ToBool(rState.rule.IsDefault) {
+			continue
+		}
+		priority, updated := newPriorities[ruleARN]
+		if !updated {
+			currentPriority, _ := strconv.Atoi(awssdk.ToString(rState.rule.Priority))
+			priority = int32(currentPriority)
+		}
+		if priority < minRulePriority || priority > maxRulePriority {
+			return nil, newValidationError("Priority '%d' must be between %d and %d", priority, minRulePriority, maxRulePriority)
+		}
+		if prioritiesByListener[rState.listenerARN] == nil {
+			prioritiesByListener[rState.listenerARN] = make(map[int32]string)
+		}
+		if _, inUse := prioritiesByListener[rState.listenerARN][priority]; inUse {
+			return nil, newPriorityInUseError(priority)
+		}
+		prioritiesByListener[rState.listenerARN][priority] = ruleARN
+	}
+	var rules []elbv2types.Rule
+	for _, pair := range input.RulePriorities {
+		rState := f.rules[awssdk.ToString(pair.RuleArn)]
+		rState.rule.Priority = awssdk.String(strconv.Itoa(int(awssdk.ToInt32(pair.Priority))))
+		rules = append(rules, rState.rule)
+	}
+	return &elbv2sdk.SetRulePrioritiesOutput{Rules: rules}, nil
+}
+
+// validateRulePriority checks priority is valid and unused by other rules of the listener than ruleARN.
+func (f *ELBV2) validateRulePriority(listenerARN string, ruleARN string, priority int32) error {
+	if priority < minRulePriority || priority > maxRulePriority {
+		return newValidationError("Priority '%d' must be between %d and %d", priority, minRulePriority, maxRulePriority)
+	}
+	for otherRuleARN, rState := range f.rules {
+		if otherRuleARN == ruleARN || rState.listenerARN != listenerARN {
+			continue
+		}
+		if awssdk.ToString(rState.rule.Priority) == strconv.Itoa(int(priority)) {
+			return newPriorityInUseError(priority)
+		}
+	}
+	return nil
+}
+
+// validateActions checks the target groups of actions exist, match the load balancer type,
+// and aren't used by another load balancer, since a target group can only be associated with one load balancer.
+func (f *ELBV2) validateActions(lbARN string, actions []elbv2types.Action) error {
+	lbType := f.loadBalancers[lbARN].loadBalancer.Type
+	for _, tgARN := range targetGroupARNsOfActions(actions) {
+		tgState, exists := f.targetGroups[tgARN]
+		if !exists {
+			return newTargetGroupNotFoundError(tgARN)
+		}
+		tgProtocol := tgState.targetGroup.Protocol
+		isHTTPTargetGroup := tgProtocol == elbv2types.ProtocolEnumHttp || tgProtocol == elbv2types.ProtocolEnumHttps
+		if tgState.targetGroup.TargetType != elbv2types.TargetTypeEnumLambda && isHTTPTargetGroup != (lbType == elbv2types.LoadBalancerTypeEnumApplication) {
+			return &elbv2types.InvalidConfigurationRequestException{Message: awssdk.String(fmt.Sprintf("The protocol of target group '%s' is not supported by load balancers of type '%s'", tgARN, lbType))}
+		}
+		for _, otherLBARN := range f.targetGroupLoadBalancerARNs(tgARN) {
+			if otherLBARN != lbARN {
+				return &elbv2types.TargetGroupAssociationLimitException{Message: awssdk.String(fmt.Sprintf("The following target groups cannot be associated with more than one load balancer: %s", tgARN))}
+			}
+		}
+	}
+	return nil
+}
+
+// targetGroupLoadBalancerARNs returns the load balancers whose listeners or rules forward to a target group.
+func (f *ELBV2) targetGroupLoadBalancerARNs(tgARN string) []string {
+	var lbARNs []string
+	for _, rState := range f.rules {
+		if !slices.Contains(targetGroupARNsOfActions(rState.rule.Actions), tgARN) {
+			continue
+		}
+		lbARN := awssdk.ToString(f.listeners[rState.listenerARN].listener.LoadBalancerArn)
+		if !slices.Contains(lbARNs, lbARN) {
+			lbARNs = append(lbARNs, lbARN)
+		}
+	}
+	sort.Strings(lbARNs)
+	return lbARNs
+}
+
+// sortedRules returns the rules ordered by listener and priority, with the default rule of each listener last.
+func (f *ELBV2) sortedRules() []*ruleState {
+	rules := make([]*ruleState, 0, len(f.rules))
+	for _, rState := range f.rules {
+		rules = append(rules, rState)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].listenerARN != rules[j].listenerARN {
+			return rules[i].listenerARN < rules[j].listenerARN
+		}
+		return rulePriorityOrder(rules[i].rule) < rulePriorityOrder(rules[j].rule)
+	})
+	return rules
+}
+
+func rulePriorityOrder(rule elbv2types.Rule) int {
+	if awssdk.ToBool(rule.IsDefault) {
+		return maxRulePriority + 1
+	}
+	priority, _ := strconv.Atoi(awssdk.ToString(rule.Priority))
+	return priority
+}
+
+// targetGroupARNsOfActions returns the target groups forwarded to by actions.
+func targetGroupARNsOfActions(actions []elbv2types.Action) []string {
+	var tgARNs []string
+	for _, action := range actions {
+		if action.Type != elbv2types.ActionTypeEnumForward {
+			continue
+		}
+		if action.TargetGroupArn != nil && !slices.Contains(tgARNs, awssdk.ToString(action.TargetGroupArn)) {
+			tgARNs = append(tgARNs, awssdk.ToString(action.TargetGroupArn))
+		}
+		if action.ForwardConfig == nil {
+			continue
+		}
+		for _, tgTuple := range action.ForwardConfig.TargetGroups {
+			if !slices.Contains(tgARNs, awssdk.ToString(tgTuple.TargetGroupArn)) {
+				tgARNs = append(tgARNs, awssdk.ToString(tgTuple.TargetGroupArn))
+			}
+		}
+	}
+	return tgARNs
+}
+
+func validateListenerProtocol(lbType elbv2types.LoadBalancerTypeEnum, protocol elbv2types.ProtocolEnum, certificates []elbv2types.Certificate) error {
+	var supportedProtocols []elbv2types.ProtocolEnum
+	switch lbType {
+	case elbv2types.LoadBalancerTypeEnumApplication:
+		supportedProtocols = []elbv2types.ProtocolEnum{elbv2types.ProtocolEnumHttp, elbv2types.ProtocolEnumHttps}
+	case elbv2types.LoadBalancerTypeEnumNetwork:
+		supportedProtocols = []elbv2types.ProtocolEnum{elbv2types.ProtocolEnumTcp, elbv2types.ProtocolEnumUdp, elbv2types.ProtocolEnumTcpUdp,
+			elbv2types.ProtocolEnumTls, elbv2types.ProtocolEnumQuic, elbv2types.ProtocolEnumTcpQuic}
+	default:
+		supportedProtocols = []elbv2types.ProtocolEnum{elbv2types.ProtocolEnumGeneve}
+	}
+	if !slices.Contains(supportedProtocols, protocol) {
+		return &elbv2types.UnsupportedProtocolException{Message: awssdk.String(fmt.Sprintf("Protocol '%s' is not supported by load balancers of type '%s'", protocol, lbType))}
+	}
+	if (protocol == elbv2types.ProtocolEnumHttps || protocol == elbv2types.ProtocolEnumTls) && len(certificates) == 0 {
+		return newValidationError("A certificate must be specified for %s listeners", protocol)
+	}
+	return nil
+}
+
+// buildDefaultCertificates returns the default certificate of a listener, the only certificate listed on the listener itself.
+func buildDefaultCertificates(certificates []elbv2types.Certificate) []elbv2types.Certificate {
+	if len(certificates) == 0 {
+		return nil
+	}
+	return []elbv2types.Certificate{{CertificateArn: certificates[0].CertificateArn, IsDefault: awssdk.Bool(true)}}
+}
+
+func buildDefaultListenerAttributes(protocol elbv2types.ProtocolEnum) map[string]string {
+	switch protocol {
+	case elbv2types.ProtocolEnumTcp, elbv2types.ProtocolEnumTls, elbv2types.ProtocolEnumTcpUdp:
+		return map[string]string{"tcp.idle_timeout.seconds": "350"}
+	case elbv2types.ProtocolEnumHttp, elbv2types.ProtocolEnumHttps:
+		return map[string]string{"routing.http.response.server.enabled": "true"}
+	default:
+		return make(map[string]string)
+	}
+}
+
+func buildListenerAttributes(attributes map[string]string) []elbv2types.ListenerAttribute {
+	var sdkAttributes []elbv2types.ListenerAttribute
+	for _, key := range sortedKeys(attributes) {
+		sdkAttributes = append(sdkAttributes, elbv2types.ListenerAttribute{Key: awssdk.String(key), Value: awssdk.String(attributes[key])})
+	}
+	return sdkAttributes
+}
+
+// arnResource returns the resource part of an ARN, e.g. "loadbalancer/app/my-lb/0000000000000001".
+func arnResource(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	return parts[len(parts)-1]
+}
+
+func newListenerNotFoundError(listenerARN string) error {
+	return &elbv2types.ListenerNotFoundException{Message: awssdk.String(fmt.Sprintf("One or more listeners not found: %s", listenerARN))}
+}
+
+func newRuleNotFoundError(ruleARN string) error {
+	return &elbv2types.RuleNotFoundException{Message: awssdk.String(fmt.Sprintf("One or more rules not found: %s", ruleARN))}
+}
+
+func newPriorityInUseError(priority int32) error {
+	return &elbv2types.PriorityInUseException{Message: awssdk.String(fmt.Sprintf("Priority '%d' is currently in use", priority))}
+}
+
+func (f *ELBV2) countListeners(lbARN string) int {
+	count := 0
+	for _, lsState := range f.listeners {
+		if awssdk.ToString(lsState.listener.LoadBalancerArn) == lbARN {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,412 @@
+package fake
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	conditionFieldHostHeader        = "host-header"
+	conditionFieldPathPattern       = "path-pattern"
+	conditionFieldHTTPHeader        = "http-header"
+	conditionFieldHTTPRequestMethod = "http-request-method"
+	conditionFieldQueryString       = "query-string"
+	conditionFieldSourceIP          = "source-ip"
+)
+
+// RouteResult describes how a load balancer handles a request.
+type RouteResult struct {
+	ListenerARN string
+	RuleARN     string
+	ActionType  elbv2types.ActionTypeEnum
+
+	// TargetGroupARN and Target are the target the request is forwarded to, for forward actions.
+	TargetGroupARN string
+	Target         *elbv2types.TargetDescription
+	// Host, Path and RawQuery are the request as forwarded to the target, after the transforms of the rule.
+	Host     string
+	Path     string
+	RawQuery string
+
+	// StatusCode is the status code of redirect and fixed-response actions.
+	StatusCode int
+	// Location is the redirect URL of redirect actions.
+	Location string
+	// ContentType and Body are the response of fixed-response actions.
+	ContentType string
+	Body        string
+}
+
+// RouteHTTPRequest evaluates the rules of the listener on port of the load balancer with dnsName against req,
+// the way an ALB picks the rule with the lowest priority whose conditions all match, falling back to the default rule.
+// Forward actions pick a target group by weight, and a target of it at random.
+func (f *ELBV2) RouteHTTPRequest(dnsName string, port int32, req *http.Request) (RouteResult, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lsState, err := f.lookupListenerByDNSName(dnsName, port)
+	if err != nil {
+		return RouteResult{}, err
+	}
+	listenerARN := awssdk.ToString(lsState.listener.ListenerArn)
+	for _, rState := range f.sortedRules() {
+		if rState.listenerARN != listenerARN {
+			continue
+		}
+		matches, err := matchRuleConditions(rState.rule.Conditions, req)
+		if err != nil {
+			return RouteResult{}, err
+		}
+		if !matches {
+			continue
+		}
+		result := RouteResult{
+			ListenerARN: listenerARN,
+			RuleARN:     awssdk.ToString(rState.rule.RuleArn),
+			Host:        stripPort(req.Host),
+			Path:        req.URL.Path,
+			RawQuery:    req.URL.RawQuery,
+		}
+		if err := applyRuleTransforms(rState.rule.Transforms, &result); err != nil {
+			return RouteResult{}, err
+		}
+		if err := f.applyActions(lsState.listener, rState.rule.Actions, req, &result); err != nil {
+			return RouteResult{}, err
+		}
+		return result, nil
+	}
+	return RouteResult{}, errors.Errorf("listener %s has no default rule", listenerARN)
+}
+
+// RouteConnection returns how the listener on port of the load balancer with dnsName forwards a new connection,
+// the way an NLB picks a target of the target group of the default action.
+func (f *ELBV2) RouteConnection(dnsName string, port int32) (RouteResult, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lsState, err := f.lookupListenerByDNSName(dnsName, port)
+	if err != nil {
+		return RouteResult{}, err
+	}
+	result := RouteResult{ListenerARN: awssdk.ToString(lsState.listener.ListenerArn)}
+	if err := f.applyActions(lsState.listener, lsState.listener.DefaultActions, nil, &result); err != nil {
+		return RouteResult{}, err
+	}
+	return result, nil
+}
+
+// ListenerPorts returns the ports and protocols of the listeners of the load balancer with dnsName.
+func (f *ELBV2) ListenerPorts(dnsName string) map[int32]elbv2types.ProtocolEnum {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	ports := make(map[int32]elbv2types.ProtocolEnum)
+	lbARN := f.lookupLoadBalancerARNByDNSName(dnsName)
+	for _, lsState := range f.listeners {
+		if lbARN != "" && awssdk.ToString(lsState.listener.LoadBalancerArn) == lbARN {
+			ports[awssdk.ToInt32(lsState.listener.Port)] = lsState.listener.Protocol
+		}
+	}
+	return ports
+}
+
+func (f *ELBV2) lookupLoadBalancerARNByDNSName(dnsName string) string {
+	for lbARN, lbState := range f.loadBalancers {
+		if strings.EqualFold(awssdk.ToString(lbState.loadBalancer.DNSName), dnsName) {
+			return lbARN
+		}
+	}
+	return ""
+}
+
+func (f *ELBV2) lookupListenerByDNSName(dnsName string, port int32) (*listenerState, error) {
+	lbARN := f.lookupLoadBalancerARNByDNSName(dnsName)
+	if lbARN == "" {
+		return nil, errors.Errorf("no load balancer with DNS name %s", dnsName)
+	}
+	for _, lsState := range f.listeners {
+		if awssdk.ToString(lsState.listener.LoadBalancerArn) == lbARN && awssdk.ToInt32(lsState.listener.Port) == port {
+			return lsState, nil
+		}
+	}
+	return nil, errors.Errorf("load balancer %s has no listener on port %d", dnsName, port)
+}
+
+// applyActions applies the terminal action of actions. Authentication actions are skipped, the fake doesn't simulate identity providers.
+func (f *ELBV2) applyActions(listener elbv2types.Listener, actions []elbv2types.Action, req *http.Request, result *RouteResult) error {
+	orderedActions := slices.Clone(actions)
+	slices.SortStableFunc(orderedActions, func(a, b elbv2types.Action) int {
+		return int(awssdk.ToInt32(a.Order)) - int(awssdk.ToInt32(b.Order))
+	})
+	for _, action := range orderedActions {
+		switch action.Type {
+		case elbv2types.ActionTypeEnumForward:
+			result.ActionType = action.Type
+			return f.applyForwardAction(action, result)
+		case elbv2types.ActionTypeEnumRedirect:
+			result.ActionType = action.Type
+			return applyRedirectAction(listener, action.RedirectConfig, req, result)
+		case elbv2types.ActionTypeEnumFixedResponse:
+			result.ActionType = action.Type
+			return applyFixedResponseAction(action.FixedResponseConfig, result)
+		}
+	}
+	return errors.Errorf("no terminal action in %d actions", len(actions))
+}
+
+func (f *ELBV2) applyForwardAction(action elbv2types.Action, result *RouteResult) error {
+	var tgTuples []elbv2types.TargetGroupTuple
+	if action.ForwardConfig != nil {
+		tgTuples = action.ForwardConfig.TargetGroups
+	}
+	if len(tgTuples) == 0 && action.TargetGroupArn != nil {
+		tgTuples = []elbv2types.TargetGroupTuple{{TargetGroupArn: action.TargetGroupArn}}
+	}
+	tgARN := pickWeightedTargetGroup(tgTuples)
+	if tgARN == "" {
+		return errors.New("forward action has no target group with weight")
+	}
+	result.TargetGroupARN = tgARN
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists || len(tgState.targets) == 0 {
+		return nil
+	}
+	targetKeys := sortedKeys(tgState.targets)
+	target := tgState.targets[targetKeys[rand.IntN(len(targetKeys))]]
+	result.Target = &target
+	return nil
+}
+
+// pickWeightedTargetGroup picks a target group at random in proportion to its weight. A single target group has an implicit weight of 1.
+func pickWeightedTargetGroup(tgTuples []elbv2types.TargetGroupTuple) string {
+	if len(tgTuples) == 1 && tgTuples[0].Weight == nil {
+		return awssdk.ToString(tgTuples[0].TargetGroupArn)
+	}
+	totalWeight := 0
+	for _, tgTuple := range tgTuples {
+		totalWeight += int(awssdk.ToInt32(tgTuple.Weight))
+	}
+	if totalWeight == 0 {
+		return ""
+	}
+	pick := rand.IntN(totalWeight)
+	for _, tgTuple := range tgTuples {
+		pick -= int(awssdk.ToInt32(tgTuple.Weight))
+		if pick < 0 {
+			return awssdk.ToString(tgTuple.TargetGroupArn)
+		}
+	}
+	return ""
+}
+
+// applyRedirectAction builds the redirect location, substituting the #{protocol}, #{host}, #{port}, #{path} and #{query} placeholders
+// with the components of the original request.
+func applyRedirectAction(listener elbv2types.Listener, cfg *elbv2types.RedirectActionConfig, req *http.Request, result *RouteResult) error {
+	if cfg == nil || req == nil {
+		return errors.New("redirect action requires a redirect config and an HTTP request")
+	}
+	replacer := strings.NewReplacer(
+		"#{protocol}", strings.ToLower(string(listener.Protocol)),
+		"#{host}", stripPort(req.Host),
+		"#{port}", strconv.Itoa(int(awssdk.ToInt32(listener.Port))),
+		"#{path}", strings.TrimPrefix(req.URL.Path, "/"),
+		"#{query}", req.URL.RawQuery,
+	)
+	expand := func(value *string, defaultValue string) string {
+		if value == nil {
+			return replacer.Replace(defaultValue)
+		}
+		return replacer.Replace(*value)
+	}
+	protocol := strings.ToLower(expand(cfg.Protocol, "#{protocol}"))
+	host := expand(cfg.Host, "#{host}")
+	port := expand(cfg.Port, "#{port}")
+	path := expand(cfg.Path, "/#{path}")
+	query := expand(cfg.Query, "#{query}")
+
+	location := fmt.Sprintf("%s://%s", protocol, host)
+	if !(protocol == "http" && port == "80") && !(protocol == "https" && port == "443") {
+		location = fmt.Sprintf("%s:%s", location, port)
+	}
+	location += path
+	if query != "" {
+		location = fmt.Sprintf("%s?%s", location, query)
+	}
+	result.Location = location
+	result.StatusCode = http.StatusFound
+	if cfg.StatusCode == elbv2types.RedirectActionStatusCodeEnumHttp301 {
+		result.StatusCode = http.StatusMovedPermanently
+	}
+	return nil
+}
+
+func applyFixedResponseAction(cfg *elbv2types.FixedResponseActionConfig, result *RouteResult) error {
+	if cfg == nil {
+		return errors.New("fixed-response action requires a fixed response config")
+	}
+	statusCode, err := strconv.Atoi(awssdk.ToString(cfg.StatusCode))
+	if err != nil {
+		return errors.Wrapf(err, "invalid fixed response status code %s", awssdk.ToString(cfg.StatusCode))
+	}
+	result.StatusCode = statusCode
+	result.ContentType = awssdk.ToString(cfg.ContentType)
+	result.Body = awssdk.ToString(cfg.MessageBody)
+	return nil
+}
+
+// applyRuleTransforms rewrites the host and path of the forwarded request. Only the first rewrite of each transform applies, as on ALB.
+func applyRuleTransforms(transforms []elbv2types.RuleTransform, result *RouteResult) error {
+	for _, transform := range transforms {
+		switch transform.Type {
+		case elbv2types.TransformTypeEnumUrlRewrite:
+			if transform.UrlRewriteConfig == nil || len(transform.UrlRewriteConfig.Rewrites) == 0 {
+				continue
+			}
+			rewritten, err := applyRewrite(transform.UrlRewriteConfig.Rewrites[0], result.Path)
+			if err != nil {
+				return err
+			}
+			result.Path = rewritten
+		case elbv2types.TransformTypeEnumHostHeaderRewrite:
+			if transform.HostHeaderRewriteConfig == nil || len(transform.HostHeaderRewriteConfig.Rewrites) == 0 {
+				continue
+			}
+			rewritten, err := applyRewrite(transform.HostHeaderRewriteConfig.Rewrites[0], result.Host)
+			if err != nil {
+				return err
+			}
+			result.Host = rewritten
+		}
+	}
+	return nil
+}
+
+func applyRewrite(rewrite elbv2types.RewriteConfig, value string) (string, error) {
+	pattern, err := regexp.Compile(awssdk.ToString(rewrite.Regex))
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid rewrite regex %s", awssdk.ToString(rewrite.Regex))
+	}
+	return pattern.ReplaceAllString(value, awssdk.ToString(rewrite.Replace)), nil
+}
+
+// matchRuleConditions checks whether req matches all conditions.
+func matchRuleConditions(conditions []elbv2types.RuleCondition, req *http.Request) (bool, error) {
+	for _, condition := range conditions {
+		matches, err := matchRuleCondition(condition, req)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchRuleCondition(condition elbv2types.RuleCondition, req *http.Request) (bool, error) {
+	switch awssdk.ToString(condition.Field) {
+	case conditionFieldHostHeader:
+		values, regexValues := condition.Values, condition.RegexValues
+		if cfg := condition.HostHeaderConfig; cfg != nil {
+			values, regexValues = append(slices.Clone(values), cfg.Values...), append(slices.Clone(regexValues), cfg.RegexValues...)
+		}
+		return matchValueOrRegex(values, regexValues, stripPort(req.Host), false)
+	case conditionFieldPathPattern:
+		values, regexValues := condition.Values, condition.RegexValues
+		if cfg := condition.PathPatternConfig; cfg != nil {
+			values, regexValues = append(slices.Clone(values), cfg.Values...), append(slices.Clone(regexValues), cfg.RegexValues...)
+		}
+		return matchValueOrRegex(values, regexValues, req.URL.Path, true)
+	case conditionFieldHTTPHeader:
+		cfg := condition.HttpHeaderConfig
+		if cfg == nil {
+			return false, errors.New("http-header condition requires an http header config")
+		}
+		for _, headerValue := range req.Header.Values(awssdk.ToString(cfg.HttpHeaderName)) {
+			matches, err := matchValueOrRegex(cfg.Values, cfg.RegexValues, headerValue, false)
+			if err != nil || matches {
+				return matches, err
+			}
+		}
+		return false, nil
+	case conditionFieldHTTPRequestMethod:
+		cfg := condition.HttpRequestMethodConfig
+		return cfg != nil && slices.Contains(cfg.Values, req.Method), nil
+	case conditionFieldQueryString:
+		cfg := condition.QueryStringConfig
+		if cfg == nil {
+			return false, errors.New("query-string condition requires a query string config")
+		}
+		query := req.URL.Query()
+		for _, pair := range cfg.Values {
+			for key, values := range query {
+				if pair.Key != nil && !matchGlob(strings.ToLower(awssdk.ToString(pair.Key)), strings.ToLower(key)) {
+					continue
+				}
+				for _, value := range values {
+					if matchGlob(strings.ToLower(awssdk.ToString(pair.Value)), strings.ToLower(value)) {
+						return true, nil
+					}
+				}
+			}
+		}
+		return false, nil
+	case conditionFieldSourceIP:
+		cfg := condition.SourceIpConfig
+		if cfg == nil {
+			return false, errors.New("source-ip condition requires a source ip config")
+		}
+		sourceIP, err := netip.ParseAddr(stripPort(req.RemoteAddr))
+		if err != nil {
+			return false, nil
+		}
+		for _, cidr := range cfg.Values {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return false, errors.Wrapf(err, "invalid source-ip CIDR %s", cidr)
+			}
+			if prefix.Contains(sourceIP.Unmap()) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, errors.Errorf("unsupported condition field %s", awssdk.ToString(condition.Field))
+	}
+}
+
+// matchValueOrRegex checks whether value matches any of the wildcard values or regular expressions.
+func matchValueOrRegex(values []string, regexValues []string, value string, caseSensitive bool) (bool, error) {
+	for _, pattern := range values {
+		if caseSensitive && matchGlob(pattern, value) {
+			return true, nil
+		}
+		if !caseSensitive && matchGlob(strings.ToLower(pattern), strings.ToLower(value)) {
+			return true, nil
+		}
+	}
+	for _, regexValue := range regexValues {
+		pattern, err := regexp.Compile(regexValue)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid regex %s", regexValue)
+		}
+		if pattern.MatchString(value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stripPort returns hostport without its port, if any.
+func stripPort(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport
+	}
+	return host
+}
@@ -0,0 +1,562 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+const (
+	// targetAvailabilityZoneAll is the availability zone of IP targets outside of the VPC of the target group.
+	targetAvailabilityZoneAll = "all"
+)
+
+var targetGroupNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,30}[a-zA-Z0-9])?$`)
+
+func (f *ELBV2) DescribeTargetGroupsAsList(ctx context.Context, input *elbv2sdk.DescribeTargetGroupsInput) ([]elbv2types.TargetGroup, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeTargetGroups"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	lbARN := awssdk.ToString(input.LoadBalancerArn)
+	if lbARN != "" {
+		if _, exists := f.loadBalancers[lbARN]; !exists {
+			return nil, newLoadBalancerNotFoundError(lbARN)
+		}
+	}
+	for _, tgARN := range input.TargetGroupArns {
+		if _, exists := f.targetGroups[tgARN]; !exists {
+			return nil, newTargetGroupNotFoundError(tgARN)
+		}
+	}
+	var tgs []elbv2types.TargetGroup
+	foundNames := make(map[string]bool)
+	for _, tgARN := range sortedKeys(f.targetGroups) {
+		if !f.simulation.visible(tgARN) {
+			if slices.Contains(input.TargetGroupArns, tgARN) {
+				return nil, newTargetGroupNotFoundError(tgARN)
+			}
+			continue
+		}
+		tg := f.buildTargetGroupView(tgARN)
+		if lbARN != "" && !slices.Contains(tg.LoadBalancerArns, lbARN) {
+			continue
+		}
+		if len(input.TargetGroupArns) != 0 && !slices.Contains(input.TargetGroupArns, tgARN) {
+			continue
+		}
+		if len(input.Names) != 0 && !slices.Contains(input.Names, awssdk.ToString(tg.TargetGroupName)) {
+			continue
+		}
+		foundNames[awssdk.ToString(tg.TargetGroupName)] = true
+		tgs = append(tgs, tg)
+	}
+	for _, name := range input.Names {
+		if !foundNames[name] {
+			return nil, newTargetGroupNotFoundError(name)
+		}
+	}
+	return tgs, nil
+}
+
+func (f *ELBV2) DescribeTargetGroupsWithContext(ctx context.Context, input *elbv2sdk.DescribeTargetGroupsInput) (*elbv2sdk.DescribeTargetGroupsOutput, error) {
+	tgs, err := f.DescribeTargetGroupsAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeTargetGroupsOutput{TargetGroups: tgs}, nil
+}
+
+func (f *ELBV2) CreateTargetGroupWithContext(ctx context.Context, input *elbv2sdk.CreateTargetGroupInput) (*elbv2sdk.CreateTargetGroupOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "CreateTargetGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgName := awssdk.ToString(input.Name)
+	if !targetGroupNamePattern.MatchString(tgName) {
+		return nil, newValidationError("The target group name '%s' is not valid", tgName)
+	}
+	for _, tgState := range f.targetGroups {
+		if awssdk.ToString(tgState.targetGroup.TargetGroupName) == tgName {
+			return nil, &elbv2types.DuplicateTargetGroupNameException{Message: awssdk.String(fmt.Sprintf("A target group with the same name '%s' exists, but with different settings", tgName))}
+		}
+	}
+	if len(f.targetGroups) >= f.simulation.Quotas().TargetGroups {
+		return nil, &elbv2types.TooManyTargetGroupsException{Message: awssdk.String("The quota for the number of target groups has been reached")}
+	}
+	targetType := input.TargetType
+	if targetType == "" {
+		targetType = elbv2types.TargetTypeEnumInstance
+	}
+	if targetType != elbv2types.TargetTypeEnumLambda {
+		if input.Port == nil || input.Protocol == "" {
+			return nil, newValidationError("A port and protocol must be specified for target groups of type '%s'", targetType)
+		}
+		if input.VpcId == nil {
+			return nil, newValidationError("A VPC ID must be specified for target groups of type '%s'", targetType)
+		}
+	}
+
+	tgARN := fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:targetgroup/%s/%s", f.region, f.accountID, tgName, f.ids.nextHex("targetgroup"))
+	tg := elbv2types.TargetGroup{
+		TargetGroupArn:             awssdk.String(tgARN),
+		TargetGroupName:            input.Name,
+		TargetType:                 targetType,
+		Protocol:                   input.Protocol,
+		ProtocolVersion:            input.ProtocolVersion,
+		Port:                       input.Port,
+		VpcId:                      input.VpcId,
+		IpAddressType:              elbv2types.TargetGroupIpAddressTypeEnum(input.IpAddressType),
+		HealthCheckEnabled:         input.HealthCheckEnabled,
+		HealthCheckProtocol:        input.HealthCheckProtocol,
+		HealthCheckPort:            input.HealthCheckPort,
+		HealthCheckPath:            input.HealthCheckPath,
+		HealthCheckIntervalSeconds: input.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  input.HealthCheckTimeoutSeconds,
+		HealthyThresholdCount:      input.HealthyThresholdCount,
+		UnhealthyThresholdCount:    input.UnhealthyThresholdCount,
+		Matcher:                    input.Matcher,
+	}
+	applyTargetGroupDefaults(&tg)
+	f.targetGroups[tgARN] = &targetGroupState{
+		targetGroup: tg,
+		attributes:  buildDefaultTargetGroupAttributes(tg.Protocol),
+		targets:     make(map[string]elbv2types.TargetDescription),
+	}
+	f.tags[tgARN] = buildTagMap(input.Tags)
+	f.simulation.created(tgARN)
+	return &elbv2sdk.CreateTargetGroupOutput{TargetGroups: []elbv2types.TargetGroup{tg}}, nil
+}
+
+func (f *ELBV2) ModifyTargetGroupWithContext(ctx context.Context, input *elbv2sdk.ModifyTargetGroupInput) (*elbv2sdk.ModifyTargetGroupOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyTargetGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgARN := awssdk.ToString(input.TargetGroupArn)
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(tgARN)
+	}
+	tg := &tgState.targetGroup
+	if input.HealthCheckEnabled != nil {
+		tg.HealthCheckEnabled = input.HealthCheckEnabled
+	}
+	if input.HealthCheckProtocol != "" {
+		tg.HealthCheckProtocol = input.HealthCheckProtocol
+	}
+	if input.HealthCheckPort != nil {
+		tg.HealthCheckPort = input.HealthCheckPort
+	}
+	if input.HealthCheckPath != nil {
+		tg.HealthCheckPath = input.HealthCheckPath
+	}
+	if input.HealthCheckIntervalSeconds != nil {
+		tg.HealthCheckIntervalSeconds = input.HealthCheckIntervalSeconds
+	}
+	if input.HealthCheckTimeoutSeconds != nil {
+		tg.HealthCheckTimeoutSeconds = input.HealthCheckTimeoutSeconds
+	}
+	if input.HealthyThresholdCount != nil {
+		tg.HealthyThresholdCount = input.HealthyThresholdCount
+	}
+	if input.UnhealthyThresholdCount != nil {
+		tg.UnhealthyThresholdCount = input.UnhealthyThresholdCount
+	}
+	if input.Matcher != nil {
+		tg.Matcher = input.Matcher
+	}
+	return &elbv2sdk.ModifyTargetGroupOutput{TargetGroups: []elbv2types.TargetGroup{f.buildTargetGroupView(tgARN)}}, nil
+}
+
+func (f *ELBV2) DeleteTargetGroupWithContext(ctx context.Context, input *elbv2sdk.DeleteTargetGroupInput) (*elbv2sdk.DeleteTargetGroupOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DeleteTargetGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgARN := awssdk.ToString(input.TargetGroupArn)
+	if _, exists := f.targetGroups[tgARN]; !exists {
+		return &elbv2sdk.DeleteTargetGroupOutput{}, nil
+	}
+	if len(f.targetGroupLoadBalancerARNs(tgARN)) != 0 {
+		return nil, &elbv2types.ResourceInUseException{Message: awssdk.String(fmt.Sprintf("Target group '%s' is currently in use by a listener or a rule", tgARN))}
+	}
+	delete(f.targetGroups, tgARN)
+	delete(f.tags, tgARN)
+	return &elbv2sdk.DeleteTargetGroupOutput{}, nil
+}
+
+func (f *ELBV2) ModifyTargetGroupAttributesWithContext(ctx context.Context, input *elbv2sdk.ModifyTargetGroupAttributesInput) (*elbv2sdk.ModifyTargetGroupAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "ModifyTargetGroupAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgState, exists := f.targetGroups[awssdk.ToString(input.TargetGroupArn)]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(awssdk.ToString(input.TargetGroupArn))
+	}
+	for _, attribute := range input.Attributes {
+		tgState.attributes[awssdk.ToString(attribute.Key)] = awssdk.ToString(attribute.Value)
+	}
+	return &elbv2sdk.ModifyTargetGroupAttributesOutput{Attributes: buildTargetGroupAttributes(tgState.attributes)}, nil
+}
+
+func (f *ELBV2) DescribeTargetGroupAttributesWithContext(ctx context.Context, input *elbv2sdk.DescribeTargetGroupAttributesInput) (*elbv2sdk.DescribeTargetGroupAttributesOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeTargetGroupAttributes"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	tgState, exists := f.targetGroups[awssdk.ToString(input.TargetGroupArn)]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(awssdk.ToString(input.TargetGroupArn))
+	}
+	return &elbv2sdk.DescribeTargetGroupAttributesOutput{Attributes: buildTargetGroupAttributes(tgState.attributes)}, nil
+}
+
+func (f *ELBV2) RegisterTargetsWithContext(ctx context.Context, input *elbv2sdk.RegisterTargetsInput) (*elbv2sdk.RegisterTargetsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "RegisterTargets"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgARN := awssdk.ToString(input.TargetGroupArn)
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(tgARN)
+	}
+	targets := make([]elbv2types.TargetDescription, 0, len(input.Targets))
+	for _, target := range input.Targets {
+		if target.Port == nil {
+			target.Port = tgState.targetGroup.Port
+		}
+		if err := f.validateTarget(tgState.targetGroup, target); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	newTargetCount := len(tgState.targets)
+	for _, target := range targets {
+		if _, exists := tgState.targets[buildTargetKey(target)]; !exists {
+			newTargetCount++
+		}
+	}
+	if newTargetCount > f.simulation.Quotas().TargetsPerTargetGroup {
+		return nil, &elbv2types.TooManyTargetsException{Message: awssdk.String(fmt.Sprintf("The target group '%s' would exceed the quota for the number of targets", tgARN))}
+	}
+	for _, target := range targets {
+		tgState.targets[buildTargetKey(target)] = target
+	}
+	return &elbv2sdk.RegisterTargetsOutput{}, nil
+}
+
+func (f *ELBV2) DeregisterTargetsWithContext(ctx context.Context, input *elbv2sdk.DeregisterTargetsInput) (*elbv2sdk.DeregisterTargetsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DeregisterTargets"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	tgARN := awssdk.ToString(input.TargetGroupArn)
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(tgARN)
+	}
+	for _, target := range input.Targets {
+		if target.Port == nil {
+			target.Port = tgState.targetGroup.Port
+		}
+		delete(tgState.targets, buildTargetKey(target))
+	}
+	return &elbv2sdk.DeregisterTargetsOutput{}, nil
+}
+
+// DescribeTargetHealthWithContext reports registered targets as healthy once the target group is used by a load balancer,
+// the fake doesn't simulate health checks.
+func (f *ELBV2) DescribeTargetHealthWithContext(ctx context.Context, input *elbv2sdk.DescribeTargetHealthInput) (*elbv2sdk.DescribeTargetHealthOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeTargetHealth"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	tgARN := awssdk.ToString(input.TargetGroupArn)
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists {
+		return nil, newTargetGroupNotFoundError(tgARN)
+	}
+	registeredTargetHealth := &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy}
+	if len(f.targetGroupLoadBalancerARNs(tgARN)) == 0 {
+		registeredTargetHealth = &elbv2types.TargetHealth{
+			State:       elbv2types.TargetHealthStateEnumUnused,
+			Reason:      elbv2types.TargetHealthReasonEnumNotInUse,
+			Description: awssdk.String("Target group is not configured to receive traffic from the load balancer"),
+		}
+	}
+	healthCheckPort := awssdk.ToString(tgState.targetGroup.HealthCheckPort)
+
+	var descriptions []elbv2types.TargetHealthDescription
+	if len(input.Targets) == 0 {
+		for _, key := range sortedKeys(tgState.targets) {
+			target := tgState.targets[key]
+			descriptions = append(descriptions, buildTargetHealthDescription(target, healthCheckPort, registeredTargetHealth))
+		}
+		return &elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: descriptions}, nil
+	}
+	for _, target := range input.Targets {
+		if target.Port == nil {
+			target.Port = tgState.targetGroup.Port
+		}
+		if registeredTarget, registered := tgState.targets[buildTargetKey(target)]; registered {
+			descriptions = append(descriptions, buildTargetHealthDescription(registeredTarget, healthCheckPort, registeredTargetHealth))
+			continue
+		}
+		descriptions = append(descriptions, buildTargetHealthDescription(target, healthCheckPort, &elbv2types.TargetHealth{
+			State:       elbv2types.TargetHealthStateEnumUnused,
+			Reason:      elbv2types.TargetHealthReasonEnumNotRegistered,
+			Description: awssdk.String("Target is not registered to the target group"),
+		}))
+	}
+	return &elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: descriptions}, nil
+}
+
+// RegisteredTargets returns the targets registered to a target group.
+func (f *ELBV2) RegisteredTargets(tgARN string) []elbv2types.TargetDescription {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	tgState, exists := f.targetGroups[tgARN]
+	if !exists {
+		return nil
+	}
+	var targets []elbv2types.TargetDescription
+	for _, key := range sortedKeys(tgState.targets) {
+		targets = append(targets, tgState.targets[key])
+	}
+	return targets
+}
+
+func (f *ELBV2) validateTarget(tg elbv2types.TargetGroup, target elbv2types.TargetDescription) error {
+	targetID := awssdk.ToString(target.Id)
+	switch tg.TargetType {
+	case elbv2types.TargetTypeEnumInstance:
+		instance, exists := f.ec2.lookupInstance(targetID)
+		if !exists || awssdk.ToString(instance.VpcId) != awssdk.ToString(tg.VpcId) {
+			return newInvalidTargetError("The following targets are not in the target group VPC '%s': '%s'", awssdk.ToString(tg.VpcId), targetID)
+		}
+	case elbv2types.TargetTypeEnumIp:
+		ip, err := netip.ParseAddr(targetID)
+		if err != nil {
+			return newInvalidTargetError("The IP address '%s' is not valid", targetID)
+		}
+		inVPC := f.ec2.isAddressInVPC(awssdk.ToString(tg.VpcId), ip)
+		if !inVPC && awssdk.ToString(target.AvailabilityZone) != targetAvailabilityZoneAll {
+			return newInvalidTargetError("The IP address '%s' is not within the target group VPC, the availability zone must be '%s'", targetID, targetAvailabilityZoneAll)
+		}
+	}
+	return nil
+}
+
+// buildTargetGroupView returns a target group together with the load balancers using it.
+func (f *ELBV2) buildTargetGroupView(tgARN string) elbv2types.TargetGroup {
+	tg := f.targetGroups[tgARN].targetGroup
+	tg.LoadBalancerArns = f.targetGroupLoadBalancerARNs(tgARN)
+	return tg
+}
+
+func applyTargetGroupDefaults(tg *elbv2types.TargetGroup) {
+	if tg.TargetType == elbv2types.TargetTypeEnumLambda {
+		return
+	}
+	isHTTP := tg.Protocol == elbv2types.ProtocolEnumHttp || tg.Protocol == elbv2types.ProtocolEnumHttps
+	if tg.HealthCheckEnabled == nil {
+		tg.HealthCheckEnabled = awssdk.Bool(true)
+	}
+	if tg.HealthCheckProtocol == "" {
+		tg.HealthCheckProtocol = elbv2types.ProtocolEnumTcp
+		if isHTTP {
+			tg.HealthCheckProtocol = tg.Protocol
+		}
+	}
+	if tg.HealthCheckPort == nil {
+		tg.HealthCheckPort = awssdk.String("traffic-port")
+	}
+	if tg.HealthCheckPath == nil && (tg.HealthCheckProtocol == elbv2types.ProtocolEnumHttp || tg.HealthCheckProtocol == elbv2types.ProtocolEnumHttps) {
+		tg.HealthCheckPath = awssdk.String("/")
+	}
+	if tg.HealthCheckIntervalSeconds == nil {
+		tg.HealthCheckIntervalSeconds = awssdk.Int32(30)
+	}
+	if tg.HealthCheckTimeoutSeconds == nil {
+		tg.HealthCheckTimeoutSeconds = awssdk.Int32(10)
+		if isHTTP {
+			tg.HealthCheckTimeoutSeconds = awssdk.Int32(5)
+		}
+	}
+	if tg.HealthyThresholdCount == nil {
+		tg.HealthyThresholdCount = awssdk.Int32(5)
+	}
+	if tg.UnhealthyThresholdCount == nil {
+		tg.UnhealthyThresholdCount = awssdk.Int32(2)
+	}
+	if tg.Matcher == nil && isHTTP && tg.ProtocolVersion == nil {
+		tg.Matcher = &elbv2types.Matcher{HttpCode: awssdk.String("200")}
+	}
+	if isHTTP && tg.ProtocolVersion == nil {
+		tg.ProtocolVersion = awssdk.String("HTTP1")
+	}
+	if tg.IpAddressType == "" {
+		tg.IpAddressType = elbv2types.TargetGroupIpAddressTypeEnumIpv4
+	}
+}
+
+func buildDefaultTargetGroupAttributes(protocol elbv2types.ProtocolEnum) map[string]string {
+	attributes := map[string]string{
+		"deregistration_delay.timeout_seconds": "300",
+		"stickiness.enabled":                   "false",
+	}
+	switch protocol {
+	case elbv2types.ProtocolEnumHttp, elbv2types.ProtocolEnumHttps:
+		attributes["load_balancing.algorithm.type"] = "round_robin"
+		attributes["slow_start.duration_seconds"] = "0"
+		attributes["stickiness.type"] = "lb_cookie"
+	default:
+		attributes["proxy_protocol_v2.enabled"] = "false"
+		attributes["preserve_client_ip.enabled"] = "true"
+		attributes["stickiness.type"] = "source_ip"
+	}
+	return attributes
+}
+
+func buildTargetGroupAttributes(attributes map[string]string) []elbv2types.TargetGroupAttribute {
+	var sdkAttributes []elbv2types.TargetGroupAttribute
+	for _, key := range sortedKeys(attributes) {
+		sdkAttributes = append(sdkAttributes, elbv2types.TargetGroupAttribute{Key: awssdk.String(key), Value: awssdk.String(attributes[key])})
+	}
+	return sdkAttributes
+}
+
+func buildTargetHealthDescription(target elbv2types.TargetDescription, healthCheckPort string, health *elbv2types.TargetHealth) elbv2types.TargetHealthDescription {
+	if healthCheckPort == "" || healthCheckPort == "traffic-port" {
+		healthCheckPort = fmt.Sprintf("%d", awssdk.ToInt32(target.Port))
+	}
+	return elbv2types.TargetHealthDescription{
+		Target:          &target,
+		HealthCheckPort: awssdk.String(healthCheckPort),
+		TargetHealth:    health,
+	}
+}
+
+func buildTargetKey(target elbv2types.TargetDescription) string {
+	return fmt.Sprintf("%s:%d", awssdk.ToString(target.Id), awssdk.ToInt32(target.Port))
+}
+
+func newTargetGroupNotFoundError(tgRef string) error {
+	return &elbv2types.TargetGroupNotFoundException{Message: awssdk.String(fmt.Sprintf("One or more target groups not found: %s", tgRef))}
+}
+
+func newInvalidTargetError(format string, args ...any) error {
+	return &elbv2types.InvalidTargetException{Message: awssdk.String(fmt.Sprintf(format, args...))}
+}
+
+func (f *ELBV2) AddTagsWithContext(ctx context.Context, input *elbv2sdk.AddTagsInput) (*elbv2sdk.AddTagsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "AddTags"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, resourceARN := range input.ResourceArns {
+		if err := f.validateTaggableResource(resourceARN); err != nil {
+			return nil, err
+		}
+	}
+	for _, resourceARN := range input.ResourceArns {
+		for _, tag := range input.Tags {
+			f.tags[resourceARN][awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+		}
+	}
+	return &elbv2sdk.AddTagsOutput{}, nil
+}
+
+func (f *ELBV2) RemoveTagsWithContext(ctx context.Context, input *elbv2sdk.RemoveTagsInput) (*elbv2sdk.RemoveTagsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "RemoveTags"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, resourceARN := range input.ResourceArns {
+		if err := f.validateTaggableResource(resourceARN); err != nil {
+			return nil, err
+		}
+	}
+	for _, resourceARN := range input.ResourceArns {
+		for _, tagKey := range input.TagKeys {
+			delete(f.tags[resourceARN], tagKey)
+		}
+	}
+	return &elbv2sdk.RemoveTagsOutput{}, nil
+}
+
+func (f *ELBV2) DescribeTagsWithContext(ctx context.Context, input *elbv2sdk.DescribeTagsInput) (*elbv2sdk.DescribeTagsOutput, error) {
+	if err := f.simulation.call(ServiceELBV2, "DescribeTags"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var tagDescriptions []elbv2types.TagDescription
+	for _, resourceARN := range input.ResourceArns {
+		if err := f.validateTaggableResource(resourceARN); err != nil {
+			return nil, err
+		}
+		tagDescriptions = append(tagDescriptions, elbv2types.TagDescription{
+			ResourceArn: awssdk.String(resourceARN),
+			Tags:        buildELBV2Tags(f.tags[resourceARN]),
+		})
+	}
+	return &elbv2sdk.DescribeTagsOutput{TagDescriptions: tagDescriptions}, nil
+}
+
+// validateTaggableResource checks a resource exists, returning the not found error of its resource type otherwise.
+func (f *ELBV2) validateTaggableResource(resourceARN string) error {
+	if _, exists := f.tags[resourceARN]; exists {
+		return nil
+	}
+	resource := arnResource(resourceARN)
+	switch {
+	case strings.HasPrefix(resource, "loadbalancer/"):
+		return newLoadBalancerNotFoundError(resourceARN)
+	case strings.HasPrefix(resource, "listener/"):
+		return newListenerNotFoundError(resourceARN)
+	case strings.HasPrefix(resource, "listener-rule/"):
+		return newRuleNotFoundError(resourceARN)
+	case strings.HasPrefix(resource, "targetgroup/"):
+		return newTargetGroupNotFoundError(resourceARN)
+	default:
+		return newValidationError("The ARN '%s' is not valid", resourceARN)
+	}
+}
+
+func buildTagMap(tags []elbv2types.Tag) map[string]string {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+	}
+	return tagMap
+}
+
+func buildELBV2Tags(tagMap map[string]string) []elbv2types.Tag {
+	tags := make([]elbv2types.Tag, 0, len(tagMap))
+	for _, key := range sortedKeys(tagMap) {
+		tags = append(tags, elbv2types.Tag{Key: awssdk.String(key), Value: awssdk.String(tagMap[key])})
+	}
+	return tags
+}
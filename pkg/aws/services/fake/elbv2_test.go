@@ -0,0 +1,289 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNetwork struct {
+	cloud   *Cloud
+	vpcID   string
+	subnets []string
+	sgID    string
+}
+
+func newTestNetwork(t *testing.T) testNetwork {
+	cloud := NewCloud("us-west-2", "123456789012", "")
+	vpc := cloud.FakeEC2().AddVpc(ec2types.Vpc{CidrBlock: awssdk.String("192.168.0.0/16")})
+	var subnets []string
+	for i, az := range []string{"us-west-2a", "us-west-2b"} {
+		subnet := cloud.FakeEC2().AddSubnet(ec2types.Subnet{
+			VpcId:            vpc.VpcId,
+			AvailabilityZone: awssdk.String(az),
+			CidrBlock:        awssdk.String([]string{"192.168.0.0/24", "192.168.1.0/24"}[i]),
+		})
+		subnets = append(subnets, awssdk.ToString(subnet.SubnetId))
+	}
+	sg := cloud.FakeEC2().AddSecurityGroup(ec2types.SecurityGroup{VpcId: vpc.VpcId, GroupName: awssdk.String("lb")})
+	return testNetwork{cloud: cloud, vpcID: awssdk.ToString(vpc.VpcId), subnets: subnets, sgID: awssdk.ToString(sg.GroupId)}
+}
+
+func (n testNetwork) createALB(t *testing.T, name string) elbv2types.LoadBalancer {
+	output, err := n.cloud.ELBV2().CreateLoadBalancerWithContext(context.Background(), &elbv2sdk.CreateLoadBalancerInput{
+		Name:           awssdk.String(name),
+		Type:           elbv2types.LoadBalancerTypeEnumApplication,
+		Subnets:        n.subnets,
+		SecurityGroups: []string{n.sgID},
+		Tags:           []elbv2types.Tag{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")}},
+	})
+	assert.NoError(t, err)
+	return output.LoadBalancers[0]
+}
+
+func (n testNetwork) createTargetGroup(t *testing.T, name string, protocol elbv2types.ProtocolEnum) string {
+	output, err := n.cloud.ELBV2().CreateTargetGroupWithContext(context.Background(), &elbv2sdk.CreateTargetGroupInput{
+		Name:       awssdk.String(name),
+		Protocol:   protocol,
+		Port:       awssdk.Int32(8080),
+		TargetType: elbv2types.TargetTypeEnumIp,
+		VpcId:      awssdk.String(n.vpcID),
+	})
+	assert.NoError(t, err)
+	return awssdk.ToString(output.TargetGroups[0].TargetGroupArn)
+}
+
+func forwardTo(tgARN string) []elbv2types.Action {
+	return []elbv2types.Action{
+		{
+			Type: elbv2types.ActionTypeEnumForward,
+			ForwardConfig: &elbv2types.ForwardActionConfig{
+				TargetGroups: []elbv2types.TargetGroupTuple{{TargetGroupArn: awssdk.String(tgARN)}},
+			},
+		},
+	}
+}
+
+func TestELBV2_CreateLoadBalancerWithContext(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	elbv2Client := network.cloud.ELBV2()
+
+	lb := network.createALB(t, "k8s-default-gw")
+	assert.Equal(t, "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-default-gw/0000000000000001", awssdk.ToString(lb.LoadBalancerArn))
+	assert.Equal(t, "k8s-default-gw-0000000001.us-west-2.elb.amazonaws.com", awssdk.ToString(lb.DNSName))
+	assert.Equal(t, network.vpcID, awssdk.ToString(lb.VpcId))
+	assert.Len(t, lb.AvailabilityZones, 2)
+
+	_, err := elbv2Client.CreateLoadBalancerWithContext(ctx, &elbv2sdk.CreateLoadBalancerInput{
+		Name:    awssdk.String("k8s-default-gw"),
+		Subnets: network.subnets,
+	})
+	var duplicateErr *elbv2types.DuplicateLoadBalancerNameException
+	assert.True(t, errors.As(err, &duplicateErr))
+
+	_, err = elbv2Client.CreateLoadBalancerWithContext(ctx, &elbv2sdk.CreateLoadBalancerInput{
+		Name:    awssdk.String("single-az"),
+		Subnets: network.subnets[:1],
+	})
+	var invalidConfigErr *elbv2types.InvalidConfigurationRequestException
+	assert.True(t, errors.As(err, &invalidConfigErr))
+
+	tagsOutput, err := elbv2Client.DescribeTagsWithContext(ctx, &elbv2sdk.DescribeTagsInput{ResourceArns: []string{awssdk.ToString(lb.LoadBalancerArn)}})
+	assert.NoError(t, err)
+	assert.Equal(t, []elbv2types.Tag{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")}}, tagsOutput.TagDescriptions[0].Tags)
+
+	_, err = network.cloud.EC2().DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{GroupId: awssdk.String(network.sgID)})
+	assertAPIErrorCode(t, "DependencyViolation", err)
+
+	_, err = elbv2Client.DeleteLoadBalancerWithContext(ctx, &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: lb.LoadBalancerArn})
+	assert.NoError(t, err)
+	_, err = elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{Names: []string{"k8s-default-gw"}})
+	var notFoundErr *elbv2types.LoadBalancerNotFoundException
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestELBV2_listenerAndRules(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	elbv2Client := network.cloud.ELBV2()
+	lb := network.createALB(t, "k8s-default-gw")
+	tgARN := network.createTargetGroup(t, "k8s-default-svc", elbv2types.ProtocolEnumHttp)
+
+	listenerOutput, err := elbv2Client.CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            awssdk.Int32(80),
+		Protocol:        elbv2types.ProtocolEnumHttp,
+		DefaultActions:  forwardTo(tgARN),
+	})
+	assert.NoError(t, err)
+	listenerARN := listenerOutput.Listeners[0].ListenerArn
+
+	_, err = elbv2Client.CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            awssdk.Int32(80),
+		Protocol:        elbv2types.ProtocolEnumHttp,
+		DefaultActions:  forwardTo(tgARN),
+	})
+	var duplicateListenerErr *elbv2types.DuplicateListenerException
+	assert.True(t, errors.As(err, &duplicateListenerErr))
+
+	_, err = elbv2Client.CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            awssdk.Int32(443),
+		Protocol:        elbv2types.ProtocolEnumTcp,
+		DefaultActions:  forwardTo(tgARN),
+	})
+	var unsupportedProtocolErr *elbv2types.UnsupportedProtocolException
+	assert.True(t, errors.As(err, &unsupportedProtocolErr))
+
+	tgs, err := elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{LoadBalancerArn: lb.LoadBalancerArn})
+	assert.NoError(t, err)
+	assert.Len(t, tgs, 1)
+	assert.Equal(t, []string{awssdk.ToString(lb.LoadBalancerArn)}, tgs[0].LoadBalancerArns)
+
+	hostCondition := []elbv2types.RuleCondition{
+		{Field: awssdk.String("host-header"), HostHeaderConfig: &elbv2types.HostHeaderConditionConfig{Values: []string{"example.com"}}},
+	}
+	ruleOutput, err := elbv2Client.CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+		ListenerArn: listenerARN,
+		Priority:    awssdk.Int32(1),
+		Conditions:  hostCondition,
+		Actions:     forwardTo(tgARN),
+	})
+	assert.NoError(t, err)
+	_, err = elbv2Client.CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+		ListenerArn: listenerARN,
+		Priority:    awssdk.Int32(1),
+		Conditions:  hostCondition,
+		Actions:     forwardTo(tgARN),
+	})
+	var priorityInUseErr *elbv2types.PriorityInUseException
+	assert.True(t, errors.As(err, &priorityInUseErr))
+
+	secondRuleOutput, err := elbv2Client.CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+		ListenerArn: listenerARN,
+		Priority:    awssdk.Int32(2),
+		Conditions:  hostCondition,
+		Actions:     forwardTo(tgARN),
+	})
+	assert.NoError(t, err)
+	_, err = elbv2Client.SetRulePrioritiesWithContext(ctx, &elbv2sdk.SetRulePrioritiesInput{
+		RulePriorities: []elbv2types.RulePriorityPair{
+			{RuleArn: ruleOutput.Rules[0].RuleArn, Priority: awssdk.Int32(2)},
+			{RuleArn: secondRuleOutput.Rules[0].RuleArn, Priority: awssdk.Int32(1)},
+		},
+	})
+	assert.NoError(t, err)
+
+	rules, err := elbv2Client.DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{ListenerArn: listenerARN})
+	assert.NoError(t, err)
+	var priorities []string
+	for _, rule := range rules {
+		priorities = append(priorities, awssdk.ToString(rule.Priority))
+	}
+	assert.Equal(t, []string{"1", "2", "default"}, priorities)
+	assert.Equal(t, secondRuleOutput.Rules[0].RuleArn, rules[0].RuleArn)
+
+	_, err = elbv2Client.DeleteRuleWithContext(ctx, &elbv2sdk.DeleteRuleInput{RuleArn: rules[2].RuleArn})
+	var operationNotPermittedErr *elbv2types.OperationNotPermittedException
+	assert.True(t, errors.As(err, &operationNotPermittedErr))
+
+	_, err = elbv2Client.DeleteTargetGroupWithContext(ctx, &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String(tgARN)})
+	var resourceInUseErr *elbv2types.ResourceInUseException
+	assert.True(t, errors.As(err, &resourceInUseErr))
+
+	_, err = elbv2Client.DeleteListenerWithContext(ctx, &elbv2sdk.DeleteListenerInput{ListenerArn: listenerARN})
+	assert.NoError(t, err)
+	_, err = elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{ListenerArns: []string{awssdk.ToString(listenerARN)}})
+	var listenerNotFoundErr *elbv2types.ListenerNotFoundException
+	assert.True(t, errors.As(err, &listenerNotFoundErr))
+	_, err = elbv2Client.DeleteTargetGroupWithContext(ctx, &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String(tgARN)})
+	assert.NoError(t, err)
+}
+
+func TestELBV2_targetGroupAssociationLimit(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	elbv2Client := network.cloud.ELBV2()
+	tgARN := network.createTargetGroup(t, "k8s-default-svc", elbv2types.ProtocolEnumHttp)
+	for i, name := range []string{"lb-one", "lb-two"} {
+		lb := network.createALB(t, name)
+		_, err := elbv2Client.CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+			LoadBalancerArn: lb.LoadBalancerArn,
+			Port:            awssdk.Int32(80),
+			Protocol:        elbv2types.ProtocolEnumHttp,
+			DefaultActions:  forwardTo(tgARN),
+		})
+		if i == 0 {
+			assert.NoError(t, err)
+			continue
+		}
+		var associationLimitErr *elbv2types.TargetGroupAssociationLimitException
+		assert.True(t, errors.As(err, &associationLimitErr))
+	}
+}
+
+func TestELBV2_targets(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	elbv2Client := network.cloud.ELBV2()
+	tgARN := network.createTargetGroup(t, "k8s-default-svc", elbv2types.ProtocolEnumHttp)
+
+	_, err := elbv2Client.RegisterTargetsWithContext(ctx, &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: awssdk.String(tgARN),
+		Targets:        []elbv2types.TargetDescription{{Id: awssdk.String("10.0.0.1")}},
+	})
+	var invalidTargetErr *elbv2types.InvalidTargetException
+	assert.True(t, errors.As(err, &invalidTargetErr))
+
+	_, err = elbv2Client.RegisterTargetsWithContext(ctx, &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: awssdk.String(tgARN),
+		Targets: []elbv2types.TargetDescription{
+			{Id: awssdk.String("192.168.0.10")},
+			{Id: awssdk.String("10.0.0.1"), Port: awssdk.Int32(80), AvailabilityZone: awssdk.String("all")},
+		},
+	})
+	assert.NoError(t, err)
+
+	healthOutput, err := elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String(tgARN)})
+	assert.NoError(t, err)
+	assert.Len(t, healthOutput.TargetHealthDescriptions, 2)
+	for _, description := range healthOutput.TargetHealthDescriptions {
+		assert.Equal(t, elbv2types.TargetHealthStateEnumUnused, description.TargetHealth.State)
+		assert.Equal(t, elbv2types.TargetHealthReasonEnumNotInUse, description.TargetHealth.Reason)
+	}
+
+	lb := network.createALB(t, "k8s-default-gw")
+	_, err = elbv2Client.CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            awssdk.Int32(80),
+		Protocol:        elbv2types.ProtocolEnumHttp,
+		DefaultActions:  forwardTo(tgARN),
+	})
+	assert.NoError(t, err)
+	healthOutput, err = elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+		Targets:        []elbv2types.TargetDescription{{Id: awssdk.String("192.168.0.10")}, {Id: awssdk.String("192.168.0.11")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, elbv2types.TargetHealthStateEnumHealthy, healthOutput.TargetHealthDescriptions[0].TargetHealth.State)
+	assert.Equal(t, "8080", awssdk.ToString(healthOutput.TargetHealthDescriptions[0].HealthCheckPort))
+	assert.Equal(t, elbv2types.TargetHealthReasonEnumNotRegistered, healthOutput.TargetHealthDescriptions[1].TargetHealth.Reason)
+
+	_, err = elbv2Client.DeregisterTargetsWithContext(ctx, &elbv2sdk.DeregisterTargetsInput{
+		TargetGroupArn: awssdk.String(tgARN),
+		Targets:        []elbv2types.TargetDescription{{Id: awssdk.String("192.168.0.10")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []elbv2types.TargetDescription{
+		{Id: awssdk.String("10.0.0.1"), Port: awssdk.Int32(80), AvailabilityZone: awssdk.String("all")},
+	}, network.cloud.FakeELBV2().RegisteredTargets(tgARN))
+}
@@ -0,0 +1,518 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.GlobalAccelerator = &GlobalAccelerator{}
+
+// GlobalAccelerator is a stateful in-memory implementation of services.GlobalAccelerator.
+// Accelerators report IN_PROGRESS while their changes are hidden by the consistency delay of the Simulation, and DEPLOYED afterward.
+type GlobalAccelerator struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+	accountID  string
+
+	accelerators   map[string]*acceleratorState
+	listeners      map[string]*gaListenerState
+	endpointGroups map[string]*endpointGroupState
+	tags           map[string]map[string]string
+	byoipCidrs     []gatypes.ByoipCidr
+
+	// endpointExists reports whether an endpoint exists, such as a load balancer. Endpoints that don't exist are unhealthy.
+	endpointExists func(endpointID string) bool
+}
+
+type acceleratorState struct {
+	accelerator gatypes.Accelerator
+}
+
+type gaListenerState struct {
+	acceleratorARN string
+	listener       gatypes.Listener
+}
+
+type endpointGroupState struct {
+	listenerARN   string
+	endpointGroup gatypes.EndpointGroup
+}
+
+// NewGlobalAccelerator constructs a new fake GlobalAccelerator without accelerators.
+func NewGlobalAccelerator(accountID string) *GlobalAccelerator {
+	return &GlobalAccelerator{
+		ids:            newIDGenerator(),
+		simulation:     NewSimulation(),
+		accountID:      accountID,
+		accelerators:   make(map[string]*acceleratorState),
+		listeners:      make(map[string]*gaListenerState),
+		endpointGroups: make(map[string]*endpointGroupState),
+		tags:           make(map[string]map[string]string),
+	}
+}
+
+// AddByoipCidr adds an address range brought to Global Accelerator, accelerators can only be created with addresses of provisioned ranges.
+func (f *GlobalAccelerator) AddByoipCidr(byoipCidr gatypes.ByoipCidr) gatypes.ByoipCidr {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.byoipCidrs = append(f.byoipCidrs, byoipCidr)
+	return byoipCidr
+}
+
+func (f *GlobalAccelerator) ListByoipCidrsAsList(ctx context.Context, input *gasdk.ListByoipCidrsInput) ([]gatypes.ByoipCidr, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "ListByoipCidrs"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return slices.Clone(f.byoipCidrs), nil
+}
+
+func (f *GlobalAccelerator) ListAcceleratorsAsList(ctx context.Context, input *gasdk.ListAcceleratorsInput) ([]gatypes.Accelerator, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "ListAccelerators"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var accelerators []gatypes.Accelerator
+	for _, acceleratorARN := range sortedKeys(f.accelerators) {
+		accelerators = append(accelerators, f.buildAcceleratorView(acceleratorARN))
+	}
+	return accelerators, nil
+}
+
+func (f *GlobalAccelerator) CreateAcceleratorWithContext(ctx context.Context, input *gasdk.CreateAcceleratorInput) (*gasdk.CreateAcceleratorOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "CreateAccelerator"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if awssdk.ToString(input.Name) == "" {
+		return nil, &gatypes.InvalidArgumentException{Message: awssdk.String("Name must be specified")}
+	}
+	if len(f.accelerators) >= f.simulation.Quotas().Accelerators {
+		return nil, &gatypes.LimitExceededException{Message: awssdk.String("The quota for the number of accelerators has been reached")}
+	}
+	ipAddressType := input.IpAddressType
+	if ipAddressType == "" {
+		ipAddressType = gatypes.IpAddressTypeIpv4
+	}
+	count := f.ids.nextCount("accelerator")
+	acceleratorID := fmt.Sprintf("%08x-0000-4000-8000-%012x", count, count)
+	acceleratorARN := fmt.Sprintf("arn:aws:globalaccelerator::%s:accelerator/%s", f.accountID, acceleratorID)
+	if err := f.validateByoipAddresses(input.IpAddresses); err != nil {
+		return nil, err
+	}
+	ipSets, err := f.buildIPSets(ipAddressType, input.IpAddresses)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	dnsPrefix := "a" + f.ids.nextHex("dnsname")
+	accelerator := gatypes.Accelerator{
+		AcceleratorArn:   awssdk.String(acceleratorARN),
+		Name:             input.Name,
+		Enabled:          awssdk.Bool(input.Enabled == nil || awssdk.ToBool(input.Enabled)),
+		IpAddressType:    ipAddressType,
+		IpSets:           ipSets,
+		DnsName:          awssdk.String(dnsPrefix + ".awsglobalaccelerator.com"),
+		CreatedTime:      awssdk.Time(now),
+		LastModifiedTime: awssdk.Time(now),
+	}
+	if ipAddressType == gatypes.IpAddressTypeDualStack {
+		accelerator.DualStackDnsName = awssdk.String(dnsPrefix + ".dualstack.awsglobalaccelerator.com")
+	}
+	f.accelerators[acceleratorARN] = &acceleratorState{accelerator: accelerator}
+	f.tags[acceleratorARN] = buildGATagMap(input.Tags)
+	f.simulation.created(acceleratorARN)
+	accelerator = f.buildAcceleratorView(acceleratorARN)
+	return &gasdk.CreateAcceleratorOutput{Accelerator: &accelerator}, nil
+}
+
+func (f *GlobalAccelerator) DescribeAcceleratorWithContext(ctx context.Context, input *gasdk.DescribeAcceleratorInput) (*gasdk.DescribeAcceleratorOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DescribeAccelerator"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	acceleratorARN := awssdk.ToString(input.AcceleratorArn)
+	if _, exists := f.accelerators[acceleratorARN]; !exists {
+		return nil, newAcceleratorNotFoundError(acceleratorARN)
+	}
+	accelerator := f.buildAcceleratorView(acceleratorARN)
+	return &gasdk.DescribeAcceleratorOutput{Accelerator: &accelerator}, nil
+}
+
+func (f *GlobalAccelerator) UpdateAcceleratorWithContext(ctx context.Context, input *gasdk.UpdateAcceleratorInput) (*gasdk.UpdateAcceleratorOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "UpdateAccelerator"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	acceleratorARN := awssdk.ToString(input.AcceleratorArn)
+	accState, exists := f.accelerators[acceleratorARN]
+	if !exists {
+		return nil, newAcceleratorNotFoundError(acceleratorARN)
+	}
+	accelerator := accState.accelerator
+	if input.Name != nil {
+		accelerator.Name = input.Name
+	}
+	if input.Enabled != nil {
+		accelerator.Enabled = input.Enabled
+	}
+	if input.IpAddressType != "" && input.IpAddressType != accelerator.IpAddressType || len(input.IpAddresses) != 0 {
+		ipAddressType := input.IpAddressType
+		if ipAddressType == "" {
+			ipAddressType = accelerator.IpAddressType
+		}
+		if err := f.validateByoipAddresses(input.IpAddresses); err != nil {
+			return nil, err
+		}
+		ipSets, err := f.buildIPSets(ipAddressType, input.IpAddresses)
+		if err != nil {
+			return nil, err
+		}
+		// changing the IP address type keeps the static IPv4 addresses, only the IPv6 addresses are allocated or released.
+		if len(input.IpAddresses) == 0 {
+			ipSets[0] = accelerator.IpSets[0]
+		}
+		accelerator.IpAddressType = ipAddressType
+		accelerator.IpSets = ipSets
+		accelerator.DualStackDnsName = nil
+		if ipAddressType == gatypes.IpAddressTypeDualStack {
+			accelerator.DualStackDnsName = awssdk.String(strings.Replace(awssdk.ToString(accelerator.DnsName), ".awsglobalaccelerator.com", ".dualstack.awsglobalaccelerator.com", 1))
+		}
+	}
+	accelerator.LastModifiedTime = awssdk.Time(time.Now())
+	accState.accelerator = accelerator
+	f.simulation.created(acceleratorARN)
+	accelerator = f.buildAcceleratorView(acceleratorARN)
+	return &gasdk.UpdateAcceleratorOutput{Accelerator: &accelerator}, nil
+}
+
+func (f *GlobalAccelerator) DeleteAcceleratorWithContext(ctx context.Context, input *gasdk.DeleteAcceleratorInput) (*gasdk.DeleteAcceleratorOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DeleteAccelerator"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	acceleratorARN := awssdk.ToString(input.AcceleratorArn)
+	accState, exists := f.accelerators[acceleratorARN]
+	if !exists {
+		return nil, newAcceleratorNotFoundError(acceleratorARN)
+	}
+	if awssdk.ToBool(accState.accelerator.Enabled) {
+		return nil, &gatypes.AcceleratorNotDisabledException{Message: awssdk.String("The accelerator must be disabled before it can be deleted")}
+	}
+	for _, lsState := range f.listeners {
+		if lsState.acceleratorARN == acceleratorARN {
+			return nil, &gatypes.AssociatedListenerFoundException{Message: awssdk.String("The accelerator has listeners that must be deleted first")}
+		}
+	}
+	delete(f.accelerators, acceleratorARN)
+	delete(f.tags, acceleratorARN)
+	return &gasdk.DeleteAcceleratorOutput{}, nil
+}
+
+func (f *GlobalAccelerator) CreateListenerWithContext(ctx context.Context, input *gasdk.CreateListenerInput) (*gasdk.CreateListenerOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "CreateListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	acceleratorARN := awssdk.ToString(input.AcceleratorArn)
+	if _, exists := f.accelerators[acceleratorARN]; !exists {
+		return nil, newAcceleratorNotFoundError(acceleratorARN)
+	}
+	listenerCount := 0
+	for _, lsState := range f.listeners {
+		if lsState.acceleratorARN == acceleratorARN {
+			listenerCount++
+		}
+	}
+	if listenerCount >= f.simulation.Quotas().ListenersPerAccelerator {
+		return nil, &gatypes.LimitExceededException{Message: awssdk.String("The quota for the number of listeners per accelerator has been reached")}
+	}
+	if err := f.validatePortRanges(acceleratorARN, "", input.PortRanges); err != nil {
+		return nil, err
+	}
+	clientAffinity := input.ClientAffinity
+	if clientAffinity == "" {
+		clientAffinity = gatypes.ClientAffinityNone
+	}
+	listenerARN := fmt.Sprintf("%s/listener/%s", acceleratorARN, f.ids.nextHex("listener")[8:])
+	listener := gatypes.Listener{
+		ListenerArn:    awssdk.String(listenerARN),
+		PortRanges:     slices.Clone(input.PortRanges),
+		Protocol:       input.Protocol,
+		ClientAffinity: clientAffinity,
+	}
+	f.listeners[listenerARN] = &gaListenerState{acceleratorARN: acceleratorARN, listener: listener}
+	f.simulation.created(acceleratorARN)
+	return &gasdk.CreateListenerOutput{Listener: &listener}, nil
+}
+
+func (f *GlobalAccelerator) DescribeListenerWithContext(ctx context.Context, input *gasdk.DescribeListenerInput) (*gasdk.DescribeListenerOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DescribeListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	lsState, exists := f.listeners[listenerARN]
+	if !exists {
+		return nil, newGAListenerNotFoundError(listenerARN)
+	}
+	listener := lsState.listener
+	return &gasdk.DescribeListenerOutput{Listener: &listener}, nil
+}
+
+func (f *GlobalAccelerator) UpdateListenerWithContext(ctx context.Context, input *gasdk.UpdateListenerInput) (*gasdk.UpdateListenerOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "UpdateListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	lsState, exists := f.listeners[listenerARN]
+	if !exists {
+		return nil, newGAListenerNotFoundError(listenerARN)
+	}
+	listener := lsState.listener
+	if len(input.PortRanges) != 0 {
+		if err := f.validatePortRanges(lsState.acceleratorARN, listenerARN, input.PortRanges); err != nil {
+			return nil, err
+		}
+		listener.PortRanges = slices.Clone(input.PortRanges)
+	}
+	if input.Protocol != "" {
+		listener.Protocol = input.Protocol
+	}
+	if input.ClientAffinity != "" {
+		listener.ClientAffinity = input.ClientAffinity
+	}
+	lsState.listener = listener
+	f.simulation.created(lsState.acceleratorARN)
+	return &gasdk.UpdateListenerOutput{Listener: &listener}, nil
+}
+
+func (f *GlobalAccelerator) DeleteListenerWithContext(ctx context.Context, input *gasdk.DeleteListenerInput) (*gasdk.DeleteListenerOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DeleteListener"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	if _, exists := f.listeners[listenerARN]; !exists {
+		return nil, newGAListenerNotFoundError(listenerARN)
+	}
+	for _, egState := range f.endpointGroups {
+		if egState.listenerARN == listenerARN {
+			return nil, &gatypes.AssociatedEndpointGroupFoundException{Message: awssdk.String("The listener has endpoint groups that must be deleted first")}
+		}
+	}
+	delete(f.listeners, listenerARN)
+	return &gasdk.DeleteListenerOutput{}, nil
+}
+
+func (f *GlobalAccelerator) ListListenersAsList(ctx context.Context, input *gasdk.ListListenersInput) ([]gatypes.Listener, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "ListListeners"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	acceleratorARN := awssdk.ToString(input.AcceleratorArn)
+	if _, exists := f.accelerators[acceleratorARN]; !exists {
+		return nil, newAcceleratorNotFoundError(acceleratorARN)
+	}
+	var listeners []gatypes.Listener
+	for _, listenerARN := range sortedKeys(f.listeners) {
+		if lsState := f.listeners[listenerARN]; lsState.acceleratorARN == acceleratorARN {
+			listeners = append(listeners, lsState.listener)
+		}
+	}
+	return listeners, nil
+}
+
+func (f *GlobalAccelerator) ListListenersForAcceleratorWithContext(ctx context.Context, input *gasdk.ListListenersInput) (*gasdk.ListListenersOutput, error) {
+	listeners, err := f.ListListenersAsList(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &gasdk.ListListenersOutput{Listeners: listeners}, nil
+}
+
+func (f *GlobalAccelerator) TagResourceWithContext(ctx context.Context, input *gasdk.TagResourceInput) (*gasdk.TagResourceOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "TagResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	resourceARN := awssdk.ToString(input.ResourceArn)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newAcceleratorNotFoundError(resourceARN)
+	}
+	for key, value := range buildGATagMap(input.Tags) {
+		tags[key] = value
+	}
+	return &gasdk.TagResourceOutput{}, nil
+}
+
+func (f *GlobalAccelerator) UntagResourceWithContext(ctx context.Context, input *gasdk.UntagResourceInput) (*gasdk.UntagResourceOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "UntagResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	resourceARN := awssdk.ToString(input.ResourceArn)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newAcceleratorNotFoundError(resourceARN)
+	}
+	for _, key := range input.TagKeys {
+		delete(tags, key)
+	}
+	return &gasdk.UntagResourceOutput{}, nil
+}
+
+func (f *GlobalAccelerator) ListTagsForResourceWithContext(ctx context.Context, input *gasdk.ListTagsForResourceInput) (*gasdk.ListTagsForResourceOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "ListTagsForResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	resourceARN := awssdk.ToString(input.ResourceArn)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newAcceleratorNotFoundError(resourceARN)
+	}
+	var sdkTags []gatypes.Tag
+	for _, key := range sortedKeys(tags) {
+		sdkTags = append(sdkTags, gatypes.Tag{Key: awssdk.String(key), Value: awssdk.String(tags[key])})
+	}
+	return &gasdk.ListTagsForResourceOutput{Tags: sdkTags}, nil
+}
+
+// buildAcceleratorView returns the accelerator with its deployment status.
+func (f *GlobalAccelerator) buildAcceleratorView(acceleratorARN string) gatypes.Accelerator {
+	accelerator := f.accelerators[acceleratorARN].accelerator
+	accelerator.Status = gatypes.AcceleratorStatusDeployed
+	if !f.simulation.visible(acceleratorARN) {
+		accelerator.Status = gatypes.AcceleratorStatusInProgress
+	}
+	return accelerator
+}
+
+// buildIPSets allocates the static IP addresses of an accelerator, unless it brings its own IP addresses.
+func (f *GlobalAccelerator) buildIPSets(ipAddressType gatypes.IpAddressType, ipAddresses []string) ([]gatypes.IpSet, error) {
+	if len(ipAddresses) > 2 {
+		return nil, &gatypes.InvalidArgumentException{Message: awssdk.String("At most two IP addresses can be specified")}
+	}
+	count := f.ids.nextCount("ipset")
+	ipv4Addresses := []string{
+		fmt.Sprintf("75.2.%d.%d", count/256%256, count%256),
+		fmt.Sprintf("99.83.%d.%d", count/256%256, count%256),
+	}
+	copy(ipv4Addresses, ipAddresses)
+	ipSets := []gatypes.IpSet{
+		{IpAddressFamily: gatypes.IpAddressFamilyIPv4, IpFamily: awssdk.String("IPv4"), IpAddresses: ipv4Addresses},
+	}
+	if ipAddressType == gatypes.IpAddressTypeDualStack {
+		ipSets = append(ipSets, gatypes.IpSet{
+			IpAddressFamily: gatypes.IpAddressFamilyIPv6,
+			IpFamily:        awssdk.String("IPv6"),
+			IpAddresses:     []string{fmt.Sprintf("2600:9000:a400::%x", count), fmt.Sprintf("2600:9000:a500::%x", count)},
+		})
+	}
+	return ipSets, nil
+}
+
+// validateByoipAddresses checks that ipAddresses belong to provisioned BYOIP address ranges.
+func (f *GlobalAccelerator) validateByoipAddresses(ipAddresses []string) error {
+	for _, ipAddress := range ipAddresses {
+		addr, err := netip.ParseAddr(ipAddress)
+		if err != nil {
+			return &gatypes.InvalidArgumentException{Message: awssdk.String(fmt.Sprintf("The IP address %s is not valid", ipAddress))}
+		}
+		if !slices.ContainsFunc(f.byoipCidrs, func(byoipCidr gatypes.ByoipCidr) bool {
+			prefix, err := netip.ParsePrefix(awssdk.ToString(byoipCidr.Cidr))
+			return err == nil && prefix.Contains(addr) && isByoipCidrProvisioned(byoipCidr.State)
+		}) {
+			return &gatypes.InvalidArgumentException{Message: awssdk.String(fmt.Sprintf("The IP address %s is not in a provisioned address range", ipAddress))}
+		}
+	}
+	return nil
+}
+
+func isByoipCidrProvisioned(state gatypes.ByoipCidrState) bool {
+	switch state {
+	case gatypes.ByoipCidrStateReady, gatypes.ByoipCidrStatePendingAdvertising, gatypes.ByoipCidrStateAdvertising,
+		gatypes.ByoipCidrStatePendingWithdrawing, gatypes.ByoipCidrStateFailedAdvertising, gatypes.ByoipCidrStateFailedWithdraw:
+		return true
+	}
+	return false
+}
+
+// validatePortRanges checks that portRanges are valid and don't overlap the port ranges of the other listeners of the accelerator.
+func (f *GlobalAccelerator) validatePortRanges(acceleratorARN string, listenerARN string, portRanges []gatypes.PortRange) error {
+	if len(portRanges) == 0 {
+		return &gatypes.InvalidArgumentException{Message: awssdk.String("At least one port range must be specified")}
+	}
+	for _, portRange := range portRanges {
+		from, to := awssdk.ToInt32(portRange.FromPort), awssdk.ToInt32(portRange.ToPort)
+		if from < 1 || to > 65535 || from > to {
+			return &gatypes.InvalidPortRangeException{Message: awssdk.String(fmt.Sprintf("The port range %d-%d is not valid", from, to))}
+		}
+		for otherARN, lsState := range f.listeners {
+			if otherARN == listenerARN || lsState.acceleratorARN != acceleratorARN {
+				continue
+			}
+			for _, other := range lsState.listener.PortRanges {
+				if from <= awssdk.ToInt32(other.ToPort) && awssdk.ToInt32(other.FromPort) <= to {
+					return &gatypes.InvalidPortRangeException{Message: awssdk.String(fmt.Sprintf("The port range %d-%d overlaps with listener %s", from, to, otherARN))}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func buildGATagMap(tags []gatypes.Tag) map[string]string {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+	}
+	return tagMap
+}
+
+func newAcceleratorNotFoundError(acceleratorARN string) error {
+	return &gatypes.AcceleratorNotFoundException{Message: awssdk.String(fmt.Sprintf("Accelerator %s not found", acceleratorARN))}
+}
+
+func newGAListenerNotFoundError(listenerARN string) error {
+	return &gatypes.ListenerNotFoundException{Message: awssdk.String(fmt.Sprintf("Listener %s not found", listenerARN))}
+}
+
+// taggedResources returns the accelerators with their tags.
+func (f *GlobalAccelerator) taggedResources() []taggedResource {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var resources []taggedResource
+	for _, acceleratorARN := range sortedKeys(f.accelerators) {
+		resources = append(resources, taggedResource{arn: acceleratorARN, resourceType: services.ResourceTypeGlobalAccelerator, tags: copyTags(f.tags[acceleratorARN])})
+	}
+	return resources
+}
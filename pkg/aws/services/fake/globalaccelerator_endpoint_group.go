@@ -0,0 +1,278 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+)
+
+const (
+	defaultEndpointWeight         = 128
+	defaultTrafficDialPercentage  = 100
+	defaultHealthCheckInterval    = 30
+	defaultHealthCheckThreshold   = 3
+	endpointHealthReasonNotExists = "Endpoint does not exist"
+)
+
+func (f *GlobalAccelerator) CreateEndpointGroupWithContext(ctx context.Context, input *gasdk.CreateEndpointGroupInput) (*gasdk.CreateEndpointGroupOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "CreateEndpointGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	lsState, exists := f.listeners[listenerARN]
+	if !exists {
+		return nil, newGAListenerNotFoundError(listenerARN)
+	}
+	region := awssdk.ToString(input.EndpointGroupRegion)
+	for _, egState := range f.endpointGroups {
+		if egState.listenerARN == listenerARN && awssdk.ToString(egState.endpointGroup.EndpointGroupRegion) == region {
+			return nil, &gatypes.EndpointGroupAlreadyExistsException{Message: awssdk.String(fmt.Sprintf("An endpoint group for region %s already exists on listener %s", region, listenerARN))}
+		}
+	}
+	endpoints, err := f.addEndpoints(nil, input.EndpointConfigurations)
+	if err != nil {
+		return nil, err
+	}
+	endpointGroup := gatypes.EndpointGroup{
+		EndpointGroupArn:           awssdk.String(fmt.Sprintf("%s/endpoint-group/%s", listenerARN, f.ids.nextHex("endpointgroup")[4:])),
+		EndpointGroupRegion:        input.EndpointGroupRegion,
+		EndpointDescriptions:       endpoints,
+		TrafficDialPercentage:      input.TrafficDialPercentage,
+		HealthCheckPort:            input.HealthCheckPort,
+		HealthCheckProtocol:        input.HealthCheckProtocol,
+		HealthCheckPath:            input.HealthCheckPath,
+		HealthCheckIntervalSeconds: input.HealthCheckIntervalSeconds,
+		ThresholdCount:             input.ThresholdCount,
+		PortOverrides:              slices.Clone(input.PortOverrides),
+	}
+	applyEndpointGroupDefaults(&endpointGroup, lsState.listener)
+	f.endpointGroups[awssdk.ToString(endpointGroup.EndpointGroupArn)] = &endpointGroupState{
+		listenerARN:   listenerARN,
+		endpointGroup: endpointGroup,
+	}
+	f.simulation.created(lsState.acceleratorARN)
+	endpointGroup = f.buildEndpointGroupView(awssdk.ToString(endpointGroup.EndpointGroupArn))
+	return &gasdk.CreateEndpointGroupOutput{EndpointGroup: &endpointGroup}, nil
+}
+
+func (f *GlobalAccelerator) DescribeEndpointGroupWithContext(ctx context.Context, input *gasdk.DescribeEndpointGroupInput) (*gasdk.DescribeEndpointGroupOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DescribeEndpointGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	endpointGroupARN := awssdk.ToString(input.EndpointGroupArn)
+	if _, exists := f.endpointGroups[endpointGroupARN]; !exists {
+		return nil, newEndpointGroupNotFoundError(endpointGroupARN)
+	}
+	endpointGroup := f.buildEndpointGroupView(endpointGroupARN)
+	return &gasdk.DescribeEndpointGroupOutput{EndpointGroup: &endpointGroup}, nil
+}
+
+// UpdateEndpointGroupWithContext updates an endpoint group, replacing its endpoints when EndpointConfigurations is set.
+func (f *GlobalAccelerator) UpdateEndpointGroupWithContext(ctx context.Context, input *gasdk.UpdateEndpointGroupInput) (*gasdk.UpdateEndpointGroupOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "UpdateEndpointGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	endpointGroupARN := awssdk.ToString(input.EndpointGroupArn)
+	egState, exists := f.endpointGroups[endpointGroupARN]
+	if !exists {
+		return nil, newEndpointGroupNotFoundError(endpointGroupARN)
+	}
+	endpointGroup := egState.endpointGroup
+	if input.EndpointConfigurations != nil {
+		endpoints, err := f.addEndpoints(nil, input.EndpointConfigurations)
+		if err != nil {
+			return nil, err
+		}
+		endpointGroup.EndpointDescriptions = endpoints
+	}
+	if input.TrafficDialPercentage != nil {
+		endpointGroup.TrafficDialPercentage = input.TrafficDialPercentage
+	}
+	if input.HealthCheckPort != nil {
+		endpointGroup.HealthCheckPort = input.HealthCheckPort
+	}
+	if input.HealthCheckProtocol != "" {
+		endpointGroup.HealthCheckProtocol = input.HealthCheckProtocol
+	}
+	if input.HealthCheckPath != nil {
+		endpointGroup.HealthCheckPath = input.HealthCheckPath
+	}
+	if input.HealthCheckIntervalSeconds != nil {
+		endpointGroup.HealthCheckIntervalSeconds = input.HealthCheckIntervalSeconds
+	}
+	if input.ThresholdCount != nil {
+		endpointGroup.ThresholdCount = input.ThresholdCount
+	}
+	if input.PortOverrides != nil {
+		endpointGroup.PortOverrides = slices.Clone(input.PortOverrides)
+	}
+	egState.endpointGroup = endpointGroup
+	f.simulation.created(f.listeners[egState.listenerARN].acceleratorARN)
+	endpointGroup = f.buildEndpointGroupView(endpointGroupARN)
+	return &gasdk.UpdateEndpointGroupOutput{EndpointGroup: &endpointGroup}, nil
+}
+
+func (f *GlobalAccelerator) DeleteEndpointGroupWithContext(ctx context.Context, input *gasdk.DeleteEndpointGroupInput) (*gasdk.DeleteEndpointGroupOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "DeleteEndpointGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	endpointGroupARN := awssdk.ToString(input.EndpointGroupArn)
+	if _, exists := f.endpointGroups[endpointGroupARN]; !exists {
+		return nil, newEndpointGroupNotFoundError(endpointGroupARN)
+	}
+	delete(f.endpointGroups, endpointGroupARN)
+	return &gasdk.DeleteEndpointGroupOutput{}, nil
+}
+
+func (f *GlobalAccelerator) ListEndpointGroupsAsList(ctx context.Context, input *gasdk.ListEndpointGroupsInput) ([]gatypes.EndpointGroup, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "ListEndpointGroups"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	listenerARN := awssdk.ToString(input.ListenerArn)
+	if _, exists := f.listeners[listenerARN]; !exists {
+		return nil, newGAListenerNotFoundError(listenerARN)
+	}
+	var endpointGroups []gatypes.EndpointGroup
+	for _, endpointGroupARN := range sortedKeys(f.endpointGroups) {
+		if f.endpointGroups[endpointGroupARN].listenerARN == listenerARN {
+			endpointGroups = append(endpointGroups, f.buildEndpointGroupView(endpointGroupARN))
+		}
+	}
+	return endpointGroups, nil
+}
+
+func (f *GlobalAccelerator) AddEndpointsWithContext(ctx context.Context, input *gasdk.AddEndpointsInput) (*gasdk.AddEndpointsOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "AddEndpoints"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	endpointGroupARN := awssdk.ToString(input.EndpointGroupArn)
+	egState, exists := f.endpointGroups[endpointGroupARN]
+	if !exists {
+		return nil, newEndpointGroupNotFoundError(endpointGroupARN)
+	}
+	endpoints, err := f.addEndpoints(egState.endpointGroup.EndpointDescriptions, input.EndpointConfigurations)
+	if err != nil {
+		return nil, err
+	}
+	egState.endpointGroup.EndpointDescriptions = endpoints
+	f.simulation.created(f.listeners[egState.listenerARN].acceleratorARN)
+	endpointGroup := f.buildEndpointGroupView(endpointGroupARN)
+	var added []gatypes.EndpointDescription
+	for _, endpoint := range endpointGroup.EndpointDescriptions {
+		if slices.ContainsFunc(input.EndpointConfigurations, func(cfg gatypes.EndpointConfiguration) bool {
+			return awssdk.ToString(cfg.EndpointId) == awssdk.ToString(endpoint.EndpointId)
+		}) {
+			added = append(added, endpoint)
+		}
+	}
+	return &gasdk.AddEndpointsOutput{EndpointGroupArn: input.EndpointGroupArn, EndpointDescriptions: added}, nil
+}
+
+func (f *GlobalAccelerator) RemoveEndpointsWithContext(ctx context.Context, input *gasdk.RemoveEndpointsInput) (*gasdk.RemoveEndpointsOutput, error) {
+	if err := f.simulation.call(ServiceGlobalAccelerator, "RemoveEndpoints"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	endpointGroupARN := awssdk.ToString(input.EndpointGroupArn)
+	egState, exists := f.endpointGroups[endpointGroupARN]
+	if !exists {
+		return nil, newEndpointGroupNotFoundError(endpointGroupARN)
+	}
+	endpoints := slices.Clone(egState.endpointGroup.EndpointDescriptions)
+	for _, identifier := range input.EndpointIdentifiers {
+		endpointID := awssdk.ToString(identifier.EndpointId)
+		index := slices.IndexFunc(endpoints, func(endpoint gatypes.EndpointDescription) bool {
+			return awssdk.ToString(endpoint.EndpointId) == endpointID
+		})
+		if index < 0 {
+			return nil, &gatypes.EndpointNotFoundException{Message: awssdk.String(fmt.Sprintf("Endpoint %s not found", endpointID))}
+		}
+		endpoints = slices.Delete(endpoints, index, index+1)
+	}
+	egState.endpointGroup.EndpointDescriptions = endpoints
+	f.simulation.created(f.listeners[egState.listenerARN].acceleratorARN)
+	return &gasdk.RemoveEndpointsOutput{}, nil
+}
+
+// addEndpoints adds the endpoints of configurations to endpoints, enforcing the endpoints quota.
+func (f *GlobalAccelerator) addEndpoints(endpoints []gatypes.EndpointDescription, configurations []gatypes.EndpointConfiguration) ([]gatypes.EndpointDescription, error) {
+	endpoints = slices.Clone(endpoints)
+	for _, cfg := range configurations {
+		endpointID := awssdk.ToString(cfg.EndpointId)
+		if endpointID == "" {
+			return nil, &gatypes.InvalidArgumentException{Message: awssdk.String("EndpointId must be specified")}
+		}
+		if slices.ContainsFunc(endpoints, func(endpoint gatypes.EndpointDescription) bool {
+			return awssdk.ToString(endpoint.EndpointId) == endpointID
+		}) {
+			return nil, &gatypes.EndpointAlreadyExistsException{Message: awssdk.String(fmt.Sprintf("Endpoint %s already exists in the endpoint group", endpointID))}
+		}
+		weight := cfg.Weight
+		if weight == nil {
+			weight = awssdk.Int32(defaultEndpointWeight)
+		}
+		endpoints = append(endpoints, gatypes.EndpointDescription{
+			EndpointId:                  cfg.EndpointId,
+			Weight:                      weight,
+			ClientIPPreservationEnabled: awssdk.Bool(cfg.ClientIPPreservationEnabled == nil || awssdk.ToBool(cfg.ClientIPPreservationEnabled)),
+		})
+	}
+	if len(endpoints) > f.simulation.Quotas().EndpointsPerEndpointGroup {
+		return nil, &gatypes.LimitExceededException{Message: awssdk.String("The quota for the number of endpoints per endpoint group has been reached")}
+	}
+	return endpoints, nil
+}
+
+// buildEndpointGroupView returns the endpoint group with the health of its endpoints.
+func (f *GlobalAccelerator) buildEndpointGroupView(endpointGroupARN string) gatypes.EndpointGroup {
+	endpointGroup := f.endpointGroups[endpointGroupARN].endpointGroup
+	endpointGroup.EndpointDescriptions = slices.Clone(endpointGroup.EndpointDescriptions)
+	for i, endpoint := range endpointGroup.EndpointDescriptions {
+		if f.endpointExists == nil || f.endpointExists(awssdk.ToString(endpoint.EndpointId)) {
+			endpointGroup.EndpointDescriptions[i].HealthState = gatypes.HealthStateHealthy
+		} else {
+			endpointGroup.EndpointDescriptions[i].HealthState = gatypes.HealthStateUnhealthy
+			endpointGroup.EndpointDescriptions[i].HealthReason = awssdk.String(endpointHealthReasonNotExists)
+		}
+	}
+	return endpointGroup
+}
+
+func applyEndpointGroupDefaults(endpointGroup *gatypes.EndpointGroup, listener gatypes.Listener) {
+	if endpointGroup.TrafficDialPercentage == nil {
+		endpointGroup.TrafficDialPercentage = awssdk.Float32(defaultTrafficDialPercentage)
+	}
+	if endpointGroup.HealthCheckProtocol == "" {
+		endpointGroup.HealthCheckProtocol = gatypes.HealthCheckProtocolTcp
+	}
+	if endpointGroup.HealthCheckPort == nil && len(listener.PortRanges) != 0 {
+		endpointGroup.HealthCheckPort = listener.PortRanges[0].FromPort
+	}
+	if endpointGroup.HealthCheckIntervalSeconds == nil {
+		endpointGroup.HealthCheckIntervalSeconds = awssdk.Int32(defaultHealthCheckInterval)
+	}
+	if endpointGroup.ThresholdCount == nil {
+		endpointGroup.ThresholdCount = awssdk.Int32(defaultHealthCheckThreshold)
+	}
+}
+
+func newEndpointGroupNotFoundError(endpointGroupARN string) error {
+	return &gatypes.EndpointGroupNotFoundException{Message: awssdk.String(fmt.Sprintf("Endpoint group %s not found", endpointGroupARN))}
+}
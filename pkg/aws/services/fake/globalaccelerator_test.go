@@ -0,0 +1,112 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalAccelerator_lifecycle(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	gaClient := network.cloud.GlobalAccelerator()
+	lb := network.createALB(t, "k8s-default-aga")
+
+	acceleratorOutput, err := gaClient.CreateAcceleratorWithContext(ctx, &gasdk.CreateAcceleratorInput{
+		Name:    awssdk.String("aga"),
+		Enabled: awssdk.Bool(true),
+		Tags:    []gatypes.Tag{{Key: awssdk.String("aga.k8s.aws/resource"), Value: awssdk.String("default/aga")}},
+	})
+	assert.NoError(t, err)
+	acceleratorARN := acceleratorOutput.Accelerator.AcceleratorArn
+
+	listenerOutput, err := gaClient.CreateListenerWithContext(ctx, &gasdk.CreateListenerInput{
+		AcceleratorArn: acceleratorARN,
+		Protocol:       gatypes.ProtocolTcp,
+		PortRanges:     []gatypes.PortRange{{FromPort: awssdk.Int32(80), ToPort: awssdk.Int32(80)}},
+	})
+	assert.NoError(t, err)
+	_, err = gaClient.CreateListenerWithContext(ctx, &gasdk.CreateListenerInput{
+		AcceleratorArn: acceleratorARN,
+		Protocol:       gatypes.ProtocolTcp,
+		PortRanges:     []gatypes.PortRange{{FromPort: awssdk.Int32(1), ToPort: awssdk.Int32(100)}},
+	})
+	var portRangeErr *gatypes.InvalidPortRangeException
+	assert.True(t, errors.As(err, &portRangeErr))
+
+	egOutput, err := gaClient.CreateEndpointGroupWithContext(ctx, &gasdk.CreateEndpointGroupInput{
+		ListenerArn:            listenerOutput.Listener.ListenerArn,
+		EndpointGroupRegion:    awssdk.String("us-west-2"),
+		EndpointConfigurations: []gatypes.EndpointConfiguration{{EndpointId: lb.LoadBalancerArn}},
+	})
+	assert.NoError(t, err)
+	endpoints := egOutput.EndpointGroup.EndpointDescriptions
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, gatypes.HealthStateHealthy, endpoints[0].HealthState)
+		assert.Equal(t, int32(128), awssdk.ToInt32(endpoints[0].Weight))
+	}
+
+	_, err = network.cloud.ELBV2().DeleteLoadBalancerWithContext(ctx, &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: lb.LoadBalancerArn})
+	assert.NoError(t, err)
+	describeEGOutput, err := gaClient.DescribeEndpointGroupWithContext(ctx, &gasdk.DescribeEndpointGroupInput{EndpointGroupArn: egOutput.EndpointGroup.EndpointGroupArn})
+	assert.NoError(t, err)
+	assert.Equal(t, gatypes.HealthStateUnhealthy, describeEGOutput.EndpointGroup.EndpointDescriptions[0].HealthState)
+
+	_, err = gaClient.DeleteAcceleratorWithContext(ctx, &gasdk.DeleteAcceleratorInput{AcceleratorArn: acceleratorARN})
+	var notDisabledErr *gatypes.AcceleratorNotDisabledException
+	assert.True(t, errors.As(err, &notDisabledErr))
+
+	_, err = gaClient.DeleteEndpointGroupWithContext(ctx, &gasdk.DeleteEndpointGroupInput{EndpointGroupArn: egOutput.EndpointGroup.EndpointGroupArn})
+	assert.NoError(t, err)
+	_, err = gaClient.DeleteListenerWithContext(ctx, &gasdk.DeleteListenerInput{ListenerArn: listenerOutput.Listener.ListenerArn})
+	assert.NoError(t, err)
+	_, err = gaClient.UpdateAcceleratorWithContext(ctx, &gasdk.UpdateAcceleratorInput{AcceleratorArn: acceleratorARN, Enabled: awssdk.Bool(false)})
+	assert.NoError(t, err)
+	_, err = gaClient.DeleteAcceleratorWithContext(ctx, &gasdk.DeleteAcceleratorInput{AcceleratorArn: acceleratorARN})
+	assert.NoError(t, err)
+	_, err = gaClient.DescribeAcceleratorWithContext(ctx, &gasdk.DescribeAcceleratorInput{AcceleratorArn: acceleratorARN})
+	var notFoundErr *gatypes.AcceleratorNotFoundException
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestGlobalAccelerator_byoipAddresses(t *testing.T) {
+	ctx := context.Background()
+	gaClient := NewGlobalAccelerator("123456789012")
+	gaClient.AddByoipCidr(gatypes.ByoipCidr{Cidr: awssdk.String("198.51.100.0/24"), State: gatypes.ByoipCidrStateAdvertising})
+
+	_, err := gaClient.CreateAcceleratorWithContext(ctx, &gasdk.CreateAcceleratorInput{
+		Name:        awssdk.String("aga"),
+		IpAddresses: []string{"203.0.113.10"},
+	})
+	var invalidArgumentErr *gatypes.InvalidArgumentException
+	assert.True(t, errors.As(err, &invalidArgumentErr))
+
+	acceleratorOutput, err := gaClient.CreateAcceleratorWithContext(ctx, &gasdk.CreateAcceleratorInput{
+		Name:        awssdk.String("aga"),
+		IpAddresses: []string{"198.51.100.10"},
+	})
+	assert.NoError(t, err)
+	ipv4Addresses := acceleratorOutput.Accelerator.IpSets[0].IpAddresses
+	assert.Equal(t, "198.51.100.10", ipv4Addresses[0])
+
+	// changing the IP address type keeps the IPv4 addresses
+	updateOutput, err := gaClient.UpdateAcceleratorWithContext(ctx, &gasdk.UpdateAcceleratorInput{
+		AcceleratorArn: acceleratorOutput.Accelerator.AcceleratorArn,
+		IpAddressType:  gatypes.IpAddressTypeDualStack,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, updateOutput.Accelerator.IpSets, 2) {
+		assert.Equal(t, ipv4Addresses, updateOutput.Accelerator.IpSets[0].IpAddresses)
+		assert.Equal(t, gatypes.IpAddressFamilyIPv6, updateOutput.Accelerator.IpSets[1].IpAddressFamily)
+	}
+
+	byoipCidrs, err := gaClient.ListByoipCidrsAsList(ctx, &gasdk.ListByoipCidrsInput{})
+	assert.NoError(t, err)
+	assert.Len(t, byoipCidrs, 1)
+}
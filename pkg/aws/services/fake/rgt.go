@@ -0,0 +1,105 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.RGT = &RGT{}
+
+// taggedResource is a resource reported by the tagging API.
+type taggedResource struct {
+	arn string
+	// resourceType is the RGT resource type of the resource, e.g. "elasticloadbalancing:loadbalancer".
+	resourceType string
+	tags         map[string]string
+}
+
+// RGT is an in-memory implementation of services.RGT, which reports the tagged resources of the other fakes.
+type RGT struct {
+	mutex      sync.RWMutex
+	simulation *Simulation
+
+	// sources list the tagged resources of the fakes. They are called without holding the lock.
+	sources []func() []taggedResource
+}
+
+// NewRGT constructs a new fake RGT without resources.
+func NewRGT() *RGT {
+	return &RGT{
+		simulation: NewSimulation(),
+	}
+}
+
+// addSource registers a source of tagged resources.
+func (f *RGT) addSource(source func() []taggedResource) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.sources = append(f.sources, source)
+}
+
+// GetResourcesAsList returns the tagged resources matching all TagFilters and any of ResourceTypeFilters, sorted by ARN.
+func (f *RGT) GetResourcesAsList(ctx context.Context, input *rgtsdk.GetResourcesInput) ([]rgttypes.ResourceTagMapping, error) {
+	if err := f.simulation.call(ServiceRGT, "GetResources"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	sources := slices.Clone(f.sources)
+	f.mutex.RUnlock()
+
+	var resources []taggedResource
+	for _, source := range sources {
+		resources = append(resources, source()...)
+	}
+	slices.SortFunc(resources, func(a, b taggedResource) int {
+		return strings.Compare(a.arn, b.arn)
+	})
+	var mappings []rgttypes.ResourceTagMapping
+	for _, resource := range resources {
+		if len(resource.tags) == 0 {
+			continue
+		}
+		if !matchResourceTypeFilters(input.ResourceTypeFilters, resource.resourceType) || !matchTagFilters(input.TagFilters, resource.tags) {
+			continue
+		}
+		if !f.simulation.visible(resource.arn) {
+			continue
+		}
+		mapping := rgttypes.ResourceTagMapping{ResourceARN: awssdk.String(resource.arn)}
+		for _, key := range sortedKeys(resource.tags) {
+			mapping.Tags = append(mapping.Tags, rgttypes.Tag{Key: awssdk.String(key), Value: awssdk.String(resource.tags[key])})
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// matchResourceTypeFilters checks whether resourceType matches any of filters, which are either a service or a service:type.
+func matchResourceTypeFilters(filters []string, resourceType string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	service, _, _ := strings.Cut(resourceType, ":")
+	return slices.Contains(filters, resourceType) || slices.Contains(filters, service)
+}
+
+// matchTagFilters checks whether tags have the keys of all filters, with one of their values if specified.
+func matchTagFilters(filters []rgttypes.TagFilter, tags map[string]string) bool {
+	for _, filter := range filters {
+		value, exists := tags[awssdk.ToString(filter.Key)]
+		if !exists {
+			return false
+		}
+		if len(filter.Values) != 0 && !slices.Contains(filter.Values, value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+func TestRGT_GetResourcesAsList(t *testing.T) {
+	network := newTestNetwork(t)
+	lb := network.createALB(t, "k8s-default-tagged")
+	network.createTargetGroup(t, "k8s-default-untagged", elbv2types.ProtocolEnumHttp)
+	sg := network.cloud.FakeEC2().AddSecurityGroup(ec2types.SecurityGroup{
+		VpcId:     awssdk.String(network.vpcID),
+		GroupName: awssdk.String("managed"),
+		Tags:      []ec2types.Tag{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("other-cluster")}},
+	})
+
+	tests := []struct {
+		name  string
+		input *rgtsdk.GetResourcesInput
+		want  []string
+	}{
+		{
+			name:  "all tagged resources",
+			input: &rgtsdk.GetResourcesInput{},
+			want: []string{
+				"arn:aws:ec2:us-west-2:123456789012:security-group/" + awssdk.ToString(sg.GroupId),
+				awssdk.ToString(lb.LoadBalancerArn),
+			},
+		},
+		{
+			name: "resource type and tag value",
+			input: &rgtsdk.GetResourcesInput{
+				ResourceTypeFilters: []string{services.ResourceTypeELBLoadBalancer, services.ResourceTypeEC2SecurityGroup},
+				TagFilters:          []rgttypes.TagFilter{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Values: []string{"my-cluster"}}},
+			},
+			want: []string{awssdk.ToString(lb.LoadBalancerArn)},
+		},
+		{
+			name: "service filter",
+			input: &rgtsdk.GetResourcesInput{
+				ResourceTypeFilters: []string{"ec2"},
+			},
+			want: []string{"arn:aws:ec2:us-west-2:123456789012:security-group/" + awssdk.ToString(sg.GroupId)},
+		},
+		{
+			name: "missing tag key",
+			input: &rgtsdk.GetResourcesInput{
+				TagFilters: []rgttypes.TagFilter{{Key: awssdk.String("service.k8s.aws/stack")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mappings, err := network.cloud.RGT().GetResourcesAsList(context.Background(), tt.input)
+			assert.NoError(t, err)
+			var got []string
+			for _, mapping := range mappings {
+				got = append(got, awssdk.ToString(mapping.ResourceARN))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
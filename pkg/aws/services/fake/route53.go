@@ -0,0 +1,203 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	route53sdk "github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.Route53 = &Route53{}
+
+const hostedZoneIDPrefix = "/hostedzone/"
+
+// Route53 is a stateful in-memory implementation of services.Route53.
+// Hosted zones are seeded with AddHostedZone.
+type Route53 struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+
+	hostedZones map[string]*hostedZoneState
+}
+
+type hostedZoneState struct {
+	hostedZone route53types.HostedZone
+	// recordSets are the record sets of the hosted zone, keyed by name and type.
+	recordSets map[string]route53types.ResourceRecordSet
+}
+
+// NewRoute53 constructs a new fake Route53 without hosted zones.
+func NewRoute53() *Route53 {
+	return &Route53{
+		ids:         newIDGenerator(),
+		simulation:  NewSimulation(),
+		hostedZones: make(map[string]*hostedZoneState),
+	}
+}
+
+// AddHostedZone seeds a hosted zone for domain, returning its ID in the "/hostedzone/<id>" form of ListHostedZones.
+func (f *Route53) AddHostedZone(domain string, private bool) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	zoneID := hostedZoneIDPrefix + fmt.Sprintf("Z%020X", f.ids.nextCount("hostedzone"))
+	f.hostedZones[zoneID] = &hostedZoneState{
+		hostedZone: route53types.HostedZone{
+			Id:              awssdk.String(zoneID),
+			Name:            awssdk.String(normalizeDNSName(domain)),
+			CallerReference: awssdk.String(zoneID),
+			Config:          &route53types.HostedZoneConfig{PrivateZone: private},
+		},
+		recordSets: make(map[string]route53types.ResourceRecordSet),
+	}
+	return zoneID
+}
+
+// RecordSets returns the record sets of the hosted zone zoneID, sorted by name and type.
+func (f *Route53) RecordSets(zoneID string) []route53types.ResourceRecordSet {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	zoneState, exists := f.hostedZones[normalizeHostedZoneID(zoneID)]
+	if !exists {
+		return nil
+	}
+	var recordSets []route53types.ResourceRecordSet
+	for _, key := range sortedKeys(zoneState.recordSets) {
+		recordSets = append(recordSets, zoneState.recordSets[key])
+	}
+	return recordSets
+}
+
+// hasRecord checks whether any hosted zone has a record of recordType named name with value.
+func (f *Route53) hasRecord(name string, recordType string, value string) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, zoneState := range f.hostedZones {
+		recordSet, exists := zoneState.recordSets[buildRecordSetKey(name, recordType)]
+		if !exists {
+			continue
+		}
+		if slices.ContainsFunc(recordSet.ResourceRecords, func(record route53types.ResourceRecord) bool {
+			return normalizeDNSName(awssdk.ToString(record.Value)) == normalizeDNSName(value)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Route53) ChangeRecordsWithContext(ctx context.Context, input *route53sdk.ChangeResourceRecordSetsInput) (*route53sdk.ChangeResourceRecordSetsOutput, error) {
+	if err := f.simulation.call(ServiceRoute53, "ChangeResourceRecordSets"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	zoneID := normalizeHostedZoneID(awssdk.ToString(input.HostedZoneId))
+	zoneState, exists := f.hostedZones[zoneID]
+	if !exists {
+		return nil, &route53types.NoSuchHostedZone{Message: awssdk.String(fmt.Sprintf("No hosted zone found with ID: %s", strings.TrimPrefix(zoneID, hostedZoneIDPrefix)))}
+	}
+	if input.ChangeBatch == nil || len(input.ChangeBatch.Changes) == 0 {
+		return nil, &route53types.InvalidInput{Message: awssdk.String("ChangeBatch must contain at least one change")}
+	}
+
+	// changes of a batch are applied atomically, so they are validated against a copy of the record sets.
+	recordSets := make(map[string]route53types.ResourceRecordSet, len(zoneState.recordSets))
+	for key, recordSet := range zoneState.recordSets {
+		recordSets[key] = recordSet
+	}
+	var errMessages []string
+	for _, change := range input.ChangeBatch.Changes {
+		if change.ResourceRecordSet == nil {
+			return nil, &route53types.InvalidInput{Message: awssdk.String("ResourceRecordSet must be specified")}
+		}
+		recordSet := *change.ResourceRecordSet
+		name := normalizeDNSName(awssdk.ToString(recordSet.Name))
+		if name != awssdk.ToString(zoneState.hostedZone.Name) && !strings.HasSuffix(name, "."+awssdk.ToString(zoneState.hostedZone.Name)) {
+			errMessages = append(errMessages, fmt.Sprintf("RRSet with DNS name %s is not permitted in zone %s", name, awssdk.ToString(zoneState.hostedZone.Name)))
+			continue
+		}
+		recordSet.Name = awssdk.String(name)
+		key := buildRecordSetKey(name, string(recordSet.Type))
+		existing, exists := recordSets[key]
+		switch change.Action {
+		case route53types.ChangeActionCreate:
+			if exists {
+				errMessages = append(errMessages, fmt.Sprintf("Tried to create resource record set [name='%s', type='%s'] but it already exists", name, recordSet.Type))
+				continue
+			}
+			recordSets[key] = recordSet
+		case route53types.ChangeActionUpsert:
+			recordSets[key] = recordSet
+		case route53types.ChangeActionDelete:
+			if !exists || !slices.Equal(buildRecordValues(existing), buildRecordValues(recordSet)) {
+				errMessages = append(errMessages, fmt.Sprintf("Tried to delete resource record set [name='%s', type='%s'] but it was not found", name, recordSet.Type))
+				continue
+			}
+			delete(recordSets, key)
+		default:
+			return nil, &route53types.InvalidInput{Message: awssdk.String(fmt.Sprintf("Invalid change action %s", change.Action))}
+		}
+	}
+	if len(errMessages) != 0 {
+		return nil, &route53types.InvalidChangeBatch{Message: awssdk.String(strings.Join(errMessages, ", ")), Messages: errMessages}
+	}
+	zoneState.recordSets = recordSets
+	return &route53sdk.ChangeResourceRecordSetsOutput{
+		ChangeInfo: &route53types.ChangeInfo{
+			Id:     awssdk.String("/change/" + fmt.Sprintf("C%020X", f.ids.nextCount("change"))),
+			Status: route53types.ChangeStatusInsync,
+		},
+	}, nil
+}
+
+// GetHostedZoneID returns the hosted zone with the longest name that domain belongs to.
+func (f *Route53) GetHostedZoneID(ctx context.Context, domain string) (*string, error) {
+	if err := f.simulation.call(ServiceRoute53, "ListHostedZones"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	name := normalizeDNSName(domain)
+	var bestID string
+	bestLen := -1
+	for _, zoneID := range sortedKeys(f.hostedZones) {
+		zoneName := awssdk.ToString(f.hostedZones[zoneID].hostedZone.Name)
+		if (name == zoneName || strings.HasSuffix(name, "."+zoneName)) && len(zoneName) > bestLen {
+			bestID = zoneID
+			bestLen = len(zoneName)
+		}
+	}
+	if bestLen < 0 {
+		return nil, fmt.Errorf("no hosted zone found for validation records")
+	}
+	return awssdk.String(bestID), nil
+}
+
+// normalizeDNSName returns name in lower case with a trailing dot, the way Route53 returns names.
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+func normalizeHostedZoneID(zoneID string) string {
+	return hostedZoneIDPrefix + strings.TrimPrefix(zoneID, hostedZoneIDPrefix)
+}
+
+func buildRecordSetKey(name string, recordType string) string {
+	return normalizeDNSName(name) + "/" + recordType
+}
+
+func buildRecordValues(recordSet route53types.ResourceRecordSet) []string {
+	var values []string
+	for _, record := range recordSet.ResourceRecords {
+		values = append(values, awssdk.ToString(record.Value))
+	}
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,263 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	shieldsdk "github.com/aws/aws-sdk-go-v2/service/shield"
+	shieldtypes "github.com/aws/aws-sdk-go-v2/service/shield/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.Shield = &Shield{}
+
+// Shield is a stateful in-memory implementation of services.Shield.
+// The account isn't subscribed to Shield Advanced unless SetSubscribed is called.
+type Shield struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+	accountID  string
+
+	subscribed       bool
+	protections      map[string]shieldtypes.Protection
+	protectionGroups map[string]shieldtypes.ProtectionGroup
+	tags             map[string][]shieldtypes.Tag
+}
+
+// NewShield constructs a new fake Shield without subscription.
+func NewShield(accountID string) *Shield {
+	return &Shield{
+		ids:              newIDGenerator(),
+		simulation:       NewSimulation(),
+		accountID:        accountID,
+		protections:      make(map[string]shieldtypes.Protection),
+		protectionGroups: make(map[string]shieldtypes.ProtectionGroup),
+		tags:             make(map[string][]shieldtypes.Tag),
+	}
+}
+
+// SetSubscribed sets whether the account is subscribed to Shield Advanced.
+func (f *Shield) SetSubscribed(subscribed bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.subscribed = subscribed
+}
+
+// ProtectionForResource returns the protection of resourceARN, if any.
+func (f *Shield) ProtectionForResource(resourceARN string) (shieldtypes.Protection, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.findProtectionByResource(resourceARN)
+}
+
+func (f *Shield) CreateProtectionWithContext(ctx context.Context, input *shieldsdk.CreateProtectionInput) (*shieldsdk.CreateProtectionOutput, error) {
+	if err := f.simulation.call(ServiceShield, "CreateProtection"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.subscribed {
+		return nil, &shieldtypes.ResourceNotFoundException{Message: awssdk.String("The subscription does not exist.")}
+	}
+	resourceARN := awssdk.ToString(input.ResourceArn)
+	if _, exists := f.findProtectionByResource(resourceARN); exists {
+		return nil, &shieldtypes.ResourceAlreadyExistsException{Message: awssdk.String(fmt.Sprintf("The referenced protection already exists for resource %s.", resourceARN))}
+	}
+	count := f.ids.nextCount("protection")
+	protectionID := fmt.Sprintf("%08x-0000-4000-8000-%012x", count, count)
+	protection := shieldtypes.Protection{
+		Id:            awssdk.String(protectionID),
+		Name:          input.Name,
+		ResourceArn:   input.ResourceArn,
+		ProtectionArn: awssdk.String(fmt.Sprintf("arn:aws:shield::%s:protection/%s", f.accountID, protectionID)),
+	}
+	f.protections[protectionID] = protection
+	return &shieldsdk.CreateProtectionOutput{ProtectionId: protection.Id}, nil
+}
+
+func (f *Shield) DeleteProtectionWithContext(ctx context.Context, input *shieldsdk.DeleteProtectionInput) (*shieldsdk.DeleteProtectionOutput, error) {
+	if err := f.simulation.call(ServiceShield, "DeleteProtection"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protectionID := awssdk.ToString(input.ProtectionId)
+	if _, exists := f.protections[protectionID]; !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	delete(f.protections, protectionID)
+	return &shieldsdk.DeleteProtectionOutput{}, nil
+}
+
+func (f *Shield) DescribeProtectionWithContext(ctx context.Context, input *shieldsdk.DescribeProtectionInput) (*shieldsdk.DescribeProtectionOutput, error) {
+	if err := f.simulation.call(ServiceShield, "DescribeProtection"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	if input.ProtectionId != nil && input.ResourceArn != nil {
+		return nil, &shieldtypes.InvalidParameterException{Message: awssdk.String("Only one of ProtectionId and ResourceArn can be specified.")}
+	}
+	var protection shieldtypes.Protection
+	var exists bool
+	if input.ProtectionId != nil {
+		protection, exists = f.protections[awssdk.ToString(input.ProtectionId)]
+	} else {
+		protection, exists = f.findProtectionByResource(awssdk.ToString(input.ResourceArn))
+	}
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	return &shieldsdk.DescribeProtectionOutput{Protection: &protection}, nil
+}
+
+func (f *Shield) GetSubscriptionStateWithContext(ctx context.Context, input *shieldsdk.GetSubscriptionStateInput) (*shieldsdk.GetSubscriptionStateOutput, error) {
+	if err := f.simulation.call(ServiceShield, "GetSubscriptionState"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	state := shieldtypes.SubscriptionStateInactive
+	if f.subscribed {
+		state = shieldtypes.SubscriptionStateActive
+	}
+	return &shieldsdk.GetSubscriptionStateOutput{SubscriptionState: state}, nil
+}
+
+func (f *Shield) AssociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.AssociateHealthCheckInput) (*shieldsdk.AssociateHealthCheckOutput, error) {
+	if err := f.simulation.call(ServiceShield, "AssociateHealthCheck"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protection, exists := f.protections[awssdk.ToString(input.ProtectionId)]
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	healthCheckID, err := parseHealthCheckARN(awssdk.ToString(input.HealthCheckArn))
+	if err != nil {
+		return nil, err
+	}
+	if len(protection.HealthCheckIds) != 0 {
+		return nil, &shieldtypes.LimitsExceededException{Message: awssdk.String("Only one health check can be associated with a protection.")}
+	}
+	protection.HealthCheckIds = []string{healthCheckID}
+	f.protections[awssdk.ToString(protection.Id)] = protection
+	return &shieldsdk.AssociateHealthCheckOutput{}, nil
+}
+
+func (f *Shield) DisassociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.DisassociateHealthCheckInput) (*shieldsdk.DisassociateHealthCheckOutput, error) {
+	if err := f.simulation.call(ServiceShield, "DisassociateHealthCheck"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protection, exists := f.protections[awssdk.ToString(input.ProtectionId)]
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	healthCheckID, err := parseHealthCheckARN(awssdk.ToString(input.HealthCheckArn))
+	if err != nil {
+		return nil, err
+	}
+	var healthCheckIDs []string
+	for _, id := range protection.HealthCheckIds {
+		if id != healthCheckID {
+			healthCheckIDs = append(healthCheckIDs, id)
+		}
+	}
+	protection.HealthCheckIds = healthCheckIDs
+	f.protections[awssdk.ToString(protection.Id)] = protection
+	return &shieldsdk.DisassociateHealthCheckOutput{}, nil
+}
+
+func (f *Shield) EnableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.EnableApplicationLayerAutomaticResponseInput) (*shieldsdk.EnableApplicationLayerAutomaticResponseOutput, error) {
+	if err := f.simulation.call(ServiceShield, "EnableApplicationLayerAutomaticResponse"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protection, exists := f.findProtectionByResource(awssdk.ToString(input.ResourceArn))
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	if isApplicationLayerAutomaticResponseEnabled(protection) {
+		return nil, &shieldtypes.InvalidOperationException{Message: awssdk.String("Application layer automatic response is already enabled.")}
+	}
+	protection.ApplicationLayerAutomaticResponseConfiguration = &shieldtypes.ApplicationLayerAutomaticResponseConfiguration{
+		Action: input.Action,
+		Status: shieldtypes.ApplicationLayerAutomaticResponseStatusEnabled,
+	}
+	f.protections[awssdk.ToString(protection.Id)] = protection
+	return &shieldsdk.EnableApplicationLayerAutomaticResponseOutput{}, nil
+}
+
+func (f *Shield) UpdateApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.UpdateApplicationLayerAutomaticResponseInput) (*shieldsdk.UpdateApplicationLayerAutomaticResponseOutput, error) {
+	if err := f.simulation.call(ServiceShield, "UpdateApplicationLayerAutomaticResponse"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protection, exists := f.findProtectionByResource(awssdk.ToString(input.ResourceArn))
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	if !isApplicationLayerAutomaticResponseEnabled(protection) {
+		return nil, &shieldtypes.InvalidOperationException{Message: awssdk.String("Application layer automatic response isn't enabled.")}
+	}
+	protection.ApplicationLayerAutomaticResponseConfiguration.Action = input.Action
+	f.protections[awssdk.ToString(protection.Id)] = protection
+	return &shieldsdk.UpdateApplicationLayerAutomaticResponseOutput{}, nil
+}
+
+func (f *Shield) DisableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.DisableApplicationLayerAutomaticResponseInput) (*shieldsdk.DisableApplicationLayerAutomaticResponseOutput, error) {
+	if err := f.simulation.call(ServiceShield, "DisableApplicationLayerAutomaticResponse"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protection, exists := f.findProtectionByResource(awssdk.ToString(input.ResourceArn))
+	if !exists {
+		return nil, newProtectionNotFoundError()
+	}
+	if !isApplicationLayerAutomaticResponseEnabled(protection) {
+		return nil, &shieldtypes.InvalidOperationException{Message: awssdk.String("Application layer automatic response isn't enabled.")}
+	}
+	protection.ApplicationLayerAutomaticResponseConfiguration = &shieldtypes.ApplicationLayerAutomaticResponseConfiguration{
+		Status: shieldtypes.ApplicationLayerAutomaticResponseStatusDisabled,
+	}
+	f.protections[awssdk.ToString(protection.Id)] = protection
+	return &shieldsdk.DisableApplicationLayerAutomaticResponseOutput{}, nil
+}
+
+func (f *Shield) findProtectionByResource(resourceARN string) (shieldtypes.Protection, bool) {
+	for _, protectionID := range sortedKeys(f.protections) {
+		protection := f.protections[protectionID]
+		if awssdk.ToString(protection.ResourceArn) == resourceARN {
+			return protection, true
+		}
+	}
+	return shieldtypes.Protection{}, false
+}
+
+func newProtectionNotFoundError() error {
+	return &shieldtypes.ResourceNotFoundException{Message: awssdk.String("The referenced protection does not exist.")}
+}
+
+func isApplicationLayerAutomaticResponseEnabled(protection shieldtypes.Protection) bool {
+	return protection.ApplicationLayerAutomaticResponseConfiguration != nil &&
+		protection.ApplicationLayerAutomaticResponseConfiguration.Status == shieldtypes.ApplicationLayerAutomaticResponseStatusEnabled
+}
+
+// parseHealthCheckARN returns the ID of a Route 53 health check ARN, e.g. "arn:aws:route53:::healthcheck/<id>".
+func parseHealthCheckARN(healthCheckARN string) (string, error) {
+	_, healthCheckID, found := strings.Cut(healthCheckARN, ":healthcheck/")
+	if !found || healthCheckID == "" {
+		return "", &shieldtypes.InvalidResourceException{Message: awssdk.String(fmt.Sprintf("Invalid health check ARN %s.", healthCheckARN))}
+	}
+	return healthCheckID, nil
+}
@@ -0,0 +1,157 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	shieldsdk "github.com/aws/aws-sdk-go-v2/service/shield"
+	shieldtypes "github.com/aws/aws-sdk-go-v2/service/shield/types"
+)
+
+// AddProtectionGroup adds a protection group, e.g. one managed outside the controller, and returns it.
+func (f *Shield) AddProtectionGroup(protectionGroupID string, pattern shieldtypes.ProtectionGroupPattern, members []string) shieldtypes.ProtectionGroup {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protectionGroup := shieldtypes.ProtectionGroup{
+		ProtectionGroupId:  awssdk.String(protectionGroupID),
+		ProtectionGroupArn: awssdk.String(f.protectionGroupARN(protectionGroupID)),
+		Aggregation:        shieldtypes.ProtectionGroupAggregationSum,
+		Pattern:            pattern,
+		Members:            members,
+	}
+	f.protectionGroups[protectionGroupID] = protectionGroup
+	return protectionGroup
+}
+
+// ProtectionGroup returns the protection group protectionGroupID, if any.
+func (f *Shield) ProtectionGroup(protectionGroupID string) (shieldtypes.ProtectionGroup, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	protectionGroup, exists := f.protectionGroups[protectionGroupID]
+	return protectionGroup, exists
+}
+
+func (f *Shield) CreateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.CreateProtectionGroupInput) (*shieldsdk.CreateProtectionGroupOutput, error) {
+	if err := f.simulation.call(ServiceShield, "CreateProtectionGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.subscribed {
+		return nil, &shieldtypes.ResourceNotFoundException{Message: awssdk.String("The subscription does not exist.")}
+	}
+	protectionGroupID := awssdk.ToString(input.ProtectionGroupId)
+	if _, exists := f.protectionGroups[protectionGroupID]; exists {
+		return nil, &shieldtypes.ResourceAlreadyExistsException{Message: awssdk.String(fmt.Sprintf("The referenced protection group %s already exists.", protectionGroupID))}
+	}
+	if err := validateProtectionGroupMembers(input.Pattern, input.Members); err != nil {
+		return nil, err
+	}
+	protectionGroupARN := f.protectionGroupARN(protectionGroupID)
+	f.protectionGroups[protectionGroupID] = shieldtypes.ProtectionGroup{
+		ProtectionGroupId:  input.ProtectionGroupId,
+		ProtectionGroupArn: awssdk.String(protectionGroupARN),
+		Aggregation:        input.Aggregation,
+		Pattern:            input.Pattern,
+		ResourceType:       input.ResourceType,
+		Members:            slices.Clone(input.Members),
+	}
+	f.tags[protectionGroupARN] = slices.Clone(input.Tags)
+	return &shieldsdk.CreateProtectionGroupOutput{}, nil
+}
+
+func (f *Shield) UpdateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.UpdateProtectionGroupInput) (*shieldsdk.UpdateProtectionGroupOutput, error) {
+	if err := f.simulation.call(ServiceShield, "UpdateProtectionGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protectionGroupID := awssdk.ToString(input.ProtectionGroupId)
+	protectionGroup, exists := f.protectionGroups[protectionGroupID]
+	if !exists {
+		return nil, newProtectionGroupNotFoundError()
+	}
+	if err := validateProtectionGroupMembers(input.Pattern, input.Members); err != nil {
+		return nil, err
+	}
+	protectionGroup.Aggregation = input.Aggregation
+	protectionGroup.Pattern = input.Pattern
+	protectionGroup.ResourceType = input.ResourceType
+	protectionGroup.Members = slices.Clone(input.Members)
+	f.protectionGroups[protectionGroupID] = protectionGroup
+	return &shieldsdk.UpdateProtectionGroupOutput{}, nil
+}
+
+func (f *Shield) DeleteProtectionGroupWithContext(ctx context.Context, input *shieldsdk.DeleteProtectionGroupInput) (*shieldsdk.DeleteProtectionGroupOutput, error) {
+	if err := f.simulation.call(ServiceShield, "DeleteProtectionGroup"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	protectionGroupID := awssdk.ToString(input.ProtectionGroupId)
+	protectionGroup, exists := f.protectionGroups[protectionGroupID]
+	if !exists {
+		return nil, newProtectionGroupNotFoundError()
+	}
+	delete(f.protectionGroups, protectionGroupID)
+	delete(f.tags, awssdk.ToString(protectionGroup.ProtectionGroupArn))
+	return &shieldsdk.DeleteProtectionGroupOutput{}, nil
+}
+
+func (f *Shield) ListProtectionGroupsAsList(ctx context.Context, input *shieldsdk.ListProtectionGroupsInput) ([]shieldtypes.ProtectionGroup, error) {
+	if err := f.simulation.call(ServiceShield, "ListProtectionGroups"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	var protectionGroups []shieldtypes.ProtectionGroup
+	for _, protectionGroupID := range sortedKeys(f.protectionGroups) {
+		protectionGroup := f.protectionGroups[protectionGroupID]
+		if filters := input.InclusionFilters; filters != nil {
+			if len(filters.ProtectionGroupIds) != 0 && !slices.Contains(filters.ProtectionGroupIds, protectionGroupID) {
+				continue
+			}
+			if len(filters.Patterns) != 0 && !slices.Contains(filters.Patterns, protectionGroup.Pattern) {
+				continue
+			}
+		}
+		protectionGroup.Members = slices.Clone(protectionGroup.Members)
+		protectionGroups = append(protectionGroups, protectionGroup)
+	}
+	return protectionGroups, nil
+}
+
+func (f *Shield) ListTagsForResourceWithContext(ctx context.Context, input *shieldsdk.ListTagsForResourceInput) (*shieldsdk.ListTagsForResourceOutput, error) {
+	if err := f.simulation.call(ServiceShield, "ListTagsForResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	resourceARN := awssdk.ToString(input.ResourceARN)
+	for _, protectionGroup := range f.protectionGroups {
+		if awssdk.ToString(protectionGroup.ProtectionGroupArn) == resourceARN {
+			return &shieldsdk.ListTagsForResourceOutput{Tags: slices.Clone(f.tags[resourceARN])}, nil
+		}
+	}
+	return nil, &shieldtypes.ResourceNotFoundException{Message: awssdk.String(fmt.Sprintf("The referenced resource %s does not exist.", resourceARN))}
+}
+
+func (f *Shield) protectionGroupARN(protectionGroupID string) string {
+	return fmt.Sprintf("arn:aws:shield::%s:protection-group/%s", f.accountID, protectionGroupID)
+}
+
+func validateProtectionGroupMembers(pattern shieldtypes.ProtectionGroupPattern, members []string) error {
+	if pattern == shieldtypes.ProtectionGroupPatternArbitrary && len(members) == 0 {
+		return &shieldtypes.InvalidParameterException{Message: awssdk.String("Members must be specified for the ARBITRARY pattern.")}
+	}
+	if pattern != shieldtypes.ProtectionGroupPatternArbitrary && len(members) != 0 {
+		return &shieldtypes.InvalidParameterException{Message: awssdk.String("Members can only be specified for the ARBITRARY pattern.")}
+	}
+	return nil
+}
+
+func newProtectionGroupNotFoundError() error {
+	return &shieldtypes.ResourceNotFoundException{Message: awssdk.String("The referenced protection group does not exist.")}
+}
@@ -0,0 +1,195 @@
+package fake
+
+import (
+	"sync"
+)
+
+// Service names used to target injected faults.
+const (
+	ServiceEC2               = "ec2"
+	ServiceELBV2             = "elasticloadbalancing"
+	ServiceACM               = "acm"
+	ServiceWAFv2             = "wafv2"
+	ServiceShield            = "shield"
+	ServiceGlobalAccelerator = "globalaccelerator"
+	ServiceRoute53           = "route53"
+	ServiceRGT               = "tagging"
+)
+
+// throttlingErrorCodes are the error codes each service returns when requests are throttled.
+var throttlingErrorCodes = map[string]string{
+	ServiceEC2:     "RequestLimitExceeded",
+	ServiceELBV2:   "Throttling",
+	ServiceRoute53: "Throttling",
+}
+
+// Quotas are the service quotas enforced by the fakes.
+type Quotas struct {
+	// LoadBalancers is the number of load balancers per region.
+	LoadBalancers int
+	// TargetGroups is the number of target groups per region.
+	TargetGroups int
+	// ListenersPerLoadBalancer is the number of listeners per load balancer.
+	ListenersPerLoadBalancer int
+	// RulesPerListener is the number of rules per listener, excluding the default rule.
+	RulesPerListener int
+	// TargetsPerTargetGroup is the number of targets registered to a target group.
+	TargetsPerTargetGroup int
+	// SecurityGroupsPerVPC is the number of security groups per VPC.
+	SecurityGroupsPerVPC int
+	// InboundRulesPerSecurityGroup is the number of inbound rules per security group.
+	InboundRulesPerSecurityGroup int
+	// Certificates is the number of ACM certificates per region.
+	Certificates int
+	// Accelerators is the number of accelerators per account.
+	Accelerators int
+	// ListenersPerAccelerator is the number of listeners per accelerator.
+	ListenersPerAccelerator int
+	// EndpointsPerEndpointGroup is the number of endpoints per endpoint group.
+	EndpointsPerEndpointGroup int
+}
+
+// DefaultQuotas returns the default service quotas of an AWS account.
+func DefaultQuotas() Quotas {
+	return Quotas{
+		LoadBalancers:                50,
+		TargetGroups:                 3000,
+		ListenersPerLoadBalancer:     50,
+		RulesPerListener:             100,
+		TargetsPerTargetGroup:        1000,
+		SecurityGroupsPerVPC:         2500,
+		InboundRulesPerSecurityGroup: 60,
+		Certificates:                 2500,
+		Accelerators:                 20,
+		ListenersPerAccelerator:      10,
+		EndpointsPerEndpointGroup:    10,
+	}
+}
+
+// Simulation controls the behaviors of the fakes that are hard to reproduce against AWS:
+// injected errors and throttling, service quotas, and eventual consistency of newly created resources.
+// A Simulation is shared by all fakes of a Cloud, and is safe for concurrent use.
+type Simulation struct {
+	mutex  sync.Mutex
+	faults []*fault
+	quotas Quotas
+	// consistencyDelay is the number of describe calls for which a newly created resource isn't visible.
+	consistencyDelay int
+	// pendingResources are the newly created resources that aren't visible yet, with the number of describe calls left to hide them.
+	pendingResources map[string]int
+}
+
+type fault struct {
+	service   string
+	operation string
+	err       error
+	// remaining is the number of calls left to fail, or negative to fail until the faults are cleared.
+	remaining int
+}
+
+// NewSimulation constructs a new Simulation with the default quotas, and without faults or consistency delay.
+func NewSimulation() *Simulation {
+	return &Simulation{
+		quotas:           DefaultQuotas(),
+		pendingResources: make(map[string]int),
+	}
+}
+
+// InjectError fails the next times calls of operation of service with err, e.g. ("elasticloadbalancing", "CreateLoadBalancer").
+// An empty operation matches all operations of service. If times isn't positive, calls fail until ClearFaults is called.
+func (s *Simulation) InjectError(service string, operation string, err error, times int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if times <= 0 {
+		times = -1
+	}
+	s.faults = append(s.faults, &fault{
+		service:   service,
+		operation: operation,
+		err:       err,
+		remaining: times,
+	})
+}
+
+// Throttle throttles the next times calls of operation of service, with the error code the service returns when throttling.
+// The fakes have no retry middleware, so each throttled call returns the error to its caller, which has to retry it.
+func (s *Simulation) Throttle(service string, operation string, times int) {
+	code, exists := throttlingErrorCodes[service]
+	if !exists {
+		code = "ThrottlingException"
+	}
+	s.InjectError(service, operation, newAPIError(code, "Rate exceeded"), times)
+}
+
+// ClearFaults removes all injected errors.
+func (s *Simulation) ClearFaults() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = nil
+}
+
+// SetQuotas sets the service quotas enforced by the fakes.
+func (s *Simulation) SetQuotas(quotas Quotas) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.quotas = quotas
+}
+
+// Quotas returns the service quotas enforced by the fakes.
+func (s *Simulation) Quotas() Quotas {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.quotas
+}
+
+// SetConsistencyDelay hides the resources created afterward from that many describe calls,
+// the way reads lag behind writes in AWS APIs. Lookups by ID fail with the not found error of the service meanwhile.
+func (s *Simulation) SetConsistencyDelay(calls int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.consistencyDelay = calls
+}
+
+// call returns the error injected for a call of operation of service, if any.
+func (s *Simulation) call(service string, operation string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, f := range s.faults {
+		if f.service != service || (f.operation != "" && f.operation != operation) {
+			continue
+		}
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f.err
+	}
+	return nil
+}
+
+// created records the creation of resourceID, hiding it from describe calls for the consistency delay.
+func (s *Simulation) created(resourceID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.consistencyDelay > 0 {
+		s.pendingResources[resourceID] = s.consistencyDelay
+	}
+}
+
+// visible checks whether resourceID is visible to a describe call, counting the call against its consistency delay.
+func (s *Simulation) visible(resourceID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	remaining, pending := s.pendingResources[resourceID]
+	if !pending {
+		return true
+	}
+	if remaining <= 1 {
+		delete(s.pendingResources, resourceID)
+	} else {
+		s.pendingResources[resourceID] = remaining - 1
+	}
+	return false
+}
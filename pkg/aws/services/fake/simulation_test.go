@@ -0,0 +1,62 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulation_faults(t *testing.T) {
+	simulation := NewSimulation()
+	injectedErr := errors.New("injected")
+	simulation.InjectError(ServiceELBV2, "CreateLoadBalancer", injectedErr, 2)
+	simulation.Throttle(ServiceEC2, "", 1)
+
+	assert.NoError(t, simulation.call(ServiceELBV2, "DescribeLoadBalancers"))
+	assert.Equal(t, injectedErr, simulation.call(ServiceELBV2, "CreateLoadBalancer"))
+	assert.Equal(t, injectedErr, simulation.call(ServiceELBV2, "CreateLoadBalancer"))
+	assert.NoError(t, simulation.call(ServiceELBV2, "CreateLoadBalancer"))
+
+	assertAPIErrorCode(t, "RequestLimitExceeded", simulation.call(ServiceEC2, "DescribeSubnets"))
+	assert.NoError(t, simulation.call(ServiceEC2, "DescribeSubnets"))
+
+	simulation.Throttle(ServiceACM, "ListCertificates", 0)
+	for i := 0; i < 3; i++ {
+		assertAPIErrorCode(t, "ThrottlingException", simulation.call(ServiceACM, "ListCertificates"))
+	}
+	simulation.ClearFaults()
+	assert.NoError(t, simulation.call(ServiceACM, "ListCertificates"))
+}
+
+func TestSimulation_quotasAndConsistency(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t)
+	simulation := network.cloud.Simulation()
+	quotas := DefaultQuotas()
+	quotas.LoadBalancers = 1
+	simulation.SetQuotas(quotas)
+	simulation.SetConsistencyDelay(2)
+
+	lb := network.createALB(t, "k8s-default-first")
+	_, err := network.cloud.ELBV2().CreateLoadBalancerWithContext(ctx, &elbv2sdk.CreateLoadBalancerInput{
+		Name:    awssdk.String("k8s-default-second"),
+		Subnets: network.subnets,
+	})
+	var tooManyErr *elbv2types.TooManyLoadBalancersException
+	assert.ErrorAs(t, err, &tooManyErr)
+
+	describeInput := &elbv2sdk.DescribeLoadBalancersInput{LoadBalancerArns: []string{awssdk.ToString(lb.LoadBalancerArn)}}
+	for i := 0; i < 2; i++ {
+		_, err = network.cloud.ELBV2().DescribeLoadBalancersAsList(ctx, describeInput)
+		var notFoundErr *elbv2types.LoadBalancerNotFoundException
+		assert.ErrorAs(t, err, &notFoundErr)
+	}
+	lbs, err := network.cloud.ELBV2().DescribeLoadBalancersAsList(ctx, describeInput)
+	assert.NoError(t, err)
+	assert.Len(t, lbs, 1)
+}
@@ -0,0 +1,192 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2sdk "github.com/aws/aws-sdk-go-v2/service/wafv2"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+var _ services.WAFv2 = &WAFv2{}
+
+const defaultWebACLPageSize = 100
+
+// WAFv2 is a stateful in-memory implementation of services.WAFv2 for regional web ACLs and IP sets.
+// Web ACLs are seeded with AddWebACL or created through CreateWebACL.
+type WAFv2 struct {
+	mutex      sync.RWMutex
+	ids        *idGenerator
+	simulation *Simulation
+	region     string
+	accountID  string
+
+	webACLs map[string]wafv2types.WebACLSummary
+	// webACLDetails are the rules and settings of the web ACLs, keyed by web ACL ARN.
+	webACLDetails map[string]wafv2types.WebACL
+	ipSets        map[string]wafv2types.IPSet
+	// ipSetLockTokens are the lock tokens of the IP sets, keyed by IP set ARN.
+	ipSetLockTokens map[string]string
+	// tags are the tags of web ACLs and IP sets, keyed by ARN.
+	tags map[string]map[string]string
+	// associations are the web ACLs associated with resources, keyed by resource ARN.
+	associations map[string]string
+
+	// resourceExists reports whether a resource web ACLs can be associated with exists, such as a load balancer.
+	resourceExists func(resourceARN string) bool
+}
+
+// NewWAFv2 constructs a new fake WAFv2 without web ACLs.
+func NewWAFv2(region string, accountID string) *WAFv2 {
+	return &WAFv2{
+		ids:             newIDGenerator(),
+		simulation:      NewSimulation(),
+		region:          region,
+		accountID:       accountID,
+		webACLs:         make(map[string]wafv2types.WebACLSummary),
+		webACLDetails:   make(map[string]wafv2types.WebACL),
+		ipSets:          make(map[string]wafv2types.IPSet),
+		ipSetLockTokens: make(map[string]string),
+		tags:            make(map[string]map[string]string),
+		associations:    make(map[string]string),
+	}
+}
+
+// AddWebACL seeds a regional web ACL named name, without rules.
+func (f *WAFv2) AddWebACL(name string) wafv2types.WebACLSummary {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.addWebACL(wafv2types.WebACL{
+		Name:          awssdk.String(name),
+		DefaultAction: &wafv2types.DefaultAction{Allow: &wafv2types.AllowAction{}},
+	})
+}
+
+// addWebACL stores webACL with a new ARN and ID, the caller must hold the mutex.
+func (f *WAFv2) addWebACL(webACL wafv2types.WebACL) wafv2types.WebACLSummary {
+	id := f.nextUUID("webacl")
+	webACL.Id = awssdk.String(id)
+	webACL.ARN = awssdk.String(fmt.Sprintf("arn:aws:wafv2:%s:%s:regional/webacl/%s/%s", f.region, f.accountID, awssdk.ToString(webACL.Name), id))
+	webACL.Capacity = webACLCapacity(webACL.Rules)
+	summary := wafv2types.WebACLSummary{
+		ARN:         webACL.ARN,
+		Id:          webACL.Id,
+		Name:        webACL.Name,
+		Description: webACL.Description,
+		LockToken:   awssdk.String(f.ids.nextHex("locktoken")),
+	}
+	f.webACLs[awssdk.ToString(webACL.ARN)] = summary
+	f.webACLDetails[awssdk.ToString(webACL.ARN)] = webACL
+	f.tags[awssdk.ToString(webACL.ARN)] = make(map[string]string)
+	return summary
+}
+
+// nextUUID returns the next ID for prefix in the UUID format used by WAFv2.
+func (f *WAFv2) nextUUID(prefix string) string {
+	count := f.ids.nextCount(prefix)
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", count, count)
+}
+
+// AssociatedWebACL returns the ARN of the web ACL associated with resourceARN, if any.
+func (f *WAFv2) AssociatedWebACL(resourceARN string) (string, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	webACLARN, exists := f.associations[resourceARN]
+	return webACLARN, exists && f.isResourceAlive(resourceARN)
+}
+
+func (f *WAFv2) AssociateWebACLWithContext(ctx context.Context, input *wafv2sdk.AssociateWebACLInput) (*wafv2sdk.AssociateWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "AssociateWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	webACLARN := awssdk.ToString(input.WebACLArn)
+	if _, exists := f.webACLs[webACLARN]; !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	resourceARN := awssdk.ToString(input.ResourceArn)
+	if !f.isResourceAlive(resourceARN) {
+		return nil, newWebACLNotFoundError()
+	}
+	f.associations[resourceARN] = webACLARN
+	return &wafv2sdk.AssociateWebACLOutput{}, nil
+}
+
+func (f *WAFv2) DisassociateWebACLWithContext(ctx context.Context, req *wafv2sdk.DisassociateWebACLInput) (*wafv2sdk.DisassociateWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "DisassociateWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.associations, awssdk.ToString(req.ResourceArn))
+	return &wafv2sdk.DisassociateWebACLOutput{}, nil
+}
+
+func (f *WAFv2) GetWebACLForResourceWithContext(ctx context.Context, req *wafv2sdk.GetWebACLForResourceInput) (*wafv2sdk.GetWebACLForResourceOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "GetWebACLForResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	resourceARN := awssdk.ToString(req.ResourceArn)
+	if !f.isResourceAlive(resourceARN) {
+		return nil, newWebACLNotFoundError()
+	}
+	webACLARN, exists := f.associations[resourceARN]
+	if !exists {
+		return &wafv2sdk.GetWebACLForResourceOutput{}, nil
+	}
+	webACL := f.webACLs[webACLARN]
+	return &wafv2sdk.GetWebACLForResourceOutput{
+		WebACL: &wafv2types.WebACL{
+			ARN:  webACL.ARN,
+			Id:   webACL.Id,
+			Name: webACL.Name,
+		},
+	}, nil
+}
+
+// ListWebACLsWithContext lists the web ACLs in pages of Limit web ACLs, regional web ACLs only.
+func (f *WAFv2) ListWebACLsWithContext(ctx context.Context, req *wafv2sdk.ListWebACLsInput) (*wafv2sdk.ListWebACLsOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "ListWebACLs"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	if req.Scope != wafv2types.ScopeRegional {
+		return &wafv2sdk.ListWebACLsOutput{}, nil
+	}
+	start := 0
+	if req.NextMarker != nil {
+		var err error
+		if start, err = strconv.Atoi(awssdk.ToString(req.NextMarker)); err != nil {
+			return nil, &wafv2types.WAFInvalidParameterException{Message: awssdk.String("Invalid NextMarker")}
+		}
+	}
+	limit := int(awssdk.ToInt32(req.Limit))
+	if limit == 0 {
+		limit = defaultWebACLPageSize
+	}
+	webACLARNs := sortedKeys(f.webACLs)
+	output := &wafv2sdk.ListWebACLsOutput{}
+	for i := start; i < len(webACLARNs) && i < start+limit; i++ {
+		output.WebACLs = append(output.WebACLs, f.webACLs[webACLARNs[i]])
+	}
+	if start+limit < len(webACLARNs) {
+		output.NextMarker = awssdk.String(strconv.Itoa(start + limit))
+	}
+	return output, nil
+}
+
+func (f *WAFv2) isResourceAlive(resourceARN string) bool {
+	return f.resourceExists == nil || f.resourceExists(resourceARN)
+}
+
+func newWebACLNotFoundError() error {
+	return &wafv2types.WAFNonexistentItemException{Message: awssdk.String("AWS WAF couldn’t perform the operation because your resource doesn’t exist.")}
+}
@@ -0,0 +1,461 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2sdk "github.com/aws/aws-sdk-go-v2/service/wafv2"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+const (
+	// approximate capacity of rules, the real capacity of managed rule groups depends on the rule group.
+	managedRuleGroupCapacity = 100
+	rateBasedRuleCapacity    = 2
+	ipSetReferenceCapacity   = 1
+)
+
+// WebACL returns the web ACL with webACLARN, if any.
+func (f *WAFv2) WebACL(webACLARN string) (wafv2types.WebACL, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	webACL, exists := f.webACLDetails[webACLARN]
+	return webACL, exists
+}
+
+// IPSet returns the IP set with ipSetARN, if any.
+func (f *WAFv2) IPSet(ipSetARN string) (wafv2types.IPSet, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	ipSet, exists := f.ipSets[ipSetARN]
+	return ipSet, exists
+}
+
+// Tags returns the tags of the web ACL or IP set with resourceARN.
+func (f *WAFv2) Tags(resourceARN string) map[string]string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	tags := make(map[string]string, len(f.tags[resourceARN]))
+	for key, value := range f.tags[resourceARN] {
+		tags[key] = value
+	}
+	return tags
+}
+
+// Associate associates the web ACL with resourceARN, as the association synthesizer of a load balancer would.
+func (f *WAFv2) Associate(webACLARN string, resourceARN string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.associations[resourceARN] = webACLARN
+}
+
+func (f *WAFv2) CreateWebACLWithContext(ctx context.Context, input *wafv2sdk.CreateWebACLInput) (*wafv2sdk.CreateWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "CreateWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if input.Scope != wafv2types.ScopeRegional {
+		return nil, newWAFInvalidParameterError("Scope")
+	}
+	for _, webACL := range f.webACLs {
+		if awssdk.ToString(webACL.Name) == awssdk.ToString(input.Name) {
+			return nil, &wafv2types.WAFDuplicateItemException{Message: awssdk.String("AWS WAF couldn’t perform the operation because some resource in your request is a duplicate of an existing one.")}
+		}
+	}
+	if err := f.validateRules(input.Rules); err != nil {
+		return nil, err
+	}
+	summary := f.addWebACL(wafv2types.WebACL{
+		Name:             input.Name,
+		Description:      input.Description,
+		DefaultAction:    input.DefaultAction,
+		VisibilityConfig: input.VisibilityConfig,
+		Rules:            input.Rules,
+	})
+	f.tags[awssdk.ToString(summary.ARN)] = buildWAFv2TagMap(input.Tags)
+	f.simulation.created(awssdk.ToString(summary.Id))
+	return &wafv2sdk.CreateWebACLOutput{Summary: &summary}, nil
+}
+
+func (f *WAFv2) GetWebACLWithContext(ctx context.Context, input *wafv2sdk.GetWebACLInput) (*wafv2sdk.GetWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "GetWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	summary, exists := f.findWebACL(awssdk.ToString(input.Id))
+	if !exists || !f.simulation.visible(awssdk.ToString(summary.Id)) {
+		return nil, newWebACLNotFoundError()
+	}
+	webACL := f.webACLDetails[awssdk.ToString(summary.ARN)]
+	return &wafv2sdk.GetWebACLOutput{
+		WebACL:    &webACL,
+		LockToken: summary.LockToken,
+	}, nil
+}
+
+func (f *WAFv2) UpdateWebACLWithContext(ctx context.Context, input *wafv2sdk.UpdateWebACLInput) (*wafv2sdk.UpdateWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "UpdateWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	summary, exists := f.findWebACL(awssdk.ToString(input.Id))
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	if awssdk.ToString(input.LockToken) != awssdk.ToString(summary.LockToken) {
+		return nil, newWAFOptimisticLockError()
+	}
+	if err := f.validateRules(input.Rules); err != nil {
+		return nil, err
+	}
+	webACLARN := awssdk.ToString(summary.ARN)
+	webACL := f.webACLDetails[webACLARN]
+	webACL.Description = input.Description
+	webACL.DefaultAction = input.DefaultAction
+	webACL.VisibilityConfig = input.VisibilityConfig
+	webACL.Rules = input.Rules
+	webACL.Capacity = webACLCapacity(input.Rules)
+	f.webACLDetails[webACLARN] = webACL
+	summary.Description = input.Description
+	summary.LockToken = awssdk.String(f.ids.nextHex("locktoken"))
+	f.webACLs[webACLARN] = summary
+	return &wafv2sdk.UpdateWebACLOutput{NextLockToken: summary.LockToken}, nil
+}
+
+func (f *WAFv2) DeleteWebACLWithContext(ctx context.Context, input *wafv2sdk.DeleteWebACLInput) (*wafv2sdk.DeleteWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "DeleteWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	summary, exists := f.findWebACL(awssdk.ToString(input.Id))
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	if awssdk.ToString(input.LockToken) != awssdk.ToString(summary.LockToken) {
+		return nil, newWAFOptimisticLockError()
+	}
+	webACLARN := awssdk.ToString(summary.ARN)
+	if len(f.resourcesForWebACL(webACLARN)) != 0 {
+		return nil, &wafv2types.WAFAssociatedItemException{Message: awssdk.String("AWS WAF couldn’t perform the operation because your resource is being used by another resource or it’s associated with another resource.")}
+	}
+	delete(f.webACLs, webACLARN)
+	delete(f.webACLDetails, webACLARN)
+	delete(f.tags, webACLARN)
+	return &wafv2sdk.DeleteWebACLOutput{}, nil
+}
+
+func (f *WAFv2) ListResourcesForWebACLWithContext(ctx context.Context, input *wafv2sdk.ListResourcesForWebACLInput) (*wafv2sdk.ListResourcesForWebACLOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "ListResourcesForWebACL"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	webACLARN := awssdk.ToString(input.WebACLArn)
+	if _, exists := f.webACLs[webACLARN]; !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	return &wafv2sdk.ListResourcesForWebACLOutput{ResourceArns: f.resourcesForWebACL(webACLARN)}, nil
+}
+
+func (f *WAFv2) CreateIPSetWithContext(ctx context.Context, input *wafv2sdk.CreateIPSetInput) (*wafv2sdk.CreateIPSetOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "CreateIPSet"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if input.Scope != wafv2types.ScopeRegional {
+		return nil, newWAFInvalidParameterError("Scope")
+	}
+	for _, ipSet := range f.ipSets {
+		if awssdk.ToString(ipSet.Name) == awssdk.ToString(input.Name) {
+			return nil, &wafv2types.WAFDuplicateItemException{Message: awssdk.String("AWS WAF couldn’t perform the operation because some resource in your request is a duplicate of an existing one.")}
+		}
+	}
+	id := f.nextUUID("ipset")
+	ipSet := wafv2types.IPSet{
+		ARN:              awssdk.String(fmt.Sprintf("arn:aws:wafv2:%s:%s:regional/ipset/%s/%s", f.region, f.accountID, awssdk.ToString(input.Name), id)),
+		Id:               awssdk.String(id),
+		Name:             input.Name,
+		Description:      input.Description,
+		IPAddressVersion: input.IPAddressVersion,
+		Addresses:        input.Addresses,
+	}
+	ipSetARN := awssdk.ToString(ipSet.ARN)
+	f.ipSets[ipSetARN] = ipSet
+	f.ipSetLockTokens[ipSetARN] = f.ids.nextHex("locktoken")
+	f.tags[ipSetARN] = buildWAFv2TagMap(input.Tags)
+	return &wafv2sdk.CreateIPSetOutput{
+		Summary: &wafv2types.IPSetSummary{
+			ARN:         ipSet.ARN,
+			Id:          ipSet.Id,
+			Name:        ipSet.Name,
+			Description: ipSet.Description,
+			LockToken:   awssdk.String(f.ipSetLockTokens[ipSetARN]),
+		},
+	}, nil
+}
+
+func (f *WAFv2) GetIPSetWithContext(ctx context.Context, input *wafv2sdk.GetIPSetInput) (*wafv2sdk.GetIPSetOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "GetIPSet"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	ipSet, exists := f.findIPSet(awssdk.ToString(input.Id))
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	return &wafv2sdk.GetIPSetOutput{
+		IPSet:     &ipSet,
+		LockToken: awssdk.String(f.ipSetLockTokens[awssdk.ToString(ipSet.ARN)]),
+	}, nil
+}
+
+func (f *WAFv2) UpdateIPSetWithContext(ctx context.Context, input *wafv2sdk.UpdateIPSetInput) (*wafv2sdk.UpdateIPSetOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "UpdateIPSet"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	ipSet, exists := f.findIPSet(awssdk.ToString(input.Id))
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	ipSetARN := awssdk.ToString(ipSet.ARN)
+	if awssdk.ToString(input.LockToken) != f.ipSetLockTokens[ipSetARN] {
+		return nil, newWAFOptimisticLockError()
+	}
+	ipSet.Addresses = input.Addresses
+	ipSet.Description = input.Description
+	f.ipSets[ipSetARN] = ipSet
+	f.ipSetLockTokens[ipSetARN] = f.ids.nextHex("locktoken")
+	return &wafv2sdk.UpdateIPSetOutput{NextLockToken: awssdk.String(f.ipSetLockTokens[ipSetARN])}, nil
+}
+
+func (f *WAFv2) DeleteIPSetWithContext(ctx context.Context, input *wafv2sdk.DeleteIPSetInput) (*wafv2sdk.DeleteIPSetOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "DeleteIPSet"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	ipSet, exists := f.findIPSet(awssdk.ToString(input.Id))
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	ipSetARN := awssdk.ToString(ipSet.ARN)
+	if awssdk.ToString(input.LockToken) != f.ipSetLockTokens[ipSetARN] {
+		return nil, newWAFOptimisticLockError()
+	}
+	for _, webACL := range f.webACLDetails {
+		if referencesIPSet(webACL.Rules, ipSetARN) {
+			return nil, &wafv2types.WAFAssociatedItemException{Message: awssdk.String("AWS WAF couldn’t perform the operation because your resource is being used by another resource or it’s associated with another resource.")}
+		}
+	}
+	delete(f.ipSets, ipSetARN)
+	delete(f.ipSetLockTokens, ipSetARN)
+	delete(f.tags, ipSetARN)
+	return &wafv2sdk.DeleteIPSetOutput{}, nil
+}
+
+// ListIPSetsWithContext lists the IP sets in pages of Limit IP sets, regional IP sets only.
+func (f *WAFv2) ListIPSetsWithContext(ctx context.Context, input *wafv2sdk.ListIPSetsInput) (*wafv2sdk.ListIPSetsOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "ListIPSets"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	if input.Scope != wafv2types.ScopeRegional {
+		return &wafv2sdk.ListIPSetsOutput{}, nil
+	}
+	start := 0
+	if input.NextMarker != nil {
+		var err error
+		if start, err = strconv.Atoi(awssdk.ToString(input.NextMarker)); err != nil {
+			return nil, newWAFInvalidParameterError("NextMarker")
+		}
+	}
+	limit := int(awssdk.ToInt32(input.Limit))
+	if limit == 0 {
+		limit = defaultWebACLPageSize
+	}
+	ipSetARNs := sortedKeys(f.ipSets)
+	output := &wafv2sdk.ListIPSetsOutput{}
+	for i := start; i < len(ipSetARNs) && i < start+limit; i++ {
+		ipSet := f.ipSets[ipSetARNs[i]]
+		output.IPSets = append(output.IPSets, wafv2types.IPSetSummary{
+			ARN:         ipSet.ARN,
+			Id:          ipSet.Id,
+			Name:        ipSet.Name,
+			Description: ipSet.Description,
+			LockToken:   awssdk.String(f.ipSetLockTokens[ipSetARNs[i]]),
+		})
+	}
+	if start+limit < len(ipSetARNs) {
+		output.NextMarker = awssdk.String(strconv.Itoa(start + limit))
+	}
+	return output, nil
+}
+
+func (f *WAFv2) TagResourceWithContext(ctx context.Context, input *wafv2sdk.TagResourceInput) (*wafv2sdk.TagResourceOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "TagResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	resourceARN := awssdk.ToString(input.ResourceARN)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	for key, value := range buildWAFv2TagMap(input.Tags) {
+		tags[key] = value
+	}
+	return &wafv2sdk.TagResourceOutput{}, nil
+}
+
+func (f *WAFv2) UntagResourceWithContext(ctx context.Context, input *wafv2sdk.UntagResourceInput) (*wafv2sdk.UntagResourceOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "UntagResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	resourceARN := awssdk.ToString(input.ResourceARN)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	for _, key := range input.TagKeys {
+		delete(tags, key)
+	}
+	return &wafv2sdk.UntagResourceOutput{}, nil
+}
+
+func (f *WAFv2) ListTagsForResourceWithContext(ctx context.Context, input *wafv2sdk.ListTagsForResourceInput) (*wafv2sdk.ListTagsForResourceOutput, error) {
+	if err := f.simulation.call(ServiceWAFv2, "ListTagsForResource"); err != nil {
+		return nil, err
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	resourceARN := awssdk.ToString(input.ResourceARN)
+	tags, exists := f.tags[resourceARN]
+	if !exists {
+		return nil, newWebACLNotFoundError()
+	}
+	tagInfo := &wafv2types.TagInfoForResource{ResourceARN: input.ResourceARN}
+	for _, key := range sortedKeys(tags) {
+		tagInfo.TagList = append(tagInfo.TagList, wafv2types.Tag{Key: awssdk.String(key), Value: awssdk.String(tags[key])})
+	}
+	return &wafv2sdk.ListTagsForResourceOutput{TagInfoForResource: tagInfo}, nil
+}
+
+func (f *WAFv2) findWebACL(id string) (wafv2types.WebACLSummary, bool) {
+	for _, webACL := range f.webACLs {
+		if awssdk.ToString(webACL.Id) == id {
+			return webACL, true
+		}
+	}
+	return wafv2types.WebACLSummary{}, false
+}
+
+func (f *WAFv2) findIPSet(id string) (wafv2types.IPSet, bool) {
+	for _, ipSet := range f.ipSets {
+		if awssdk.ToString(ipSet.Id) == id {
+			return ipSet, true
+		}
+	}
+	return wafv2types.IPSet{}, false
+}
+
+// resourcesForWebACL returns the ARNs of the live resources associated with the web ACL, the caller must hold the mutex.
+func (f *WAFv2) resourcesForWebACL(webACLARN string) []string {
+	var resourceARNs []string
+	for _, resourceARN := range sortedKeys(f.associations) {
+		if f.associations[resourceARN] == webACLARN && f.isResourceAlive(resourceARN) {
+			resourceARNs = append(resourceARNs, resourceARN)
+		}
+	}
+	return resourceARNs
+}
+
+// validateRules checks that the IP sets referenced by rules exist, the caller must hold the mutex.
+func (f *WAFv2) validateRules(rules []wafv2types.Rule) error {
+	for _, rule := range rules {
+		for _, ipSetARN := range referencedIPSets(rule.Statement) {
+			if _, exists := f.ipSets[ipSetARN]; !exists {
+				return &wafv2types.WAFNonexistentItemException{Message: awssdk.String(fmt.Sprintf("AWS WAF couldn’t perform the operation because the IP set %s doesn’t exist.", ipSetARN))}
+			}
+		}
+	}
+	return nil
+}
+
+func referencesIPSet(rules []wafv2types.Rule, ipSetARN string) bool {
+	for _, rule := range rules {
+		for _, referencedARN := range referencedIPSets(rule.Statement) {
+			if referencedARN == ipSetARN {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func referencedIPSets(statement *wafv2types.Statement) []string {
+	if statement == nil {
+		return nil
+	}
+	var ipSetARNs []string
+	if statement.IPSetReferenceStatement != nil {
+		ipSetARNs = append(ipSetARNs, awssdk.ToString(statement.IPSetReferenceStatement.ARN))
+	}
+	if statement.RateBasedStatement != nil {
+		ipSetARNs = append(ipSetARNs, referencedIPSets(statement.RateBasedStatement.ScopeDownStatement)...)
+	}
+	return ipSetARNs
+}
+
+// webACLCapacity approximates the web ACL capacity units used by rules.
+func webACLCapacity(rules []wafv2types.Rule) int64 {
+	var capacity int64
+	for _, rule := range rules {
+		capacity += statementCapacity(rule.Statement)
+	}
+	return capacity
+}
+
+func statementCapacity(statement *wafv2types.Statement) int64 {
+	switch {
+	case statement == nil:
+		return 0
+	case statement.ManagedRuleGroupStatement != nil:
+		return managedRuleGroupCapacity
+	case statement.RateBasedStatement != nil:
+		return rateBasedRuleCapacity + statementCapacity(statement.RateBasedStatement.ScopeDownStatement)
+	case statement.IPSetReferenceStatement != nil:
+		return ipSetReferenceCapacity
+	default:
+		return 0
+	}
+}
+
+func buildWAFv2TagMap(tags []wafv2types.Tag) map[string]string {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+	}
+	return tagMap
+}
+
+func newWAFOptimisticLockError() error {
+	return &wafv2types.WAFOptimisticLockException{Message: awssdk.String("AWS WAF couldn’t save your changes because someone changed the resource after you started to edit it.")}
+}
+
+func newWAFInvalidParameterError(field string) error {
+	return &wafv2types.WAFInvalidParameterException{Message: awssdk.String(fmt.Sprintf("Invalid %s", field)), Field: wafv2types.ParameterExceptionField(field)}
+}
@@ -70,6 +70,9 @@ type GlobalAccelerator interface {
 
 	// RemoveEndpoints removes endpoints from an endpoint group.
 	RemoveEndpointsWithContext(ctx context.Context, input *globalaccelerator.RemoveEndpointsInput) (*globalaccelerator.RemoveEndpointsOutput, error)
+
+	// ListByoipCidrsAsList lists the BYOIP address ranges provisioned to Global Accelerator.
+	ListByoipCidrsAsList(ctx context.Context, input *globalaccelerator.ListByoipCidrsInput) ([]types.ByoipCidr, error)
 }
 
 // NewGlobalAccelerator constructs new GlobalAccelerator implementation.
@@ -278,3 +281,20 @@ func (c *defaultGlobalAccelerator) RemoveEndpointsWithContext(ctx context.Contex
 	}
 	return client.RemoveEndpoints(ctx, input)
 }
+
+func (c *defaultGlobalAccelerator) ListByoipCidrsAsList(ctx context.Context, input *globalaccelerator.ListByoipCidrsInput) ([]types.ByoipCidr, error) {
+	var result []types.ByoipCidr
+	client, err := c.awsClientsProvider.GetGlobalAcceleratorClient(ctx, "ListByoipCidrs")
+	if err != nil {
+		return nil, err
+	}
+	paginator := globalaccelerator.NewListByoipCidrsPaginator(client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, output.ByoipCidrs...)
+	}
+	return result, nil
+}
@@ -201,6 +201,21 @@ func (mr *MockGlobalAcceleratorMockRecorder) ListAcceleratorsAsList(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcceleratorsAsList", reflect.TypeOf((*MockGlobalAccelerator)(nil).ListAcceleratorsAsList), arg0, arg1)
 }
 
+// ListByoipCidrsAsList mocks base method.
+func (m *MockGlobalAccelerator) ListByoipCidrsAsList(arg0 context.Context, arg1 *globalaccelerator.ListByoipCidrsInput) ([]types.ByoipCidr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByoipCidrsAsList", arg0, arg1)
+	ret0, _ := ret[0].([]types.ByoipCidr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByoipCidrsAsList indicates an expected call of ListByoipCidrsAsList.
+func (mr *MockGlobalAcceleratorMockRecorder) ListByoipCidrsAsList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByoipCidrsAsList", reflect.TypeOf((*MockGlobalAccelerator)(nil).ListByoipCidrsAsList), arg0, arg1)
+}
+
 // ListEndpointGroupsAsList mocks base method.
 func (m *MockGlobalAccelerator) ListEndpointGroupsAsList(arg0 context.Context, arg1 *globalaccelerator.ListEndpointGroupsInput) ([]types.EndpointGroup, error) {
 	m.ctrl.T.Helper()
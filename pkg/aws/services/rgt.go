@@ -12,6 +12,7 @@ const (
 	ResourceTypeELBTargetGroup    = "elasticloadbalancing:targetgroup"
 	ResourceTypeELBLoadBalancer   = "elasticloadbalancing:loadbalancer"
 	ResourceTypeGlobalAccelerator = "globalaccelerator:accelerator"
+	ResourceTypeEC2SecurityGroup  = "ec2:security-group"
 )
 
 type RGT interface {
@@ -21,16 +22,23 @@ type RGT interface {
 // NewRGT constructs new RGT implementation.
 func NewRGT(awsClientsProvider provider.AWSClientsProvider) RGT {
 	return &rgtClient{
-		awsClientsProvider: awsClientsProvider,
+		getRGTClient: awsClientsProvider.GetRGTClient,
+	}
+}
+
+// NewGlobalAcceleratorRGT constructs new RGT implementation for the region of Global Accelerator resources.
+func NewGlobalAcceleratorRGT(awsClientsProvider provider.AWSClientsProvider) RGT {
+	return &rgtClient{
+		getRGTClient: awsClientsProvider.GetGlobalAcceleratorRGTClient,
 	}
 }
 
 type rgtClient struct {
-	awsClientsProvider provider.AWSClientsProvider
+	getRGTClient func(ctx context.Context, operationName string) (*resourcegroupstaggingapi.Client, error)
 }
 
 func (c *rgtClient) GetResourcesAsList(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]rgttypes.ResourceTagMapping, error) {
-	client, err := c.awsClientsProvider.GetRGTClient(ctx, "GetResources")
+	client, err := c.getRGTClient(ctx, "GetResources")
 	if err != nil {
 		return nil, err
 	}
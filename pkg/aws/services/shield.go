@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	shieldsdk "github.com/aws/aws-sdk-go-v2/service/shield"
+	shieldtypes "github.com/aws/aws-sdk-go-v2/service/shield/types"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/provider"
 )
 
@@ -11,6 +12,18 @@ type Shield interface {
 	DeleteProtectionWithContext(ctx context.Context, input *shieldsdk.DeleteProtectionInput) (*shieldsdk.DeleteProtectionOutput, error)
 	DescribeProtectionWithContext(ctx context.Context, input *shieldsdk.DescribeProtectionInput) (*shieldsdk.DescribeProtectionOutput, error)
 	GetSubscriptionStateWithContext(ctx context.Context, input *shieldsdk.GetSubscriptionStateInput) (*shieldsdk.GetSubscriptionStateOutput, error)
+	AssociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.AssociateHealthCheckInput) (*shieldsdk.AssociateHealthCheckOutput, error)
+	DisassociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.DisassociateHealthCheckInput) (*shieldsdk.DisassociateHealthCheckOutput, error)
+	EnableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.EnableApplicationLayerAutomaticResponseInput) (*shieldsdk.EnableApplicationLayerAutomaticResponseOutput, error)
+	UpdateApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.UpdateApplicationLayerAutomaticResponseInput) (*shieldsdk.UpdateApplicationLayerAutomaticResponseOutput, error)
+	DisableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.DisableApplicationLayerAutomaticResponseInput) (*shieldsdk.DisableApplicationLayerAutomaticResponseOutput, error)
+	CreateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.CreateProtectionGroupInput) (*shieldsdk.CreateProtectionGroupOutput, error)
+	UpdateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.UpdateProtectionGroupInput) (*shieldsdk.UpdateProtectionGroupOutput, error)
+	DeleteProtectionGroupWithContext(ctx context.Context, input *shieldsdk.DeleteProtectionGroupInput) (*shieldsdk.DeleteProtectionGroupOutput, error)
+	ListTagsForResourceWithContext(ctx context.Context, input *shieldsdk.ListTagsForResourceInput) (*shieldsdk.ListTagsForResourceOutput, error)
+
+	// ListProtectionGroupsAsList wraps the ListProtectionGroups API, which aggregates paged results into list.
+	ListProtectionGroupsAsList(ctx context.Context, input *shieldsdk.ListProtectionGroupsInput) ([]shieldtypes.ProtectionGroup, error)
 }
 
 // NewShield constructs new Shield implementation.
@@ -56,3 +69,92 @@ func (s *shieldClient) DeleteProtectionWithContext(ctx context.Context, input *s
 	}
 	return client.DeleteProtection(ctx, input)
 }
+
+func (s *shieldClient) AssociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.AssociateHealthCheckInput) (*shieldsdk.AssociateHealthCheckOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "AssociateHealthCheck")
+	if err != nil {
+		return nil, err
+	}
+	return client.AssociateHealthCheck(ctx, input)
+}
+
+func (s *shieldClient) DisassociateHealthCheckWithContext(ctx context.Context, input *shieldsdk.DisassociateHealthCheckInput) (*shieldsdk.DisassociateHealthCheckOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "DisassociateHealthCheck")
+	if err != nil {
+		return nil, err
+	}
+	return client.DisassociateHealthCheck(ctx, input)
+}
+
+func (s *shieldClient) EnableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.EnableApplicationLayerAutomaticResponseInput) (*shieldsdk.EnableApplicationLayerAutomaticResponseOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "EnableApplicationLayerAutomaticResponse")
+	if err != nil {
+		return nil, err
+	}
+	return client.EnableApplicationLayerAutomaticResponse(ctx, input)
+}
+
+func (s *shieldClient) UpdateApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.UpdateApplicationLayerAutomaticResponseInput) (*shieldsdk.UpdateApplicationLayerAutomaticResponseOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "UpdateApplicationLayerAutomaticResponse")
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateApplicationLayerAutomaticResponse(ctx, input)
+}
+
+func (s *shieldClient) DisableApplicationLayerAutomaticResponseWithContext(ctx context.Context, input *shieldsdk.DisableApplicationLayerAutomaticResponseInput) (*shieldsdk.DisableApplicationLayerAutomaticResponseOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "DisableApplicationLayerAutomaticResponse")
+	if err != nil {
+		return nil, err
+	}
+	return client.DisableApplicationLayerAutomaticResponse(ctx, input)
+}
+
+func (s *shieldClient) CreateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.CreateProtectionGroupInput) (*shieldsdk.CreateProtectionGroupOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "CreateProtectionGroup")
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateProtectionGroup(ctx, input)
+}
+
+func (s *shieldClient) UpdateProtectionGroupWithContext(ctx context.Context, input *shieldsdk.UpdateProtectionGroupInput) (*shieldsdk.UpdateProtectionGroupOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "UpdateProtectionGroup")
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateProtectionGroup(ctx, input)
+}
+
+func (s *shieldClient) DeleteProtectionGroupWithContext(ctx context.Context, input *shieldsdk.DeleteProtectionGroupInput) (*shieldsdk.DeleteProtectionGroupOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "DeleteProtectionGroup")
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteProtectionGroup(ctx, input)
+}
+
+func (s *shieldClient) ListTagsForResourceWithContext(ctx context.Context, input *shieldsdk.ListTagsForResourceInput) (*shieldsdk.ListTagsForResourceOutput, error) {
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "ListTagsForResource")
+	if err != nil {
+		return nil, err
+	}
+	return client.ListTagsForResource(ctx, input)
+}
+
+func (s *shieldClient) ListProtectionGroupsAsList(ctx context.Context, input *shieldsdk.ListProtectionGroupsInput) ([]shieldtypes.ProtectionGroup, error) {
+	var result []shieldtypes.ProtectionGroup
+	client, err := s.awsClientsProvider.GetShieldClient(ctx, "ListProtectionGroups")
+	if err != nil {
+		return nil, err
+	}
+	paginator := shieldsdk.NewListProtectionGroupsPaginator(client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, output.ProtectionGroups...)
+	}
+	return result, nil
+}
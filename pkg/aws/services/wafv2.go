@@ -11,6 +11,19 @@ type WAFv2 interface {
 	DisassociateWebACLWithContext(ctx context.Context, req *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error)
 	GetWebACLForResourceWithContext(ctx context.Context, req *wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error)
 	ListWebACLsWithContext(ctx context.Context, req *wafv2.ListWebACLsInput) (*wafv2.ListWebACLsOutput, error)
+	CreateIPSetWithContext(ctx context.Context, req *wafv2.CreateIPSetInput) (*wafv2.CreateIPSetOutput, error)
+	CreateWebACLWithContext(ctx context.Context, req *wafv2.CreateWebACLInput) (*wafv2.CreateWebACLOutput, error)
+	DeleteIPSetWithContext(ctx context.Context, req *wafv2.DeleteIPSetInput) (*wafv2.DeleteIPSetOutput, error)
+	DeleteWebACLWithContext(ctx context.Context, req *wafv2.DeleteWebACLInput) (*wafv2.DeleteWebACLOutput, error)
+	GetIPSetWithContext(ctx context.Context, req *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error)
+	GetWebACLWithContext(ctx context.Context, req *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error)
+	ListIPSetsWithContext(ctx context.Context, req *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error)
+	ListResourcesForWebACLWithContext(ctx context.Context, req *wafv2.ListResourcesForWebACLInput) (*wafv2.ListResourcesForWebACLOutput, error)
+	ListTagsForResourceWithContext(ctx context.Context, req *wafv2.ListTagsForResourceInput) (*wafv2.ListTagsForResourceOutput, error)
+	TagResourceWithContext(ctx context.Context, req *wafv2.TagResourceInput) (*wafv2.TagResourceOutput, error)
+	UntagResourceWithContext(ctx context.Context, req *wafv2.UntagResourceInput) (*wafv2.UntagResourceOutput, error)
+	UpdateIPSetWithContext(ctx context.Context, req *wafv2.UpdateIPSetInput) (*wafv2.UpdateIPSetOutput, error)
+	UpdateWebACLWithContext(ctx context.Context, req *wafv2.UpdateWebACLInput) (*wafv2.UpdateWebACLOutput, error)
 }
 
 // NewWAFv2 constructs new WAFv2 implementation.
@@ -55,3 +68,107 @@ func (c *wafv2Client) ListWebACLsWithContext(ctx context.Context, req *wafv2.Lis
 	}
 	return client.ListWebACLs(ctx, req)
 }
+
+func (c *wafv2Client) CreateIPSetWithContext(ctx context.Context, req *wafv2.CreateIPSetInput) (*wafv2.CreateIPSetOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "CreateIPSet")
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateIPSet(ctx, req)
+}
+
+func (c *wafv2Client) CreateWebACLWithContext(ctx context.Context, req *wafv2.CreateWebACLInput) (*wafv2.CreateWebACLOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "CreateWebACL")
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateWebACL(ctx, req)
+}
+
+func (c *wafv2Client) DeleteIPSetWithContext(ctx context.Context, req *wafv2.DeleteIPSetInput) (*wafv2.DeleteIPSetOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "DeleteIPSet")
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteIPSet(ctx, req)
+}
+
+func (c *wafv2Client) DeleteWebACLWithContext(ctx context.Context, req *wafv2.DeleteWebACLInput) (*wafv2.DeleteWebACLOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "DeleteWebACL")
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteWebACL(ctx, req)
+}
+
+func (c *wafv2Client) GetIPSetWithContext(ctx context.Context, req *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "GetIPSet")
+	if err != nil {
+		return nil, err
+	}
+	return client.GetIPSet(ctx, req)
+}
+
+func (c *wafv2Client) GetWebACLWithContext(ctx context.Context, req *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "GetWebACL")
+	if err != nil {
+		return nil, err
+	}
+	return client.GetWebACL(ctx, req)
+}
+
+func (c *wafv2Client) ListIPSetsWithContext(ctx context.Context, req *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "ListIPSets")
+	if err != nil {
+		return nil, err
+	}
+	return client.ListIPSets(ctx, req)
+}
+
+func (c *wafv2Client) ListResourcesForWebACLWithContext(ctx context.Context, req *wafv2.ListResourcesForWebACLInput) (*wafv2.ListResourcesForWebACLOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "ListResourcesForWebACL")
+	if err != nil {
+		return nil, err
+	}
+	return client.ListResourcesForWebACL(ctx, req)
+}
+
+func (c *wafv2Client) ListTagsForResourceWithContext(ctx context.Context, req *wafv2.ListTagsForResourceInput) (*wafv2.ListTagsForResourceOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "ListTagsForResource")
+	if err != nil {
+		return nil, err
+	}
+	return client.ListTagsForResource(ctx, req)
+}
+
+func (c *wafv2Client) TagResourceWithContext(ctx context.Context, req *wafv2.TagResourceInput) (*wafv2.TagResourceOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "TagResource")
+	if err != nil {
+		return nil, err
+	}
+	return client.TagResource(ctx, req)
+}
+
+func (c *wafv2Client) UntagResourceWithContext(ctx context.Context, req *wafv2.UntagResourceInput) (*wafv2.UntagResourceOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "UntagResource")
+	if err != nil {
+		return nil, err
+	}
+	return client.UntagResource(ctx, req)
+}
+
+func (c *wafv2Client) UpdateIPSetWithContext(ctx context.Context, req *wafv2.UpdateIPSetInput) (*wafv2.UpdateIPSetOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "UpdateIPSet")
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateIPSet(ctx, req)
+}
+
+func (c *wafv2Client) UpdateWebACLWithContext(ctx context.Context, req *wafv2.UpdateWebACLInput) (*wafv2.UpdateWebACLOutput, error) {
+	client, err := c.awsClientsProvider.GetWAFv2Client(ctx, "UpdateWebACL")
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateWebACL(ctx, req)
+}
@@ -50,6 +50,66 @@ func (mr *MockWAFv2MockRecorder) AssociateWebACLWithContext(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).AssociateWebACLWithContext), arg0, arg1)
 }
 
+// CreateIPSetWithContext mocks base method.
+func (m *MockWAFv2) CreateIPSetWithContext(arg0 context.Context, arg1 *wafv2.CreateIPSetInput) (*wafv2.CreateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIPSetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.CreateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIPSetWithContext indicates an expected call of CreateIPSetWithContext.
+func (mr *MockWAFv2MockRecorder) CreateIPSetWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateIPSetWithContext), arg0, arg1)
+}
+
+// CreateWebACLWithContext mocks base method.
+func (m *MockWAFv2) CreateWebACLWithContext(arg0 context.Context, arg1 *wafv2.CreateWebACLInput) (*wafv2.CreateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebACLWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.CreateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebACLWithContext indicates an expected call of CreateWebACLWithContext.
+func (mr *MockWAFv2MockRecorder) CreateWebACLWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).CreateWebACLWithContext), arg0, arg1)
+}
+
+// DeleteIPSetWithContext mocks base method.
+func (m *MockWAFv2) DeleteIPSetWithContext(arg0 context.Context, arg1 *wafv2.DeleteIPSetInput) (*wafv2.DeleteIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIPSetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.DeleteIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIPSetWithContext indicates an expected call of DeleteIPSetWithContext.
+func (mr *MockWAFv2MockRecorder) DeleteIPSetWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteIPSetWithContext), arg0, arg1)
+}
+
+// DeleteWebACLWithContext mocks base method.
+func (m *MockWAFv2) DeleteWebACLWithContext(arg0 context.Context, arg1 *wafv2.DeleteWebACLInput) (*wafv2.DeleteWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebACLWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.DeleteWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebACLWithContext indicates an expected call of DeleteWebACLWithContext.
+func (mr *MockWAFv2MockRecorder) DeleteWebACLWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).DeleteWebACLWithContext), arg0, arg1)
+}
+
 // DisassociateWebACLWithContext mocks base method.
 func (m *MockWAFv2) DisassociateWebACLWithContext(arg0 context.Context, arg1 *wafv2.DisassociateWebACLInput) (*wafv2.DisassociateWebACLOutput, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +125,36 @@ func (mr *MockWAFv2MockRecorder) DisassociateWebACLWithContext(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).DisassociateWebACLWithContext), arg0, arg1)
 }
 
+// GetIPSetWithContext mocks base method.
+func (m *MockWAFv2) GetIPSetWithContext(arg0 context.Context, arg1 *wafv2.GetIPSetInput) (*wafv2.GetIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPSetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.GetIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIPSetWithContext indicates an expected call of GetIPSetWithContext.
+func (mr *MockWAFv2MockRecorder) GetIPSetWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetIPSetWithContext), arg0, arg1)
+}
+
+// GetWebACLWithContext mocks base method.
+func (m *MockWAFv2) GetWebACLWithContext(arg0 context.Context, arg1 *wafv2.GetWebACLInput) (*wafv2.GetWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebACLWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.GetWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebACLWithContext indicates an expected call of GetWebACLWithContext.
+func (mr *MockWAFv2MockRecorder) GetWebACLWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLWithContext), arg0, arg1)
+}
+
 // GetWebACLForResourceWithContext mocks base method.
 func (m *MockWAFv2) GetWebACLForResourceWithContext(arg0 context.Context, arg1 *wafv2.GetWebACLForResourceInput) (*wafv2.GetWebACLForResourceOutput, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +170,51 @@ func (mr *MockWAFv2MockRecorder) GetWebACLForResourceWithContext(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebACLForResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).GetWebACLForResourceWithContext), arg0, arg1)
 }
 
+// ListIPSetsWithContext mocks base method.
+func (m *MockWAFv2) ListIPSetsWithContext(arg0 context.Context, arg1 *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIPSetsWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.ListIPSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIPSetsWithContext indicates an expected call of ListIPSetsWithContext.
+func (mr *MockWAFv2MockRecorder) ListIPSetsWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPSetsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListIPSetsWithContext), arg0, arg1)
+}
+
+// ListResourcesForWebACLWithContext mocks base method.
+func (m *MockWAFv2) ListResourcesForWebACLWithContext(arg0 context.Context, arg1 *wafv2.ListResourcesForWebACLInput) (*wafv2.ListResourcesForWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcesForWebACLWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.ListResourcesForWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcesForWebACLWithContext indicates an expected call of ListResourcesForWebACLWithContext.
+func (mr *MockWAFv2MockRecorder) ListResourcesForWebACLWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcesForWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListResourcesForWebACLWithContext), arg0, arg1)
+}
+
+// ListTagsForResourceWithContext mocks base method.
+func (m *MockWAFv2) ListTagsForResourceWithContext(arg0 context.Context, arg1 *wafv2.ListTagsForResourceInput) (*wafv2.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResourceWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResourceWithContext indicates an expected call of ListTagsForResourceWithContext.
+func (mr *MockWAFv2MockRecorder) ListTagsForResourceWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListTagsForResourceWithContext), arg0, arg1)
+}
+
 // ListWebACLsWithContext mocks base method.
 func (m *MockWAFv2) ListWebACLsWithContext(arg0 context.Context, arg1 *wafv2.ListWebACLsInput) (*wafv2.ListWebACLsOutput, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +229,63 @@ func (mr *MockWAFv2MockRecorder) ListWebACLsWithContext(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebACLsWithContext", reflect.TypeOf((*MockWAFv2)(nil).ListWebACLsWithContext), arg0, arg1)
 }
+
+// TagResourceWithContext mocks base method.
+func (m *MockWAFv2) TagResourceWithContext(arg0 context.Context, arg1 *wafv2.TagResourceInput) (*wafv2.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResourceWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResourceWithContext indicates an expected call of TagResourceWithContext.
+func (mr *MockWAFv2MockRecorder) TagResourceWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).TagResourceWithContext), arg0, arg1)
+}
+
+// UntagResourceWithContext mocks base method.
+func (m *MockWAFv2) UntagResourceWithContext(arg0 context.Context, arg1 *wafv2.UntagResourceInput) (*wafv2.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResourceWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResourceWithContext indicates an expected call of UntagResourceWithContext.
+func (mr *MockWAFv2MockRecorder) UntagResourceWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceWithContext", reflect.TypeOf((*MockWAFv2)(nil).UntagResourceWithContext), arg0, arg1)
+}
+
+// UpdateIPSetWithContext mocks base method.
+func (m *MockWAFv2) UpdateIPSetWithContext(arg0 context.Context, arg1 *wafv2.UpdateIPSetInput) (*wafv2.UpdateIPSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIPSetWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.UpdateIPSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIPSetWithContext indicates an expected call of UpdateIPSetWithContext.
+func (mr *MockWAFv2MockRecorder) UpdateIPSetWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPSetWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateIPSetWithContext), arg0, arg1)
+}
+
+// UpdateWebACLWithContext mocks base method.
+func (m *MockWAFv2) UpdateWebACLWithContext(arg0 context.Context, arg1 *wafv2.UpdateWebACLInput) (*wafv2.UpdateWebACLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebACLWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*wafv2.UpdateWebACLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebACLWithContext indicates an expected call of UpdateWebACLWithContext.
+func (mr *MockWAFv2MockRecorder) UpdateWebACLWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebACLWithContext", reflect.TypeOf((*MockWAFv2)(nil).UpdateWebACLWithContext), arg0, arg1)
+}
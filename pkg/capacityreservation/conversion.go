@@ -0,0 +1,66 @@
+package capacityreservation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// FromIngressClassParams converts the capacity reservation of an IngressClassParams into its model.
+func FromIngressClassParams(capacity *elbv2api.MinimumLoadBalancerCapacity) *elbv2model.MinimumLoadBalancerCapacity {
+	if capacity == nil {
+		return nil
+	}
+	modelCapacity := &elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: capacity.CapacityUnits,
+		TimeZone:      capacity.TimeZone,
+	}
+	for _, schedule := range capacity.Schedules {
+		modelCapacity.Schedules = append(modelCapacity.Schedules, elbv2model.CapacityReservationSchedule(schedule))
+	}
+	for _, entry := range capacity.Calendar {
+		modelCapacity.Calendar = append(modelCapacity.Calendar, elbv2model.CapacityReservationCalendarEntry(entry))
+	}
+	return modelCapacity
+}
+
+// FromLoadBalancerConfiguration converts the capacity reservation of a LoadBalancerConfiguration into its model.
+func FromLoadBalancerConfiguration(capacity *elbv2gw.MinimumLoadBalancerCapacity) *elbv2model.MinimumLoadBalancerCapacity {
+	if capacity == nil {
+		return nil
+	}
+	modelCapacity := &elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: capacity.CapacityUnits,
+		TimeZone:      capacity.TimeZone,
+	}
+	for _, schedule := range capacity.Schedules {
+		modelCapacity.Schedules = append(modelCapacity.Schedules, elbv2model.CapacityReservationSchedule(schedule))
+	}
+	for _, entry := range capacity.Calendar {
+		modelCapacity.Calendar = append(modelCapacity.Calendar, elbv2model.CapacityReservationCalendarEntry(entry))
+	}
+	return modelCapacity
+}
+
+// ModelStatus converts the reservation into the status of the load balancer model.
+func (r Reservation) ModelStatus() *elbv2model.CapacityReservationStatus {
+	status := &elbv2model.CapacityReservationStatus{
+		CurrentCapacityUnits: r.CapacityUnits,
+		NextCapacityUnits:    r.NextCapacityUnits,
+	}
+	if r.NextTransitionTime != nil {
+		status.NextTransitionTime = &metav1.Time{Time: *r.NextTransitionTime}
+	}
+	return status
+}
+
+// IngressClassParamsStatus converts the reservation into the status of an IngressClassParams.
+func (r Reservation) IngressClassParamsStatus() *elbv2api.CapacityReservationStatus {
+	return (*elbv2api.CapacityReservationStatus)(r.ModelStatus())
+}
+
+// LoadBalancerConfigurationStatus converts the reservation into the status of a LoadBalancerConfiguration.
+func (r Reservation) LoadBalancerConfigurationStatus() *elbv2gw.CapacityReservationStatus {
+	return (*elbv2gw.CapacityReservationStatus)(r.ModelStatus())
+}
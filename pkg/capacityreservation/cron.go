@@ -0,0 +1,183 @@
+package capacityreservation
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cronSearchLimit bounds the search for the next activation of a cron expression, expressions like "0 0 30 2 *" never fire.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	cronMinuteField = cronField{name: "minute", min: 0, max: 59}
+	cronHourField   = cronField{name: "hour", min: 0, max: 23}
+	cronDomField    = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day-of-week accepts 7 as an alias of Sunday.
+	cronDowField = cronField{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronSchedule is a parsed standard five-field cron expression: minute hour day-of-month month day-of-week.
+type cronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	doms     uint64
+	months   uint64
+	dows     uint64
+	domStar  bool
+	dowStar  bool
+	location *time.Location
+}
+
+// parseCronExpression parses a standard five-field cron expression evaluated in location.
+func parseCronExpression(expr string, location *time.Location) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	minutes, _, err := parseCronField(fields[0], cronMinuteField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+	hours, _, err := parseCronField(fields[1], cronHourField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+	doms, domStar, err := parseCronField(fields[2], cronDomField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+	months, _, err := parseCronField(fields[3], cronMonthField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+	dows, dowStar, err := parseCronField(fields[4], cronDowField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+	if dows&(1<<7) != 0 {
+		dows |= 1
+	}
+	return &cronSchedule{
+		minutes:  minutes,
+		hours:    hours,
+		doms:     doms,
+		months:   months,
+		dows:     dows,
+		domStar:  domStar,
+		dowStar:  dowStar,
+		location: location,
+	}, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps into a bitset.
+// The returned bool is true when the field is an unrestricted "*".
+func parseCronField(expr string, field cronField) (uint64, bool, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangeExpr = part[:idx]
+			parsedStep, err := strconv.Atoi(part[idx+1:])
+			if err != nil || parsedStep <= 0 {
+				return 0, false, errors.Errorf("invalid step %q in %v field", part, field.name)
+			}
+			step = parsedStep
+		}
+		var low, high int
+		switch {
+		case rangeExpr == "*":
+			low, high = field.min, field.max
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if low, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, false, err
+			}
+			if high, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, false, err
+			}
+			if low > high {
+				return 0, false, errors.Errorf("invalid range %q in %v field", rangeExpr, field.name)
+			}
+		default:
+			value, err := parseCronValue(rangeExpr, field)
+			if err != nil {
+				return 0, false, err
+			}
+			low, high = value, value
+			if step > 1 {
+				high = field.max
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, expr == "*", nil
+}
+
+func parseCronValue(expr string, field cronField) (int, error) {
+	if value, ok := field.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q in %v field", expr, field.name)
+	}
+	if value < field.min || value > field.max {
+		return 0, errors.Errorf("value %d out of range [%d, %d] in %v field", value, field.min, field.max, field.name)
+	}
+	return value, nil
+}
+
+// next returns the earliest activation strictly after t, or the zero time if there is none within cronSearchLimit.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay follows the standard cron semantic: when both day-of-month and day-of-week are restricted, either may match.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.doms&(1<<uint(t.Day())) != 0
+	dowMatch := s.dows&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,137 @@
+package capacityreservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseCronExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+		},
+		{
+			name: "lists, ranges, steps and names",
+			expr: "0,30 8-18/2 1-15 jan-jun MON-FRI",
+		},
+		{
+			name:    "too few fields",
+			expr:    "0 8 * *",
+			wantErr: "invalid cron expression \"0 8 * *\": expected 5 fields, got 4",
+		},
+		{
+			name:    "value out of range",
+			expr:    "60 8 * * *",
+			wantErr: "invalid cron expression \"60 8 * * *\": value 60 out of range [0, 59] in minute field",
+		},
+		{
+			name:    "invalid step",
+			expr:    "*/0 8 * * *",
+			wantErr: "invalid cron expression \"*/0 8 * * *\": invalid step \"*/0\" in minute field",
+		},
+		{
+			name:    "inverted range",
+			expr:    "0 18-8 * * *",
+			wantErr: "invalid cron expression \"0 18-8 * * *\": invalid range \"18-8\" in hour field",
+		},
+		{
+			name:    "unknown name",
+			expr:    "0 8 * * funday",
+			wantErr: "invalid cron expression \"0 8 * * funday\": invalid value \"funday\" in day-of-week field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCronExpression(tt.expr, time.UTC)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_cronSchedule_next(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	tests := []struct {
+		name     string
+		expr     string
+		location *time.Location
+		after    time.Time
+		want     time.Time
+	}{
+		{
+			name:     "later the same day",
+			expr:     "0 8 * * *",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 6, 30, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "strictly after the given time",
+			expr:     "0 8 * * *",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays only",
+			expr:     "0 8 * * 1-5",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 6, 9, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 0 * * 7",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day-of-month or day-of-week when both are restricted",
+			expr:     "0 0 15 * fri",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next year",
+			expr:     "0 0 1 jan *",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "evaluated in the time zone",
+			expr:     "0 8 * * *",
+			location: newYork,
+			after:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "never fires",
+			expr:     "0 0 30 feb *",
+			location: time.UTC,
+			after:    time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:     time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCronExpression(tt.expr, tt.location)
+			assert.NoError(t, err)
+			got := schedule.next(tt.after)
+			assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package capacityreservation
+
+import (
+	"time"
+
+	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// RequeueAtNextTransition returns a requeue error at the next scheduled capacity reservation transition of the deployed
+// load balancer, so that the reservation is raised or lowered on time. It returns nil when no transition is scheduled.
+func RequeueAtNextTransition(lb *elbv2model.LoadBalancer) error {
+	if lb == nil || lb.Status == nil || lb.Status.CapacityReservation == nil || lb.Status.CapacityReservation.NextTransitionTime == nil {
+		return nil
+	}
+	// requeue right away when the transition time has passed during the reconcile.
+	requeueAfter := max(time.Until(lb.Status.CapacityReservation.NextTransitionTime.Time), time.Second)
+	return ctrlerrors.NewRequeueNeededAfter("capacity reservation schedule", requeueAfter)
+}
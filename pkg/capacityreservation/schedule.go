@@ -0,0 +1,201 @@
+package capacityreservation
+
+import (
+	"sort"
+	"time"
+	// time zones are resolved from the embedded database, the controller image doesn't ship one.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// CalendarTimeLayout is the layout of the start and end of calendar entries, interpreted in the configured time zone.
+const CalendarTimeLayout = "2006-01-02T15:04"
+
+const (
+	// maxWindowStartsPerEvaluation bounds the window starts walked to decide whether a recurring window is open.
+	maxWindowStartsPerEvaluation = 10000
+	// mergedWindowHorizon bounds how far ahead overlapping occurrences of a recurring window are merged.
+	mergedWindowHorizon = 31 * 24 * time.Hour
+)
+
+// Reservation is the capacity reservation in effect at a point in time.
+type Reservation struct {
+	// CapacityUnits is the capacity reservation in effect.
+	CapacityUnits int32
+	// NextCapacityUnits is the capacity reservation after the next transition, nil when no transition is scheduled.
+	NextCapacityUnits *int32
+	// NextTransitionTime is the time of the next transition, nil when no transition is scheduled.
+	NextTransitionTime *time.Time
+}
+
+// IsScheduled returns whether the capacity reservation has schedules or calendar entries.
+func IsScheduled(capacity *elbv2model.MinimumLoadBalancerCapacity) bool {
+	return capacity != nil && (len(capacity.Schedules) != 0 || len(capacity.Calendar) != 0)
+}
+
+// Validate checks the schedules, calendar entries and time zone of the capacity reservation.
+func Validate(capacity elbv2model.MinimumLoadBalancerCapacity) error {
+	_, err := compile(capacity)
+	return err
+}
+
+// Evaluate returns the capacity reservation in effect at now along with the next transition.
+// Outside of any window the capacity reservation is CapacityUnits, when windows overlap the largest one applies.
+func Evaluate(capacity elbv2model.MinimumLoadBalancerCapacity, now time.Time) (Reservation, error) {
+	compiled, err := compile(capacity)
+	if err != nil {
+		return Reservation{}, err
+	}
+	reservation := Reservation{
+		CapacityUnits: compiled.capacityUnitsAt(now),
+	}
+	for _, candidate := range compiled.transitionCandidates(now) {
+		nextCapacityUnits := compiled.capacityUnitsAt(candidate)
+		if nextCapacityUnits != reservation.CapacityUnits {
+			nextTransitionTime := candidate
+			reservation.NextCapacityUnits = &nextCapacityUnits
+			reservation.NextTransitionTime = &nextTransitionTime
+			break
+		}
+	}
+	return reservation, nil
+}
+
+type recurringWindow struct {
+	cron          *cronSchedule
+	duration      time.Duration
+	capacityUnits int32
+}
+
+type datedWindow struct {
+	start         time.Time
+	end           time.Time
+	capacityUnits int32
+}
+
+type compiledCapacity struct {
+	capacityUnits    int32
+	recurringWindows []recurringWindow
+	datedWindows     []datedWindow
+}
+
+func compile(capacity elbv2model.MinimumLoadBalancerCapacity) (*compiledCapacity, error) {
+	if capacity.CapacityUnits < 0 {
+		return nil, errors.Errorf("invalid capacity units %d, must not be negative", capacity.CapacityUnits)
+	}
+	location := time.UTC
+	if capacity.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(capacity.TimeZone); err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone %v", capacity.TimeZone)
+		}
+	}
+	compiled := &compiledCapacity{
+		capacityUnits: capacity.CapacityUnits,
+	}
+	for _, schedule := range capacity.Schedules {
+		cron, err := parseCronExpression(schedule.Start, location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid capacity reservation schedule %v", schedule.Name)
+		}
+		if schedule.Duration.Duration <= 0 {
+			return nil, errors.Errorf("invalid capacity reservation schedule %v: duration must be positive", schedule.Name)
+		}
+		if schedule.CapacityUnits < 0 {
+			return nil, errors.Errorf("invalid capacity reservation schedule %v: capacity units must not be negative", schedule.Name)
+		}
+		compiled.recurringWindows = append(compiled.recurringWindows, recurringWindow{
+			cron:          cron,
+			duration:      schedule.Duration.Duration,
+			capacityUnits: schedule.CapacityUnits,
+		})
+	}
+	for _, entry := range capacity.Calendar {
+		start, err := time.ParseInLocation(CalendarTimeLayout, entry.Start, location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid capacity reservation calendar entry %v start", entry.Name)
+		}
+		end, err := time.ParseInLocation(CalendarTimeLayout, entry.End, location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid capacity reservation calendar entry %v end", entry.Name)
+		}
+		if !end.After(start) {
+			return nil, errors.Errorf("invalid capacity reservation calendar entry %v: end must be after start", entry.Name)
+		}
+		if entry.CapacityUnits < 0 {
+			return nil, errors.Errorf("invalid capacity reservation calendar entry %v: capacity units must not be negative", entry.Name)
+		}
+		compiled.datedWindows = append(compiled.datedWindows, datedWindow{
+			start:         start,
+			end:           end,
+			capacityUnits: entry.CapacityUnits,
+		})
+	}
+	return compiled, nil
+}
+
+// capacityUnitsAt returns the largest capacity reservation among the windows open at t, or the default outside of windows.
+func (c *compiledCapacity) capacityUnitsAt(t time.Time) int32 {
+	var capacityUnits int32
+	inWindow := false
+	for _, window := range c.recurringWindows {
+		if _, open := window.openUntil(t); open && (!inWindow || window.capacityUnits > capacityUnits) {
+			capacityUnits, inWindow = window.capacityUnits, true
+		}
+	}
+	for _, window := range c.datedWindows {
+		if !t.Before(window.start) && t.Before(window.end) && (!inWindow || window.capacityUnits > capacityUnits) {
+			capacityUnits, inWindow = window.capacityUnits, true
+		}
+	}
+	if !inWindow {
+		return c.capacityUnits
+	}
+	return capacityUnits
+}
+
+// transitionCandidates returns the sorted window boundaries after now that may change the capacity reservation.
+func (c *compiledCapacity) transitionCandidates(now time.Time) []time.Time {
+	var candidates []time.Time
+	for _, window := range c.recurringWindows {
+		if end, open := window.openUntil(now); open {
+			candidates = append(candidates, end)
+		}
+		if start := window.cron.next(now); !start.IsZero() {
+			candidates = append(candidates, start, start.Add(window.duration))
+		}
+	}
+	for _, window := range c.datedWindows {
+		if window.start.After(now) {
+			candidates = append(candidates, window.start)
+		}
+		if window.end.After(now) {
+			candidates = append(candidates, window.end)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	return candidates
+}
+
+// openUntil returns when the window open at t closes, windows whose occurrences overlap are merged.
+func (w recurringWindow) openUntil(t time.Time) (time.Time, bool) {
+	var end time.Time
+	start := w.cron.next(t.Add(-w.duration))
+	for i := 0; i < maxWindowStartsPerEvaluation && !start.IsZero() && !start.After(t); i++ {
+		end = start.Add(w.duration)
+		start = w.cron.next(start)
+	}
+	if !end.After(t) {
+		return time.Time{}, false
+	}
+	horizon := t.Add(mergedWindowHorizon)
+	for i := 0; i < maxWindowStartsPerEvaluation && !start.IsZero() && !start.After(end) && end.Before(horizon); i++ {
+		end = start.Add(w.duration)
+		start = w.cron.next(start)
+	}
+	return end, true
+}
@@ -0,0 +1,210 @@
+package capacityreservation
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func TestEvaluate(t *testing.T) {
+	businessHours := elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: 100,
+		Schedules: []elbv2model.CapacityReservationSchedule{
+			{
+				Name:          "business-hours",
+				Start:         "0 8 * * mon-fri",
+				Duration:      metav1.Duration{Duration: 10 * time.Hour},
+				CapacityUnits: 400,
+			},
+		},
+		TimeZone: "Europe/Paris",
+	}
+	launch := elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: 0,
+		Schedules:     businessHours.Schedules,
+		Calendar: []elbv2model.CapacityReservationCalendarEntry{
+			{
+				Name:          "launch",
+				Start:         "2026-03-04T12:00",
+				End:           "2026-03-05T00:00",
+				CapacityUnits: 1000,
+			},
+		},
+		TimeZone: "Europe/Paris",
+	}
+	tests := []struct {
+		name     string
+		capacity elbv2model.MinimumLoadBalancerCapacity
+		now      time.Time
+		want     Reservation
+		wantErr  string
+	}{
+		{
+			name: "no schedule",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				CapacityUnits: 100,
+			},
+			now: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits: 100,
+			},
+		},
+		{
+			name:     "before the window opens",
+			capacity: businessHours,
+			now:      time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      100,
+				NextCapacityUnits:  awssdk.Int32(400),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "window open",
+			capacity: businessHours,
+			now:      time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      400,
+				NextCapacityUnits:  awssdk.Int32(100),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "after the window on friday",
+			capacity: businessHours,
+			now:      time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      100,
+				NextCapacityUnits:  awssdk.Int32(400),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 9, 7, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "calendar entry raises over the schedule",
+			capacity: launch,
+			now:      time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      400,
+				NextCapacityUnits:  awssdk.Int32(1000),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "calendar entry outlasts the schedule",
+			capacity: launch,
+			now:      time.Date(2026, 3, 4, 17, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      1000,
+				NextCapacityUnits:  awssdk.Int32(0),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "window lowers the reservation",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				CapacityUnits: 400,
+				Schedules: []elbv2model.CapacityReservationSchedule{
+					{
+						Start:         "0 22 * * *",
+						Duration:      metav1.Duration{Duration: 8 * time.Hour},
+						CapacityUnits: 0,
+					},
+				},
+			},
+			now: time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits:      0,
+				NextCapacityUnits:  awssdk.Int32(400),
+				NextTransitionTime: awssdk.Time(time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "overlapping occurrences are merged",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				Schedules: []elbv2model.CapacityReservationSchedule{
+					{
+						Start:         "0 * * * *",
+						Duration:      metav1.Duration{Duration: 90 * time.Minute},
+						CapacityUnits: 200,
+					},
+				},
+				Calendar: []elbv2model.CapacityReservationCalendarEntry{
+					{
+						Start:         "2026-03-02T00:00",
+						End:           "2026-03-02T02:00",
+						CapacityUnits: 100,
+					},
+				},
+			},
+			now: time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits: 200,
+			},
+		},
+		{
+			name: "calendar entry in the past",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				CapacityUnits: 100,
+				Calendar:      launch.Calendar,
+			},
+			now: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			want: Reservation{
+				CapacityUnits: 100,
+			},
+		},
+		{
+			name: "invalid time zone",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				Schedules: businessHours.Schedules,
+				TimeZone:  "Mars/Olympus_Mons",
+			},
+			wantErr: "invalid time zone Mars/Olympus_Mons: unknown time zone Mars/Olympus_Mons",
+		},
+		{
+			name: "invalid schedule duration",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				Schedules: []elbv2model.CapacityReservationSchedule{
+					{
+						Name:  "nightly",
+						Start: "0 22 * * *",
+					},
+				},
+			},
+			wantErr: "invalid capacity reservation schedule nightly: duration must be positive",
+		},
+		{
+			name: "invalid calendar entry",
+			capacity: elbv2model.MinimumLoadBalancerCapacity{
+				Calendar: []elbv2model.CapacityReservationCalendarEntry{
+					{
+						Name:  "launch",
+						Start: "2026-03-05T00:00",
+						End:   "2026-03-04T00:00",
+					},
+				},
+			},
+			wantErr: "invalid capacity reservation calendar entry launch: end must be after start",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.capacity, tt.now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.CapacityUnits, got.CapacityUnits)
+			assert.Equal(t, tt.want.NextCapacityUnits, got.NextCapacityUnits)
+			if tt.want.NextTransitionTime == nil {
+				assert.Nil(t, got.NextTransitionTime)
+			} else if assert.NotNil(t, got.NextTransitionTime) {
+				assert.True(t, tt.want.NextTransitionTime.Equal(*got.NextTransitionTime), "want %v, got %v", tt.want.NextTransitionTime, got.NextTransitionTime)
+			}
+		})
+	}
+}
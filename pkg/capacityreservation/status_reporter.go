@@ -0,0 +1,131 @@
+package capacityreservation
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/wait"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// statusReportInterval is the interval between status reports, transitions are scheduled at minute granularity.
+	statusReportInterval = 1 * time.Minute
+	// jitter applied to the report interval.
+	statusReportIntervalJitterFactor = 0.1
+)
+
+var _ manager.Runnable = &statusReporter{}
+var _ manager.LeaderElectionRunnable = &statusReporter{}
+
+// NewStatusReporter constructs new statusReporter.
+// LoadBalancerConfigurations are only reported when gatewayEnabled, as their CRD may not be installed otherwise.
+func NewStatusReporter(k8sClient client.Client, gatewayEnabled bool, logger logr.Logger) *statusReporter {
+	return &statusReporter{
+		k8sClient:      k8sClient,
+		gatewayEnabled: gatewayEnabled,
+		interval:       statusReportInterval,
+		logger:         logger,
+		clock:          time.Now,
+	}
+}
+
+// statusReporter periodically reports the current and next scheduled capacity reservation in the status of
+// IngressClassParams and LoadBalancerConfigurations. The reservation itself is applied by the load balancer reconcile.
+type statusReporter struct {
+	k8sClient      client.Client
+	gatewayEnabled bool
+	interval       time.Duration
+	logger         logr.Logger
+	clock          func() time.Time
+}
+
+// Start runs the reporting loop until ctx is done.
+func (r *statusReporter) Start(ctx context.Context) error {
+	r.logger.Info("starting capacity reservation status reporter", "interval", r.interval)
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Report(ctx); err != nil {
+			r.logger.Error(err, "failed to report capacity reservation status")
+		}
+	}, r.interval, statusReportIntervalJitterFactor, true)
+	return nil
+}
+
+// NeedLeaderElection makes sure only the leader reports status.
+func (r *statusReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Report runs a single report of the capacity reservation status.
+func (r *statusReporter) Report(ctx context.Context) error {
+	now := r.clock()
+	if err := r.reportIngressClassParams(ctx, now); err != nil {
+		return err
+	}
+	if r.gatewayEnabled {
+		return r.reportLoadBalancerConfigurations(ctx, now)
+	}
+	return nil
+}
+
+func (r *statusReporter) reportIngressClassParams(ctx context.Context, now time.Time) error {
+	icpList := &elbv2api.IngressClassParamsList{}
+	if err := r.k8sClient.List(ctx, icpList); err != nil {
+		return err
+	}
+	for i := range icpList.Items {
+		icp := &icpList.Items[i]
+		var desiredStatus *elbv2api.CapacityReservationStatus
+		if capacity := FromIngressClassParams(icp.Spec.MinimumLoadBalancerCapacity); IsScheduled(capacity) {
+			reservation, err := Evaluate(*capacity, now)
+			if err != nil {
+				r.logger.Error(err, "invalid capacity reservation", "ingressClassParams", icp.Name)
+				continue
+			}
+			desiredStatus = reservation.IngressClassParamsStatus()
+		}
+		if equality.Semantic.DeepEqual(icp.Status.CapacityReservation, desiredStatus) {
+			continue
+		}
+		icpOld := icp.DeepCopy()
+		icp.Status.CapacityReservation = desiredStatus
+		if err := r.k8sClient.Status().Patch(ctx, icp, client.MergeFrom(icpOld)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *statusReporter) reportLoadBalancerConfigurations(ctx context.Context, now time.Time) error {
+	lbConfigList := &elbv2gw.LoadBalancerConfigurationList{}
+	if err := r.k8sClient.List(ctx, lbConfigList); err != nil {
+		return err
+	}
+	for i := range lbConfigList.Items {
+		lbConfig := &lbConfigList.Items[i]
+		var desiredStatus *elbv2gw.CapacityReservationStatus
+		if capacity := FromLoadBalancerConfiguration(lbConfig.Spec.MinimumLoadBalancerCapacity); IsScheduled(capacity) {
+			reservation, err := Evaluate(*capacity, now)
+			if err != nil {
+				r.logger.Error(err, "invalid capacity reservation", "loadBalancerConfiguration", k8s.NamespacedName(lbConfig))
+				continue
+			}
+			desiredStatus = reservation.LoadBalancerConfigurationStatus()
+		}
+		if equality.Semantic.DeepEqual(lbConfig.Status.CapacityReservation, desiredStatus) {
+			continue
+		}
+		lbConfigOld := lbConfig.DeepCopy()
+		lbConfig.Status.CapacityReservation = desiredStatus
+		if err := r.k8sClient.Status().Patch(ctx, lbConfig, client.MergeFrom(lbConfigOld)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package capacityreservation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_statusReporter_Report(t *testing.T) {
+	now := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+	schedules := []elbv2api.CapacityReservationSchedule{
+		{
+			Name:          "business-hours",
+			Start:         "0 8 * * mon-fri",
+			Duration:      metav1.Duration{Duration: 10 * time.Hour},
+			CapacityUnits: 400,
+		},
+	}
+	staleStatus := &elbv2api.CapacityReservationStatus{
+		CurrentCapacityUnits: 400,
+	}
+	tests := []struct {
+		name           string
+		icp            *elbv2api.IngressClassParams
+		lbConfig       *elbv2gw.LoadBalancerConfiguration
+		gatewayEnabled bool
+		wantICPStatus  *elbv2api.CapacityReservationStatus
+		wantLBCStatus  *elbv2gw.CapacityReservationStatus
+	}{
+		{
+			name: "scheduled IngressClassParams",
+			icp: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "icp"},
+				Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+						CapacityUnits: 100,
+						Schedules:     schedules,
+					},
+				},
+			},
+			wantICPStatus: &elbv2api.CapacityReservationStatus{
+				CurrentCapacityUnits: 100,
+				NextCapacityUnits:    awssdk.Int32(400),
+				NextTransitionTime:   &metav1.Time{Time: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "status cleared once no longer scheduled",
+			icp: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "icp"},
+				Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+						CapacityUnits: 100,
+					},
+				},
+				Status: elbv2api.IngressClassParamsStatus{
+					CapacityReservation: staleStatus,
+				},
+			},
+			wantICPStatus: nil,
+		},
+		{
+			name: "scheduled LoadBalancerConfiguration",
+			icp: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "icp"},
+			},
+			lbConfig: &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "lbc", Namespace: "default"},
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					MinimumLoadBalancerCapacity: &elbv2gw.MinimumLoadBalancerCapacity{
+						Calendar: []elbv2gw.CapacityReservationCalendarEntry{
+							{
+								Name:          "launch",
+								Start:         "2026-03-02T05:00",
+								End:           "2026-03-02T07:00",
+								CapacityUnits: 1000,
+							},
+						},
+					},
+				},
+			},
+			gatewayEnabled: true,
+			wantLBCStatus: &elbv2gw.CapacityReservationStatus{
+				CurrentCapacityUnits: 1000,
+				NextCapacityUnits:    awssdk.Int32(0),
+				NextTransitionTime:   &metav1.Time{Time: time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(scheme)
+			elbv2api.AddToScheme(scheme)
+			elbv2gw.AddToScheme(scheme)
+			builder := testclient.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(tt.icp).WithObjects(tt.icp)
+			if tt.lbConfig != nil {
+				builder = builder.WithStatusSubresource(tt.lbConfig).WithObjects(tt.lbConfig)
+			}
+			k8sClient := builder.Build()
+
+			reporter := &statusReporter{
+				k8sClient:      k8sClient,
+				gatewayEnabled: tt.gatewayEnabled,
+				logger:         log.Log,
+				clock:          func() time.Time { return now },
+			}
+			assert.NoError(t, reporter.Report(context.Background()))
+
+			gotICP := &elbv2api.IngressClassParams{}
+			assert.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(tt.icp), gotICP))
+			assertCapacityReservationStatus(t, tt.wantICPStatus, gotICP.Status.CapacityReservation)
+			if tt.lbConfig != nil {
+				gotLBConfig := &elbv2gw.LoadBalancerConfiguration{}
+				assert.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(tt.lbConfig), gotLBConfig))
+				assertCapacityReservationStatus(t, (*elbv2api.CapacityReservationStatus)(tt.wantLBCStatus), (*elbv2api.CapacityReservationStatus)(gotLBConfig.Status.CapacityReservation))
+			}
+		})
+	}
+}
+
+func assertCapacityReservationStatus(t *testing.T, want, got *elbv2api.CapacityReservationStatus) {
+	if want == nil {
+		assert.Nil(t, got)
+		return
+	}
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.Equal(t, want.CurrentCapacityUnits, got.CurrentCapacityUnits)
+	assert.Equal(t, want.NextCapacityUnits, got.NextCapacityUnits)
+	assert.True(t, want.NextTransitionTime.Equal(got.NextTransitionTime), "want %v, got %v", want.NextTransitionTime, got.NextTransitionTime)
+}
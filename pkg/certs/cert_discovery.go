@@ -2,6 +2,9 @@ package certs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +15,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/strings/slices"
@@ -33,16 +35,48 @@ const (
 // CertDiscovery is responsible for auto-discover TLS certificates for tls hosts.
 type CertDiscovery interface {
 	// Discover will try to find valid certificateARNs for each tlsHost.
+	// When partial discovery is allowed and some hosts have no certificate, the certificates found for the other hosts
+	// are returned along with a *PartialDiscoveryError. Otherwise, an unmatched host fails discovery with a plain error.
 	Discover(ctx context.Context, tlsHosts []string, tags map[string]string) ([]string, error)
 }
 
+// SelectionPolicy controls which of the certificates matching a host are discovered.
+type SelectionPolicy struct {
+	// MinRemainingValidity is the minimum remaining validity of a certificate to be discovered.
+	// Expired certificates are never discovered.
+	MinRemainingValidity time.Duration
+
+	// AllowPartialDiscovery specifies whether to return the certificates found when some hosts have no certificate,
+	// instead of failing.
+	AllowPartialDiscovery bool
+}
+
+// PartialDiscoveryError is returned along with the discovered certificates when some hosts have no certificate,
+// and partial discovery is allowed.
+type PartialDiscoveryError struct {
+	UnmatchedHosts []string
+}
+
+func (e *PartialDiscoveryError) Error() string {
+	return fmt.Sprintf("no certificate found for host: %s", strings.Join(e.UnmatchedHosts, ", "))
+}
+
+// certificateDetail contains the details of a certificate used to select certificates for hosts.
+type certificateDetail struct {
+	domains      sets.String
+	keyAlgorithm acmTypes.KeyAlgorithm
+	notAfter     *time.Time
+}
+
 // NewACMCertDiscovery constructs new acmCertDiscovery
-func NewACMCertDiscovery(acmClient services.ACM, allowedCAARNs []string, enableCertificateManagement bool, logger logr.Logger) *acmCertDiscovery {
+func NewACMCertDiscovery(acmClient services.ACM, allowedCAARNs []string, enableCertificateManagement bool, selectionPolicy SelectionPolicy, logger logr.Logger) *acmCertDiscovery {
 	return &acmCertDiscovery{
-		acmClient: acmClient,
-		logger:    logger,
+		acmClient:       acmClient,
+		selectionPolicy: selectionPolicy,
+		logger:          logger,
+		clock:           time.Now,
 
-		loadDomainsByCertARNMutex:   sync.Mutex{},
+		loadCertDetailsMutex:        sync.Mutex{},
 		certARNsCache:               cache.NewExpiring(),
 		certARNsCacheTTL:            defaultCertARNsCacheTTL,
 		certDomainsCache:            cache.NewExpiring(),
@@ -57,11 +91,13 @@ var _ CertDiscovery = &acmCertDiscovery{}
 
 // CertDiscovery implementation for ACM certificates.
 type acmCertDiscovery struct {
-	acmClient services.ACM
-	logger    logr.Logger
+	acmClient       services.ACM
+	selectionPolicy SelectionPolicy
+	logger          logr.Logger
+	clock           func() time.Time
 
-	// mutex to serialize the call to loadDomainsForAllCertificates
-	loadDomainsByCertARNMutex   sync.Mutex
+	// mutex to serialize the call to loadDetailsForAllCertificates
+	loadCertDetailsMutex        sync.Mutex
 	certARNsCache               *cache.Expiring
 	certARNsCacheTTL            time.Duration
 	certDomainsCache            *cache.Expiring
@@ -72,50 +108,102 @@ type acmCertDiscovery struct {
 }
 
 func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string, filterTags map[string]string) ([]string, error) {
-	domainsByCertARN, err := d.loadDomainsForAllCertificates(ctx, filterTags)
+	certDetailByARN, err := d.loadDetailsForAllCertificates(ctx, filterTags)
 	if err != nil {
 		return nil, err
 	}
+	now := d.clock()
 	certARNs := sets.NewString()
+	var unmatchedHosts []string
 	for _, host := range tlsHosts {
-		var certARNsForHost []string
-		for certARN, domains := range domainsByCertARN {
-			for domain := range domains {
-				if d.domainMatchesHost(domain, host) {
-					certARNsForHost = append(certARNsForHost, certARN)
-					break
-				}
-			}
-		}
-
+		certARNsForHost := d.selectCertificatesForHost(host, certDetailByARN, now)
 		if len(certARNsForHost) == 0 {
-			return nil, errors.Errorf("no certificate found for host: %s", host)
+			unmatchedHosts = append(unmatchedHosts, host)
+			continue
 		}
 		certARNs.Insert(certARNsForHost...)
 	}
+	if len(unmatchedHosts) != 0 {
+		partialErr := &PartialDiscoveryError{UnmatchedHosts: unmatchedHosts}
+		// only a usable partial result is reported as *PartialDiscoveryError, callers treat it as a warning.
+		if !d.selectionPolicy.AllowPartialDiscovery || certARNs.Len() == 0 {
+			return nil, errors.New(partialErr.Error())
+		}
+		return certARNs.List(), partialErr
+	}
 	return certARNs.List(), nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context, filterTags map[string]string) (map[string]sets.String, error) {
-	d.loadDomainsByCertARNMutex.Lock()
-	defer d.loadDomainsByCertARNMutex.Unlock()
+// selectCertificatesForHost selects the certificates to use for host:
+//  1. certificates that are expired, or expire within MinRemainingValidity are skipped.
+//  2. certificates matching host exactly are preferred over wildcard certificates.
+//  3. for each key algorithm, the certificate expiring last is selected, so that clients can still negotiate RSA or ECDSA certificates.
+func (d *acmCertDiscovery) selectCertificatesForHost(host string, certDetailByARN map[string]certificateDetail, now time.Time) []string {
+	bestSpecificity := 0
+	candidatesByKeyAlgorithm := make(map[acmTypes.KeyAlgorithm][]string)
+	for certARN, certDetail := range certDetailByARN {
+		if !d.hasEnoughRemainingValidity(certDetail, now) {
+			continue
+		}
+		specificity := 0
+		for domain := range certDetail.domains {
+			specificity = max(specificity, d.domainSpecificityForHost(domain, host))
+		}
+		if specificity == 0 || specificity < bestSpecificity {
+			continue
+		}
+		if specificity > bestSpecificity {
+			bestSpecificity = specificity
+			candidatesByKeyAlgorithm = make(map[acmTypes.KeyAlgorithm][]string)
+		}
+		candidatesByKeyAlgorithm[certDetail.keyAlgorithm] = append(candidatesByKeyAlgorithm[certDetail.keyAlgorithm], certARN)
+	}
+
+	var certARNs []string
+	for _, candidates := range candidatesByKeyAlgorithm {
+		sort.Slice(candidates, func(i, j int) bool {
+			notAfterI, notAfterJ := certDetailByARN[candidates[i]].notAfter, certDetailByARN[candidates[j]].notAfter
+			if notAfterI != nil && notAfterJ != nil && !notAfterI.Equal(*notAfterJ) {
+				return notAfterI.After(*notAfterJ)
+			}
+			if (notAfterI == nil) != (notAfterJ == nil) {
+				return notAfterI != nil
+			}
+			return candidates[i] < candidates[j]
+		})
+		certARNs = append(certARNs, candidates[0])
+	}
+	return certARNs
+}
+
+func (d *acmCertDiscovery) hasEnoughRemainingValidity(certDetail certificateDetail, now time.Time) bool {
+	if certDetail.notAfter == nil {
+		return true
+	}
+	remainingValidity := certDetail.notAfter.Sub(now)
+	return remainingValidity > 0 && remainingValidity >= d.selectionPolicy.MinRemainingValidity
+}
+
+func (d *acmCertDiscovery) loadDetailsForAllCertificates(ctx context.Context, filterTags map[string]string) (map[string]certificateDetail, error) {
+	d.loadCertDetailsMutex.Lock()
+	defer d.loadCertDetailsMutex.Unlock()
 
 	certARNs, err := d.loadAllCertificateARNs(ctx, filterTags)
 	if err != nil {
 		return nil, err
 	}
-	domainsByCertARN := make(map[string]sets.String, len(certARNs))
+	certDetailByARN := make(map[string]certificateDetail, len(certARNs))
 	for _, certARN := range certARNs {
-		certDomains, err := d.loadDomainsForCertificate(ctx, certARN)
+		certDetail, err := d.loadDetailForCertificate(ctx, certARN)
 		if err != nil {
 			return nil, err
 		}
-		if len(certDomains) > 0 {
-			domainsByCertARN[certARN] = certDomains
+		if len(certDetail.domains) > 0 {
+			certDetailByARN[certARN] = certDetail
 		}
 
 	}
-	return domainsByCertARN, nil
+	return certDetailByARN, nil
 }
 
 func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context, filterTags map[string]string) ([]string, error) {
@@ -157,32 +245,48 @@ func (d *acmCertDiscovery) loadAllCertificateARNs(ctx context.Context, filterTag
 	return certARNs, nil
 }
 
-func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certARN string) (sets.String, error) {
+func (d *acmCertDiscovery) loadDetailForCertificate(ctx context.Context, certARN string) (certificateDetail, error) {
 	if rawCacheItem, ok := d.certDomainsCache.Get(certARN); ok {
-		return rawCacheItem.(sets.String), nil
+		return rawCacheItem.(certificateDetail), nil
 	}
 	req := &acm.DescribeCertificateInput{
 		CertificateArn: awssdk.String(certARN),
 	}
 	resp, err := d.acmClient.DescribeCertificateWithContext(ctx, req)
 	if err != nil {
-		return nil, err
+		return certificateDetail{}, err
 	}
 	certDetail := resp.Certificate
 
 	// check if cert is issued from an allowed CA
 	// otherwise empty-out the list of domains
-	domains := sets.String{}
+	detail := certificateDetail{
+		domains:      sets.String{},
+		keyAlgorithm: certDetail.KeyAlgorithm,
+		notAfter:     certDetail.NotAfter,
+	}
 	if len(d.allowedCAARNs) == 0 || slices.Contains(d.allowedCAARNs, awssdk.ToString(certDetail.CertificateAuthorityArn)) {
-		domains = sets.NewString(certDetail.SubjectAlternativeNames...)
+		detail.domains = sets.NewString(certDetail.SubjectAlternativeNames...)
 	}
 	switch certDetail.Type {
 	case acmTypes.CertificateTypeImported:
-		d.certDomainsCache.Set(certARN, domains, d.importedCertDomainsCacheTTL)
+		d.certDomainsCache.Set(certARN, detail, d.importedCertDomainsCacheTTL)
 	case acmTypes.CertificateTypeAmazonIssued, acmTypes.CertificateTypePrivate:
-		d.certDomainsCache.Set(certARN, domains, d.privateCertDomainsCacheTTL)
+		d.certDomainsCache.Set(certARN, detail, d.privateCertDomainsCacheTTL)
+	}
+	return detail, nil
+}
+
+// domainSpecificityForHost returns how specifically domainName matches tlsHost:
+// 2 for an exact match, 1 for a wildcard match, and 0 when it doesn't match.
+func (d *acmCertDiscovery) domainSpecificityForHost(domainName string, tlsHost string) int {
+	if !d.domainMatchesHost(domainName, tlsHost) {
+		return 0
+	}
+	if strings.HasPrefix(domainName, "*.") {
+		return 1
 	}
-	return domains, nil
+	return 2
 }
 
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
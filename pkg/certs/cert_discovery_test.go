@@ -1,12 +1,15 @@
 package certs
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/acm"
 	acmTypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
 	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/go-logr/logr"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/cache"
@@ -178,3 +181,154 @@ func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_acmCertDiscovery_Discover(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	type certificate struct {
+		arn          string
+		domains      []string
+		keyAlgorithm acmtypes.KeyAlgorithm
+		notAfter     time.Time
+	}
+	exactCert := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/exact",
+		domains:      []string{"app.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmRsa2048,
+		notAfter:     now.Add(90 * 24 * time.Hour),
+	}
+	exactCertRenewed := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/exact-renewed",
+		domains:      []string{"app.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmRsa2048,
+		notAfter:     now.Add(395 * 24 * time.Hour),
+	}
+	exactCertECDSA := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/exact-ecdsa",
+		domains:      []string{"app.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmEcPrime256v1,
+		notAfter:     now.Add(90 * 24 * time.Hour),
+	}
+	exactCertExpired := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/exact-expired",
+		domains:      []string{"app.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmRsa2048,
+		notAfter:     now.Add(-time.Hour),
+	}
+	exactCertExpiringSoon := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/exact-expiring-soon",
+		domains:      []string{"app.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmRsa2048,
+		notAfter:     now.Add(3 * 24 * time.Hour),
+	}
+	wildcardCert := certificate{
+		arn:          "arn:aws:acm:us-west-2:123456789012:certificate/wildcard",
+		domains:      []string{"*.example.com"},
+		keyAlgorithm: acmtypes.KeyAlgorithmRsa2048,
+		notAfter:     now.Add(365 * 24 * time.Hour),
+	}
+
+	tests := []struct {
+		name            string
+		certificates    []certificate
+		selectionPolicy SelectionPolicy
+		tlsHosts        []string
+		want            []string
+		wantErr         string
+	}{
+		{
+			name:         "exact match is preferred over wildcard match",
+			certificates: []certificate{exactCert, wildcardCert},
+			tlsHosts:     []string{"app.example.com", "api.example.com"},
+			want:         []string{exactCert.arn, wildcardCert.arn},
+		},
+		{
+			name:         "certificate expiring last is preferred",
+			certificates: []certificate{exactCert, exactCertRenewed},
+			tlsHosts:     []string{"app.example.com"},
+			want:         []string{exactCertRenewed.arn},
+		},
+		{
+			name:         "certificates with different key algorithms are both selected",
+			certificates: []certificate{exactCert, exactCertECDSA},
+			tlsHosts:     []string{"app.example.com"},
+			want:         []string{exactCert.arn, exactCertECDSA.arn},
+		},
+		{
+			name:         "expired certificate is skipped in favor of wildcard match",
+			certificates: []certificate{exactCertExpired, wildcardCert},
+			tlsHosts:     []string{"app.example.com"},
+			want:         []string{wildcardCert.arn},
+		},
+		{
+			name:            "certificate expiring within min remaining validity is skipped",
+			certificates:    []certificate{exactCertExpiringSoon, wildcardCert},
+			selectionPolicy: SelectionPolicy{MinRemainingValidity: 7 * 24 * time.Hour},
+			tlsHosts:        []string{"app.example.com"},
+			want:            []string{wildcardCert.arn},
+		},
+		{
+			name:         "certificate expiring soon is kept without min remaining validity",
+			certificates: []certificate{exactCertExpiringSoon, wildcardCert},
+			tlsHosts:     []string{"app.example.com"},
+			want:         []string{exactCertExpiringSoon.arn},
+		},
+		{
+			name:         "unmatched host fails discovery",
+			certificates: []certificate{exactCert},
+			tlsHosts:     []string{"app.example.com", "app.example.org"},
+			wantErr:      "no certificate found for host: app.example.org",
+		},
+		{
+			name:            "unmatched host is reported with partial discovery",
+			certificates:    []certificate{exactCert},
+			selectionPolicy: SelectionPolicy{AllowPartialDiscovery: true},
+			tlsHosts:        []string{"app.example.com", "app.example.org"},
+			want:            []string{exactCert.arn},
+			wantErr:         "no certificate found for host: app.example.org",
+		},
+		{
+			name:            "partial discovery still fails without any certificate",
+			certificates:    []certificate{exactCertExpired},
+			selectionPolicy: SelectionPolicy{AllowPartialDiscovery: true},
+			tlsHosts:        []string{"app.example.com"},
+			wantErr:         "no certificate found for host: app.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockACM := services.NewMockACM(ctrl)
+			var certSummaries []acmtypes.CertificateSummary
+			for _, cert := range tt.certificates {
+				certSummaries = append(certSummaries, acmtypes.CertificateSummary{CertificateArn: awssdk.String(cert.arn)})
+				mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), &acm.DescribeCertificateInput{
+					CertificateArn: awssdk.String(cert.arn),
+				}).Return(&acm.DescribeCertificateOutput{
+					Certificate: &acmtypes.CertificateDetail{
+						CertificateArn:          awssdk.String(cert.arn),
+						SubjectAlternativeNames: cert.domains,
+						KeyAlgorithm:            cert.keyAlgorithm,
+						NotAfter:                awssdk.Time(cert.notAfter),
+						Type:                    acmtypes.CertificateTypeAmazonIssued,
+					},
+				}, nil)
+			}
+			mockACM.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return(certSummaries, nil)
+
+			d := NewACMCertDiscovery(mockACM, nil, false, tt.selectionPolicy, logr.Discard())
+			d.clock = func() time.Time { return now }
+
+			got, err := d.Discover(t.Context(), tt.tlsHosts, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				var partialErr *PartialDiscoveryError
+				assert.Equal(t, len(tt.want) != 0, errors.As(err, &partialErr))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package certs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// jitter applied to the scan interval to avoid synchronized scans across restarts.
+	expiryScanIntervalJitterFactor = 0.1
+
+	// expirySoonThreshold is the remaining validity below which expiring certificates are logged.
+	expirySoonThreshold = 30 * 24 * time.Hour
+)
+
+var _ manager.Runnable = &certExpiryMonitor{}
+var _ manager.LeaderElectionRunnable = &certExpiryMonitor{}
+
+// NewCertExpiryMonitor constructs new certExpiryMonitor.
+func NewCertExpiryMonitor(rgtClient services.RGT, elbv2Client services.ELBV2, acmClient services.ACM, clusterName string,
+	interval time.Duration, metricsCollector lbcmetrics.CertificateExpiryMetricsCollector, logger logr.Logger) *certExpiryMonitor {
+	return &certExpiryMonitor{
+		rgtClient:        rgtClient,
+		elbv2Client:      elbv2Client,
+		acmClient:        acmClient,
+		clusterName:      clusterName,
+		interval:         interval,
+		metricsCollector: metricsCollector,
+		logger:           logger,
+		clock:            time.Now,
+	}
+}
+
+// certExpiryMonitor periodically exports the days to expiry of the ACM certificates attached to the listeners of
+// load balancers managed by this cluster, so that renewals ACM couldn't complete automatically get noticed before they expire.
+type certExpiryMonitor struct {
+	rgtClient        services.RGT
+	elbv2Client      services.ELBV2
+	acmClient        services.ACM
+	clusterName      string
+	interval         time.Duration
+	metricsCollector lbcmetrics.CertificateExpiryMetricsCollector
+	logger           logr.Logger
+	clock            func() time.Time
+}
+
+// Start runs the monitoring loop until ctx is done.
+func (m *certExpiryMonitor) Start(ctx context.Context) error {
+	m.logger.Info("starting certificate expiry monitor", "interval", m.interval)
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.Scan(ctx); err != nil {
+			m.logger.Error(err, "failed to scan certificates for expiry")
+		}
+	}, m.interval, expiryScanIntervalJitterFactor, true)
+	return nil
+}
+
+// NeedLeaderElection makes sure only the leader scans certificates.
+func (m *certExpiryMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Scan runs a single scan of the certificates attached to managed listeners.
+func (m *certExpiryMonitor) Scan(ctx context.Context) error {
+	certARNs, err := m.listAttachedACMCertificateARNs(ctx)
+	if err != nil {
+		return err
+	}
+	now := m.clock()
+	daysToExpiryByARN := make(map[string]float64, len(certARNs))
+	for _, certARN := range certARNs.List() {
+		resp, err := m.acmClient.DescribeCertificateWithContext(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: awssdk.String(certARN),
+		})
+		if err != nil {
+			return err
+		}
+		notAfter := resp.Certificate.NotAfter
+		if notAfter == nil {
+			continue
+		}
+		remainingValidity := notAfter.Sub(now)
+		daysToExpiryByARN[certARN] = remainingValidity.Hours() / 24
+		if remainingValidity < expirySoonThreshold {
+			var renewalStatus string
+			if resp.Certificate.RenewalSummary != nil {
+				renewalStatus = string(resp.Certificate.RenewalSummary.RenewalStatus)
+			}
+			m.logger.Info("certificate attached to managed listeners expires soon",
+				"certificateARN", certARN, "notAfter", *notAfter, "renewalStatus", renewalStatus)
+		}
+	}
+	m.metricsCollector.ObserveCertificateDaysToExpiry(daysToExpiryByARN)
+	return nil
+}
+
+// listAttachedACMCertificateARNs lists the ACM certificates attached to the secure listeners of load balancers tagged for this cluster.
+// certificates imported into IAM are ignored.
+func (m *certExpiryMonitor) listAttachedACMCertificateARNs(ctx context.Context) (sets.String, error) {
+	resources, err := m.rgtClient.GetResourcesAsList(ctx, &rgtsdk.GetResourcesInput{
+		TagFilters: []rgttypes.TagFilter{
+			{
+				Key:    awssdk.String(shared_constants.TagKeyK8sCluster),
+				Values: []string{m.clusterName},
+			},
+		},
+		ResourceTypeFilters: []string{services.ResourceTypeELBLoadBalancer},
+	})
+	if err != nil {
+		return nil, err
+	}
+	certARNs := sets.NewString()
+	for _, resource := range resources {
+		listeners, err := m.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{
+			LoadBalancerArn: resource.ResourceARN,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, listener := range listeners {
+			if listener.Protocol != elbv2types.ProtocolEnumHttps && listener.Protocol != elbv2types.ProtocolEnumTls {
+				continue
+			}
+			certs, err := m.elbv2Client.DescribeListenerCertificatesAsList(ctx, &elbv2sdk.DescribeListenerCertificatesInput{
+				ListenerArn: listener.ListenerArn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, cert := range certs {
+				certARN := awssdk.ToString(cert.CertificateArn)
+				if isACMCertificateARN(certARN) {
+					certARNs.Insert(certARN)
+				}
+			}
+		}
+	}
+	return certARNs, nil
+}
+
+func isACMCertificateARN(certARN string) bool {
+	parsedARN, err := arn.Parse(certARN)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsedARN.Service, "acm")
+}
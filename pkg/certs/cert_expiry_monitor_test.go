@@ -0,0 +1,113 @@
+package certs
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/go-logr/logr"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+type fakeCertificateExpiryMetricsCollector struct {
+	daysToExpiryByARN map[string]float64
+}
+
+func (c *fakeCertificateExpiryMetricsCollector) ObserveCertificateDaysToExpiry(daysToExpiryByARN map[string]float64) {
+	c.daysToExpiryByARN = daysToExpiryByARN
+}
+
+func Test_certExpiryMonitor_Scan(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-awesomegroup/abc"
+	httpsListenerARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/k8s-awesomegroup/abc/https"
+	httpListenerARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/k8s-awesomegroup/abc/http"
+	defaultCertARN := "arn:aws:acm:us-west-2:123456789012:certificate/default"
+	sniCertARN := "arn:aws:acm:us-west-2:123456789012:certificate/sni"
+	iamCertARN := "arn:aws:iam::123456789012:server-certificate/legacy"
+
+	tests := []struct {
+		name                  string
+		describeCertErr       error
+		wantDaysToExpiryByARN map[string]float64
+		wantErr               string
+	}{
+		{
+			name: "days to expiry are exported for ACM certificates of secure listeners",
+			wantDaysToExpiryByARN: map[string]float64{
+				defaultCertARN: 10,
+				sniCertARN:     -0.5,
+			},
+		},
+		{
+			name:            "failure to describe certificates keeps previous metrics",
+			describeCertErr: errors.New("throttled"),
+			wantErr:         "throttled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRGT := services.NewMockRGT(ctrl)
+			mockELBV2 := services.NewMockELBV2(ctrl)
+			mockACM := services.NewMockACM(ctrl)
+			mockRGT.EXPECT().GetResourcesAsList(gomock.Any(), gomock.Any()).Return([]rgttypes.ResourceTagMapping{
+				{ResourceARN: awssdk.String(lbARN)},
+			}, nil)
+			mockELBV2.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+				LoadBalancerArn: awssdk.String(lbARN),
+			}).Return([]elbv2types.Listener{
+				{ListenerArn: awssdk.String(httpListenerARN), Protocol: elbv2types.ProtocolEnumHttp},
+				{ListenerArn: awssdk.String(httpsListenerARN), Protocol: elbv2types.ProtocolEnumHttps},
+			}, nil)
+			mockELBV2.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), &elbv2sdk.DescribeListenerCertificatesInput{
+				ListenerArn: awssdk.String(httpsListenerARN),
+			}).Return([]elbv2types.Certificate{
+				{CertificateArn: awssdk.String(defaultCertARN), IsDefault: awssdk.Bool(true)},
+				{CertificateArn: awssdk.String(sniCertARN)},
+				{CertificateArn: awssdk.String(iamCertARN)},
+			}, nil)
+			if tt.describeCertErr != nil {
+				mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), gomock.Any()).Return(nil, tt.describeCertErr)
+			} else {
+				mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), &acm.DescribeCertificateInput{
+					CertificateArn: awssdk.String(defaultCertARN),
+				}).Return(&acm.DescribeCertificateOutput{
+					Certificate: &acmtypes.CertificateDetail{NotAfter: awssdk.Time(now.Add(10 * 24 * time.Hour))},
+				}, nil)
+				mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), &acm.DescribeCertificateInput{
+					CertificateArn: awssdk.String(sniCertARN),
+				}).Return(&acm.DescribeCertificateOutput{
+					Certificate: &acmtypes.CertificateDetail{
+						NotAfter: awssdk.Time(now.Add(-12 * time.Hour)),
+						RenewalSummary: &acmtypes.RenewalSummary{
+							RenewalStatus: acmtypes.RenewalStatusPendingValidation,
+						},
+					},
+				}, nil)
+			}
+
+			metricsCollector := &fakeCertificateExpiryMetricsCollector{}
+			m := NewCertExpiryMonitor(mockRGT, mockELBV2, mockACM, "cluster", time.Hour, metricsCollector, logr.Discard())
+			m.clock = func() time.Time { return now }
+
+			err := m.Scan(t.Context())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDaysToExpiryByARN, metricsCollector.daysToExpiryByARN)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
+)
+
+const (
+	flagCertDiscoveryMinRemainingValidity    = "cert-discovery-min-remaining-validity"
+	flagCertDiscoveryAllowPartial            = "cert-discovery-allow-partial"
+	flagCertExpiryMonitorInterval            = "cert-expiry-monitor-interval"
+	defaultCertDiscoveryMinRemainingValidity = 0
+	defaultCertDiscoveryAllowPartial         = false
+	defaultCertExpiryMonitorInterval         = time.Hour
+)
+
+// CertDiscoveryConfig contains the configurations for the discovery and monitoring of ACM certificates.
+type CertDiscoveryConfig struct {
+	// MinRemainingValidity is the minimum remaining validity of a certificate to be discovered.
+	MinRemainingValidity time.Duration
+
+	// AllowPartialDiscovery specifies whether to use the certificates discovered for some hosts
+	// when other hosts have no certificate, instead of failing the reconcile.
+	AllowPartialDiscovery bool
+
+	// ExpiryMonitorInterval is the interval between two scans of the certificates attached to managed listeners.
+	// The monitor only runs when the CertificateExpiryMonitor feature gate is enabled.
+	ExpiryMonitorInterval time.Duration
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *CertDiscoveryConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&cfg.MinRemainingValidity, flagCertDiscoveryMinRemainingValidity, defaultCertDiscoveryMinRemainingValidity,
+		"Minimum remaining validity of a certificate to be discovered, expired certificates are never discovered")
+	fs.BoolVar(&cfg.AllowPartialDiscovery, flagCertDiscoveryAllowPartial, defaultCertDiscoveryAllowPartial,
+		"Use the certificates discovered for some hosts when other hosts have no certificate, instead of failing")
+	fs.DurationVar(&cfg.ExpiryMonitorInterval, flagCertExpiryMonitorInterval, defaultCertExpiryMonitorInterval,
+		"Interval between scans of the certificates attached to managed listeners for expiry")
+}
+
+// SelectionPolicy returns the policy to select discovered certificates.
+func (cfg *CertDiscoveryConfig) SelectionPolicy() certs.SelectionPolicy {
+	return certs.SelectionPolicy{
+		MinRemainingValidity:  cfg.MinRemainingValidity,
+		AllowPartialDiscovery: cfg.AllowPartialDiscovery,
+	}
+}
+
+// Validate the cert discovery configuration
+func (cfg *CertDiscoveryConfig) Validate() error {
+	if cfg.MinRemainingValidity < 0 {
+		return errors.Errorf("%v must not be negative", flagCertDiscoveryMinRemainingValidity)
+	}
+	if cfg.ExpiryMonitorInterval <= 0 {
+		return errors.Errorf("%v must be positive", flagCertExpiryMonitorInterval)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/pod_readiness"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/pod_termination"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/quic"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
 
@@ -40,6 +41,8 @@ const (
 	flagDisableRestrictedSGRules                     = "disable-restricted-sg-rules"
 	flagMaxTargetsPerTargetGroup                     = "max-targets-per-target-group"
 	flagTargetGroupBindingRequeueDuration            = "targetgroupbinding-requeue-duration"
+	flagTargetHealthRefreshInterval                  = "targetgroupbinding-target-health-refresh-interval"
+	flagTrafficRolloutPrometheusAddresses            = "traffic-rollout-prometheus-addresses"
 	defaultLogLevel                                  = "info"
 	defaultGlobalAcceleratorMaxConcurrentReconciles  = 1
 	defaultMaxConcurrentReconciles                   = 3
@@ -52,6 +55,7 @@ const (
 	defaultLbStabilizationMonitorInterval            = time.Second * 120
 	defaultMaxTargetsPerTargetGroup                  = 0
 	defaultTargetGroupBindingRequeuDuration          = time.Second * 15
+	defaultTargetHealthRefreshInterval               = time.Minute * 5
 )
 
 var (
@@ -81,6 +85,8 @@ type ControllerConfig struct {
 	RuntimeConfig RuntimeConfig
 	// Configurations for Pod inject webhook
 	PodWebhookConfig pod_readiness.PodReadinessGateConfig
+	// Configurations for Pod termination gate
+	PodTerminationGateConfig pod_termination.PodTerminationGateConfig
 	// Configurations for QUIC integration.
 	ServerIDInjectionConfig quic.ServerIDInjectionConfig
 	// Configurations for the Ingress controller
@@ -89,6 +95,10 @@ type ControllerConfig struct {
 	AddonsConfig AddonsConfig
 	// Configurations for the Service controller
 	ServiceConfig ServiceConfig
+	// Configurations for the orphaned AWS resource garbage collector
+	OrphanedResourceGCConfig OrphanedResourceGCConfig
+	// Configurations for the discovery and monitoring of ACM certificates
+	CertDiscoveryConfig CertDiscoveryConfig
 
 	// Default AWS Tags that will be applied to all AWS resources managed by this controller.
 	DefaultTags map[string]string
@@ -158,6 +168,13 @@ type ControllerConfig struct {
 	// for AWS resources to update.
 	TargetGroupBindingRequeueDuration time.Duration
 
+	// TargetHealthRefreshInterval specifies the interval to refresh the target health
+	// in TargetGroupBinding status when its targets are unchanged.
+	TargetHealthRefreshInterval time.Duration
+
+	// TrafficRolloutPrometheusAddresses are the Prometheus servers that TrafficRollouts are allowed to query.
+	TrafficRolloutPrometheusAddresses []string
+
 	FeatureGates FeatureGates
 }
 
@@ -210,15 +227,22 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Maximum number of targets that can be added to an ELB instance. Use this to prevent TargetGroup quotas being exceeded from blocking reconciliation.")
 	fs.DurationVar(&cfg.TargetGroupBindingRequeueDuration, flagTargetGroupBindingRequeueDuration, defaultTargetGroupBindingRequeuDuration,
 		"Duration after which TargetGroupBinding will be requeued for reconciliation when it's waiting for AWS resources to update.")
+	fs.DurationVar(&cfg.TargetHealthRefreshInterval, flagTargetHealthRefreshInterval, defaultTargetHealthRefreshInterval,
+		"Interval to refresh the target health in TargetGroupBinding status when its targets are unchanged. Set to 0 to refresh only when targets change.")
+	fs.StringSliceVar(&cfg.TrafficRolloutPrometheusAddresses, flagTrafficRolloutPrometheusAddresses, nil,
+		"Addresses of the Prometheus servers that TrafficRollouts are allowed to query")
 	cfg.FeatureGates.BindFlags(fs)
 	cfg.AWSConfig.BindFlags(fs)
 	cfg.RuntimeConfig.BindFlags(fs)
 
 	cfg.PodWebhookConfig.BindFlags(fs)
+	cfg.PodTerminationGateConfig.BindFlags(fs)
 	cfg.ServerIDInjectionConfig.BindFlags(fs)
 	cfg.IngressConfig.BindFlags(fs)
 	cfg.AddonsConfig.BindFlags(fs)
 	cfg.ServiceConfig.BindFlags(fs)
+	cfg.OrphanedResourceGCConfig.BindFlags(fs)
+	cfg.CertDiscoveryConfig.BindFlags(fs)
 }
 
 // Validate the controller configuration
@@ -248,6 +272,12 @@ func (cfg *ControllerConfig) Validate() error {
 	if err := cfg.validateManageBackendSecurityGroupRulesConfiguration(); err != nil {
 		return err
 	}
+	if err := cfg.OrphanedResourceGCConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.CertDiscoveryConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -42,6 +42,12 @@ const (
 	GatewayListenerSet            Feature = "GatewayListenerSet"
 	EnableCertificateManagement   Feature = "EnableCertificateManagement"
 	IngressPlanAnnotation         Feature = "IngressPlanAnnotation"
+	OrphanedResourceGC            Feature = "OrphanedResourceGC"
+	TrafficRollout                Feature = "TrafficRollout"
+	CertificateExpiryMonitor      Feature = "CertificateExpiryMonitor"
+	WAFv2WebACLManagement         Feature = "WAFv2WebACLManagement"
+	QUICServerIDRegistry          Feature = "QUICServerIDRegistry"
+	AGAReplacementProtection      Feature = "AGAReplacementProtection"
 )
 
 type FeatureGates interface {
@@ -97,6 +103,12 @@ func NewFeatureGates() FeatureGates {
 			GatewayListenerSet:            generateDefaultFeatureStatus(true),
 			EnableCertificateManagement:   generateDefaultFeatureStatus(false),
 			IngressPlanAnnotation:         generateDefaultFeatureStatus(false),
+			OrphanedResourceGC:            generateDefaultFeatureStatus(false),
+			TrafficRollout:                generateDefaultFeatureStatus(false),
+			CertificateExpiryMonitor:      generateDefaultFeatureStatus(false),
+			WAFv2WebACLManagement:         generateDefaultFeatureStatus(false),
+			QUICServerIDRegistry:          generateDefaultFeatureStatus(false),
+			AGAReplacementProtection:      generateDefaultFeatureStatus(true),
 		},
 	}
 }
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagOrphanedResourceGCInterval         = "orphaned-resource-gc-interval"
+	flagOrphanedResourceGCQuarantinePeriod = "orphaned-resource-gc-quarantine-period"
+	flagOrphanedResourceGCDryRun           = "orphaned-resource-gc-dry-run"
+	defaultOrphanedResourceGCInterval      = time.Hour
+	defaultOrphanedResourceGCQuarantine    = time.Hour * 24
+	defaultOrphanedResourceGCDryRun        = true
+)
+
+// OrphanedResourceGCConfig contains the configurations for the orphaned AWS resource garbage collector.
+// The garbage collector only runs when the OrphanedResourceGC feature gate is enabled.
+type OrphanedResourceGCConfig struct {
+	// Interval between two scans for orphaned resources.
+	Interval time.Duration
+
+	// QuarantinePeriod is the duration a stack must stay orphaned before its resources are deleted.
+	QuarantinePeriod time.Duration
+
+	// DryRun only reports orphaned resources without deleting them.
+	DryRun bool
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *OrphanedResourceGCConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&cfg.Interval, flagOrphanedResourceGCInterval, defaultOrphanedResourceGCInterval,
+		"Interval between scans for orphaned AWS resources")
+	fs.DurationVar(&cfg.QuarantinePeriod, flagOrphanedResourceGCQuarantinePeriod, defaultOrphanedResourceGCQuarantine,
+		"Duration an orphaned AWS resource stack must stay orphaned before it's deleted")
+	fs.BoolVar(&cfg.DryRun, flagOrphanedResourceGCDryRun, defaultOrphanedResourceGCDryRun,
+		"Only report orphaned AWS resources without deleting them")
+}
+
+// Validate the orphaned resource GC configuration
+func (cfg *OrphanedResourceGCConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.Errorf("%v must be positive", flagOrphanedResourceGCInterval)
+	}
+	if cfg.QuarantinePeriod < 0 {
+		return errors.Errorf("%v must not be negative", flagOrphanedResourceGCQuarantinePeriod)
+	}
+	return nil
+}
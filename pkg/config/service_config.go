@@ -3,18 +3,30 @@ package config
 import "github.com/spf13/pflag"
 
 const (
-	flagLoadBalancerClass    = "load-balancer-class"
-	defaultLoadBalancerClass = "service.k8s.aws/nlb"
+	flagLoadBalancerClass             = "load-balancer-class"
+	flagAdditionalLoadBalancerClasses = "additional-load-balancer-classes"
+	defaultLoadBalancerClass          = "service.k8s.aws/nlb"
 )
 
 // ServiceConfig contains the configurations for the Service controller
 type ServiceConfig struct {
 	// LoadBalancerClass is the name of the load balancer class reconciled by this controller
 	LoadBalancerClass string
+
+	// AdditionalLoadBalancerClasses are the names of other load balancer classes reconciled by this controller,
+	// typically each selected by a ServiceClassParams
+	AdditionalLoadBalancerClasses []string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
 func (cfg *ServiceConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.LoadBalancerClass, flagLoadBalancerClass, defaultLoadBalancerClass,
 		"Name of the load balancer class reconciled by this controller")
+	fs.StringSliceVar(&cfg.AdditionalLoadBalancerClasses, flagAdditionalLoadBalancerClasses, nil,
+		"Names of additional load balancer classes reconciled by this controller")
+}
+
+// LoadBalancerClasses returns all the load balancer classes reconciled by this controller
+func (cfg *ServiceConfig) LoadBalancerClasses() []string {
+	return append([]string{cfg.LoadBalancerClass}, cfg.AdditionalLoadBalancerClasses...)
 }
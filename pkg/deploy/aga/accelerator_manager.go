@@ -2,9 +2,13 @@ package aga
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/netip"
 	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
@@ -76,6 +80,13 @@ func (m *defaultAcceleratorManager) buildSDKCreateAcceleratorInput(_ context.Con
 
 func (m *defaultAcceleratorManager) Create(ctx context.Context, resAccelerator *agamodel.Accelerator) (agamodel.AcceleratorStatus, error) {
 
+	// BYOIP feature: Validate IP addresses against the provisioned address ranges
+	if len(resAccelerator.Spec.IpAddresses) > 0 {
+		if err := m.validateBYOIPAddresses(ctx, resAccelerator.Spec.IpAddresses); err != nil {
+			return agamodel.AcceleratorStatus{}, err
+		}
+	}
+
 	// Build create input
 	createInput := m.buildSDKCreateAcceleratorInput(ctx, resAccelerator)
 
@@ -271,30 +282,88 @@ func (m *defaultAcceleratorManager) isSDKAcceleratorSettingsDrifted(resAccelerat
 		return true
 	}
 
-	// Check if user attempts to change IP addresses (BYOIP only supported at creation)
-	if len(resAccelerator.Spec.IpAddresses) > 0 && !m.areIPAddressesEqual(resAccelerator.Spec.IpAddresses, sdkAccelerator.Accelerator.IpSets) {
+	// IP addresses can't be updated, the accelerator synthesizer replaces the accelerator to assign them (BYOIP only supported at creation)
+	if len(resAccelerator.Spec.IpAddresses) > 0 && !areIPv4AddressesAssigned(resAccelerator.Spec.IpAddresses, sdkAccelerator.Accelerator.IpSets) {
 		m.logger.Info("IP addresses cannot be updated after accelerator creation, ignoring IP address changes")
 	}
 
 	return false
 }
 
-func (m *defaultAcceleratorManager) areIPAddressesEqual(desiredIPs []string, actualIPSets []agatypes.IpSet) bool {
+// areIPv4AddressesAssigned checks whether all desired IPv4 addresses are assigned to the accelerator.
+// When a single BYOIP address is desired, Global Accelerator assigns the second address from the Amazon pool.
+// IPv6 BYOIP is not supported at this time
+func areIPv4AddressesAssigned(desiredIPs []string, actualIPSets []agatypes.IpSet) bool {
+	actualIPv4s := extractIPv4Addresses(actualIPSets)
+	for _, desiredIP := range desiredIPs {
+		if !slices.Contains(actualIPv4s, desiredIP) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateBYOIPAddresses checks that the desired IP addresses belong to BYOIP address ranges provisioned to Global Accelerator, one address per range.
+// Ranges don't need to be advertised yet, as addresses are usually assigned to the accelerator before advertising the range.
+func (m *defaultAcceleratorManager) validateBYOIPAddresses(ctx context.Context, ipAddresses []string) error {
+	byoipCidrs, err := m.gaService.ListByoipCidrsAsList(ctx, &globalaccelerator.ListByoipCidrsInput{})
+	if err != nil {
+		return fmt.Errorf("failed to list BYOIP address ranges: %w", err)
+	}
 
-	// IPv6 BYOIP is not supported at this time
-	return m.areIPv4AddressesEqual(desiredIPs, actualIPSets)
+	var problems []string
+	usedCidrs := make(map[string]string)
+	for _, ipAddress := range ipAddresses {
+		addr, err := netip.ParseAddr(ipAddress)
+		if err != nil || !addr.Is4() {
+			problems = append(problems, fmt.Sprintf("%s is not a valid IPv4 address", ipAddress))
+			continue
+		}
+		byoipCidr, found := findBYOIPCidr(byoipCidrs, addr)
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s doesn't belong to a BYOIP address range", ipAddress))
+			continue
+		}
+		cidr := awssdk.ToString(byoipCidr.Cidr)
+		if !isBYOIPCidrProvisioned(byoipCidr.State) {
+			problems = append(problems, fmt.Sprintf("%s belongs to BYOIP address range %s in state %s", ipAddress, cidr, byoipCidr.State))
+			continue
+		}
+		if otherIPAddress, used := usedCidrs[cidr]; used {
+			problems = append(problems, fmt.Sprintf("%s and %s belong to the same BYOIP address range %s", otherIPAddress, ipAddress, cidr))
+			continue
+		}
+		usedCidrs[cidr] = ipAddress
+		if byoipCidr.State != agatypes.ByoipCidrStateAdvertising {
+			m.logger.Info("BYOIP address range is not advertised, the accelerator won't receive traffic on its address until it is",
+				"ipAddress", ipAddress, "cidr", cidr, "state", byoipCidr.State)
+		}
+	}
+	if len(problems) > 0 {
+		return &InvalidBYOIPAddressesError{Message: fmt.Sprintf("invalid BYOIP addresses: %s", strings.Join(problems, "; "))}
+	}
+	return nil
 }
 
-// areIPv4AddressesEqual compares desired IPv4 addresses with actual IP sets from AWS
-func (m *defaultAcceleratorManager) areIPv4AddressesEqual(desiredIPs []string, actualIPSets []agatypes.IpSet) bool {
-	actualIPv4s := extractIPv4Addresses(actualIPSets)
-	if len(desiredIPs) != len(actualIPv4s) {
-		return false
+// findBYOIPCidr returns the BYOIP address range containing addr.
+func findBYOIPCidr(byoipCidrs []agatypes.ByoipCidr, addr netip.Addr) (agatypes.ByoipCidr, bool) {
+	for _, byoipCidr := range byoipCidrs {
+		prefix, err := netip.ParsePrefix(awssdk.ToString(byoipCidr.Cidr))
+		if err == nil && prefix.Contains(addr) {
+			return byoipCidr, true
+		}
 	}
+	return agatypes.ByoipCidr{}, false
+}
 
-	slices.Sort(desiredIPs)
-	slices.Sort(actualIPv4s)
-	return slices.Equal(desiredIPs, actualIPv4s)
+// isBYOIPCidrProvisioned checks whether addresses of a BYOIP address range in the state can be assigned to accelerators.
+func isBYOIPCidrProvisioned(state agatypes.ByoipCidrState) bool {
+	switch state {
+	case agatypes.ByoipCidrStateReady, agatypes.ByoipCidrStatePendingAdvertising, agatypes.ByoipCidrStateAdvertising,
+		agatypes.ByoipCidrStatePendingWithdrawing, agatypes.ByoipCidrStateFailedAdvertising, agatypes.ByoipCidrStateFailedWithdraw:
+		return true
+	}
+	return false
 }
 
 // extractIPv4Addresses extracts IPv4 addresses from IPSets
@@ -310,7 +379,13 @@ func extractIPv4Addresses(ipSets []agatypes.IpSet) []string {
 
 func (m *defaultAcceleratorManager) getIdempotencyToken(resAccelerator *agamodel.Accelerator) string {
 	// Use the CRD's UID as the idempotency token as its unique
-	return resAccelerator.GetCRDUID()
+	replacedARN := resAccelerator.GetARNFromCRDStatus()
+	if replacedARN == "" {
+		return resAccelerator.GetCRDUID()
+	}
+	// An accelerator replacing the one in the CRD status needs its own token, otherwise the replaced accelerator would be returned
+	checksum := sha256.Sum256([]byte(replacedARN))
+	return resAccelerator.GetCRDUID() + "-" + hex.EncodeToString(checksum[:4])
 }
 
 // listListeners lists all listeners for a given accelerator
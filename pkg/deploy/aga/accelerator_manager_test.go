@@ -943,7 +943,7 @@ func Test_defaultAcceleratorManager_isSDKAcceleratorSettingsDrifted(t *testing.T
 	}
 }
 
-func Test_defaultAcceleratorManager_areIPv4AddressesEqual(t *testing.T) {
+func Test_areIPv4AddressesAssigned(t *testing.T) {
 	tests := []struct {
 		name           string
 		desiredIPs     []string
@@ -965,6 +965,13 @@ func Test_defaultAcceleratorManager_areIPv4AddressesEqual(t *testing.T) {
 			expectedResult: false,
 			description:    "BYOIP present, Amazon auto-assigned the other",
 		},
+		{
+			name:           "One BYOIP assigned, Amazon auto-assigned the other - no drift",
+			desiredIPs:     []string{"169.254.8.13"},
+			actualIPSets:   makeIPSets([]string{"169.254.8.13", "99.82.158.217"}),
+			expectedResult: true,
+			description:    "Single BYOIP present alongside the Amazon pool address",
+		},
 		{
 			name:           "One BYOIP missing from current - drift detected",
 			desiredIPs:     []string{"169.254.9.13"},
@@ -990,15 +997,93 @@ func Test_defaultAcceleratorManager_areIPv4AddressesEqual(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			result := areIPv4AddressesAssigned(tt.desiredIPs, tt.actualIPSets)
+			assert.Equal(t, tt.expectedResult, result, tt.description)
+		})
+	}
+}
+
+func Test_defaultAcceleratorManager_validateBYOIPAddresses(t *testing.T) {
+	byoipCidrs := []agatypes.ByoipCidr{
+		{Cidr: aws.String("198.51.100.0/24"), State: agatypes.ByoipCidrStateAdvertising},
+		{Cidr: aws.String("203.0.113.0/24"), State: agatypes.ByoipCidrStateReady},
+		{Cidr: aws.String("192.0.2.0/24"), State: agatypes.ByoipCidrStatePendingProvisioning},
+	}
+	tests := []struct {
+		name        string
+		ipAddresses []string
+		listErr     error
+		wantErr     string
+	}{
+		{
+			name:        "addresses of advertised and provisioned ranges",
+			ipAddresses: []string{"198.51.100.10", "203.0.113.10"},
+		},
+		{
+			name:        "address outside BYOIP ranges",
+			ipAddresses: []string{"198.51.100.10", "233.252.0.10"},
+			wantErr:     "invalid BYOIP addresses: 233.252.0.10 doesn't belong to a BYOIP address range",
+		},
+		{
+			name:        "address of range not provisioned",
+			ipAddresses: []string{"192.0.2.10"},
+			wantErr:     "invalid BYOIP addresses: 192.0.2.10 belongs to BYOIP address range 192.0.2.0/24 in state PENDING_PROVISIONING",
+		},
+		{
+			name:        "addresses of the same range",
+			ipAddresses: []string{"198.51.100.10", "198.51.100.11"},
+			wantErr:     "invalid BYOIP addresses: 198.51.100.10 and 198.51.100.11 belong to the same BYOIP address range 198.51.100.0/24",
+		},
+		{
+			name:        "invalid addresses",
+			ipAddresses: []string{"198.51.100", "2001:db8::1"},
+			wantErr:     "invalid BYOIP addresses: 198.51.100 is not a valid IPv4 address; 2001:db8::1 is not a valid IPv4 address",
+		},
+		{
+			name:        "failed to list ranges",
+			ipAddresses: []string{"198.51.100.10"},
+			listErr:     errors.New("access denied"),
+			wantErr:     "failed to list BYOIP address ranges: access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGAClient := services.NewMockGlobalAccelerator(ctrl)
+			mockGAClient.EXPECT().ListByoipCidrsAsList(gomock.Any(), gomock.Any()).Return(byoipCidrs, tt.listErr)
 			m := &defaultAcceleratorManager{
-				logger: logr.Discard(),
+				gaService: mockGAClient,
+				logger:    logr.Discard(),
 			}
-			result := m.areIPv4AddressesEqual(tt.desiredIPs, tt.actualIPSets)
-			assert.Equal(t, tt.expectedResult, result, tt.description)
+			err := m.validateBYOIPAddresses(context.Background(), tt.ipAddresses)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
 		})
 	}
 }
 
+func Test_defaultAcceleratorManager_getIdempotencyToken(t *testing.T) {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "test-namespace", Name: "test-name"})
+	m := &defaultAcceleratorManager{logger: logr.Discard()}
+
+	crd := &agaapi.GlobalAccelerator{}
+	crd.UID = types.UID("test-uid")
+	resAccelerator := agamodel.NewAccelerator(stack, "new-accelerator", agamodel.AcceleratorSpec{}, crd)
+	assert.Equal(t, "test-uid", m.getIdempotencyToken(resAccelerator))
+
+	// replacing accelerators get a token per replaced accelerator
+	crd.Status.AcceleratorARN = aws.String("arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh")
+	replacingToken := m.getIdempotencyToken(agamodel.NewAccelerator(stack, "replacing-accelerator", agamodel.AcceleratorSpec{}, crd))
+	assert.Regexp(t, "^test-uid-[0-9a-f]{8}$", replacingToken)
+	crd.Status.AcceleratorARN = aws.String("arn:aws:globalaccelerator::123456789012:accelerator/5678abcd-abcd-1234-abcd-1234abcdefgh")
+	assert.NotEqual(t, replacingToken, m.getIdempotencyToken(agamodel.NewAccelerator(stack, "replacing-accelerator-2", agamodel.AcceleratorSpec{}, crd)))
+}
+
 func makeIPSets(ips []string) []agatypes.IpSet {
 	if len(ips) == 0 {
 		return []agatypes.IpSet{}
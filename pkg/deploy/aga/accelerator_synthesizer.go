@@ -2,12 +2,15 @@ package aga
 
 import (
 	"context"
+	"strings"
+
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
 	agatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
 	"github.com/aws/smithy-go"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
@@ -72,12 +75,36 @@ func (s *acceleratorSynthesizer) Synthesize(ctx context.Context) error {
 
 	// Accelerator exists, determine if it needs replacement or update
 	if isSDKAcceleratorRequiresReplacement(sdkAccelerator, resAccelerator) {
+		if err := s.checkAddressReleaseConfirmed(resAccelerator, sdkAccelerator, "Assigning IP addresses "+strings.Join(resAccelerator.Spec.IpAddresses, ", ")); err != nil {
+			return err
+		}
+		s.logger.Info("Replacing accelerator to assign IP addresses",
+			"arn", arn, "resourceID", resAccelerator.ID(), "ipAddresses", resAccelerator.Spec.IpAddresses)
 		// Store for deletion in PostSynthesize, then recreate
-		// TODO: We will test this for BYOIP feature
 		s.unmatchedSDKAccelerators = []AcceleratorWithTags{sdkAccelerator}
 		return s.handleCreateAccelerator(ctx, resAccelerator)
-	} else {
-		return s.handleUpdateAccelerator(ctx, resAccelerator, sdkAccelerator)
+	}
+	if isSDKAcceleratorReleasingIPv6Addresses(sdkAccelerator, resAccelerator) {
+		if err := s.checkAddressReleaseConfirmed(resAccelerator, sdkAccelerator, "Changing the IP address type to IPV4"); err != nil {
+			return err
+		}
+	}
+	return s.handleUpdateAccelerator(ctx, resAccelerator, sdkAccelerator)
+}
+
+// checkAddressReleaseConfirmed checks that a change releasing the static IP addresses of the accelerator is confirmed by annotation.
+// The annotation must contain the ARN of the accelerator, so that it doesn't confirm changes to the accelerator replacing it.
+func (s *acceleratorSynthesizer) checkAddressReleaseConfirmed(resAccelerator *agamodel.Accelerator, sdkAccelerator AcceleratorWithTags, change string) error {
+	if !s.featureGates.Enabled(config.AGAReplacementProtection) {
+		return nil
+	}
+	acceleratorARN := awssdk.ToString(sdkAccelerator.Accelerator.AcceleratorArn)
+	if resAccelerator.GetCRDAnnotation(annotations.AnnotationConfirmAcceleratorReplacement) == acceleratorARN {
+		return nil
+	}
+	return &ReplacementNotConfirmedError{
+		AcceleratorARN: acceleratorARN,
+		Change:         change,
 	}
 }
 
@@ -182,8 +209,16 @@ func (s *acceleratorSynthesizer) isAcceleratorNotFound(err error) bool {
 }
 
 // isSDKAcceleratorRequiresReplacement checks whether a sdk Accelerator requires replacement to fulfill an Accelerator resource.
+// The accelerator only needs replacement to assign BYOIP addresses, as its static IP addresses can't be updated.
 func isSDKAcceleratorRequiresReplacement(sdkAccelerator AcceleratorWithTags, resAccelerator *agamodel.Accelerator) bool {
-	// The accelerator will only need replacement in BYOIP scenarios. I will implement this later as a separate PR
-	// TODO : BYOIP feature
-	return false
+	if len(resAccelerator.Spec.IpAddresses) == 0 {
+		return false
+	}
+	return !areIPv4AddressesAssigned(resAccelerator.Spec.IpAddresses, sdkAccelerator.Accelerator.IpSets)
+}
+
+// isSDKAcceleratorReleasingIPv6Addresses checks whether updating a sdk Accelerator to fulfill an Accelerator resource releases its static IPv6 addresses.
+func isSDKAcceleratorReleasingIPv6Addresses(sdkAccelerator AcceleratorWithTags, resAccelerator *agamodel.Accelerator) bool {
+	return sdkAccelerator.Accelerator.IpAddressType == agatypes.IpAddressTypeDualStack &&
+		resAccelerator.Spec.IPAddressType == agamodel.IPAddressTypeIPV4
 }
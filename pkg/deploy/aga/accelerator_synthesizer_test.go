@@ -11,7 +11,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	agamodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/aga"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
@@ -648,3 +650,118 @@ func Test_acceleratorSynthesizer_handleUpdateAccelerator(t *testing.T) {
 		})
 	}
 }
+
+func Test_acceleratorSynthesizer_Synthesize(t *testing.T) {
+	const acceleratorARN = "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh"
+	ipv4IPSet := agatypes.IpSet{IpAddressFamily: agatypes.IpAddressFamilyIPv4, IpAddresses: []string{"198.51.100.10", "99.83.0.1"}}
+	ipv6IPSet := agatypes.IpSet{IpAddressFamily: agatypes.IpAddressFamilyIPv6, IpAddresses: []string{"2001:db8::1", "2001:db8::2"}}
+
+	tests := []struct {
+		name                     string
+		spec                     agamodel.AcceleratorSpec
+		annotations              map[string]string
+		disableProtection        bool
+		sdkAccelerator           agatypes.Accelerator
+		wantCreate               bool
+		wantUpdate               bool
+		wantReplacedAccelerators int
+		wantNotConfirmedErr      bool
+	}{
+		{
+			name:           "BYOIP address assigned - update",
+			spec:           agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4, IpAddresses: []string{"198.51.100.10"}},
+			sdkAccelerator: agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantUpdate:     true,
+		},
+		{
+			name:                "BYOIP address not assigned - replacement not confirmed",
+			spec:                agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4, IpAddresses: []string{"203.0.113.10"}},
+			sdkAccelerator:      agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantNotConfirmedErr: true,
+		},
+		{
+			name:                "BYOIP address not assigned - confirmation for another accelerator",
+			spec:                agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4, IpAddresses: []string{"203.0.113.10"}},
+			annotations:         map[string]string{"aga.k8s.aws/confirm-replacement": "arn:aws:globalaccelerator::123456789012:accelerator/other"},
+			sdkAccelerator:      agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantNotConfirmedErr: true,
+		},
+		{
+			name:                     "BYOIP address not assigned - replacement confirmed",
+			spec:                     agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4, IpAddresses: []string{"203.0.113.10"}},
+			annotations:              map[string]string{"aga.k8s.aws/confirm-replacement": acceleratorARN},
+			sdkAccelerator:           agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantCreate:               true,
+			wantReplacedAccelerators: 1,
+		},
+		{
+			name:                     "BYOIP address not assigned - protection disabled",
+			spec:                     agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4, IpAddresses: []string{"203.0.113.10"}},
+			disableProtection:        true,
+			sdkAccelerator:           agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantCreate:               true,
+			wantReplacedAccelerators: 1,
+		},
+		{
+			name:                "dual stack to IPv4 - change not confirmed",
+			spec:                agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4},
+			sdkAccelerator:      agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeDualStack, IpSets: []agatypes.IpSet{ipv4IPSet, ipv6IPSet}},
+			wantNotConfirmedErr: true,
+		},
+		{
+			name:           "dual stack to IPv4 - change confirmed",
+			spec:           agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeIPV4},
+			annotations:    map[string]string{"aga.k8s.aws/confirm-replacement": acceleratorARN},
+			sdkAccelerator: agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeDualStack, IpSets: []agatypes.IpSet{ipv4IPSet, ipv6IPSet}},
+			wantUpdate:     true,
+		},
+		{
+			name:           "IPv4 to dual stack - update",
+			spec:           agamodel.AcceleratorSpec{IPAddressType: agamodel.IPAddressTypeDualStack},
+			sdkAccelerator: agatypes.Accelerator{IpAddressType: agatypes.IpAddressTypeIpv4, IpSets: []agatypes.IpSet{ipv4IPSet}},
+			wantUpdate:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "test-namespace", Name: "test-name"})
+			crd := &agaapi.GlobalAccelerator{}
+			crd.Annotations = tt.annotations
+			crd.Status.AcceleratorARN = aws.String(acceleratorARN)
+			agamodel.NewAccelerator(stack, agamodel.ResourceIDAccelerator, tt.spec, crd)
+
+			sdkAccelerator := tt.sdkAccelerator
+			sdkAccelerator.AcceleratorArn = aws.String(acceleratorARN)
+			mockGAClient := services.NewMockGlobalAccelerator(ctrl)
+			mockGAClient.EXPECT().DescribeAcceleratorWithContext(gomock.Any(), gomock.Any()).
+				Return(&globalaccelerator.DescribeAcceleratorOutput{Accelerator: &sdkAccelerator}, nil)
+			mockGAClient.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+				Return(&globalaccelerator.ListTagsForResourceOutput{}, nil)
+			mockAccManager := NewMockAcceleratorManager(ctrl)
+			if tt.wantCreate {
+				mockAccManager.EXPECT().Create(gomock.Any(), gomock.Any()).Return(agamodel.AcceleratorStatus{}, nil)
+			}
+			if tt.wantUpdate {
+				mockAccManager.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(agamodel.AcceleratorStatus{}, nil)
+			}
+			featureGates := config.NewFeatureGates()
+			if tt.disableProtection {
+				featureGates.Disable(config.AGAReplacementProtection)
+			}
+
+			s := NewAcceleratorSynthesizer(mockGAClient, nil, nil, mockAccManager, logr.Discard(), featureGates, stack)
+			err := s.Synthesize(context.Background())
+			if tt.wantNotConfirmedErr {
+				var replacementNotConfirmedErr *ReplacementNotConfirmedError
+				assert.ErrorAs(t, err, &replacementNotConfirmedErr)
+				assert.Equal(t, acceleratorARN, replacementNotConfirmedErr.AcceleratorARN)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, s.unmatchedSDKAccelerators, tt.wantReplacedAccelerators)
+		})
+	}
+}
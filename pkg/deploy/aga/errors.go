@@ -1,6 +1,10 @@
 package aga
 
-import "fmt"
+import (
+	"fmt"
+
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+)
 
 // Error constants
 const (
@@ -12,6 +16,12 @@ const (
 
 	// Status reason constants
 	EndpointLoadFailed = "EndpointLoadFailed"
+
+	// ReplacementNotConfirmed is the error code when a change releasing static IP addresses is not confirmed
+	ReplacementNotConfirmed = "ReplacementNotConfirmed"
+
+	// InvalidBYOIPAddresses is the error code when requested IP addresses don't belong to provisioned BYOIP address ranges
+	InvalidBYOIPAddresses = "InvalidBYOIPAddresses"
 )
 
 // AcceleratorNotDisabledError is returned when an accelerator is not ready for deletion
@@ -22,3 +32,24 @@ type AcceleratorNotDisabledError struct {
 func (e *AcceleratorNotDisabledError) Error() string {
 	return fmt.Sprintf("%s", e.Message)
 }
+
+// ReplacementNotConfirmedError is returned when a spec change would release the static IP addresses of an accelerator
+// and the change isn't confirmed by annotation
+type ReplacementNotConfirmedError struct {
+	AcceleratorARN string
+	Change         string
+}
+
+func (e *ReplacementNotConfirmedError) Error() string {
+	return fmt.Sprintf("%s releases static IP addresses of accelerator %s, confirm the change by annotating the GlobalAccelerator with %s=%s",
+		e.Change, e.AcceleratorARN, annotations.AnnotationConfirmAcceleratorReplacement, e.AcceleratorARN)
+}
+
+// InvalidBYOIPAddressesError is returned when requested IP addresses don't belong to provisioned BYOIP address ranges
+type InvalidBYOIPAddressesError struct {
+	Message string
+}
+
+func (e *InvalidBYOIPAddressesError) Error() string {
+	return e.Message
+}
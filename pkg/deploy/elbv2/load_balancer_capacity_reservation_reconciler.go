@@ -8,8 +8,11 @@ import (
 	"github.com/go-logr/logr"
 	"reflect"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"time"
 )
 
 // reconciler for LoadBalancer Capacity Reservation
@@ -19,11 +22,13 @@ type LoadBalancerCapacityReservationReconciler interface {
 }
 
 // NewDefaultLoadBalancerCapacityReservationReconciler constructs new defaultLoadBalancerCapacityReservationReconciler.
-func NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client services.ELBV2, featureGates config.FeatureGates, logger logr.Logger) *defaultLoadBalancerCapacityReservationReconciler {
+func NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client services.ELBV2, featureGates config.FeatureGates, metricsCollector lbcmetrics.MetricCollector, logger logr.Logger) *defaultLoadBalancerCapacityReservationReconciler {
 	return &defaultLoadBalancerCapacityReservationReconciler{
-		elbv2Client:  elbv2Client,
-		logger:       logger,
-		featureGates: featureGates,
+		elbv2Client:      elbv2Client,
+		logger:           logger,
+		featureGates:     featureGates,
+		metricsCollector: metricsCollector,
+		clock:            time.Now,
 	}
 }
 
@@ -31,9 +36,11 @@ var _ LoadBalancerCapacityReservationReconciler = &defaultLoadBalancerCapacityRe
 
 // default implementation for LoadBalancerCapacityReservationReconciler
 type defaultLoadBalancerCapacityReservationReconciler struct {
-	elbv2Client  services.ELBV2
-	logger       logr.Logger
-	featureGates config.FeatureGates
+	elbv2Client      services.ELBV2
+	logger           logr.Logger
+	featureGates     config.FeatureGates
+	metricsCollector lbcmetrics.MetricCollector
+	clock            func() time.Time
 }
 
 func (r *defaultLoadBalancerCapacityReservationReconciler) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
@@ -42,6 +49,25 @@ func (r *defaultLoadBalancerCapacityReservationReconciler) Reconcile(ctx context
 	if desiredCapacityReservation == nil {
 		return nil
 	}
+	lbARN := awssdk.ToString(sdkLB.LoadBalancer.LoadBalancerArn)
+	if capacityreservation.IsScheduled(desiredCapacityReservation) {
+		// the scheduled windows are evaluated on every reconcile, the controllers requeue at the next transition.
+		reservation, err := capacityreservation.Evaluate(*desiredCapacityReservation, r.clock())
+		if err != nil {
+			return err
+		}
+		if resLB.Status != nil {
+			resLB.Status.CapacityReservation = reservation.ModelStatus()
+		}
+		if r.metricsCollector != nil {
+			r.metricsCollector.ObserveCapacityReservation(lbARN, reservation.CapacityUnits, reservation.NextCapacityUnits, reservation.NextTransitionTime)
+		}
+		desiredCapacityReservation = &elbv2model.MinimumLoadBalancerCapacity{
+			CapacityUnits: reservation.CapacityUnits,
+		}
+	} else if r.metricsCollector != nil {
+		r.metricsCollector.DeleteCapacityReservation(lbARN)
+	}
 	//If the value of desired capacityUnits is zero then set desiredCapacityReservation to nil to reset the capacity
 	if desiredCapacityReservation.CapacityUnits == 0 {
 		desiredCapacityReservation = nil
@@ -9,12 +9,15 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultLoadBalancerCapacityReservationReconciler_updateSDKLoadBalancerWithCapacityReservation(t *testing.T) {
@@ -210,6 +213,112 @@ func Test_defaultLoadBalancerCapacityReservationReconciler_updateSDKLoadBalancer
 	}
 }
 
+func Test_defaultLoadBalancerCapacityReservationReconciler_reconcileScheduledCapacityReservation(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	capacity := &elbv2model.MinimumLoadBalancerCapacity{
+		CapacityUnits: 100,
+		Schedules: []elbv2model.CapacityReservationSchedule{
+			{
+				Name:          "business-hours",
+				Start:         "0 8 * * mon-fri",
+				Duration:      metav1.Duration{Duration: 10 * time.Hour},
+				CapacityUnits: 400,
+			},
+		},
+	}
+	tests := []struct {
+		name                   string
+		now                    time.Time
+		currentCapacityUnits   *int32
+		wantModifyCapacity     *int32
+		wantStatus             *elbv2model.CapacityReservationStatus
+		wantNextTransitionTime time.Time
+	}{
+		{
+			name:                 "raise the reservation when the window opens",
+			now:                  time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+			currentCapacityUnits: awssdk.Int32(100),
+			wantModifyCapacity:   awssdk.Int32(400),
+			wantStatus: &elbv2model.CapacityReservationStatus{
+				CurrentCapacityUnits: 400,
+				NextCapacityUnits:    awssdk.Int32(100),
+				NextTransitionTime:   &metav1.Time{Time: time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:                 "lower the reservation when the window closes",
+			now:                  time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC),
+			currentCapacityUnits: awssdk.Int32(400),
+			wantModifyCapacity:   awssdk.Int32(100),
+			wantStatus: &elbv2model.CapacityReservationStatus{
+				CurrentCapacityUnits: 100,
+				NextCapacityUnits:    awssdk.Int32(400),
+				NextTransitionTime:   &metav1.Time{Time: time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:                 "no change within the window",
+			now:                  time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+			currentCapacityUnits: awssdk.Int32(400),
+			wantStatus: &elbv2model.CapacityReservationStatus{
+				CurrentCapacityUnits: 400,
+				NextCapacityUnits:    awssdk.Int32(100),
+				NextTransitionTime:   &metav1.Time{Time: time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeCapacityReservationWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeCapacityReservationOutput{
+				MinimumLoadBalancerCapacity: &elbv2types.MinimumLoadBalancerCapacity{CapacityUnits: tt.currentCapacityUnits},
+			}, nil)
+			if tt.wantModifyCapacity != nil {
+				elbv2Client.EXPECT().ModifyCapacityReservationWithContext(gomock.Any(), &elbv2sdk.ModifyCapacityReservationInput{
+					LoadBalancerArn:             awssdk.String("my-arn"),
+					MinimumLoadBalancerCapacity: &elbv2types.MinimumLoadBalancerCapacity{CapacityUnits: tt.wantModifyCapacity},
+				}).Return(&elbv2sdk.ModifyCapacityReservationOutput{}, nil)
+			}
+			metricsCollector := lbcmetrics.NewMockCollector()
+			r := &defaultLoadBalancerCapacityReservationReconciler{
+				elbv2Client:      elbv2Client,
+				logger:           logr.New(&log.NullLogSink{}),
+				metricsCollector: metricsCollector,
+				clock: func() time.Time {
+					return tt.now
+				},
+			}
+			resLB := &elbv2model.LoadBalancer{
+				ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+				Spec: elbv2model.LoadBalancerSpec{
+					MinimumLoadBalancerCapacity: capacity,
+				},
+				Status: &elbv2model.LoadBalancerStatus{
+					LoadBalancerARN: "my-arn",
+				},
+			}
+			sdkLB := LoadBalancerWithTags{
+				LoadBalancer: &elbv2types.LoadBalancer{
+					LoadBalancerArn: awssdk.String("my-arn"),
+				},
+			}
+			err := r.Reconcile(context.Background(), resLB, sdkLB)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus.CurrentCapacityUnits, resLB.Status.CapacityReservation.CurrentCapacityUnits)
+			assert.Equal(t, tt.wantStatus.NextCapacityUnits, resLB.Status.CapacityReservation.NextCapacityUnits)
+			assert.True(t, tt.wantStatus.NextTransitionTime.Equal(resLB.Status.CapacityReservation.NextTransitionTime))
+			invocations := metricsCollector.(*lbcmetrics.MockCollector).Invocations[lbcmetrics.MetricCapacityReservationUnits]
+			if assert.Len(t, invocations, 1) {
+				metric := invocations[0].(lbcmetrics.MockCapacityReservationMetric)
+				assert.Equal(t, "my-arn", metric.LoadBalancerARN)
+				assert.Equal(t, tt.wantStatus.CurrentCapacityUnits, metric.CapacityUnits)
+			}
+		})
+	}
+}
+
 func Test_defaultLoadBalancerCapacityReservationReconciler_getCurrentLoadBalancerCapacityReservation(t *testing.T) {
 	type describeCapacityReservationWithContextCall struct {
 		req  *elbv2sdk.DescribeCapacityReservationInput
@@ -33,7 +33,7 @@ func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider
 		trackingProvider:              trackingProvider,
 		taggingManager:                taggingManager,
 		attributesReconciler:          NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
-		capacityReservationReconciler: NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client, featureGates, logger),
+		capacityReservationReconciler: NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client, featureGates, nil, logger),
 		externalManagedTags:           externalManagedTags,
 		featureGates:                  featureGates,
 		logger:                        logger,
@@ -12,15 +12,23 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
 	"strings"
 )
 
+// LoadBalancersResult is the result of finding the AWS LoadBalancers created for a stack.
+type LoadBalancersResult struct {
+	LoadBalancers []LoadBalancerWithTags
+	Err           error
+}
+
 // NewLoadBalancerSynthesizer constructs loadBalancerSynthesizer
 func NewLoadBalancerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	lbManager LoadBalancerManager, logger logr.Logger, featureGates config.FeatureGates, controllerConfig config.ControllerConfig, stack core.Stack) *loadBalancerSynthesizer {
+	lbManager LoadBalancerManager, logger logr.Logger, featureGates config.FeatureGates, controllerConfig config.ControllerConfig, metricsCollector lbcmetrics.MetricCollector, stack core.Stack,
+	findSDKLoadBalancers func() LoadBalancersResult) *loadBalancerSynthesizer {
 	return &loadBalancerSynthesizer{
 		elbv2Client:                    elbv2Client,
 		trackingProvider:               trackingProvider,
@@ -30,8 +38,10 @@ func NewLoadBalancerSynthesizer(elbv2Client services.ELBV2, trackingProvider tra
 		stack:                          stack,
 		featureGates:                   featureGates,
 		controllerConfig:               controllerConfig,
+		metricsCollector:               metricsCollector,
 		lbsNeedingCapacityModification: nil,
-		capacityReservationReconciler:  NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client, featureGates, logger),
+		capacityReservationReconciler:  NewDefaultLoadBalancerCapacityReservationReconciler(elbv2Client, featureGates, metricsCollector, logger),
+		findSDKLoadBalancers:           findSDKLoadBalancers,
 	}
 }
 
@@ -45,17 +55,20 @@ type loadBalancerSynthesizer struct {
 	stack                          core.Stack
 	featureGates                   config.FeatureGates
 	controllerConfig               config.ControllerConfig
+	metricsCollector               lbcmetrics.MetricCollector
 	lbsNeedingCapacityModification []resAndSDKLoadBalancerPair
 	capacityReservationReconciler  LoadBalancerCapacityReservationReconciler
+	findSDKLoadBalancers           func() LoadBalancersResult
 }
 
 func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 	var resLBs []*elbv2model.LoadBalancer
 	s.stack.ListResources(&resLBs)
-	sdkLBs, err := s.findSDKLoadBalancers(ctx)
-	if err != nil {
-		return err
+	res := s.findSDKLoadBalancers()
+	if res.Err != nil {
+		return res.Err
 	}
+	sdkLBs := res.LoadBalancers
 
 	matchedResAndSDKLBs, unmatchedResLBs, unmatchedSDKLBs, err := matchResAndSDKLoadBalancers(resLBs, sdkLBs, s.trackingProvider.ResourceIDTagKey())
 	if err != nil {
@@ -78,6 +91,9 @@ func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 				return err
 			}
 		}
+		if s.metricsCollector != nil {
+			s.metricsCollector.DeleteCapacityReservation(awssdk.ToString(sdkLB.LoadBalancer.LoadBalancerArn))
+		}
 	}
 	for _, resLB := range unmatchedResLBs {
 		lbStatus, sdkLB, err := s.lbManager.Create(ctx, resLB)
@@ -140,15 +156,6 @@ func (s *loadBalancerSynthesizer) PostSynthesize(ctx context.Context) error {
 	return nil
 }
 
-// findSDKLoadBalancers will find all AWS LoadBalancer created for stack.
-func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]LoadBalancerWithTags, error) {
-	stackTags := s.trackingProvider.StackTags(s.stack)
-	stackTagsLegacy := s.trackingProvider.StackTagsLegacy(s.stack)
-	return s.taggingManager.ListLoadBalancers(ctx,
-		tracking.TagsAsTagFilter(stackTags),
-		tracking.TagsAsTagFilter(stackTagsLegacy))
-}
-
 type resAndSDKLoadBalancerPair struct {
 	resLB *elbv2model.LoadBalancer
 	sdkLB LoadBalancerWithTags
@@ -2,10 +2,14 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2modelk8s "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -134,7 +138,9 @@ func mapResTargetGroupBindingByARN(resTGBs []*elbv2modelk8s.TargetGroupBindingRe
 		if err != nil {
 			return nil, err
 		}
-		resTGBsByARN[tgARN] = resTGB
+		weighted := resTGB.Spec.Template.Spec.WeightedRegistration != nil
+		tgbKey := types.NamespacedName{Namespace: resTGB.Spec.Template.Namespace, Name: resTGB.Spec.Template.Name}
+		resTGBsByARN[buildTargetGroupBindingMatchKey(tgARN, weighted, tgbKey)] = resTGB
 	}
 	return resTGBsByARN, nil
 }
@@ -142,8 +148,17 @@ func mapResTargetGroupBindingByARN(resTGBs []*elbv2modelk8s.TargetGroupBindingRe
 func mapK8sTargetGroupBindingByARN(k8sTGBs []*elbv2api.TargetGroupBinding) map[string]*elbv2api.TargetGroupBinding {
 	k8sTGBsByARN := make(map[string]*elbv2api.TargetGroupBinding, len(k8sTGBs))
 	for _, k8sTGB := range k8sTGBs {
-		tgARN := k8sTGB.Spec.TargetGroupARN
-		k8sTGBsByARN[tgARN] = k8sTGB
+		weighted := k8sTGB.Spec.WeightedRegistration != nil
+		k8sTGBsByARN[buildTargetGroupBindingMatchKey(k8sTGB.Spec.TargetGroupARN, weighted, k8s.NamespacedName(k8sTGB))] = k8sTGB
 	}
 	return k8sTGBsByARN
 }
+
+// buildTargetGroupBindingMatchKey builds the key to match TargetGroupBindings with their resources.
+// TargetGroupBindings are identified by their TargetGroup, except for weighted ones which share the TargetGroup with each other.
+func buildTargetGroupBindingMatchKey(tgARN string, weighted bool, tgbKey types.NamespacedName) string {
+	if !weighted {
+		return tgARN
+	}
+	return fmt.Sprintf("%s/%s", tgARN, tgbKey)
+}
@@ -0,0 +1,208 @@
+package shield
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	shieldsdk "github.com/aws/aws-sdk-go-v2/service/shield"
+	shieldtypes "github.com/aws/aws-sdk-go-v2/service/shield/types"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+)
+
+// ProtectionGroupManager manages the membership of resources in shield protection groups.
+// Only protection groups with the ARBITRARY pattern are managed, as the members of other patterns are implicit.
+type ProtectionGroupManager interface {
+	// ReconcileMembership makes resource a member of exactly the desired protection groups with the ARBITRARY pattern.
+	// Missing protection groups are created, and protection groups created by the controller are deleted once they're empty.
+	ReconcileMembership(ctx context.Context, resourceARN string, desiredProtectionGroups []shieldmodel.ProtectionGroup) error
+}
+
+// NewDefaultProtectionGroupManager constructs new defaultProtectionGroupManager.
+func NewDefaultProtectionGroupManager(shieldClient services.Shield, clusterName string, logger logr.Logger) *defaultProtectionGroupManager {
+	return &defaultProtectionGroupManager{
+		shieldClient: shieldClient,
+		clusterName:  clusterName,
+		logger:       logger,
+	}
+}
+
+var _ ProtectionGroupManager = &defaultProtectionGroupManager{}
+
+type defaultProtectionGroupManager struct {
+	shieldClient services.Shield
+	clusterName  string
+	logger       logr.Logger
+
+	// membersMutex serializes the updates of protection group members, which are read-modify-write of the entire member list.
+	membersMutex sync.Mutex
+}
+
+func (m *defaultProtectionGroupManager) ReconcileMembership(ctx context.Context, resourceARN string, desiredProtectionGroups []shieldmodel.ProtectionGroup) error {
+	m.membersMutex.Lock()
+	defer m.membersMutex.Unlock()
+
+	protectionGroups, err := m.shieldClient.ListProtectionGroupsAsList(ctx, &shieldsdk.ListProtectionGroupsInput{})
+	if err != nil {
+		return err
+	}
+	protectionGroupByID := make(map[string]shieldtypes.ProtectionGroup, len(protectionGroups))
+	for _, protectionGroup := range protectionGroups {
+		protectionGroupByID[awssdk.ToString(protectionGroup.ProtectionGroupId)] = protectionGroup
+	}
+
+	desiredProtectionGroupIDs := make(map[string]struct{}, len(desiredProtectionGroups))
+	for _, desired := range desiredProtectionGroups {
+		desiredProtectionGroupIDs[desired.ID] = struct{}{}
+		protectionGroup, exists := protectionGroupByID[desired.ID]
+		if !exists {
+			if err := m.createProtectionGroup(ctx, resourceARN, desired); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.addMember(ctx, resourceARN, desired, protectionGroup); err != nil {
+			return err
+		}
+	}
+
+	for _, protectionGroup := range protectionGroups {
+		if _, desired := desiredProtectionGroupIDs[awssdk.ToString(protectionGroup.ProtectionGroupId)]; desired {
+			continue
+		}
+		if protectionGroup.Pattern != shieldtypes.ProtectionGroupPatternArbitrary || !slices.Contains(protectionGroup.Members, resourceARN) {
+			continue
+		}
+		if err := m.removeMember(ctx, resourceARN, protectionGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultProtectionGroupManager) createProtectionGroup(ctx context.Context, resourceARN string, desired shieldmodel.ProtectionGroup) error {
+	req := &shieldsdk.CreateProtectionGroupInput{
+		ProtectionGroupId: awssdk.String(desired.ID),
+		Aggregation:       shieldtypes.ProtectionGroupAggregation(desired.Aggregation),
+		Pattern:           shieldtypes.ProtectionGroupPatternArbitrary,
+		Members:           []string{resourceARN},
+		Tags: []shieldtypes.Tag{
+			{
+				Key:   awssdk.String(shared_constants.TagKeyK8sCluster),
+				Value: awssdk.String(m.clusterName),
+			},
+		},
+	}
+	m.logger.Info("creating shield protection group",
+		"protectionGroupID", desired.ID,
+		"resourceARN", resourceARN)
+	if _, err := m.shieldClient.CreateProtectionGroupWithContext(ctx, req); err != nil {
+		return errors.Wrapf(err, "failed to create shield protection group %v", desired.ID)
+	}
+	m.logger.Info("created shield protection group",
+		"protectionGroupID", desired.ID)
+	return nil
+}
+
+// addMember adds resource to the protection group, and updates the aggregation of protection groups created by the controller.
+func (m *defaultProtectionGroupManager) addMember(ctx context.Context, resourceARN string, desired shieldmodel.ProtectionGroup, protectionGroup shieldtypes.ProtectionGroup) error {
+	if protectionGroup.Pattern != shieldtypes.ProtectionGroupPatternArbitrary {
+		return errors.Errorf("shield protection group %v has pattern %v, only %v is supported",
+			desired.ID, protectionGroup.Pattern, shieldtypes.ProtectionGroupPatternArbitrary)
+	}
+	isMember := slices.Contains(protectionGroup.Members, resourceARN)
+	aggregation := protectionGroup.Aggregation
+	if desiredAggregation := shieldtypes.ProtectionGroupAggregation(desired.Aggregation); desiredAggregation != aggregation {
+		managed, err := m.isManagedProtectionGroup(ctx, protectionGroup)
+		if err != nil {
+			return err
+		}
+		if managed {
+			aggregation = desiredAggregation
+		}
+	}
+	if isMember && aggregation == protectionGroup.Aggregation {
+		return nil
+	}
+	members := protectionGroup.Members
+	if !isMember {
+		members = append(slices.Clone(members), resourceARN)
+	}
+	m.logger.Info("adding resource to shield protection group",
+		"protectionGroupID", desired.ID,
+		"resourceARN", resourceARN)
+	return m.updateProtectionGroup(ctx, protectionGroup, aggregation, members)
+}
+
+// removeMember removes resource from the protection group, and deletes the protection group if it's empty and created by the controller.
+func (m *defaultProtectionGroupManager) removeMember(ctx context.Context, resourceARN string, protectionGroup shieldtypes.ProtectionGroup) error {
+	protectionGroupID := awssdk.ToString(protectionGroup.ProtectionGroupId)
+	members := slices.DeleteFunc(slices.Clone(protectionGroup.Members), func(member string) bool {
+		return member == resourceARN
+	})
+	if len(members) != 0 {
+		m.logger.Info("removing resource from shield protection group",
+			"protectionGroupID", protectionGroupID,
+			"resourceARN", resourceARN)
+		return m.updateProtectionGroup(ctx, protectionGroup, protectionGroup.Aggregation, members)
+	}
+
+	// protection groups with the ARBITRARY pattern can't be empty.
+	managed, err := m.isManagedProtectionGroup(ctx, protectionGroup)
+	if err != nil {
+		return err
+	}
+	if !managed {
+		m.logger.Info("ignoring unmanaged shield protection group with the resource as last member",
+			"protectionGroupID", protectionGroupID,
+			"resourceARN", resourceARN)
+		return nil
+	}
+	m.logger.Info("deleting shield protection group",
+		"protectionGroupID", protectionGroupID)
+	if _, err := m.shieldClient.DeleteProtectionGroupWithContext(ctx, &shieldsdk.DeleteProtectionGroupInput{
+		ProtectionGroupId: protectionGroup.ProtectionGroupId,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete shield protection group %v", protectionGroupID)
+	}
+	m.logger.Info("deleted shield protection group",
+		"protectionGroupID", protectionGroupID)
+	return nil
+}
+
+func (m *defaultProtectionGroupManager) updateProtectionGroup(ctx context.Context, protectionGroup shieldtypes.ProtectionGroup,
+	aggregation shieldtypes.ProtectionGroupAggregation, members []string) error {
+	req := &shieldsdk.UpdateProtectionGroupInput{
+		ProtectionGroupId: protectionGroup.ProtectionGroupId,
+		Aggregation:       aggregation,
+		Pattern:           protectionGroup.Pattern,
+		Members:           members,
+	}
+	if _, err := m.shieldClient.UpdateProtectionGroupWithContext(ctx, req); err != nil {
+		return errors.Wrapf(err, "failed to update shield protection group %v", awssdk.ToString(protectionGroup.ProtectionGroupId))
+	}
+	m.logger.Info("updated shield protection group",
+		"protectionGroupID", awssdk.ToString(protectionGroup.ProtectionGroupId))
+	return nil
+}
+
+// isManagedProtectionGroup checks whether the protection group is created by the controller of this cluster.
+func (m *defaultProtectionGroupManager) isManagedProtectionGroup(ctx context.Context, protectionGroup shieldtypes.ProtectionGroup) (bool, error) {
+	resp, err := m.shieldClient.ListTagsForResourceWithContext(ctx, &shieldsdk.ListTagsForResourceInput{
+		ResourceARN: protectionGroup.ProtectionGroupArn,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, tag := range resp.Tags {
+		if awssdk.ToString(tag.Key) == shared_constants.TagKeyK8sCluster && awssdk.ToString(tag.Value) == m.clusterName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
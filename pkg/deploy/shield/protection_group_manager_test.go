@@ -0,0 +1,71 @@
+package shield
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	shieldsdk "github.com/aws/aws-sdk-go-v2/service/shield"
+	shieldtypes "github.com/aws/aws-sdk-go-v2/service/shield/types"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultProtectionGroupManager_ReconcileMembership(t *testing.T) {
+	const (
+		lbARN      = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-1/1234567890abcdef"
+		otherLBARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-2/1234567890abcdef"
+	)
+	ctx := context.Background()
+	shieldClient := fake.NewShield("123456789012")
+	shieldClient.SetSubscribed(true)
+	shieldClient.AddProtectionGroup("shared", shieldtypes.ProtectionGroupPatternArbitrary, []string{otherLBARN})
+	shieldClient.AddProtectionGroup("unmanaged", shieldtypes.ProtectionGroupPatternArbitrary, []string{lbARN})
+	shieldClient.AddProtectionGroup("all", shieldtypes.ProtectionGroupPatternAll, nil)
+	m := NewDefaultProtectionGroupManager(shieldClient, "my-cluster", log.Log)
+
+	// missing protection groups are created and existing ones are joined.
+	err := m.ReconcileMembership(ctx, lbARN, []shieldmodel.ProtectionGroup{
+		{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationMax},
+		{ID: "shared", Aggregation: shieldmodel.ProtectionGroupAggregationMean},
+		{ID: "unmanaged", Aggregation: shieldmodel.ProtectionGroupAggregationSum},
+	})
+	assert.NoError(t, err)
+	web, exists := shieldClient.ProtectionGroup("web")
+	assert.True(t, exists)
+	assert.Equal(t, []string{lbARN}, web.Members)
+	assert.Equal(t, shieldtypes.ProtectionGroupAggregationMax, web.Aggregation)
+	tags, err := shieldClient.ListTagsForResourceWithContext(ctx, &shieldsdk.ListTagsForResourceInput{ResourceARN: web.ProtectionGroupArn})
+	assert.NoError(t, err)
+	assert.Equal(t, []shieldtypes.Tag{{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")}}, tags.Tags)
+	shared, _ := shieldClient.ProtectionGroup("shared")
+	assert.Equal(t, []string{otherLBARN, lbARN}, shared.Members)
+	// the aggregation of protection groups created outside the controller is left unchanged.
+	assert.Equal(t, shieldtypes.ProtectionGroupAggregationSum, shared.Aggregation)
+
+	// the aggregation of protection groups created by the controller is updated.
+	err = m.ReconcileMembership(ctx, lbARN, []shieldmodel.ProtectionGroup{
+		{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationSum},
+		{ID: "unmanaged", Aggregation: shieldmodel.ProtectionGroupAggregationSum},
+	})
+	assert.NoError(t, err)
+	web, _ = shieldClient.ProtectionGroup("web")
+	assert.Equal(t, shieldtypes.ProtectionGroupAggregationSum, web.Aggregation)
+	shared, _ = shieldClient.ProtectionGroup("shared")
+	assert.Equal(t, []string{otherLBARN}, shared.Members)
+
+	// empty protection groups are only deleted if created by the controller.
+	err = m.ReconcileMembership(ctx, lbARN, nil)
+	assert.NoError(t, err)
+	_, exists = shieldClient.ProtectionGroup("web")
+	assert.False(t, exists)
+	unmanaged, exists := shieldClient.ProtectionGroup("unmanaged")
+	assert.True(t, exists)
+	assert.Equal(t, []string{lbARN}, unmanaged.Members)
+
+	// protection groups with other patterns can't be joined.
+	err = m.ReconcileMembership(ctx, lbARN, []shieldmodel.ProtectionGroup{{ID: "all", Aggregation: shieldmodel.ProtectionGroupAggregationSum}})
+	assert.EqualError(t, err, "shield protection group all has pattern ALL, only ARBITRARY is supported")
+}
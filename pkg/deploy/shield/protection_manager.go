@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	"time"
 )
 
@@ -32,6 +33,21 @@ type ProtectionManager interface {
 
 	// IsSubscribed checks whether subscribed to shield service.
 	IsSubscribed(ctx context.Context) (bool, error)
+
+	// AssociateHealthCheck associates Route 53 health check with shield protection for resource.
+	AssociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckARN string) error
+
+	// DisassociateHealthCheck disassociates Route 53 health check from shield protection for resource.
+	DisassociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckARN string) error
+
+	// EnableApplicationLayerAutomaticResponse enables automatic application-layer DDoS mitigation for resource.
+	EnableApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string, action shieldmodel.ApplicationLayerAutomaticResponseAction) error
+
+	// UpdateApplicationLayerAutomaticResponse updates the action of automatic application-layer DDoS mitigation for resource.
+	UpdateApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string, action shieldmodel.ApplicationLayerAutomaticResponseAction) error
+
+	// DisableApplicationLayerAutomaticResponse disables automatic application-layer DDoS mitigation for resource.
+	DisableApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string) error
 }
 
 func NewDefaultProtectionManager(shieldClient services.Shield, logger logr.Logger) *defaultProtectionManager {
@@ -60,6 +76,10 @@ type defaultProtectionManager struct {
 type ProtectionInfo struct {
 	Name string
 	ID   string
+	// HealthCheckIDs are the IDs of the Route 53 health checks associated with the protection.
+	HealthCheckIDs []string
+	// ApplicationLayerAutomaticResponseAction is the action of automatic application-layer DDoS mitigation, empty if disabled.
+	ApplicationLayerAutomaticResponseAction shieldmodel.ApplicationLayerAutomaticResponseAction
 }
 
 func (m *defaultProtectionManager) CreateProtection(ctx context.Context, resourceARN string, protectionName string) (string, error) {
@@ -127,8 +147,10 @@ func (m *defaultProtectionManager) GetProtection(ctx context.Context, resourceAR
 	}
 	if resp != nil && resp.Protection != nil {
 		protectionInfo = &ProtectionInfo{
-			Name: awssdk.ToString(resp.Protection.Name),
-			ID:   awssdk.ToString(resp.Protection.Id),
+			Name:                                    awssdk.ToString(resp.Protection.Name),
+			ID:                                      awssdk.ToString(resp.Protection.Id),
+			HealthCheckIDs:                          resp.Protection.HealthCheckIds,
+			ApplicationLayerAutomaticResponseAction: applicationLayerAutomaticResponseAction(resp.Protection.ApplicationLayerAutomaticResponseConfiguration),
 		}
 	}
 	m.protectionInfoByResourceARNCache.Set(resourceARN, protectionInfo, m.protectionInfoByResourceARNCacheTTL)
@@ -151,3 +173,106 @@ func (m *defaultProtectionManager) IsSubscribed(ctx context.Context) (bool, erro
 	m.subscriptionStateCache.Set(subscriptionStateCacheKey, subscriptionState, m.subscriptionStateCacheTTL)
 	return shieldtypes.SubscriptionStateActive == subscriptionState, nil
 }
+
+func (m *defaultProtectionManager) AssociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckARN string) error {
+	req := &shieldsdk.AssociateHealthCheckInput{
+		ProtectionId:   awssdk.String(protectionID),
+		HealthCheckArn: awssdk.String(healthCheckARN),
+	}
+	m.logger.Info("associating health check with shield protection",
+		"resourceARN", resourceARN,
+		"healthCheckARN", healthCheckARN)
+	// the cached protection is stale from now on, even if the call fails.
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	if _, err := m.shieldClient.AssociateHealthCheckWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("associated health check with shield protection",
+		"resourceARN", resourceARN,
+		"healthCheckARN", healthCheckARN)
+	return nil
+}
+
+func (m *defaultProtectionManager) DisassociateHealthCheck(ctx context.Context, resourceARN string, protectionID string, healthCheckARN string) error {
+	req := &shieldsdk.DisassociateHealthCheckInput{
+		ProtectionId:   awssdk.String(protectionID),
+		HealthCheckArn: awssdk.String(healthCheckARN),
+	}
+	m.logger.Info("disassociating health check from shield protection",
+		"resourceARN", resourceARN,
+		"healthCheckARN", healthCheckARN)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	if _, err := m.shieldClient.DisassociateHealthCheckWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("disassociated health check from shield protection",
+		"resourceARN", resourceARN,
+		"healthCheckARN", healthCheckARN)
+	return nil
+}
+
+func (m *defaultProtectionManager) EnableApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string, action shieldmodel.ApplicationLayerAutomaticResponseAction) error {
+	req := &shieldsdk.EnableApplicationLayerAutomaticResponseInput{
+		ResourceArn: awssdk.String(resourceARN),
+		Action:      buildSDKResponseAction(action),
+	}
+	m.logger.Info("enabling shield application layer automatic response",
+		"resourceARN", resourceARN,
+		"action", action)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	if _, err := m.shieldClient.EnableApplicationLayerAutomaticResponseWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("enabled shield application layer automatic response",
+		"resourceARN", resourceARN)
+	return nil
+}
+
+func (m *defaultProtectionManager) UpdateApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string, action shieldmodel.ApplicationLayerAutomaticResponseAction) error {
+	req := &shieldsdk.UpdateApplicationLayerAutomaticResponseInput{
+		ResourceArn: awssdk.String(resourceARN),
+		Action:      buildSDKResponseAction(action),
+	}
+	m.logger.Info("updating shield application layer automatic response",
+		"resourceARN", resourceARN,
+		"action", action)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	if _, err := m.shieldClient.UpdateApplicationLayerAutomaticResponseWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("updated shield application layer automatic response",
+		"resourceARN", resourceARN)
+	return nil
+}
+
+func (m *defaultProtectionManager) DisableApplicationLayerAutomaticResponse(ctx context.Context, resourceARN string) error {
+	req := &shieldsdk.DisableApplicationLayerAutomaticResponseInput{
+		ResourceArn: awssdk.String(resourceARN),
+	}
+	m.logger.Info("disabling shield application layer automatic response",
+		"resourceARN", resourceARN)
+	m.protectionInfoByResourceARNCache.Delete(resourceARN)
+	if _, err := m.shieldClient.DisableApplicationLayerAutomaticResponseWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("disabled shield application layer automatic response",
+		"resourceARN", resourceARN)
+	return nil
+}
+
+func buildSDKResponseAction(action shieldmodel.ApplicationLayerAutomaticResponseAction) *shieldtypes.ResponseAction {
+	if action == shieldmodel.ApplicationLayerAutomaticResponseActionCount {
+		return &shieldtypes.ResponseAction{Count: &shieldtypes.CountAction{}}
+	}
+	return &shieldtypes.ResponseAction{Block: &shieldtypes.BlockAction{}}
+}
+
+func applicationLayerAutomaticResponseAction(config *shieldtypes.ApplicationLayerAutomaticResponseConfiguration) shieldmodel.ApplicationLayerAutomaticResponseAction {
+	if config == nil || config.Status != shieldtypes.ApplicationLayerAutomaticResponseStatusEnabled || config.Action == nil {
+		return ""
+	}
+	if config.Action.Count != nil {
+		return shieldmodel.ApplicationLayerAutomaticResponseActionCount
+	}
+	return shieldmodel.ApplicationLayerAutomaticResponseActionBlock
+}
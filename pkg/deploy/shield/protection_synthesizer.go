@@ -3,31 +3,46 @@ package shield
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 )
 
 const (
 	protectionNameManaged       = "managed by aws-load-balancer-controller"
 	protectionNameManagedLegacy = "managed by aws-alb-ingress-controller"
+
+	healthCheckARNResourcePrefix = "healthcheck/"
 )
 
 // NewProtectionSynthesizer constructs new protectionSynthesizer
-func NewProtectionSynthesizer(protectionManager ProtectionManager, logger logr.Logger, stack core.Stack) *protectionSynthesizer {
+func NewProtectionSynthesizer(protectionManager ProtectionManager, protectionGroupManager ProtectionGroupManager, logger logr.Logger, stack core.Stack,
+	findSDKLoadBalancers func() elbv2.LoadBalancersResult) *protectionSynthesizer {
 	return &protectionSynthesizer{
-		protectionManager: protectionManager,
-		logger:            logger,
-		stack:             stack,
+		protectionManager:      protectionManager,
+		protectionGroupManager: protectionGroupManager,
+		logger:                 logger,
+		stack:                  stack,
+		findSDKLoadBalancers:   findSDKLoadBalancers,
 	}
 }
 
 type protectionSynthesizer struct {
-	protectionManager ProtectionManager
-	logger            logr.Logger
-	stack             core.Stack
+	protectionManager      ProtectionManager
+	protectionGroupManager ProtectionGroupManager
+	logger                 logr.Logger
+	stack                  core.Stack
+	// findSDKLoadBalancers finds the AWS LoadBalancers of the stack as they were before the LoadBalancerSynthesizer deleted unmatched ones.
+	findSDKLoadBalancers func() elbv2.LoadBalancersResult
 }
 
 func (s *protectionSynthesizer) Synthesize(ctx context.Context) error {
@@ -51,23 +66,69 @@ func (s *protectionSynthesizer) Synthesize(ctx context.Context) error {
 }
 
 func (s *protectionSynthesizer) PostSynthesize(ctx context.Context) error {
-	// nothing to do here.
+	deletedLBARNs, err := s.findDeletedLoadBalancerARNs(ctx)
+	if err != nil {
+		return err
+	}
+	// the protection groups outlive the deleted LoadBalancers, hence they're removed from them.
+	for _, lbARN := range deletedLBARNs {
+		if err := s.protectionGroupManager.ReconcileMembership(ctx, lbARN, nil); err != nil {
+			return errors.Wrap(err, "failed to remove deleted LoadBalancer from shield protection groups")
+		}
+	}
 	return nil
 }
 
+// findDeletedLoadBalancerARNs finds the ARNs of the AWS LoadBalancers of the stack that are no longer desired, which are deleted by the LoadBalancerSynthesizer.
+func (s *protectionSynthesizer) findDeletedLoadBalancerARNs(ctx context.Context) ([]string, error) {
+	if s.findSDKLoadBalancers == nil {
+		return nil, nil
+	}
+	res := s.findSDKLoadBalancers()
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	var resLBs []*elbv2model.LoadBalancer
+	if err := s.stack.ListResources(&resLBs); err != nil {
+		return nil, fmt.Errorf("[should never happen] failed to list resources: %w", err)
+	}
+	desiredLBARNs := sets.NewString()
+	for _, resLB := range resLBs {
+		lbARN, err := resLB.LoadBalancerARN().Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		desiredLBARNs.Insert(lbARN)
+	}
+	var deletedLBARNs []string
+	for _, sdkLB := range res.LoadBalancers {
+		lbARN := awssdk.ToString(sdkLB.LoadBalancer.LoadBalancerArn)
+		if !desiredLBARNs.Has(lbARN) {
+			deletedLBARNs = append(deletedLBARNs, lbARN)
+		}
+	}
+	return deletedLBARNs, nil
+}
+
 func (s *protectionSynthesizer) synthesizeProtectionsOnLB(ctx context.Context, lbARN string, resProtections []*shieldmodel.Protection) error {
 	if len(resProtections) != 1 {
 		return errors.Errorf("[should never happen] should be exactly one shield protection desired on LoadBalancer: %v", lbARN)
 	}
-	enableProtection := resProtections[0].Spec.Enabled
+	resProtection := resProtections[0]
+	enableProtection := resProtection.Spec.Enabled
 	protectionInfo, err := s.protectionManager.GetProtection(ctx, lbARN)
 	if err != nil {
 		return errors.Wrap(err, "failed to get shield protection on LoadBalancer")
 	}
 	switch {
 	case !enableProtection && protectionInfo != nil:
-		managedProtectionNames := sets.NewString(protectionNameManaged, protectionNameManagedLegacy)
-		if managedProtectionNames.Has(protectionInfo.Name) {
+		if isManagedProtection(protectionInfo) {
+			// the protection groups outlive the protection, hence the LoadBalancer is removed from them first.
+			if resProtection.Spec.ProtectionGroups != nil {
+				if err := s.protectionGroupManager.ReconcileMembership(ctx, lbARN, nil); err != nil {
+					return errors.Wrap(err, "failed to reconcile shield protection groups of LoadBalancer")
+				}
+			}
 			if err := s.protectionManager.DeleteProtection(ctx, lbARN, protectionInfo.ID); err != nil {
 				return errors.Wrap(err, "failed to delete shield protection on LoadBalancer")
 			}
@@ -77,13 +138,106 @@ func (s *protectionSynthesizer) synthesizeProtectionsOnLB(ctx context.Context, l
 				"protectionID", protectionInfo.ID)
 		}
 	case enableProtection && protectionInfo == nil:
-		if _, err := s.protectionManager.CreateProtection(ctx, lbARN, protectionNameManaged); err != nil {
+		protectionID, err := s.protectionManager.CreateProtection(ctx, lbARN, protectionNameManaged)
+		if err != nil {
 			return errors.Wrap(err, "failed to create shield protection on LoadBalancer")
 		}
+		return s.synthesizeProtectionAttributes(ctx, lbARN, resProtection.Spec, &ProtectionInfo{Name: protectionNameManaged, ID: protectionID})
+	case enableProtection && protectionInfo != nil:
+		if isManagedProtection(protectionInfo) {
+			return s.synthesizeProtectionAttributes(ctx, lbARN, resProtection.Spec, protectionInfo)
+		}
+		if hasProtectionAttributes(resProtection.Spec) {
+			s.logger.Info("ignoring attributes of unmanaged shield protection",
+				"protectionName", protectionInfo.Name,
+				"protectionID", protectionInfo.ID)
+		}
+	}
+	return nil
+}
+
+// synthesizeProtectionAttributes reconciles the attributes of a managed protection, attributes that are nil in spec are left unchanged.
+func (s *protectionSynthesizer) synthesizeProtectionAttributes(ctx context.Context, lbARN string, spec shieldmodel.ProtectionSpec, protectionInfo *ProtectionInfo) error {
+	if spec.HealthCheck != nil {
+		if err := s.synthesizeHealthCheck(ctx, lbARN, spec.HealthCheck.HealthCheckARN, protectionInfo); err != nil {
+			return errors.Wrap(err, "failed to reconcile shield health check of LoadBalancer")
+		}
+	}
+	if spec.ApplicationLayerAutomaticResponse != nil {
+		if err := s.synthesizeApplicationLayerAutomaticResponse(ctx, lbARN, *spec.ApplicationLayerAutomaticResponse, protectionInfo); err != nil {
+			return errors.Wrap(err, "failed to reconcile shield application layer automatic response of LoadBalancer")
+		}
+	}
+	if spec.ProtectionGroups != nil {
+		if err := s.protectionGroupManager.ReconcileMembership(ctx, lbARN, spec.ProtectionGroups.ProtectionGroups); err != nil {
+			return errors.Wrap(err, "failed to reconcile shield protection groups of LoadBalancer")
+		}
+	}
+	return nil
+}
+
+func (s *protectionSynthesizer) synthesizeHealthCheck(ctx context.Context, lbARN string, desiredHealthCheckARN string, protectionInfo *ProtectionInfo) error {
+	desiredHealthCheckID := ""
+	if desiredHealthCheckARN != "" {
+		parsedARN, err := arn.Parse(desiredHealthCheckARN)
+		if err != nil {
+			return errors.Wrapf(err, "invalid health check ARN %v", desiredHealthCheckARN)
+		}
+		desiredHealthCheckID = strings.TrimPrefix(parsedARN.Resource, healthCheckARNResourcePrefix)
+	}
+	// a protection has at most one health check, hence the other health checks are disassociated first.
+	for _, healthCheckID := range protectionInfo.HealthCheckIDs {
+		if healthCheckID == desiredHealthCheckID {
+			continue
+		}
+		healthCheckARN, err := buildHealthCheckARN(lbARN, healthCheckID)
+		if err != nil {
+			return err
+		}
+		if err := s.protectionManager.DisassociateHealthCheck(ctx, lbARN, protectionInfo.ID, healthCheckARN); err != nil {
+			return err
+		}
+	}
+	if desiredHealthCheckID != "" && !slices.Contains(protectionInfo.HealthCheckIDs, desiredHealthCheckID) {
+		return s.protectionManager.AssociateHealthCheck(ctx, lbARN, protectionInfo.ID, desiredHealthCheckARN)
+	}
+	return nil
+}
+
+func (s *protectionSynthesizer) synthesizeApplicationLayerAutomaticResponse(ctx context.Context, lbARN string, desired shieldmodel.ApplicationLayerAutomaticResponse, protectionInfo *ProtectionInfo) error {
+	currentAction := protectionInfo.ApplicationLayerAutomaticResponseAction
+	switch {
+	case !desired.Enabled && currentAction != "":
+		return s.protectionManager.DisableApplicationLayerAutomaticResponse(ctx, lbARN)
+	case desired.Enabled && currentAction == "":
+		return s.protectionManager.EnableApplicationLayerAutomaticResponse(ctx, lbARN, desired.Action)
+	case desired.Enabled && currentAction != desired.Action:
+		return s.protectionManager.UpdateApplicationLayerAutomaticResponse(ctx, lbARN, desired.Action)
 	}
 	return nil
 }
 
+func isManagedProtection(protectionInfo *ProtectionInfo) bool {
+	return sets.NewString(protectionNameManaged, protectionNameManagedLegacy).Has(protectionInfo.Name)
+}
+
+func hasProtectionAttributes(spec shieldmodel.ProtectionSpec) bool {
+	return spec.ProtectionGroups != nil || spec.HealthCheck != nil || spec.ApplicationLayerAutomaticResponse != nil
+}
+
+// buildHealthCheckARN builds the ARN of a Route 53 health check, in the partition of the LoadBalancer.
+func buildHealthCheckARN(lbARN string, healthCheckID string) (string, error) {
+	parsedLBARN, err := arn.Parse(lbARN)
+	if err != nil {
+		return "", err
+	}
+	return arn.ARN{
+		Partition: parsedLBARN.Partition,
+		Service:   "route53",
+		Resource:  healthCheckARNResourcePrefix + healthCheckID,
+	}.String(), nil
+}
+
 func mapResProtectionByResourceARN(resProtections []*shieldmodel.Protection) (map[string][]*shieldmodel.Protection, error) {
 	resProtectionsByResARN := make(map[string][]*shieldmodel.Protection, len(resProtections))
 	ctx := context.Background()
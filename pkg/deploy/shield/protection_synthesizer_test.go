@@ -3,10 +3,14 @@ package shield
 import (
 	"context"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -247,3 +251,233 @@ func Test_protectionSynthesizer_Synthesize(t *testing.T) {
 		})
 	}
 }
+
+func Test_protectionSynthesizer_Synthesize_attributes(t *testing.T) {
+	const (
+		lbARN          = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-1/1234567890abcdef"
+		healthCheckARN = "arn:aws:route53:::healthcheck/hc-1"
+	)
+	protectionGroups := []shieldmodel.ProtectionGroup{{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationSum}}
+	tests := []struct {
+		name           string
+		spec           shieldmodel.ProtectionSpec
+		protectionInfo *ProtectionInfo
+		expectCalls    func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager)
+		wantErr        string
+	}{
+		{
+			name: "attributes are configured on a new protection",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:                           true,
+				ProtectionGroups:                  &shieldmodel.ProtectionGroups{ProtectionGroups: protectionGroups},
+				HealthCheck:                       &shieldmodel.HealthCheck{HealthCheckARN: healthCheckARN},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: true, Action: shieldmodel.ApplicationLayerAutomaticResponseActionCount},
+			},
+			expectCalls: func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager) {
+				protectionManager.EXPECT().CreateProtection(gomock.Any(), lbARN, "managed by aws-load-balancer-controller").Return("protection-1", nil)
+				protectionManager.EXPECT().AssociateHealthCheck(gomock.Any(), lbARN, "protection-1", healthCheckARN).Return(nil)
+				protectionManager.EXPECT().EnableApplicationLayerAutomaticResponse(gomock.Any(), lbARN, shieldmodel.ApplicationLayerAutomaticResponseActionCount).Return(nil)
+				protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), lbARN, protectionGroups).Return(nil)
+			},
+		},
+		{
+			name: "attributes in sync are left unchanged",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:                           true,
+				HealthCheck:                       &shieldmodel.HealthCheck{HealthCheckARN: healthCheckARN},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: true, Action: shieldmodel.ApplicationLayerAutomaticResponseActionBlock},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name:                                    "managed by aws-load-balancer-controller",
+				ID:                                      "protection-1",
+				HealthCheckIDs:                          []string{"hc-1"},
+				ApplicationLayerAutomaticResponseAction: shieldmodel.ApplicationLayerAutomaticResponseActionBlock,
+			},
+		},
+		{
+			name: "attributes are updated",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:                           true,
+				HealthCheck:                       &shieldmodel.HealthCheck{HealthCheckARN: healthCheckARN},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: true, Action: shieldmodel.ApplicationLayerAutomaticResponseActionBlock},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name:                                    "managed by aws-load-balancer-controller",
+				ID:                                      "protection-1",
+				HealthCheckIDs:                          []string{"hc-0"},
+				ApplicationLayerAutomaticResponseAction: shieldmodel.ApplicationLayerAutomaticResponseActionCount,
+			},
+			expectCalls: func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager) {
+				gomock.InOrder(
+					protectionManager.EXPECT().DisassociateHealthCheck(gomock.Any(), lbARN, "protection-1", "arn:aws:route53:::healthcheck/hc-0").Return(nil),
+					protectionManager.EXPECT().AssociateHealthCheck(gomock.Any(), lbARN, "protection-1", healthCheckARN).Return(nil),
+				)
+				protectionManager.EXPECT().UpdateApplicationLayerAutomaticResponse(gomock.Any(), lbARN, shieldmodel.ApplicationLayerAutomaticResponseActionBlock).Return(nil)
+			},
+		},
+		{
+			name: "removed attributes are cleaned up",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:                           true,
+				ProtectionGroups:                  &shieldmodel.ProtectionGroups{},
+				HealthCheck:                       &shieldmodel.HealthCheck{},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: false},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name:                                    "managed by aws-load-balancer-controller",
+				ID:                                      "protection-1",
+				HealthCheckIDs:                          []string{"hc-1"},
+				ApplicationLayerAutomaticResponseAction: shieldmodel.ApplicationLayerAutomaticResponseActionBlock,
+			},
+			expectCalls: func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager) {
+				protectionManager.EXPECT().DisassociateHealthCheck(gomock.Any(), lbARN, "protection-1", healthCheckARN).Return(nil)
+				protectionManager.EXPECT().DisableApplicationLayerAutomaticResponse(gomock.Any(), lbARN).Return(nil)
+				protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), lbARN, nil).Return(nil)
+			},
+		},
+		{
+			name: "protection groups are left before the protection is deleted",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:          false,
+				ProtectionGroups: &shieldmodel.ProtectionGroups{},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name: "managed by aws-load-balancer-controller",
+				ID:   "protection-1",
+			},
+			expectCalls: func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager) {
+				gomock.InOrder(
+					protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), lbARN, nil).Return(nil),
+					protectionManager.EXPECT().DeleteProtection(gomock.Any(), lbARN, "protection-1").Return(nil),
+				)
+			},
+		},
+		{
+			name: "attributes of unmanaged protection are ignored",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:     true,
+				HealthCheck: &shieldmodel.HealthCheck{HealthCheckARN: healthCheckARN},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name: "some other name",
+				ID:   "protection-1",
+			},
+		},
+		{
+			name: "invalid health check ARN",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:     true,
+				HealthCheck: &shieldmodel.HealthCheck{HealthCheckARN: "hc-1"},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name: "managed by aws-load-balancer-controller",
+				ID:   "protection-1",
+			},
+			wantErr: "failed to reconcile shield health check of LoadBalancer: invalid health check ARN hc-1",
+		},
+		{
+			name: "failed to reconcile protection groups",
+			spec: shieldmodel.ProtectionSpec{
+				Enabled:          true,
+				ProtectionGroups: &shieldmodel.ProtectionGroups{ProtectionGroups: protectionGroups},
+			},
+			protectionInfo: &ProtectionInfo{
+				Name: "managed by aws-load-balancer-controller",
+				ID:   "protection-1",
+			},
+			expectCalls: func(protectionManager *MockProtectionManager, protectionGroupManager *MockProtectionGroupManager) {
+				protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), lbARN, protectionGroups).Return(fmt.Errorf("some error"))
+			},
+			wantErr: "failed to reconcile shield protection groups of LoadBalancer: some error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			protectionManager := NewMockProtectionManager(ctrl)
+			protectionGroupManager := NewMockProtectionGroupManager(ctrl)
+			protectionManager.EXPECT().GetProtection(gomock.Any(), lbARN).Return(tt.protectionInfo, nil)
+			if tt.expectCalls != nil {
+				tt.expectCalls(protectionManager, protectionGroupManager)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-stack"})
+			spec := tt.spec
+			spec.ResourceARN = core.LiteralStringToken(lbARN)
+			shieldmodel.NewProtection(stack, "LoadBalancer", spec)
+			s := NewProtectionSynthesizer(protectionManager, protectionGroupManager, logr.New(&log.NullLogSink{}), stack, nil)
+			err := s.Synthesize(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_protectionSynthesizer_PostSynthesize(t *testing.T) {
+	buildSDKLB := func(lbARN string) elbv2.LoadBalancerWithTags {
+		return elbv2.LoadBalancerWithTags{LoadBalancer: &elbv2types.LoadBalancer{LoadBalancerArn: awssdk.String(lbARN)}}
+	}
+	tests := []struct {
+		name        string
+		desiredLB   string
+		sdkLBs      elbv2.LoadBalancersResult
+		expectCalls func(protectionGroupManager *MockProtectionGroupManager)
+		wantErr     string
+	}{
+		{
+			name:      "no LoadBalancer deleted",
+			desiredLB: "lb-arn-1",
+			sdkLBs:    elbv2.LoadBalancersResult{LoadBalancers: []elbv2.LoadBalancerWithTags{buildSDKLB("lb-arn-1")}},
+		},
+		{
+			name:   "deleted LoadBalancer is removed from protection groups",
+			sdkLBs: elbv2.LoadBalancersResult{LoadBalancers: []elbv2.LoadBalancerWithTags{buildSDKLB("lb-arn-1")}},
+			expectCalls: func(protectionGroupManager *MockProtectionGroupManager) {
+				protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), "lb-arn-1", nil).Return(nil)
+			},
+		},
+		{
+			name:      "replaced LoadBalancer is removed from protection groups",
+			desiredLB: "lb-arn-2",
+			sdkLBs:    elbv2.LoadBalancersResult{LoadBalancers: []elbv2.LoadBalancerWithTags{buildSDKLB("lb-arn-1")}},
+			expectCalls: func(protectionGroupManager *MockProtectionGroupManager) {
+				protectionGroupManager.EXPECT().ReconcileMembership(gomock.Any(), "lb-arn-1", nil).Return(fmt.Errorf("some error"))
+			},
+			wantErr: "failed to remove deleted LoadBalancer from shield protection groups: some error",
+		},
+		{
+			name:    "failed to find LoadBalancers",
+			sdkLBs:  elbv2.LoadBalancersResult{Err: fmt.Errorf("some error")},
+			wantErr: "some error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			protectionManager := NewMockProtectionManager(ctrl)
+			protectionGroupManager := NewMockProtectionGroupManager(ctrl)
+			if tt.expectCalls != nil {
+				tt.expectCalls(protectionGroupManager)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-stack"})
+			if tt.desiredLB != "" {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				lb.SetStatus(elbv2model.LoadBalancerStatus{LoadBalancerARN: tt.desiredLB})
+			}
+			s := NewProtectionSynthesizer(protectionManager, protectionGroupManager, logr.New(&log.NullLogSink{}), stack,
+				func() elbv2.LoadBalancersResult { return tt.sdkLBs })
+			err := s.PostSynthesize(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
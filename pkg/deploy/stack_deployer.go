@@ -63,6 +63,7 @@ func NewDefaultStackDeployer(cloud services.Cloud, k8sClient client.Client,
 		wafv2WebACLAssociationManager:       wafv2.NewDefaultWebACLAssociationManager(cloud.WAFv2(), logger),
 		wafRegionalWebACLAssociationManager: wafregional.NewDefaultWebACLAssociationManager(cloud.WAFRegional(), logger),
 		shieldProtectionManager:             shield.NewDefaultProtectionManager(cloud.Shield(), logger),
+		shieldProtectionGroupManager:        shield.NewDefaultProtectionGroupManager(cloud.Shield(), config.ClusterName, logger),
 		featureGates:                        config.FeatureGates,
 		vpcID:                               cloud.VpcID(),
 		logger:                              logger,
@@ -95,6 +96,7 @@ type defaultStackDeployer struct {
 	wafv2WebACLAssociationManager       wafv2.WebACLAssociationManager
 	wafRegionalWebACLAssociationManager wafregional.WebACLAssociationManager
 	shieldProtectionManager             shield.ProtectionManager
+	shieldProtectionGroupManager        shield.ProtectionGroupManager
 	featureGates                        config.FeatureGates
 	vpcID                               string
 	metricsCollector                    lbcmetrics.MetricCollector
@@ -126,6 +128,17 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack, met
 		return elbv2.TargetGroupsResult{TargetGroups: tgs, Err: err}
 	})
 
+	// AWS LoadBalancers are found before the LoadBalancerSynthesizer deletes unmatched ones,
+	// so that the add-on synthesizers can release the deleted LoadBalancers.
+	findSDKLoadBalancers := sync.OnceValue(func() elbv2.LoadBalancersResult {
+		stackTags := d.trackingProvider.StackTags(stack)
+		stackTagsLegacy := d.trackingProvider.StackTagsLegacy(stack)
+		lbs, err := d.elbv2TaggingManager.ListLoadBalancers(ctx,
+			tracking.TagsAsTagFilter(stackTags),
+			tracking.TagsAsTagFilter(stackTagsLegacy))
+		return elbv2.LoadBalancersResult{LoadBalancers: lbs, Err: err}
+	})
+
 	if d.enableFrontendNLB {
 		var desiredFENLBState []*elbv2model.FrontendNlbTargetGroupDesiredState
 		stack.ListResources(&desiredFENLBState)
@@ -145,7 +158,7 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack, met
 
 	synthesizers = append(synthesizers,
 		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, d.featureGates, stack, findSDKTargetGroups),
-		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, d.featureGates, d.controllerConfig, stack),
+		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, d.featureGates, d.controllerConfig, metricsCollector, stack, findSDKLoadBalancers),
 		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
 		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LRManager, d.logger, d.featureGates, stack),
 		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack))
@@ -161,7 +174,7 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack, met
 		if err != nil {
 			d.logger.Error(err, "unable to determine AWS Shield subscription state, skipping AWS shield reconciliation")
 		} else if shieldSubscribed {
-			synthesizers = append(synthesizers, shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.logger, stack))
+			synthesizers = append(synthesizers, shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.shieldProtectionGroupManager, d.logger, stack, findSDKLoadBalancers))
 		}
 	}
 
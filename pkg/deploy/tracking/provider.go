@@ -29,6 +29,11 @@ import (
 //    * `stack-id` will be `namespace/globalAcceleratorName`
 //  * `aga.k8s.aws/resource: resource-id` will be applied on all AWS resources provisioned for GlobalAccelerator resources:
 //    * For GlobalAccelerator, `resource-id` will be `GlobalAccelerator`
+//  * `wafv2.k8s.aws/stack: stack-id` will be applied on all AWS resources provisioned for WAFv2WebACL resources:
+//    * `stack-id` will be `webACLName`
+//  * `wafv2.k8s.aws/resource: resource-id` will be applied on all AWS resources provisioned for WAFv2WebACL resources:
+//    * For WebACL, `resource-id` will be `WebACL`
+//    * For IPSet, `resource-id` will be `IPSet/ipSetName`
 //  * `elbv2.k8s.aws/cluster-region: region` will be applied on AGA AWS resources when region is available.
 //For K8s resources created by this controller, the labelling strategy is as follows:
 //  * For explicit IngressGroup, the following tags will be applied on all K8s resources:
@@ -44,7 +49,7 @@ import (
 const clusterNameTagKeyLegacy = "ingress.k8s.aws/cluster"
 
 // Cluster region tag key
-const clusterRegionTagKey = "elbv2.k8s.aws/cluster-region"
+const clusterRegionTagKey = shared_constants.TagKeyK8sClusterRegion
 
 // an abstraction that generates metadata to track actual resources provisioned for stack.
 type Provider interface {
@@ -103,3 +103,17 @@ const (
 	// AnnotationDryRunEnabledValue is the value that enables dry-run mode on a Gateway.
 	AnnotationDryRunEnabledValue = "true"
 )
+
+/*
+   ListenerSet delegation constants
+*/
+
+const (
+	// AnnotationListenerSetAllowedPorts when set on a Gateway, restricts the ports ListenerSet listeners can use.
+	// The value is a comma separated list of ports and port ranges, e.g. "443,8000-8100".
+	AnnotationListenerSetAllowedPorts = "gateway.k8s.aws/listener-set-allowed-ports"
+
+	// TLSOptionCertificateARNs is the listener TLS option holding a comma separated list of ACM certificate ARNs
+	// served by the listener, in addition to the certificates of the LoadBalancerConfiguration.
+	TLSOptionCertificateARNs = "gateway.k8s.aws/certificate-arns"
+)
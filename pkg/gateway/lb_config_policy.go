@@ -0,0 +1,330 @@
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+)
+
+// LoadBalancerConfigPolicyEnforcer enforces the policies of the GatewayClass LoadBalancerConfiguration.
+type LoadBalancerConfigPolicyEnforcer interface {
+	// Enforce applies the locked fields and defaults of the policies of gwClassLbConfig to mergedLbConfig.
+	// It returns a PolicyViolationError if gwLbConfig or the resulting configuration violates the policies.
+	Enforce(gwClassLbConfig elbv2gw.LoadBalancerConfiguration, gwLbConfig *elbv2gw.LoadBalancerConfiguration, mergedLbConfig elbv2gw.LoadBalancerConfiguration) (elbv2gw.LoadBalancerConfiguration, error)
+}
+
+// PolicyViolationError is returned when a LoadBalancerConfiguration violates the policies of the GatewayClass.
+type PolicyViolationError struct {
+	GatewayClassLbConfig string
+	Violations           []string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("LoadBalancerConfiguration violates the policies of GatewayClass LoadBalancerConfiguration %s: %s", e.GatewayClassLbConfig, strings.Join(e.Violations, "; "))
+}
+
+var _ LoadBalancerConfigPolicyEnforcer = &loadBalancerConfigPolicyEnforcerImpl{}
+
+type loadBalancerConfigPolicyEnforcerImpl struct {
+}
+
+func NewLoadBalancerConfigPolicyEnforcer() LoadBalancerConfigPolicyEnforcer {
+	return &loadBalancerConfigPolicyEnforcerImpl{}
+}
+
+func (enforcer *loadBalancerConfigPolicyEnforcerImpl) Enforce(gwClassLbConfig elbv2gw.LoadBalancerConfiguration, gwLbConfig *elbv2gw.LoadBalancerConfiguration, mergedLbConfig elbv2gw.LoadBalancerConfiguration) (elbv2gw.LoadBalancerConfiguration, error) {
+	if len(gwClassLbConfig.Spec.Policies) == 0 {
+		return mergedLbConfig, nil
+	}
+	// the merged configuration shares fields with the cached configurations, which must not be mutated.
+	enforced := *mergedLbConfig.DeepCopy()
+	classSpec := &gwClassLbConfig.Spec
+	var gwSpec *elbv2gw.LoadBalancerConfigurationSpec
+	if gwLbConfig != nil {
+		gwSpec = &gwLbConfig.Spec
+	}
+
+	var violations []string
+	for _, policy := range classSpec.Policies {
+		if policy.Field == elbv2gw.PolicyFieldTags {
+			violations = append(violations, enforceTagPolicy(policy, classSpec, gwSpec, &enforced.Spec)...)
+			continue
+		}
+		accessor, ok := policyFieldAccessors[policy.Field]
+		if !ok {
+			continue
+		}
+		if policy.Locked && accessor.copy != nil {
+			if gwSpec != nil {
+				gwValues, gwSet := accessor.get(gwSpec)
+				classValues, _ := accessor.get(classSpec)
+				if gwSet && !slices.Equal(gwValues, classValues) {
+					violations = append(violations, fmt.Sprintf("%s is locked and cannot be set to %v", policy.Field, gwValues))
+				}
+			}
+			accessor.copy(&enforced.Spec, classSpec)
+		}
+		if policy.Default != nil && accessor.setDefault != nil {
+			accessor.setDefault(&enforced.Spec, *policy.Default)
+		}
+		if len(policy.AllowedValues) != 0 {
+			values, _ := accessor.get(&enforced.Spec)
+			for _, value := range values {
+				if !slices.Contains(policy.AllowedValues, value) {
+					violations = append(violations, fmt.Sprintf("%s value %q is not allowed, must be one of %v", policy.Field, value, policy.AllowedValues))
+				}
+			}
+		}
+	}
+
+	if len(violations) != 0 {
+		return elbv2gw.LoadBalancerConfiguration{}, &PolicyViolationError{
+			GatewayClassLbConfig: fmt.Sprintf("%s/%s", gwClassLbConfig.Namespace, gwClassLbConfig.Name),
+			Violations:           violations,
+		}
+	}
+	return enforced, nil
+}
+
+// enforceTagPolicy restores the locked tags of the GatewayClass configuration, and checks that required tags are set.
+func enforceTagPolicy(policy elbv2gw.LoadBalancerConfigurationPolicy, classSpec *elbv2gw.LoadBalancerConfigurationSpec, gwSpec *elbv2gw.LoadBalancerConfigurationSpec, enforced *elbv2gw.LoadBalancerConfigurationSpec) []string {
+	var violations []string
+	if policy.Locked && classSpec.Tags != nil {
+		tags := make(map[string]string)
+		if enforced.Tags != nil {
+			for key, value := range *enforced.Tags {
+				tags[key] = value
+			}
+		}
+		for _, key := range sortedMapKeys(*classSpec.Tags) {
+			classValue := (*classSpec.Tags)[key]
+			if gwSpec != nil && gwSpec.Tags != nil {
+				if gwValue, exists := (*gwSpec.Tags)[key]; exists && gwValue != classValue {
+					violations = append(violations, fmt.Sprintf("tag %q is locked and cannot be set to %q", key, gwValue))
+				}
+			}
+			tags[key] = classValue
+		}
+		enforced.Tags = &tags
+	}
+	for _, key := range policy.RequiredKeys {
+		if enforced.Tags == nil {
+			violations = append(violations, fmt.Sprintf("tag %q is required", key))
+			continue
+		}
+		if _, exists := (*enforced.Tags)[key]; !exists {
+			violations = append(violations, fmt.Sprintf("tag %q is required", key))
+		}
+	}
+	return violations
+}
+
+// ValidateLoadBalancerConfigPolicies validates the policies of a LoadBalancerConfiguration.
+func ValidateLoadBalancerConfigPolicies(policies []elbv2gw.LoadBalancerConfigurationPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for idx, policy := range policies {
+		policyPath := fldPath.Index(idx)
+		if policy.Field == elbv2gw.PolicyFieldTags {
+			if policy.Default != nil {
+				allErrs = append(allErrs, field.Forbidden(policyPath.Child("default"), "default is not supported for tags"))
+			}
+			if len(policy.AllowedValues) != 0 {
+				allErrs = append(allErrs, field.Forbidden(policyPath.Child("allowedValues"), "allowedValues is not supported for tags"))
+			}
+			continue
+		}
+		accessor, ok := policyFieldAccessors[policy.Field]
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(policyPath.Child("field"), policy.Field, supportedPolicyFields()))
+			continue
+		}
+		if len(policy.RequiredKeys) != 0 {
+			allErrs = append(allErrs, field.Forbidden(policyPath.Child("requiredKeys"), "requiredKeys is only supported for tags"))
+		}
+		if policy.Locked && accessor.copy == nil {
+			allErrs = append(allErrs, field.Forbidden(policyPath.Child("locked"), fmt.Sprintf("locked is not supported for %s", policy.Field)))
+		}
+		if policy.Default != nil {
+			if accessor.setDefault == nil {
+				allErrs = append(allErrs, field.Forbidden(policyPath.Child("default"), fmt.Sprintf("default is not supported for %s", policy.Field)))
+			} else if len(policy.AllowedValues) != 0 && !slices.Contains(policy.AllowedValues, *policy.Default) {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("default"), *policy.Default, "default must be one of allowedValues"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// policyFieldAccessor reads and writes the field of a LoadBalancerConfigurationSpec a policy applies to.
+type policyFieldAccessor struct {
+	// get returns the values of the field, and whether the field is set.
+	get func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool)
+	// copy sets the field of dst to the field of src. nil if the field can't be locked.
+	copy func(dst *elbv2gw.LoadBalancerConfigurationSpec, src *elbv2gw.LoadBalancerConfigurationSpec)
+	// setDefault sets the field to value if it isn't set. nil if the field doesn't support defaults.
+	setDefault func(spec *elbv2gw.LoadBalancerConfigurationSpec, value string)
+}
+
+var policyFieldAccessors = map[elbv2gw.LoadBalancerConfigurationPolicyField]policyFieldAccessor{
+	elbv2gw.PolicyFieldScheme: stringFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **elbv2gw.LoadBalancerScheme {
+		return &spec.Scheme
+	}),
+	elbv2gw.PolicyFieldIpAddressType: stringFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **elbv2gw.LoadBalancerIpAddressType {
+		return &spec.IpAddressType
+	}),
+	elbv2gw.PolicyFieldEnforceSecurityGroupInboundRulesOnPrivateLinkTraffic: stringFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **string {
+		return &spec.EnforceSecurityGroupInboundRulesOnPrivateLinkTraffic
+	}),
+	elbv2gw.PolicyFieldCustomerOwnedIpv4Pool: stringFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **string {
+		return &spec.CustomerOwnedIpv4Pool
+	}),
+	elbv2gw.PolicyFieldIPv4IPAMPoolId: stringFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **string {
+		return &spec.IPv4IPAMPoolId
+	}),
+	elbv2gw.PolicyFieldSecurityGroups: listFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **[]string {
+		return &spec.SecurityGroups
+	}),
+	elbv2gw.PolicyFieldSourceRanges: listFieldAccessor(func(spec *elbv2gw.LoadBalancerConfigurationSpec) **[]string {
+		return &spec.SourceRanges
+	}),
+	elbv2gw.PolicyFieldWAFv2: {
+		get: func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool) {
+			if spec.WAFv2 == nil {
+				return nil, false
+			}
+			if spec.WAFv2.ACL == "" {
+				return []string{spec.WAFv2.WebACLRef}, true
+			}
+			return []string{spec.WAFv2.ACL}, true
+		},
+		copy: func(dst *elbv2gw.LoadBalancerConfigurationSpec, src *elbv2gw.LoadBalancerConfigurationSpec) {
+			dst.WAFv2 = src.WAFv2.DeepCopy()
+		},
+		setDefault: func(spec *elbv2gw.LoadBalancerConfigurationSpec, value string) {
+			if spec.WAFv2 == nil {
+				spec.WAFv2 = &elbv2gw.WAFv2Configuration{ACL: value}
+			}
+		},
+	},
+	elbv2gw.PolicyFieldShieldAdvanced: {
+		get: func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool) {
+			if spec.ShieldAdvanced == nil {
+				return nil, false
+			}
+			return []string{strconv.FormatBool(spec.ShieldAdvanced.Enabled)}, true
+		},
+		copy: func(dst *elbv2gw.LoadBalancerConfigurationSpec, src *elbv2gw.LoadBalancerConfigurationSpec) {
+			dst.ShieldAdvanced = src.ShieldAdvanced.DeepCopy()
+		},
+		setDefault: func(spec *elbv2gw.LoadBalancerConfigurationSpec, value string) {
+			if spec.ShieldAdvanced == nil {
+				spec.ShieldAdvanced = &elbv2gw.ShieldConfiguration{Enabled: value == "true"}
+			}
+		},
+	},
+	elbv2gw.PolicyFieldSSLPolicy: {
+		get: func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool) {
+			var values []string
+			for _, cfg := range secureListenerConfigurations(spec) {
+				if cfg.SslPolicy != nil {
+					values = append(values, *cfg.SslPolicy)
+				}
+			}
+			return values, len(values) != 0
+		},
+		setDefault: func(spec *elbv2gw.LoadBalancerConfigurationSpec, value string) {
+			for _, cfg := range secureListenerConfigurations(spec) {
+				if cfg.SslPolicy == nil {
+					sslPolicy := value
+					cfg.SslPolicy = &sslPolicy
+				}
+			}
+		},
+	},
+}
+
+func stringFieldAccessor[T ~string](fieldFn func(spec *elbv2gw.LoadBalancerConfigurationSpec) **T) policyFieldAccessor {
+	return policyFieldAccessor{
+		get: func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool) {
+			value := *fieldFn(spec)
+			if value == nil {
+				return nil, false
+			}
+			return []string{string(*value)}, true
+		},
+		copy: func(dst *elbv2gw.LoadBalancerConfigurationSpec, src *elbv2gw.LoadBalancerConfigurationSpec) {
+			value := *fieldFn(src)
+			if value == nil {
+				*fieldFn(dst) = nil
+				return
+			}
+			copied := *value
+			*fieldFn(dst) = &copied
+		},
+		setDefault: func(spec *elbv2gw.LoadBalancerConfigurationSpec, value string) {
+			if *fieldFn(spec) == nil {
+				typedValue := T(value)
+				*fieldFn(spec) = &typedValue
+			}
+		},
+	}
+}
+
+func listFieldAccessor(fieldFn func(spec *elbv2gw.LoadBalancerConfigurationSpec) **[]string) policyFieldAccessor {
+	return policyFieldAccessor{
+		get: func(spec *elbv2gw.LoadBalancerConfigurationSpec) ([]string, bool) {
+			values := *fieldFn(spec)
+			if values == nil {
+				return nil, false
+			}
+			return *values, true
+		},
+		copy: func(dst *elbv2gw.LoadBalancerConfigurationSpec, src *elbv2gw.LoadBalancerConfigurationSpec) {
+			values := *fieldFn(src)
+			if values == nil {
+				*fieldFn(dst) = nil
+				return
+			}
+			copied := slices.Clone(*values)
+			*fieldFn(dst) = &copied
+		},
+	}
+}
+
+// secureListenerConfigurations returns the HTTPS and TLS listener configurations of spec.
+func secureListenerConfigurations(spec *elbv2gw.LoadBalancerConfigurationSpec) []*elbv2gw.ListenerConfiguration {
+	if spec.ListenerConfigurations == nil {
+		return nil
+	}
+	var configs []*elbv2gw.ListenerConfiguration
+	for i := range *spec.ListenerConfigurations {
+		cfg := &(*spec.ListenerConfigurations)[i]
+		protocol, _, _ := strings.Cut(string(cfg.ProtocolPort), ":")
+		if protocol == "HTTPS" || protocol == "TLS" {
+			configs = append(configs, cfg)
+		}
+	}
+	return configs
+}
+
+func supportedPolicyFields() []string {
+	fields := []string{string(elbv2gw.PolicyFieldTags)}
+	for policyField := range policyFieldAccessors {
+		fields = append(fields, string(policyField))
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,269 @@
+package gateway
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+)
+
+func Test_Enforce(t *testing.T) {
+	internalScheme := elbv2gw.LoadBalancerSchemeInternal
+	internetFacingScheme := elbv2gw.LoadBalancerSchemeInternetFacing
+	dualstack := elbv2gw.LoadBalancerIpAddressTypeDualstack
+	mergeModeGW := elbv2gw.MergeModePreferGateway
+	classMeta := metav1.ObjectMeta{Namespace: "platform", Name: "guardrails"}
+
+	testCases := []struct {
+		name            string
+		gwClassLbConfig elbv2gw.LoadBalancerConfiguration
+		gwLbConfig      *elbv2gw.LoadBalancerConfiguration
+		expected        elbv2gw.LoadBalancerConfiguration
+		expectedErr     string
+	}{
+		{
+			name: "no policies",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec:       elbv2gw.LoadBalancerConfigurationSpec{MergingMode: &mergeModeGW, Scheme: &internalScheme},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacingScheme},
+			},
+			expected: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacingScheme},
+			},
+		},
+		{
+			name: "locked scheme overrides prefer-gateway merge",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					MergingMode: &mergeModeGW,
+					Scheme:      &internalScheme,
+					Policies:    []elbv2gw.LoadBalancerConfigurationPolicy{{Field: elbv2gw.PolicyFieldScheme, Locked: true}},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{IpAddressType: &dualstack},
+			},
+			expected: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internalScheme, IpAddressType: &dualstack},
+			},
+		},
+		{
+			name: "locked scheme set by gateway",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Scheme:   &internalScheme,
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{{Field: elbv2gw.PolicyFieldScheme, Locked: true}},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacingScheme},
+			},
+			expectedErr: "LoadBalancerConfiguration violates the policies of GatewayClass LoadBalancerConfiguration platform/guardrails: scheme is locked and cannot be set to [internet-facing]",
+		},
+		{
+			name: "defaults without gateway config",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldScheme, Default: awssdk.String("internal")},
+						{Field: elbv2gw.PolicyFieldSSLPolicy, Default: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06")},
+					},
+				},
+			},
+			expected: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internalScheme},
+			},
+		},
+		{
+			name: "ssl policy default and allowed values",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{
+							Field:         elbv2gw.PolicyFieldSSLPolicy,
+							Default:       awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+							AllowedValues: []string{"ELBSecurityPolicy-TLS13-1-2-2021-06", "ELBSecurityPolicy-TLS13-1-3-2021-06"},
+						},
+					},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					ListenerConfigurations: &[]elbv2gw.ListenerConfiguration{
+						{ProtocolPort: "HTTP:80"},
+						{ProtocolPort: "HTTPS:443"},
+						{ProtocolPort: "HTTPS:8443", SslPolicy: awssdk.String("ELBSecurityPolicy-2016-08")},
+					},
+				},
+			},
+			expectedErr: "LoadBalancerConfiguration violates the policies of GatewayClass LoadBalancerConfiguration platform/guardrails: sslPolicy value \"ELBSecurityPolicy-2016-08\" is not allowed, must be one of [ELBSecurityPolicy-TLS13-1-2-2021-06 ELBSecurityPolicy-TLS13-1-3-2021-06]",
+		},
+		{
+			name: "ssl policy default applies to secure listeners only",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldSSLPolicy, Default: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06")},
+					},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					ListenerConfigurations: &[]elbv2gw.ListenerConfiguration{
+						{ProtocolPort: "HTTP:80"},
+						{ProtocolPort: "HTTPS:443"},
+					},
+				},
+			},
+			expected: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					ListenerConfigurations: &[]elbv2gw.ListenerConfiguration{
+						{ProtocolPort: "HTTP:80"},
+						{ProtocolPort: "HTTPS:443", SslPolicy: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06")},
+					},
+				},
+			},
+		},
+		{
+			name: "locked and required tags",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Tags: &map[string]string{"cost-center": "platform"},
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldTags, Locked: true, RequiredKeys: []string{"cost-center", "team"}},
+					},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Tags: &map[string]string{"team": "checkout"},
+				},
+			},
+			expected: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Tags: &map[string]string{"cost-center": "platform", "team": "checkout"},
+				},
+			},
+		},
+		{
+			name: "locked tag changed and required tag missing",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Tags: &map[string]string{"cost-center": "platform"},
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldTags, Locked: true, RequiredKeys: []string{"team"}},
+					},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Tags: &map[string]string{"cost-center": "checkout"},
+				},
+			},
+			expectedErr: "LoadBalancerConfiguration violates the policies of GatewayClass LoadBalancerConfiguration platform/guardrails: tag \"cost-center\" is locked and cannot be set to \"checkout\"; tag \"team\" is required",
+		},
+		{
+			name: "source ranges allowed values",
+			gwClassLbConfig: elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: classMeta,
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldSourceRanges, AllowedValues: []string{"10.0.0.0/8"}},
+					},
+				},
+			},
+			gwLbConfig: &elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					SourceRanges: &[]string{"10.0.0.0/8", "0.0.0.0/0"},
+				},
+			},
+			expectedErr: "LoadBalancerConfiguration violates the policies of GatewayClass LoadBalancerConfiguration platform/guardrails: sourceRanges value \"0.0.0.0/0\" is not allowed, must be one of [10.0.0.0/8]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := tc.gwClassLbConfig
+			if tc.gwLbConfig != nil {
+				merged = NewLoadBalancerConfigMerger().Merge(tc.gwClassLbConfig, *tc.gwLbConfig)
+				merged.Spec.LoadBalancerAttributes = nil
+				if merged.Spec.Tags != nil && len(*merged.Spec.Tags) == 0 {
+					merged.Spec.Tags = nil
+				}
+			} else {
+				merged.ObjectMeta = metav1.ObjectMeta{}
+			}
+			gwClassLbConfig := tc.gwClassLbConfig.DeepCopy()
+			result, err := NewLoadBalancerConfigPolicyEnforcer().Enforce(tc.gwClassLbConfig, tc.gwLbConfig, merged)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			result.Spec.Policies = nil
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, *gwClassLbConfig, tc.gwClassLbConfig)
+		})
+	}
+}
+
+func Test_ValidateLoadBalancerConfigPolicies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policies []elbv2gw.LoadBalancerConfigurationPolicy
+		expected string
+	}{
+		{
+			name: "valid",
+			policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+				{Field: elbv2gw.PolicyFieldScheme, Locked: true},
+				{Field: elbv2gw.PolicyFieldIpAddressType, Default: awssdk.String("ipv4"), AllowedValues: []string{"ipv4", "dualstack"}},
+				{Field: elbv2gw.PolicyFieldTags, Locked: true, RequiredKeys: []string{"team"}},
+			},
+		},
+		{
+			name: "unsupported settings",
+			policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+				{Field: elbv2gw.PolicyFieldSSLPolicy, Locked: true},
+				{Field: elbv2gw.PolicyFieldSourceRanges, Default: awssdk.String("10.0.0.0/8")},
+				{Field: elbv2gw.PolicyFieldTags, AllowedValues: []string{"a"}},
+				{Field: elbv2gw.PolicyFieldScheme, RequiredKeys: []string{"team"}},
+			},
+			expected: "[spec.policies[0].locked: Forbidden: locked is not supported for sslPolicy, " +
+				"spec.policies[1].default: Forbidden: default is not supported for sourceRanges, " +
+				"spec.policies[2].allowedValues: Forbidden: allowedValues is not supported for tags, " +
+				"spec.policies[3].requiredKeys: Forbidden: requiredKeys is only supported for tags]",
+		},
+		{
+			name: "default not allowed",
+			policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+				{Field: elbv2gw.PolicyFieldScheme, Default: awssdk.String("internet-facing"), AllowedValues: []string{"internal"}},
+			},
+			expected: "spec.policies[0].default: Invalid value: \"internet-facing\": default must be one of allowedValues",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateLoadBalancerConfigPolicies(tc.policies, field.NewPath("spec", "policies"))
+			if tc.expected == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			assert.EqualError(t, errs.ToAggregate(), tc.expected)
+		})
+	}
+}
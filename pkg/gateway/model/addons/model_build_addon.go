@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
@@ -49,6 +50,12 @@ func (aob *addOnBuilderImpl) BuildAddons(lbSpec *elbv2model.LoadBalancerSpec, lb
 			})
 			prestack = append(prestack, shieldPrestack)
 			break
+		case addon.ShieldProtectionGroups, addon.ShieldHealthCheck, addon.ShieldApplicationLayerAutomaticResponse:
+			// The attributes are reconciled by the Shield prestack addon, they only need to be tracked here.
+			metadata = append(metadata, addon.AddonMetadata{
+				Name:    supportedAddon,
+				Enabled: isShieldAttributeEnabled(lbCfg, supportedAddon),
+			})
 		case addon.ProvisionedCapacity:
 			// PC doesn't rely on the resource stack, as it's added directly to the LB Spec hence no prestack addon needed.
 			metadata = append(metadata, addon.AddonMetadata{
@@ -89,26 +96,39 @@ func (aob *addOnBuilderImpl) buildShield(lbCfg elbv2gw.LoadBalancerConfiguration
 		return false, makeNoOpPrestack()
 	}
 
-	return shieldEnabled, makeShieldPrestack(shieldEnabled)
+	shieldPrestack := &shield{
+		enabled: shieldEnabled,
+	}
+	// Likewise, the attributes are only reverted if they're active, otherwise they're left unchanged.
+	if isShieldAttributeEnabled(lbCfg, addon.ShieldProtectionGroups) || aob.isAddonActive(addon.ShieldProtectionGroups, previousAddonConfig) {
+		shieldPrestack.protectionGroups = buildShieldProtectionGroups(lbCfg)
+	}
+	if isShieldAttributeEnabled(lbCfg, addon.ShieldHealthCheck) || aob.isAddonActive(addon.ShieldHealthCheck, previousAddonConfig) {
+		shieldPrestack.healthCheck = buildShieldHealthCheck(lbCfg)
+	}
+	if isShieldAttributeEnabled(lbCfg, addon.ShieldApplicationLayerAutomaticResponse) || aob.isAddonActive(addon.ShieldApplicationLayerAutomaticResponse, previousAddonConfig) {
+		shieldPrestack.applicationLayerAutomaticResponse = buildShieldApplicationLayerAutomaticResponse(lbCfg)
+	}
+	return shieldEnabled, shieldPrestack
 }
 
 func (aob *addOnBuilderImpl) buildProvisionedCapacity(lbSpec *elbv2model.LoadBalancerSpec, lbCfg elbv2gw.LoadBalancerConfiguration, previousAddonConfig []addon.Addon) bool {
-	var minCapacity int32
-	if lbCfg.Spec.MinimumLoadBalancerCapacity != nil {
-		minCapacity = lbCfg.Spec.MinimumLoadBalancerCapacity.CapacityUnits
+	minCapacity := capacityreservation.FromLoadBalancerConfiguration(lbCfg.Spec.MinimumLoadBalancerCapacity)
+	if minCapacity == nil {
+		minCapacity = &elbv2model.MinimumLoadBalancerCapacity{}
 	}
+	// A scheduled capacity reservation keeps the addon active, it may only be raised during the scheduled windows.
+	enabled := minCapacity.CapacityUnits != 0 || capacityreservation.IsScheduled(minCapacity)
 
 	// Check if we're trying to disable PC, if so, we should only do so if the addon is active.
 	// We should not call PC APIs repeatedly when the user has disabled it.
-	if minCapacity == 0 && !aob.isAddonActive(addon.ProvisionedCapacity, previousAddonConfig) {
+	if !enabled && !aob.isAddonActive(addon.ProvisionedCapacity, previousAddonConfig) {
 		return false
 	}
 
-	lbSpec.MinimumLoadBalancerCapacity = &elbv2model.MinimumLoadBalancerCapacity{
-		CapacityUnits: minCapacity,
-	}
+	lbSpec.MinimumLoadBalancerCapacity = minCapacity
 
-	return minCapacity != 0
+	return enabled
 }
 
 func (aob *addOnBuilderImpl) isAddonActive(target addon.Addon, previousAddonConfig []addon.Addon) bool {
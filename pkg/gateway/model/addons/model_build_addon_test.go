@@ -4,6 +4,7 @@ import (
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
@@ -11,6 +12,7 @@ import (
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
 	"testing"
+	"time"
 )
 
 func Test_buildAddons(t *testing.T) {
@@ -50,6 +52,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 		},
 		{
@@ -87,6 +101,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{
@@ -115,6 +141,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{
@@ -143,6 +181,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{
@@ -178,6 +228,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{},
@@ -204,6 +266,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{},
@@ -228,6 +302,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: true,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			lbCfg: elbv2gw.LoadBalancerConfiguration{
 				Spec: elbv2gw.LoadBalancerConfigurationSpec{
@@ -238,6 +324,51 @@ func Test_buildAddons(t *testing.T) {
 			},
 			expectedPcValue: awssdk.Int32(100),
 		},
+		{
+			name:                "enabled pc with schedule only",
+			supportedAddons:     addon.AllAddons,
+			previousAddonConfig: []addon.Addon{},
+			expectedMetadata: []addon.AddonMetadata{
+				{
+					Name:    addon.WAFv2,
+					Enabled: false,
+				},
+				{
+					Name:    addon.Shield,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ProvisionedCapacity,
+					Enabled: true,
+				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
+			},
+			lbCfg: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					MinimumLoadBalancerCapacity: &elbv2gw.MinimumLoadBalancerCapacity{
+						Schedules: []elbv2gw.CapacityReservationSchedule{
+							{
+								Start:         "0 8 * * *",
+								Duration:      metav1.Duration{Duration: time.Hour},
+								CapacityUnits: 100,
+							},
+						},
+					},
+				},
+			},
+			expectedPcValue: awssdk.Int32(0),
+		},
 		{
 			name:            "pc was enabled, now is not",
 			supportedAddons: addon.AllAddons,
@@ -257,6 +388,18 @@ func Test_buildAddons(t *testing.T) {
 					Name:    addon.ProvisionedCapacity,
 					Enabled: false,
 				},
+				{
+					Name:    addon.ShieldProtectionGroups,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldHealthCheck,
+					Enabled: false,
+				},
+				{
+					Name:    addon.ShieldApplicationLayerAutomaticResponse,
+					Enabled: false,
+				},
 			},
 			expectedPcValue: awssdk.Int32(0),
 		},
@@ -305,3 +448,119 @@ func Test_buildAddons(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildAddons_shieldAttributes(t *testing.T) {
+	lbArn := coremodel.LiteralStringToken("test")
+	supportedAddons := []addon.Addon{addon.Shield, addon.ShieldProtectionGroups, addon.ShieldHealthCheck, addon.ShieldApplicationLayerAutomaticResponse}
+	mean := elbv2gw.ShieldProtectionGroupAggregationMean
+	testCases := []struct {
+		name                string
+		shieldCfg           *elbv2gw.ShieldConfiguration
+		previousAddonConfig []addon.Addon
+		expectedEnabled     map[addon.Addon]bool
+		expectedSpec        *shieldmodel.ProtectionSpec
+	}{
+		{
+			name: "attributes enabled",
+			shieldCfg: &elbv2gw.ShieldConfiguration{
+				Enabled:                           true,
+				ProtectionGroups:                  []elbv2gw.ShieldProtectionGroup{{ID: "web"}, {ID: "api", Aggregation: &mean}},
+				HealthCheckARN:                    awssdk.String("arn:aws:route53:::healthcheck/hc-1"),
+				ApplicationLayerAutomaticResponse: &elbv2gw.ShieldApplicationLayerAutomaticResponse{Action: elbv2gw.ShieldApplicationLayerAutomaticResponseActionCount},
+			},
+			expectedEnabled: map[addon.Addon]bool{
+				addon.Shield:                                  true,
+				addon.ShieldProtectionGroups:                  true,
+				addon.ShieldHealthCheck:                       true,
+				addon.ShieldApplicationLayerAutomaticResponse: true,
+			},
+			expectedSpec: &shieldmodel.ProtectionSpec{
+				Enabled: true,
+				ProtectionGroups: &shieldmodel.ProtectionGroups{ProtectionGroups: []shieldmodel.ProtectionGroup{
+					{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationSum},
+					{ID: "api", Aggregation: shieldmodel.ProtectionGroupAggregationMean},
+				}},
+				HealthCheck: &shieldmodel.HealthCheck{HealthCheckARN: "arn:aws:route53:::healthcheck/hc-1"},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{
+					Enabled: true,
+					Action:  shieldmodel.ApplicationLayerAutomaticResponseActionCount,
+				},
+			},
+		},
+		{
+			name:                "attributes never enabled are left unchanged",
+			shieldCfg:           &elbv2gw.ShieldConfiguration{Enabled: true},
+			previousAddonConfig: []addon.Addon{addon.Shield},
+			expectedEnabled: map[addon.Addon]bool{
+				addon.Shield: true,
+			},
+			expectedSpec: &shieldmodel.ProtectionSpec{
+				Enabled: true,
+			},
+		},
+		{
+			name:                "removed attributes are reverted",
+			shieldCfg:           &elbv2gw.ShieldConfiguration{Enabled: true},
+			previousAddonConfig: []addon.Addon{addon.Shield, addon.ShieldProtectionGroups, addon.ShieldHealthCheck, addon.ShieldApplicationLayerAutomaticResponse},
+			expectedEnabled: map[addon.Addon]bool{
+				addon.Shield: true,
+			},
+			expectedSpec: &shieldmodel.ProtectionSpec{
+				Enabled:                           true,
+				ProtectionGroups:                  &shieldmodel.ProtectionGroups{},
+				HealthCheck:                       &shieldmodel.HealthCheck{},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: false},
+			},
+		},
+		{
+			name: "attributes are disabled along with shield",
+			shieldCfg: &elbv2gw.ShieldConfiguration{
+				Enabled:          false,
+				ProtectionGroups: []elbv2gw.ShieldProtectionGroup{{ID: "web"}},
+			},
+			previousAddonConfig: []addon.Addon{addon.Shield, addon.ShieldProtectionGroups},
+			expectedEnabled:     map[addon.Addon]bool{},
+			expectedSpec: &shieldmodel.ProtectionSpec{
+				Enabled:          false,
+				ProtectionGroups: &shieldmodel.ProtectionGroups{},
+			},
+		},
+		{
+			name: "attributes without shield",
+			shieldCfg: &elbv2gw.ShieldConfiguration{
+				HealthCheckARN: awssdk.String("arn:aws:route53:::healthcheck/hc-1"),
+			},
+			expectedEnabled: map[addon.Addon]bool{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			builder := NewAddOnBuilder(logr.Discard(), supportedAddons)
+			lbCfg := elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{ShieldAdvanced: tc.shieldCfg},
+			}
+
+			metadata, prestackAddons, err := builder.BuildAddons(&elbv2.LoadBalancerSpec{}, lbCfg, tc.previousAddonConfig)
+			assert.NoError(t, err)
+			for _, m := range metadata {
+				assert.Equal(t, tc.expectedEnabled[m.Name], m.Enabled, string(m.Name))
+			}
+			for _, psa := range prestackAddons {
+				psa.AddToStack(stack, lbArn)
+			}
+
+			var shieldResult []*shieldmodel.Protection
+			assert.NoError(t, stack.ListResources(&shieldResult))
+			if tc.expectedSpec == nil {
+				assert.Empty(t, shieldResult)
+				return
+			}
+			assert.Len(t, shieldResult, 1)
+			expectedSpec := *tc.expectedSpec
+			expectedSpec.ResourceARN = lbArn
+			assert.Equal(t, expectedSpec, shieldResult[0].Spec)
+		})
+	}
+}
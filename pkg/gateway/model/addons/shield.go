@@ -1,26 +1,81 @@
 package addons
 
 import (
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
 )
 
 type shield struct {
-	enabled bool
+	enabled                           bool
+	protectionGroups                  *shieldmodel.ProtectionGroups
+	healthCheck                       *shieldmodel.HealthCheck
+	applicationLayerAutomaticResponse *shieldmodel.ApplicationLayerAutomaticResponse
 }
 
 func (w *shield) AddToStack(stack core.Stack, lbARN core.StringToken) {
 	shieldmodel.NewProtection(stack, shared_constants.ResourceIDLoadBalancer, shieldmodel.ProtectionSpec{
-		Enabled:     w.enabled,
-		ResourceARN: lbARN,
+		Enabled:                           w.enabled,
+		ResourceARN:                       lbARN,
+		ProtectionGroups:                  w.protectionGroups,
+		HealthCheck:                       w.healthCheck,
+		ApplicationLayerAutomaticResponse: w.applicationLayerAutomaticResponse,
 	})
 }
 
 var _ PreStackAddon = &shield{}
 
-func makeShieldPrestack(enabled bool) PreStackAddon {
-	return &shield{
-		enabled: enabled,
+// isShieldAttributeEnabled checks whether an attribute of the Shield protection is configured, which requires Shield to be enabled.
+func isShieldAttributeEnabled(lbCfg elbv2gw.LoadBalancerConfiguration, attribute addon.Addon) bool {
+	shieldCfg := lbCfg.Spec.ShieldAdvanced
+	if shieldCfg == nil || !shieldCfg.Enabled {
+		return false
+	}
+	switch attribute {
+	case addon.ShieldProtectionGroups:
+		return len(shieldCfg.ProtectionGroups) != 0
+	case addon.ShieldHealthCheck:
+		return awssdk.ToString(shieldCfg.HealthCheckARN) != ""
+	case addon.ShieldApplicationLayerAutomaticResponse:
+		return shieldCfg.ApplicationLayerAutomaticResponse != nil
+	}
+	return false
+}
+
+func buildShieldProtectionGroups(lbCfg elbv2gw.LoadBalancerConfiguration) *shieldmodel.ProtectionGroups {
+	protectionGroups := &shieldmodel.ProtectionGroups{}
+	if !isShieldAttributeEnabled(lbCfg, addon.ShieldProtectionGroups) {
+		return protectionGroups
+	}
+	for _, protectionGroup := range lbCfg.Spec.ShieldAdvanced.ProtectionGroups {
+		aggregation := shieldmodel.ProtectionGroupAggregationSum
+		if protectionGroup.Aggregation != nil {
+			aggregation = shieldmodel.ProtectionGroupAggregation(*protectionGroup.Aggregation)
+		}
+		protectionGroups.ProtectionGroups = append(protectionGroups.ProtectionGroups, shieldmodel.ProtectionGroup{
+			ID:          protectionGroup.ID,
+			Aggregation: aggregation,
+		})
+	}
+	return protectionGroups
+}
+
+func buildShieldHealthCheck(lbCfg elbv2gw.LoadBalancerConfiguration) *shieldmodel.HealthCheck {
+	if !isShieldAttributeEnabled(lbCfg, addon.ShieldHealthCheck) {
+		return &shieldmodel.HealthCheck{}
+	}
+	return &shieldmodel.HealthCheck{HealthCheckARN: awssdk.ToString(lbCfg.Spec.ShieldAdvanced.HealthCheckARN)}
+}
+
+func buildShieldApplicationLayerAutomaticResponse(lbCfg elbv2gw.LoadBalancerConfiguration) *shieldmodel.ApplicationLayerAutomaticResponse {
+	if !isShieldAttributeEnabled(lbCfg, addon.ShieldApplicationLayerAutomaticResponse) {
+		return &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: false}
+	}
+	return &shieldmodel.ApplicationLayerAutomaticResponse{
+		Enabled: true,
+		Action:  shieldmodel.ApplicationLayerAutomaticResponseAction(lbCfg.Spec.ShieldAdvanced.ApplicationLayerAutomaticResponse.Action),
 	}
 }
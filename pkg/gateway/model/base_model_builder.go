@@ -28,6 +28,7 @@ import (
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webacl"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -43,7 +44,7 @@ func NewModelBuilder(subnetsResolver networking.SubnetsResolver,
 	elbv2TaggingManager elbv2deploy.TaggingManager, lbcConfig config.ControllerConfig, ec2Client services.EC2, elbv2Client services.ELBV2, certDiscovery certs.CertDiscovery, k8sClient client.Client, featureGates config.FeatureGates, clusterName string, defaultTags map[string]string,
 	externalManagedTags sets.Set[string], defaultSSLPolicy string, defaultTargetType string, defaultLoadBalancerScheme string,
 	backendSGProvider networking.BackendSGProvider, sgResolver networking.SecurityGroupResolver, enableBackendSG bool,
-	disableRestrictedSGRules bool, supportedAddons []addon.Addon, logger logr.Logger) Builder {
+	disableRestrictedSGRules bool, supportedAddons []addon.Addon, routeStatusSubmitter routeutils.RouteReconcilerSubmitter, logger logr.Logger) Builder {
 
 	gwTagHelper := newTagHelper(sets.New(lbcConfig.ExternalManagedTags...), lbcConfig.DefaultTags, featureGates.Enabled(config.EnableDefaultTagsLowPriority))
 	subnetBuilder := newSubnetModelBuilder(loadBalancerType, trackingProvider, subnetsResolver, elbv2TaggingManager)
@@ -77,6 +78,7 @@ func NewModelBuilder(subnetsResolver networking.SubnetsResolver,
 		defaultTags:              defaultTags,
 		disableRestrictedSGRules: disableRestrictedSGRules,
 		addOnBuilder:             modelAddons.NewAddOnBuilder(logger, supportedAddons),
+		routeStatusSubmitter:     routeStatusSubmitter,
 
 		defaultLoadBalancerScheme: elbv2model.LoadBalancerScheme(defaultLoadBalancerScheme),
 		defaultIPType:             elbv2model.IPAddressTypeIPV4,
@@ -106,6 +108,7 @@ type baseModelBuilder struct {
 	ec2Client                  services.EC2
 	elbv2Client                services.ELBV2
 	certDiscovery              certs.CertDiscovery
+	routeStatusSubmitter       routeutils.RouteReconcilerSubmitter
 	k8sClient                  client.Client
 	metricsCollector           lbcmetrics.MetricCollector
 	lbBuilder                  loadBalancerBuilder
@@ -168,11 +171,10 @@ func (baseBuilder *baseModelBuilder) Build(ctx context.Context, gw *gwv1.Gateway
 		return nil, nil, nil, false, nil, err
 	}
 
-	addOnCfg := lbConf
-	if isDelete {
-		addOnCfg = elbv2gw.LoadBalancerConfiguration{}
+	addOnCfg, err := baseBuilder.buildAddOnConfig(ctx, lbConf, isDelete)
+	if err != nil {
+		return nil, nil, nil, false, nil, err
 	}
-
 	newAddonConfig, preStackAddons, err := baseBuilder.addOnBuilder.BuildAddons(&spec, addOnCfg, currentAddonConfig)
 	if err != nil {
 		return nil, nil, nil, false, nil, err
@@ -181,7 +183,7 @@ func (baseBuilder *baseModelBuilder) Build(ctx context.Context, gw *gwv1.Gateway
 
 	tgbNetworkingBuilder := newTargetGroupBindingNetworkBuilder(baseBuilder.disableRestrictedSGRules, baseBuilder.vpcID, spec.Scheme, lbConf.Spec.SourceRanges, securityGroups, subnets.ec2Result, baseBuilder.vpcInfoProvider)
 	tgBuilder := newTargetGroupBuilder(baseBuilder.clusterName, baseBuilder.vpcID, baseBuilder.gwTagHelper, baseBuilder.loadBalancerType, tgbNetworkingBuilder, baseBuilder.tgPropertiesConstructor, baseBuilder.defaultTargetType, targetGroupNameToArnMapper)
-	listenerBuilder := newListenerBuilder(baseBuilder.loadBalancerType, tgBuilder, baseBuilder.gwTagHelper, baseBuilder.certDiscovery, baseBuilder.clusterName, baseBuilder.defaultSSLPolicy, baseBuilder.elbv2Client, baseBuilder.k8sClient, secretsManager, baseBuilder.logger)
+	listenerBuilder := newListenerBuilder(baseBuilder.loadBalancerType, tgBuilder, baseBuilder.gwTagHelper, baseBuilder.certDiscovery, baseBuilder.clusterName, baseBuilder.defaultSSLPolicy, baseBuilder.elbv2Client, baseBuilder.k8sClient, secretsManager, baseBuilder.routeStatusSubmitter, baseBuilder.logger)
 
 	secrets, err := listenerBuilder.buildListeners(ctx, stack, lb, gw, listeners, routes, lbConf)
 	if err != nil {
@@ -197,6 +199,23 @@ func (baseBuilder *baseModelBuilder) Build(ctx context.Context, gw *gwv1.Gateway
 	return stack, lb, newAddonConfig, securityGroups.backendSecurityGroupAllocated, secrets, nil
 }
 
+// buildAddOnConfig builds the configuration of the load balancer add-ons, resolving the web ACL referenced by WAFv2 webACLRef.
+func (baseBuilder *baseModelBuilder) buildAddOnConfig(ctx context.Context, lbConf elbv2gw.LoadBalancerConfiguration, isDelete bool) (elbv2gw.LoadBalancerConfiguration, error) {
+	if isDelete {
+		return elbv2gw.LoadBalancerConfiguration{}, nil
+	}
+	if lbConf.Spec.WAFv2 == nil || lbConf.Spec.WAFv2.WebACLRef == "" {
+		return lbConf, nil
+	}
+	webACLARN, err := webacl.ResolveWebACLARN(ctx, baseBuilder.k8sClient, lbConf.Spec.WAFv2.WebACLRef)
+	if err != nil {
+		return elbv2gw.LoadBalancerConfiguration{}, err
+	}
+	addOnCfg := *lbConf.DeepCopy()
+	addOnCfg.Spec.WAFv2 = &elbv2gw.WAFv2Configuration{ACL: webACLARN}
+	return addOnCfg, nil
+}
+
 func (baseBuilder *baseModelBuilder) isDeleteProtected(lbConf elbv2gw.LoadBalancerConfiguration) bool {
 	for _, attr := range lbConf.Spec.LoadBalancerAttributes {
 		if attr.Key == shared_constants.LBAttributeDeletionProtection {
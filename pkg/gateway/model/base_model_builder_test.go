@@ -1,13 +1,19 @@
 package model
 
 import (
+	"context"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_baseModelBuilder_isDeleteProtected(t *testing.T) {
@@ -266,3 +272,67 @@ func Test_baseModelBuilder_buildLoadBalancerIPAddressType(t *testing.T) {
 		})
 	}
 }
+
+func Test_baseModelBuilder_buildAddOnConfig(t *testing.T) {
+	provisionedWebACL := &elbv2api.WAFv2WebACL{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop-web-acl"},
+		Status:     elbv2api.WAFv2WebACLStatus{WebACLARN: awssdk.String("wafv2-arn-shop")},
+	}
+	tests := []struct {
+		name     string
+		lbConf   elbv2gw.LoadBalancerConfiguration
+		isDelete bool
+		want     elbv2gw.LoadBalancerConfiguration
+		wantErr  bool
+	}{
+		{
+			name: "web ACL ARN",
+			lbConf: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{ACL: "wafv2-arn-1"}},
+			},
+			want: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{ACL: "wafv2-arn-1"}},
+			},
+		},
+		{
+			name: "web ACL reference takes precedence over web ACL ARN",
+			lbConf: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{ACL: "wafv2-arn-1", WebACLRef: "shop-web-acl"}},
+			},
+			want: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{ACL: "wafv2-arn-shop"}},
+			},
+		},
+		{
+			name: "unknown web ACL reference",
+			lbConf: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{WebACLRef: "unknown-web-acl"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "web ACL reference isn't resolved on deletion",
+			lbConf: elbv2gw.LoadBalancerConfiguration{
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{WAFv2: &elbv2gw.WAFv2Configuration{WebACLRef: "unknown-web-acl"}},
+			},
+			isDelete: true,
+			want:     elbv2gw.LoadBalancerConfiguration{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			builder := &baseModelBuilder{
+				k8sClient: testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(provisionedWebACL.DeepCopy()).Build(),
+			}
+			got, err := builder.buildAddOnConfig(context.Background(), tt.lbConf, tt.isDelete)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
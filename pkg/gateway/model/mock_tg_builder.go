@@ -13,6 +13,7 @@ type mockTargetGroupBuilder struct {
 	tgs                  []*elbv2model.TargetGroup
 	localFrontendNlbData map[string]*elbv2model.FrontendNlbTargetGroupState
 	buildErr             error
+	aggregatedBackends   [][]routeutils.Backend
 }
 
 func (m *mockTargetGroupBuilder) getLocalFrontendNlbData() map[string]*elbv2model.FrontendNlbTargetGroupState {
@@ -35,6 +36,12 @@ func (m *mockTargetGroupBuilder) buildTargetGroup(stack core.Stack,
 	return arn, m.buildErr
 }
 
+func (m *mockTargetGroupBuilder) buildAggregatedTargetGroup(stack core.Stack,
+	gw *gwv1.Gateway, listenerProtocol elbv2model.Protocol, lbIPType elbv2model.IPAddressType, routeDescriptor routeutils.RouteDescriptor, backends []routeutils.Backend) (core.StringToken, error) {
+	m.aggregatedBackends = append(m.aggregatedBackends, backends)
+	return m.buildTargetGroup(stack, gw, 0, listenerProtocol, lbIPType, routeDescriptor, routeutils.Backend{})
+}
+
 var _ targetGroupBuilder = &mockTargetGroupBuilder{}
 
 type mockTargetGroupBindingNetworkingBuilder struct {
@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -29,6 +30,11 @@ import (
 type gwListenerConfig struct {
 	protocol  elbv2model.Protocol
 	hostnames sets.Set[string]
+	// certificateARNs are the certificates set in the TLS options of the listeners, in listener order.
+	certificateARNs []string
+	// certificateHostnames are the hostnames of the listeners that bring their own certificates.
+	// An empty hostname matches any hostname.
+	certificateHostnames sets.Set[string]
 }
 
 type listenerBuilder interface {
@@ -46,9 +52,13 @@ type listenerBuilderImpl struct {
 	secretsManager             k8s.SecretsManager
 	certDiscovery              certs.CertDiscovery
 	targetGroupNameToArnMapper shared_utils.TargetGroupARNMapper
+	routeStatusSubmitter       routeutils.RouteReconcilerSubmitter
 	logger                     logr.Logger
 }
 
+// maxWeightedTargetGroupsPerAction is the maximum number of target groups of a forward action.
+const maxWeightedTargetGroupsPerAction = 5
+
 func (l listenerBuilderImpl) buildListeners(ctx context.Context, stack core.Stack, lb *elbv2model.LoadBalancer, gw *gwv1.Gateway, listeners []gwv1.Listener, routes map[int32][]routeutils.RouteDescriptor, lbCfg elbv2gw.LoadBalancerConfiguration) ([]types.NamespacedName, error) {
 	gwLsCfgs, err := mapGatewayListenerConfigsByPort(listeners, routes)
 	if err != nil {
@@ -191,43 +201,77 @@ func (l listenerBuilderImpl) buildL4TargetGroupTuples(stack core.Stack, routes [
 	hasNonZeroWeight := false
 
 	descriptorPtr := pickOneL4Route(routes)
-	if descriptorPtr != nil && (*descriptorPtr).GetAttachedRules() != nil {
-		routeDescriptor := *descriptorPtr
-		for _, rule := range routeDescriptor.GetAttachedRules() {
-			backends := rule.GetBackends()
-			for backendIndx := range backends {
-				backend := backends[backendIndx]
-
-				arn, tgErr := l.tgBuilder.buildTargetGroup(stack, gw, port, listenerProtocol, ipAddressType, routeDescriptor, backend)
-				if tgErr != nil {
-					return tgTuples, tgErr
-				}
-
-				tuple := elbv2model.TargetGroupTuple{
-					TargetGroupARN: arn,
-					Weight:         awssdk.Int32(int32(backend.Weight)),
-				}
+	if descriptorPtr == nil || (*descriptorPtr).GetAttachedRules() == nil {
+		return tgTuples, nil
+	}
+	routeDescriptor := *descriptorPtr
+	var backends []routeutils.Backend
+	for _, rule := range routeDescriptor.GetAttachedRules() {
+		backends = append(backends, rule.GetBackends()...)
+	}
+	for _, backend := range backends {
+		if backend.Weight > 0 {
+			hasNonZeroWeight = true
+		}
+	}
 
-				if listenerProtocol == elbv2model.ProtocolQUIC || listenerProtocol == elbv2model.ProtocolTCP_QUIC {
-					// QUIC protocols don't support specifying weights.
-					tuple.Weight = nil
-				}
+	strategy := selectL4BackendStrategy(listenerProtocol, backends)
+	if strategy == routeutils.BackendStrategyAggregatedTargetGroup && hasNonZeroWeight {
+		arn, tgErr := l.tgBuilder.buildAggregatedTargetGroup(stack, gw, listenerProtocol, ipAddressType, routeDescriptor, backends)
+		if tgErr != nil {
+			return tgTuples, tgErr
+		}
+		tgTuples = append(tgTuples, elbv2model.TargetGroupTuple{TargetGroupARN: arn})
+	} else {
+		for _, backend := range backends {
+			arn, tgErr := l.tgBuilder.buildTargetGroup(stack, gw, port, listenerProtocol, ipAddressType, routeDescriptor, backend)
+			if tgErr != nil {
+				return tgTuples, tgErr
+			}
 
-				if backend.Weight > 0 {
-					hasNonZeroWeight = true
-				}
+			tuple := elbv2model.TargetGroupTuple{
+				TargetGroupARN: arn,
+				Weight:         awssdk.Int32(int32(backend.Weight)),
+			}
 
-				tgTuples = append(tgTuples, tuple)
+			if !isWeightedForwardSupported(listenerProtocol) {
+				// QUIC protocols don't support specifying weights.
+				tuple.Weight = nil
 			}
+
+			tgTuples = append(tgTuples, tuple)
 		}
 	}
 	if len(tgTuples) > 0 && !hasNonZeroWeight {
 		l.logger.Info("Skipping listener creation due to all backends having 0 weight", "gateway", k8s.NamespacedName(gw))
 		return nil, nil
 	}
+	if len(tgTuples) > 0 && l.routeStatusSubmitter != nil {
+		for _, routeData := range routeutils.GenerateBackendStrategyRouteData(routeDescriptor, *gw, strategy) {
+			l.routeStatusSubmitter.Enqueue(routeData)
+		}
+	}
 	return tgTuples, nil
 }
 
+// selectL4BackendStrategy selects how the backends of an L4 route are fronted by the listener.
+// Backends are forwarded to their own target group with the backend weight where the listener supports it,
+// otherwise they share an aggregated target group and are weighted by the number of registered targets.
+func selectL4BackendStrategy(listenerProtocol elbv2model.Protocol, backends []routeutils.Backend) routeutils.BackendStrategy {
+	if len(backends) <= 1 {
+		return routeutils.BackendStrategyWeightedTargetGroups
+	}
+	if isWeightedForwardSupported(listenerProtocol) && len(backends) <= maxWeightedTargetGroupsPerAction {
+		return routeutils.BackendStrategyWeightedTargetGroups
+	}
+	return routeutils.BackendStrategyAggregatedTargetGroup
+}
+
+// isWeightedForwardSupported checks whether the listener protocol supports forwarding to weighted target groups.
+func isWeightedForwardSupported(listenerProtocol elbv2model.Protocol) bool {
+	return listenerProtocol != elbv2model.ProtocolQUIC && listenerProtocol != elbv2model.ProtocolTCP_QUIC
+}
+
 func (l listenerBuilderImpl) buildListenerRules(ctx context.Context, stack core.Stack, ls *elbv2model.Listener, ipAddressType elbv2model.IPAddressType, gw *gwv1.Gateway, port int32, routes map[int32][]routeutils.RouteDescriptor) ([]types.NamespacedName, error) {
 	// sort all rules based on precedence
 	rulesWithPrecedenceOrder := routeutils.SortAllRulesByPrecedence(routes[port], port)
@@ -365,17 +409,32 @@ func (l listenerBuilderImpl) buildCertificates(ctx context.Context, gw *gwv1.Gat
 	if lbLsCfg != nil {
 		certs = append(certs, l.buildExplicitTLSCertARNs(ctx, *lbLsCfg)...)
 	}
-	// If any explicit certs are not found then build inferred certs using cert discovery
-	if len(certs) == 0 {
-		if len(gwLsCfg.hostnames) == 0 {
+	hasExplicitCerts := len(certs) != 0
+	// Listeners (including those of ListenerSets) can bring their own certificates through TLS options
+	for _, certARN := range gwLsCfg.certificateARNs {
+		if !slices.ContainsFunc(certs, func(cert elbv2model.Certificate) bool { return isSameCertificate(ctx, cert, certARN) }) {
+			certs = append(certs, elbv2model.Certificate{CertificateARN: acmModel.NewExistingCertificate(certARN).CertificateARN()})
+		}
+	}
+	// If any explicit certs are not found then build inferred certs using cert discovery,
+	// for the hostnames not served by listeners with their own certificates.
+	if !hasExplicitCerts {
+		discoveryHostnames := hostnamesWithoutListenerCertificates(gwLsCfg)
+		if len(discoveryHostnames) == 0 {
+			if len(certs) != 0 {
+				return certs, nil
+			}
 			return []elbv2model.Certificate{}, errors.Errorf("No hostnames found for TLS cert discovery for listener on gateway %s with protocol:port %s:%v", k8s.NamespacedName(gw), gwLsCfg.protocol, port)
 		}
-		discoveredCerts, err := l.buildInferredTLSCertARNs(ctx, gwLsCfg.hostnames.UnsortedList())
+		discoveredCerts, err := l.buildInferredTLSCertARNs(ctx, discoveryHostnames)
 		if err != nil {
 			l.logger.Error(err, fmt.Sprintf("Unable to discover certs for listener on gateway %s with protocol:port %s:%v", k8s.NamespacedName(gw), gwLsCfg.protocol, port))
 			return []elbv2model.Certificate{}, err
 		}
 		for _, cert := range discoveredCerts {
+			if slices.ContainsFunc(certs, func(existing elbv2model.Certificate) bool { return isSameCertificate(ctx, existing, cert) }) {
+				continue
+			}
 			certs = append(certs, elbv2model.Certificate{
 				CertificateARN: acmModel.NewExistingCertificate(cert).CertificateARN(),
 			})
@@ -384,6 +443,42 @@ func (l listenerBuilderImpl) buildCertificates(ctx context.Context, gw *gwv1.Gat
 	return certs, nil
 }
 
+func isSameCertificate(ctx context.Context, cert elbv2model.Certificate, certARN string) bool {
+	resolvedARN, err := cert.CertificateARN.Resolve(ctx)
+	return err == nil && resolvedARN == certARN
+}
+
+// hostnamesWithoutListenerCertificates returns the hostnames of the listener config that aren't covered by
+// the hostname of a listener bringing its own certificates.
+func hostnamesWithoutListenerCertificates(gwLsCfg gwListenerConfig) []string {
+	if gwLsCfg.certificateHostnames.Has("") {
+		return nil
+	}
+	var hostnames []string
+	for _, hostname := range sets.List(gwLsCfg.hostnames) {
+		covered := false
+		for certHostname := range gwLsCfg.certificateHostnames {
+			if hostname == certHostname || wildcardHostnameCovers(certHostname, hostname) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// wildcardHostnameCovers checks whether the wildcard hostname covers the hostname, the wildcard matches exactly one label like in ACM certificates.
+func wildcardHostnameCovers(wildcardHostname string, hostname string) bool {
+	if !strings.HasPrefix(wildcardHostname, "*.") {
+		return false
+	}
+	label, domain, found := strings.Cut(hostname, ".")
+	return found && label != "" && domain == wildcardHostname[2:]
+}
+
 func (l listenerBuilderImpl) buildExplicitTLSCertARNs(ctx context.Context, listener elbv2gw.ListenerConfiguration) []elbv2model.Certificate {
 	var certs []elbv2model.Certificate
 	if listener.DefaultCertificate != nil {
@@ -408,7 +503,15 @@ func (l listenerBuilderImpl) buildInferredTLSCertARNs(ctx context.Context, hostn
 		hosts.Insert(hostname)
 	}
 
-	return l.certDiscovery.Discover(ctx, hosts.List(), nil)
+	certARNs, err := l.certDiscovery.Discover(ctx, hosts.List(), nil)
+	if err != nil {
+		var partialErr *certs.PartialDiscoveryError
+		if !errors.As(err, &partialErr) {
+			return nil, err
+		}
+		l.logger.Info("certificate discovery incomplete", "unmatchedHosts", partialErr.UnmatchedHosts)
+	}
+	return certARNs, nil
 }
 
 // L7 listeners will always have 404 as default actions since we don't have dedicated backend
@@ -575,6 +678,24 @@ func mapGatewayListenerConfigsByPort(listeners []gwv1.Listener, routes map[int32
 			gwListenerConfigs[port].hostnames.Insert(string(*listener.Hostname))
 		}
 
+		if listenerCertARNs := routeutils.ListenerCertificateARNs(listener); len(listenerCertARNs) != 0 {
+			lsCfg := gwListenerConfigs[port]
+			for _, certARN := range listenerCertARNs {
+				if !slices.Contains(lsCfg.certificateARNs, certARN) {
+					lsCfg.certificateARNs = append(lsCfg.certificateARNs, certARN)
+				}
+			}
+			if lsCfg.certificateHostnames == nil {
+				lsCfg.certificateHostnames = sets.New[string]()
+			}
+			if listener.Hostname != nil {
+				lsCfg.certificateHostnames.Insert(string(*listener.Hostname))
+			} else {
+				lsCfg.certificateHostnames.Insert("")
+			}
+			gwListenerConfigs[port] = lsCfg
+		}
+
 		listenerRoutes := routes[port]
 
 		if listenerRoutes != nil {
@@ -626,18 +747,19 @@ func generateListenerPortKey(port int32, listener gwListenerConfig) string {
 	return fmt.Sprintf("%s:%d", strings.ToLower(string(listener.protocol)), port)
 }
 
-func newListenerBuilder(loadBalancerType elbv2model.LoadBalancerType, tgBuilder targetGroupBuilder, tagHelper tagHelper, certDiscovery certs.CertDiscovery, clusterName string, defaultSSLPolicy string, elbv2Client services.ELBV2, k8sClient client.Client, secretsManager k8s.SecretsManager, logger logr.Logger) listenerBuilder {
+func newListenerBuilder(loadBalancerType elbv2model.LoadBalancerType, tgBuilder targetGroupBuilder, tagHelper tagHelper, certDiscovery certs.CertDiscovery, clusterName string, defaultSSLPolicy string, elbv2Client services.ELBV2, k8sClient client.Client, secretsManager k8s.SecretsManager, routeStatusSubmitter routeutils.RouteReconcilerSubmitter, logger logr.Logger) listenerBuilder {
 	return &listenerBuilderImpl{
-		elbv2Client:      elbv2Client,
-		k8sClient:        k8sClient,
-		loadBalancerType: loadBalancerType,
-		tgBuilder:        tgBuilder,
-		clusterName:      clusterName,
-		tagHelper:        tagHelper,
-		defaultSSLPolicy: defaultSSLPolicy,
-		secretsManager:   secretsManager,
-		certDiscovery:    certDiscovery,
-		logger:           logger,
+		elbv2Client:          elbv2Client,
+		k8sClient:            k8sClient,
+		loadBalancerType:     loadBalancerType,
+		tgBuilder:            tgBuilder,
+		clusterName:          clusterName,
+		tagHelper:            tagHelper,
+		defaultSSLPolicy:     defaultSSLPolicy,
+		secretsManager:       secretsManager,
+		certDiscovery:        certDiscovery,
+		routeStatusSubmitter: routeStatusSubmitter,
+		logger:               logger,
 	}
 }
 
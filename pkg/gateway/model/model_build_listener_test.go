@@ -11,6 +11,8 @@ import (
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
 	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/google/go-cmp/cmp"
@@ -507,6 +509,45 @@ func Test_mapGatewayListenerConfigsByPort(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "listeners with certificates in TLS options",
+			gateway: &gwv1.Gateway{
+				Spec: gwv1.GatewaySpec{
+					Listeners: []gwv1.Listener{
+						{
+							Name:     "https-foo",
+							Port:     443,
+							Protocol: gwv1.HTTPSProtocolType,
+							Hostname: &fooHostname,
+							TLS: &gwv1.ListenerTLSConfig{
+								Options: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+									"gateway.k8s.aws/certificate-arns": "arn:aws:acm:region:123456789012:certificate/foo, arn:aws:acm:region:123456789012:certificate/shared",
+								},
+							},
+						},
+						{
+							Name:     "https-bar",
+							Port:     443,
+							Protocol: gwv1.HTTPSProtocolType,
+							Hostname: &barHostname,
+							TLS: &gwv1.ListenerTLSConfig{
+								Options: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+									"gateway.k8s.aws/certificate-arns": "arn:aws:acm:region:123456789012:certificate/shared",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: map[int32]gwListenerConfig{
+				443: {
+					protocol:             elbv2model.ProtocolHTTPS,
+					hostnames:            sets.New[string]("foo.example.com", "bar.example.com"),
+					certificateARNs:      []string{"arn:aws:acm:region:123456789012:certificate/foo", "arn:aws:acm:region:123456789012:certificate/shared"},
+					certificateHostnames: sets.New[string]("foo.example.com", "bar.example.com"),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1112,6 +1153,92 @@ func TestBuildCertificates(t *testing.T) {
 			want:    []elbv2model.Certificate{},
 			wantErr: true,
 		},
+		{
+			name:    "listener certificates appended to explicit config",
+			gateway: &gwv1.Gateway{},
+			port:    443,
+			gwLsCfg: gwListenerConfig{
+				protocol:             elbv2model.ProtocolHTTPS,
+				hostnames:            sets.New[string]("team-a.example.com"),
+				certificateARNs:      []string{"arn:aws:acm:region:123456789012:certificate/team-a", "arn:aws:acm:region:123456789012:certificate/default-cert"},
+				certificateHostnames: sets.New[string]("team-a.example.com"),
+			},
+			lbLsCfg: &elbv2gw.ListenerConfiguration{
+				DefaultCertificate: awssdk.String("arn:aws:acm:region:123456789012:certificate/default-cert"),
+			},
+			want: []elbv2model.Certificate{
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/default-cert").CertificateARN(),
+				},
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/team-a").CertificateARN(),
+				},
+			},
+		},
+		{
+			name:    "listener certificates cover all hostnames - no discovery",
+			gateway: &gwv1.Gateway{},
+			port:    443,
+			gwLsCfg: gwListenerConfig{
+				protocol:             elbv2model.ProtocolHTTPS,
+				hostnames:            sets.New[string]("*.team-a.example.com", "api.team-a.example.com"),
+				certificateARNs:      []string{"arn:aws:acm:region:123456789012:certificate/team-a"},
+				certificateHostnames: sets.New[string]("*.team-a.example.com"),
+			},
+			want: []elbv2model.Certificate{
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/team-a").CertificateARN(),
+				},
+			},
+		},
+		{
+			name:    "wildcard listener certificates only cover a single label",
+			gateway: &gwv1.Gateway{},
+			port:    443,
+			gwLsCfg: gwListenerConfig{
+				protocol:             elbv2model.ProtocolHTTPS,
+				hostnames:            sets.New[string]("api.team-a.example.com", "v1.api.team-a.example.com"),
+				certificateARNs:      []string{"arn:aws:acm:region:123456789012:certificate/team-a"},
+				certificateHostnames: sets.New[string]("*.team-a.example.com"),
+			},
+			setupMocks: func(mockCertDiscovery *certs.MockCertDiscovery) {
+				mockCertDiscovery.EXPECT().
+					Discover(gomock.Any(), []string{"v1.api.team-a.example.com"}, nil).
+					Return([]string{"arn:aws:acm:region:123456789012:certificate/v1-api"}, nil)
+			},
+			want: []elbv2model.Certificate{
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/team-a").CertificateARN(),
+				},
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/v1-api").CertificateARN(),
+				},
+			},
+		},
+		{
+			name:    "listener certificates with discovery for other hostnames",
+			gateway: &gwv1.Gateway{},
+			port:    443,
+			gwLsCfg: gwListenerConfig{
+				protocol:             elbv2model.ProtocolHTTPS,
+				hostnames:            sets.New[string]("team-a.example.com", "team-b.example.com"),
+				certificateARNs:      []string{"arn:aws:acm:region:123456789012:certificate/team-a"},
+				certificateHostnames: sets.New[string]("team-a.example.com"),
+			},
+			setupMocks: func(mockCertDiscovery *certs.MockCertDiscovery) {
+				mockCertDiscovery.EXPECT().
+					Discover(gomock.Any(), []string{"team-b.example.com"}, nil).
+					Return([]string{"arn:aws:acm:region:123456789012:certificate/team-b"}, nil)
+			},
+			want: []elbv2model.Certificate{
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/team-a").CertificateARN(),
+				},
+				{
+					CertificateARN: acmModel.NewExistingCertificate("arn:aws:acm:region:123456789012:certificate/team-b").CertificateARN(),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1147,6 +1274,55 @@ func TestBuildCertificates(t *testing.T) {
 	}
 }
 
+func Test_buildInferredTLSCertARNs_partialDiscovery(t *testing.T) {
+	tests := []struct {
+		name                  string
+		allowPartialDiscovery bool
+		want                  []string
+		wantErr               string
+	}{
+		{
+			name:                  "unmatched hostname fails the build when partial discovery is not allowed",
+			allowPartialDiscovery: false,
+			wantErr:               "no certificate found for host: team-b.example.org",
+		},
+		{
+			name:                  "unmatched hostname is skipped when partial discovery is allowed",
+			allowPartialDiscovery: true,
+			want:                  []string{"arn:aws:acm:region:123456789012:certificate/team-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certARN := "arn:aws:acm:region:123456789012:certificate/team-a"
+			mockACM := services.NewMockACM(ctrl)
+			mockACM.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return([]acmtypes.CertificateSummary{{CertificateArn: awssdk.String(certARN)}}, nil)
+			mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), gomock.Any()).Return(&acm.DescribeCertificateOutput{
+				Certificate: &acmtypes.CertificateDetail{
+					CertificateArn:          awssdk.String(certARN),
+					SubjectAlternativeNames: []string{"team-a.example.com"},
+					Type:                    acmtypes.CertificateTypeAmazonIssued,
+				},
+			}, nil)
+			builder := &listenerBuilderImpl{
+				certDiscovery: certs.NewACMCertDiscovery(mockACM, nil, false, certs.SelectionPolicy{AllowPartialDiscovery: tt.allowPartialDiscovery}, logr.Discard()),
+				logger:        logr.Discard(),
+			}
+
+			got, err := builder.buildInferredTLSCertARNs(context.Background(), []string{"team-a.example.com", "team-b.example.org"})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_buildMutualAuthenticationAttributes(t *testing.T) {
 	trueValue := true
 	falseValue := false
@@ -2446,6 +2622,141 @@ func Test_buildL4TargetGroupTuples(t *testing.T) {
 	}
 }
 
+func Test_buildL4TargetGroupTuples_backendStrategy(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "gw-ns",
+			Name:      "gw",
+		},
+	}
+	gwNamespace := gwv1.Namespace("gw-ns")
+	buildTGs := func(count int) []*elbv2model.TargetGroup {
+		tgs := make([]*elbv2model.TargetGroup, 0, count)
+		for i := 1; i <= count; i++ {
+			tgs = append(tgs, &elbv2model.TargetGroup{
+				ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", fmt.Sprintf("id-%d", i)),
+				Status: &elbv2model.TargetGroupStatus{
+					TargetGroupARN: fmt.Sprintf("arn%d", i),
+				},
+			})
+		}
+		return tgs
+	}
+	buildRoute := func(weights ...int) *routeutils.MockRoute {
+		backends := make([]routeutils.Backend, 0, len(weights))
+		for _, weight := range weights {
+			backends = append(backends, routeutils.Backend{Weight: weight})
+		}
+		return &routeutils.MockRoute{
+			Kind:      routeutils.TCPRouteKind,
+			Namespace: "route-ns",
+			Name:      "route",
+			ParentRefs: []gwv1.ParentReference{
+				{Name: "gw", Namespace: &gwNamespace},
+				{Name: "other-gw", Namespace: &gwNamespace},
+			},
+			Rules: []routeutils.RouteRule{
+				&routeutils.MockRule{
+					BackendRefs: backends,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                    string
+		listenerProtocol        elbv2model.Protocol
+		route                   *routeutils.MockRoute
+		targetGroups            []*elbv2model.TargetGroup
+		expectedARNs            []string
+		expectedWeights         []*int32
+		expectedAggregatedCount int
+		expectedStrategy        routeutils.BackendStrategy
+	}{
+		{
+			name:             "weighted target groups for tcp listener",
+			listenerProtocol: elbv2model.ProtocolTCP,
+			route:            buildRoute(80, 20),
+			targetGroups:     buildTGs(2),
+			expectedARNs:     []string{"arn1", "arn2"},
+			expectedWeights:  []*int32{awssdk.Int32(80), awssdk.Int32(20)},
+			expectedStrategy: routeutils.BackendStrategyWeightedTargetGroups,
+		},
+		{
+			name:             "single backend on quic listener keeps its own target group",
+			listenerProtocol: elbv2model.ProtocolQUIC,
+			route:            buildRoute(1),
+			targetGroups:     buildTGs(1),
+			expectedARNs:     []string{"arn1"},
+			expectedWeights:  []*int32{nil},
+			expectedStrategy: routeutils.BackendStrategyWeightedTargetGroups,
+		},
+		{
+			name:                    "aggregated target group for quic listener",
+			listenerProtocol:        elbv2model.ProtocolTCP_QUIC,
+			route:                   buildRoute(80, 20),
+			targetGroups:            buildTGs(1),
+			expectedARNs:            []string{"arn1"},
+			expectedWeights:         []*int32{nil},
+			expectedAggregatedCount: 2,
+			expectedStrategy:        routeutils.BackendStrategyAggregatedTargetGroup,
+		},
+		{
+			name:                    "aggregated target group when backends exceed the weighted target group limit",
+			listenerProtocol:        elbv2model.ProtocolTCP,
+			route:                   buildRoute(1, 1, 1, 1, 1, 1),
+			targetGroups:            buildTGs(1),
+			expectedARNs:            []string{"arn1"},
+			expectedWeights:         []*int32{nil},
+			expectedAggregatedCount: 6,
+			expectedStrategy:        routeutils.BackendStrategyAggregatedTargetGroup,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTgBuilder := &mockTargetGroupBuilder{
+				tgs: tc.targetGroups,
+			}
+			routeStatusSubmitter := routeutils.NewMockRouteReconciler()
+
+			builder := &listenerBuilderImpl{
+				tgBuilder:            mockTgBuilder,
+				routeStatusSubmitter: routeStatusSubmitter,
+				logger:               logr.Discard(),
+			}
+
+			result, err := builder.buildL4TargetGroupTuples(stack, []routeutils.RouteDescriptor{tc.route}, gw, 80, tc.listenerProtocol, elbv2model.IPAddressTypeIPV4)
+			assert.NoError(t, err)
+
+			var arns []string
+			var weights []*int32
+			for _, tuple := range result {
+				arn, _ := tuple.TargetGroupARN.Resolve(context.Background())
+				arns = append(arns, arn)
+				weights = append(weights, tuple.Weight)
+			}
+			assert.Equal(t, tc.expectedARNs, arns)
+			assert.Equal(t, tc.expectedWeights, weights)
+
+			if tc.expectedAggregatedCount == 0 {
+				assert.Empty(t, mockTgBuilder.aggregatedBackends)
+			} else {
+				assert.Len(t, mockTgBuilder.aggregatedBackends, 1)
+				assert.Len(t, mockTgBuilder.aggregatedBackends[0], tc.expectedAggregatedCount)
+			}
+
+			// only the parentRef to this gateway gets the strategy reported.
+			assert.Len(t, routeStatusSubmitter.Enqueued, 1)
+			routeData := routeStatusSubmitter.Enqueued[0].RouteData
+			assert.Equal(t, gwv1.ObjectName("gw"), routeData.ParentRef.Name)
+			assert.True(t, routeData.RouteStatusInfo.Accepted)
+			assert.Equal(t, tc.expectedStrategy, routeData.RouteStatusInfo.BackendStrategy)
+		})
+	}
+}
+
 func TestQuicProtocolUpgrade(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"strconv"
+	"strings"
 )
 
 type buildTargetGroupOutput struct {
@@ -33,6 +34,8 @@ type buildTargetGroupOutput struct {
 type targetGroupBuilder interface {
 	buildTargetGroup(stack core.Stack,
 		gw *gwv1.Gateway, listenerPort int32, listenerProtocol elbv2model.Protocol, lbIPType elbv2model.IPAddressType, routeDescriptor routeutils.RouteDescriptor, backend routeutils.Backend) (core.StringToken, error)
+	buildAggregatedTargetGroup(stack core.Stack,
+		gw *gwv1.Gateway, listenerProtocol elbv2model.Protocol, lbIPType elbv2model.IPAddressType, routeDescriptor routeutils.RouteDescriptor, backends []routeutils.Backend) (core.StringToken, error)
 	getLocalFrontendNlbData() map[string]*elbv2model.FrontendNlbTargetGroupState
 }
 
@@ -166,6 +169,70 @@ func (builder *targetGroupBuilderImpl) buildTargetGroupFromService(stack core.St
 	return tg, nil
 }
 
+// buildAggregatedTargetGroup builds a single TargetGroup shared by the Service backends of a route.
+// The TargetGroup settings come from the first backend, and each backend gets its own TargetGroupBinding that registers
+// a share of the Service endpoints proportional to the backend weight.
+func (builder *targetGroupBuilderImpl) buildAggregatedTargetGroup(stack core.Stack,
+	gw *gwv1.Gateway, listenerProtocol elbv2model.Protocol, lbIPType elbv2model.IPAddressType, routeDescriptor routeutils.RouteDescriptor, backends []routeutils.Backend) (core.StringToken, error) {
+	weightByBackendKey := make(map[string]int32)
+	var backendConfigs []routeutils.ServiceBackendConfig
+	for _, backend := range backends {
+		if backend.ServiceBackend == nil {
+			return nil, errors.Errorf("backends of %s %v must be Services to share a target group", routeDescriptor.GetRouteKind(), routeDescriptor.GetRouteNamespacedName())
+		}
+		backendKey := buildAggregatedBackendKey(*backend.ServiceBackend)
+		if _, exists := weightByBackendKey[backendKey]; !exists {
+			backendConfigs = append(backendConfigs, *backend.ServiceBackend)
+		}
+		weightByBackendKey[backendKey] += int32(backend.Weight)
+	}
+	if len(backendConfigs) == 0 {
+		return nil, errors.Errorf("%s %v has no backends", routeDescriptor.GetRouteKind(), routeDescriptor.GetRouteNamespacedName())
+	}
+
+	primaryConfig := backendConfigs[0]
+	tgSpec, err := builder.buildTargetGroupSpec(gw, routeDescriptor, listenerProtocol, lbIPType, &primaryConfig, primaryConfig.GetTargetGroupProps())
+	if err != nil {
+		return nil, err
+	}
+	// the TargetGroup is identified by the route rather than its backends, so that adding or removing backends keeps it in place.
+	aggregatedKey := types.NamespacedName{Namespace: routeDescriptor.GetRouteNamespacedName().Namespace, Name: "aggregated"}
+	tgSpec.Name = builder.buildTargetGroupName(primaryConfig.GetTargetGroupProps(), k8s.NamespacedName(gw), routeDescriptor.GetRouteNamespacedName(), routeDescriptor.GetRouteKind(), aggregatedKey, awssdk.ToInt32(tgSpec.Port), tgSpec.TargetType, tgSpec.Protocol, tgSpec.ProtocolVersion, nil)
+	tgResID := builder.buildTargetGroupResourceID(k8s.NamespacedName(gw), aggregatedKey, routeDescriptor.GetRouteNamespacedName(), routeDescriptor.GetRouteKind(), intstr.FromInt32(awssdk.ToInt32(tgSpec.Port)), nil)
+	if tg, exists := builder.tgByResID[tgResID]; exists {
+		return tg.TargetGroupARN(), nil
+	}
+
+	tg := elbv2model.NewTargetGroup(stack, tgResID, tgSpec)
+	for _, backendConfig := range backendConfigs {
+		targetGroupProps := backendConfig.GetTargetGroupProps()
+		nodeSelector := builder.buildTargetGroupBindingNodeSelector(targetGroupProps, tgSpec.TargetType)
+		bindingSpec, err := builder.buildTargetGroupBindingSpec(gw, targetGroupProps, tgSpec, nodeSelector, backendConfig)
+		if err != nil {
+			return nil, err
+		}
+		backendKey := buildAggregatedBackendKey(backendConfig)
+		bindingSpec.Template.Name = buildAggregatedTargetGroupBindingName(tgSpec.Name, backendKey)
+		bindingSpec.Template.Spec.TargetGroupARN = tg.TargetGroupARN()
+		bindingSpec.Template.Spec.MultiClusterTargetGroup = true
+		bindingSpec.Template.Spec.WeightedRegistration = &elbv2api.WeightedRegistration{Weight: weightByBackendKey[backendKey]}
+		elbv2modelk8s.NewTargetGroupBindingResource(stack, fmt.Sprintf("%s/%s", tg.ID(), backendKey), bindingSpec)
+	}
+	builder.tgByResID[tgResID] = tg
+	return tg.TargetGroupARN(), nil
+}
+
+// buildAggregatedBackendKey identifies a Service backend within an aggregated TargetGroup.
+func buildAggregatedBackendKey(backendConfig routeutils.ServiceBackendConfig) string {
+	return fmt.Sprintf("%s:%d", backendConfig.GetBackendNamespacedName(), backendConfig.GetServicePort().Port)
+}
+
+// buildAggregatedTargetGroupBindingName builds a unique TargetGroupBinding name per backend of an aggregated TargetGroup.
+func buildAggregatedTargetGroupBindingName(tgName string, backendKey string) string {
+	backendHash := sha256.Sum256([]byte(backendKey))
+	return fmt.Sprintf("%s-%.8s", strings.ToLower(tgName), hex.EncodeToString(backendHash[:]))
+}
+
 func (builder *targetGroupBuilderImpl) buildTargetGroupFromGateway(stack core.Stack,
 	gw *gwv1.Gateway, listenerPort int32, listenerProtocol elbv2model.Protocol, lbIPType elbv2model.IPAddressType, routeDescriptor routeutils.RouteDescriptor, backendConfig routeutils.GatewayBackendConfig) (*elbv2model.TargetGroup, error) {
 	targetGroupProps := backendConfig.GetTargetGroupProps()
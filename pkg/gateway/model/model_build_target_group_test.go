@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -1823,6 +1824,117 @@ func Test_buildTargetGroupTags(t *testing.T) {
 	}
 }
 
+func Test_buildAggregatedTargetGroup(t *testing.T) {
+	buildServiceBackend := func(name string, weight int) routeutils.Backend {
+		return routeutils.Backend{
+			ServiceBackend: routeutils.NewServiceBackendConfig(
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-svc-ns",
+						Name:      name,
+					},
+				},
+				nil,
+				&corev1.ServicePort{
+					Protocol:   corev1.ProtocolUDP,
+					Port:       443,
+					TargetPort: intstr.FromInt32(8443),
+				},
+			),
+			Weight: weight,
+		}
+	}
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-gw-ns",
+			Name:      "my-gw",
+		},
+	}
+	route := &routeutils.MockRoute{
+		Kind:      routeutils.UDPRouteKind,
+		Name:      "my-route",
+		Namespace: "my-route-ns",
+	}
+
+	testCases := []struct {
+		name            string
+		backends        []routeutils.Backend
+		expectedWeights map[string]int32
+		expectErr       bool
+	}{
+		{
+			name:     "one target group binding per service backend",
+			backends: []routeutils.Backend{buildServiceBackend("blue", 90), buildServiceBackend("green", 10)},
+			expectedWeights: map[string]int32{
+				"my-svc-ns/blue":  90,
+				"my-svc-ns/green": 10,
+			},
+		},
+		{
+			name:     "weights of duplicate backends are summed",
+			backends: []routeutils.Backend{buildServiceBackend("blue", 30), buildServiceBackend("green", 10), buildServiceBackend("blue", 30)},
+			expectedWeights: map[string]int32{
+				"my-svc-ns/blue":  60,
+				"my-svc-ns/green": 10,
+			},
+		},
+		{
+			name:      "non service backend",
+			backends:  []routeutils.Backend{buildServiceBackend("blue", 50), {LiteralTargetGroup: &routeutils.LiteralTargetGroupConfig{Name: "tg"}, Weight: 50}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "my-gw-ns", Name: "my-gw"})
+			tagger := &mockTagHelper{
+				tags: make(map[string]string),
+			}
+			builder := newTargetGroupBuilder("my-cluster", "vpc-xxx", tagger, elbv2model.LoadBalancerTypeNetwork, &mockTargetGroupBindingNetworkingBuilder{}, gateway.NewTargetGroupConfigConstructor(), string(elbv2model.TargetTypeIP), nil)
+
+			arn, err := builder.buildAggregatedTargetGroup(stack, gw, elbv2model.ProtocolQUIC, elbv2model.IPAddressTypeIPV4, route, tc.backends)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var resTGs []*elbv2model.TargetGroup
+			assert.NoError(t, stack.ListResources(&resTGs))
+			assert.Len(t, resTGs, 1)
+			resTGs[0].SetStatus(elbv2model.TargetGroupStatus{TargetGroupARN: "my-tg-arn"})
+			assertResolvesToTG := func(token core.StringToken) {
+				resolved, err := token.Resolve(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, "my-tg-arn", resolved)
+			}
+			assertResolvesToTG(arn)
+
+			var resTGBs []*elbv2modelk8s.TargetGroupBindingResource
+			assert.NoError(t, stack.ListResources(&resTGBs))
+			weights := make(map[string]int32)
+			names := make(map[string]struct{})
+			for _, resTGB := range resTGBs {
+				spec := resTGB.Spec.Template.Spec
+				assert.True(t, spec.MultiClusterTargetGroup)
+				assertResolvesToTG(spec.TargetGroupARN)
+				weights[fmt.Sprintf("%s/%s", resTGB.Spec.Template.Namespace, spec.ServiceRef.Name)] = spec.WeightedRegistration.Weight
+				names[resTGB.Spec.Template.Name] = struct{}{}
+			}
+			assert.Equal(t, tc.expectedWeights, weights)
+			assert.Len(t, names, len(tc.expectedWeights))
+
+			// building again for the same route reuses the TargetGroup.
+			cachedARN, err := builder.buildAggregatedTargetGroup(stack, gw, elbv2model.ProtocolQUIC, elbv2model.IPAddressTypeIPV4, route, tc.backends)
+			assert.NoError(t, err)
+			assertResolvesToTG(cachedARN)
+			assert.NoError(t, stack.ListResources(&resTGs))
+			assert.Len(t, resTGs, 1)
+		})
+	}
+}
+
 func Test_buildTargetGroupFromGateway(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -18,6 +18,7 @@ import (
 
 const (
 	serviceKind             = "Service"
+	secretKind              = "Secret"
 	gatewayKind             = "Gateway"
 	listenerSetKind         = "ListenerSet"
 	referenceGrantNotExists = "No explicit ReferenceGrant exists to allow the reference."
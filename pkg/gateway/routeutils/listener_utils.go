@@ -1,14 +1,21 @@
 package routeutils
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -85,14 +92,32 @@ type ListenerValidationResults struct {
 	HasErrors  bool
 }
 
+// listenerOwner identifies the resource declaring a listener.
+type listenerOwner struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (o listenerOwner) describeListener(listenerName gwv1.SectionName) string {
+	return fmt.Sprintf("%s %s/%s listener %s", o.kind, o.namespace, o.name, listenerName)
+}
+
+// listenerPortClaim records the listener that first claimed a port.
+type listenerPortClaim struct {
+	protocol  gwv1.ProtocolType
+	claimedBy string
+}
+
 // validateListeners validates all listeners configurations in a Gateway against controller-specific requirements.
 // it is different from listener <-> route validation
 // It checks for supported route kinds, valid port ranges (1-65535), controller-compatible protocols
 // (ALB: HTTP/HTTPS/GRPC, NLB: TCP/UDP/TLS), protocol conflicts on same ports (except TCP+UDP),
 // hostname conflicts - same port trying to use same hostname
-func validateListeners(configuredListeners allListeners, gatewayGeneration int64, controllerName string) ValidatedGatewayListeners {
-	portHostnameMap := make(map[string]bool)
-	portProtocolMap := make(map[gwv1.PortNumber]gwv1.ProtocolType)
+// ListenerSet listeners are additionally restricted to the ports allowed by the Gateway, and to the certificates they're granted.
+func validateListeners(ctx context.Context, k8sClient client.Client, configuredListeners allListeners, gw gwv1.Gateway, controllerName string) (ValidatedGatewayListeners, error) {
+	portHostnameMap := make(map[string]string)
+	portProtocolMap := make(map[gwv1.PortNumber]listenerPortClaim)
 
 	// Track portHostnameMap and portProtocolMap throughout the validation cycle. This allows us to give priority
 	// to listeners. For example, we need to allow listeners defined in the Gateway directly to be given configuration
@@ -100,9 +125,14 @@ func validateListeners(configuredListeners allListeners, gatewayGeneration int64
 	// listeners to pass validation, any listener set listeners that will conflict will fail validation but not
 	// block the listener attachment process.
 
-	gatewayValidationResults := validateListenerList(configuredListeners.GatewayListeners, portHostnameMap, portProtocolMap, controllerName, gatewayGeneration)
+	gwOwner := listenerOwner{kind: gatewayKind, namespace: gw.Namespace, name: gw.Name}
+	gatewayValidationResults, err := validateListenerList(ctx, k8sClient, gw, configuredListeners.GatewayListeners, gwOwner, nil, portHostnameMap, portProtocolMap, controllerName, gw.Generation)
+	if err != nil {
+		return ValidatedGatewayListeners{}, err
+	}
 
 	listenerSetPriorityOrder := arrangeListenerSetsForValidation(configuredListeners.ListenerSetListeners)
+	allowedPorts := newListenerSetPortAllowlist(gw)
 
 	// The sorting is important, as we are building the combined listener representation in
 	// portProtocolMap and portHostnameMap.
@@ -113,16 +143,22 @@ func validateListeners(configuredListeners allListeners, gatewayGeneration int64
 
 	for _, ls := range listenerSetPriorityOrder {
 		listenerSetListeners := configuredListeners.ListenerSetListeners.listenersPerListenerSet[k8s.NamespacedName(ls)]
-		listenerSetValidationResults[k8s.NamespacedName(ls)] = validateListenerList(extractListenerFromListenerSource(listenerSetListeners), portHostnameMap, portProtocolMap, controllerName, ls.Generation)
+		lsOwner := listenerOwner{kind: listenerSetKind, namespace: ls.Namespace, name: ls.Name}
+		lsValidationResults, err := validateListenerList(ctx, k8sClient, gw, extractListenerFromListenerSource(listenerSetListeners), lsOwner, allowedPorts, portHostnameMap, portProtocolMap, controllerName, ls.Generation)
+		if err != nil {
+			return ValidatedGatewayListeners{}, err
+		}
+		listenerSetValidationResults[k8s.NamespacedName(ls)] = lsValidationResults
 	}
 
 	return ValidatedGatewayListeners{
 		GatewayListenerValidation:     gatewayValidationResults,
 		ListenerSetListenerValidation: listenerSetValidationResults,
-	}
+	}, nil
 }
 
-func validateListenerList(listenerList []gwv1.Listener, portHostnameMap map[string]bool, portProtocolMap map[gwv1.PortNumber]gwv1.ProtocolType, controllerName string, generation int64) ListenerValidationResults {
+// validateListenerList validates the listeners declared by owner. allowedPorts restricts the listener ports when not nil.
+func validateListenerList(ctx context.Context, k8sClient client.Client, gw gwv1.Gateway, listenerList []gwv1.Listener, owner listenerOwner, allowedPorts *listenerSetPortAllowlist, portHostnameMap map[string]string, portProtocolMap map[gwv1.PortNumber]listenerPortClaim, controllerName string, generation int64) (ListenerValidationResults, error) {
 	results := ListenerValidationResults{
 		Results:    make(map[gwv1.SectionName]ListenerValidationResult),
 		Generation: generation,
@@ -149,6 +185,11 @@ func validateListenerList(listenerList []gwv1.Listener, portHostnameMap map[stri
 			result.Reason = gwv1.ListenerReasonPortUnavailable
 			result.Message = fmt.Sprintf("Port %d is not available (listener name %s)", listener.Port, listener.Name)
 			results.HasErrors = true
+		} else if msg, allowed := allowedPorts.check(listener.Port); !allowed {
+			result.IsValid = false
+			result.Reason = gwv1.ListenerReasonPortUnavailable
+			result.Message = fmt.Sprintf("Port %d is not available (listener name %s): %s", listener.Port, listener.Name, msg)
+			results.HasErrors = true
 		} else if controllerName == gateway_constants.ALBGatewayController &&
 			(listener.Protocol == gwv1.TCPProtocolType || listener.Protocol == gwv1.UDPProtocolType || listener.Protocol == gwv1.TLSProtocolType) {
 			result.IsValid = false
@@ -161,20 +202,28 @@ func validateListenerList(listenerList []gwv1.Listener, portHostnameMap map[stri
 			result.Reason = gwv1.ListenerReasonUnsupportedProtocol
 			result.Message = fmt.Sprintf("Unsupported protocol %s for listener %s", listener.Protocol, listener.Name)
 			results.HasErrors = true
+		} else if reason, msg, err := validateListenerCertificates(ctx, k8sClient, gw, listener, owner); err != nil {
+			return ListenerValidationResults{}, err
+		} else if reason != "" {
+			result.IsValid = false
+			result.Reason = reason
+			result.Message = msg
+			results.HasErrors = true
 		} else {
 			// Check protocol conflicts - same port with different protocols (except TCP+UDP)
-			if existingProtocol, exists := portProtocolMap[listener.Port]; exists {
+			if existingClaim, exists := portProtocolMap[listener.Port]; exists {
+				existingProtocol := existingClaim.protocol
 				if existingProtocol != listener.Protocol {
 					if !((existingProtocol == gwv1.TCPProtocolType && listener.Protocol == gwv1.UDPProtocolType) ||
 						(existingProtocol == gwv1.UDPProtocolType && listener.Protocol == gwv1.TCPProtocolType)) {
 						result.IsValid = false
 						result.Reason = gwv1.ListenerReasonProtocolConflict
-						result.Message = fmt.Sprintf("Protocol conflict for port %d", listener.Port)
+						result.Message = fmt.Sprintf("Protocol conflict for port %d, the port is already used with protocol %s by %s", listener.Port, existingProtocol, existingClaim.claimedBy)
 						results.HasErrors = true
 					}
 				}
 			} else {
-				portProtocolMap[listener.Port] = listener.Protocol
+				portProtocolMap[listener.Port] = listenerPortClaim{protocol: listener.Protocol, claimedBy: owner.describeListener(listener.Name)}
 			}
 
 			// Check hostname conflicts - only when hostname is specified
@@ -182,19 +231,153 @@ func validateListenerList(listenerList []gwv1.Listener, portHostnameMap map[stri
 				hostname := *listener.Hostname
 				key := fmt.Sprintf("%d-%s", listener.Port, hostname)
 
-				if portHostnameMap[key] {
+				if claimedBy, claimed := portHostnameMap[key]; claimed {
 					result.IsValid = false
 					result.Reason = gwv1.ListenerReasonHostnameConflict
-					result.Message = fmt.Sprintf("Hostname conflict for port %d with hostname %s", listener.Port, hostname)
+					result.Message = fmt.Sprintf("Hostname conflict for port %d with hostname %s, the hostname is already used by %s", listener.Port, hostname, claimedBy)
 					results.HasErrors = true
 				} else {
-					portHostnameMap[key] = true
+					portHostnameMap[key] = owner.describeListener(listener.Name)
 				}
 			}
 		}
 		results.Results[listener.Name] = result
 	}
-	return results
+	return results, nil
+}
+
+// validateListenerCertificates checks the certificates of a listener, returning the failure reason and message when they are invalid.
+// ListenerSet listeners can only reference certificates from other namespaces when a ReferenceGrant allows it. Likewise, the ACM
+// certificates of their TLS options are only permitted in the namespace of the Gateway, or when a ReferenceGrant allows the Gateway reference.
+func validateListenerCertificates(ctx context.Context, k8sClient client.Client, gw gwv1.Gateway, listener gwv1.Listener, owner listenerOwner) (gwv1.ListenerConditionReason, string, error) {
+	if listener.TLS == nil {
+		return "", "", nil
+	}
+	certARNs := ListenerCertificateARNs(listener)
+	for _, certARN := range certARNs {
+		parsedARN, err := arn.Parse(certARN)
+		if err != nil || parsedARN.Service != "acm" {
+			return gwv1.ListenerReasonInvalidCertificateRef, fmt.Sprintf("Invalid ACM certificate ARN %s in TLS option %s of listener %s", certARN, gateway_constants.TLSOptionCertificateARNs, listener.Name), nil
+		}
+	}
+	if owner.kind != listenerSetKind {
+		return "", "", nil
+	}
+	for _, ref := range listener.TLS.CertificateRefs {
+		if ref.Namespace == nil || string(*ref.Namespace) == owner.namespace {
+			continue
+		}
+		refGroup := coreAPIGroup
+		if ref.Group != nil {
+			refGroup = string(*ref.Group)
+		}
+		refKind := secretKind
+		if ref.Kind != nil {
+			refKind = string(*ref.Kind)
+		}
+		allowed, err := shared_utils.ValidateCrossNamespaceReference(ctx, k8sClient, owner.namespace, gatewayAPIGroup, listenerSetKind, refGroup, refKind, string(*ref.Namespace), string(ref.Name))
+		if err != nil {
+			return "", "", errors.Wrapf(err, "Unable to perform reference grant check")
+		}
+		if !allowed {
+			return gwv1.ListenerReasonRefNotPermitted, fmt.Sprintf("Certificate %s/%s is not permitted for listener %s: %s", *ref.Namespace, ref.Name, listener.Name, referenceGrantNotExists), nil
+		}
+	}
+	if len(certARNs) != 0 && owner.namespace != gw.Namespace {
+		allowed, err := shared_utils.ValidateCrossNamespaceReference(ctx, k8sClient, owner.namespace, gatewayAPIGroup, listenerSetKind, gatewayAPIGroup, gatewayKind, gw.Namespace, gw.Name)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "Unable to perform reference grant check")
+		}
+		if !allowed {
+			return gwv1.ListenerReasonRefNotPermitted, fmt.Sprintf("ACM certificates in TLS option %s are not permitted for listener %s, the ListenerSet isn't in the namespace of Gateway %s/%s: %s",
+				gateway_constants.TLSOptionCertificateARNs, listener.Name, gw.Namespace, gw.Name, referenceGrantNotExists), nil
+		}
+	}
+	return "", "", nil
+}
+
+// ListenerCertificateARNs returns the ACM certificate ARNs set in the TLS options of the listener.
+func ListenerCertificateARNs(listener gwv1.Listener) []string {
+	if listener.TLS == nil {
+		return nil
+	}
+	rawARNs, ok := listener.TLS.Options[gateway_constants.TLSOptionCertificateARNs]
+	if !ok {
+		return nil
+	}
+	var certARNs []string
+	for _, certARN := range strings.Split(string(rawARNs), ",") {
+		certARN = strings.TrimSpace(certARN)
+		if certARN != "" {
+			certARNs = append(certARNs, certARN)
+		}
+	}
+	return certARNs
+}
+
+// listenerSetPortAllowlist holds the ports ListenerSet listeners can use, as configured on the parent Gateway.
+type listenerSetPortAllowlist struct {
+	portRanges [][2]gwv1.PortNumber
+	err        error
+}
+
+// newListenerSetPortAllowlist parses the allowed ports of ListenerSet listeners from the Gateway annotation.
+// A nil allowlist allows every port.
+func newListenerSetPortAllowlist(gw gwv1.Gateway) *listenerSetPortAllowlist {
+	rawValue, ok := gw.Annotations[gateway_constants.AnnotationListenerSetAllowedPorts]
+	if !ok {
+		return nil
+	}
+	allowlist := &listenerSetPortAllowlist{}
+	for _, entry := range strings.Split(rawValue, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		bounds := strings.SplitN(entry, "-", 2)
+		from, err := parseListenerSetAllowedPort(bounds[0])
+		if err != nil {
+			allowlist.err = err
+			return allowlist
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = parseListenerSetAllowedPort(bounds[1]); err != nil {
+				allowlist.err = err
+				return allowlist
+			}
+		}
+		if from > to {
+			allowlist.err = fmt.Errorf("invalid port range %s", entry)
+			return allowlist
+		}
+		allowlist.portRanges = append(allowlist.portRanges, [2]gwv1.PortNumber{from, to})
+	}
+	return allowlist
+}
+
+func parseListenerSetAllowedPort(rawPort string) (gwv1.PortNumber, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(rawPort), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %s", rawPort)
+	}
+	return gwv1.PortNumber(port), nil
+}
+
+// check returns whether the port is allowed, and the reason when it's not.
+func (a *listenerSetPortAllowlist) check(port gwv1.PortNumber) (string, bool) {
+	if a == nil {
+		return "", true
+	}
+	if a.err != nil {
+		return fmt.Sprintf("Gateway annotation %s is invalid: %v", gateway_constants.AnnotationListenerSetAllowedPorts, a.err), false
+	}
+	for _, portRange := range a.portRanges {
+		if port >= portRange[0] && port <= portRange[1] {
+			return "", true
+		}
+	}
+	return fmt.Sprintf("the parent Gateway only allows ListenerSet listeners on ports %s", formatPortRanges(a.portRanges)), false
 }
 
 func getSupportedKinds(controllerName string, listener gwv1.Listener) ([]gwv1.RouteGroupKind, bool) {
@@ -272,6 +455,18 @@ func arrangeListenerSetsForValidation(lsResult listenerSetLoadResult) []*gwv1.Li
 	return orderedListenerSets
 }
 
+func formatPortRanges(portRanges [][2]gwv1.PortNumber) string {
+	formatted := make([]string, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		if portRange[0] == portRange[1] {
+			formatted = append(formatted, strconv.Itoa(int(portRange[0])))
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%d-%d", portRange[0], portRange[1]))
+		}
+	}
+	return strings.Join(formatted, ",")
+}
+
 func extractListenerFromListenerSource(listenerSources []listenerSetListenerSource) []gwv1.Listener {
 	result := make([]gwv1.Listener, 0)
 	for _, src := range listenerSources {
@@ -9,7 +9,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwbeta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 type mockListenerSetLoader struct {
@@ -269,7 +271,8 @@ func TestValidateListeners(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := validateListeners(allListeners{GatewayListeners: tt.listeners}, 0, tt.controllerName)
+			result, err := validateListeners(context.Background(), testutils.GenerateTestClient(), allListeners{GatewayListeners: tt.listeners}, gwv1.Gateway{}, tt.controllerName)
+			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expectedErrors, result.GatewayListenerValidation.HasErrors)
 			assert.Equal(t, tt.expectedCount, len(result.GatewayListenerValidation.Results))
@@ -571,7 +574,8 @@ func TestValidateListeners_ListenerSets(t *testing.T) {
 				GatewayListeners:     tt.gatewayListeners,
 				ListenerSetListeners: tt.listenerSetLoadResult,
 			}
-			result := validateListeners(input, 0, tt.controllerName)
+			result, err := validateListeners(context.Background(), testutils.GenerateTestClient(), input, gwv1.Gateway{}, tt.controllerName)
+			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expectedGatewayHasErrors, result.GatewayListenerValidation.HasErrors)
 			assert.Equal(t, tt.expectedHasErrors, result.HasErrors())
@@ -732,3 +736,163 @@ func TestCalculateAttachedListenerSets(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateListeners_ListenerSetDelegation(t *testing.T) {
+	teamAHostname := gwv1.Hostname("team-a.example.com")
+	otherNamespace := gwv1.Namespace("platform")
+	certificateARNsOption := map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+		gateway_constants.TLSOptionCertificateARNs: "arn:aws:acm:us-west-2:123456789012:certificate/team-a",
+	}
+	newListenerSetReferenceGrant := func(namespace string, toGroup string, toKind string, toName string) *gwbeta1.ReferenceGrant {
+		name := gwv1.ObjectName(toName)
+		return &gwbeta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "allow-team-a"},
+			Spec: gwbeta1.ReferenceGrantSpec{
+				From: []gwbeta1.ReferenceGrantFrom{{Group: "gateway.networking.k8s.io", Kind: "ListenerSet", Namespace: "team-a"}},
+				To:   []gwbeta1.ReferenceGrantTo{{Group: gwv1.Group(toGroup), Kind: gwv1.Kind(toKind), Name: &name}},
+			},
+		}
+	}
+	makeLoadResult := func(listeners ...gwv1.Listener) listenerSetLoadResult {
+		ls := gwv1.ListenerSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ls", CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))},
+		}
+		sources := make([]listenerSetListenerSource, 0, len(listeners))
+		for _, l := range listeners {
+			sources = append(sources, listenerSetListenerSource{parentRef: ls, listener: l})
+		}
+		nsn := types.NamespacedName{Namespace: "team-a", Name: "ls"}
+		return listenerSetLoadResult{
+			listenersPerListenerSet: map[types.NamespacedName][]listenerSetListenerSource{nsn: sources},
+			acceptedListenerSets:    map[types.NamespacedName]gwv1.ListenerSet{nsn: ls},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		gatewayListeners []gwv1.Listener
+		lsListeners      []gwv1.Listener
+		referenceGrants  []*gwbeta1.ReferenceGrant
+		wantReason       gwv1.ListenerConditionReason
+		wantMessage      string
+	}{
+		{
+			name:        "port within allowed ranges",
+			annotations: map[string]string{gateway_constants.AnnotationListenerSetAllowedPorts: "443, 8000-8100"},
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 8080, Protocol: gwv1.HTTPProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			wantReason:  gwv1.ListenerReasonAccepted,
+			wantMessage: gateway_constants.ListenerAcceptedMessage,
+		},
+		{
+			name:        "port outside allowed ranges",
+			annotations: map[string]string{gateway_constants.AnnotationListenerSetAllowedPorts: "443,8000-8100"},
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 80, Protocol: gwv1.HTTPProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			wantReason:  gwv1.ListenerReasonPortUnavailable,
+			wantMessage: "Port 80 is not available (listener name l): the parent Gateway only allows ListenerSet listeners on ports 443,8000-8100",
+		},
+		{
+			name:        "invalid allowed ports annotation",
+			annotations: map[string]string{gateway_constants.AnnotationListenerSetAllowedPorts: "9000-8000"},
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 8080, Protocol: gwv1.HTTPProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			wantReason:  gwv1.ListenerReasonPortUnavailable,
+			wantMessage: "Port 8080 is not available (listener name l): Gateway annotation gateway.k8s.aws/listener-set-allowed-ports is invalid: invalid port range 9000-8000",
+		},
+		{
+			name:        "certificate from another namespace",
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}, TLS: &gwv1.ListenerTLSConfig{CertificateRefs: []gwv1.SecretObjectReference{{Name: "cert", Namespace: &otherNamespace}}}}},
+			wantReason:  gwv1.ListenerReasonRefNotPermitted,
+			wantMessage: "Certificate platform/cert is not permitted for listener l: No explicit ReferenceGrant exists to allow the reference.",
+		},
+		{
+			name:        "certificate from another namespace with a ReferenceGrant",
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}, TLS: &gwv1.ListenerTLSConfig{CertificateRefs: []gwv1.SecretObjectReference{{Name: "cert", Namespace: &otherNamespace}}}}},
+			referenceGrants: []*gwbeta1.ReferenceGrant{
+				newListenerSetReferenceGrant("platform", "", "Secret", "cert"),
+			},
+			wantReason:  gwv1.ListenerReasonAccepted,
+			wantMessage: gateway_constants.ListenerAcceptedMessage,
+		},
+		{
+			name:        "certificate ARNs from another namespace than the Gateway",
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}, TLS: &gwv1.ListenerTLSConfig{Options: certificateARNsOption}}},
+			referenceGrants: []*gwbeta1.ReferenceGrant{
+				newListenerSetReferenceGrant("infra", "gateway.networking.k8s.io", "Gateway", "other-gw"),
+			},
+			wantReason:  gwv1.ListenerReasonRefNotPermitted,
+			wantMessage: "ACM certificates in TLS option gateway.k8s.aws/certificate-arns are not permitted for listener l, the ListenerSet isn't in the namespace of Gateway infra/gw: No explicit ReferenceGrant exists to allow the reference.",
+		},
+		{
+			name:        "certificate ARNs from another namespace than the Gateway with a ReferenceGrant",
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}, TLS: &gwv1.ListenerTLSConfig{Options: certificateARNsOption}}},
+			referenceGrants: []*gwbeta1.ReferenceGrant{
+				newListenerSetReferenceGrant("infra", "gateway.networking.k8s.io", "Gateway", "gw"),
+			},
+			wantReason:  gwv1.ListenerReasonAccepted,
+			wantMessage: gateway_constants.ListenerAcceptedMessage,
+		},
+		{
+			name: "invalid certificate ARN in TLS options",
+			lsListeners: []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}, TLS: &gwv1.ListenerTLSConfig{Options: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+				gateway_constants.TLSOptionCertificateARNs: "arn:aws:iam::123456789012:server-certificate/team-a",
+			}}}},
+			wantReason:  gwv1.ListenerReasonInvalidCertificateRef,
+			wantMessage: "Invalid ACM certificate ARN arn:aws:iam::123456789012:server-certificate/team-a in TLS option gateway.k8s.aws/certificate-arns of listener l",
+		},
+		{
+			name:             "hostname conflict names the listener holding the hostname",
+			gatewayListeners: []gwv1.Listener{{Name: "gw-https", Port: 443, Protocol: gwv1.HTTPSProtocolType, Hostname: &teamAHostname, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			lsListeners:      []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, Hostname: &teamAHostname, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			wantReason:       gwv1.ListenerReasonHostnameConflict,
+			wantMessage:      "Hostname conflict for port 443 with hostname team-a.example.com, the hostname is already used by Gateway infra/gw listener gw-https",
+		},
+		{
+			name:             "protocol conflict names the listener holding the port",
+			gatewayListeners: []gwv1.Listener{{Name: "gw-http", Port: 443, Protocol: gwv1.HTTPProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			lsListeners:      []gwv1.Listener{{Name: "l", Port: 443, Protocol: gwv1.HTTPSProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+			wantReason:       gwv1.ListenerReasonProtocolConflict,
+			wantMessage:      "Protocol conflict for port 443, the port is already used with protocol HTTP by Gateway infra/gw listener gw-http",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "gw", Annotations: tt.annotations},
+			}
+			input := allListeners{
+				GatewayListeners:     tt.gatewayListeners,
+				ListenerSetListeners: makeLoadResult(tt.lsListeners...),
+			}
+			k8sClient := testutils.GenerateTestClient()
+			for _, refGrant := range tt.referenceGrants {
+				assert.NoError(t, k8sClient.Create(context.Background(), refGrant.DeepCopy()))
+			}
+			result, err := validateListeners(context.Background(), k8sClient, input, gw, gateway_constants.ALBGatewayController)
+			assert.NoError(t, err)
+
+			assert.False(t, result.GatewayListenerValidation.HasErrors)
+			lsResult := result.ListenerSetListenerValidation[types.NamespacedName{Namespace: "team-a", Name: "ls"}]
+			assert.Equal(t, tt.wantReason != gwv1.ListenerReasonAccepted, lsResult.HasErrors)
+			assert.Equal(t, tt.wantReason, lsResult.Results["l"].Reason)
+			assert.Equal(t, tt.wantMessage, lsResult.Results["l"].Message)
+		})
+	}
+}
+
+func TestValidateListeners_GatewayListenersIgnoreListenerSetAllowedPorts(t *testing.T) {
+	gw := gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "infra",
+			Name:        "gw",
+			Annotations: map[string]string{gateway_constants.AnnotationListenerSetAllowedPorts: "443"},
+		},
+	}
+	input := allListeners{
+		GatewayListeners: []gwv1.Listener{{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType, AllowedRoutes: &gwv1.AllowedRoutes{}}},
+	}
+	result, err := validateListeners(context.Background(), testutils.GenerateTestClient(), input, gw, gateway_constants.ALBGatewayController)
+	assert.NoError(t, err)
+	assert.False(t, result.HasErrors())
+	assert.Equal(t, gwv1.ListenerReasonAccepted, result.GatewayListenerValidation.Results["http"].Reason)
+}
@@ -70,6 +70,8 @@ type loaderImpl struct {
 	k8sClient       client.Client
 	logger          logr.Logger
 	allRouteLoaders map[RouteKind]func(context context.Context, client client.Client, opts ...client.ListOption) ([]preLoadRouteDescriptor, error)
+	// whether to apply the weights of TrafficRollouts targeting HTTPRoutes.
+	trafficRolloutEnabled bool
 }
 
 func NewLoader(k8sClient client.Client, routeSubmitter RouteReconcilerSubmitter, featureGates config.FeatureGates, logger logr.Logger) Loader {
@@ -87,6 +89,8 @@ func NewLoader(k8sClient client.Client, routeSubmitter RouteReconcilerSubmitter,
 		k8sClient:       k8sClient,
 		allRouteLoaders: allRoutes,
 		logger:          logger,
+
+		trafficRolloutEnabled: featureGates.Enabled(config.TrafficRollout),
 	}
 }
 
@@ -135,7 +139,10 @@ func (l *loaderImpl) LoadRoutesForGateway(ctx context.Context, gw gwv1.Gateway,
 		ListenerSetListeners: listenerSetListeners,
 	}
 
-	listenerValidationResults := validateListeners(gatewayListeners, gw.Generation, controllerName)
+	listenerValidationResults, err := validateListeners(ctx, l.k8sClient, gatewayListeners, gw, controllerName)
+	if err != nil {
+		return nil, err
+	}
 
 	//  2. Map routes to relevant listeners
 	mapResult, err := l.mapper.mapListenersAndRoutes(ctx, gw, gatewayListeners, loadedRoutes, listenerValidationResults)
@@ -237,6 +244,12 @@ func (l *loaderImpl) loadChildResources(ctx context.Context, preloadedRoutes map
 				}
 			}
 
+			if l.trafficRolloutEnabled {
+				if err := applyTrafficRollouts(ctx, l.k8sClient, generatedRoute); err != nil {
+					return nil, failedRoutes, err
+				}
+			}
+
 			loadedRouteData[port] = append(loadedRouteData[port], generatedRoute)
 			resourceCache[cacheKey] = generatedRoute
 		}
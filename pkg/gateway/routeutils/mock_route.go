@@ -36,6 +36,8 @@ type MockRoute struct {
 	CreationTime              time.Time
 	Rules                     []RouteRule
 	CompatibleHostnamesByPort map[int32][]gwv1.Hostname
+	ParentRefs                []gwv1.ParentReference
+	Generation                int64
 }
 
 func (m *MockRoute) GetBackendRefs() []gwv1.BackendRef {
@@ -71,8 +73,7 @@ func (m *MockRoute) GetHostnames() []gwv1.Hostname {
 }
 
 func (m *MockRoute) GetParentRefs() []gwv1.ParentReference {
-	//TODO implement me
-	panic("implement me")
+	return m.ParentRefs
 }
 
 func (m *MockRoute) GetRawRoute() interface{} {
@@ -86,7 +87,7 @@ func (m *MockRoute) GetAttachedRules() []RouteRule {
 }
 
 func (m *MockRoute) GetRouteGeneration() int64 {
-	panic("implement me")
+	return m.Generation
 }
 
 func (m *MockRoute) GetRouteCreateTimestamp() time.Time {
@@ -22,8 +22,21 @@ type RouteStatusInfo struct {
 	ResolvedRefs bool
 	Reason       string
 	Message      string
+	// BackendStrategy is the strategy used to front the route backends, only reported for L4 routes once the model is built.
+	BackendStrategy BackendStrategy
 }
 
+// BackendStrategy describes how the backends of an L4 route are fronted by the load balancer listener.
+type BackendStrategy string
+
+const (
+	// BackendStrategyWeightedTargetGroups forwards to one target group per backend, using the backend weights.
+	BackendStrategyWeightedTargetGroups BackendStrategy = "WeightedTargetGroups"
+	// BackendStrategyAggregatedTargetGroup forwards to a single target group shared by all backends,
+	// each backend registers a share of its endpoints proportional to its weight.
+	BackendStrategyAggregatedTargetGroup BackendStrategy = "AggregatedTargetGroup"
+)
+
 type RouteMetadata struct {
 	RouteName       string
 	RouteNamespace  string
@@ -49,6 +62,9 @@ const (
 	RouteStatusInfoRejectedMessageKindNotMatch       = "Listener does not allow route attachment, kind does not match between listener and route"
 	RouteStatusInfoRejectedParentRefNotExist         = "ParentRefDoesNotExist"
 	RouteStatusInfoRejectedMessageParentNotMatch     = "Route parentRef does not match listener"
+
+	// RouteConditionBackendStrategy reports the BackendStrategy of L4 routes.
+	RouteConditionBackendStrategy = "gateway.k8s.aws/BackendStrategy"
 )
 
 func GenerateRouteData(accepted bool, resolvedRefs bool, reason string, message string, routeNamespaceName types.NamespacedName, routeKind RouteKind, routeGeneration int64, parentRef gwv1.ParentReference) RouteData {
@@ -68,3 +84,17 @@ func GenerateRouteData(accepted bool, resolvedRefs bool, reason string, message
 		ParentRef: parentRef,
 	}
 }
+
+// GenerateBackendStrategyRouteData generates the route data reporting the backend strategy, for every parentRef of the route that refers to the Gateway.
+func GenerateBackendStrategyRouteData(route RouteDescriptor, gw gwv1.Gateway, strategy BackendStrategy) []RouteData {
+	var routeData []RouteData
+	for _, parentRef := range route.GetParentRefs() {
+		if !doesResourceAttachToGateway(parentRef, route.GetRouteNamespacedName().Namespace, gw) {
+			continue
+		}
+		data := GenerateRouteData(true, true, string(gwv1.RouteConditionAccepted), RouteStatusInfoAcceptedMessage, route.GetRouteNamespacedName(), route.GetRouteKind(), route.GetRouteGeneration(), parentRef)
+		data.RouteStatusInfo.BackendStrategy = strategy
+		routeData = append(routeData, data)
+	}
+	return routeData
+}
@@ -0,0 +1,75 @@
+package routeutils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/rollout"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// applyTrafficRollouts splits the weight of HTTPRoute rules between the stable and canary backends of the TrafficRollouts targeting route.
+// Both backends must be referenced by the rule, typically with the canary backend initially weighted 0.
+func applyTrafficRollouts(ctx context.Context, k8sClient client.Client, route RouteDescriptor) error {
+	if route.GetRouteKind() != HTTPRouteKind {
+		return nil
+	}
+	routeKey := route.GetRouteNamespacedName()
+	rollouts, err := rollout.ListTrafficRollouts(ctx, k8sClient, elbv2api.TrafficRolloutTargetKindHTTPRoute, routeKey.Namespace, routeKey.Name)
+	if err != nil {
+		return err
+	}
+	for i := range rollouts {
+		tr := &rollouts[i]
+		canaryPercent, ok := rollout.CanaryWeight(tr)
+		if !ok {
+			continue
+		}
+		for _, rule := range route.GetAttachedRules() {
+			if tr.Spec.TargetRef.SectionName != nil {
+				rawRule, ok := rule.GetRawRouteRule().(*gwv1.HTTPRouteRule)
+				if !ok || rawRule.Name == nil || string(*rawRule.Name) != *tr.Spec.TargetRef.SectionName {
+					continue
+				}
+			}
+			splitRuleWeight(rule.GetBackends(), tr, canaryPercent)
+		}
+	}
+	return nil
+}
+
+// splitRuleWeight splits the combined weight of the stable and canary backends of a rule.
+// When they're the only backends of the rule, their weights are only relative, so the default total weight is split for precision.
+func splitRuleWeight(backends []Backend, tr *elbv2api.TrafficRollout, canaryPercent int32) {
+	stableIdx := findRolloutBackend(backends, tr.Spec.StableBackend)
+	canaryIdx := findRolloutBackend(backends, tr.Spec.CanaryBackend)
+	if stableIdx < 0 || canaryIdx < 0 {
+		return
+	}
+	total := int32(min(backends[stableIdx].Weight+backends[canaryIdx].Weight, maxWeight))
+	if len(backends) == 2 {
+		total = rollout.DefaultTotalWeight
+	}
+	stableWeight, canaryWeight := rollout.SplitWeight(total, canaryPercent)
+	backends[stableIdx].Weight = int(stableWeight)
+	backends[canaryIdx].Weight = int(canaryWeight)
+}
+
+// findRolloutBackend returns the index of the Service backend matching rolloutBackend, or -1 if there is none.
+func findRolloutBackend(backends []Backend, rolloutBackend elbv2api.TrafficRolloutBackend) int {
+	for i, backend := range backends {
+		svcBackend := backend.ServiceBackend
+		if svcBackend == nil || svcBackend.service == nil || svcBackend.servicePort == nil || svcBackend.service.Name != rolloutBackend.Name {
+			continue
+		}
+		if rolloutBackend.Port.Type == intstr.Int && svcBackend.servicePort.Port == rolloutBackend.Port.IntVal {
+			return i
+		}
+		if rolloutBackend.Port.Type == intstr.String && svcBackend.servicePort.Name == rolloutBackend.Port.StrVal {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,83 @@
+package routeutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_splitRuleWeight(t *testing.T) {
+	serviceBackend := func(name string, port int32, portName string, weight int) Backend {
+		return Backend{
+			ServiceBackend: NewServiceBackendConfig(
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}},
+				nil,
+				&corev1.ServicePort{Name: portName, Port: port},
+			),
+			Weight: weight,
+		}
+	}
+	rollout := &elbv2api.TrafficRollout{
+		Spec: elbv2api.TrafficRolloutSpec{
+			StableBackend: elbv2api.TrafficRolloutBackend{Name: "stable", Port: intstr.FromInt32(80)},
+			CanaryBackend: elbv2api.TrafficRolloutBackend{Name: "canary", Port: intstr.FromString("http")},
+		},
+	}
+	tests := []struct {
+		name          string
+		backends      []Backend
+		canaryPercent int32
+		wantWeights   []int
+	}{
+		{
+			name: "default total weight is split when rule only has stable and canary backends",
+			backends: []Backend{
+				serviceBackend("stable", 80, "http", 1),
+				serviceBackend("canary", 80, "http", 0),
+			},
+			canaryPercent: 25,
+			wantWeights:   []int{75, 25},
+		},
+		{
+			name: "combined weight is split when rule has other backends",
+			backends: []Backend{
+				serviceBackend("other", 80, "http", 100),
+				serviceBackend("stable", 80, "http", 60),
+				serviceBackend("canary", 80, "http", 40),
+			},
+			canaryPercent: 10,
+			wantWeights:   []int{100, 90, 10},
+		},
+		{
+			name: "rule without canary backend is untouched",
+			backends: []Backend{
+				serviceBackend("stable", 80, "http", 1),
+			},
+			canaryPercent: 25,
+			wantWeights:   []int{1},
+		},
+		{
+			name: "backend port must match",
+			backends: []Backend{
+				serviceBackend("stable", 8080, "http", 1),
+				serviceBackend("canary", 80, "http", 0),
+			},
+			canaryPercent: 25,
+			wantWeights:   []int{1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitRuleWeight(tt.backends, rollout, tt.canaryPercent)
+			var gotWeights []int
+			for _, backend := range tt.backends {
+				gotWeights = append(gotWeights, backend.Weight)
+			}
+			assert.Equal(t, tt.wantWeights, gotWeights)
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	globalacceleratortypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	agadeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/aga"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	gatewayconstants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/aws"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	controllerName = "orphaned-resource-gc"
+
+	// jitter applied to the scan interval to avoid synchronized scans across restarts.
+	scanIntervalJitterFactor = 0.1
+)
+
+// StackKind identifies the kind of Kubernetes object that owns a stack of AWS resources.
+type StackKind string
+
+const (
+	StackKindIngress           StackKind = "ingress"
+	StackKindService           StackKind = "service"
+	StackKindNLBGateway        StackKind = "nlb-gateway"
+	StackKindALBGateway        StackKind = "alb-gateway"
+	StackKindGlobalAccelerator StackKind = "globalaccelerator"
+)
+
+// stackKindTagPrefixes are the tracking tag prefixes used by each controller, see tracking.Provider.
+var stackKindTagPrefixes = map[StackKind]string{
+	StackKindIngress:           "ingress.k8s.aws",
+	StackKindService:           "service.k8s.aws",
+	StackKindNLBGateway:        gatewayconstants.NLBGatewayTagPrefix,
+	StackKindALBGateway:        gatewayconstants.ALBGatewayTagPrefix,
+	StackKindGlobalAccelerator: "aga.k8s.aws",
+}
+
+// elbv2StackResourceTypes are the RGT resource types provisioned for load balancer stacks.
+var elbv2StackResourceTypes = []string{
+	services.ResourceTypeELBLoadBalancer,
+	services.ResourceTypeELBTargetGroup,
+	services.ResourceTypeEC2SecurityGroup,
+}
+
+// stackKey uniquely identifies a stack across all controllers.
+type stackKey struct {
+	kind    StackKind
+	stackID core.StackID
+}
+
+// orphanedStack is a stack whose AWS resources were found but whose owner no longer exists.
+type orphanedStack struct {
+	stackKey
+	resourceARNs []string
+}
+
+// stackDeleter deletes every AWS resource belonging to an orphaned stack.
+type stackDeleter func(ctx context.Context, stack orphanedStack) error
+
+var _ manager.Runnable = &orphanedResourceCollector{}
+var _ manager.LeaderElectionRunnable = &orphanedResourceCollector{}
+
+// NewOrphanedResourceCollector constructs new orphanedResourceCollector.
+func NewOrphanedResourceCollector(cloud services.Cloud, k8sClient client.Client, k8sReader client.Reader,
+	networkingManager networking.NetworkingManager, networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
+	elbv2TaggingManager elbv2deploy.TaggingManager, controllerConfig config.ControllerConfig, metricsCollector lbcmetrics.MetricCollector,
+	gcMetricsCollector lbcmetrics.OrphanedResourceMetricsCollector, targetGroupCollector awsmetrics.TargetGroupCollector, logger logr.Logger) *orphanedResourceCollector {
+
+	newELBV2StackDeleter := func(kind StackKind, enableFrontendNLB bool) stackDeleter {
+		stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingManager, networkingSGManager, networkingSGReconciler, elbv2TaggingManager,
+			controllerConfig, stackKindTagPrefixes[kind], logger, metricsCollector, controllerName, true, targetGroupCollector, enableFrontendNLB)
+		return func(ctx context.Context, stack orphanedStack) error {
+			// deploying an empty stack removes every resource tracked under the stack, exactly as the owning controller does on deletion.
+			return stackDeployer.Deploy(ctx, core.NewDefaultStack(stack.stackID), metricsCollector, controllerName)
+		}
+	}
+	stackDeleters := map[StackKind]stackDeleter{
+		StackKindIngress:    newELBV2StackDeleter(StackKindIngress, true),
+		StackKindService:    newELBV2StackDeleter(StackKindService, false),
+		StackKindNLBGateway: newELBV2StackDeleter(StackKindNLBGateway, true),
+		StackKindALBGateway: newELBV2StackDeleter(StackKindALBGateway, false),
+	}
+
+	agaEnabled := aga.IsGlobalAcceleratorControllerEnabled(controllerConfig.FeatureGates, cloud.Region())
+	if agaEnabled {
+		agaTrackingProvider := tracking.NewDefaultProvider(stackKindTagPrefixes[StackKindGlobalAccelerator], controllerConfig.ClusterName, tracking.WithRegion(cloud.Region()))
+		agaStackDeployer := agadeploy.NewDefaultStackDeployer(cloud, controllerConfig, agaTrackingProvider, logger, metricsCollector, controllerName)
+		stackDeleters[StackKindGlobalAccelerator] = func(ctx context.Context, stack orphanedStack) error {
+			for _, acceleratorARN := range stack.resourceARNs {
+				sdkAccelerator := agadeploy.AcceleratorWithTags{
+					Accelerator: &globalacceleratortypes.Accelerator{
+						AcceleratorArn: awssdk.String(acceleratorARN),
+					},
+				}
+				// accelerators are disabled asynchronously, a not-disabled error is retried by the next scan.
+				if err := agaStackDeployer.GetAcceleratorManager().Delete(ctx, sdkAccelerator); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	return &orphanedResourceCollector{
+		rgtClient:          cloud.RGT(),
+		agaRGTClient:       cloud.GlobalAcceleratorRGT(),
+		region:             cloud.Region(),
+		ownerResolver:      NewDefaultOwnerResolver(k8sReader),
+		stackDeleters:      stackDeleters,
+		clusterName:        controllerConfig.ClusterName,
+		agaEnabled:         agaEnabled,
+		interval:           controllerConfig.OrphanedResourceGCConfig.Interval,
+		quarantinePeriod:   controllerConfig.OrphanedResourceGCConfig.QuarantinePeriod,
+		dryRun:             controllerConfig.OrphanedResourceGCConfig.DryRun,
+		gcMetricsCollector: gcMetricsCollector,
+		logger:             logger,
+		clock:              time.Now,
+		orphanedSince:      make(map[stackKey]time.Time),
+	}
+}
+
+// orphanedResourceCollector periodically scans AWS resources tagged for this cluster, reports stacks whose
+// owning Kubernetes object no longer exists, and deletes them once they stayed orphaned for the quarantine period.
+// Such leaks happen when finalizers are force-removed, or when the owner is deleted while the controller is down.
+type orphanedResourceCollector struct {
+	rgtClient          services.RGT
+	agaRGTClient       services.RGT
+	region             string
+	ownerResolver      OwnerResolver
+	stackDeleters      map[StackKind]stackDeleter
+	clusterName        string
+	agaEnabled         bool
+	interval           time.Duration
+	quarantinePeriod   time.Duration
+	dryRun             bool
+	gcMetricsCollector lbcmetrics.OrphanedResourceMetricsCollector
+	logger             logr.Logger
+	clock              func() time.Time
+
+	// orphanedSince tracks when each orphaned stack was first observed.
+	// it's kept in memory only, thus a controller restart restarts the quarantine, which errs on the safe side.
+	orphanedSince      map[stackKey]time.Time
+	orphanedSinceMutex sync.Mutex
+}
+
+// Start runs the garbage collection loop until ctx is done.
+func (c *orphanedResourceCollector) Start(ctx context.Context) error {
+	c.logger.Info("starting orphaned resource garbage collector",
+		"interval", c.interval, "quarantinePeriod", c.quarantinePeriod, "dryRun", c.dryRun)
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.Collect(ctx); err != nil {
+			c.logger.Error(err, "failed to collect orphaned resources")
+		}
+	}, c.interval, scanIntervalJitterFactor, true)
+	return nil
+}
+
+// NeedLeaderElection makes sure only the leader deletes orphaned resources.
+func (c *orphanedResourceCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Collect runs a single garbage collection scan.
+func (c *orphanedResourceCollector) Collect(ctx context.Context) error {
+	c.orphanedSinceMutex.Lock()
+	defer c.orphanedSinceMutex.Unlock()
+
+	stacks, err := c.listTrackedStacks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := c.clock()
+	orphanedStackKeys := sets.New[stackKey]()
+	orphanedResourceCounts := make(map[StackKind]map[string]int)
+	var deleteErrs []error
+	for _, stack := range stacks {
+		present, err := c.ownerResolver.IsOwnerPresent(ctx, stack.kind, stack.stackID)
+		if err != nil {
+			c.logger.Error(err, "unable to determine owner of stack, skipping",
+				"stackKind", stack.kind, "stackID", stack.stackID.String())
+			continue
+		}
+		if present {
+			continue
+		}
+
+		orphanedStackKeys.Insert(stack.stackKey)
+		for _, resourceARN := range stack.resourceARNs {
+			if orphanedResourceCounts[stack.kind] == nil {
+				orphanedResourceCounts[stack.kind] = make(map[string]int)
+			}
+			orphanedResourceCounts[stack.kind][resourceTypeFromARN(resourceARN)]++
+		}
+
+		firstSeen, ok := c.orphanedSince[stack.stackKey]
+		if !ok {
+			firstSeen = now
+			c.orphanedSince[stack.stackKey] = now
+		}
+		quarantineExpired := now.Sub(firstSeen) >= c.quarantinePeriod
+		c.logger.Info("found orphaned stack",
+			"stackKind", stack.kind,
+			"stackID", stack.stackID.String(),
+			"resourceARNs", stack.resourceARNs,
+			"orphanedSince", firstSeen,
+			"quarantineExpired", quarantineExpired,
+			"dryRun", c.dryRun)
+		if c.dryRun || !quarantineExpired {
+			continue
+		}
+
+		if err := c.deleteStack(ctx, stack); err != nil {
+			deleteErrs = append(deleteErrs, err)
+			continue
+		}
+		delete(c.orphanedSince, stack.stackKey)
+	}
+
+	// stacks that disappeared or regained an owner restart their quarantine if they are orphaned again later.
+	for key := range c.orphanedSince {
+		if !orphanedStackKeys.Has(key) {
+			delete(c.orphanedSince, key)
+		}
+	}
+	c.observeOrphanedResources(orphanedResourceCounts)
+	return errors.Join(deleteErrs...)
+}
+
+func (c *orphanedResourceCollector) deleteStack(ctx context.Context, stack orphanedStack) error {
+	deleter, ok := c.stackDeleters[stack.kind]
+	if !ok {
+		return nil
+	}
+	c.logger.Info("deleting orphaned stack", "stackKind", stack.kind, "stackID", stack.stackID.String())
+	if err := deleter(ctx, stack); err != nil {
+		c.logger.Error(err, "failed to delete orphaned stack", "stackKind", stack.kind, "stackID", stack.stackID.String())
+		return err
+	}
+	c.gcMetricsCollector.ObserveOrphanedStackDeleted(string(stack.kind))
+	c.logger.Info("deleted orphaned stack", "stackKind", stack.kind, "stackID", stack.stackID.String())
+	return nil
+}
+
+// listTrackedStacks lists every AWS resource tagged for this cluster and groups them by stack.
+// resources without a recognized stack tag, like the shared backend security group, are never considered.
+func (c *orphanedResourceCollector) listTrackedStacks(ctx context.Context) ([]orphanedStack, error) {
+	clusterTagFilter := []rgttypes.TagFilter{
+		{
+			Key:    awssdk.String(shared_constants.TagKeyK8sCluster),
+			Values: []string{c.clusterName},
+		},
+	}
+	resources, err := c.rgtClient.GetResourcesAsList(ctx, &rgtsdk.GetResourcesInput{
+		TagFilters:          clusterTagFilter,
+		ResourceTypeFilters: elbv2StackResourceTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.agaEnabled {
+		// accelerators of every region are tagged in the Global Accelerator region, thus clusters of the same name
+		// in other regions are told apart by the cluster-region tag.
+		agaResources, err := c.agaRGTClient.GetResourcesAsList(ctx, &rgtsdk.GetResourcesInput{
+			TagFilters: append(clusterTagFilter, rgttypes.TagFilter{
+				Key:    awssdk.String(shared_constants.TagKeyK8sClusterRegion),
+				Values: []string{c.region},
+			}),
+			ResourceTypeFilters: []string{services.ResourceTypeGlobalAccelerator},
+		})
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, agaResources...)
+	}
+
+	stackByKey := make(map[stackKey]*orphanedStack)
+	var stackKeys []stackKey
+	for _, resource := range resources {
+		key, ok := resolveStackKey(services.ParseRGTTags(resource.Tags))
+		if !ok {
+			continue
+		}
+		stack, exists := stackByKey[key]
+		if !exists {
+			stack = &orphanedStack{stackKey: key}
+			stackByKey[key] = stack
+			stackKeys = append(stackKeys, key)
+		}
+		stack.resourceARNs = append(stack.resourceARNs, awssdk.ToString(resource.ResourceARN))
+	}
+
+	stacks := make([]orphanedStack, 0, len(stackKeys))
+	for _, key := range stackKeys {
+		stacks = append(stacks, *stackByKey[key])
+	}
+	return stacks, nil
+}
+
+func (c *orphanedResourceCollector) observeOrphanedResources(counts map[StackKind]map[string]int) {
+	resourceTypes := append([]string{services.ResourceTypeGlobalAccelerator}, elbv2StackResourceTypes...)
+	for kind := range stackKindTagPrefixes {
+		for _, resourceType := range resourceTypes {
+			c.gcMetricsCollector.ObserveOrphanedResources(string(kind), resourceType, counts[kind][resourceType])
+		}
+	}
+}
+
+// resolveStackKey finds the stack a resource belongs to from its tracking tags.
+func resolveStackKey(tags map[string]string) (stackKey, bool) {
+	for kind, tagPrefix := range stackKindTagPrefixes {
+		rawStackID, ok := tags[tagPrefix+"/stack"]
+		if !ok || rawStackID == "" {
+			continue
+		}
+		return stackKey{kind: kind, stackID: parseStackID(rawStackID)}, true
+	}
+	return stackKey{}, false
+}
+
+// parseStackID is the reverse of core.StackID.String.
+func parseStackID(rawStackID string) core.StackID {
+	namespace, name, found := strings.Cut(rawStackID, "/")
+	if !found {
+		return core.StackID{Name: rawStackID}
+	}
+	return core.StackID{Namespace: namespace, Name: name}
+}
+
+// resourceTypeFromARN returns the RGT resource type of an ARN, e.g. `elasticloadbalancing:loadbalancer`.
+func resourceTypeFromARN(resourceARN string) string {
+	parsedARN, err := arn.Parse(resourceARN)
+	if err != nil {
+		return ""
+	}
+	resourceType, _, _ := strings.Cut(parsedARN.Resource, "/")
+	return parsedARN.Service + ":" + resourceType
+}
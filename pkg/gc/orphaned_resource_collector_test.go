@@ -0,0 +1,356 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeOwnerResolver struct {
+	presentOwners map[stackKey]bool
+	errOwners     map[stackKey]error
+}
+
+func (r *fakeOwnerResolver) IsOwnerPresent(_ context.Context, kind StackKind, stackID core.StackID) (bool, error) {
+	key := stackKey{kind: kind, stackID: stackID}
+	if err, ok := r.errOwners[key]; ok {
+		return false, err
+	}
+	return r.presentOwners[key], nil
+}
+
+func buildRGTResource(resourceARN string, tags map[string]string) rgttypes.ResourceTagMapping {
+	var rgtTags []rgttypes.Tag
+	for k, v := range tags {
+		rgtTags = append(rgtTags, rgttypes.Tag{Key: awssdk.String(k), Value: awssdk.String(v)})
+	}
+	return rgttypes.ResourceTagMapping{
+		ResourceARN: awssdk.String(resourceARN),
+		Tags:        rgtTags,
+	}
+}
+
+func Test_orphanedResourceCollector_Collect(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	svcStack := stackKey{kind: StackKindService, stackID: core.StackID{Namespace: "ns", Name: "svc"}}
+	ingStack := stackKey{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}}
+	svcLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/k8s-ns-svc/abc"
+	svcTGARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-ns-svc/abc"
+	ingLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-awesomegroup/def"
+	backendSGARN := "arn:aws:ec2:us-west-2:123456789012:security-group/sg-backend"
+	rgtResources := []rgttypes.ResourceTagMapping{
+		buildRGTResource(svcLBARN, map[string]string{
+			"elbv2.k8s.aws/cluster": "cluster",
+			"service.k8s.aws/stack": "ns/svc",
+		}),
+		buildRGTResource(svcTGARN, map[string]string{
+			"elbv2.k8s.aws/cluster": "cluster",
+			"service.k8s.aws/stack": "ns/svc",
+		}),
+		buildRGTResource(ingLBARN, map[string]string{
+			"elbv2.k8s.aws/cluster": "cluster",
+			"ingress.k8s.aws/stack": "awesome-group",
+		}),
+		buildRGTResource(backendSGARN, map[string]string{
+			"elbv2.k8s.aws/cluster":  "cluster",
+			"elbv2.k8s.aws/resource": "backend-sg",
+		}),
+	}
+
+	tests := []struct {
+		name              string
+		presentOwners     map[stackKey]bool
+		errOwners         map[stackKey]error
+		orphanedSince     map[stackKey]time.Time
+		dryRun            bool
+		deleteErr         error
+		wantDeletedStacks []orphanedStack
+		wantOrphanedSince map[stackKey]time.Time
+		wantErr           string
+	}{
+		{
+			name: "all stacks are owned",
+			presentOwners: map[stackKey]bool{
+				svcStack: true,
+				ingStack: true,
+			},
+			orphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-time.Hour),
+			},
+			wantOrphanedSince: map[stackKey]time.Time{},
+		},
+		{
+			name: "newly orphaned stack is quarantined",
+			presentOwners: map[stackKey]bool{
+				ingStack: true,
+			},
+			wantOrphanedSince: map[stackKey]time.Time{
+				svcStack: now,
+			},
+		},
+		{
+			name: "orphaned stack is deleted after quarantine period",
+			presentOwners: map[stackKey]bool{
+				ingStack: true,
+			},
+			orphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-25 * time.Hour),
+			},
+			wantDeletedStacks: []orphanedStack{
+				{stackKey: svcStack, resourceARNs: []string{svcLBARN, svcTGARN}},
+			},
+			wantOrphanedSince: map[stackKey]time.Time{},
+		},
+		{
+			name: "orphaned stack is only reported in dry-run mode",
+			presentOwners: map[stackKey]bool{
+				ingStack: true,
+			},
+			orphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-25 * time.Hour),
+			},
+			dryRun: true,
+			wantOrphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-25 * time.Hour),
+			},
+		},
+		{
+			name: "stack is skipped when owner cannot be resolved",
+			errOwners: map[stackKey]error{
+				svcStack: errors.New("no matches for kind"),
+			},
+			orphanedSince: map[stackKey]time.Time{
+				ingStack: now.Add(-time.Hour),
+			},
+			wantOrphanedSince: map[stackKey]time.Time{
+				ingStack: now.Add(-time.Hour),
+			},
+		},
+		{
+			name: "orphaned stack stays quarantined when deletion fails",
+			presentOwners: map[stackKey]bool{
+				ingStack: true,
+			},
+			orphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-25 * time.Hour),
+			},
+			deleteErr: errors.New("DependencyViolation"),
+			wantDeletedStacks: []orphanedStack{
+				{stackKey: svcStack, resourceARNs: []string{svcLBARN, svcTGARN}},
+			},
+			wantOrphanedSince: map[stackKey]time.Time{
+				svcStack: now.Add(-25 * time.Hour),
+			},
+			wantErr: "DependencyViolation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rgtClient := services.NewMockRGT(ctrl)
+			rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), &rgtsdk.GetResourcesInput{
+				TagFilters: []rgttypes.TagFilter{
+					{
+						Key:    awssdk.String("elbv2.k8s.aws/cluster"),
+						Values: []string{"cluster"},
+					},
+				},
+				ResourceTypeFilters: elbv2StackResourceTypes,
+			}).Return(rgtResources, nil)
+
+			var deletedStacks []orphanedStack
+			deleter := func(_ context.Context, stack orphanedStack) error {
+				deletedStacks = append(deletedStacks, stack)
+				return tt.deleteErr
+			}
+			orphanedSince := make(map[stackKey]time.Time)
+			for k, v := range tt.orphanedSince {
+				orphanedSince[k] = v
+			}
+			c := &orphanedResourceCollector{
+				rgtClient: rgtClient,
+				ownerResolver: &fakeOwnerResolver{
+					presentOwners: tt.presentOwners,
+					errOwners:     tt.errOwners,
+				},
+				stackDeleters: map[StackKind]stackDeleter{
+					StackKindIngress: deleter,
+					StackKindService: deleter,
+				},
+				clusterName:        "cluster",
+				interval:           time.Hour,
+				quarantinePeriod:   24 * time.Hour,
+				dryRun:             tt.dryRun,
+				gcMetricsCollector: lbcmetrics.NewOrphanedResourceMetricsCollector(nil),
+				logger:             log.Log,
+				clock:              func() time.Time { return now },
+				orphanedSince:      orphanedSince,
+			}
+			err := c.Collect(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeletedStacks, deletedStacks)
+			assert.Equal(t, tt.wantOrphanedSince, c.orphanedSince)
+		})
+	}
+}
+
+func Test_resolveStackKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   map[string]string
+		want   stackKey
+		wantOK bool
+	}{
+		{
+			name: "explicit ingress group",
+			tags: map[string]string{
+				"elbv2.k8s.aws/cluster": "cluster",
+				"ingress.k8s.aws/stack": "awesome-group",
+			},
+			want:   stackKey{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}},
+			wantOK: true,
+		},
+		{
+			name: "nlb gateway",
+			tags: map[string]string{
+				"elbv2.k8s.aws/cluster":     "cluster",
+				"gateway.k8s.aws.nlb/stack": "ns/gw",
+			},
+			want:   stackKey{kind: StackKindNLBGateway, stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			wantOK: true,
+		},
+		{
+			name: "globalaccelerator",
+			tags: map[string]string{
+				"elbv2.k8s.aws/cluster": "cluster",
+				"aga.k8s.aws/stack":     "ns/ga",
+			},
+			want:   stackKey{kind: StackKindGlobalAccelerator, stackID: core.StackID{Namespace: "ns", Name: "ga"}},
+			wantOK: true,
+		},
+		{
+			name: "resource without stack tag",
+			tags: map[string]string{
+				"elbv2.k8s.aws/cluster":  "cluster",
+				"elbv2.k8s.aws/resource": "backend-sg",
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveStackKey(tt.tags)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_resourceTypeFromARN(t *testing.T) {
+	tests := []struct {
+		name        string
+		resourceARN string
+		want        string
+	}{
+		{
+			name:        "load balancer",
+			resourceARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/abc",
+			want:        services.ResourceTypeELBLoadBalancer,
+		},
+		{
+			name:        "target group",
+			resourceARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/abc",
+			want:        services.ResourceTypeELBTargetGroup,
+		},
+		{
+			name:        "security group",
+			resourceARN: "arn:aws:ec2:us-west-2:123456789012:security-group/sg-abc",
+			want:        services.ResourceTypeEC2SecurityGroup,
+		},
+		{
+			name:        "accelerator",
+			resourceARN: "arn:aws:globalaccelerator::123456789012:accelerator/abc",
+			want:        services.ResourceTypeGlobalAccelerator,
+		},
+		{
+			name:        "invalid arn",
+			resourceARN: "sg-abc",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceTypeFromARN(tt.resourceARN))
+		})
+	}
+}
+
+func Test_orphanedResourceCollector_Collect_globalAccelerators(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cloud := fake.NewCloud("us-east-1", "123456789012", "vpc-0123456789abcdef0")
+	createAccelerator := func(region string) string {
+		resp, err := cloud.GlobalAccelerator().CreateAcceleratorWithContext(ctx, &gasdk.CreateAcceleratorInput{
+			Name: awssdk.String("k8s-awesome-accelerator"),
+			Tags: []gatypes.Tag{
+				{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster")},
+				{Key: awssdk.String("elbv2.k8s.aws/cluster-region"), Value: awssdk.String(region)},
+				{Key: awssdk.String("aga.k8s.aws/stack"), Value: awssdk.String("ns/awesome-accelerator")},
+			},
+		})
+		assert.NoError(t, err)
+		return awssdk.ToString(resp.Accelerator.AcceleratorArn)
+	}
+	acceleratorARN := createAccelerator("us-east-1")
+	// an accelerator of the same-named cluster in another region shares the same owner identity, but must never be collected.
+	createAccelerator("eu-west-1")
+
+	agaStack := stackKey{kind: StackKindGlobalAccelerator, stackID: core.StackID{Namespace: "ns", Name: "awesome-accelerator"}}
+	var deletedStacks []orphanedStack
+	c := &orphanedResourceCollector{
+		rgtClient:     cloud.RGT(),
+		agaRGTClient:  cloud.GlobalAcceleratorRGT(),
+		region:        cloud.Region(),
+		ownerResolver: &fakeOwnerResolver{},
+		stackDeleters: map[StackKind]stackDeleter{
+			StackKindGlobalAccelerator: func(_ context.Context, stack orphanedStack) error {
+				deletedStacks = append(deletedStacks, stack)
+				return nil
+			},
+		},
+		clusterName:        "cluster",
+		agaEnabled:         true,
+		interval:           time.Hour,
+		quarantinePeriod:   24 * time.Hour,
+		gcMetricsCollector: lbcmetrics.NewOrphanedResourceMetricsCollector(nil),
+		logger:             log.Log,
+		clock:              func() time.Time { return now },
+		orphanedSince: map[stackKey]time.Time{
+			agaStack: now.Add(-25 * time.Hour),
+		},
+	}
+	assert.NoError(t, c.Collect(ctx))
+	assert.Equal(t, []orphanedStack{
+		{stackKey: agaStack, resourceARNs: []string{acceleratorARN}},
+	}, deletedStacks)
+}
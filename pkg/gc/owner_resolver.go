@@ -0,0 +1,124 @@
+package gc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	serviceAnnotationPrefix = "service.beta.kubernetes.io"
+)
+
+// OwnerResolver resolves whether the Kubernetes object owning a stack of AWS resources still exists.
+type OwnerResolver interface {
+	// IsOwnerPresent checks whether the owner of the stack identified by kind and stackID exists.
+	// An error is returned when existence cannot be determined, callers must treat the stack as owned in that case.
+	IsOwnerPresent(ctx context.Context, kind StackKind, stackID core.StackID) (bool, error)
+}
+
+// NewDefaultOwnerResolver constructs new defaultOwnerResolver.
+// k8sReader should read from the API server directly so that recently created owners are never missed.
+func NewDefaultOwnerResolver(k8sReader client.Reader) *defaultOwnerResolver {
+	return &defaultOwnerResolver{
+		k8sReader: k8sReader,
+	}
+}
+
+var _ OwnerResolver = &defaultOwnerResolver{}
+
+// defaultOwnerResolver is the default implementation for OwnerResolver.
+type defaultOwnerResolver struct {
+	k8sReader client.Reader
+}
+
+func (r *defaultOwnerResolver) IsOwnerPresent(ctx context.Context, kind StackKind, stackID core.StackID) (bool, error) {
+	switch kind {
+	case StackKindIngress:
+		if stackID.Namespace == "" {
+			return r.isIngressGroupPresent(ctx, stackID.Name)
+		}
+		return r.isObjectPresent(ctx, types.NamespacedName(stackID), &networking.Ingress{})
+	case StackKindService:
+		if stackID.Namespace == "" {
+			return r.isServiceGroupPresent(ctx, stackID.Name)
+		}
+		return r.isObjectPresent(ctx, types.NamespacedName(stackID), &corev1.Service{})
+	case StackKindNLBGateway, StackKindALBGateway:
+		return r.isObjectPresent(ctx, types.NamespacedName(stackID), &gwv1.Gateway{})
+	case StackKindGlobalAccelerator:
+		return r.isObjectPresent(ctx, types.NamespacedName(stackID), &agaapi.GlobalAccelerator{})
+	default:
+		return false, errors.Errorf("unsupported stack kind: %v", kind)
+	}
+}
+
+func (r *defaultOwnerResolver) isObjectPresent(ctx context.Context, key types.NamespacedName, obj client.Object) (bool, error) {
+	if err := r.k8sReader.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// isIngressGroupPresent checks whether an explicit IngressGroup still has any member or any IngressClassParams referencing it.
+// Ingresses that are still deleting hold the group finalizer, so they are considered members as well.
+func (r *defaultOwnerResolver) isIngressGroupPresent(ctx context.Context, groupName string) (bool, error) {
+	groupFinalizer := shared_constants.ExplicitGroupFinalizerPrefix + groupName
+	groupNameAnnotation := annotations.AnnotationPrefixIngress + "/" + annotations.IngressSuffixGroupName
+
+	ingList := &networking.IngressList{}
+	if err := r.k8sReader.List(ctx, ingList); err != nil {
+		return false, err
+	}
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if k8s.HasFinalizer(ing, groupFinalizer) || ing.Annotations[groupNameAnnotation] == groupName {
+			return true, nil
+		}
+	}
+
+	ingClassParamsList := &elbv2api.IngressClassParamsList{}
+	if err := r.k8sReader.List(ctx, ingClassParamsList); err != nil {
+		return false, err
+	}
+	for _, ingClassParams := range ingClassParamsList.Items {
+		if ingClassParams.Spec.Group != nil && ingClassParams.Spec.Group.Name == groupName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isServiceGroupPresent checks whether a ServiceGroup still has any member.
+// Services that are still deleting hold the group finalizer, so they are considered members as well.
+func (r *defaultOwnerResolver) isServiceGroupPresent(ctx context.Context, groupName string) (bool, error) {
+	groupFinalizer := shared_constants.ServiceGroupFinalizerPrefix + groupName
+	groupNameAnnotation := serviceAnnotationPrefix + "/" + annotations.SvcLBSuffixGroupName
+
+	svcList := &corev1.ServiceList{}
+	if err := r.k8sReader.List(ctx, svcList); err != nil {
+		return false, err
+	}
+	for i := range svcList.Items {
+		svc := &svcList.Items[i]
+		if k8s.HasFinalizer(svc, groupFinalizer) || svc.Annotations[groupNameAnnotation] == groupName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
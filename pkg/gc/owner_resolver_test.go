@@ -0,0 +1,182 @@
+package gc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_defaultOwnerResolver_IsOwnerPresent(t *testing.T) {
+	type args struct {
+		kind    StackKind
+		stackID core.StackID
+	}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		args    args
+		want    bool
+		wantErr string
+	}{
+		{
+			name: "implicit ingress group exists",
+			objects: []client.Object{
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}},
+			},
+			args: args{kind: StackKindIngress, stackID: core.StackID{Namespace: "ns", Name: "ing"}},
+			want: true,
+		},
+		{
+			name: "implicit ingress group doesn't exist",
+			args: args{kind: StackKindIngress, stackID: core.StackID{Namespace: "ns", Name: "ing"}},
+			want: false,
+		},
+		{
+			name: "explicit ingress group referenced by finalizer",
+			objects: []client.Object{
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "ns",
+					Name:       "ing",
+					Finalizers: []string{"group.ingress.k8s.aws/awesome-group"},
+				}},
+			},
+			args: args{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}},
+			want: true,
+		},
+		{
+			name: "explicit ingress group referenced by annotation",
+			objects: []client.Object{
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Name:        "ing",
+					Annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "awesome-group"},
+				}},
+			},
+			args: args{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}},
+			want: true,
+		},
+		{
+			name: "explicit ingress group referenced by IngressClassParams",
+			objects: []client.Object{
+				&elbv2api.IngressClassParams{
+					ObjectMeta: metav1.ObjectMeta{Name: "params"},
+					Spec: elbv2api.IngressClassParamsSpec{
+						Group: &elbv2api.IngressGroup{Name: "awesome-group"},
+					},
+				},
+			},
+			args: args{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}},
+			want: true,
+		},
+		{
+			name: "explicit ingress group without members",
+			objects: []client.Object{
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Name:        "ing",
+					Annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "other-group"},
+				}},
+			},
+			args: args{kind: StackKindIngress, stackID: core.StackID{Name: "awesome-group"}},
+			want: false,
+		},
+		{
+			name: "service exists",
+			objects: []client.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}},
+			},
+			args: args{kind: StackKindService, stackID: core.StackID{Namespace: "ns", Name: "svc"}},
+			want: true,
+		},
+		{
+			name: "service doesn't exist",
+			args: args{kind: StackKindService, stackID: core.StackID{Namespace: "ns", Name: "svc"}},
+			want: false,
+		},
+		{
+			name: "service group referenced by finalizer",
+			objects: []client.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "ns",
+					Name:       "svc",
+					Finalizers: []string{"group.service.k8s.aws/awesome-group"},
+				}},
+			},
+			args: args{kind: StackKindService, stackID: core.StackID{Name: "awesome-group"}},
+			want: true,
+		},
+		{
+			name: "service group referenced by annotation",
+			objects: []client.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Name:        "svc",
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group"},
+				}},
+			},
+			args: args{kind: StackKindService, stackID: core.StackID{Name: "awesome-group"}},
+			want: true,
+		},
+		{
+			name: "service group without members",
+			objects: []client.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Name:        "svc",
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "other-group"},
+				}},
+			},
+			args: args{kind: StackKindService, stackID: core.StackID{Name: "awesome-group"}},
+			want: false,
+		},
+		{
+			name: "gateway exists",
+			objects: []client.Object{
+				&gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw"}},
+			},
+			args: args{kind: StackKindALBGateway, stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			want: true,
+		},
+		{
+			name: "globalaccelerator doesn't exist",
+			args: args{kind: StackKindGlobalAccelerator, stackID: core.StackID{Namespace: "ns", Name: "ga"}},
+			want: false,
+		},
+		{
+			name:    "unsupported stack kind",
+			args:    args{kind: StackKind("unknown"), stackID: core.StackID{Namespace: "ns", Name: "name"}},
+			wantErr: "unsupported stack kind: unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			agaapi.AddToScheme(k8sSchema)
+			gwv1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(tt.objects...).Build()
+
+			r := NewDefaultOwnerResolver(k8sClient)
+			got, err := r.IsOwnerPresent(context.Background(), tt.args.kind, tt.args.stackID)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -64,4 +64,19 @@ type Group struct {
 
 	// InactiveMembers are Ingresses that no longer belong to this group, but still hold the finalizers.
 	InactiveMembers []*networking.Ingress
+
+	// RejectedMembers are Ingresses that belong to this group, but are rejected by the group ownership rules.
+	// Their rules are not added to the LoadBalancer, and they keep the finalizers they already hold.
+	RejectedMembers []RejectedIngress
+}
+
+// RejectedIngress is an Ingress rejected from its IngressGroup.
+type RejectedIngress struct {
+	Ing *networking.Ingress
+
+	// Reason is a CamelCase reason for the rejection.
+	Reason string
+
+	// Message is a human-readable explanation of the rejection.
+	Message string
 }
@@ -102,11 +102,16 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group,
 	if err != nil {
 		return Group{}, err
 	}
+	acceptedMembers, rejectedMembers, err := m.enforceGroupOwnership(ctx, groupID, sortedMembers)
+	if err != nil {
+		return Group{}, err
+	}
 
 	return Group{
 		ID:              groupID,
-		Members:         sortedMembers,
+		Members:         acceptedMembers,
 		InactiveMembers: inactiveMembers,
+		RejectedMembers: rejectedMembers,
 	}, nil
 }
 
@@ -0,0 +1,396 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+const (
+	GroupMemberRejectedReasonNamespaceNotAllowed         = "NamespaceNotAllowed"
+	GroupMemberRejectedReasonHostNotAllowed              = "HostNotAllowed"
+	GroupMemberRejectedReasonPathNotAllowed              = "PathNotAllowed"
+	GroupMemberRejectedReasonDefaultBackendNotAllowed    = "DefaultBackendNotAllowed"
+	GroupMemberRejectedReasonGroupOrderNotAllowed        = "GroupOrderNotAllowed"
+	GroupMemberRejectedReasonRuleQuotaExceeded           = "RuleQuotaExceeded"
+	GroupMemberRejectedReasonHostOwnedByAnotherNamespace = "HostOwnedByAnotherNamespace"
+	GroupMemberRejectedReasonGroupOwnershipNotApplied    = "GroupOwnershipNotApplied"
+)
+
+// groupOwnershipState tracks the hosts and rules claimed by the accepted members of an IngressGroup.
+type groupOwnershipState struct {
+	// hostOwners maps each claimed host to the namespace that claimed it first.
+	hostOwners map[string]string
+	// ruleCounts maps each namespace to the number of rules its accepted members claimed.
+	ruleCounts map[string]int32
+	// namespaceLabels caches the labels of namespaces looked up for namespaceSelectors.
+	namespaceLabels map[string]labels.Set
+}
+
+// enforceGroupOwnership splits the members of an explicit IngressGroup into accepted and rejected members,
+// according to the GroupOwnership of the IngressGroup, see resolveGroupOwnershipParams.
+// Members are evaluated in creation order, so that older members keep their hosts and rule quota when newer members conflict.
+// The relative order of accepted members is preserved.
+func (m *defaultGroupLoader) enforceGroupOwnership(ctx context.Context, groupID GroupID, members []ClassifiedIngress) ([]ClassifiedIngress, []RejectedIngress, error) {
+	if !groupID.IsExplicit() || len(members) == 0 {
+		return members, nil, nil
+	}
+	ownershipParams, err := m.resolveGroupOwnershipParams(ctx, groupID, members)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ownershipParams == nil {
+		return members, nil, nil
+	}
+
+	membersByAge := make([]ClassifiedIngress, len(members))
+	copy(membersByAge, members)
+	sort.SliceStable(membersByAge, func(i, j int) bool {
+		tsI := membersByAge[i].Ing.CreationTimestamp
+		tsJ := membersByAge[j].Ing.CreationTimestamp
+		if !tsI.Equal(&tsJ) {
+			return tsI.Before(&tsJ)
+		}
+		return k8s.NamespacedName(membersByAge[i].Ing).String() < k8s.NamespacedName(membersByAge[j].Ing).String()
+	})
+
+	state := &groupOwnershipState{
+		hostOwners:      make(map[string]string),
+		ruleCounts:      make(map[string]int32),
+		namespaceLabels: make(map[string]labels.Set),
+	}
+	rejectedByKey := make(map[types.NamespacedName]RejectedIngress)
+	for _, member := range membersByAge {
+		rejected, err := m.checkGroupOwnership(ctx, member, ownershipParams, state)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Ingress: %v", k8s.NamespacedName(member.Ing))
+		}
+		if rejected != nil {
+			rejectedByKey[k8s.NamespacedName(member.Ing)] = *rejected
+		}
+	}
+	if len(rejectedByKey) == 0 {
+		return members, nil, nil
+	}
+
+	var acceptedMembers []ClassifiedIngress
+	var rejectedMembers []RejectedIngress
+	for _, member := range members {
+		if rejected, ok := rejectedByKey[k8s.NamespacedName(member.Ing)]; ok {
+			rejectedMembers = append(rejectedMembers, rejected)
+			continue
+		}
+		acceptedMembers = append(acceptedMembers, member)
+	}
+	return acceptedMembers, rejectedMembers, nil
+}
+
+// resolveGroupOwnershipParams returns the IngressClassParams defining the GroupOwnership of an explicit IngressGroup, or nil if
+// the IngressGroup has no GroupOwnership. IngressClassParams bound to the IngressGroup via spec.group are preferred over the ones
+// of members that join the IngressGroup by annotation, and ties are broken by name, so that all members are checked against the
+// same rules regardless of their own IngressClass.
+func (m *defaultGroupLoader) resolveGroupOwnershipParams(ctx context.Context, groupID GroupID, members []ClassifiedIngress) (*elbv2api.IngressClassParams, error) {
+	var candidates []*elbv2api.IngressClassParams
+	for _, member := range members {
+		if params := member.IngClassConfig.IngClassParams; params != nil && params.Spec.GroupOwnership != nil {
+			candidates = append(candidates, params)
+		}
+	}
+	paramsList := &elbv2api.IngressClassParamsList{}
+	if err := m.client.List(ctx, paramsList); err != nil {
+		return nil, errors.Wrap(err, "failed to list IngressClassParams")
+	}
+	for i := range paramsList.Items {
+		params := &paramsList.Items[i]
+		if params.Spec.GroupOwnership != nil && isIngressClassParamsBoundToGroup(params, groupID) {
+			candidates = append(candidates, params)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		boundI, boundJ := isIngressClassParamsBoundToGroup(candidates[i], groupID), isIngressClassParamsBoundToGroup(candidates[j], groupID)
+		if boundI != boundJ {
+			return boundI
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0], nil
+}
+
+func isIngressClassParamsBoundToGroup(params *elbv2api.IngressClassParams, groupID GroupID) bool {
+	return params.Spec.Group != nil && params.Spec.Group.Name == groupID.Name
+}
+
+// checkGroupOwnership checks a single member against the GroupOwnership of ownershipParams and the claims of the members accepted before it.
+// Members whose own IngressClassParams don't carry the GroupOwnership are rejected, so that they can't bypass it by using another IngressClass.
+// If accepted, the hosts and rules of the member are claimed in state, otherwise the rejection is returned.
+func (m *defaultGroupLoader) checkGroupOwnership(ctx context.Context, member ClassifiedIngress, ownershipParams *elbv2api.IngressClassParams, state *groupOwnershipState) (*RejectedIngress, error) {
+	ing := member.Ing
+	hosts, paths := ingressHostsAndPaths(ing)
+	ownership := ownershipParams.Spec.GroupOwnership
+	conditionValues, err := m.ingressConditionHostsAndPaths(ing)
+	if err != nil {
+		return nil, err
+	}
+	hosts = appendDistinct(hosts, conditionValues.hosts...)
+
+	reject := func(reason string, format string, args ...interface{}) (*RejectedIngress, error) {
+		return &RejectedIngress{
+			Ing:     ing,
+			Reason:  reason,
+			Message: fmt.Sprintf(format, args...),
+		}, nil
+	}
+
+	if params := member.IngClassConfig.IngClassParams; params == nil || params.Name != ownershipParams.Name {
+		return reject(GroupMemberRejectedReasonGroupOwnershipNotApplied,
+			"IngressClass of Ingress doesn't use IngressClassParams %v, which defines the groupOwnership of IngressGroup", ownershipParams.Name)
+	}
+
+	if len(ownership.NamespaceRules) != 0 {
+		rule, err := m.matchGroupNamespaceRule(ctx, ownership.NamespaceRules, ing.Namespace, state)
+		if err != nil {
+			return nil, err
+		}
+		if rule == nil {
+			return reject(GroupMemberRejectedReasonNamespaceNotAllowed,
+				"namespace %v is not allowed to join IngressGroup by IngressClassParams %v", ing.Namespace, ownershipParams.Name)
+		}
+		if ing.Spec.DefaultBackend != nil && (len(rule.AllowedHosts) != 0 || len(rule.AllowedPathPrefixes) != 0) {
+			return reject(GroupMemberRejectedReasonDefaultBackendNotAllowed,
+				"defaultBackend is not allowed for namespace %v, as it serves requests of any host and path", ing.Namespace)
+		}
+		if len(rule.AllowedHosts) != 0 {
+			if len(conditionValues.hostRegexes) != 0 {
+				return reject(GroupMemberRejectedReasonHostNotAllowed,
+					"host regex %q is not allowed for namespace %v, allowed hosts: %v", conditionValues.hostRegexes[0], ing.Namespace, strings.Join(rule.AllowedHosts, ","))
+			}
+			for _, host := range hosts {
+				if !isGroupHostAllowed(host, rule.AllowedHosts) {
+					return reject(GroupMemberRejectedReasonHostNotAllowed,
+						"host %q is not allowed for namespace %v, allowed hosts: %v", host, ing.Namespace, strings.Join(rule.AllowedHosts, ","))
+				}
+			}
+		}
+		if len(rule.AllowedPathPrefixes) != 0 {
+			if len(conditionValues.pathRegexes) != 0 {
+				return reject(GroupMemberRejectedReasonPathNotAllowed,
+					"path regex %q is not allowed for namespace %v, allowed path prefixes: %v", conditionValues.pathRegexes[0], ing.Namespace, strings.Join(rule.AllowedPathPrefixes, ","))
+			}
+			for _, path := range append(slices.Clone(paths), conditionValues.paths...) {
+				if !isGroupPathAllowed(path, rule.AllowedPathPrefixes) {
+					return reject(GroupMemberRejectedReasonPathNotAllowed,
+						"path %q is not allowed for namespace %v, allowed path prefixes: %v", path, ing.Namespace, strings.Join(rule.AllowedPathPrefixes, ","))
+				}
+			}
+		}
+		if rule.AllowedGroupOrder != nil {
+			order := defaultGroupOrder
+			if _, err := m.annotationParser.ParseInt32Annotation(annotations.IngressSuffixGroupOrder, &order, ing.Annotations); err != nil {
+				return nil, errors.Wrap(err, "failed to load Ingress group order")
+			}
+			if order < rule.AllowedGroupOrder.Min || order > rule.AllowedGroupOrder.Max {
+				return reject(GroupMemberRejectedReasonGroupOrderNotAllowed,
+					"group order %v is not allowed for namespace %v, allowed group order: [%v:%v]", order, ing.Namespace, rule.AllowedGroupOrder.Min, rule.AllowedGroupOrder.Max)
+			}
+		}
+		if rule.MaxRules != nil {
+			ruleCount := state.ruleCounts[ing.Namespace] + int32(len(paths))
+			if ruleCount > *rule.MaxRules {
+				return reject(GroupMemberRejectedReasonRuleQuotaExceeded,
+					"namespace %v would use %v rules, exceeding its quota of %v rules", ing.Namespace, ruleCount, *rule.MaxRules)
+			}
+		}
+	}
+
+	if ownership.HostOwnership != nil && *ownership.HostOwnership == elbv2api.HostOwnershipFirstCome {
+		for _, host := range hosts {
+			if owner, ok := state.hostOwners[host]; ok && owner != ing.Namespace {
+				return reject(GroupMemberRejectedReasonHostOwnedByAnotherNamespace,
+					"host %q is owned by namespace %v", host, owner)
+			}
+		}
+	}
+
+	state.claim(ing.Namespace, hosts, int32(len(paths)))
+	return nil, nil
+}
+
+// matchGroupNamespaceRule returns the first rule that applies to namespace, or nil if no rule applies.
+func (m *defaultGroupLoader) matchGroupNamespaceRule(ctx context.Context, rules []elbv2api.IngressGroupNamespaceRule, namespace string, state *groupOwnershipState) (*elbv2api.IngressGroupNamespaceRule, error) {
+	for i := range rules {
+		rule := &rules[i]
+		for _, ruleNamespace := range rule.Namespaces {
+			if ruleNamespace == namespace {
+				return rule, nil
+			}
+		}
+		if rule.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(rule.NamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid namespaceSelector in groupOwnership")
+		}
+		nsLabels, err := m.loadNamespaceLabels(ctx, namespace, state)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(nsLabels) {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *defaultGroupLoader) loadNamespaceLabels(ctx context.Context, namespace string, state *groupOwnershipState) (labels.Set, error) {
+	if nsLabels, ok := state.namespaceLabels[namespace]; ok {
+		return nsLabels, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := m.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to load namespace %v", namespace)
+		}
+	}
+	nsLabels := labels.Set(ns.Labels)
+	state.namespaceLabels[namespace] = nsLabels
+	return nsLabels, nil
+}
+
+func (s *groupOwnershipState) claim(namespace string, hosts []string, ruleCount int32) {
+	for _, host := range hosts {
+		if _, ok := s.hostOwners[host]; !ok {
+			s.hostOwners[host] = namespace
+		}
+	}
+	s.ruleCounts[namespace] += ruleCount
+}
+
+// ingressHostsAndPaths returns the distinct hosts and all paths of the rules of an Ingress.
+// Rules without host are represented by an empty host, and paths without value are represented by "/".
+func ingressHostsAndPaths(ing *networking.Ingress) ([]string, []string) {
+	var hosts []string
+	var paths []string
+	seenHosts := make(map[string]struct{})
+	for _, rule := range ing.Spec.Rules {
+		if _, ok := seenHosts[rule.Host]; !ok {
+			seenHosts[rule.Host] = struct{}{}
+			hosts = append(hosts, rule.Host)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Path == "" {
+				paths = append(paths, "/")
+			} else {
+				paths = append(paths, path.Path)
+			}
+		}
+	}
+	return hosts, paths
+}
+
+// ingressConditionValues are the host-header and path-pattern values of the conditions annotations of an Ingress.
+type ingressConditionValues struct {
+	hosts       []string
+	hostRegexes []string
+	paths       []string
+	pathRegexes []string
+}
+
+// ingressConditionHostsAndPaths returns the host-header and path-pattern values of the `conditions.<serviceName>` annotations
+// of the backends of an Ingress. They're added to the host and path of the rules, so they're subject to group ownership too.
+func (m *defaultGroupLoader) ingressConditionHostsAndPaths(ing *networking.Ingress) (ingressConditionValues, error) {
+	var values ingressConditionValues
+	seenServiceNames := make(map[string]struct{})
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			svcName := path.Backend.Service.Name
+			if _, ok := seenServiceNames[svcName]; ok {
+				continue
+			}
+			seenServiceNames[svcName] = struct{}{}
+			var conditions []RuleCondition
+			if _, err := m.annotationParser.ParseJSONAnnotation(fmt.Sprintf("conditions.%v", svcName), &conditions, ing.Annotations); err != nil {
+				return ingressConditionValues{}, errors.Wrapf(err, "failed to load conditions of service %v", svcName)
+			}
+			for _, condition := range conditions {
+				switch {
+				case condition.Field == RuleConditionFieldHostHeader && condition.HostHeaderConfig != nil:
+					values.hosts = append(values.hosts, condition.HostHeaderConfig.Values...)
+					values.hostRegexes = append(values.hostRegexes, condition.HostHeaderConfig.RegexValues...)
+				case condition.Field == RuleConditionFieldPathPattern && condition.PathPatternConfig != nil:
+					values.paths = append(values.paths, condition.PathPatternConfig.Values...)
+					values.pathRegexes = append(values.pathRegexes, condition.PathPatternConfig.RegexValues...)
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+func appendDistinct(values []string, newValues ...string) []string {
+	for _, value := range newValues {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// isGroupHostAllowed checks whether host matches any of the allowed hosts.
+// A wildcard like `*.example.com` matches a single DNS label in place of the `*`, following Ingress host semantics.
+func isGroupHostAllowed(host string, allowedHosts []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, allowedHost := range allowedHosts {
+		if host == allowedHost {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowedHost, "*"); ok {
+			label, found := strings.CutSuffix(host, suffix)
+			if found && label != "" && !strings.Contains(label, ".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGroupPathAllowed checks whether path starts with any of the allowed prefixes at a path segment boundary,
+// so that prefix `/team-a` allows `/team-a` and `/team-a/api` but not `/team-ab`.
+func isGroupPathAllowed(path string, allowedPathPrefixes []string) bool {
+	for _, prefix := range allowedPathPrefixes {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		if len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,412 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultGroupLoader_enforceGroupOwnership(t *testing.T) {
+	baseTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstCome := elbv2api.HostOwnershipFirstCome
+	shared := elbv2api.HostOwnershipShared
+
+	newParams := func(ownership *elbv2api.IngressGroupOwnership) *elbv2api.IngressClassParams {
+		return &elbv2api.IngressClassParams{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-params"},
+			Spec: elbv2api.IngressClassParamsSpec{
+				Group:          &elbv2api.IngressGroup{Name: "shared"},
+				GroupOwnership: ownership,
+			},
+		}
+	}
+	newMember := func(namespace string, name string, age int, order string, params *elbv2api.IngressClassParams, hostPaths map[string][]string) ClassifiedIngress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(baseTime.Add(time.Duration(age) * time.Minute)),
+			},
+		}
+		if order != "" {
+			ing.Annotations = map[string]string{"alb.ingress.kubernetes.io/group.order": order}
+		}
+		for host, paths := range hostPaths {
+			rule := networking.IngressRule{Host: host, IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{}}}
+			for _, path := range paths {
+				rule.HTTP.Paths = append(rule.HTTP.Paths, networking.HTTPIngressPath{Path: path})
+			}
+			ing.Spec.Rules = append(ing.Spec.Rules, rule)
+		}
+		return ClassifiedIngress{
+			Ing:            ing,
+			IngClassConfig: ClassConfiguration{IngClassParams: params},
+		}
+	}
+	withBackend := func(member ClassifiedIngress, svcName string, conditions string) ClassifiedIngress {
+		ing := member.Ing
+		for i := range ing.Spec.Rules {
+			for j := range ing.Spec.Rules[i].HTTP.Paths {
+				ing.Spec.Rules[i].HTTP.Paths[j].Backend.Service = &networking.IngressServiceBackend{Name: svcName}
+			}
+		}
+		if conditions != "" {
+			if ing.Annotations == nil {
+				ing.Annotations = make(map[string]string)
+			}
+			ing.Annotations["alb.ingress.kubernetes.io/conditions."+svcName] = conditions
+		}
+		return member
+	}
+	withDefaultBackend := func(member ClassifiedIngress) ClassifiedIngress {
+		member.Ing.Spec.DefaultBackend = &networking.IngressBackend{Service: &networking.IngressServiceBackend{Name: "catch-all"}}
+		return member
+	}
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-c"}},
+	}
+
+	teamRules := newParams(&elbv2api.IngressGroupOwnership{
+		NamespaceRules: []elbv2api.IngressGroupNamespaceRule{
+			{
+				Namespaces:          []string{"team-a"},
+				AllowedHosts:        []string{"*.team-a.example.com", "team-a.example.com"},
+				AllowedPathPrefixes: []string{"/team-a"},
+				MaxRules:            awssdk.Int32(2),
+				AllowedGroupOrder:   &elbv2api.GroupOrderRange{Min: 10, Max: 20},
+			},
+			{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+		},
+	})
+	firstComeParams := newParams(&elbv2api.IngressGroupOwnership{HostOwnership: &firstCome})
+	sharedParams := newParams(&elbv2api.IngressGroupOwnership{HostOwnership: &shared})
+	teamRulesFirstComeParams := newParams(&elbv2api.IngressGroupOwnership{
+		NamespaceRules: teamRules.Spec.GroupOwnership.NamespaceRules,
+		HostOwnership:  &firstCome,
+	})
+	otherParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-params"},
+	}
+
+	type args struct {
+		groupID GroupID
+		members []ClassifiedIngress
+	}
+	tests := []struct {
+		name             string
+		ingClassParams   []*elbv2api.IngressClassParams
+		args             args
+		wantAccepted     []string
+		wantRejected     map[string]string
+		wantErrSubstring string
+	}{
+		{
+			name: "implicit groups are not subject to ownership rules",
+			args: args{
+				groupID: NewGroupIDForImplicitGroup(types.NamespacedName{Namespace: "team-c", Name: "ing"}),
+				members: []ClassifiedIngress{
+					newMember("team-c", "ing", 0, "", teamRules, map[string][]string{"app.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-c/ing"},
+		},
+		{
+			name: "members without group ownership are accepted",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-c", "ing", 0, "", newParams(nil), map[string][]string{"app.example.com": {"/"}}),
+					newMember("team-c", "ing-2", 1, "", nil, map[string][]string{"app.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-c/ing", "team-c/ing-2"},
+		},
+		{
+			name: "namespace rules by name and selector",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "ing", 0, "10", teamRules, map[string][]string{"web.team-a.example.com": {"/team-a", "/team-a/api"}}),
+					newMember("team-b", "ing", 1, "", teamRules, map[string][]string{"anything.example.com": {"/"}}),
+					newMember("team-c", "ing", 2, "", teamRules, map[string][]string{"team-c.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/ing", "team-b/ing"},
+			wantRejected: map[string]string{
+				"team-c/ing": GroupMemberRejectedReasonNamespaceNotAllowed,
+			},
+		},
+		{
+			name: "host, path and group order restrictions",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "bad-host", 0, "10", teamRules, map[string][]string{"a.b.team-a.example.com": {"/team-a"}}),
+					newMember("team-a", "no-host", 1, "10", teamRules, map[string][]string{"": {"/team-a"}}),
+					newMember("team-a", "bad-path", 2, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-ab"}}),
+					newMember("team-a", "bad-order", 3, "21", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}),
+					newMember("team-a", "default-order", 4, "", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}),
+				},
+			},
+			wantRejected: map[string]string{
+				"team-a/bad-host":      GroupMemberRejectedReasonHostNotAllowed,
+				"team-a/no-host":       GroupMemberRejectedReasonHostNotAllowed,
+				"team-a/bad-path":      GroupMemberRejectedReasonPathNotAllowed,
+				"team-a/bad-order":     GroupMemberRejectedReasonGroupOrderNotAllowed,
+				"team-a/default-order": GroupMemberRejectedReasonGroupOrderNotAllowed,
+			},
+		},
+		{
+			name: "default backend and conditions annotations restrictions",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					withDefaultBackend(newMember("team-a", "default-backend", 0, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}})),
+					withBackend(newMember("team-a", "condition-host", 1, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}), "svc",
+						`[{"field":"host-header","hostHeaderConfig":{"values":["victim.example.com"]}}]`),
+					withBackend(newMember("team-a", "condition-host-regex", 2, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}), "svc",
+						`[{"field":"host-header","hostHeaderConfig":{"regexValues":[".*"]}}]`),
+					withBackend(newMember("team-a", "condition-path", 3, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}), "svc",
+						`[{"field":"path-pattern","pathPatternConfig":{"values":["/admin/*"]}}]`),
+					withBackend(newMember("team-a", "condition-path-regex", 4, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}), "svc",
+						`[{"field":"path-pattern","pathPatternConfig":{"regexValues":["^/.*"]}}]`),
+					withBackend(newMember("team-a", "conditions-allowed", 5, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}), "svc",
+						`[{"field":"host-header","hostHeaderConfig":{"values":["api.team-a.example.com"]}},{"field":"path-pattern","pathPatternConfig":{"values":["/team-a/*"]}}]`),
+					withDefaultBackend(newMember("team-b", "default-backend", 6, "", teamRules, map[string][]string{"team-b.example.com": {"/"}})),
+				},
+			},
+			wantAccepted: []string{"team-a/conditions-allowed", "team-b/default-backend"},
+			wantRejected: map[string]string{
+				"team-a/default-backend":      GroupMemberRejectedReasonDefaultBackendNotAllowed,
+				"team-a/condition-host":       GroupMemberRejectedReasonHostNotAllowed,
+				"team-a/condition-host-regex": GroupMemberRejectedReasonHostNotAllowed,
+				"team-a/condition-path":       GroupMemberRejectedReasonPathNotAllowed,
+				"team-a/condition-path-regex": GroupMemberRejectedReasonPathNotAllowed,
+			},
+		},
+		{
+			name: "condition hosts are claimed with first come host ownership",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					withBackend(newMember("team-b", "ing", 0, "", firstComeParams, map[string][]string{"app.example.com": {"/"}}), "svc",
+						`[{"field":"host-header","hostHeaderConfig":{"values":["admin.example.com"]}}]`),
+					newMember("team-c", "ing", 1, "", firstComeParams, map[string][]string{"admin.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-b/ing"},
+			wantRejected: map[string]string{
+				"team-c/ing": GroupMemberRejectedReasonHostOwnedByAnotherNamespace,
+			},
+		},
+		{
+			name: "rule quota is consumed by older members first",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "newer", 1, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a/x"}}),
+					newMember("team-a", "older", 0, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a", "/team-a/y"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/older"},
+			wantRejected: map[string]string{
+				"team-a/newer": GroupMemberRejectedReasonRuleQuotaExceeded,
+			},
+		},
+		{
+			name: "first come host ownership",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-b", "hijack", 1, "", firstComeParams, map[string][]string{"app.example.com": {"/"}}),
+					newMember("team-a", "owner", 0, "", firstComeParams, map[string][]string{"app.example.com": {"/"}}),
+					newMember("team-a", "same-namespace", 2, "", firstComeParams, map[string][]string{"app.example.com": {"/api"}}),
+					newMember("team-c", "other-host", 3, "", firstComeParams, map[string][]string{"other.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/owner", "team-a/same-namespace", "team-c/other-host"},
+			wantRejected: map[string]string{
+				"team-b/hijack": GroupMemberRejectedReasonHostOwnedByAnotherNamespace,
+			},
+		},
+		{
+			name: "shared host ownership",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "owner", 0, "", sharedParams, map[string][]string{"app.example.com": {"/"}}),
+					newMember("team-b", "other", 1, "", sharedParams, map[string][]string{"app.example.com": {"/b"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/owner", "team-b/other"},
+		},
+		{
+			name: "rejected members don't claim hosts",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-c", "rejected", 0, "", teamRulesFirstComeParams, map[string][]string{"app.example.com": {"/"}}),
+					newMember("team-b", "ing", 1, "", teamRulesFirstComeParams, map[string][]string{"app.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-b/ing"},
+			wantRejected: map[string]string{
+				"team-c/rejected": GroupMemberRejectedReasonNamespaceNotAllowed,
+			},
+		},
+		{
+			name: "members of another IngressClass don't bypass group ownership",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "ing", 0, "10", teamRules, map[string][]string{"team-a.example.com": {"/team-a"}}),
+					newMember("team-c", "no-params", 1, "", nil, map[string][]string{"team-c.example.com": {"/"}}),
+					newMember("team-c", "other-params", 2, "", otherParams, map[string][]string{"team-c.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/ing"},
+			wantRejected: map[string]string{
+				"team-c/no-params":    GroupMemberRejectedReasonGroupOwnershipNotApplied,
+				"team-c/other-params": GroupMemberRejectedReasonGroupOwnershipNotApplied,
+			},
+		},
+		{
+			name:           "group ownership of IngressClassParams bound to the group applies without members of its IngressClass",
+			ingClassParams: []*elbv2api.IngressClassParams{firstComeParams},
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "ing", 0, "", otherParams, map[string][]string{"app.example.com": {"/"}}),
+				},
+			},
+			wantRejected: map[string]string{
+				"team-a/ing": GroupMemberRejectedReasonGroupOwnershipNotApplied,
+			},
+		},
+		{
+			name:           "IngressClassParams bound to another group doesn't apply",
+			ingClassParams: []*elbv2api.IngressClassParams{firstComeParams},
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("another"),
+				members: []ClassifiedIngress{
+					newMember("team-a", "ing", 0, "", otherParams, map[string][]string{"app.example.com": {"/"}}),
+				},
+			},
+			wantAccepted: []string{"team-a/ing"},
+		},
+		{
+			name: "invalid namespace selector",
+			args: args{
+				groupID: NewGroupIDForExplicitGroup("shared"),
+				members: []ClassifiedIngress{
+					newMember("team-c", "ing", 0, "", newParams(&elbv2api.IngressGroupOwnership{
+						NamespaceRules: []elbv2api.IngressGroupNamespaceRule{
+							{
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+								},
+							},
+						},
+					}), nil),
+				},
+			},
+			wantErrSubstring: "invalid namespaceSelector in groupOwnership",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			for _, ns := range namespaces {
+				assert.NoError(t, k8sClient.Create(context.Background(), ns.DeepCopy()))
+			}
+			for _, params := range tt.ingClassParams {
+				assert.NoError(t, k8sClient.Create(context.Background(), params.DeepCopy()))
+			}
+			m := &defaultGroupLoader{
+				client:           k8sClient,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			accepted, rejected, err := m.enforceGroupOwnership(context.Background(), tt.args.groupID, tt.args.members)
+			if tt.wantErrSubstring != "" {
+				assert.ErrorContains(t, err, tt.wantErrSubstring)
+				return
+			}
+			assert.NoError(t, err)
+			var gotAccepted []string
+			for _, member := range accepted {
+				gotAccepted = append(gotAccepted, member.Ing.Namespace+"/"+member.Ing.Name)
+			}
+			assert.Equal(t, tt.wantAccepted, gotAccepted)
+			var gotRejected map[string]string
+			for _, member := range rejected {
+				if gotRejected == nil {
+					gotRejected = make(map[string]string)
+				}
+				gotRejected[member.Ing.Namespace+"/"+member.Ing.Name] = member.Reason
+				assert.NotEmpty(t, member.Message)
+			}
+			assert.Equal(t, tt.wantRejected, gotRejected)
+		})
+	}
+}
+
+func Test_isGroupHostAllowed(t *testing.T) {
+	allowedHosts := []string{"app.example.com", "*.team-a.example.com"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "app.example.com", want: true},
+		{host: "web.team-a.example.com", want: true},
+		{host: "*.team-a.example.com", want: true},
+		{host: "team-a.example.com", want: false},
+		{host: "a.web.team-a.example.com", want: false},
+		{host: "other.example.com", want: false},
+		{host: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, isGroupHostAllowed(tt.host, allowedHosts))
+		})
+	}
+}
+
+func Test_isGroupPathAllowed(t *testing.T) {
+	allowedPathPrefixes := []string{"/team-a", "/static/"}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/team-a", want: true},
+		{path: "/team-a/api", want: true},
+		{path: "/team-a/*", want: true},
+		{path: "/team-ab", want: false},
+		{path: "/static/css", want: true},
+		{path: "/static", want: false},
+		{path: "/", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, isGroupPathAllowed(tt.path, allowedPathPrefixes))
+		})
+	}
+}
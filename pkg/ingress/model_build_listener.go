@@ -14,11 +14,13 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	acmModel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/acm"
@@ -97,6 +99,13 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	rollouts, err := t.loadTrafficRollouts(ctx, ing.Ing)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.applyTrafficRollouts(ctx, ing.Ing, &enhancedBackend.Action, rollouts); err != nil {
+		return nil, err
+	}
 	return t.buildActions(ctx, protocol, ing, enhancedBackend)
 }
 
@@ -223,7 +232,11 @@ func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Co
 
 	discoveredCerts, err := t.certDiscovery.Discover(ctx, hosts.List(), t.trackingProvider.StackTags(t.stack))
 	if err != nil {
-		return nil, err
+		var partialErr *certs.PartialDiscoveryError
+		if !errors.As(err, &partialErr) {
+			return nil, err
+		}
+		t.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonCertificateDiscoveryIncomplete, err.Error())
 	}
 
 	var discoveredCertsPointers []core.StringToken
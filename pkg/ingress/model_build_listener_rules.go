@@ -23,6 +23,10 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 
 	var rules []Rule
 	for _, ing := range ingList {
+		rollouts, err := t.loadTrafficRollouts(ctx, ing.Ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+		}
 		for _, rule := range ing.Ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
@@ -38,10 +42,18 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
 				}
+				if err := t.applyTrafficRollouts(ctx, ing.Ing, &enhancedBackend.Action, rollouts); err != nil {
+					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+				}
 				conditions, err := t.buildRuleConditions(ctx, ing, rule, path, enhancedBackend)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
 				}
+				headerRules, err := t.buildTrafficRolloutHeaderRules(ctx, protocol, ing, enhancedBackend, conditions, rollouts)
+				if err != nil {
+					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+				}
+				rules = append(rules, headerRules...)
 				actions, err := t.buildActions(ctx, protocol, ing, enhancedBackend)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
@@ -5,13 +5,21 @@ import (
 	"testing"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	acmModel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/acm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -314,6 +322,72 @@ func Test_computeIngressListenPortConfigByPort_Certificates(t *testing.T) {
 	}
 }
 
+func Test_computeIngressInferredTLSCertARNs_partialDiscovery(t *testing.T) {
+	tests := []struct {
+		name                  string
+		allowPartialDiscovery bool
+		want                  []string
+		wantErr               string
+	}{
+		{
+			name:                  "unmatched host fails the build when partial discovery is not allowed",
+			allowPartialDiscovery: false,
+			wantErr:               "no certificate found for host: app.example.org",
+		},
+		{
+			name:                  "unmatched host is skipped when partial discovery is allowed",
+			allowPartialDiscovery: true,
+			want:                  []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certARN := "arn:aws:acm:us-west-2:123456789012:certificate/app"
+			mockACM := services.NewMockACM(ctrl)
+			mockACM.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return([]acmtypes.CertificateSummary{{CertificateArn: awssdk.String(certARN)}}, nil)
+			mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), gomock.Any()).Return(&acm.DescribeCertificateOutput{
+				Certificate: &acmtypes.CertificateDetail{
+					CertificateArn:          awssdk.String(certARN),
+					SubjectAlternativeNames: []string{"app.example.com"},
+					Type:                    acmtypes.CertificateTypeAmazonIssued,
+				},
+			}, nil)
+			task := &defaultModelBuildTask{
+				certDiscovery:    certs.NewACMCertDiscovery(mockACM, nil, false, certs.SelectionPolicy{AllowPartialDiscovery: tt.allowPartialDiscovery}, logr.Discard()),
+				trackingProvider: tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+				stack:            core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing"}),
+				eventRecorder:    record.NewFakeRecorder(10),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing",
+				},
+				Spec: networking.IngressSpec{
+					TLS: []networking.IngressTLS{{Hosts: []string{"app.example.com", "app.example.org"}}},
+				},
+			}
+
+			got, err := task.computeIngressInferredTLSCertARNs(context.Background(), ing)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var gotCertARNs []string
+			for _, cert := range got {
+				arn, err := cert.Resolve(t.Context())
+				assert.NoError(t, err)
+				gotCertARNs = append(gotCertARNs, arn)
+			}
+			assert.Equal(t, tt.want, gotCertARNs)
+		})
+	}
+}
+
 func Test_buildListenerAttributes(t *testing.T) {
 	type fields struct {
 		ingGroup Group
@@ -2,14 +2,20 @@ package ingress
 
 import (
 	"context"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webacl"
 )
 
 const (
@@ -53,12 +59,30 @@ func (t *defaultModelBuildTask) buildWAFv2WebACLAssociation(ctx context.Context,
 
 	if len(explicitWebACLNames) == 0 {
 		for _, member := range t.ingGroup.Members {
+			if member.IngClassConfig.IngClassParams != nil && member.IngClassConfig.IngClassParams.Spec.WAFv2ACLRef != "" {
+				refWebACLARN, err := webacl.ResolveWebACLARN(ctx, t.k8sClient, member.IngClassConfig.IngClassParams.Spec.WAFv2ACLRef)
+				if err != nil {
+					return nil, err
+				}
+				explicitWebACLARNs.Insert(refWebACLARN)
+				continue
+			}
 			if member.IngClassConfig.IngClassParams != nil && member.IngClassConfig.IngClassParams.Spec.WAFv2ACLArn != "" {
 				webACLARN = member.IngClassConfig.IngClassParams.Spec.WAFv2ACLArn
 				explicitWebACLARNs.Insert(webACLARN)
 				continue
 			}
 
+			rawWebACLRef := ""
+			if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixWAFv2ACLRef, &rawWebACLRef, member.Ing.Annotations); exists {
+				refWebACLARN, err := webacl.ResolveWebACLARN(ctx, t.k8sClient, rawWebACLRef)
+				if err != nil {
+					return nil, err
+				}
+				explicitWebACLARNs.Insert(refWebACLARN)
+				continue
+			}
+
 			rawWebACLARN := ""
 			if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixWAFv2ACLARN, &rawWebACLARN, member.Ing.Annotations); !exists {
 				continue
@@ -141,7 +165,19 @@ func (t *defaultModelBuildTask) buildWAFRegionalWebACLAssociation(_ context.Cont
 
 func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN core.StringToken) (*shieldmodel.Protection, error) {
 	explicitEnableProtections := make(map[bool]struct{})
+	var shieldCfg *elbv2api.ShieldAdvancedConfiguration
 	for _, member := range t.ingGroup.Members {
+		if member.IngClassConfig.IngClassParams != nil && member.IngClassConfig.IngClassParams.Spec.ShieldAdvanced != nil {
+			memberShieldCfg := member.IngClassConfig.IngClassParams.Spec.ShieldAdvanced
+			if shieldCfg != nil && !equality.Semantic.DeepEqual(shieldCfg, memberShieldCfg) {
+				return nil, errors.New("conflicting shield advanced configuration")
+			}
+			shieldCfg = memberShieldCfg
+			if memberShieldCfg.Enabled != nil {
+				explicitEnableProtections[*memberShieldCfg.Enabled] = struct{}{}
+				continue
+			}
+		}
 		rawEnableProtection := false
 		exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixShieldAdvancedProtection, &rawEnableProtection, member.Ing.Annotations)
 		if err != nil {
@@ -151,16 +187,60 @@ func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN c
 			explicitEnableProtections[rawEnableProtection] = struct{}{}
 		}
 	}
+	activeAttributes := GetActiveShieldAttributes(t.ingGroup.Members)
 	if len(explicitEnableProtections) == 0 {
-		return nil, nil
+		if activeAttributes.Len() == 0 {
+			return nil, nil
+		}
+		// the protection configured previously is disabled, so that its attributes are reverted along with it.
+		explicitEnableProtections[false] = struct{}{}
 	}
 	if len(explicitEnableProtections) > 1 {
 		return nil, errors.New("conflicting enable shield advanced protection")
 	}
 	_, enableProtection := explicitEnableProtections[true]
-	protection := shieldmodel.NewProtection(t.stack, shared_constants.ResourceIDLoadBalancer, shieldmodel.ProtectionSpec{
+	spec := shieldmodel.ProtectionSpec{
 		Enabled:     enableProtection,
 		ResourceARN: lbARN,
-	})
+	}
+	var protectionGroups []elbv2api.ShieldProtectionGroup
+	var healthCheckARN string
+	var l7Response *elbv2api.ShieldApplicationLayerAutomaticResponse
+	if shieldCfg != nil {
+		protectionGroups = shieldCfg.ProtectionGroups
+		healthCheckARN = awssdk.ToString(shieldCfg.HealthCheckARN)
+		l7Response = shieldCfg.ApplicationLayerAutomaticResponse
+	}
+	// the attributes are only managed when configured, or to revert them once removed from the IngressClassParams.
+	if len(protectionGroups) != 0 || activeAttributes.Has(addon.ShieldProtectionGroups) {
+		spec.ProtectionGroups = buildShieldProtectionGroups(protectionGroups)
+	}
+	if healthCheckARN != "" || activeAttributes.Has(addon.ShieldHealthCheck) {
+		spec.HealthCheck = &shieldmodel.HealthCheck{HealthCheckARN: healthCheckARN}
+	}
+	if l7Response != nil {
+		spec.ApplicationLayerAutomaticResponse = &shieldmodel.ApplicationLayerAutomaticResponse{
+			Enabled: true,
+			Action:  shieldmodel.ApplicationLayerAutomaticResponseAction(l7Response.Action),
+		}
+	} else if activeAttributes.Has(addon.ShieldApplicationLayerAutomaticResponse) {
+		spec.ApplicationLayerAutomaticResponse = &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: false}
+	}
+	protection := shieldmodel.NewProtection(t.stack, shared_constants.ResourceIDLoadBalancer, spec)
 	return protection, nil
 }
+
+func buildShieldProtectionGroups(protectionGroups []elbv2api.ShieldProtectionGroup) *shieldmodel.ProtectionGroups {
+	res := &shieldmodel.ProtectionGroups{}
+	for _, protectionGroup := range protectionGroups {
+		aggregation := shieldmodel.ProtectionGroupAggregationSum
+		if protectionGroup.Aggregation != nil {
+			aggregation = shieldmodel.ProtectionGroupAggregation(*protectionGroup.Aggregation)
+		}
+		res.ProtectionGroups = append(res.ProtectionGroups, shieldmodel.ProtectionGroup{
+			ID:          protectionGroup.ID,
+			Aggregation: aggregation,
+		})
+	}
+	return res
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
@@ -21,6 +22,7 @@ import (
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_defaultModelBuildTask_buildWAFv2WebACLAssociation(t *testing.T) {
@@ -825,6 +827,229 @@ func Test_defaultModelBuildTask_buildShieldProtection(t *testing.T) {
 				return false
 			},
 		},
+		{
+			name: "when IngressClassParams overrides shield-advanced-protection annotation and configures attributes",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-0",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/shield-advanced-protection": "false",
+									},
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &v1beta1.IngressClassParams{
+									Spec: v1beta1.IngressClassParamsSpec{
+										ShieldAdvanced: &v1beta1.ShieldAdvancedConfiguration{
+											Enabled:          awssdk.Bool(true),
+											ProtectionGroups: []v1beta1.ShieldProtectionGroup{{ID: "web"}},
+											ApplicationLayerAutomaticResponse: &v1beta1.ShieldApplicationLayerAutomaticResponse{
+												Action: v1beta1.ShieldApplicationLayerAutomaticResponseActionBlock,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				lbARN: core.LiteralStringToken("awesome-lb-arn"),
+			},
+			want: &shieldmodel.Protection{
+				Spec: shieldmodel.ProtectionSpec{
+					Enabled:     true,
+					ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+					ProtectionGroups: &shieldmodel.ProtectionGroups{
+						ProtectionGroups: []shieldmodel.ProtectionGroup{{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationSum}},
+					},
+					ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{
+						Enabled: true,
+						Action:  shieldmodel.ApplicationLayerAutomaticResponseActionBlock,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "when IngressClassParams configures attributes and annotation enables protection",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-0",
+									Annotations: map[string]string{
+										"alb.ingress.kubernetes.io/shield-advanced-protection": "true",
+									},
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &v1beta1.IngressClassParams{
+									Spec: v1beta1.IngressClassParamsSpec{
+										ShieldAdvanced: &v1beta1.ShieldAdvancedConfiguration{
+											HealthCheckARN: awssdk.String("arn:aws:route53:::healthcheck/hc-1"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				lbARN: core.LiteralStringToken("awesome-lb-arn"),
+			},
+			want: &shieldmodel.Protection{
+				Spec: shieldmodel.ProtectionSpec{
+					Enabled:     true,
+					ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+					HealthCheck: &shieldmodel.HealthCheck{HealthCheckARN: "arn:aws:route53:::healthcheck/hc-1"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "when IngressClassParams removes attributes that are tracked as active",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-0",
+									Annotations: map[string]string{
+										"ingress.k8s.aws.addon.shieldprotectiongroups":                  "true",
+										"ingress.k8s.aws.addon.shieldapplicationlayerautomaticresponse": "true",
+									},
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &v1beta1.IngressClassParams{
+									Spec: v1beta1.IngressClassParamsSpec{
+										ShieldAdvanced: &v1beta1.ShieldAdvancedConfiguration{
+											Enabled:        awssdk.Bool(true),
+											HealthCheckARN: awssdk.String("arn:aws:route53:::healthcheck/hc-1"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				lbARN: core.LiteralStringToken("awesome-lb-arn"),
+			},
+			want: &shieldmodel.Protection{
+				Spec: shieldmodel.ProtectionSpec{
+					Enabled:                           true,
+					ResourceARN:                       core.LiteralStringToken("awesome-lb-arn"),
+					ProtectionGroups:                  &shieldmodel.ProtectionGroups{},
+					HealthCheck:                       &shieldmodel.HealthCheck{HealthCheckARN: "arn:aws:route53:::healthcheck/hc-1"},
+					ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: false},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "when shield advanced configuration is removed while attributes are tracked as active",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-0",
+								},
+							},
+						},
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-1",
+									Annotations: map[string]string{
+										"ingress.k8s.aws.addon.shieldprotectiongroups": "false",
+										"ingress.k8s.aws.addon.shieldhealthcheck":      "true",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				lbARN: core.LiteralStringToken("awesome-lb-arn"),
+			},
+			want: &shieldmodel.Protection{
+				Spec: shieldmodel.ProtectionSpec{
+					Enabled:     false,
+					ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+					HealthCheck: &shieldmodel.HealthCheck{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "when IngressClassParams have conflicting shield advanced configuration",
+			fields: fields{
+				ingGroup: Group{
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-0",
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &v1beta1.IngressClassParams{
+									Spec: v1beta1.IngressClassParamsSpec{
+										ShieldAdvanced: &v1beta1.ShieldAdvancedConfiguration{Enabled: awssdk.Bool(true)},
+									},
+								},
+							},
+						},
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing-1",
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &v1beta1.IngressClassParams{
+									Spec: v1beta1.IngressClassParamsSpec{
+										ShieldAdvanced: &v1beta1.ShieldAdvancedConfiguration{
+											Enabled:          awssdk.Bool(true),
+											ProtectionGroups: []v1beta1.ShieldProtectionGroup{{ID: "web"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				lbARN: core.LiteralStringToken("awesome-lb-arn"),
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				assert.EqualError(t, err, "conflicting shield advanced configuration", msgAndArgs...)
+				return false
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1327,3 +1552,147 @@ func Test_defaultModelBuildTask_buildWAFv2WebACLAssociationFromWAFv2Name(t *test
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildWAFv2WebACLAssociationFromWAFv2WebACLRef(t *testing.T) {
+	provisionedWebACL := &v1beta1.WAFv2WebACL{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop-web-acl"},
+		Status:     v1beta1.WAFv2WebACLStatus{WebACLARN: awssdk.String("wafv2-arn-shop")},
+	}
+	pendingWebACL := &v1beta1.WAFv2WebACL{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending-web-acl"},
+	}
+	tests := []struct {
+		name     string
+		ingGroup Group
+		want     *wafv2model.WebACLAssociation
+		wantErr  string
+	}{
+		{
+			name: "wafv2-acl-ref annotation takes precedence over wafv2-acl-arn",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing-0",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-ref": "shop-web-acl",
+									"alb.ingress.kubernetes.io/wafv2-acl-arn": "wafv2-arn-1",
+								},
+							},
+						},
+					},
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-arn": "wafv2-arn-shop",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &wafv2model.WebACLAssociation{
+				Spec: wafv2model.WebACLAssociationSpec{
+					WebACLARN:   "wafv2-arn-shop",
+					ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+				},
+			},
+		},
+		{
+			name: "wafv2AclRef of IngressClassParams takes precedence over annotations",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing-0",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-ref": "pending-web-acl",
+								},
+							},
+						},
+						IngClassConfig: ClassConfiguration{
+							IngClassParams: &v1beta1.IngressClassParams{
+								Spec: v1beta1.IngressClassParamsSpec{
+									WAFv2ACLRef: "shop-web-acl",
+									WAFv2ACLArn: "wafv2-arn-1",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &wafv2model.WebACLAssociation{
+				Spec: wafv2model.WebACLAssociationSpec{
+					WebACLARN:   "wafv2-arn-shop",
+					ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+				},
+			},
+		},
+		{
+			name: "referenced WAFv2WebACL isn't provisioned yet",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing-0",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-ref": "pending-web-acl",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "WAFv2WebACL pending-web-acl isn't provisioned yet",
+		},
+		{
+			name: "referenced WAFv2WebACL doesn't exist",
+			ingGroup: Group{
+				Members: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing-0",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/wafv2-acl-ref": "unknown-web-acl",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "failed to get WAFv2WebACL unknown-web-acl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			v1beta1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(provisionedWebACL.DeepCopy(), pendingWebACL.DeepCopy()).Build()
+			task := &defaultModelBuildTask{
+				k8sClient:        k8sClient,
+				ingGroup:         tt.ingGroup,
+				stack:            core.NewDefaultStack(core.StackID{Name: "awesome-stack"}),
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildWAFv2WebACLAssociation(context.Background(), core.LiteralStringToken("awesome-lb-arn"))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			opts := cmpopts.IgnoreTypes(core.ResourceMeta{})
+			assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
@@ -32,9 +33,32 @@ func (t *defaultModelBuildTask) buildLoadBalancerMinimumCapacity(_ context.Conte
 		}
 
 	}
+	if minimumLoadBalancerCapacity != nil && len(t.ingGroup.Members) > 0 {
+		if err := buildIngressClassLoadBalancerCapacitySchedule(t.ingGroup.Members[0].IngClassConfig, minimumLoadBalancerCapacity); err != nil {
+			return nil, err
+		}
+	}
 	return minimumLoadBalancerCapacity, nil
 }
 
+// buildIngressClassLoadBalancerCapacitySchedule sets the capacity reservation schedules and calendar of an IngressClass.
+func buildIngressClassLoadBalancerCapacitySchedule(ingClassConfig ClassConfiguration, minimumLoadBalancerCapacity *elbv2model.MinimumLoadBalancerCapacity) error {
+	if ingClassConfig.IngClassParams == nil {
+		return nil
+	}
+	ingClassCapacity := capacityreservation.FromIngressClassParams(ingClassConfig.IngClassParams.Spec.MinimumLoadBalancerCapacity)
+	if !capacityreservation.IsScheduled(ingClassCapacity) {
+		return nil
+	}
+	if err := capacityreservation.Validate(*ingClassCapacity); err != nil {
+		return err
+	}
+	minimumLoadBalancerCapacity.Schedules = ingClassCapacity.Schedules
+	minimumLoadBalancerCapacity.Calendar = ingClassCapacity.Calendar
+	minimumLoadBalancerCapacity.TimeZone = ingClassCapacity.TimeZone
+	return nil
+}
+
 // buildIngressGroupLoadBalancerMinimumCapacity builds the minimum load balancer capacity for ingresses within a group.
 // Note: the capacity reservation specified via IngressClass takes higher priority than the capacity specified via annotation on Ingress.
 func (t *defaultModelBuildTask) buildIngressGroupLoadBalancerMinimumCapacity(ingList []ClassifiedIngress) (map[string]string, error) {
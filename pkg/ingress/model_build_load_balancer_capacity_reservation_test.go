@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
+	"time"
 )
 
 func Test_defaultModelBuildTask_buildLoadBalancerMinimumCapacity(t *testing.T) {
@@ -180,6 +181,117 @@ func Test_defaultModelBuildTask_buildLoadBalancerMinimumCapacity(t *testing.T) {
 			},
 			want: &elbv2model.MinimumLoadBalancerCapacity{CapacityUnits: 1200},
 		},
+		{
+			name: "IngressClass capacity reservation with schedules and calendar",
+			featureGates: map[config.Feature]bool{
+				config.LBCapacityReservation: true,
+			},
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Name: "ig-group-3"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing",
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &elbv2api.IngressClassParams{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "awesome-class",
+									},
+									Spec: elbv2api.IngressClassParamsSpec{
+										MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+											CapacityUnits: 100,
+											Schedules: []elbv2api.CapacityReservationSchedule{
+												{
+													Name:          "business-hours",
+													Start:         "0 8 * * mon-fri",
+													Duration:      metav1.Duration{Duration: 10 * time.Hour},
+													CapacityUnits: 400,
+												},
+											},
+											Calendar: []elbv2api.CapacityReservationCalendarEntry{
+												{
+													Name:          "launch",
+													Start:         "2026-03-04T12:00",
+													End:           "2026-03-05T00:00",
+													CapacityUnits: 1000,
+												},
+											},
+											TimeZone: "Europe/Paris",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &elbv2model.MinimumLoadBalancerCapacity{
+				CapacityUnits: 100,
+				Schedules: []elbv2model.CapacityReservationSchedule{
+					{
+						Name:          "business-hours",
+						Start:         "0 8 * * mon-fri",
+						Duration:      metav1.Duration{Duration: 10 * time.Hour},
+						CapacityUnits: 400,
+					},
+				},
+				Calendar: []elbv2model.CapacityReservationCalendarEntry{
+					{
+						Name:          "launch",
+						Start:         "2026-03-04T12:00",
+						End:           "2026-03-05T00:00",
+						CapacityUnits: 1000,
+					},
+				},
+				TimeZone: "Europe/Paris",
+			},
+		},
+		{
+			name: "IngressClass capacity reservation with invalid time zone",
+			featureGates: map[config.Feature]bool{
+				config.LBCapacityReservation: true,
+			},
+			fields: fields{
+				ingGroup: Group{
+					ID: GroupID{Name: "ig-group-3"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{
+								ObjectMeta: metav1.ObjectMeta{
+									Namespace: "awesome-ns",
+									Name:      "awesome-ing",
+								},
+							},
+							IngClassConfig: ClassConfiguration{
+								IngClassParams: &elbv2api.IngressClassParams{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "awesome-class",
+									},
+									Spec: elbv2api.IngressClassParamsSpec{
+										MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+											Calendar: []elbv2api.CapacityReservationCalendarEntry{
+												{
+													Start:         "2026-03-04T12:00",
+													End:           "2026-03-05T00:00",
+													CapacityUnits: 1000,
+												},
+											},
+											TimeZone: "Mars/Olympus_Mons",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid time zone Mars/Olympus_Mons: unknown time zone Mars/Olympus_Mons"),
+		},
 		{
 			name: "capacity reservation from Ingress that does not have annotation for setting capacity reservation",
 			featureGates: map[config.Feature]bool{
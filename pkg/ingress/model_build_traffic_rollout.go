@@ -0,0 +1,152 @@
+package ingress
+
+import (
+	"context"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/rollout"
+)
+
+// loadTrafficRollouts loads the TrafficRollouts targeting ing.
+func (t *defaultModelBuildTask) loadTrafficRollouts(ctx context.Context, ing *networking.Ingress) ([]elbv2api.TrafficRollout, error) {
+	if !t.featureGates.Enabled(config.TrafficRollout) {
+		return nil, nil
+	}
+	return rollout.ListTrafficRollouts(ctx, t.k8sClient, elbv2api.TrafficRolloutTargetKindIngress, ing.Namespace, ing.Name)
+}
+
+// applyTrafficRollouts splits the weight of the stable backend of each rollout between stable and canary backends.
+// The canary Service is loaded into backendServices, so that its target group is built along with the others.
+func (t *defaultModelBuildTask) applyTrafficRollouts(ctx context.Context, ing *networking.Ingress, action *Action, rollouts []elbv2api.TrafficRollout) error {
+	if action.Type != ActionTypeForward || action.ForwardConfig == nil {
+		return nil
+	}
+	for i := range rollouts {
+		tr := &rollouts[i]
+		canaryPercent, ok := rollout.CanaryWeight(tr)
+		if !ok {
+			continue
+		}
+		stableIdx := findTargetGroupTuple(action.ForwardConfig.TargetGroups, tr.Spec.StableBackend)
+		if stableIdx < 0 {
+			continue
+		}
+		canaryIdx := findTargetGroupTuple(action.ForwardConfig.TargetGroups, tr.Spec.CanaryBackend)
+		if canaryIdx < 0 && canaryPercent == 0 {
+			continue
+		}
+
+		stableTuple := &action.ForwardConfig.TargetGroups[stableIdx]
+		total := rollout.DefaultTotalWeight
+		if stableTuple.Weight != nil {
+			total = *stableTuple.Weight
+		}
+		stableWeight, canaryWeight := rollout.SplitWeight(total, canaryPercent)
+		stableTuple.Weight = awssdk.Int32(stableWeight)
+		if canaryIdx >= 0 {
+			action.ForwardConfig.TargetGroups[canaryIdx].Weight = awssdk.Int32(canaryWeight)
+			continue
+		}
+		if err := t.loadRolloutCanaryService(ctx, ing.Namespace, tr.Spec.CanaryBackend); err != nil {
+			return err
+		}
+		canaryPort := tr.Spec.CanaryBackend.Port
+		action.ForwardConfig.TargetGroups = append(action.ForwardConfig.TargetGroups, TargetGroupTuple{
+			ServiceName: awssdk.String(tr.Spec.CanaryBackend.Name),
+			ServicePort: &canaryPort,
+			Weight:      awssdk.Int32(canaryWeight),
+		})
+	}
+	return nil
+}
+
+// buildTrafficRolloutHeaderRules builds the rules sending requests matching the headerRouting of rollouts to their canary backend.
+// These rules share the conditions of the rule for backend, and must take precedence over it.
+func (t *defaultModelBuildTask) buildTrafficRolloutHeaderRules(ctx context.Context, protocol elbv2model.Protocol, ing ClassifiedIngress,
+	backend EnhancedBackend, conditions []elbv2model.RuleCondition, rollouts []elbv2api.TrafficRollout) ([]Rule, error) {
+	if backend.Action.Type != ActionTypeForward || backend.Action.ForwardConfig == nil {
+		return nil, nil
+	}
+	var rules []Rule
+	for i := range rollouts {
+		tr := &rollouts[i]
+		if !rollout.IsHeaderRoutingActive(tr) || findTargetGroupTuple(backend.Action.ForwardConfig.TargetGroups, tr.Spec.StableBackend) < 0 {
+			continue
+		}
+		if err := t.loadRolloutCanaryService(ctx, ing.Ing.Namespace, tr.Spec.CanaryBackend); err != nil {
+			return nil, err
+		}
+		canaryBackend := backend
+		canaryPort := tr.Spec.CanaryBackend.Port
+		canaryBackend.Action = Action{
+			Type: ActionTypeForward,
+			ForwardConfig: &ForwardActionConfig{
+				TargetGroups: []TargetGroupTuple{
+					{
+						ServiceName: awssdk.String(tr.Spec.CanaryBackend.Name),
+						ServicePort: &canaryPort,
+					},
+				},
+			},
+		}
+		actions, err := t.buildActions(ctx, protocol, ing, canaryBackend)
+		if err != nil {
+			return nil, err
+		}
+		transforms, err := t.buildTransforms(ctx, canaryBackend)
+		if err != nil {
+			return nil, err
+		}
+		tags, err := t.buildListenerRuleTags(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		canaryConditions := append([]elbv2model.RuleCondition{}, conditions...)
+		canaryConditions = append(canaryConditions, elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldHTTPHeader,
+			HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+				HTTPHeaderName: tr.Spec.HeaderRouting.Name,
+				Values:         tr.Spec.HeaderRouting.Values,
+			},
+		})
+		rules = append(rules, Rule{
+			Conditions: canaryConditions,
+			Actions:    actions,
+			Transforms: transforms,
+			Tags:       tags,
+		})
+	}
+	return rules, nil
+}
+
+func (t *defaultModelBuildTask) loadRolloutCanaryService(ctx context.Context, namespace string, backend elbv2api.TrafficRolloutBackend) error {
+	svcKey := types.NamespacedName{Namespace: namespace, Name: backend.Name}
+	if _, ok := t.backendServices[svcKey]; ok {
+		return nil
+	}
+	svc := &corev1.Service{}
+	if err := t.k8sClient.Get(ctx, svcKey, svc); err != nil {
+		return err
+	}
+	t.backendServices[svcKey] = svc
+	return nil
+}
+
+// findTargetGroupTuple returns the index of the tuple forwarding to backend, or -1 if there is none.
+func findTargetGroupTuple(tuples []TargetGroupTuple, backend elbv2api.TrafficRolloutBackend) int {
+	for i, tuple := range tuples {
+		if tuple.ServiceName == nil || tuple.ServicePort == nil {
+			continue
+		}
+		if rollout.BackendMatches(backend, *tuple.ServiceName, *tuple.ServicePort) {
+			return i
+		}
+	}
+	return -1
+}
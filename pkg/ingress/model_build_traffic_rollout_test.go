@@ -0,0 +1,182 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/rollout"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+)
+
+func Test_defaultModelBuildTask_applyTrafficRollouts(t *testing.T) {
+	port80 := intstr.FromInt32(80)
+	progressingRollout := func(canaryWeight int32) elbv2api.TrafficRollout {
+		spec := elbv2api.TrafficRolloutSpec{
+			TargetRef:     elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindIngress, Name: "ing"},
+			StableBackend: elbv2api.TrafficRolloutBackend{Name: "stable", Port: port80},
+			CanaryBackend: elbv2api.TrafficRolloutBackend{Name: "canary", Port: port80},
+		}
+		return elbv2api.TrafficRollout{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout", Generation: 1},
+			Spec:       spec,
+			Status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 1,
+				RolloutHash:        rollout.ComputeRolloutHash(spec),
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       canaryWeight,
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		action       Action
+		rollouts     []elbv2api.TrafficRollout
+		wantAction   Action
+		wantServices []string
+	}{
+		{
+			name: "canary backend is added to forward action",
+			action: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80},
+					},
+				},
+			},
+			rollouts: []elbv2api.TrafficRollout{progressingRollout(20)},
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80, Weight: awssdk.Int32(80)},
+						{ServiceName: awssdk.String("canary"), ServicePort: &port80, Weight: awssdk.Int32(20)},
+					},
+				},
+			},
+			wantServices: []string{"canary"},
+		},
+		{
+			name: "weight of stable backend is split with existing canary backend",
+			action: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{TargetGroupARN: awssdk.String("tg-arn"), Weight: awssdk.Int32(50)},
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80, Weight: awssdk.Int32(50)},
+						{ServiceName: awssdk.String("canary"), ServicePort: &port80, Weight: awssdk.Int32(0)},
+					},
+				},
+			},
+			rollouts: []elbv2api.TrafficRollout{progressingRollout(40)},
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{TargetGroupARN: awssdk.String("tg-arn"), Weight: awssdk.Int32(50)},
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80, Weight: awssdk.Int32(30)},
+						{ServiceName: awssdk.String("canary"), ServicePort: &port80, Weight: awssdk.Int32(20)},
+					},
+				},
+			},
+		},
+		{
+			name: "rolled back rollout doesn't add canary backend",
+			action: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80},
+					},
+				},
+			},
+			rollouts: func() []elbv2api.TrafficRollout {
+				rollout := progressingRollout(0)
+				rollout.Status.Phase = elbv2api.TrafficRolloutPhaseRolledBack
+				return []elbv2api.TrafficRollout{rollout}
+			}(),
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80},
+					},
+				},
+			},
+		},
+		{
+			name: "rollout not started yet is ignored",
+			action: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80},
+					},
+				},
+			},
+			rollouts: func() []elbv2api.TrafficRollout {
+				rollout := progressingRollout(20)
+				rollout.Status.RolloutHash = "outdated"
+				return []elbv2api.TrafficRollout{rollout}
+			}(),
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("stable"), ServicePort: &port80},
+					},
+				},
+			},
+		},
+		{
+			name: "forward action without stable backend is untouched",
+			action: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("other"), ServicePort: &port80},
+					},
+				},
+			},
+			rollouts: []elbv2api.TrafficRollout{progressingRollout(20)},
+			wantAction: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{ServiceName: awssdk.String("other"), ServicePort: &port80},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testutils.GenerateTestClient()
+			assert.NoError(t, k8sClient.Create(context.Background(), &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "canary"},
+			}))
+			task := &defaultModelBuildTask{
+				k8sClient:       k8sClient,
+				backendServices: map[types.NamespacedName]*corev1.Service{},
+			}
+			ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}}
+			action := tt.action
+			err := task.applyTrafficRollouts(context.Background(), ing, &action, tt.rollouts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAction, action)
+			var gotServices []string
+			for svcKey := range task.backendServices {
+				gotServices = append(gotServices, svcKey.Name)
+			}
+			assert.Equal(t, tt.wantServices, gotServices)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package ingress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+)
+
+const (
+	// IngressPrefixEnabledAddon is the annotation prefix used to track the addons enabled on the load balancer of an IngressGroup.
+	IngressPrefixEnabledAddon = "ingress.k8s.aws.addon."
+)
+
+var (
+	// shieldAttributeAddons are the Shield protection attributes that are tracked, so that they're reverted when removed from the IngressClassParams.
+	shieldAttributeAddons = []addon.Addon{addon.ShieldProtectionGroups, addon.ShieldHealthCheck, addon.ShieldApplicationLayerAutomaticResponse}
+)
+
+// GenerateAddonKey translates an addon into the respective annotation key on the Ingresses.
+func GenerateAddonKey(a addon.Addon) string {
+	return fmt.Sprintf("%s%s", IngressPrefixEnabledAddon, strings.ToLower(string(a)))
+}
+
+// GetActiveShieldAttributes returns the Shield protection attributes that are tracked as enabled on any member of the IngressGroup.
+func GetActiveShieldAttributes(members []ClassifiedIngress) sets.Set[addon.Addon] {
+	res := sets.New[addon.Addon]()
+	for _, member := range members {
+		for _, a := range shieldAttributeAddons {
+			if enabled, err := strconv.ParseBool(member.Ing.Annotations[GenerateAddonKey(a)]); err == nil && enabled {
+				res.Insert(a)
+			}
+		}
+	}
+	return res
+}
+
+// BuildShieldAttributeAddons returns the enablement of the Shield protection attributes in the stack.
+func BuildShieldAttributeAddons(stack core.Stack) ([]addon.AddonMetadata, error) {
+	var protections []*shieldmodel.Protection
+	if err := stack.ListResources(&protections); err != nil {
+		return nil, err
+	}
+	enabled := sets.New[addon.Addon]()
+	for _, protection := range protections {
+		if !protection.Spec.Enabled {
+			continue
+		}
+		if protection.Spec.ProtectionGroups != nil && len(protection.Spec.ProtectionGroups.ProtectionGroups) != 0 {
+			enabled.Insert(addon.ShieldProtectionGroups)
+		}
+		if protection.Spec.HealthCheck != nil && protection.Spec.HealthCheck.HealthCheckARN != "" {
+			enabled.Insert(addon.ShieldHealthCheck)
+		}
+		if protection.Spec.ApplicationLayerAutomaticResponse != nil && protection.Spec.ApplicationLayerAutomaticResponse.Enabled {
+			enabled.Insert(addon.ShieldApplicationLayerAutomaticResponse)
+		}
+	}
+	res := make([]addon.AddonMetadata, 0, len(shieldAttributeAddons))
+	for _, a := range shieldAttributeAddons {
+		res = append(res, addon.AddonMetadata{
+			Name:    a,
+			Enabled: enabled.Has(a),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
+)
+
+func Test_GetActiveShieldAttributes(t *testing.T) {
+	members := []ClassifiedIngress{
+		{
+			Ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"ingress.k8s.aws.addon.shieldprotectiongroups": "true",
+						"ingress.k8s.aws.addon.shieldhealthcheck":      "false",
+					},
+				},
+			},
+		},
+		{
+			Ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"ingress.k8s.aws.addon.shieldapplicationlayerautomaticresponse": "malformed",
+						"ingress.k8s.aws.addon.waf":                                     "true",
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, sets.New(addon.ShieldProtectionGroups), GetActiveShieldAttributes(members))
+}
+
+func Test_BuildShieldAttributeAddons(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *shieldmodel.ProtectionSpec
+		want []addon.AddonMetadata
+	}{
+		{
+			name: "without protection",
+			want: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: false},
+				{Name: addon.ShieldHealthCheck, Enabled: false},
+				{Name: addon.ShieldApplicationLayerAutomaticResponse, Enabled: false},
+			},
+		},
+		{
+			name: "with enabled protection and attributes",
+			spec: &shieldmodel.ProtectionSpec{
+				Enabled:     true,
+				ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+				ProtectionGroups: &shieldmodel.ProtectionGroups{
+					ProtectionGroups: []shieldmodel.ProtectionGroup{{ID: "web", Aggregation: shieldmodel.ProtectionGroupAggregationSum}},
+				},
+				HealthCheck:                       &shieldmodel.HealthCheck{},
+				ApplicationLayerAutomaticResponse: &shieldmodel.ApplicationLayerAutomaticResponse{Enabled: true},
+			},
+			want: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: true},
+				{Name: addon.ShieldHealthCheck, Enabled: false},
+				{Name: addon.ShieldApplicationLayerAutomaticResponse, Enabled: true},
+			},
+		},
+		{
+			name: "with disabled protection",
+			spec: &shieldmodel.ProtectionSpec{
+				Enabled:     false,
+				ResourceARN: core.LiteralStringToken("awesome-lb-arn"),
+				HealthCheck: &shieldmodel.HealthCheck{HealthCheckARN: "arn:aws:route53:::healthcheck/hc-1"},
+			},
+			want: []addon.AddonMetadata{
+				{Name: addon.ShieldProtectionGroups, Enabled: false},
+				{Name: addon.ShieldHealthCheck, Enabled: false},
+				{Name: addon.ShieldApplicationLayerAutomaticResponse, Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-stack"})
+			if tt.spec != nil {
+				shieldmodel.NewProtection(stack, "LoadBalancer", *tt.spec)
+			}
+			got, err := BuildShieldAttributeAddons(stack)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
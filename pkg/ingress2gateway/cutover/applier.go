@@ -0,0 +1,99 @@
+package cutover
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const kindGateway = "Gateway"
+
+// readManifests decodes every object in the manifest files.
+// Gateways are stripped of the dry-run annotation so that the controller provisions them for real.
+func readManifests(files []string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %w", file, err)
+		}
+		reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+		for {
+			doc, err := reader.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("error reading file %s: %w", file, err)
+			}
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+				return nil, fmt.Errorf("error decoding file %s: %w", file, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if obj.GetKind() == kindGateway {
+				annotations := obj.GetAnnotations()
+				delete(annotations, gateway_constants.AnnotationDryRun)
+				delete(annotations, gateway_constants.AnnotationDryRunPlan)
+				obj.SetAnnotations(annotations)
+			}
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// applyManifests creates or updates every object in the manifest files.
+func applyManifests(ctx context.Context, k8sClient client.Client, files []string) error {
+	objs, err := readManifests(files)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if err := applyObject(ctx, k8sClient, obj); err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+	}
+	return nil
+}
+
+func applyObject(ctx context.Context, k8sClient client.Client, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return k8sClient.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	// keep annotations added by others, but drop the dry-run ones from Gateways previously applied in dry-run mode.
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if obj.GetKind() == kindGateway {
+		delete(annotations, gateway_constants.AnnotationDryRun)
+		delete(annotations, gateway_constants.AnnotationDryRunPlan)
+	}
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	obj.SetAnnotations(annotations)
+	return k8sClient.Update(ctx, obj)
+}
@@ -0,0 +1,93 @@
+package cutover
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testManifest = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gw
+  namespace: ns
+  annotations:
+    gateway.k8s.aws/dry-run: "true"
+    example.com/owner: team-a
+spec:
+  gatewayClassName: aws-alb
+  listeners:
+  - name: http
+    port: 80
+    protocol: HTTP
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: ns
+data:
+  key: value
+`
+
+func Test_applyManifests(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "gateway-resources.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(testManifest), 0644))
+
+	tests := []struct {
+		name            string
+		existingGateway *gwv1.Gateway
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "objects created",
+			wantAnnotations: map[string]string{"example.com/owner": "team-a"},
+		},
+		{
+			name: "gateway applied in dry-run mode is updated",
+			existingGateway: &gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "gw",
+					Annotations: map[string]string{
+						"gateway.k8s.aws/dry-run":      "true",
+						"gateway.k8s.aws/dry-run-plan": "{}",
+						"example.com/other":            "kept",
+					},
+				},
+				Spec: gwv1.GatewaySpec{GatewayClassName: "aws-alb"},
+			},
+			wantAnnotations: map[string]string{"example.com/owner": "team-a", "example.com/other": "kept"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := testclient.NewClientBuilder().WithScheme(newTestScheme())
+			if tt.existingGateway != nil {
+				builder = builder.WithObjects(tt.existingGateway)
+			}
+			k8sClient := builder.Build()
+
+			require.NoError(t, applyManifests(context.Background(), k8sClient, []string{file}))
+
+			gw := &gwv1.Gateway{}
+			require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "gw"}, gw))
+			assert.Equal(t, tt.wantAnnotations, gw.Annotations)
+			assert.Len(t, gw.Spec.Listeners, 1)
+
+			cm := &corev1.ConfigMap{}
+			require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "cm"}, cm))
+			assert.Equal(t, map[string]string{"key": "value"}, cm.Data)
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package cutover
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+// Cutover shifts traffic from an IngressGroup to the Gateway generated from it.
+type Cutover interface {
+	// Run applies the Gateway, waits until it's ready, and shifts traffic to it step by step.
+	// It resumes from the state file if one exists, and rolls back automatically if the Gateway degrades during a step.
+	Run(ctx context.Context, opts Options) error
+
+	// Rollback shifts all traffic back to the Ingress.
+	Rollback(ctx context.Context, opts Options) error
+}
+
+// NewDefaultCutover constructs new defaultCutover.
+func NewDefaultCutover(k8sClient client.Client, groupLoader ingress.GroupLoader, elbv2Client services.ELBV2,
+	route53Client services.Route53, gaClient services.GlobalAccelerator, out io.Writer) *defaultCutover {
+	return &defaultCutover{
+		k8sClient: k8sClient,
+		readiness: &readinessChecker{
+			k8sClient:   k8sClient,
+			groupLoader: groupLoader,
+		},
+		newTrafficShifter: func(ctx context.Context, target TrafficTarget, ingressLBDNS string, gatewayLBDNS string) (trafficShifter, error) {
+			return newTrafficShifter(ctx, target, ingressLBDNS, gatewayLBDNS, elbv2Client, route53Client, gaClient)
+		},
+		out:          out,
+		pollInterval: defaultPollInterval,
+		sleep:        sleepWithContext,
+		now:          time.Now,
+	}
+}
+
+var _ Cutover = &defaultCutover{}
+
+type defaultCutover struct {
+	k8sClient         client.Client
+	readiness         *readinessChecker
+	newTrafficShifter func(ctx context.Context, target TrafficTarget, ingressLBDNS string, gatewayLBDNS string) (trafficShifter, error)
+	out               io.Writer
+
+	pollInterval time.Duration
+	sleep        func(ctx context.Context, d time.Duration) error
+	now          func() time.Time
+}
+
+func (c *defaultCutover) Run(ctx context.Context, opts Options) error {
+	if err := validateSteps(opts.Steps); err != nil {
+		return err
+	}
+	state, err := c.loadOrInitState(opts)
+	if err != nil {
+		return err
+	}
+	switch state.Phase {
+	case PhaseCompleted:
+		c.logf("Cutover already completed, all traffic is served by gateway %s", state.Gateway)
+		return nil
+	case PhaseRolledBack:
+		return fmt.Errorf("cutover was rolled back: %s; remove state file %s to start over", state.Message, opts.StateFile)
+	}
+
+	if state.Phase == PhasePending {
+		if len(opts.ManifestFiles) != 0 {
+			c.logf("Applying %d manifest file(s)", len(opts.ManifestFiles))
+			if err := applyManifests(ctx, c.k8sClient, opts.ManifestFiles); err != nil {
+				return err
+			}
+		}
+		if err := c.transition(opts, state, PhaseGatewayApplied, "manifests applied"); err != nil {
+			return err
+		}
+	}
+
+	groupID, ingressLBDNS, err := c.readiness.ingressGroup(ctx, opts.Ingress)
+	if err != nil {
+		return err
+	}
+	state.IngressLoadBalancerDNS = ingressLBDNS
+
+	if state.Phase == PhaseGatewayApplied {
+		gatewayLBDNS, err := c.waitForGatewayReady(ctx, opts, groupID)
+		if err != nil {
+			return err
+		}
+		state.GatewayLoadBalancerDNS = gatewayLBDNS
+		if err := c.transition(opts, state, PhaseGatewayReady, "gateway programmed with healthy targets"); err != nil {
+			return err
+		}
+	}
+
+	shifter, err := c.newTrafficShifter(ctx, opts.TrafficTarget, state.IngressLoadBalancerDNS, state.GatewayLoadBalancerDNS)
+	if err != nil {
+		return err
+	}
+	if state.Phase == PhaseGatewayReady {
+		// validates the traffic target and creates the weighted entries before any traffic is shifted.
+		if err := shifter.setWeights(ctx, 0); err != nil {
+			return fmt.Errorf("failed to prepare traffic target: %w", err)
+		}
+		state.GatewayWeight = 0
+		if err := c.transition(opts, state, PhaseShifting, "weighted traffic target prepared"); err != nil {
+			return err
+		}
+	}
+
+	for state.CompletedSteps < len(state.Steps) {
+		weight := state.Steps[state.CompletedSteps]
+		c.logf("Step %d/%d: shifting %d%% of traffic to gateway %s", state.CompletedSteps+1, len(state.Steps), weight, state.Gateway)
+		if err := shifter.setWeights(ctx, weight); err != nil {
+			return fmt.Errorf("failed to shift %d%% of traffic to gateway: %w", weight, err)
+		}
+		state.GatewayWeight = weight
+		if err := c.transition(opts, state, PhaseShifting, fmt.Sprintf("shifted %d%% of traffic to gateway", weight)); err != nil {
+			return err
+		}
+		if err := c.sleep(ctx, opts.StepInterval); err != nil {
+			return err
+		}
+		gaps, err := c.healthGaps(ctx, opts, groupID)
+		if err != nil {
+			return err
+		}
+		if len(gaps) != 0 {
+			reason := fmt.Sprintf("gateway degraded at %d%%: %s", weight, strings.Join(gaps, "; "))
+			c.logf("Rolling back, %s", reason)
+			if err := c.rollback(ctx, opts, state, shifter, reason); err != nil {
+				return err
+			}
+			return fmt.Errorf("cutover rolled back, %s", reason)
+		}
+		state.CompletedSteps++
+		if err := c.transition(opts, state, PhaseShifting, fmt.Sprintf("step %d observed healthy", state.CompletedSteps)); err != nil {
+			return err
+		}
+	}
+	if err := c.transition(opts, state, PhaseCompleted, "all traffic shifted to gateway"); err != nil {
+		return err
+	}
+	c.logf("Cutover completed, all traffic is served by gateway %s", state.Gateway)
+	return nil
+}
+
+func (c *defaultCutover) Rollback(ctx context.Context, opts Options) error {
+	state, err := loadState(opts.StateFile)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("state file %s not found, nothing to roll back", opts.StateFile)
+	}
+	if err := validateResumable(state, opts); err != nil {
+		return err
+	}
+	if state.IngressLoadBalancerDNS == "" || state.GatewayLoadBalancerDNS == "" {
+		// no traffic was shifted yet.
+		return c.transition(opts, state, PhaseRolledBack, "rolled back before traffic was shifted")
+	}
+	shifter, err := c.newTrafficShifter(ctx, state.TrafficTarget, state.IngressLoadBalancerDNS, state.GatewayLoadBalancerDNS)
+	if err != nil {
+		return err
+	}
+	if err := c.rollback(ctx, opts, state, shifter, "rolled back on request"); err != nil {
+		return err
+	}
+	c.logf("Rolled back, all traffic is served by ingress %s", state.Ingress)
+	return nil
+}
+
+func (c *defaultCutover) rollback(ctx context.Context, opts Options, state *State, shifter trafficShifter, reason string) error {
+	if err := shifter.setWeights(ctx, 0); err != nil {
+		return fmt.Errorf("failed to shift traffic back to ingress: %w", err)
+	}
+	state.GatewayWeight = 0
+	return c.transition(opts, state, PhaseRolledBack, reason)
+}
+
+// waitForGatewayReady waits until the Gateway is Programmed and its targets are as healthy as the Ingress targets.
+// It returns the DNS name of the Gateway load balancer.
+func (c *defaultCutover) waitForGatewayReady(ctx context.Context, opts Options, groupID ingress.GroupID) (string, error) {
+	var gatewayLBDNS string
+	var lastGaps []string
+	c.logf("Waiting for gateway %s to be ready", opts.Gateway)
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, opts.ReadinessTimeout, true, func(ctx context.Context) (bool, error) {
+		dnsName, programmed, err := c.readiness.gatewayProgrammed(ctx, opts.Gateway)
+		if err != nil {
+			return false, err
+		}
+		if !programmed {
+			lastGaps = []string{"gateway is not programmed"}
+			return false, nil
+		}
+		gaps, err := c.readiness.targetHealthGaps(ctx, groupID, opts.Gateway)
+		if err != nil {
+			return false, err
+		}
+		if len(gaps) != 0 {
+			lastGaps = gaps
+			return false, nil
+		}
+		gatewayLBDNS = dnsName
+		return true, nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) && len(lastGaps) != 0 {
+			return "", fmt.Errorf("timed out waiting for gateway %s to be ready: %s", opts.Gateway, strings.Join(lastGaps, "; "))
+		}
+		return "", err
+	}
+	return gatewayLBDNS, nil
+}
+
+// healthGaps checks the Gateway is still Programmed with targets as healthy as the Ingress targets.
+func (c *defaultCutover) healthGaps(ctx context.Context, opts Options, groupID ingress.GroupID) ([]string, error) {
+	_, programmed, err := c.readiness.gatewayProgrammed(ctx, opts.Gateway)
+	if err != nil {
+		return nil, err
+	}
+	if !programmed {
+		return []string{"gateway is not programmed"}, nil
+	}
+	return c.readiness.targetHealthGaps(ctx, groupID, opts.Gateway)
+}
+
+func (c *defaultCutover) loadOrInitState(opts Options) (*State, error) {
+	state, err := loadState(opts.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		if err := validateResumable(state, opts); err != nil {
+			return nil, err
+		}
+		c.logf("Resuming cutover from state file %s, phase %s, %d%% of traffic on gateway", opts.StateFile, state.Phase, state.GatewayWeight)
+		return state, nil
+	}
+	state = &State{
+		Ingress:       opts.Ingress.String(),
+		Gateway:       opts.Gateway.String(),
+		TrafficTarget: opts.TrafficTarget,
+		Steps:         opts.Steps,
+		Phase:         PhasePending,
+	}
+	return state, nil
+}
+
+// transition records the phase of the cutover in the state file.
+func (c *defaultCutover) transition(opts Options, state *State, phase Phase, message string) error {
+	state.Phase = phase
+	state.Message = message
+	state.UpdatedAt = c.now().UTC()
+	return saveState(opts.StateFile, state)
+}
+
+func (c *defaultCutover) logf(format string, args ...any) {
+	fmt.Fprintf(c.out, format+"\n", args...)
+}
+
+// validateSteps checks steps are increasing percentages ending at 100.
+func validateSteps(steps []int32) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	var prev int32
+	for _, step := range steps {
+		if step <= prev || step > 100 {
+			return fmt.Errorf("steps must be increasing percentages between 1 and 100, got %v", steps)
+		}
+		prev = step
+	}
+	if prev != 100 {
+		return fmt.Errorf("the last step must be 100, got %v", steps)
+	}
+	return nil
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,307 @@
+package cutover
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeTrafficShifter struct {
+	weights []int32
+}
+
+func (s *fakeTrafficShifter) setWeights(_ context.Context, gatewayWeight int32) error {
+	s.weights = append(s.weights, gatewayWeight)
+	return nil
+}
+
+func newTestCutover(k8sClient client.Client, shifter *fakeTrafficShifter, onSleep func(ctx context.Context)) *defaultCutover {
+	return &defaultCutover{
+		k8sClient: k8sClient,
+		readiness: newTestReadinessChecker(k8sClient),
+		newTrafficShifter: func(_ context.Context, _ TrafficTarget, _ string, _ string) (trafficShifter, error) {
+			return shifter, nil
+		},
+		out:          &testWriter{},
+		pollInterval: time.Millisecond,
+		sleep: func(ctx context.Context, _ time.Duration) error {
+			if onSleep != nil {
+				onSleep(ctx)
+			}
+			return nil
+		},
+		now: func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+type testWriter struct{}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func newTestOptions(t *testing.T, steps []int32) Options {
+	return Options{
+		Ingress:          types.NamespacedName{Namespace: "ns", Name: "ing"},
+		Gateway:          types.NamespacedName{Namespace: "ns", Name: "gw"},
+		TrafficTarget:    TrafficTarget{Type: TrafficTargetTypeRoute53, RecordName: "app.example.com"},
+		Steps:            steps,
+		StepInterval:     time.Minute,
+		ReadinessTimeout: time.Second,
+		StateFile:        filepath.Join(t.TempDir(), "state.json"),
+	}
+}
+
+func newHealthyObjects() []client.Object {
+	return []client.Object{
+		newTestIngress("awesome-group", testIngressLBDNS),
+		newTestGateway(true, testGatewayLBDNS),
+		newTestTGB("ing-svc-a", ingressGroupLabels, "svc-a", int32Ptr(2)),
+		newTestTGB("gw-svc-a", gatewayLabels, "svc-a", int32Ptr(2)),
+	}
+}
+
+func Test_defaultCutover_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		steps       []int32
+		state       *State
+		onSleep     func(ctx context.Context, k8sClient client.Client)
+		wantWeights []int32
+		wantState   State
+		wantErr     string
+	}{
+		{
+			name:        "all steps observed healthy",
+			objects:     newHealthyObjects(),
+			steps:       []int32{10, 50, 100},
+			wantWeights: []int32{0, 10, 50, 100},
+			wantState: State{
+				Phase:                  PhaseCompleted,
+				CompletedSteps:         3,
+				GatewayWeight:          100,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+				Message:                "all traffic shifted to gateway",
+			},
+		},
+		{
+			name:    "gateway degraded during a step",
+			objects: newHealthyObjects(),
+			steps:   []int32{10, 100},
+			onSleep: func(ctx context.Context, k8sClient client.Client) {
+				tgb := &elbv2api.TargetGroupBinding{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "gw-svc-a"}, tgb)
+				tgb.Status.TargetHealth.Healthy = 0
+				_ = k8sClient.Status().Update(ctx, tgb)
+			},
+			wantWeights: []int32{0, 10, 0},
+			wantState: State{
+				Phase:                  PhaseRolledBack,
+				GatewayWeight:          0,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+				Message:                "gateway degraded at 10%: ns/svc-a:80: 0 healthy targets for the gateway, 2 for the ingress",
+			},
+			wantErr: "cutover rolled back, gateway degraded at 10%: ns/svc-a:80: 0 healthy targets for the gateway, 2 for the ingress",
+		},
+		{
+			name:    "gateway never ready",
+			objects: []client.Object{newTestIngress("awesome-group", testIngressLBDNS), newTestGateway(false, "")},
+			steps:   []int32{100},
+			wantState: State{
+				Phase:   PhaseGatewayApplied,
+				Message: "manifests applied",
+			},
+			wantErr: "timed out waiting for gateway ns/gw to be ready: gateway is not programmed",
+		},
+		{
+			name:    "resumed from an interrupted step",
+			objects: newHealthyObjects(),
+			steps:   []int32{10, 50, 100},
+			state: &State{
+				Phase:                  PhaseShifting,
+				CompletedSteps:         1,
+				GatewayWeight:          50,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+			},
+			wantWeights: []int32{50, 100},
+			wantState: State{
+				Phase:                  PhaseCompleted,
+				CompletedSteps:         3,
+				GatewayWeight:          100,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+				Message:                "all traffic shifted to gateway",
+			},
+		},
+		{
+			name:    "rolled back cutover is not resumed",
+			objects: newHealthyObjects(),
+			steps:   []int32{100},
+			state: &State{
+				Phase:   PhaseRolledBack,
+				Message: "rolled back on request",
+			},
+			wantState: State{
+				Phase:   PhaseRolledBack,
+				Message: "rolled back on request",
+			},
+			wantErr: "cutover was rolled back: rolled back on request; remove state file",
+		},
+		{
+			name:    "steps not ending at 100",
+			objects: newHealthyObjects(),
+			steps:   []int32{10, 50},
+			wantErr: "the last step must be 100, got [10 50]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(tt.objects...).
+				WithStatusSubresource(&elbv2api.TargetGroupBinding{}).Build()
+			shifter := &fakeTrafficShifter{}
+			var onSleep func(ctx context.Context)
+			if tt.onSleep != nil {
+				onSleep = func(ctx context.Context) { tt.onSleep(ctx, k8sClient) }
+			}
+			c := newTestCutover(k8sClient, shifter, onSleep)
+			opts := newTestOptions(t, tt.steps)
+			if tt.state != nil {
+				tt.state.Ingress = opts.Ingress.String()
+				tt.state.Gateway = opts.Gateway.String()
+				tt.state.TrafficTarget = opts.TrafficTarget
+				tt.state.Steps = opts.Steps
+				require.NoError(t, saveState(opts.StateFile, tt.state))
+			}
+
+			err := c.Run(context.Background(), opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantWeights, shifter.weights)
+
+			state, err := loadState(opts.StateFile)
+			require.NoError(t, err)
+			if tt.wantState.Phase == "" {
+				assert.Nil(t, state)
+				return
+			}
+			tt.wantState.Ingress = opts.Ingress.String()
+			tt.wantState.Gateway = opts.Gateway.String()
+			tt.wantState.TrafficTarget = opts.TrafficTarget
+			tt.wantState.Steps = opts.Steps
+			tt.wantState.UpdatedAt = state.UpdatedAt
+			assert.Equal(t, tt.wantState, *state)
+		})
+	}
+}
+
+func Test_defaultCutover_Rollback(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       *State
+		wantWeights []int32
+		wantState   State
+		wantErr     string
+	}{
+		{
+			name: "rollback during shifting",
+			state: &State{
+				Phase:                  PhaseShifting,
+				CompletedSteps:         2,
+				GatewayWeight:          50,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+			},
+			wantWeights: []int32{0},
+			wantState: State{
+				Phase:                  PhaseRolledBack,
+				CompletedSteps:         2,
+				IngressLoadBalancerDNS: testIngressLBDNS,
+				GatewayLoadBalancerDNS: testGatewayLBDNS,
+				Message:                "rolled back on request",
+			},
+		},
+		{
+			name:  "rollback before traffic was shifted",
+			state: &State{Phase: PhaseGatewayApplied},
+			wantState: State{
+				Phase:   PhaseRolledBack,
+				Message: "rolled back before traffic was shifted",
+			},
+		},
+		{
+			name:    "no state file",
+			wantErr: "not found, nothing to roll back",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(newTestScheme()).Build()
+			shifter := &fakeTrafficShifter{}
+			c := newTestCutover(k8sClient, shifter, nil)
+			opts := newTestOptions(t, []int32{10, 50, 100})
+			if tt.state != nil {
+				tt.state.Ingress = opts.Ingress.String()
+				tt.state.Gateway = opts.Gateway.String()
+				tt.state.TrafficTarget = opts.TrafficTarget
+				tt.state.Steps = opts.Steps
+				require.NoError(t, saveState(opts.StateFile, tt.state))
+			}
+
+			err := c.Rollback(context.Background(), opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWeights, shifter.weights)
+			state, err := loadState(opts.StateFile)
+			require.NoError(t, err)
+			tt.wantState.Ingress = opts.Ingress.String()
+			tt.wantState.Gateway = opts.Gateway.String()
+			tt.wantState.TrafficTarget = opts.TrafficTarget
+			tt.wantState.Steps = opts.Steps
+			tt.wantState.UpdatedAt = state.UpdatedAt
+			assert.Equal(t, tt.wantState, *state)
+		})
+	}
+}
+
+func Test_validateSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []int32
+		wantErr string
+	}{
+		{name: "valid", steps: []int32{10, 25, 50, 100}},
+		{name: "single step", steps: []int32{100}},
+		{name: "empty", wantErr: "at least one step is required"},
+		{name: "not increasing", steps: []int32{50, 25, 100}, wantErr: "steps must be increasing percentages between 1 and 100, got [50 25 100]"},
+		{name: "zero", steps: []int32{0, 100}, wantErr: "steps must be increasing percentages between 1 and 100, got [0 100]"},
+		{name: "above 100", steps: []int32{50, 150}, wantErr: "steps must be increasing percentages between 1 and 100, got [50 150]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSteps(tt.steps)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
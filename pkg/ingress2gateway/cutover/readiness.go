@@ -0,0 +1,116 @@
+package cutover
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	gateway_constants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const ingressTagPrefix = "ingress.k8s.aws"
+
+// readinessChecker checks whether the Gateway is ready to take over the traffic of the IngressGroup.
+type readinessChecker struct {
+	k8sClient   client.Client
+	groupLoader ingress.GroupLoader
+}
+
+// ingressGroup returns the IngressGroup of the Ingress and the DNS name of its load balancer.
+func (c *readinessChecker) ingressGroup(ctx context.Context, ingKey types.NamespacedName) (ingress.GroupID, string, error) {
+	ing := &networking.Ingress{}
+	if err := c.k8sClient.Get(ctx, ingKey, ing); err != nil {
+		return ingress.GroupID{}, "", err
+	}
+	groupID, err := c.groupLoader.LoadGroupIDIfAny(ctx, ing)
+	if err != nil {
+		return ingress.GroupID{}, "", err
+	}
+	if groupID == nil {
+		return ingress.GroupID{}, "", fmt.Errorf("ingress %s is not managed by the controller", ingKey)
+	}
+	for _, lbIngress := range ing.Status.LoadBalancer.Ingress {
+		if lbIngress.Hostname != "" {
+			return *groupID, lbIngress.Hostname, nil
+		}
+	}
+	return ingress.GroupID{}, "", fmt.Errorf("ingress %s has no load balancer hostname in status", ingKey)
+}
+
+// gatewayProgrammed returns the DNS name of the Gateway load balancer once the Gateway is Programmed.
+func (c *readinessChecker) gatewayProgrammed(ctx context.Context, gwKey types.NamespacedName) (string, bool, error) {
+	gw := &gwv1.Gateway{}
+	if err := c.k8sClient.Get(ctx, gwKey, gw); err != nil {
+		return "", false, err
+	}
+	programmed := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+	if programmed == nil || programmed.Status != "True" || programmed.ObservedGeneration != gw.Generation {
+		return "", false, nil
+	}
+	for _, addr := range gw.Status.Addresses {
+		if addr.Value != "" {
+			return addr.Value, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// targetHealthGaps compares the target health of the Gateway with the IngressGroup, per backend service port.
+// It returns why the Gateway targets are not yet as healthy as the Ingress targets, or nil if they are.
+func (c *readinessChecker) targetHealthGaps(ctx context.Context, groupID ingress.GroupID, gwKey types.NamespacedName) ([]string, error) {
+	ingTGBs, err := c.listStackTGBs(ctx, ingressTagPrefix, core.StackID(groupID))
+	if err != nil {
+		return nil, err
+	}
+	gwTGBs, err := c.listStackTGBs(ctx, gateway_constants.ALBGatewayTagPrefix, core.StackID(gwKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var gaps []string
+	for backend, ingTGB := range ingTGBs {
+		var ingHealthy int32
+		if ingTGB.Status.TargetHealth != nil {
+			ingHealthy = ingTGB.Status.TargetHealth.Healthy
+		}
+		gwTGB, exists := gwTGBs[backend]
+		if !exists {
+			gaps = append(gaps, fmt.Sprintf("%s: no target group for the gateway yet", backend))
+			continue
+		}
+		if gwTGB.Status.TargetHealth == nil {
+			gaps = append(gaps, fmt.Sprintf("%s: target health of the gateway is not reported yet", backend))
+			continue
+		}
+		if gwTGB.Status.TargetHealth.Healthy < ingHealthy {
+			gaps = append(gaps, fmt.Sprintf("%s: %d healthy targets for the gateway, %d for the ingress",
+				backend, gwTGB.Status.TargetHealth.Healthy, ingHealthy))
+		}
+	}
+	sort.Strings(gaps)
+	return gaps, nil
+}
+
+// listStackTGBs lists the TargetGroupBindings of a stack, keyed by their backend service port.
+func (c *readinessChecker) listStackTGBs(ctx context.Context, tagPrefix string, stackID core.StackID) (map[string]elbv2api.TargetGroupBinding, error) {
+	stackLabels := tracking.NewDefaultProvider(tagPrefix, "").StackLabels(core.NewDefaultStack(stackID))
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := c.k8sClient.List(ctx, tgbList, client.MatchingLabels(stackLabels)); err != nil {
+		return nil, err
+	}
+	tgbByBackend := make(map[string]elbv2api.TargetGroupBinding, len(tgbList.Items))
+	for _, tgb := range tgbList.Items {
+		backend := fmt.Sprintf("%s/%s:%s", tgb.Namespace, tgb.Spec.ServiceRef.Name, tgb.Spec.ServiceRef.Port.String())
+		tgbByBackend[backend] = tgb
+	}
+	return tgbByBackend, nil
+}
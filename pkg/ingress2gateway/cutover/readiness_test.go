@@ -0,0 +1,232 @@
+package cutover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func newTestScheme() *runtime.Scheme {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	gwv1.Install(k8sSchema)
+	return k8sSchema
+}
+
+func newTestReadinessChecker(k8sClient client.Client) *readinessChecker {
+	return &readinessChecker{
+		k8sClient: k8sClient,
+		groupLoader: ingress.NewDefaultGroupLoader(k8sClient, &record.FakeRecorder{},
+			annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress),
+			ingress.NewDefaultClassLoader(k8sClient, true),
+			ingress.NewDefaultClassAnnotationMatcher("alb"), false),
+	}
+}
+
+func newTestIngress(groupName string, lbDNS string) *networking.Ingress {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "ing",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "alb"},
+		},
+	}
+	if groupName != "" {
+		ing.Annotations["alb.ingress.kubernetes.io/group.name"] = groupName
+	}
+	if lbDNS != "" {
+		ing.Status.LoadBalancer.Ingress = []networking.IngressLoadBalancerIngress{{Hostname: lbDNS}}
+	}
+	return ing
+}
+
+func newTestGateway(programmed bool, lbDNS string) *gwv1.Gateway {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw", Generation: 1},
+	}
+	status := metav1.ConditionFalse
+	if programmed {
+		status = metav1.ConditionTrue
+	}
+	gw.Status.Conditions = []metav1.Condition{{
+		Type:               string(gwv1.GatewayConditionProgrammed),
+		Status:             status,
+		ObservedGeneration: 1,
+		Reason:             "Programmed",
+	}}
+	if lbDNS != "" {
+		gw.Status.Addresses = []gwv1.GatewayStatusAddress{{Value: lbDNS}}
+	}
+	return gw
+}
+
+func newTestTGB(name string, stackLabels map[string]string, svcName string, healthy *int32) *elbv2api.TargetGroupBinding {
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: stackLabels},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			ServiceRef: elbv2api.ServiceReference{Name: svcName, Port: intstr.FromInt32(80)},
+		},
+	}
+	if healthy != nil {
+		tgb.Status.TargetHealth = &elbv2api.TargetHealthSummary{Healthy: *healthy}
+	}
+	return tgb
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+var (
+	ingressGroupLabels = map[string]string{"ingress.k8s.aws/stack": "awesome-group"}
+	gatewayLabels      = map[string]string{"gateway.k8s.aws.alb/stack-namespace": "ns", "gateway.k8s.aws.alb/stack-name": "gw"}
+)
+
+func Test_readinessChecker_ingressGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		ing         *networking.Ingress
+		wantGroupID ingress.GroupID
+		wantDNS     string
+		wantErr     string
+	}{
+		{
+			name:        "explicit group",
+			ing:         newTestIngress("awesome-group", "ing-lb.elb.amazonaws.com"),
+			wantGroupID: ingress.GroupID{Name: "awesome-group"},
+			wantDNS:     "ing-lb.elb.amazonaws.com",
+		},
+		{
+			name:        "implicit group",
+			ing:         newTestIngress("", "ing-lb.elb.amazonaws.com"),
+			wantGroupID: ingress.GroupID{Namespace: "ns", Name: "ing"},
+			wantDNS:     "ing-lb.elb.amazonaws.com",
+		},
+		{
+			name:    "load balancer not provisioned",
+			ing:     newTestIngress("", ""),
+			wantErr: "ingress ns/ing has no load balancer hostname in status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(tt.ing).Build()
+			c := newTestReadinessChecker(k8sClient)
+			groupID, dnsName, err := c.ingressGroup(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ing"})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantGroupID, groupID)
+				assert.Equal(t, tt.wantDNS, dnsName)
+			}
+		})
+	}
+}
+
+func Test_readinessChecker_gatewayProgrammed(t *testing.T) {
+	tests := []struct {
+		name           string
+		gw             *gwv1.Gateway
+		wantDNS        string
+		wantProgrammed bool
+	}{
+		{
+			name:           "programmed",
+			gw:             newTestGateway(true, "gw-lb.elb.amazonaws.com"),
+			wantDNS:        "gw-lb.elb.amazonaws.com",
+			wantProgrammed: true,
+		},
+		{
+			name: "not programmed",
+			gw:   newTestGateway(false, "gw-lb.elb.amazonaws.com"),
+		},
+		{
+			name: "programmed condition of a previous generation",
+			gw: func() *gwv1.Gateway {
+				gw := newTestGateway(true, "gw-lb.elb.amazonaws.com")
+				gw.Generation = 2
+				return gw
+			}(),
+		},
+		{
+			name: "programmed without address",
+			gw:   newTestGateway(true, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(tt.gw).Build()
+			c := newTestReadinessChecker(k8sClient)
+			dnsName, programmed, err := c.gatewayProgrammed(context.Background(), types.NamespacedName{Namespace: "ns", Name: "gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDNS, dnsName)
+			assert.Equal(t, tt.wantProgrammed, programmed)
+		})
+	}
+}
+
+func Test_readinessChecker_targetHealthGaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		wantGaps []string
+	}{
+		{
+			name: "gateway targets as healthy as ingress targets",
+			objects: []client.Object{
+				newTestTGB("ing-svc-a", ingressGroupLabels, "svc-a", int32Ptr(2)),
+				newTestTGB("ing-svc-b", ingressGroupLabels, "svc-b", int32Ptr(1)),
+				newTestTGB("gw-svc-a", gatewayLabels, "svc-a", int32Ptr(2)),
+				newTestTGB("gw-svc-b", gatewayLabels, "svc-b", int32Ptr(3)),
+			},
+		},
+		{
+			name: "gateway targets missing, unreported or less healthy",
+			objects: []client.Object{
+				newTestTGB("ing-svc-a", ingressGroupLabels, "svc-a", int32Ptr(2)),
+				newTestTGB("ing-svc-b", ingressGroupLabels, "svc-b", int32Ptr(1)),
+				newTestTGB("ing-svc-c", ingressGroupLabels, "svc-c", int32Ptr(1)),
+				newTestTGB("gw-svc-a", gatewayLabels, "svc-a", int32Ptr(1)),
+				newTestTGB("gw-svc-b", gatewayLabels, "svc-b", nil),
+			},
+			wantGaps: []string{
+				"ns/svc-a:80: 1 healthy targets for the gateway, 2 for the ingress",
+				"ns/svc-b:80: target health of the gateway is not reported yet",
+				"ns/svc-c:80: no target group for the gateway yet",
+			},
+		},
+		{
+			name: "target groups of other stacks are ignored",
+			objects: []client.Object{
+				newTestTGB("ing-svc-a", ingressGroupLabels, "svc-a", int32Ptr(2)),
+				newTestTGB("gw-svc-a", gatewayLabels, "svc-a", int32Ptr(2)),
+				newTestTGB("other-svc-b", map[string]string{"ingress.k8s.aws/stack": "other-group"}, "svc-b", int32Ptr(1)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(tt.objects...).Build()
+			c := newTestReadinessChecker(k8sClient)
+			gaps, err := c.targetHealthGaps(context.Background(), ingress.GroupID{Name: "awesome-group"}, types.NamespacedName{Namespace: "ns", Name: "gw"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantGaps, gaps)
+		})
+	}
+}
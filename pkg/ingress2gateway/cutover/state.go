@@ -0,0 +1,67 @@
+package cutover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// loadState reads the state file, it returns nil if the state file doesn't exist.
+func loadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read state file %s: %w", path, err)
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveState writes the state file atomically, so that an interruption never leaves a partial state file behind.
+func saveState(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(append(data, '\n')); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// validateResumable checks that a persisted state belongs to the cutover described by opts.
+func validateResumable(state *State, opts Options) error {
+	if state.Ingress != opts.Ingress.String() || state.Gateway != opts.Gateway.String() {
+		return fmt.Errorf("state file belongs to the cutover of ingress %s to gateway %s, remove it to start a new cutover",
+			state.Ingress, state.Gateway)
+	}
+	if state.TrafficTarget.Type != opts.TrafficTarget.Type ||
+		state.TrafficTarget.RecordName != opts.TrafficTarget.RecordName ||
+		state.TrafficTarget.EndpointGroupARN != opts.TrafficTarget.EndpointGroupARN {
+		return fmt.Errorf("state file was created for a different traffic target, remove it to start a new cutover")
+	}
+	if len(opts.Steps) != 0 && !slices.Equal(state.Steps, opts.Steps) {
+		return fmt.Errorf("state file was created with steps %v, got %v", state.Steps, opts.Steps)
+	}
+	return nil
+}
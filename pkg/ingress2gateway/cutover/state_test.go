@@ -0,0 +1,95 @@
+package cutover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_saveState_loadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := loadState(path)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	want := &State{
+		Ingress:        "ns/ing",
+		Gateway:        "ns/gw",
+		TrafficTarget:  TrafficTarget{Type: TrafficTargetTypeRoute53, RecordName: "app.example.com"},
+		Steps:          []int32{10, 100},
+		Phase:          PhaseShifting,
+		CompletedSteps: 1,
+		GatewayWeight:  10,
+		UpdatedAt:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, saveState(path, want))
+	state, err = loadState(path)
+	assert.NoError(t, err)
+	assert.Equal(t, want, state)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary state files should be cleaned up")
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = loadState(path)
+	assert.ErrorContains(t, err, "failed to parse state file")
+}
+
+func Test_validateResumable(t *testing.T) {
+	state := &State{
+		Ingress:       "ns/ing",
+		Gateway:       "ns/gw",
+		TrafficTarget: TrafficTarget{Type: TrafficTargetTypeRoute53, RecordName: "app.example.com"},
+		Steps:         []int32{10, 100},
+	}
+	opts := Options{
+		Ingress:       types.NamespacedName{Namespace: "ns", Name: "ing"},
+		Gateway:       types.NamespacedName{Namespace: "ns", Name: "gw"},
+		TrafficTarget: TrafficTarget{Type: TrafficTargetTypeRoute53, RecordName: "app.example.com", HostedZoneID: "Z123"},
+		Steps:         []int32{10, 100},
+	}
+	tests := []struct {
+		name    string
+		mutate  func(opts *Options)
+		wantErr string
+	}{
+		{
+			name:   "same cutover",
+			mutate: func(opts *Options) {},
+		},
+		{
+			name:    "different gateway",
+			mutate:  func(opts *Options) { opts.Gateway.Name = "other" },
+			wantErr: "state file belongs to the cutover of ingress ns/ing to gateway ns/gw, remove it to start a new cutover",
+		},
+		{
+			name:    "different traffic target",
+			mutate:  func(opts *Options) { opts.TrafficTarget.RecordName = "other.example.com" },
+			wantErr: "state file was created for a different traffic target, remove it to start a new cutover",
+		},
+		{
+			name:    "different steps",
+			mutate:  func(opts *Options) { opts.Steps = []int32{50, 100} },
+			wantErr: "state file was created with steps [10 100], got [50 100]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := opts
+			tt.mutate(&o)
+			err := validateResumable(state, o)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
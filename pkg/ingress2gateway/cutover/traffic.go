@@ -0,0 +1,199 @@
+package cutover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	route53sdk "github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	// setIdentifierIngress identifies the weighted record pointing to the Ingress load balancer.
+	setIdentifierIngress = "lbc-migrate-ingress"
+	// setIdentifierGateway identifies the weighted record pointing to the Gateway load balancer.
+	setIdentifierGateway = "lbc-migrate-gateway"
+)
+
+// trafficShifter splits traffic between the Ingress and the Gateway load balancers.
+type trafficShifter interface {
+	// setWeights sends gatewayWeight percent of traffic to the Gateway load balancer, and the rest to the Ingress load balancer.
+	setWeights(ctx context.Context, gatewayWeight int32) error
+}
+
+// newTrafficShifter constructs the trafficShifter for the traffic target.
+func newTrafficShifter(ctx context.Context, target TrafficTarget, ingressLBDNS string, gatewayLBDNS string,
+	elbv2Client services.ELBV2, route53Client services.Route53, gaClient services.GlobalAccelerator) (trafficShifter, error) {
+	lbs, err := resolveLoadBalancers(ctx, elbv2Client, ingressLBDNS, gatewayLBDNS)
+	if err != nil {
+		return nil, err
+	}
+	ingressLB, gatewayLB := lbs[0], lbs[1]
+
+	switch target.Type {
+	case TrafficTargetTypeRoute53:
+		hostedZoneID := target.HostedZoneID
+		if hostedZoneID == "" {
+			zoneID, err := route53Client.GetHostedZoneID(ctx, target.RecordName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find hosted zone of %s: %w", target.RecordName, err)
+			}
+			hostedZoneID = awssdk.ToString(zoneID)
+		}
+		return &route53Shifter{
+			route53Client: route53Client,
+			hostedZoneID:  hostedZoneID,
+			recordName:    target.RecordName,
+			ingressLB:     ingressLB,
+			gatewayLB:     gatewayLB,
+		}, nil
+	case TrafficTargetTypeGlobalAccelerator:
+		return &acceleratorShifter{
+			gaClient:         gaClient,
+			endpointGroupARN: target.EndpointGroupARN,
+			ingressLBARN:     awssdk.ToString(ingressLB.LoadBalancerArn),
+			gatewayLBARN:     awssdk.ToString(gatewayLB.LoadBalancerArn),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported traffic target type: %s", target.Type)
+	}
+}
+
+// resolveLoadBalancers finds the load balancers with the DNS names, in the same order.
+func resolveLoadBalancers(ctx context.Context, elbv2Client services.ELBV2, dnsNames ...string) ([]elbv2types.LoadBalancer, error) {
+	allLBs, err := elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+	if err != nil {
+		return nil, err
+	}
+	lbs := make([]elbv2types.LoadBalancer, 0, len(dnsNames))
+	for _, dnsName := range dnsNames {
+		found := false
+		for _, lb := range allLBs {
+			if strings.EqualFold(awssdk.ToString(lb.DNSName), dnsName) {
+				lbs = append(lbs, lb)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no load balancer found with DNS name %s", dnsName)
+		}
+	}
+	return lbs, nil
+}
+
+// route53Shifter shifts traffic via a pair of weighted alias records, and a pair of weighted AAAA alias records
+// when either load balancer is dualstack.
+type route53Shifter struct {
+	route53Client services.Route53
+	hostedZoneID  string
+	recordName    string
+	ingressLB     elbv2types.LoadBalancer
+	gatewayLB     elbv2types.LoadBalancer
+}
+
+func (s *route53Shifter) setWeights(ctx context.Context, gatewayWeight int32) error {
+	recordTypes := []route53types.RRType{route53types.RRTypeA}
+	// IPv6 clients would otherwise keep resolving the existing AAAA records, and never shift to the Gateway load balancer.
+	if isDualstackLoadBalancer(s.ingressLB) || isDualstackLoadBalancer(s.gatewayLB) {
+		recordTypes = append(recordTypes, route53types.RRTypeAaaa)
+	}
+	var changes []route53types.Change
+	for _, recordType := range recordTypes {
+		changes = append(changes,
+			s.weightedRecordChange(recordType, setIdentifierIngress, s.ingressLB, 100-gatewayWeight),
+			s.weightedRecordChange(recordType, setIdentifierGateway, s.gatewayLB, gatewayWeight),
+		)
+	}
+	_, err := s.route53Client.ChangeRecordsWithContext(ctx, &route53sdk.ChangeResourceRecordSetsInput{
+		HostedZoneId: awssdk.String(s.hostedZoneID),
+		ChangeBatch: &route53types.ChangeBatch{
+			Comment: awssdk.String(fmt.Sprintf("lbc-migrate cutover: %d%% to gateway", gatewayWeight)),
+			Changes: changes,
+		},
+	})
+	return err
+}
+
+func (s *route53Shifter) weightedRecordChange(recordType route53types.RRType, setIdentifier string, lb elbv2types.LoadBalancer, weight int32) route53types.Change {
+	return route53types.Change{
+		Action: route53types.ChangeActionUpsert,
+		ResourceRecordSet: &route53types.ResourceRecordSet{
+			Name:          awssdk.String(s.recordName),
+			Type:          recordType,
+			SetIdentifier: awssdk.String(setIdentifier),
+			Weight:        awssdk.Int64(int64(weight)),
+			AliasTarget: &route53types.AliasTarget{
+				DNSName:              lb.DNSName,
+				HostedZoneId:         lb.CanonicalHostedZoneId,
+				EvaluateTargetHealth: true,
+			},
+		},
+	}
+}
+
+// isDualstackLoadBalancer checks whether lb has IPv6 addresses.
+func isDualstackLoadBalancer(lb elbv2types.LoadBalancer) bool {
+	return lb.IpAddressType == elbv2types.IpAddressTypeDualstack || lb.IpAddressType == elbv2types.IpAddressTypeDualstackWithoutPublicIpv4
+}
+
+// acceleratorShifter shifts traffic via the endpoint weights of a GlobalAccelerator endpoint group.
+// Endpoints other than the Ingress and Gateway load balancers are left untouched.
+type acceleratorShifter struct {
+	gaClient         services.GlobalAccelerator
+	endpointGroupARN string
+	ingressLBARN     string
+	gatewayLBARN     string
+}
+
+func (s *acceleratorShifter) setWeights(ctx context.Context, gatewayWeight int32) error {
+	resp, err := s.gaClient.DescribeEndpointGroupWithContext(ctx, &gasdk.DescribeEndpointGroupInput{
+		EndpointGroupArn: awssdk.String(s.endpointGroupARN),
+	})
+	if err != nil {
+		return err
+	}
+
+	var configs []gatypes.EndpointConfiguration
+	var ingressEndpoint *gatypes.EndpointDescription
+	for i, endpoint := range resp.EndpointGroup.EndpointDescriptions {
+		switch awssdk.ToString(endpoint.EndpointId) {
+		case s.ingressLBARN:
+			ingressEndpoint = &resp.EndpointGroup.EndpointDescriptions[i]
+		case s.gatewayLBARN:
+		default:
+			configs = append(configs, gatypes.EndpointConfiguration{
+				EndpointId:                  endpoint.EndpointId,
+				Weight:                      endpoint.Weight,
+				ClientIPPreservationEnabled: endpoint.ClientIPPreservationEnabled,
+			})
+		}
+	}
+	if ingressEndpoint == nil {
+		return fmt.Errorf("endpoint group %s doesn't contain the ingress load balancer %s", s.endpointGroupARN, s.ingressLBARN)
+	}
+	configs = append(configs,
+		gatypes.EndpointConfiguration{
+			EndpointId:                  awssdk.String(s.ingressLBARN),
+			Weight:                      awssdk.Int32(100 - gatewayWeight),
+			ClientIPPreservationEnabled: ingressEndpoint.ClientIPPreservationEnabled,
+		},
+		gatypes.EndpointConfiguration{
+			EndpointId:                  awssdk.String(s.gatewayLBARN),
+			Weight:                      awssdk.Int32(gatewayWeight),
+			ClientIPPreservationEnabled: ingressEndpoint.ClientIPPreservationEnabled,
+		},
+	)
+	_, err = s.gaClient.UpdateEndpointGroupWithContext(ctx, &gasdk.UpdateEndpointGroupInput{
+		EndpointGroupArn:       awssdk.String(s.endpointGroupARN),
+		EndpointConfigurations: configs,
+	})
+	return err
+}
@@ -0,0 +1,264 @@
+package cutover
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	route53sdk "github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	testIngressLBARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-awesomegroup/ing"
+	testGatewayLBARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-ns-gw/gw"
+	testIngressLBDNS = "k8s-awesomegroup-ing.us-west-2.elb.amazonaws.com"
+	testGatewayLBDNS = "k8s-ns-gw-gw.us-west-2.elb.amazonaws.com"
+	testLBZoneID     = "Z1H1FL5HABSF5"
+	testEndpointGrp  = "arn:aws:globalaccelerator::123456789012:accelerator/abc/listener/l1/endpoint-group/eg1"
+)
+
+var testLoadBalancers = []elbv2types.LoadBalancer{
+	{LoadBalancerArn: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/other/other"), DNSName: awssdk.String("other.us-west-2.elb.amazonaws.com")},
+	{LoadBalancerArn: awssdk.String(testIngressLBARN), DNSName: awssdk.String(testIngressLBDNS), CanonicalHostedZoneId: awssdk.String(testLBZoneID)},
+	{LoadBalancerArn: awssdk.String(testGatewayLBARN), DNSName: awssdk.String(testGatewayLBDNS), CanonicalHostedZoneId: awssdk.String(testLBZoneID)},
+}
+
+func Test_newTrafficShifter(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       TrafficTarget
+		gatewayLBDNS string
+		setupMocks   func(elbv2Client *services.MockELBV2, route53Client *services.MockRoute53)
+		want         trafficShifter
+		wantErr      string
+	}{
+		{
+			name:         "route53 with hosted zone discovered",
+			target:       TrafficTarget{Type: TrafficTargetTypeRoute53, RecordName: "app.example.com"},
+			gatewayLBDNS: "K8S-NS-GW-GW.us-west-2.elb.amazonaws.com",
+			setupMocks: func(elbv2Client *services.MockELBV2, route53Client *services.MockRoute53) {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(testLoadBalancers, nil)
+				route53Client.EXPECT().GetHostedZoneID(gomock.Any(), "app.example.com").Return(awssdk.String("Z123"), nil)
+			},
+			want: &route53Shifter{
+				hostedZoneID: "Z123",
+				recordName:   "app.example.com",
+				ingressLB:    testLoadBalancers[1],
+				gatewayLB:    testLoadBalancers[2],
+			},
+		},
+		{
+			name:         "globalaccelerator",
+			target:       TrafficTarget{Type: TrafficTargetTypeGlobalAccelerator, EndpointGroupARN: testEndpointGrp},
+			gatewayLBDNS: testGatewayLBDNS,
+			setupMocks: func(elbv2Client *services.MockELBV2, route53Client *services.MockRoute53) {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(testLoadBalancers, nil)
+			},
+			want: &acceleratorShifter{
+				endpointGroupARN: testEndpointGrp,
+				ingressLBARN:     testIngressLBARN,
+				gatewayLBARN:     testGatewayLBARN,
+			},
+		},
+		{
+			name:         "gateway load balancer not found",
+			target:       TrafficTarget{Type: TrafficTargetTypeGlobalAccelerator, EndpointGroupARN: testEndpointGrp},
+			gatewayLBDNS: "missing.us-west-2.elb.amazonaws.com",
+			setupMocks: func(elbv2Client *services.MockELBV2, route53Client *services.MockRoute53) {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(testLoadBalancers, nil)
+			},
+			wantErr: "no load balancer found with DNS name missing.us-west-2.elb.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			route53Client := services.NewMockRoute53(ctrl)
+			gaClient := services.NewMockGlobalAccelerator(ctrl)
+			tt.setupMocks(elbv2Client, route53Client)
+
+			got, err := newTrafficShifter(context.Background(), tt.target, testIngressLBDNS, tt.gatewayLBDNS, elbv2Client, route53Client, gaClient)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			switch want := tt.want.(type) {
+			case *route53Shifter:
+				want.route53Client = route53Client
+			case *acceleratorShifter:
+				want.gaClient = gaClient
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_route53Shifter_setWeights(t *testing.T) {
+	weightedRecordChange := func(recordType route53types.RRType, setIdentifier string, dnsName string, weight int64) route53types.Change {
+		return route53types.Change{
+			Action: route53types.ChangeActionUpsert,
+			ResourceRecordSet: &route53types.ResourceRecordSet{
+				Name:          awssdk.String("app.example.com"),
+				Type:          recordType,
+				SetIdentifier: awssdk.String(setIdentifier),
+				Weight:        awssdk.Int64(weight),
+				AliasTarget: &route53types.AliasTarget{
+					DNSName:              awssdk.String(dnsName),
+					HostedZoneId:         awssdk.String(testLBZoneID),
+					EvaluateTargetHealth: true,
+				},
+			},
+		}
+	}
+	dualstackLB := func(lb elbv2types.LoadBalancer, ipAddressType elbv2types.IpAddressType) elbv2types.LoadBalancer {
+		lb.IpAddressType = ipAddressType
+		return lb
+	}
+	tests := []struct {
+		name        string
+		ingressLB   elbv2types.LoadBalancer
+		gatewayLB   elbv2types.LoadBalancer
+		wantChanges []route53types.Change
+	}{
+		{
+			name:      "ipv4 load balancers",
+			ingressLB: testLoadBalancers[1],
+			gatewayLB: testLoadBalancers[2],
+			wantChanges: []route53types.Change{
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-ingress", testIngressLBDNS, 75),
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-gateway", testGatewayLBDNS, 25),
+			},
+		},
+		{
+			name:      "dualstack ingress load balancer",
+			ingressLB: dualstackLB(testLoadBalancers[1], elbv2types.IpAddressTypeDualstack),
+			gatewayLB: testLoadBalancers[2],
+			wantChanges: []route53types.Change{
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-ingress", testIngressLBDNS, 75),
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-gateway", testGatewayLBDNS, 25),
+				weightedRecordChange(route53types.RRTypeAaaa, "lbc-migrate-ingress", testIngressLBDNS, 75),
+				weightedRecordChange(route53types.RRTypeAaaa, "lbc-migrate-gateway", testGatewayLBDNS, 25),
+			},
+		},
+		{
+			name:      "dualstack without public ipv4 gateway load balancer",
+			ingressLB: testLoadBalancers[1],
+			gatewayLB: dualstackLB(testLoadBalancers[2], elbv2types.IpAddressTypeDualstackWithoutPublicIpv4),
+			wantChanges: []route53types.Change{
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-ingress", testIngressLBDNS, 75),
+				weightedRecordChange(route53types.RRTypeA, "lbc-migrate-gateway", testGatewayLBDNS, 25),
+				weightedRecordChange(route53types.RRTypeAaaa, "lbc-migrate-ingress", testIngressLBDNS, 75),
+				weightedRecordChange(route53types.RRTypeAaaa, "lbc-migrate-gateway", testGatewayLBDNS, 25),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			route53Client := services.NewMockRoute53(ctrl)
+			route53Client.EXPECT().ChangeRecordsWithContext(gomock.Any(), &route53sdk.ChangeResourceRecordSetsInput{
+				HostedZoneId: awssdk.String("Z123"),
+				ChangeBatch: &route53types.ChangeBatch{
+					Comment: awssdk.String("lbc-migrate cutover: 25% to gateway"),
+					Changes: tt.wantChanges,
+				},
+			}).Return(&route53sdk.ChangeResourceRecordSetsOutput{}, nil)
+
+			s := &route53Shifter{
+				route53Client: route53Client,
+				hostedZoneID:  "Z123",
+				recordName:    "app.example.com",
+				ingressLB:     tt.ingressLB,
+				gatewayLB:     tt.gatewayLB,
+			}
+			assert.NoError(t, s.setWeights(context.Background(), 25))
+		})
+	}
+}
+
+func Test_acceleratorShifter_setWeights(t *testing.T) {
+	otherEndpointID := "arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/app/other/other"
+	tests := []struct {
+		name          string
+		endpoints     []gatypes.EndpointDescription
+		gatewayWeight int32
+		wantConfigs   []gatypes.EndpointConfiguration
+		wantErr       string
+	}{
+		{
+			name: "gateway endpoint added, other endpoints kept",
+			endpoints: []gatypes.EndpointDescription{
+				{EndpointId: awssdk.String(otherEndpointID), Weight: awssdk.Int32(128)},
+				{EndpointId: awssdk.String(testIngressLBARN), Weight: awssdk.Int32(128), ClientIPPreservationEnabled: awssdk.Bool(true)},
+			},
+			gatewayWeight: 10,
+			wantConfigs: []gatypes.EndpointConfiguration{
+				{EndpointId: awssdk.String(otherEndpointID), Weight: awssdk.Int32(128)},
+				{EndpointId: awssdk.String(testIngressLBARN), Weight: awssdk.Int32(90), ClientIPPreservationEnabled: awssdk.Bool(true)},
+				{EndpointId: awssdk.String(testGatewayLBARN), Weight: awssdk.Int32(10), ClientIPPreservationEnabled: awssdk.Bool(true)},
+			},
+		},
+		{
+			name: "gateway endpoint updated",
+			endpoints: []gatypes.EndpointDescription{
+				{EndpointId: awssdk.String(testIngressLBARN), Weight: awssdk.Int32(90)},
+				{EndpointId: awssdk.String(testGatewayLBARN), Weight: awssdk.Int32(10)},
+			},
+			gatewayWeight: 100,
+			wantConfigs: []gatypes.EndpointConfiguration{
+				{EndpointId: awssdk.String(testIngressLBARN), Weight: awssdk.Int32(0)},
+				{EndpointId: awssdk.String(testGatewayLBARN), Weight: awssdk.Int32(100)},
+			},
+		},
+		{
+			name: "ingress endpoint missing",
+			endpoints: []gatypes.EndpointDescription{
+				{EndpointId: awssdk.String(otherEndpointID), Weight: awssdk.Int32(128)},
+			},
+			gatewayWeight: 10,
+			wantErr:       "endpoint group " + testEndpointGrp + " doesn't contain the ingress load balancer " + testIngressLBARN,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gaClient := services.NewMockGlobalAccelerator(ctrl)
+			gaClient.EXPECT().DescribeEndpointGroupWithContext(gomock.Any(), &gasdk.DescribeEndpointGroupInput{
+				EndpointGroupArn: awssdk.String(testEndpointGrp),
+			}).Return(&gasdk.DescribeEndpointGroupOutput{
+				EndpointGroup: &gatypes.EndpointGroup{EndpointDescriptions: tt.endpoints},
+			}, nil)
+			if tt.wantConfigs != nil {
+				gaClient.EXPECT().UpdateEndpointGroupWithContext(gomock.Any(), &gasdk.UpdateEndpointGroupInput{
+					EndpointGroupArn:       awssdk.String(testEndpointGrp),
+					EndpointConfigurations: tt.wantConfigs,
+				}).Return(&gasdk.UpdateEndpointGroupOutput{}, nil)
+			}
+
+			s := &acceleratorShifter{
+				gaClient:         gaClient,
+				endpointGroupARN: testEndpointGrp,
+				ingressLBARN:     testIngressLBARN,
+				gatewayLBARN:     testGatewayLBARN,
+			}
+			err := s.setWeights(context.Background(), tt.gatewayWeight)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
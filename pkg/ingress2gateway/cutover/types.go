@@ -0,0 +1,89 @@
+package cutover
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TrafficTargetType is the mechanism used to shift traffic between the Ingress and the Gateway load balancers.
+type TrafficTargetType string
+
+const (
+	// TrafficTargetTypeRoute53 shifts traffic via a pair of Route53 weighted alias records.
+	TrafficTargetTypeRoute53 TrafficTargetType = "route53"
+	// TrafficTargetTypeGlobalAccelerator shifts traffic via GlobalAccelerator endpoint weights.
+	TrafficTargetTypeGlobalAccelerator TrafficTargetType = "globalaccelerator"
+)
+
+// TrafficTarget identifies where traffic weights are applied.
+type TrafficTarget struct {
+	Type TrafficTargetType `json:"type"`
+
+	// RecordName is the DNS name of the Route53 weighted records.
+	RecordName string `json:"recordName,omitempty"`
+	// HostedZoneID is the Route53 hosted zone of RecordName, it's discovered from RecordName when empty.
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+
+	// EndpointGroupARN is the GlobalAccelerator endpoint group that contains the Ingress load balancer.
+	EndpointGroupARN string `json:"endpointGroupARN,omitempty"`
+}
+
+// Options holds the resolved configuration for a cutover run.
+type Options struct {
+	// Ingress is a member of the IngressGroup whose traffic is being cut over.
+	Ingress types.NamespacedName
+	// Gateway is the Gateway that replaces the IngressGroup.
+	Gateway types.NamespacedName
+	// ManifestFiles are the generated manifests to apply, the Gateway is expected to exist already when empty.
+	ManifestFiles []string
+
+	TrafficTarget TrafficTarget
+	// Steps are the percentages of traffic shifted to the Gateway at each step, in increasing order ending at 100.
+	Steps []int32
+	// StepInterval is how long each step is observed before moving on.
+	StepInterval time.Duration
+	// ReadinessTimeout bounds the wait for the Gateway to be Programmed and for its targets to be healthy.
+	ReadinessTimeout time.Duration
+	// StateFile persists the progress so that an interrupted cutover can be resumed.
+	StateFile string
+}
+
+// Phase is the phase of a cutover.
+type Phase string
+
+const (
+	// PhasePending means nothing has been changed yet.
+	PhasePending Phase = "Pending"
+	// PhaseGatewayApplied means the manifests were applied without the dry-run annotation.
+	PhaseGatewayApplied Phase = "GatewayApplied"
+	// PhaseGatewayReady means the Gateway is Programmed and its targets are as healthy as the Ingress targets.
+	PhaseGatewayReady Phase = "GatewayReady"
+	// PhaseShifting means traffic is being shifted step by step.
+	PhaseShifting Phase = "Shifting"
+	// PhaseCompleted means all traffic is served by the Gateway.
+	PhaseCompleted Phase = "Completed"
+	// PhaseRolledBack means all traffic was shifted back to the Ingress.
+	PhaseRolledBack Phase = "RolledBack"
+)
+
+// State is the persisted progress of a cutover.
+type State struct {
+	Ingress       string        `json:"ingress"`
+	Gateway       string        `json:"gateway"`
+	TrafficTarget TrafficTarget `json:"trafficTarget"`
+	Steps         []int32       `json:"steps"`
+
+	Phase Phase `json:"phase"`
+	// CompletedSteps is the number of steps whose weights were applied and observed healthy.
+	CompletedSteps int `json:"completedSteps"`
+	// GatewayWeight is the percentage of traffic currently shifted to the Gateway.
+	GatewayWeight int32 `json:"gatewayWeight"`
+
+	IngressLoadBalancerDNS string `json:"ingressLoadBalancerDNS,omitempty"`
+	GatewayLoadBalancerDNS string `json:"gatewayLoadBalancerDNS,omitempty"`
+
+	// Message explains the latest phase transition, such as the reason of an automatic rollback.
+	Message   string    `json:"message,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
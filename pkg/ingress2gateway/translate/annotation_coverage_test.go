@@ -51,6 +51,7 @@ func TestAllIngressAnnotationsCovered(t *testing.T) {
 			annotations.IngressSuffixLoadBalancerCapacityReservation,
 			annotations.IngressSuffixWAFv2ACLARN,
 			annotations.IngressSuffixWAFv2ACLName,
+			annotations.IngressSuffixWAFv2ACLRef,
 			annotations.IngressSuffixShieldAdvancedProtection,
 		},
 		ListenerConfig: {
@@ -158,7 +159,7 @@ func TestAllIngressAnnotationsCovered(t *testing.T) {
 
 	// Total IngressSuffix* + IngressLBSuffix* constants in pkg/annotations/constants.go.
 	// Update when adding new annotations: grep -c 'IngressSuffix\|IngressLBSuffix' pkg/annotations/constants.go
-	const totalExpectedAnnotations = 66
+	const totalExpectedAnnotations = 67
 
 	assert.Equal(t, totalExpectedAnnotations, len(all),
 		"Annotation count mismatch. A new Ingress annotation was likely added to pkg/annotations/constants.go. "+
@@ -124,6 +124,7 @@ var lbLevelAnnotationSuffixes = []string{
 	annotations.IngressSuffixSSLPolicy,
 	annotations.IngressSuffixWAFv2ACLARN,
 	annotations.IngressSuffixWAFv2ACLName,
+	annotations.IngressSuffixWAFv2ACLRef,
 	annotations.IngressSuffixShieldAdvancedProtection,
 	annotations.IngressSuffixLoadBalancerCapacityReservation,
 	annotations.IngressSuffixMutualAuthentication,
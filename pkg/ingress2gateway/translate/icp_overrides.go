@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/utils/ptr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	gatewayv1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
 	annotations "sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
@@ -158,7 +159,13 @@ func applyIngressClassParamsToLBConfig(spec *gatewayv1beta1.LoadBalancerConfigur
 		spec.SecurityGroupPrefixes = &icp.Spec.PrefixListsIDsLegacy
 	}
 
-	if icp.Spec.WAFv2ACLArn != "" {
+	if icp.Spec.WAFv2ACLRef != "" {
+		newRef := icp.Spec.WAFv2ACLRef
+		if spec.WAFv2 != nil && spec.WAFv2.WebACLRef != newRef {
+			return fmt.Errorf("conflicting IngressClassParams wafv2-acl-ref: %q vs %q", spec.WAFv2.WebACLRef, newRef)
+		}
+		spec.WAFv2 = &gatewayv1beta1.WAFv2Configuration{WebACLRef: newRef}
+	} else if icp.Spec.WAFv2ACLArn != "" {
 		newACL := icp.Spec.WAFv2ACLArn
 		if spec.WAFv2 != nil && spec.WAFv2.ACL != newACL {
 			return fmt.Errorf("conflicting IngressClassParams wafv2-acl: %q vs %q", spec.WAFv2.ACL, newACL)
@@ -173,12 +180,23 @@ func applyIngressClassParamsToLBConfig(spec *gatewayv1beta1.LoadBalancerConfigur
 	}
 
 	if icp.Spec.MinimumLoadBalancerCapacity != nil {
-		newCap := icp.Spec.MinimumLoadBalancerCapacity.CapacityUnits
-		if spec.MinimumLoadBalancerCapacity != nil && spec.MinimumLoadBalancerCapacity.CapacityUnits != newCap {
+		newCap := toGatewayMinimumLoadBalancerCapacity(icp.Spec.MinimumLoadBalancerCapacity)
+		if spec.MinimumLoadBalancerCapacity != nil && spec.MinimumLoadBalancerCapacity.CapacityUnits != newCap.CapacityUnits {
 			return fmt.Errorf("conflicting IngressClassParams minimum-load-balancer-capacity: %d vs %d",
-				spec.MinimumLoadBalancerCapacity.CapacityUnits, newCap)
+				spec.MinimumLoadBalancerCapacity.CapacityUnits, newCap.CapacityUnits)
+		}
+		if spec.MinimumLoadBalancerCapacity != nil && !reflect.DeepEqual(*spec.MinimumLoadBalancerCapacity, *newCap) {
+			return fmt.Errorf("conflicting IngressClassParams minimum-load-balancer-capacity schedules")
+		}
+		spec.MinimumLoadBalancerCapacity = newCap
+	}
+
+	if icp.Spec.ShieldAdvanced != nil {
+		newShield := toGatewayShieldConfiguration(icp.Spec.ShieldAdvanced)
+		if spec.ShieldAdvanced != nil && !reflect.DeepEqual(*spec.ShieldAdvanced, *newShield) {
+			return fmt.Errorf("conflicting IngressClassParams shield-advanced configuration")
 		}
-		spec.MinimumLoadBalancerCapacity = &gatewayv1beta1.MinimumLoadBalancerCapacity{CapacityUnits: newCap}
+		spec.ShieldAdvanced = newShield
 	}
 
 	if icp.Spec.IPAMConfiguration != nil && icp.Spec.IPAMConfiguration.IPv4IPAMPoolId != nil {
@@ -224,6 +242,56 @@ func applyIngressClassParamsToLBConfig(spec *gatewayv1beta1.LoadBalancerConfigur
 	return nil
 }
 
+// toGatewayMinimumLoadBalancerCapacity converts the capacity reservation of an IngressClassParams, including its schedules and calendar.
+func toGatewayMinimumLoadBalancerCapacity(capacity *elbv2api.MinimumLoadBalancerCapacity) *gatewayv1beta1.MinimumLoadBalancerCapacity {
+	gwCapacity := &gatewayv1beta1.MinimumLoadBalancerCapacity{
+		CapacityUnits: capacity.CapacityUnits,
+		TimeZone:      capacity.TimeZone,
+	}
+	for _, schedule := range capacity.Schedules {
+		gwCapacity.Schedules = append(gwCapacity.Schedules, gatewayv1beta1.CapacityReservationSchedule(schedule))
+	}
+	for _, entry := range capacity.Calendar {
+		gwCapacity.Calendar = append(gwCapacity.Calendar, gatewayv1beta1.CapacityReservationCalendarEntry(entry))
+	}
+	return gwCapacity
+}
+
+// toGatewayShieldConfiguration converts the Shield Advanced configuration of an IngressClassParams.
+// Enabled is false when unset in the IngressClassParams, applyICPShieldEnabled keeps the annotation value in that case.
+func toGatewayShieldConfiguration(shieldCfg *elbv2api.ShieldAdvancedConfiguration) *gatewayv1beta1.ShieldConfiguration {
+	gwShieldCfg := &gatewayv1beta1.ShieldConfiguration{
+		Enabled:        ptr.Deref(shieldCfg.Enabled, false),
+		HealthCheckARN: shieldCfg.HealthCheckARN,
+	}
+	for _, protectionGroup := range shieldCfg.ProtectionGroups {
+		gwShieldCfg.ProtectionGroups = append(gwShieldCfg.ProtectionGroups, gatewayv1beta1.ShieldProtectionGroup{
+			ID:          protectionGroup.ID,
+			Aggregation: (*gatewayv1beta1.ShieldProtectionGroupAggregation)(protectionGroup.Aggregation),
+		})
+	}
+	if shieldCfg.ApplicationLayerAutomaticResponse != nil {
+		gwShieldCfg.ApplicationLayerAutomaticResponse = &gatewayv1beta1.ShieldApplicationLayerAutomaticResponse{
+			Action: gatewayv1beta1.ShieldApplicationLayerAutomaticResponseAction(shieldCfg.ApplicationLayerAutomaticResponse.Action),
+		}
+	}
+	return gwShieldCfg
+}
+
+// applyICPShieldEnabled restores the shield-advanced-protection annotation value after the IngressClassParams override,
+// when none of the IngressClassParams sets shieldAdvanced.enabled, as the annotation then decides whether the protection is enabled.
+func applyICPShieldEnabled(spec *gatewayv1beta1.LoadBalancerConfigurationSpec, annotationShieldEnabled bool, icps []*elbv2api.IngressClassParams) {
+	if spec.ShieldAdvanced == nil {
+		return
+	}
+	for _, icp := range icps {
+		if icp.Spec.ShieldAdvanced != nil && icp.Spec.ShieldAdvanced.Enabled != nil {
+			return
+		}
+	}
+	spec.ShieldAdvanced.Enabled = annotationShieldEnabled
+}
+
 // applyICPSpecOverride copies non-nil/non-zero fields from src to dst.
 // This is used to apply the merged ICP spec on top of the annotation-derived LBConfig.
 // ICP always has higher priority than annotations.
@@ -267,6 +335,9 @@ func applyICPSpecOverride(dst, src *gatewayv1beta1.LoadBalancerConfigurationSpec
 	if src.MinimumLoadBalancerCapacity != nil {
 		dst.MinimumLoadBalancerCapacity = src.MinimumLoadBalancerCapacity
 	}
+	if src.ShieldAdvanced != nil {
+		dst.ShieldAdvanced = src.ShieldAdvanced
+	}
 	if src.IPv4IPAMPoolId != nil {
 		dst.IPv4IPAMPoolId = src.IPv4IPAMPoolId
 	}
@@ -3,6 +3,7 @@ package translate
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,6 +48,11 @@ func TestAllIngressClassParamsFieldsHandled(t *testing.T) {
 			PrefixListsIDsLegacy:        []string{"pl-111"},
 			WAFv2ACLArn:                 "arn:aws:wafv2:us-west-2:123:regional/webacl/my-acl/abc",
 			WAFv2ACLName:                "my-acl",
+			WAFv2ACLRef:                 "my-web-acl",
+			ShieldAdvanced: &elbv2api.ShieldAdvancedConfiguration{
+				Enabled:          ptr.To(true),
+				ProtectionGroups: []elbv2api.ShieldProtectionGroup{{ID: "web"}},
+			},
 		},
 	}
 
@@ -118,8 +124,12 @@ func TestAllIngressClassParamsFieldsHandled(t *testing.T) {
 			handled = lbSpec.WAFv2 != nil
 		case "WAFv2ACLName":
 			handled = lbSpec.WAFv2 != nil
+		case "WAFv2ACLRef":
+			handled = lbSpec.WAFv2 != nil && lbSpec.WAFv2.WebACLRef != ""
 		case "MinimumLoadBalancerCapacity":
 			handled = lbSpec.MinimumLoadBalancerCapacity != nil
+		case "ShieldAdvanced":
+			handled = lbSpec.ShieldAdvanced != nil && len(lbSpec.ShieldAdvanced.ProtectionGroups) != 0
 		case "IPAMConfiguration":
 			handled = lbSpec.IPv4IPAMPoolId != nil
 		case "Listeners":
@@ -245,6 +255,41 @@ func TestApplyIngressClassParamsToLBConfig_MultiICP_Conflict(t *testing.T) {
 			},
 			wantErr: "conflicting IngressClassParams tag",
 		},
+		{
+			name: "conflicting capacity reservation schedules errors",
+			icps: []*elbv2api.IngressClassParams{
+				{Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+						CapacityUnits: 100,
+						Schedules: []elbv2api.CapacityReservationSchedule{{
+							Name:          "business-hours",
+							Start:         "0 8 * * mon-fri",
+							Duration:      metav1.Duration{Duration: 10 * time.Hour},
+							CapacityUnits: 400,
+						}},
+					},
+				}},
+				{Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{CapacityUnits: 100},
+				}},
+			},
+			wantErr: "conflicting IngressClassParams minimum-load-balancer-capacity schedules",
+		},
+		{
+			name: "conflicting shield advanced configuration errors",
+			icps: []*elbv2api.IngressClassParams{
+				{Spec: elbv2api.IngressClassParamsSpec{
+					ShieldAdvanced: &elbv2api.ShieldAdvancedConfiguration{Enabled: ptr.To(true)},
+				}},
+				{Spec: elbv2api.IngressClassParamsSpec{
+					ShieldAdvanced: &elbv2api.ShieldAdvancedConfiguration{
+						Enabled:          ptr.To(true),
+						ProtectionGroups: []elbv2api.ShieldProtectionGroup{{ID: "web"}},
+					},
+				}},
+			},
+			wantErr: "conflicting IngressClassParams shield-advanced configuration",
+		},
 		{
 			name: "non-overlapping tags combine",
 			icps: []*elbv2api.IngressClassParams{
@@ -321,3 +366,50 @@ func TestApplyICPSpecOverride(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyICPShieldEnabled(t *testing.T) {
+	healthCheckARN := "arn:aws:route53:::healthcheck/hc-1"
+	tests := []struct {
+		name                    string
+		annotationShieldEnabled bool
+		icpShield               *elbv2api.ShieldAdvancedConfiguration
+		want                    gatewayv1beta1.ShieldConfiguration
+	}{
+		{
+			name:                    "annotation enables protection when ICP doesn't set enabled",
+			annotationShieldEnabled: true,
+			icpShield:               &elbv2api.ShieldAdvancedConfiguration{HealthCheckARN: &healthCheckARN},
+			want:                    gatewayv1beta1.ShieldConfiguration{Enabled: true, HealthCheckARN: &healthCheckARN},
+		},
+		{
+			name:                    "ICP enabled wins over annotation",
+			annotationShieldEnabled: true,
+			icpShield: &elbv2api.ShieldAdvancedConfiguration{
+				Enabled: ptr.To(false),
+				ApplicationLayerAutomaticResponse: &elbv2api.ShieldApplicationLayerAutomaticResponse{
+					Action: elbv2api.ShieldApplicationLayerAutomaticResponseActionCount,
+				},
+			},
+			want: gatewayv1beta1.ShieldConfiguration{
+				Enabled: false,
+				ApplicationLayerAutomaticResponse: &gatewayv1beta1.ShieldApplicationLayerAutomaticResponse{
+					Action: gatewayv1beta1.ShieldApplicationLayerAutomaticResponseActionCount,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icps := []*elbv2api.IngressClassParams{{Spec: elbv2api.IngressClassParamsSpec{ShieldAdvanced: tt.icpShield}}}
+			var mergedICPSpec gatewayv1beta1.LoadBalancerConfigurationSpec
+			require.NoError(t, applyIngressClassParamsToLBConfig(&mergedICPSpec, icps[0]))
+			spec := gatewayv1beta1.LoadBalancerConfigurationSpec{
+				ShieldAdvanced: &gatewayv1beta1.ShieldConfiguration{Enabled: tt.annotationShieldEnabled},
+			}
+			applyICPSpecOverride(&spec, &mergedICPSpec)
+			applyICPShieldEnabled(&spec, tt.annotationShieldEnabled, icps)
+			require.NotNil(t, spec.ShieldAdvanced)
+			assert.Equal(t, tt.want, *spec.ShieldAdvanced)
+		})
+	}
+}
@@ -119,7 +119,9 @@ func buildLoadBalancerConfigSpec(annos map[string]string, listenPorts []listenPo
 		}
 	}
 
-	if v := getString(annos, annotations.IngressSuffixWAFv2ACLARN); v != "" && v != "none" {
+	if v := getString(annos, annotations.IngressSuffixWAFv2ACLRef); v != "" {
+		spec.WAFv2 = &gatewayv1beta1.WAFv2Configuration{WebACLRef: v}
+	} else if v := getString(annos, annotations.IngressSuffixWAFv2ACLARN); v != "" && v != "none" {
 		spec.WAFv2 = &gatewayv1beta1.WAFv2Configuration{ACL: v}
 	} else if v := getString(annos, annotations.IngressSuffixWAFv2ACLName); v != "" && v != "none" {
 		spec.WAFv2 = &gatewayv1beta1.WAFv2Configuration{ACL: v}
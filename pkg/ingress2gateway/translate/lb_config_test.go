@@ -95,6 +95,19 @@ func TestBuildLoadBalancerConfigResource(t *testing.T) {
 				assert.True(t, lbc.Spec.ShieldAdvanced.Enabled)
 			},
 		},
+		{
+			name: "wafv2 web ACL reference takes precedence over ARN",
+			annos: map[string]string{
+				"alb.ingress.kubernetes.io/wafv2-acl-ref": "my-web-acl",
+				"alb.ingress.kubernetes.io/wafv2-acl-arn": "arn:aws:wafv2:us-west-2:123:regional/webacl/my-acl/abc",
+			},
+			ports: []listenPortEntry{{Protocol: "HTTP", Port: 80}},
+			check: func(t *testing.T, lbc *gatewayv1beta1.LoadBalancerConfiguration) {
+				require.NotNil(t, lbc.Spec.WAFv2)
+				assert.Equal(t, "my-web-acl", lbc.Spec.WAFv2.WebACLRef)
+				assert.Empty(t, lbc.Spec.WAFv2.ACL)
+			},
+		},
 		{
 			name: "subnets and security groups",
 			annos: map[string]string{
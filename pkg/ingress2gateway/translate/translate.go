@@ -105,7 +105,9 @@ func Translate(in *ingress2gateway.InputResources) (*ingress2gateway.OutputResou
 				}
 			}
 			// Step 2: Apply merged ICP spec on top of annotation-derived LBConfig (ICP wins over annotations)
+			annotationShieldEnabled := lbConfig.Spec.ShieldAdvanced != nil && lbConfig.Spec.ShieldAdvanced.Enabled
 			applyICPSpecOverride(&lbConfig.Spec, &mergedICPSpec)
+			applyICPShieldEnabled(&lbConfig.Spec, annotationShieldEnabled, icps)
 		}
 		if lbConfig != nil {
 			out.LoadBalancerConfigurations = append(out.LoadBalancerConfigurations, *lbConfig)
@@ -0,0 +1,16 @@
+package albtargetcontrol
+
+import (
+	"encoding/json"
+
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+)
+
+// ComputeConfigHash computes the hash of an agent configuration, pods injected with a different hash run an outdated agent.
+// RolloutOnUpdate is excluded as it doesn't change the injected agent.
+func ComputeConfigHash(spec elbv2api.ALBTargetControlConfigSpec) string {
+	spec.RolloutOnUpdate = nil
+	payload, _ := json.Marshal(spec)
+	return algorithm.ComputeSha256(string(payload))
+}
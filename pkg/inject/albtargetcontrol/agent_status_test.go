@@ -0,0 +1,33 @@
+package albtargetcontrol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_ComputeConfigHash(t *testing.T) {
+	spec := elbv2api.ALBTargetControlConfigSpec{
+		Image:              "public.ecr.aws/aws-elb/target-optimizer/target-control-agent:latest",
+		DataAddress:        "0.0.0.0:80",
+		ControlAddress:     "0.0.0.0:3000",
+		DestinationAddress: "127.0.0.1:8080",
+		MaxConcurrency:     1,
+	}
+	hash := ComputeConfigHash(spec)
+
+	rolloutSpec := *spec.DeepCopy()
+	rolloutSpec.RolloutOnUpdate = ptr.To(true)
+	assert.Equal(t, hash, ComputeConfigHash(rolloutSpec))
+
+	updatedSpec := *spec.DeepCopy()
+	updatedSpec.Image = "public.ecr.aws/aws-elb/target-optimizer/target-control-agent:v2"
+	assert.NotEqual(t, hash, ComputeConfigHash(updatedSpec))
+
+	logSpec := *spec.DeepCopy()
+	logSpec.RustLog = ptr.To("debug")
+	assert.NotEqual(t, hash, ComputeConfigHash(logSpec))
+}
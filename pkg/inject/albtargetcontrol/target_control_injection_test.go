@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -237,7 +238,7 @@ func Test_AlbTargetControlAgent_Mutate(t *testing.T) {
 				assert.NoError(t, k8sClient.Create(ctx, tt.agentConfig.DeepCopy()))
 			}
 
-			agent := NewALBTargetControlAgentInjector(k8sClient, k8sClient, logr.New(&log.NullLogSink{}), DefaultControllerNamespace)
+			agent := NewALBTargetControlAgentInjector(k8sClient, logr.New(&log.NullLogSink{}), DefaultControllerNamespace)
 			err := agent.Mutate(ctx, tt.pod)
 
 			if tt.wantError {
@@ -260,6 +261,9 @@ func Test_AlbTargetControlAgent_Mutate(t *testing.T) {
 					}
 				}
 				assert.NotNil(t, sidecarContainer, "sidecar container should exist")
+				assert.Equal(t, ComputeConfigHash(tt.agentConfig.Spec), tt.pod.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigHash])
+				assert.Equal(t, tt.agentConfig.Namespace, tt.pod.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigNamespace])
+				assert.Equal(t, PortNameControl, sidecarContainer.ReadinessProbe.TCPSocket.Port.StrVal)
 
 				// For annotation override test, verify overrides are applied
 				if tt.name == "annotation overrides work correctly" {
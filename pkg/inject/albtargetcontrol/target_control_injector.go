@@ -5,29 +5,30 @@ import (
 	"fmt"
 	"net"
 	"strconv"
-	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 )
 
-//+kubebuilder:rbac:groups=elbv2.k8s.aws,resources=albtargetcontrolconfigs,verbs=get
+//+kubebuilder:rbac:groups=elbv2.k8s.aws,resources=albtargetcontrolconfigs,verbs=get;list;watch
 
 const (
-	// defaultAlbTargetControlAgentConfigName is the default name for the AlbTargetControlAgentConfig
-	defaultAlbTargetControlAgentConfigName = "aws-load-balancer-controller-alb-target-control-agent-config"
+	// ConfigName is the name of the ALBTargetControlConfig the agent is injected from
+	ConfigName = "aws-load-balancer-controller-alb-target-control-agent-config"
 
 	// Logger and controller constants
 	LoggerName                 = "alb-target-control-agent-injector"
 	DefaultControllerNamespace = "kube-system"
 
 	// Container and environment variable names
-	SidecarContainerName  = "alb-target-control-agent"
+	SidecarContainerName  = k8s.ALBTargetControlAgentContainerName
 	EnvDataAddress        = "TARGET_CONTROL_DATA_ADDRESS"
 	EnvControlAddress     = "TARGET_CONTROL_CONTROL_ADDRESS"
 	EnvDestinationAddress = "TARGET_CONTROL_DESTINATION_ADDRESS"
@@ -47,7 +48,7 @@ const (
 
 	// ALB target control agent injection labels and annotations
 	InjectLabel        = "elbv2.k8s.aws/alb-target-control-agent-inject"
-	InjectedAnnotation = "elbv2.k8s.aws/alb-target-control-agent-injected"
+	InjectedAnnotation = k8s.AnnotationKeyALBTargetControlAgentInjected
 	NamespaceLabel     = "elbv2.k8s.aws/alb-target-control-agent-injection"
 
 	// Pod annotation keys for configuration overrides
@@ -74,28 +75,20 @@ type ALBTargetControlAgentInjector interface {
 
 // ALBTargetControlAgentInjectorImpl is the implementation of ALBTargetControlAgentInjector
 type ALBTargetControlAgentInjectorImpl struct {
-	// k8sClient is the Kubernetes client for API operations
+	// k8sClient is the Kubernetes client for API operations, its cache keeps the configurations up to date
 	k8sClient client.Client
-	// apiReader is the API reader for configuration retrieval
-	apiReader client.Reader
 	// logger is the structured logger instance
 	logger logr.Logger
 	// controllerNamespace is the namespace where the controller is running
 	controllerNamespace string
-	// configCache caches ALBTargetControlConfigSpec by namespace
-	configCache map[string]*elbv2api.ALBTargetControlConfigSpec
-	// cacheMutex protects configCache
-	cacheMutex sync.RWMutex
 }
 
 // NewALBTargetControlAgentInjector constructs new ALBTargetControlAgentInjector
-func NewALBTargetControlAgentInjector(k8sClient client.Client, apiReader client.Reader, logger logr.Logger, controllerNamespace string) ALBTargetControlAgentInjector {
+func NewALBTargetControlAgentInjector(k8sClient client.Client, logger logr.Logger, controllerNamespace string) ALBTargetControlAgentInjector {
 	return &ALBTargetControlAgentInjectorImpl{
 		k8sClient:           k8sClient,
-		apiReader:           apiReader,
 		logger:              logger,
 		controllerNamespace: controllerNamespace,
-		configCache:         make(map[string]*elbv2api.ALBTargetControlConfigSpec),
 	}
 }
 
@@ -151,48 +144,32 @@ func (r *ALBTargetControlAgentInjectorImpl) isInjectionEnabled(ctx context.Conte
 	return false
 }
 
-// getSidecarConfig retrieves ALB target control agent configuration
+// getSidecarConfig retrieves ALB target control agent configuration and the namespace it was found in
 // First tries pod namespace, then falls back to controller namespace
-func (r *ALBTargetControlAgentInjectorImpl) getSidecarConfig(ctx context.Context, podNamespace string) (*elbv2api.ALBTargetControlConfigSpec, error) {
+func (r *ALBTargetControlAgentInjectorImpl) getSidecarConfig(ctx context.Context, podNamespace string) (*elbv2api.ALBTargetControlConfigSpec, string, error) {
 	// Try pod namespace first
 	if config := r.getConfigFromNamespace(ctx, podNamespace); config != nil {
-		return config, nil
+		return config, podNamespace, nil
 	}
 
 	// Fallback to controller namespace
 	if r.controllerNamespace != "" && r.controllerNamespace != podNamespace {
 		if config := r.getConfigFromNamespace(ctx, r.controllerNamespace); config != nil {
-			return config, nil
+			return config, r.controllerNamespace, nil
 		}
 	}
 
-	return nil, apierrors.NewNotFound(elbv2api.GroupVersion.WithResource("albtargetcontrolconfigs").GroupResource(), defaultAlbTargetControlAgentConfigName)
+	return nil, "", apierrors.NewNotFound(elbv2api.GroupVersion.WithResource("albtargetcontrolconfigs").GroupResource(), ConfigName)
 }
 
-// getConfigFromNamespace gets config from namespace with caching
+// getConfigFromNamespace gets config from namespace
 func (r *ALBTargetControlAgentInjectorImpl) getConfigFromNamespace(ctx context.Context, namespace string) *elbv2api.ALBTargetControlConfigSpec {
-	r.cacheMutex.RLock()
-	cached, exists := r.configCache[namespace]
-	r.cacheMutex.RUnlock()
-	if exists {
-		return cached
-	}
-
 	agentConfig := &elbv2api.ALBTargetControlConfig{}
-	err := r.apiReader.Get(ctx, client.ObjectKey{Name: defaultAlbTargetControlAgentConfigName, Namespace: namespace}, agentConfig)
-
-	r.cacheMutex.Lock()
-	defer r.cacheMutex.Unlock()
-
-	if err != nil {
-		r.configCache[namespace] = nil
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: ConfigName, Namespace: namespace}, agentConfig); err != nil {
 		return nil
 	}
-
-	spec := &agentConfig.Spec
-	r.configCache[namespace] = spec
-	r.logger.V(1).Info("Found and cached ALBTargetControlConfig", "namespace", namespace)
-	return spec
+	r.logger.V(1).Info("Found ALBTargetControlConfig", "namespace", namespace)
+	return &agentConfig.Spec
 }
 
 // injectALBTargetControlAgent adds the ALB target control agent sidecar container to the pod
@@ -209,11 +186,11 @@ func (r *ALBTargetControlAgentInjectorImpl) injectALBTargetControlAgent(ctx cont
 	}
 
 	r.logger.V(1).Info("Creating ALB target control agent sidecar container")
-	sidecarConfig, err := r.getSidecarConfig(ctx, pod.Namespace)
+	sidecarConfig, configNamespace, err := r.getSidecarConfig(ctx, pod.Namespace)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			r.logger.V(1).Info("ALBTargetControlAgentConfig not found in pod or controller namespace, skipping injection",
-				"configName", defaultAlbTargetControlAgentConfigName,
+				"configName", ConfigName,
 				"podNamespace", pod.Namespace,
 				"controllerNamespace", r.controllerNamespace)
 			return nil
@@ -223,7 +200,7 @@ func (r *ALBTargetControlAgentInjectorImpl) injectALBTargetControlAgent(ctx cont
 	}
 
 	// Create a copy of the config to override with pod-specific annotations
-	effectiveConfig := *sidecarConfig
+	effectiveConfig := *sidecarConfig.DeepCopy()
 
 	// Initialize values from CRD configuration
 	image := effectiveConfig.Image
@@ -418,6 +395,14 @@ func (r *ALBTargetControlAgentInjectorImpl) injectALBTargetControlAgent(ctx cont
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
+		// The agent readiness is folded into the target health readiness gate of the pod
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromString(PortNameControl),
+				},
+			},
+		},
 	}
 
 	// Set resources if configured
@@ -433,6 +418,8 @@ func (r *ALBTargetControlAgentInjectorImpl) injectALBTargetControlAgent(ctx cont
 		pod.Annotations = make(map[string]string)
 	}
 	pod.Annotations[InjectedAnnotation] = "true"
+	pod.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigHash] = ComputeConfigHash(*sidecarConfig)
+	pod.Annotations[k8s.AnnotationKeyALBTargetControlAgentConfigNamespace] = configNamespace
 
 	r.logger.Info("Successfully injected ALB target control agent sidecar", "pod", pod.Name, "dataAddress", dataAddress, "controlAddress", controlAddress)
 	return nil
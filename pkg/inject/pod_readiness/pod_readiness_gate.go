@@ -73,6 +73,10 @@ func (m *PodReadinessGate) computeTargetHealthReadinessGateConditionTypes(ctx co
 		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
 			continue
 		}
+		// pods left out by weighted registration are never registered, so their readiness gate would never pass.
+		if tgb.Spec.WeightedRegistration != nil {
+			continue
+		}
 
 		svcKey := types.NamespacedName{Namespace: tgb.Namespace, Name: tgb.Spec.ServiceRef.Name}
 		svc := &corev1.Service{}
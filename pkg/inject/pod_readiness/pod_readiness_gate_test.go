@@ -114,6 +114,20 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 			},
 		},
 	}
+	tgb6 := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tgb-6-l6qw6",
+			Namespace: testNS1,
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType:              &targetTypeIP,
+			MultiClusterTargetGroup: true,
+			WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 10},
+			ServiceRef: elbv2api.ServiceReference{
+				Name: svc1.Name,
+			},
+		},
+	}
 
 	tests := []struct {
 		name      string
@@ -193,6 +207,29 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "matching tgb with weighted registration",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1, tgb6},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":    "app-1",
+						"svc":    "svc1",
+						"stable": "none",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: PodReadinessGateConfig{
+				EnablePodReadinessGateInject: true,
+			},
+		},
 		{
 			name:      "nonexistent service",
 			namespace: testNS1,
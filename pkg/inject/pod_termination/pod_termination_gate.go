@@ -0,0 +1,206 @@
+package pod_termination
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewPodTerminationGate constructs new PodTerminationGate
+func NewPodTerminationGate(config PodTerminationGateConfig, k8sClient client.Client, logger logr.Logger) *PodTerminationGate {
+	return &PodTerminationGate{
+		config:    config,
+		k8sClient: k8sClient,
+		logger:    logger,
+		clock:     time.Now,
+	}
+}
+
+// PodTerminationGate holds the termination of pods registered as IP targets until their targets are drained.
+// The termination of a pod is held by rejecting its deletion and adding a targetDrain condition per matching TargetGroupBinding,
+// the TargetGroupBinding reconciler deregisters the pod's targets and deletes the pod once all targets are drained.
+type PodTerminationGate struct {
+	config    PodTerminationGateConfig
+	k8sClient client.Client
+	logger    logr.Logger
+	clock     func() time.Time
+}
+
+// CheckDeletion returns an error if pod deletion should be held until its targets are drained.
+// deleteOptions are the options of the deletion, which are applied when the pod is deleted after draining.
+func (g *PodTerminationGate) CheckDeletion(ctx context.Context, pod *corev1.Pod, deleteOptions *metav1.DeleteOptions) error {
+	if !g.config.EnablePodTerminationGate || pod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	drainStatus := targetgroupbinding.ComputePodTargetDrainStatus(pod.Status.Conditions)
+	if drainStatus.Gated {
+		if drainStatus.Drained || drainStatus.IsTimedOut(g.config.PodTerminationGateTimeout, g.clock()) {
+			return nil
+		}
+		return errors.Errorf("pod %v is draining from load balancer target groups, it will be deleted once draining completes or after %v",
+			k8s.NamespacedName(pod), drainStatus.RequestedAt.Add(g.config.PodTerminationGateTimeout).Format(time.RFC3339))
+	}
+
+	if !isPodServingTraffic(pod) {
+		return nil
+	}
+	targetDrainCondTypes, err := g.computeTargetDrainConditionTypes(ctx, pod)
+	if err != nil {
+		return err
+	}
+	if len(targetDrainCondTypes) == 0 {
+		return nil
+	}
+
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	if req == nil || req.DryRun == nil || !*req.DryRun {
+		// the options are recorded before the targetDrain conditions, which trigger the deletion after draining.
+		if err := g.recordDeleteOptions(ctx, pod, deleteOptions); err != nil {
+			return err
+		}
+		if err := g.holdPodTermination(ctx, pod, targetDrainCondTypes); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("pod %v is registered in load balancer target groups, it will be deleted once its targets are drained",
+		k8s.NamespacedName(pod))
+}
+
+// computeTargetDrainConditionTypes computes the targetDrain condition types for IP TargetGroupBindings whose service has the pod as an endpoint.
+func (g *PodTerminationGate) computeTargetDrainConditionTypes(ctx context.Context, pod *corev1.Pod) ([]corev1.PodConditionType, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := g.k8sClient.List(ctx, tgbList, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "unable to determine targetDrain conditions")
+	}
+	var targetDrainCondTypes []corev1.PodConditionType
+	for i := range tgbList.Items {
+		tgb := &tgbList.Items[i]
+		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+			continue
+		}
+
+		svcKey := types.NamespacedName{Namespace: tgb.Namespace, Name: tgb.Spec.ServiceRef.Name}
+		svc := &corev1.Service{}
+		if err := g.k8sClient.Get(ctx, svcKey, svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "unable to determine targetDrain conditions")
+		}
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		registered, err := g.isPodInServiceEndpoints(ctx, svcKey, pod)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to determine targetDrain conditions")
+		}
+		if registered {
+			targetDrainCondTypes = append(targetDrainCondTypes, targetgroupbinding.BuildTargetDrainPodConditionType(tgb))
+		}
+	}
+	return targetDrainCondTypes, nil
+}
+
+// isPodInServiceEndpoints checks whether pod is an endpoint of service, only such pods are registered as IP targets.
+func (g *PodTerminationGate) isPodInServiceEndpoints(ctx context.Context, svcKey types.NamespacedName, pod *corev1.Pod) (bool, error) {
+	epSliceList := &discovery.EndpointSliceList{}
+	if err := g.k8sClient.List(ctx, epSliceList,
+		client.InNamespace(svcKey.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svcKey.Name}); err != nil {
+		return false, err
+	}
+	for _, epSlice := range epSliceList.Items {
+		for _, ep := range epSlice.Endpoints {
+			if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" && ep.TargetRef.Name == pod.Name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// recordDeleteOptions records the grace period and propagation policy of the held deletion on pod.
+func (g *PodTerminationGate) recordDeleteOptions(ctx context.Context, pod *corev1.Pod, deleteOptions *metav1.DeleteOptions) error {
+	var podDeleteOptions k8s.PodDeleteOptions
+	if deleteOptions != nil {
+		podDeleteOptions.GracePeriodSeconds = deleteOptions.GracePeriodSeconds
+		podDeleteOptions.PropagationPolicy = deleteOptions.PropagationPolicy
+	}
+	// options recorded by an earlier deletion are overwritten, as only the latest deletion is applied.
+	if _, recorded := pod.Annotations[k8s.AnnotationKeyPodTerminationDeleteOptions]; !recorded && podDeleteOptions == (k8s.PodDeleteOptions{}) {
+		return nil
+	}
+	rawDeleteOptions, err := json.Marshal(podDeleteOptions)
+	if err != nil {
+		return err
+	}
+	podPatchSource := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+	podPatchTarget := podPatchSource.DeepCopy()
+	podPatchTarget.UID = pod.UID // only put the uid in the new object to ensure it appears in the patch as a precondition
+	podPatchTarget.Annotations = map[string]string{
+		k8s.AnnotationKeyPodTerminationDeleteOptions: string(rawDeleteOptions),
+	}
+	if err := g.k8sClient.Patch(ctx, podPatchTarget, client.MergeFrom(podPatchSource)); err != nil {
+		return errors.Wrap(err, "unable to record pod delete options")
+	}
+	return nil
+}
+
+// holdPodTermination adds the pending targetDrain conditions to pod, which signals the TargetGroupBinding reconciler to deregister its targets.
+func (g *PodTerminationGate) holdPodTermination(ctx context.Context, pod *corev1.Pod, targetDrainCondTypes []corev1.PodConditionType) error {
+	now := metav1.NewTime(g.clock())
+	podPatchSource := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{},
+		},
+	}
+	podPatchTarget := podPatchSource.DeepCopy()
+	podPatchTarget.UID = pod.UID // only put the uid in the new object to ensure it appears in the patch as a precondition
+	for _, condType := range targetDrainCondTypes {
+		podPatchTarget.Status.Conditions = append(podPatchTarget.Status.Conditions, corev1.PodCondition{
+			Type:               condType,
+			Status:             corev1.ConditionFalse,
+			Reason:             targetgroupbinding.PodTargetDrainReasonPending,
+			Message:            "Pod termination is held until targets are drained",
+			LastTransitionTime: now,
+		})
+	}
+	if err := g.k8sClient.Status().Patch(ctx, podPatchTarget, client.StrategicMergeFrom(podPatchSource)); err != nil {
+		return errors.Wrap(err, "unable to hold pod termination")
+	}
+	g.logger.Info("holding pod termination until targets are drained", "pod", k8s.NamespacedName(pod), "conditions", targetDrainCondTypes)
+	return nil
+}
+
+// isPodServingTraffic checks whether pod can be registered as a target, other pods are not held since they are not in endpoints.
+func isPodServingTraffic(pod *corev1.Pod) bool {
+	if pod.Status.PodIP == "" {
+		return false
+	}
+	readyCond := k8s.GetPodCondition(pod, corev1.PodReady)
+	return readyCond != nil && readyCond.Status == corev1.ConditionTrue
+}
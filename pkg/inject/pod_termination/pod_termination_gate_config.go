@@ -0,0 +1,27 @@
+package pod_termination
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagEnablePodTerminationGate  = "enable-pod-termination-gate"
+	flagPodTerminationGateTimeout = "pod-termination-gate-timeout"
+
+	defaultPodTerminationGateTimeout = 5 * time.Minute
+)
+
+// PodTerminationGateConfig contains the configurations for holding pod termination until its targets are drained.
+type PodTerminationGateConfig struct {
+	EnablePodTerminationGate  bool
+	PodTerminationGateTimeout time.Duration
+}
+
+func (cfg *PodTerminationGateConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&cfg.EnablePodTerminationGate, flagEnablePodTerminationGate, false,
+		`If enabled, termination of pods registered as IP targets will be held until the targets are drained from all target groups`)
+	fs.DurationVar(&cfg.PodTerminationGateTimeout, flagPodTerminationGateTimeout, defaultPodTerminationGateTimeout,
+		`The maximum duration pod termination is held for its targets to drain`)
+}
@@ -0,0 +1,255 @@
+package pod_termination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func Test_PodTerminationGate_CheckDeletion(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "awesome-app"},
+		},
+	}
+	ipTGB := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ip-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: ptr.To(elbv2api.TargetTypeIP),
+			ServiceRef: elbv2api.ServiceReference{Name: "awesome-svc"},
+		},
+	}
+	instanceTGB := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "instance-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: ptr.To(elbv2api.TargetTypeInstance),
+			ServiceRef: elbv2api.ServiceReference{Name: "awesome-svc"},
+		},
+	}
+	readyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-pod",
+			UID:       "awesome-pod-uid",
+			Labels:    map[string]string{"app": "awesome-app"},
+		},
+		Status: corev1.PodStatus{
+			PodIP: "192.168.1.1",
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	gatedPod := func(drainStatus corev1.ConditionStatus, requestedAt time.Time) *corev1.Pod {
+		pod := readyPod.DeepCopy()
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               "target-drain.elbv2.k8s.aws/ip-tgb",
+			Status:             drainStatus,
+			LastTransitionTime: metav1.NewTime(requestedAt),
+		})
+		return pod
+	}
+	epSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc-abcde",
+			Labels:    map[string]string{discovery.LabelServiceName: "awesome-svc"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{"192.168.1.1"},
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "awesome-ns", Name: "awesome-pod"},
+			},
+		},
+	}
+	unreadyPod := readyPod.DeepCopy()
+	unreadyPod.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name               string
+		enabled            bool
+		tgbs               []*elbv2api.TargetGroupBinding
+		pod                *corev1.Pod
+		notInEndpoints     bool
+		dryRun             bool
+		deleteOptions      *metav1.DeleteOptions
+		wantErr            string
+		wantDrainCondition *corev1.PodCondition
+		wantAnnotations    map[string]string
+	}{
+		{
+			name:    "termination gate disabled",
+			enabled: false,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     readyPod,
+		},
+		{
+			name:    "pod registered in IP TargetGroupBinding is held",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB, instanceTGB},
+			pod:     readyPod,
+			wantErr: "pod awesome-ns/awesome-pod is registered in load balancer target groups, it will be deleted once its targets are drained",
+			wantDrainCondition: &corev1.PodCondition{
+				Type:               "target-drain.elbv2.k8s.aws/ip-tgb",
+				Status:             corev1.ConditionFalse,
+				Reason:             "DeregistrationPending",
+				Message:            "Pod termination is held until targets are drained",
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:    "pod is held with its delete options recorded",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     readyPod,
+			deleteOptions: &metav1.DeleteOptions{
+				GracePeriodSeconds: ptr.To[int64](0),
+				PropagationPolicy:  ptr.To(metav1.DeletePropagationBackground),
+			},
+			wantErr: "pod awesome-ns/awesome-pod is registered in load balancer target groups, it will be deleted once its targets are drained",
+			wantDrainCondition: &corev1.PodCondition{
+				Type:               "target-drain.elbv2.k8s.aws/ip-tgb",
+				Status:             corev1.ConditionFalse,
+				Reason:             "DeregistrationPending",
+				Message:            "Pod termination is held until targets are drained",
+				LastTransitionTime: metav1.NewTime(now),
+			},
+			wantAnnotations: map[string]string{
+				"elbv2.k8s.aws/pod-termination-delete-options": `{"gracePeriodSeconds":0,"propagationPolicy":"Background"}`,
+			},
+		},
+		{
+			name:    "pod is held without side effects in dry-run",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     readyPod,
+			dryRun:  true,
+			wantErr: "pod awesome-ns/awesome-pod is registered in load balancer target groups, it will be deleted once its targets are drained",
+		},
+		{
+			name:    "pod only registered in instance TargetGroupBinding",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{instanceTGB},
+			pod:     readyPod,
+		},
+		{
+			name:           "pod selected by service but not registered",
+			enabled:        true,
+			tgbs:           []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:            readyPod,
+			notInEndpoints: true,
+		},
+		{
+			name:    "pod not ready",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     unreadyPod,
+		},
+		{
+			name:    "pod still draining",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     gatedPod(corev1.ConditionFalse, now.Add(-time.Minute)),
+			wantErr: "pod awesome-ns/awesome-pod is draining from load balancer target groups, it will be deleted once draining completes or after 2024-01-02T03:08:05Z",
+		},
+		{
+			name:    "pod drained",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     gatedPod(corev1.ConditionTrue, now.Add(-time.Minute)),
+		},
+		{
+			name:    "pod draining timed out",
+			enabled: true,
+			tgbs:    []*elbv2api.TargetGroupBinding{ipTGB},
+			pod:     gatedPod(corev1.ConditionFalse, now.Add(-10*time.Minute)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithStatusSubresource(&corev1.Pod{}).Build()
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			if !tt.notInEndpoints {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
+			}
+			for _, tgb := range tt.tgbs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tt.pod.DeepCopy()))
+
+			g := NewPodTerminationGate(PodTerminationGateConfig{
+				EnablePodTerminationGate:  tt.enabled,
+				PodTerminationGateTimeout: 5 * time.Minute,
+			}, k8sClient, logr.New(&log.NullLogSink{}))
+			g.clock = func() time.Time { return now }
+
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Namespace: tt.pod.Namespace,
+					Name:      tt.pod.Name,
+					Operation: admissionv1.Delete,
+					DryRun:    ptr.To(tt.dryRun),
+				},
+			}
+			err := g.CheckDeletion(webhook.ContextWithAdmissionRequest(ctx, req), tt.pod.DeepCopy(), tt.deleteOptions)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.wantDrainCondition != nil {
+				updatedPod := &corev1.Pod{}
+				assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: tt.pod.Namespace, Name: tt.pod.Name}, updatedPod))
+				var gotDrainCondition *corev1.PodCondition
+				for i := range updatedPod.Status.Conditions {
+					if updatedPod.Status.Conditions[i].Type == tt.wantDrainCondition.Type {
+						gotDrainCondition = &updatedPod.Status.Conditions[i]
+					}
+				}
+				if assert.NotNil(t, gotDrainCondition) {
+					assert.True(t, tt.wantDrainCondition.LastTransitionTime.Equal(&gotDrainCondition.LastTransitionTime))
+					gotDrainCondition.LastTransitionTime = tt.wantDrainCondition.LastTransitionTime
+					assert.Equal(t, *tt.wantDrainCondition, *gotDrainCondition)
+				}
+				assert.Equal(t, tt.wantAnnotations, updatedPod.Annotations)
+			} else if tt.dryRun {
+				updatedPod := &corev1.Pod{}
+				assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: tt.pod.Namespace, Name: tt.pod.Name}, updatedPod))
+				assert.Equal(t, tt.pod.Status.Conditions, updatedPod.Status.Conditions)
+			}
+		})
+	}
+}
@@ -5,14 +5,20 @@ import "github.com/spf13/pflag"
 const (
 	flagQUICEnvironmentVariableName = "quic-environment-variable-name"
 	defaultEnvironmentVariableName  = "AWS_LBC_QUIC_SERVER_ID"
+	flagQUICServerIDCollisionRepair = "quic-server-id-collision-repair"
+	defaultServerIDCollisionRepair  = true
 )
 
 // ServerIDInjectionConfig configuration for handling Server ID injection.
 type ServerIDInjectionConfig struct {
 	EnvironmentVariableName string
+	// CollisionRepair enables deleting colliding pods detected by the server ID registry, so that they get recreated with a unique server ID.
+	CollisionRepair bool
 }
 
 func (cfg *ServerIDInjectionConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.EnvironmentVariableName, flagQUICEnvironmentVariableName, defaultEnvironmentVariableName,
 		`The environment variable to find the generated QUIC Server ID.`)
+	fs.BoolVar(&cfg.CollisionRepair, flagQUICServerIDCollisionRepair, defaultServerIDCollisionRepair,
+		`Delete the newer controller-managed pod of pods sharing a QUIC Server ID, only applies when the QUICServerIDRegistry feature is enabled.`)
 }
@@ -15,11 +15,13 @@ func TestServerIDInjectionConfig_BindFlags_Integration(t *testing.T) {
 
 	// Test default value
 	assert.Equal(t, defaultEnvironmentVariableName, config.EnvironmentVariableName)
+	assert.True(t, config.CollisionRepair)
 
 	// Test setting custom value
-	err := fs.Parse([]string{"--quic-environment-variable-name", "CUSTOM_QUIC_ID"})
+	err := fs.Parse([]string{"--quic-environment-variable-name", "CUSTOM_QUIC_ID", "--quic-server-id-collision-repair=false"})
 	assert.NoError(t, err)
 	assert.Equal(t, "CUSTOM_QUIC_ID", config.EnvironmentVariableName)
+	assert.False(t, config.CollisionRepair)
 }
 
 func TestServerIDInjectionConfig_DefaultValues(t *testing.T) {
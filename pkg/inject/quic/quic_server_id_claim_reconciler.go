@@ -0,0 +1,385 @@
+package quic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// claimReconcileInterval is the interval between reconciles of the server ID claims, pod changes aren't watched.
+	claimReconcileInterval = 1 * time.Minute
+	// jitter applied to the reconcile interval.
+	claimReconcileIntervalJitterFactor = 0.1
+	// pendingClaimGracePeriod is how long a claim without pod is kept, to cover the pod creation after admission.
+	pendingClaimGracePeriod = 5 * time.Minute
+)
+
+var _ manager.Runnable = &serverIDClaimReconciler{}
+var _ manager.LeaderElectionRunnable = &serverIDClaimReconciler{}
+
+// NewServerIDClaimReconciler constructs new serverIDClaimReconciler.
+// Only claims in watchNamespace are reconciled when it's set, as pods of other namespaces aren't known.
+func NewServerIDClaimReconciler(k8sClient client.Client, apiReader client.Reader, podInfoRepo k8s.PodInfoRepo,
+	eventRecorder record.EventRecorder, metricsCollector lbcmetrics.MetricCollector, watchNamespace string,
+	collisionRepair bool, logger logr.Logger) *serverIDClaimReconciler {
+	return &serverIDClaimReconciler{
+		k8sClient:          k8sClient,
+		apiReader:          apiReader,
+		podInfoRepo:        podInfoRepo,
+		eventRecorder:      eventRecorder,
+		metricsCollector:   metricsCollector,
+		watchNamespace:     watchNamespace,
+		collisionRepair:    collisionRepair,
+		interval:           claimReconcileInterval,
+		logger:             logger,
+		clock:              time.Now,
+		reportedNamespaces: sets.New[string](),
+	}
+}
+
+// serverIDClaimReconciler periodically binds the QUICServerIDClaims to the pods using their server ID.
+// It releases the claims of deleted pods, retains the claims of StatefulSet pods for their next incarnation,
+// claims server IDs of pods admitted without claim, and repairs pods sharing a server ID.
+type serverIDClaimReconciler struct {
+	k8sClient        client.Client
+	apiReader        client.Reader
+	podInfoRepo      k8s.PodInfoRepo
+	eventRecorder    record.EventRecorder
+	metricsCollector lbcmetrics.MetricCollector
+	watchNamespace   string
+	collisionRepair  bool
+	interval         time.Duration
+	logger           logr.Logger
+	clock            func() time.Time
+
+	// reportedNamespaces are the namespaces with claim metrics, so that they're dropped once the namespace has no claims.
+	reportedNamespaces sets.Set[string]
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=quicserveridclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=quicserveridclaims/status,verbs=update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+
+// Start runs the reconcile loop until ctx is done.
+func (r *serverIDClaimReconciler) Start(ctx context.Context) error {
+	r.logger.Info("starting QUIC server ID claim reconciler", "interval", r.interval)
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Reconcile(ctx); err != nil {
+			r.logger.Error(err, "failed to reconcile QUIC server ID claims")
+		}
+	}, r.interval, claimReconcileIntervalJitterFactor, true)
+	return nil
+}
+
+// NeedLeaderElection makes sure only the leader reconciles claims.
+func (r *serverIDClaimReconciler) NeedLeaderElection() bool {
+	return true
+}
+
+// claimCounts are the number of claims per phase and the number of colliding pods of a namespace.
+type claimCounts struct {
+	pending       int
+	bound         int
+	retained      int
+	collidingPods int
+}
+
+// Reconcile runs a single reconcile of all server ID claims.
+func (r *serverIDClaimReconciler) Reconcile(ctx context.Context) error {
+	claimList := &elbv2api.QUICServerIDClaimList{}
+	if err := r.k8sClient.List(ctx, claimList, client.InNamespace(r.watchNamespace)); err != nil {
+		return err
+	}
+	claimsByNamespace := make(map[string][]*elbv2api.QUICServerIDClaim)
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		claimsByNamespace[claim.Namespace] = append(claimsByNamespace[claim.Namespace], claim)
+	}
+	podsByNamespace, err := r.listPodsWithServerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	namespaces := sets.KeySet(claimsByNamespace).Union(sets.KeySet(podsByNamespace))
+	for _, namespace := range sets.List(namespaces) {
+		counts, err := r.reconcileNamespace(ctx, claimsByNamespace[namespace], podsByNamespace[namespace])
+		if err != nil {
+			return err
+		}
+		r.metricsCollector.ObserveQUICServerIDClaims(namespace, counts.pending, counts.bound, counts.retained, counts.collidingPods)
+	}
+	for _, namespace := range sets.List(r.reportedNamespaces.Difference(namespaces)) {
+		r.metricsCollector.DeleteQUICServerIDClaims(namespace)
+	}
+	r.reportedNamespaces = namespaces
+	return nil
+}
+
+// listPodsWithServerID returns the pods with QUIC server IDs per namespace.
+func (r *serverIDClaimReconciler) listPodsWithServerID(ctx context.Context) (map[string][]k8s.PodInfo, error) {
+	podsByNamespace := make(map[string][]k8s.PodInfo)
+	for _, podKey := range r.podInfoRepo.ListKeys(ctx) {
+		if r.watchNamespace != "" && podKey.Namespace != r.watchNamespace {
+			continue
+		}
+		pod, exists, err := r.podInfoRepo.Get(ctx, podKey)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || len(pod.QUICServerIDs) == 0 {
+			continue
+		}
+		podsByNamespace[podKey.Namespace] = append(podsByNamespace[podKey.Namespace], pod)
+	}
+	return podsByNamespace, nil
+}
+
+func (r *serverIDClaimReconciler) reconcileNamespace(ctx context.Context, claims []*elbv2api.QUICServerIDClaim, pods []k8s.PodInfo) (claimCounts, error) {
+	// pods are ordered by age, the oldest pod keeps a shared server ID.
+	sort.Slice(pods, func(i, j int) bool {
+		if !pods[i].CreationTime.Equal(&pods[j].CreationTime) {
+			return pods[i].CreationTime.Before(&pods[j].CreationTime)
+		}
+		return pods[i].Key.Name < pods[j].Key.Name
+	})
+	podsByServerID := make(map[string][]k8s.PodInfo)
+	for _, pod := range pods {
+		for _, serverID := range sets.List(sets.New(pod.QUICServerIDs...)) {
+			podsByServerID[serverID] = append(podsByServerID[serverID], pod)
+		}
+	}
+
+	var counts claimCounts
+	claimedServerIDs := sets.New[string]()
+	for _, claim := range claims {
+		claimedServerIDs.Insert(claim.Spec.ServerID)
+		if !claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		phase, collidingPods, err := r.reconcileClaim(ctx, claim, podsByServerID[claim.Spec.ServerID])
+		if err != nil {
+			return claimCounts{}, err
+		}
+		counts.collidingPods += collidingPods
+		switch phase {
+		case elbv2api.QUICServerIDClaimPhasePending:
+			counts.pending++
+		case elbv2api.QUICServerIDClaimPhaseBound:
+			counts.bound++
+		case elbv2api.QUICServerIDClaimPhaseRetained:
+			counts.retained++
+		}
+	}
+
+	// server IDs of pods admitted before the registry was enabled, or set explicitly, are claimed so that they aren't allocated again.
+	for _, serverID := range sets.List(sets.KeySet(podsByServerID).Difference(claimedServerIDs)) {
+		if err := r.adoptServerID(ctx, serverID, podsByServerID[serverID][0]); err != nil {
+			return claimCounts{}, err
+		}
+		counts.pending++
+	}
+	return counts, nil
+}
+
+// reconcileClaim binds, retains or releases the claim, and repairs the pods colliding on its server ID.
+// It returns the phase of the claim, empty if the claim was released, and the number of colliding pods.
+func (r *serverIDClaimReconciler) reconcileClaim(ctx context.Context, claim *elbv2api.QUICServerIDClaim, pods []k8s.PodInfo) (elbv2api.QUICServerIDClaimPhase, int, error) {
+	owner, collidingPods := electServerIDOwner(claim, pods)
+	var status elbv2api.QUICServerIDClaimStatus
+	if owner != nil {
+		status = elbv2api.QUICServerIDClaimStatus{
+			Phase:   elbv2api.QUICServerIDClaimPhaseBound,
+			PodName: owner.Key.Name,
+			PodUID:  owner.UID,
+		}
+		for _, pod := range collidingPods {
+			status.CollidingPodNames = append(status.CollidingPodNames, pod.Key.Name)
+		}
+	} else {
+		phase, err := r.computeUnboundClaimPhase(ctx, claim)
+		if err != nil {
+			return "", 0, err
+		}
+		if phase == "" {
+			r.logger.Info("releasing QUIC server ID claim", "claim", k8s.NamespacedName(claim), "serverID", claim.Spec.ServerID)
+			if err := r.k8sClient.Delete(ctx, claim); err != nil {
+				return "", 0, client.IgnoreNotFound(err)
+			}
+			return "", 0, nil
+		}
+		status = elbv2api.QUICServerIDClaimStatus{Phase: phase}
+	}
+
+	if len(collidingPods) != 0 {
+		if !equality.Semantic.DeepEqual(claim.Status.CollidingPodNames, status.CollidingPodNames) {
+			r.eventRecorder.Event(claim, corev1.EventTypeWarning, k8s.QUICServerIDClaimEventReasonCollisionDetected,
+				fmt.Sprintf("Pods %s share server ID %s with pod %s", strings.Join(status.CollidingPodNames, ","), claim.Spec.ServerID, owner.Key.Name))
+		}
+		if r.collisionRepair {
+			for _, pod := range collidingPods {
+				r.repairCollision(ctx, claim, pod)
+			}
+		}
+	}
+	if err := r.updateClaimStatus(ctx, claim, status); err != nil {
+		return "", 0, err
+	}
+	return status.Phase, len(collidingPods), nil
+}
+
+// electServerIDOwner returns the pod keeping the server ID of the claim and the other pods using it.
+// The pod bound to the claim is preferred, then the pod the claim was allocated for, then the oldest pod.
+func electServerIDOwner(claim *elbv2api.QUICServerIDClaim, pods []k8s.PodInfo) (*k8s.PodInfo, []k8s.PodInfo) {
+	if len(pods) == 0 {
+		return nil, nil
+	}
+	ownerIdx := 0
+	for i, pod := range pods {
+		if claim.Status.PodUID != "" && pod.UID == claim.Status.PodUID {
+			ownerIdx = i
+			break
+		}
+		if claim.Spec.PodName != "" && pod.Key.Name == claim.Spec.PodName {
+			ownerIdx = i
+		}
+	}
+	var collidingPods []k8s.PodInfo
+	for i, pod := range pods {
+		if i != ownerIdx {
+			collidingPods = append(collidingPods, pod)
+		}
+	}
+	return &pods[ownerIdx], collidingPods
+}
+
+// computeUnboundClaimPhase returns the phase of a claim no pod uses, empty if the claim should be released.
+func (r *serverIDClaimReconciler) computeUnboundClaimPhase(ctx context.Context, claim *elbv2api.QUICServerIDClaim) (elbv2api.QUICServerIDClaimPhase, error) {
+	if claim.Spec.StatefulSetName != "" {
+		retained, err := r.isStatefulSetPodRetained(ctx, claim.Namespace, claim.Spec.StatefulSetName, claim.Spec.PodName)
+		if err != nil {
+			return "", err
+		}
+		if retained {
+			return elbv2api.QUICServerIDClaimPhaseRetained, nil
+		}
+	}
+	neverBound := claim.Status.Phase == "" || claim.Status.Phase == elbv2api.QUICServerIDClaimPhasePending
+	if neverBound && r.clock().Sub(claim.CreationTimestamp.Time) < pendingClaimGracePeriod {
+		return elbv2api.QUICServerIDClaimPhasePending, nil
+	}
+	return "", nil
+}
+
+// isStatefulSetPodRetained returns whether the StatefulSet still wants a pod with the name, i.e. its ordinal is within the replicas.
+func (r *serverIDClaimReconciler) isStatefulSetPodRetained(ctx context.Context, namespace string, statefulSetName string, podName string) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: statefulSetName}, sts); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if !sts.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+	ordinal, err := strconv.Atoi(strings.TrimPrefix(podName, statefulSetName+"-"))
+	if err != nil {
+		return false, nil
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	startOrdinal := int32(0)
+	if sts.Spec.Ordinals != nil {
+		startOrdinal = sts.Spec.Ordinals.Start
+	}
+	return int32(ordinal) >= startOrdinal && int32(ordinal) < startOrdinal+replicas, nil
+}
+
+// repairCollision evicts a pod sharing the server ID of the claim, so that its controller recreates it with a unique server ID.
+// The eviction API is used so that PodDisruptionBudgets limit how many pods of a workload are repaired at once, blocked evictions
+// are retried on the next reconcile. Pods without controller aren't evicted, as they wouldn't be recreated.
+func (r *serverIDClaimReconciler) repairCollision(ctx context.Context, claim *elbv2api.QUICServerIDClaim, podInfo k8s.PodInfo) {
+	pod := &corev1.Pod{}
+	if err := r.apiReader.Get(ctx, podInfo.Key, pod); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			r.eventRecorder.Event(claim, corev1.EventTypeWarning, k8s.QUICServerIDClaimEventReasonFailedRepair, fmt.Sprintf("Failed get pod %s due to %v", podInfo.Key.Name, err))
+		}
+		return
+	}
+	if metav1.GetControllerOf(pod) == nil {
+		r.logger.Info("colliding pod has no controller, skipping repair", "pod", podInfo.Key, "serverID", claim.Spec.ServerID)
+		return
+	}
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &pod.UID},
+		},
+	}
+	if err := r.k8sClient.SubResource("eviction").Create(ctx, pod, eviction); err != nil {
+		if apierrors.IsTooManyRequests(err) {
+			r.logger.Info("eviction of pod colliding on QUIC server ID is blocked by a PodDisruptionBudget, retrying later", "pod", podInfo.Key, "serverID", claim.Spec.ServerID)
+			return
+		}
+		if client.IgnoreNotFound(err) != nil {
+			r.eventRecorder.Event(claim, corev1.EventTypeWarning, k8s.QUICServerIDClaimEventReasonFailedRepair, fmt.Sprintf("Failed evict pod %s due to %v", podInfo.Key.Name, err))
+		}
+		return
+	}
+	r.logger.Info("evicted pod colliding on QUIC server ID", "pod", podInfo.Key, "serverID", claim.Spec.ServerID)
+	r.eventRecorder.Event(claim, corev1.EventTypeNormal, k8s.QUICServerIDClaimEventReasonCollisionRepaired, fmt.Sprintf("Evicted pod %s", podInfo.Key.Name))
+}
+
+// adoptServerID claims the server ID of a pod admitted without claim.
+func (r *serverIDClaimReconciler) adoptServerID(ctx context.Context, serverID string, pod k8s.PodInfo) error {
+	claim := &elbv2api.QUICServerIDClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Key.Namespace,
+			Name:      ServerIDClaimName(serverID),
+		},
+		Spec: elbv2api.QUICServerIDClaimSpec{
+			ServerID: serverID,
+			PodName:  pod.Key.Name,
+		},
+	}
+	r.logger.Info("claiming QUIC server ID of pod admitted without claim", "pod", pod.Key, "serverID", serverID)
+	if err := r.k8sClient.Create(ctx, claim); err != nil {
+		// the claim may be missing from the cache only.
+		return client.IgnoreAlreadyExists(err)
+	}
+	return nil
+}
+
+func (r *serverIDClaimReconciler) updateClaimStatus(ctx context.Context, claim *elbv2api.QUICServerIDClaim, status elbv2api.QUICServerIDClaimStatus) error {
+	if equality.Semantic.DeepEqual(claim.Status, status) {
+		return nil
+	}
+	claimOld := claim.DeepCopy()
+	claim.Status = status
+	return client.IgnoreNotFound(r.k8sClient.Status().Patch(ctx, claim, client.MergeFrom(claimOld)))
+}
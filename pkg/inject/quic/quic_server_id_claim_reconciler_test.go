@@ -0,0 +1,199 @@
+package quic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func Test_serverIDClaimReconciler_Reconcile(t *testing.T) {
+	now := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+	isController := true
+	buildClaim := func(serverID string, podName string, statefulSetName string, phase elbv2api.QUICServerIDClaimPhase, age time.Duration) *elbv2api.QUICServerIDClaim {
+		return &elbv2api.QUICServerIDClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "app",
+				Name:              ServerIDClaimName(serverID),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: elbv2api.QUICServerIDClaimSpec{
+				ServerID:        serverID,
+				PodName:         podName,
+				StatefulSetName: statefulSetName,
+			},
+			Status: elbv2api.QUICServerIDClaimStatus{Phase: phase},
+		}
+	}
+	buildPodInfo := func(name string, uid types.UID, serverID string, age time.Duration) k8s.PodInfo {
+		return k8s.PodInfo{
+			Key:           types.NamespacedName{Namespace: "app", Name: name},
+			UID:           uid,
+			CreationTime:  metav1.NewTime(now.Add(-age)),
+			QUICServerIDs: []string{serverID},
+		}
+	}
+	claims := []*elbv2api.QUICServerIDClaim{
+		// shared by two pods, the newer one is repaired.
+		buildClaim("0x0000000000000001", "", "", elbv2api.QUICServerIDClaimPhasePending, time.Hour),
+		// pod deleted.
+		buildClaim("0x0000000000000002", "", "", elbv2api.QUICServerIDClaimPhaseBound, time.Hour),
+		// StatefulSet pod being recreated.
+		buildClaim("0x0000000000000003", "web-1", "web", elbv2api.QUICServerIDClaimPhaseBound, time.Hour),
+		// StatefulSet scaled in.
+		buildClaim("0x0000000000000004", "web-5", "web", elbv2api.QUICServerIDClaimPhaseBound, time.Hour),
+		// pod not created yet.
+		buildClaim("0x0000000000000005", "", "", elbv2api.QUICServerIDClaimPhasePending, time.Minute),
+		// pod never created.
+		buildClaim("0x0000000000000006", "", "", elbv2api.QUICServerIDClaimPhasePending, time.Hour),
+	}
+	podInfos := map[types.NamespacedName]k8s.PodInfo{
+		{Namespace: "app", Name: "api-1"}:  buildPodInfo("api-1", "uid-1", "0x0000000000000001", 2*time.Hour),
+		{Namespace: "app", Name: "api-2"}:  buildPodInfo("api-2", "uid-2", "0x0000000000000001", time.Hour),
+		{Namespace: "app", Name: "legacy"}: buildPodInfo("legacy", "uid-3", "0x0000000000000007", time.Hour),
+		{Namespace: "app", Name: "no-quic"}: {
+			Key: types.NamespacedName{Namespace: "app", Name: "no-quic"},
+		},
+	}
+	collidingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "api-2",
+			UID:       "uid-2",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5d4f", Controller: &isController},
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: awssdk.Int32(2)},
+	}
+
+	tests := []struct {
+		name            string
+		collisionRepair bool
+		evictionErr     error
+		wantPodDeleted  bool
+	}{
+		{
+			name:            "reconciles claims and repairs collisions",
+			collisionRepair: true,
+			wantPodDeleted:  true,
+		},
+		{
+			name:            "reconciles claims and retries collisions repair blocked by disruption budget",
+			collisionRepair: true,
+			evictionErr:     apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0),
+			wantPodDeleted:  false,
+		},
+		{
+			name:            "reconciles claims and reports collisions only",
+			collisionRepair: false,
+			wantPodDeleted:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithStatusSubresource(&elbv2api.QUICServerIDClaim{}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+						if subResourceName == "eviction" && tt.evictionErr != nil {
+							return tt.evictionErr
+						}
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					},
+				}).Build()
+			for _, claim := range claims {
+				claim := claim.DeepCopy()
+				status := claim.Status
+				assert.NoError(t, k8sClient.Create(ctx, claim))
+				claim.Status = status
+				assert.NoError(t, k8sClient.Status().Update(ctx, claim))
+			}
+			assert.NoError(t, k8sClient.Create(ctx, collidingPod.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, statefulSet.DeepCopy()))
+
+			podInfoRepo := k8s.NewMockPodInfoRepo(ctrl)
+			var podKeys []types.NamespacedName
+			for podKey := range podInfos {
+				podKeys = append(podKeys, podKey)
+			}
+			podInfoRepo.EXPECT().ListKeys(gomock.Any()).Return(podKeys)
+			podInfoRepo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key types.NamespacedName) (k8s.PodInfo, bool, error) {
+				podInfo, exists := podInfos[key]
+				return podInfo, exists, nil
+			}).AnyTimes()
+			metricsCollector := lbcmetrics.NewMockCollector()
+
+			r := NewServerIDClaimReconciler(k8sClient, k8sClient, podInfoRepo, record.NewFakeRecorder(10), metricsCollector,
+				"", tt.collisionRepair, logr.Discard())
+			r.clock = func() time.Time { return now }
+			r.reportedNamespaces = sets.New("app", "gone")
+			assert.NoError(t, r.Reconcile(ctx))
+
+			claimList := &elbv2api.QUICServerIDClaimList{}
+			assert.NoError(t, k8sClient.List(ctx, claimList))
+			gotStatuses := make(map[string]elbv2api.QUICServerIDClaimStatus)
+			for _, claim := range claimList.Items {
+				gotStatuses[claim.Spec.ServerID] = claim.Status
+			}
+			assert.Equal(t, map[string]elbv2api.QUICServerIDClaimStatus{
+				"0x0000000000000001": {
+					Phase:             elbv2api.QUICServerIDClaimPhaseBound,
+					PodName:           "api-1",
+					PodUID:            "uid-1",
+					CollidingPodNames: []string{"api-2"},
+				},
+				"0x0000000000000003": {Phase: elbv2api.QUICServerIDClaimPhaseRetained},
+				"0x0000000000000005": {Phase: elbv2api.QUICServerIDClaimPhasePending},
+				"0x0000000000000007": {},
+			}, gotStatuses)
+
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(collidingPod), &corev1.Pod{})
+			assert.Equal(t, tt.wantPodDeleted, apierrors.IsNotFound(err))
+
+			mockCollector := metricsCollector.(*lbcmetrics.MockCollector)
+			assert.Equal(t, []interface{}{
+				lbcmetrics.MockQUICServerIDClaimsMetric{
+					Namespace:     "app",
+					Pending:       2,
+					Bound:         1,
+					Retained:      1,
+					CollidingPods: 1,
+				},
+				lbcmetrics.MockQUICServerIDClaimsMetric{
+					Namespace: "gone",
+					Deleted:   true,
+				},
+			}, mockCollector.Invocations[lbcmetrics.MetricQuicServerIdClaims])
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strings"
 )
@@ -19,15 +21,25 @@ type QUICServerIDInjector interface {
 type quicServerIDInjectorImpl struct {
 	config      ServerIDInjectionConfig
 	idGenerator quicServerIDGenerator
-	logger      logr.Logger
+	// registry claims the generated server IDs, nil when the server ID registry is disabled.
+	registry serverIDRegistry
+	logger   logr.Logger
 }
 
 // NewQUICServerIDInjector constructs a new injector to generate QUIC server IDs for containers.
-func NewQUICServerIDInjector(config ServerIDInjectionConfig, client client.Client, apiReader client.Reader, logger logr.Logger) QUICServerIDInjector {
+// When registryEnabled, the server IDs are claimed with QUICServerIDClaims to guarantee their uniqueness within the namespace.
+func NewQUICServerIDInjector(config ServerIDInjectionConfig, client client.Client, apiReader client.Reader, registryEnabled bool,
+	metricsCollector lbcmetrics.MetricCollector, logger logr.Logger) QUICServerIDInjector {
+	idGenerator := newQuicServerIDGenerator(newWorkerIdGenerator(client, apiReader))
+	var registry serverIDRegistry
+	if registryEnabled {
+		registry = newServerIDRegistry(client, idGenerator, metricsCollector, logger)
+	}
 	return &quicServerIDInjectorImpl{
 		config:      config,
 		logger:      logger,
-		idGenerator: newQuicServerIDGenerator(newWorkerIdGenerator(client, apiReader)),
+		idGenerator: idGenerator,
+		registry:    registry,
 	}
 }
 
@@ -44,16 +56,21 @@ func (m *quicServerIDInjectorImpl) Mutate(ctx context.Context, pod *corev1.Pod)
 		return nil
 	}
 
+	namespace := pod.Namespace
+	if req := webhook.ContextGetAdmissionRequest(ctx); req != nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+
 	containerNameSet := sets.New(strings.Split(containerNameList, ",")...)
 	for i := range pod.Spec.Containers {
-		err := m.mutateContainerSpec(&pod.Spec.Containers[i], containerNameSet)
+		err := m.mutateContainerSpec(ctx, namespace, pod, &pod.Spec.Containers[i], containerNameSet)
 		if err != nil {
 			return err
 		}
 	}
 
 	for i := range pod.Spec.InitContainers {
-		err := m.mutateContainerSpec(&pod.Spec.InitContainers[i], containerNameSet)
+		err := m.mutateContainerSpec(ctx, namespace, pod, &pod.Spec.InitContainers[i], containerNameSet)
 		if err != nil {
 			return err
 		}
@@ -62,7 +79,7 @@ func (m *quicServerIDInjectorImpl) Mutate(ctx context.Context, pod *corev1.Pod)
 	return nil
 }
 
-func (m *quicServerIDInjectorImpl) mutateContainerSpec(cont *corev1.Container, containerNameSet sets.Set[string]) error {
+func (m *quicServerIDInjectorImpl) mutateContainerSpec(ctx context.Context, namespace string, pod *corev1.Pod, cont *corev1.Container, containerNameSet sets.Set[string]) error {
 	if containerNameSet.Has(cont.Name) {
 		if cont.Env == nil {
 			cont.Env = make([]corev1.EnvVar, 0)
@@ -78,7 +95,7 @@ func (m *quicServerIDInjectorImpl) mutateContainerSpec(cont *corev1.Container, c
 
 		if !duplicateFound {
 
-			serverId, err := m.idGenerator.generate()
+			serverId, err := m.generateServerID(ctx, namespace, pod, cont.Name)
 			if err != nil {
 				return err
 			}
@@ -91,3 +108,12 @@ func (m *quicServerIDInjectorImpl) mutateContainerSpec(cont *corev1.Container, c
 	}
 	return nil
 }
+
+// generateServerID claims the server ID when the registry is enabled, unless the admission request is a dry run.
+func (m *quicServerIDInjectorImpl) generateServerID(ctx context.Context, namespace string, pod *corev1.Pod, containerName string) (string, error) {
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	if m.registry != nil && (req == nil || req.DryRun == nil || !*req.DryRun) {
+		return m.registry.allocate(ctx, namespace, pod, containerName)
+	}
+	return m.idGenerator.generate()
+}
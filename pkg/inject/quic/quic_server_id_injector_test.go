@@ -8,12 +8,18 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // Mock ID generator for testing
@@ -35,7 +41,7 @@ func TestQUICServerIDInjector_Mutate_NoAnnotations(t *testing.T) {
 	clientgoscheme.AddToScheme(scheme)
 	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
 
-	injector := NewQUICServerIDInjector(config, client, client, logr.Discard())
+	injector := NewQUICServerIDInjector(config, client, client, false, lbcmetrics.NewMockCollector(), logr.Discard())
 
 	pod := &corev1.Pod{
 		Spec: corev1.PodSpec{
@@ -60,7 +66,7 @@ func TestQUICServerIDInjector_Mutate_NoQuicAnnotation(t *testing.T) {
 	clientgoscheme.AddToScheme(scheme)
 	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
 
-	injector := NewQUICServerIDInjector(config, client, client, logr.Discard())
+	injector := NewQUICServerIDInjector(config, client, client, false, lbcmetrics.NewMockCollector(), logr.Discard())
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -396,6 +402,106 @@ func TestQUICServerIDInjector_Mutate_WhitespaceInContainerList(t *testing.T) {
 	assert.Equal(t, "TEST_QUIC_ID", pod.Spec.Containers[2].Env[0].Name)
 }
 
+func TestQUICServerIDInjector_Mutate_WithRegistry(t *testing.T) {
+	config := ServerIDInjectionConfig{
+		EnvironmentVariableName: "TEST_QUIC_ID",
+	}
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	elbv2api.AddToScheme(scheme)
+	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
+	idGenerator := &mockQuicServerIDGenerator{
+		id: "3q2+78r+ur4=",
+	}
+
+	injector := &quicServerIDInjectorImpl{
+		config:      config,
+		logger:      logr.Discard(),
+		idGenerator: idGenerator,
+		registry:    newServerIDRegistry(client, idGenerator, lbcmetrics.NewMockCollector(), logr.Discard()),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-pod-",
+			Annotations: map[string]string{
+				annotations.QuicEnabledContainersAnnotation: "test-container",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container"},
+			},
+		},
+	}
+
+	ctx := webhook.ContextWithAdmissionRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Namespace: "test-ns"},
+	})
+	err := injector.Mutate(ctx, pod)
+
+	require.NoError(t, err)
+	require.Len(t, pod.Spec.Containers[0].Env, 1)
+	assert.Equal(t, "3q2+78r+ur4=", pod.Spec.Containers[0].Env[0].Value)
+
+	claim := &elbv2api.QUICServerIDClaim{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "test-ns", Name: "quic-deadbeefcafebabe"}, claim))
+	assert.Equal(t, elbv2api.QUICServerIDClaimSpec{
+		ServerID:      "0xdeadbeefcafebabe",
+		ContainerName: "test-container",
+	}, claim.Spec)
+}
+
+func TestQUICServerIDInjector_Mutate_WithRegistryDryRun(t *testing.T) {
+	config := ServerIDInjectionConfig{
+		EnvironmentVariableName: "TEST_QUIC_ID",
+	}
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	elbv2api.AddToScheme(scheme)
+	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
+	idGenerator := &mockQuicServerIDGenerator{
+		id: "3q2+78r+ur4=",
+	}
+
+	injector := &quicServerIDInjectorImpl{
+		config:      config,
+		logger:      logr.Discard(),
+		idGenerator: idGenerator,
+		registry:    newServerIDRegistry(client, idGenerator, lbcmetrics.NewMockCollector(), logr.Discard()),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-pod-",
+			Annotations: map[string]string{
+				annotations.QuicEnabledContainersAnnotation: "test-container",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container"},
+			},
+		},
+	}
+
+	dryRun := true
+	ctx := webhook.ContextWithAdmissionRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Namespace: "test-ns", DryRun: &dryRun},
+	})
+	err := injector.Mutate(ctx, pod)
+
+	require.NoError(t, err)
+	require.Len(t, pod.Spec.Containers[0].Env, 1)
+	assert.Equal(t, "3q2+78r+ur4=", pod.Spec.Containers[0].Env[0].Value)
+
+	claims := &elbv2api.QUICServerIDClaimList{}
+	require.NoError(t, client.List(context.Background(), claims))
+	assert.Empty(t, claims.Items)
+}
+
 func TestServerIDInjectionConfig_BindFlags(t *testing.T) {
 	config := &ServerIDInjectionConfig{}
 
@@ -0,0 +1,159 @@
+package quic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// IndexKeyServerIDClaimPodName is the index of QUICServerIDClaims by the pod they were allocated for.
+	IndexKeyServerIDClaimPodName = "spec.podName"
+
+	// serverIDClaimNamePrefix is the prefix of QUICServerIDClaim names, followed by the hex server ID.
+	serverIDClaimNamePrefix = "quic-"
+	// elbServerIDPrefix is the mandatory prefix of server IDs used for target registration.
+	elbServerIDPrefix = "0x"
+	// maxServerIDAllocationAttempts is the number of server IDs generated before giving up on a conflicting allocation.
+	maxServerIDAllocationAttempts = 5
+)
+
+// IndexFuncServerIDClaimPodName indexes QUICServerIDClaims by the pod they were allocated for.
+func IndexFuncServerIDClaimPodName(obj client.Object) []string {
+	claim := obj.(*elbv2api.QUICServerIDClaim)
+	if claim.Spec.PodName == "" {
+		return nil
+	}
+	return []string{claim.Spec.PodName}
+}
+
+// ServerIDClaimName returns the name of the QUICServerIDClaim for a server ID in the hex format used for target registration.
+func ServerIDClaimName(elbServerID string) string {
+	return serverIDClaimNamePrefix + strings.TrimPrefix(elbServerID, elbServerIDPrefix)
+}
+
+// serverIDRegistry allocates server IDs that are unique within a namespace by claiming them with QUICServerIDClaims.
+type serverIDRegistry interface {
+	// allocate returns the base64 server ID to inject into the container of the pod.
+	allocate(ctx context.Context, namespace string, pod *corev1.Pod, containerName string) (string, error)
+}
+
+func newServerIDRegistry(k8sClient client.Client, idGenerator quicServerIDGenerator, metricsCollector lbcmetrics.MetricCollector, logger logr.Logger) serverIDRegistry {
+	return &serverIDRegistryImpl{
+		k8sClient:        k8sClient,
+		idGenerator:      idGenerator,
+		metricsCollector: metricsCollector,
+		logger:           logger,
+	}
+}
+
+type serverIDRegistryImpl struct {
+	k8sClient        client.Client
+	idGenerator      quicServerIDGenerator
+	metricsCollector lbcmetrics.MetricCollector
+	logger           logr.Logger
+}
+
+func (r *serverIDRegistryImpl) allocate(ctx context.Context, namespace string, pod *corev1.Pod, containerName string) (string, error) {
+	statefulSetName := statefulSetOwnerName(pod)
+	if statefulSetName != "" {
+		serverID, found, err := r.findStatefulSetPodServerID(ctx, namespace, pod.Name, containerName)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			r.logger.V(1).Info("reusing QUIC server ID of StatefulSet pod", "pod", pod.Name, "namespace", namespace, "container", containerName)
+			return serverID, nil
+		}
+	}
+
+	for attempt := 0; attempt < maxServerIDAllocationAttempts; attempt++ {
+		serverID, err := r.idGenerator.generate()
+		if err != nil {
+			return "", err
+		}
+		elbServerID, err := convertServerIDToELBFormat(serverID)
+		if err != nil {
+			return "", err
+		}
+		claim := &elbv2api.QUICServerIDClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      ServerIDClaimName(elbServerID),
+			},
+			Spec: elbv2api.QUICServerIDClaimSpec{
+				ServerID:        elbServerID,
+				ContainerName:   containerName,
+				PodName:         pod.Name,
+				StatefulSetName: statefulSetName,
+			},
+		}
+		if err := r.k8sClient.Create(ctx, claim); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				r.logger.Info("generated QUIC server ID is already claimed, regenerating", "serverID", elbServerID, "namespace", namespace)
+				r.metricsCollector.ObserveQUICServerIDAllocationConflict(namespace)
+				continue
+			}
+			return "", err
+		}
+		return serverID, nil
+	}
+	return "", fmt.Errorf("unable to allocate an unclaimed QUIC server ID after %d attempts", maxServerIDAllocationAttempts)
+}
+
+// findStatefulSetPodServerID returns the server ID claimed for a previous incarnation of the StatefulSet pod.
+func (r *serverIDRegistryImpl) findStatefulSetPodServerID(ctx context.Context, namespace string, podName string, containerName string) (string, bool, error) {
+	claimList := &elbv2api.QUICServerIDClaimList{}
+	if err := r.k8sClient.List(ctx, claimList, client.InNamespace(namespace),
+		client.MatchingFields{IndexKeyServerIDClaimPodName: podName}); err != nil {
+		return "", false, err
+	}
+	for _, claim := range claimList.Items {
+		if claim.Spec.ContainerName != containerName || claim.Spec.StatefulSetName == "" || !claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		serverID, err := convertServerIDFromELBFormat(claim.Spec.ServerID)
+		if err != nil {
+			return "", false, err
+		}
+		return serverID, true, nil
+	}
+	return "", false, nil
+}
+
+// statefulSetOwnerName returns the StatefulSet controlling the pod, StatefulSet pods have a stable name known at admission.
+func statefulSetOwnerName(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "StatefulSet" || pod.Name == "" {
+		return ""
+	}
+	return owner.Name
+}
+
+// convertServerIDToELBFormat converts a base64 server ID into the hex format used for target registration.
+func convertServerIDToELBFormat(serverID string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(serverID)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64: %w", err)
+	}
+	return elbServerIDPrefix + hex.EncodeToString(data), nil
+}
+
+// convertServerIDFromELBFormat converts a server ID in the hex format used for target registration into base64.
+func convertServerIDFromELBFormat(elbServerID string) (string, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(elbServerID, elbServerIDPrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode hex: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
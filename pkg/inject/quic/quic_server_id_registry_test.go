@@ -0,0 +1,151 @@
+package quic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// sequenceQuicServerIDGenerator returns the ids in order
+type sequenceQuicServerIDGenerator struct {
+	ids []string
+}
+
+func (m *sequenceQuicServerIDGenerator) generate() (string, error) {
+	id := m.ids[0]
+	m.ids = m.ids[1:]
+	return id, nil
+}
+
+func Test_serverIDRegistryImpl_allocate(t *testing.T) {
+	isController := true
+	statefulSetPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-0",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", Controller: &isController},
+			},
+		},
+	}
+	deploymentPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "web-5d4f-",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d4f", Controller: &isController},
+			},
+		},
+	}
+	buildClaim := func(serverID string, podName string, statefulSetName string) *elbv2api.QUICServerIDClaim {
+		return &elbv2api.QUICServerIDClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: ServerIDClaimName(serverID)},
+			Spec: elbv2api.QUICServerIDClaimSpec{
+				ServerID:        serverID,
+				ContainerName:   "server",
+				PodName:         podName,
+				StatefulSetName: statefulSetName,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		existingClaims []*elbv2api.QUICServerIDClaim
+		pod            *corev1.Pod
+		generatedIDs   []string
+		wantServerID   string
+		wantClaim      *elbv2api.QUICServerIDClaim
+		wantConflicts  int
+		wantErr        string
+	}{
+		{
+			name:         "claims generated server ID",
+			pod:          deploymentPod,
+			generatedIDs: []string{"AAAAAAAAAAE="},
+			wantServerID: "AAAAAAAAAAE=",
+			wantClaim:    buildClaim("0x0000000000000001", "", ""),
+		},
+		{
+			name:           "regenerates claimed server ID",
+			existingClaims: []*elbv2api.QUICServerIDClaim{buildClaim("0x0000000000000001", "", "")},
+			pod:            deploymentPod,
+			generatedIDs:   []string{"AAAAAAAAAAE=", "AAAAAAAAAAI="},
+			wantServerID:   "AAAAAAAAAAI=",
+			wantClaim:      buildClaim("0x0000000000000002", "", ""),
+			wantConflicts:  1,
+		},
+		{
+			name:         "claims generated server ID for new StatefulSet pod",
+			pod:          statefulSetPod,
+			generatedIDs: []string{"AAAAAAAAAAE="},
+			wantServerID: "AAAAAAAAAAE=",
+			wantClaim:    buildClaim("0x0000000000000001", "web-0", "web"),
+		},
+		{
+			name:           "reuses server ID of recreated StatefulSet pod",
+			existingClaims: []*elbv2api.QUICServerIDClaim{buildClaim("0x0000000000000003", "web-0", "web")},
+			pod:            statefulSetPod,
+			wantServerID:   "AAAAAAAAAAM=",
+			wantClaim:      buildClaim("0x0000000000000003", "web-0", "web"),
+		},
+		{
+			name: "gives up after too many conflicts",
+			existingClaims: []*elbv2api.QUICServerIDClaim{
+				buildClaim("0x0000000000000001", "", ""),
+			},
+			pod:           deploymentPod,
+			generatedIDs:  []string{"AAAAAAAAAAE=", "AAAAAAAAAAE=", "AAAAAAAAAAE=", "AAAAAAAAAAE=", "AAAAAAAAAAE="},
+			wantConflicts: 5,
+			wantErr:       "unable to allocate an unclaimed QUIC server ID after 5 attempts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithIndex(&elbv2api.QUICServerIDClaim{}, IndexKeyServerIDClaimPodName, IndexFuncServerIDClaimPodName).Build()
+			for _, claim := range tt.existingClaims {
+				assert.NoError(t, k8sClient.Create(ctx, claim.DeepCopy()))
+			}
+			metricsCollector := lbcmetrics.NewMockCollector()
+			r := newServerIDRegistry(k8sClient, &sequenceQuicServerIDGenerator{ids: tt.generatedIDs}, metricsCollector, logr.Discard())
+
+			got, err := r.allocate(ctx, "app", tt.pod, "server")
+			mockCollector := metricsCollector.(*lbcmetrics.MockCollector)
+			assert.Len(t, mockCollector.Invocations[lbcmetrics.MetricQuicServerIdAllocationConflicts], tt.wantConflicts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantServerID, got)
+
+			gotClaim := &elbv2api.QUICServerIDClaim{}
+			assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(tt.wantClaim), gotClaim))
+			assert.Equal(t, tt.wantClaim.Spec, gotClaim.Spec)
+		})
+	}
+}
+
+func Test_convertServerIDFormat(t *testing.T) {
+	elbServerID, err := convertServerIDToELBFormat("3q2+78r+ur4=")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xdeadbeefcafebabe", elbServerID)
+	assert.Equal(t, "quic-deadbeefcafebabe", ServerIDClaimName(elbServerID))
+
+	serverID, err := convertServerIDFromELBFormat(elbServerID)
+	assert.NoError(t, err)
+	assert.Equal(t, "3q2+78r+ur4=", serverID)
+}
@@ -0,0 +1,335 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	gatewayconstants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	gatewaymodel "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/model"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/routeutils"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	serviceAnnotationPrefix = "service.beta.kubernetes.io"
+
+	// tagValueBackendSG is the resource tag value of the backend security group managed by the controller.
+	tagValueBackendSG = "backend-sg"
+)
+
+// desiredStackBuilder rebuilds the desired stack of an object with the same model builders used by the controller.
+type desiredStackBuilder interface {
+	// build returns the desired stack, or an unsupportedKindError if the desired stack can't be rebuilt for this kind of object.
+	build(ctx context.Context, ref ObjectRef, objStack objectStack) (core.Stack, error)
+}
+
+// unsupportedKindError is returned when the desired stack isn't rebuilt for a kind of object.
+type unsupportedKindError struct {
+	kind ObjectKind
+}
+
+func (e *unsupportedKindError) Error() string {
+	return fmt.Sprintf("desired stack is not rebuilt for %s", e.kind)
+}
+
+// newDefaultDesiredStackBuilder constructs new defaultDesiredStackBuilder.
+func newDefaultDesiredStackBuilder(cloud services.Cloud, k8sClient client.Client, groupLoader ingress.GroupLoader,
+	serviceUtils service.ServiceUtils, svcGroupLoader service.GroupLoader, controllerConfig config.ControllerConfig, logger logr.Logger) *defaultDesiredStackBuilder {
+	// events emitted while building the model are discarded, inspection must not change the cluster.
+	eventRecorder := &record.FakeRecorder{}
+	metricsCollector := lbcmetrics.NewCollector(nil, nil, nil, logger)
+	backendSGProvider := &readOnlyBackendSGProvider{
+		ec2Client:   cloud.EC2(),
+		vpcID:       cloud.VpcID(),
+		clusterName: controllerConfig.ClusterName,
+		backendSG:   controllerConfig.BackendSecurityGroup,
+	}
+	azInfoProvider := networking.NewDefaultAZInfoProvider(cloud.EC2(), logger.WithName("az-info-provider"))
+	vpcInfoProvider := networking.NewDefaultVPCInfoProvider(cloud.EC2(), logger.WithName("vpc-info-provider"))
+	subnetsResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerConfig.ClusterName,
+		controllerConfig.FeatureGates.Enabled(config.SubnetsClusterTagCheck),
+		controllerConfig.FeatureGates.Enabled(config.ALBSingleSubnet),
+		controllerConfig.FeatureGates.Enabled(config.SubnetDiscoveryByReachability),
+		logger.WithName("subnets-resolver"))
+	sgResolver := networking.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID())
+	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), controllerConfig.FeatureGates, cloud.RGT(), logger)
+	tgARNMapper := shared_utils.NewTargetGroupNameToArnMapper(cloud.ELBV2())
+
+	ingAnnotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(ingAnnotationParser)
+	ingEnhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, ingAnnotationParser, authConfigBuilder, controllerConfig.IngressConfig.TolerateNonExistentBackendService, controllerConfig.IngressConfig.TolerateNonExistentBackendAction)
+	certDiscovery := certs.NewACMCertDiscovery(cloud.ACM(), controllerConfig.IngressConfig.AllowedCertificateAuthorityARNs, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.CertDiscoveryConfig.SelectionPolicy(), logger)
+	ingModelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
+		cloud.EC2(), cloud.ELBV2(), cloud.WAFv2(), cloud.ACM(),
+		ingAnnotationParser, subnetsResolver,
+		authConfigBuilder, ingEnhancedBackendBuilder, tracking.NewDefaultProvider(tagPrefixIngress, controllerConfig.ClusterName), elbv2TaggingManager, controllerConfig.FeatureGates,
+		cloud.VpcID(), controllerConfig.ClusterName, controllerConfig.DefaultTags, controllerConfig.ExternalManagedTags,
+		controllerConfig.DefaultSSLPolicy, controllerConfig.DefaultTargetType, controllerConfig.DefaultLoadBalancerScheme, backendSGProvider, sgResolver,
+		controllerConfig.EnableBackendSecurityGroup, controllerConfig.EnableManageBackendSecurityGroupRules, controllerConfig.DisableRestrictedSGRules, controllerConfig.IngressConfig.AllowedCertificateAuthorityARNs, controllerConfig.FeatureGates.Enabled(config.EnableIPTargetType), controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.IngressConfig.DefaultPCAArn, tgARNMapper, logger, metricsCollector, certDiscovery)
+
+	svcAnnotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+	svcEnhancedBackendBuilder := service.NewDefaultEnhancedBackendBuilder(k8sClient, svcAnnotationParser, logger)
+	svcModelBuilder := service.NewDefaultModelBuilder(svcAnnotationParser, subnetsResolver, vpcInfoProvider, cloud.VpcID(), tracking.NewDefaultProvider(tagPrefixService, controllerConfig.ClusterName),
+		elbv2TaggingManager, cloud.EC2(), controllerConfig.FeatureGates, controllerConfig.ClusterName, controllerConfig.DefaultTags, controllerConfig.ExternalManagedTags,
+		controllerConfig.DefaultSSLPolicy, controllerConfig.DefaultTargetType, controllerConfig.DefaultLoadBalancerScheme, controllerConfig.FeatureGates.Enabled(config.EnableIPTargetType), serviceUtils,
+		backendSGProvider, sgResolver, controllerConfig.EnableBackendSecurityGroup, controllerConfig.EnableManageBackendSecurityGroupRules, controllerConfig.DisableRestrictedSGRules, logger, metricsCollector, controllerConfig.FeatureGates.Enabled(config.EnableTCPUDPListenerType), svcEnhancedBackendBuilder,
+		service.NewDefaultClassParamsLoader(k8sClient), service.NewDefaultClassParamsMerger(serviceAnnotationPrefix, svcAnnotationParser),
+		certDiscovery, controllerConfig.FeatureGates.Enabled(config.EnableCertificateManagement), controllerConfig.IngressConfig.DefaultPCAArn)
+
+	// addons aren't compared for drift, and route statuses are never submitted.
+	buildGatewayModelBuilder := func(lbType elbv2model.LoadBalancerType, tagPrefix string) gatewaymodel.Builder {
+		return gatewaymodel.NewModelBuilder(subnetsResolver, vpcInfoProvider, cloud.VpcID(), lbType, tracking.NewDefaultProvider(tagPrefix, controllerConfig.ClusterName),
+			elbv2TaggingManager, controllerConfig, cloud.EC2(), cloud.ELBV2(), certDiscovery, k8sClient, controllerConfig.FeatureGates, controllerConfig.ClusterName, controllerConfig.DefaultTags,
+			sets.New(controllerConfig.ExternalManagedTags...), controllerConfig.DefaultSSLPolicy, controllerConfig.DefaultTargetType, controllerConfig.DefaultLoadBalancerScheme,
+			backendSGProvider, sgResolver, controllerConfig.EnableBackendSecurityGroup, controllerConfig.DisableRestrictedSGRules, nil, nil, logger)
+	}
+	gatewayBuilders := map[string]gatewayStackBuilder{
+		gatewayconstants.ALBGatewayTagPrefix: {
+			controllerName: gatewayconstants.ALBGatewayController,
+			routeFilter:    routeutils.L7RouteFilter,
+			modelBuilder:   buildGatewayModelBuilder(elbv2model.LoadBalancerTypeApplication, gatewayconstants.ALBGatewayTagPrefix),
+		},
+		gatewayconstants.NLBGatewayTagPrefix: {
+			controllerName: gatewayconstants.NLBGatewayController,
+			routeFilter:    routeutils.L4RouteFilter,
+			modelBuilder:   buildGatewayModelBuilder(elbv2model.LoadBalancerTypeNetwork, gatewayconstants.NLBGatewayTagPrefix),
+		},
+	}
+
+	agaModelBuilder := aga.NewDefaultModelBuilder(k8sClient, eventRecorder, tracking.NewDefaultProvider(tagPrefixGlobalAccelerator, controllerConfig.ClusterName, tracking.WithRegion(cloud.Region())),
+		controllerConfig.FeatureGates, controllerConfig.ClusterName, cloud.Region(), controllerConfig.DefaultTags, controllerConfig.ExternalManagedTags, logger, metricsCollector, cloud.ELBV2())
+	dnsToLoadBalancerResolver, err := aga.NewDNSToLoadBalancerResolver(cloud.ELBV2())
+	if err != nil {
+		logger.Error(err, "Failed to create DNS resolver")
+	}
+
+	return &defaultDesiredStackBuilder{
+		k8sClient:         k8sClient,
+		groupLoader:       groupLoader,
+		ingModelBuilder:   ingModelBuilder,
+		svcModelBuilder:   svcModelBuilder,
+		serviceUtils:      serviceUtils,
+		svcGroupLoader:    svcGroupLoader,
+		gwConfigLoader:    newGatewayConfigLoader(k8sClient),
+		gwRouteLoader:     routeutils.NewLoader(k8sClient, &discardRouteStatusSubmitter{}, controllerConfig.FeatureGates, logger.WithName("gateway-route-loader")),
+		gatewayBuilders:   gatewayBuilders,
+		agaEndpointLoader: aga.NewEndpointLoader(k8sClient, dnsToLoadBalancerResolver, logger.WithName("endpoint-loader")),
+		agaModelBuilder:   agaModelBuilder,
+		secretsManager:    &readOnlySecretsManager{},
+		tgARNMapper:       tgARNMapper,
+		metricsCollector:  metricsCollector,
+	}
+}
+
+var _ desiredStackBuilder = &defaultDesiredStackBuilder{}
+
+// defaultDesiredStackBuilder rebuilds desired stacks for IngressGroups, Services, Gateways and GlobalAccelerators.
+type defaultDesiredStackBuilder struct {
+	k8sClient         client.Client
+	groupLoader       ingress.GroupLoader
+	ingModelBuilder   ingress.ModelBuilder
+	svcModelBuilder   service.ModelBuilder
+	serviceUtils      service.ServiceUtils
+	svcGroupLoader    service.GroupLoader
+	gwConfigLoader    *gatewayConfigLoader
+	gwRouteLoader     routeutils.Loader
+	gatewayBuilders   map[string]gatewayStackBuilder
+	agaEndpointLoader aga.EndpointLoader
+	agaModelBuilder   aga.ModelBuilder
+	secretsManager    k8s.SecretsManager
+	tgARNMapper       shared_utils.TargetGroupARNMapper
+	metricsCollector  lbcmetrics.MetricCollector
+}
+
+// gatewayStackBuilder rebuilds the desired stack of Gateways of one gateway controller, keyed by its tag prefix.
+type gatewayStackBuilder struct {
+	controllerName string
+	routeFilter    routeutils.LoadRouteFilter
+	modelBuilder   gatewaymodel.Builder
+}
+
+func (b *defaultDesiredStackBuilder) build(ctx context.Context, ref ObjectRef, objStack objectStack) (core.Stack, error) {
+	switch objStack.kind {
+	case ObjectKindIngressGroup:
+		ingGroup, err := b.groupLoader.Load(ctx, ingress.GroupID(objStack.stackID))
+		if err != nil {
+			return nil, err
+		}
+		if len(ingGroup.Members) == 0 {
+			return nil, errors.Errorf("ingressGroup %v has no active members", ingGroup.ID)
+		}
+		stack, _, _, _, _, _, err := b.ingModelBuilder.Build(ctx, ingGroup, b.metricsCollector)
+		if err != nil {
+			return nil, err
+		}
+		return stack, nil
+	case ObjectKindService:
+		if objStack.stackID.Namespace == "" {
+			svcGroup, err := b.svcGroupLoader.Load(ctx, objStack.stackID.Name)
+			if err != nil {
+				return nil, err
+			}
+			if len(svcGroup.Members) == 0 {
+				return nil, errors.Errorf("service group %v has no active members", svcGroup.Name)
+			}
+			stack, _, _, err := b.svcModelBuilder.BuildGroup(ctx, svcGroup, b.metricsCollector)
+			if err != nil {
+				return nil, err
+			}
+			return stack, nil
+		}
+		svc := &corev1.Service{}
+		if err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, svc); err != nil {
+			return nil, err
+		}
+		if !b.serviceUtils.IsServiceSupported(svc) {
+			return nil, errors.Errorf("service %v is not supported by the controller", ref)
+		}
+		stack, _, _, err := b.svcModelBuilder.Build(ctx, svc, b.metricsCollector)
+		if err != nil {
+			return nil, err
+		}
+		return stack, nil
+	case ObjectKindGateway:
+		return b.buildGatewayStack(ctx, ref, objStack)
+	case ObjectKindGlobalAccelerator:
+		ga := &agaapi.GlobalAccelerator{}
+		if err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, ga); err != nil {
+			return nil, err
+		}
+		loadedEndpoints, fatalErrs := b.agaEndpointLoader.LoadEndpoints(ctx, ga, aga.GetAllDesiredEndpointsFromGA(ga))
+		if len(fatalErrs) != 0 {
+			return nil, errors.Wrap(fatalErrs[0], "failed to load endpoints")
+		}
+		stack, _, err := b.agaModelBuilder.Build(ctx, ga, loadedEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		return stack, nil
+	}
+	return nil, &unsupportedKindError{kind: objStack.kind}
+}
+
+// buildGatewayStack rebuilds the desired stack of a Gateway the same way as the gateway controller.
+func (b *defaultDesiredStackBuilder) buildGatewayStack(ctx context.Context, ref ObjectRef, objStack objectStack) (core.Stack, error) {
+	gwStackBuilder, exists := b.gatewayBuilders[objStack.tagPrefix]
+	if !exists {
+		return nil, &unsupportedKindError{kind: objStack.kind}
+	}
+	gw := &gwv1.Gateway{}
+	if err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, gw); err != nil {
+		return nil, err
+	}
+	gwClass := &gwv1.GatewayClass{}
+	if err := b.k8sClient.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gwClass); err != nil {
+		return nil, err
+	}
+	lbConfig, defaultTGC, err := b.gwConfigLoader.load(ctx, gw, gwClass)
+	if err != nil {
+		return nil, err
+	}
+	loaderResult, err := b.gwRouteLoader.LoadRoutesForGateway(ctx, *gw, gwStackBuilder.routeFilter, gwStackBuilder.controllerName, defaultTGC)
+	if err != nil {
+		return nil, err
+	}
+	isDelete := !gw.DeletionTimestamp.IsZero()
+	stack, _, _, _, _, err := gwStackBuilder.modelBuilder.Build(ctx, gw, lbConfig, loaderResult.Listeners, loaderResult.Routes,
+		nil, b.secretsManager, b.tgARNMapper, isDelete)
+	if err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+var _ routeutils.RouteReconcilerSubmitter = &discardRouteStatusSubmitter{}
+
+// discardRouteStatusSubmitter discards the route statuses computed while loading routes, inspection must not change the cluster.
+type discardRouteStatusSubmitter struct{}
+
+func (s *discardRouteStatusSubmitter) Enqueue(_ routeutils.RouteData) {}
+
+var _ k8s.SecretsManager = &readOnlySecretsManager{}
+
+// readOnlySecretsManager reads secrets from the API server without watching them.
+type readOnlySecretsManager struct{}
+
+func (m *readOnlySecretsManager) MonitorSecrets(_ string, _ []types.NamespacedName) {}
+
+func (m *readOnlySecretsManager) GetSecret(ctx context.Context, k8sClient client.Client, secretKey types.NamespacedName) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+var _ networking.BackendSGProvider = &readOnlyBackendSGProvider{}
+
+// readOnlyBackendSGProvider looks up the backend security group without creating or deleting it.
+type readOnlyBackendSGProvider struct {
+	ec2Client   services.EC2
+	vpcID       string
+	clusterName string
+	// backendSG is the backend security group configured via controller flags.
+	backendSG string
+}
+
+func (p *readOnlyBackendSGProvider) Get(ctx context.Context, _ networking.ResourceType, _ []types.NamespacedName) (string, error) {
+	if p.backendSG != "" {
+		return p.backendSG, nil
+	}
+	sgs, err := p.ec2Client.DescribeSecurityGroupsAsList(ctx, &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: []string{p.vpcID},
+			},
+			{
+				Name:   awssdk.String(fmt.Sprintf("tag:%v", shared_constants.TagKeyK8sCluster)),
+				Values: []string{p.clusterName},
+			},
+			{
+				Name:   awssdk.String(fmt.Sprintf("tag:%v", shared_constants.TagKeyResource)),
+				Values: []string{tagValueBackendSG},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(sgs) == 0 {
+		return "", errors.New("backend securityGroup not found")
+	}
+	return awssdk.ToString(sgs[0].GroupId), nil
+}
+
+func (p *readOnlyBackendSGProvider) Release(_ context.Context, _ networking.ResourceType, _ []types.NamespacedName) error {
+	return nil
+}
@@ -0,0 +1,276 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/addon"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aga"
+	gatewayconstants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/routeutils"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	agamodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// fakeRouteLoader returns the listeners of the Gateway without routes.
+type fakeRouteLoader struct {
+	gotDefaultTGC *elbv2gw.TargetGroupConfiguration
+}
+
+func (l *fakeRouteLoader) LoadRoutesForGateway(_ context.Context, gw gwv1.Gateway, _ routeutils.LoadRouteFilter, _ string, defaultTGConfig *elbv2gw.TargetGroupConfiguration) (*routeutils.LoaderResult, error) {
+	l.gotDefaultTGC = defaultTGConfig
+	return &routeutils.LoaderResult{Listeners: gw.Spec.Listeners}, nil
+}
+
+// fakeGatewayModelBuilder builds a stack with a single load balancer, using the scheme of the LoadBalancerConfiguration.
+type fakeGatewayModelBuilder struct {
+	gotListeners []gwv1.Listener
+	gotIsDelete  bool
+}
+
+func (b *fakeGatewayModelBuilder) Build(_ context.Context, gw *gwv1.Gateway, lbConf elbv2gw.LoadBalancerConfiguration, listeners []gwv1.Listener, _ map[int32][]routeutils.RouteDescriptor, _ []addon.Addon, _ k8s.SecretsManager, _ shared_utils.TargetGroupARNMapper, isDelete bool) (core.Stack, *elbv2model.LoadBalancer, []addon.AddonMetadata, bool, []types.NamespacedName, error) {
+	b.gotListeners = listeners
+	b.gotIsDelete = isDelete
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(gw)))
+	lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{Name: "k8s-" + gw.Name})
+	if lbConf.Spec.Scheme != nil {
+		lb.Spec.Scheme = elbv2model.LoadBalancerScheme(*lbConf.Spec.Scheme)
+	}
+	return stack, lb, nil, false, nil, nil
+}
+
+// fakeEndpointLoader loads every endpoint with the same ARN.
+type fakeEndpointLoader struct {
+	aga.EndpointLoader
+	fatalErr error
+}
+
+func (l *fakeEndpointLoader) LoadEndpoints(_ context.Context, _ *agaapi.GlobalAccelerator, endpoints []aga.EndpointReference) ([]*aga.LoadedEndpoint, []error) {
+	if l.fatalErr != nil {
+		return nil, []error{l.fatalErr}
+	}
+	var loadedEndpoints []*aga.LoadedEndpoint
+	for range endpoints {
+		loadedEndpoints = append(loadedEndpoints, &aga.LoadedEndpoint{ARN: "lb-arn", Status: aga.EndpointStatusLoaded})
+	}
+	return loadedEndpoints, nil
+}
+
+// fakeAGAModelBuilder builds a stack with a single accelerator, whose name counts the loaded endpoints.
+type fakeAGAModelBuilder struct{}
+
+func (b *fakeAGAModelBuilder) Build(_ context.Context, ga *agaapi.GlobalAccelerator, loadedEndpoints []*aga.LoadedEndpoint) (core.Stack, *agamodel.Accelerator, error) {
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(ga)))
+	accelerator := agamodel.NewAccelerator(stack, "GlobalAccelerator", agamodel.AcceleratorSpec{Name: fmt.Sprintf("%s-%d", ga.Name, len(loadedEndpoints))}, ga)
+	return stack, accelerator, nil
+}
+
+func Test_defaultDesiredStackBuilder_build(t *testing.T) {
+	internal := elbv2gw.LoadBalancerSchemeInternal
+	internetFacing := elbv2gw.LoadBalancerSchemeInternetFacing
+	buildGatewayClass := func(accepted metav1.ConditionStatus) *gwv1.GatewayClass {
+		return &gwv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+			Spec: gwv1.GatewayClassSpec{
+				ControllerName: gatewayconstants.ALBGatewayController,
+				ParametersRef: &gwv1.ParametersReference{
+					Group:     gwv1.Group(elbv2gw.GroupVersion.Group),
+					Kind:      "LoadBalancerConfiguration",
+					Name:      "class-config",
+					Namespace: (*gwv1.Namespace)(awssdk.String("infra")),
+				},
+			},
+			Status: gwv1.GatewayClassStatus{
+				Conditions: []metav1.Condition{
+					{Type: string(gwv1.GatewayClassConditionStatusAccepted), Status: accepted},
+				},
+			},
+		}
+	}
+	gateway := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw"},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: "alb",
+			Listeners:        []gwv1.Listener{{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType}},
+			Infrastructure: &gwv1.GatewayInfrastructure{
+				ParametersRef: &gwv1.LocalParametersReference{
+					Group: gwv1.Group(elbv2gw.GroupVersion.Group),
+					Kind:  "LoadBalancerConfiguration",
+					Name:  "gw-config",
+				},
+			},
+		},
+	}
+	classConfig := &elbv2gw.LoadBalancerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "class-config"},
+		Spec: elbv2gw.LoadBalancerConfigurationSpec{
+			Scheme:                          &internal,
+			DefaultTargetGroupConfiguration: &elbv2gw.DefaultTargetGroupConfigurationReference{Name: "default-tgc"},
+		},
+	}
+	gwConfig := &elbv2gw.LoadBalancerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw-config"},
+		Spec:       elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacing},
+	}
+	defaultTGC := &elbv2gw.TargetGroupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "default-tgc"},
+	}
+	ga := &agaapi.GlobalAccelerator{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ga"},
+		Spec: agaapi.GlobalAcceleratorSpec{
+			Listeners: &[]agaapi.GlobalAcceleratorListener{
+				{
+					EndpointGroups: &[]agaapi.GlobalAcceleratorEndpointGroup{
+						{
+							Endpoints: &[]agaapi.GlobalAcceleratorEndpoint{
+								{Type: agaapi.GlobalAcceleratorEndpointTypeService, Name: awssdk.String("svc")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		ref           ObjectRef
+		objStack      objectStack
+		endpointErr   error
+		wantLBScheme  elbv2model.LoadBalancerScheme
+		wantAccName   string
+		wantErr       string
+		wantUnsupport bool
+	}{
+		{
+			name:         "gateway with merged load balancer configuration",
+			objects:      []client.Object{buildGatewayClass(metav1.ConditionTrue), gateway, classConfig, gwConfig, defaultTGC},
+			ref:          ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			objStack:     objectStack{kind: ObjectKindGateway, tagPrefix: gatewayconstants.ALBGatewayTagPrefix, stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			wantLBScheme: elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name:     "gateway with gatewayClass not accepted",
+			objects:  []client.Object{buildGatewayClass(metav1.ConditionFalse), gateway, classConfig, gwConfig, defaultTGC},
+			ref:      ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			objStack: objectStack{kind: ObjectKindGateway, tagPrefix: gatewayconstants.ALBGatewayTagPrefix, stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			wantErr:  "gatewayClass alb is not accepted",
+		},
+		{
+			name:     "gateway with missing default targetGroupConfiguration",
+			objects:  []client.Object{buildGatewayClass(metav1.ConditionTrue), gateway, classConfig, gwConfig},
+			ref:      ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			objStack: objectStack{kind: ObjectKindGateway, tagPrefix: gatewayconstants.ALBGatewayTagPrefix, stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			wantErr:  `failed to get default targetGroupConfiguration infra/default-tgc of loadBalancerConfiguration class-config: targetgroupconfigurations.gateway.k8s.aws "default-tgc" not found`,
+		},
+		{
+			name:          "gateway of unknown gateway controller",
+			objects:       []client.Object{buildGatewayClass(metav1.ConditionTrue), gateway},
+			ref:           ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			objStack:      objectStack{kind: ObjectKindGateway, tagPrefix: "unknown.k8s.aws", stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+			wantErr:       "desired stack is not rebuilt for gateway",
+			wantUnsupport: true,
+		},
+		{
+			name:        "globalAccelerator",
+			objects:     []client.Object{ga},
+			ref:         ObjectRef{Kind: ObjectKindGlobalAccelerator, Namespace: "ns", Name: "ga"},
+			objStack:    objectStack{kind: ObjectKindGlobalAccelerator, tagPrefix: tagPrefixGlobalAccelerator, stackID: core.StackID{Namespace: "ns", Name: "ga"}},
+			wantAccName: "ga-1",
+		},
+		{
+			name:        "globalAccelerator with endpoints failed to load",
+			objects:     []client.Object{ga},
+			ref:         ObjectRef{Kind: ObjectKindGlobalAccelerator, Namespace: "ns", Name: "ga"},
+			objStack:    objectStack{kind: ObjectKindGlobalAccelerator, tagPrefix: tagPrefixGlobalAccelerator, stackID: core.StackID{Namespace: "ns", Name: "ga"}},
+			endpointErr: errors.New("service ns/svc not found"),
+			wantErr:     "failed to load endpoints: service ns/svc not found",
+		},
+		{
+			name:          "unsupported kind",
+			ref:           ObjectRef{Kind: ObjectKindIngress, Namespace: "ns", Name: "ing"},
+			objStack:      objectStack{kind: ObjectKindIngress, stackID: core.StackID{Namespace: "ns", Name: "ing"}},
+			wantErr:       "desired stack is not rebuilt for ingress",
+			wantUnsupport: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			agaapi.AddToScheme(k8sSchema)
+			elbv2gw.AddToScheme(k8sSchema)
+			gwv1.Install(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(tt.objects...).Build()
+			routeLoader := &fakeRouteLoader{}
+			gwModelBuilder := &fakeGatewayModelBuilder{}
+			b := &defaultDesiredStackBuilder{
+				k8sClient:      k8sClient,
+				gwConfigLoader: newGatewayConfigLoader(k8sClient),
+				gwRouteLoader:  routeLoader,
+				gatewayBuilders: map[string]gatewayStackBuilder{
+					gatewayconstants.ALBGatewayTagPrefix: {
+						controllerName: gatewayconstants.ALBGatewayController,
+						routeFilter:    routeutils.L7RouteFilter,
+						modelBuilder:   gwModelBuilder,
+					},
+				},
+				agaEndpointLoader: &fakeEndpointLoader{fatalErr: tt.endpointErr},
+				agaModelBuilder:   &fakeAGAModelBuilder{},
+				secretsManager:    &readOnlySecretsManager{},
+			}
+
+			stack, err := b.build(ctx, tt.ref, tt.objStack)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				var unsupportedKindErr *unsupportedKindError
+				assert.Equal(t, tt.wantUnsupport, errors.As(err, &unsupportedKindErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.objStack.stackID, stack.StackID())
+
+			if tt.wantLBScheme != "" {
+				var resLBs []*elbv2model.LoadBalancer
+				assert.NoError(t, stack.ListResources(&resLBs))
+				assert.Len(t, resLBs, 1)
+				assert.Equal(t, tt.wantLBScheme, resLBs[0].Spec.Scheme)
+				assert.Equal(t, gateway.Spec.Listeners, gwModelBuilder.gotListeners)
+				assert.False(t, gwModelBuilder.gotIsDelete)
+				assert.Equal(t, "default-tgc", routeLoader.gotDefaultTGC.Name)
+
+				// inspection must not add finalizers to the configurations.
+				for _, lbConfig := range []*elbv2gw.LoadBalancerConfiguration{classConfig, gwConfig} {
+					gotLBConfig := &elbv2gw.LoadBalancerConfiguration{}
+					assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(lbConfig), gotLBConfig))
+					assert.False(t, k8s.HasFinalizer(gotLBConfig, shared_constants.LoadBalancerConfigurationFinalizer))
+				}
+			}
+			if tt.wantAccName != "" {
+				var resAccelerators []*agamodel.Accelerator
+				assert.NoError(t, stack.ListResources(&resAccelerators))
+				assert.Len(t, resAccelerators, 1)
+				assert.Equal(t, tt.wantAccName, resAccelerators[0].Spec.Name)
+			}
+		})
+	}
+}
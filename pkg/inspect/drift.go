@@ -0,0 +1,418 @@
+package inspect
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	agamodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+const (
+	// driftFieldPresence is the drift field for live resources that are not in the desired stack.
+	driftFieldPresence = "presence"
+
+	driftValueAbsent  = "<absent>"
+	driftValuePresent = "<present>"
+)
+
+// detectDrift compares the live resources in report against the desired stack,
+// it records drifts on the live nodes and desired resources that don't exist in AWS.
+func detectDrift(desiredStack core.Stack, report *Report) error {
+	var resLBs []*elbv2model.LoadBalancer
+	if err := desiredStack.ListResources(&resLBs); err != nil {
+		return err
+	}
+	var resListeners []*elbv2model.Listener
+	if err := desiredStack.ListResources(&resListeners); err != nil {
+		return err
+	}
+	var resRules []*elbv2model.ListenerRule
+	if err := desiredStack.ListResources(&resRules); err != nil {
+		return err
+	}
+	var resTGs []*elbv2model.TargetGroup
+	if err := desiredStack.ListResources(&resTGs); err != nil {
+		return err
+	}
+
+	resLBByID := make(map[string]*elbv2model.LoadBalancer, len(resLBs))
+	for _, resLB := range resLBs {
+		resLBByID[resLB.ID()] = resLB
+	}
+	resListenersByLBID := make(map[string][]*elbv2model.Listener)
+	for _, resListener := range resListeners {
+		lbID := dependencyID(resListener.Spec.LoadBalancerARN)
+		resListenersByLBID[lbID] = append(resListenersByLBID[lbID], resListener)
+	}
+	resRulesByListenerID := make(map[string][]*elbv2model.ListenerRule)
+	for _, resRule := range resRules {
+		listenerID := dependencyID(resRule.Spec.ListenerARN)
+		resRulesByListenerID[listenerID] = append(resRulesByListenerID[listenerID], resRule)
+	}
+
+	liveLBIDs := make(map[string]bool)
+	for i := range report.LoadBalancers {
+		lbNode := &report.LoadBalancers[i]
+		liveLBIDs[lbNode.ResourceID] = true
+		resLB, exists := resLBByID[lbNode.ResourceID]
+		if !exists {
+			lbNode.Drifts = append(lbNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		lbNode.Drifts = append(lbNode.Drifts, compareField("name", resLB.Spec.Name, lbNode.Name)...)
+		lbNode.Drifts = append(lbNode.Drifts, compareField("type", string(resLB.Spec.Type), lbNode.Type)...)
+		if resLB.Spec.Scheme != "" {
+			lbNode.Drifts = append(lbNode.Drifts, compareField("scheme", string(resLB.Spec.Scheme), lbNode.Scheme)...)
+		}
+		detectListenersDrift(resListenersByLBID[resLB.ID()], resRulesByListenerID, lbNode, report)
+	}
+	for _, resLB := range resLBs {
+		if !liveLBIDs[resLB.ID()] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resLB.Type(),
+				ID:          resLB.ID(),
+				Description: fmt.Sprintf("%s load balancer %s", resLB.Spec.Type, resLB.Spec.Name),
+			})
+		}
+	}
+
+	detectTargetGroupsDrift(resTGs, report)
+	if err := detectAcceleratorDrift(desiredStack, report); err != nil {
+		return err
+	}
+
+	report.DriftDetected = len(report.MissingResources) != 0
+	visitTargetGroupNodes(report, func(tgNode *TargetGroupNode) {
+		if len(tgNode.Drifts) != 0 {
+			report.DriftDetected = true
+		}
+	})
+	for _, lbNode := range report.LoadBalancers {
+		if len(lbNode.Drifts) != 0 {
+			report.DriftDetected = true
+		}
+		for _, listenerNode := range lbNode.Listeners {
+			if len(listenerNode.Drifts) != 0 {
+				report.DriftDetected = true
+			}
+			for _, ruleNode := range listenerNode.Rules {
+				if len(ruleNode.Drifts) != 0 {
+					report.DriftDetected = true
+				}
+			}
+		}
+	}
+	if report.Accelerator != nil {
+		if len(report.Accelerator.Drifts) != 0 {
+			report.DriftDetected = true
+		}
+		for _, listenerNode := range report.Accelerator.Listeners {
+			if len(listenerNode.Drifts) != 0 {
+				report.DriftDetected = true
+			}
+			for _, endpointGroupNode := range listenerNode.EndpointGroups {
+				if len(endpointGroupNode.Drifts) != 0 {
+					report.DriftDetected = true
+				}
+				for _, endpointNode := range endpointGroupNode.Endpoints {
+					if len(endpointNode.Drifts) != 0 {
+						report.DriftDetected = true
+					}
+				}
+			}
+		}
+	}
+	report.DriftStatus = DriftStatusInSync
+	if report.DriftDetected {
+		report.DriftStatus = DriftStatusDrifted
+	}
+	return nil
+}
+
+// detectListenersDrift compares the live listeners of a load balancer against the desired ones, matched by port.
+func detectListenersDrift(resListeners []*elbv2model.Listener, resRulesByListenerID map[string][]*elbv2model.ListenerRule, lbNode *LoadBalancerNode, report *Report) {
+	resListenerByPort := make(map[int32]*elbv2model.Listener, len(resListeners))
+	for _, resListener := range resListeners {
+		resListenerByPort[resListener.Spec.Port] = resListener
+	}
+	livePorts := make(map[int32]bool)
+	for i := range lbNode.Listeners {
+		listenerNode := &lbNode.Listeners[i]
+		livePorts[listenerNode.Port] = true
+		resListener, exists := resListenerByPort[listenerNode.Port]
+		if !exists {
+			listenerNode.Drifts = append(listenerNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		listenerNode.Drifts = append(listenerNode.Drifts, compareField("protocol", string(resListener.Spec.Protocol), listenerNode.Protocol)...)
+		detectRulesDrift(resRulesByListenerID[resListener.ID()], listenerNode, report)
+	}
+	for _, resListener := range resListeners {
+		if !livePorts[resListener.Spec.Port] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resListener.Type(),
+				ID:          resListener.ID(),
+				Description: fmt.Sprintf("%s:%d listener", resListener.Spec.Protocol, resListener.Spec.Port),
+			})
+		}
+	}
+}
+
+// detectRulesDrift compares the live rules of a listener against the desired ones, matched by priority.
+func detectRulesDrift(resRules []*elbv2model.ListenerRule, listenerNode *ListenerNode, report *Report) {
+	resRuleByPriority := make(map[string]*elbv2model.ListenerRule, len(resRules))
+	for _, resRule := range resRules {
+		resRuleByPriority[strconv.Itoa(int(resRule.Spec.Priority))] = resRule
+	}
+	livePriorities := make(map[string]bool)
+	for i := range listenerNode.Rules {
+		ruleNode := &listenerNode.Rules[i]
+		livePriorities[ruleNode.Priority] = true
+		resRule, exists := resRuleByPriority[ruleNode.Priority]
+		if !exists {
+			ruleNode.Drifts = append(ruleNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		ruleNode.Drifts = append(ruleNode.Drifts, compareField("conditions", strconv.Itoa(len(resRule.Spec.Conditions)), strconv.Itoa(len(ruleNode.Conditions)))...)
+		ruleNode.Drifts = append(ruleNode.Drifts, compareField("actions", strconv.Itoa(len(resRule.Spec.Actions)), strconv.Itoa(len(ruleNode.Actions)))...)
+	}
+	for _, resRule := range resRules {
+		priority := strconv.Itoa(int(resRule.Spec.Priority))
+		if !livePriorities[priority] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resRule.Type(),
+				ID:          resRule.ID(),
+				Description: fmt.Sprintf("rule with priority %s on %s:%d listener", priority, listenerNode.Protocol, listenerNode.Port),
+			})
+		}
+	}
+}
+
+// detectTargetGroupsDrift compares the live target groups against the desired ones, matched by resource ID.
+func detectTargetGroupsDrift(resTGs []*elbv2model.TargetGroup, report *Report) {
+	resTGByID := make(map[string]*elbv2model.TargetGroup, len(resTGs))
+	for _, resTG := range resTGs {
+		resTGByID[resTG.ID()] = resTG
+	}
+	liveTGIDs := make(map[string]bool)
+	visitTargetGroupNodes(report, func(tgNode *TargetGroupNode) {
+		// target groups outside the stack have no resource ID, they are not managed by the stack.
+		if tgNode.ResourceID == "" {
+			return
+		}
+		liveTGIDs[tgNode.ResourceID] = true
+		resTG, exists := resTGByID[tgNode.ResourceID]
+		if !exists {
+			tgNode.Drifts = []Drift{{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent}}
+			return
+		}
+		var drifts []Drift
+		drifts = append(drifts, compareField("name", resTG.Spec.Name, tgNode.Name)...)
+		drifts = append(drifts, compareField("targetType", string(resTG.Spec.TargetType), tgNode.TargetType)...)
+		drifts = append(drifts, compareField("protocol", string(resTG.Spec.Protocol), tgNode.Protocol)...)
+		if resTG.Spec.Port != nil {
+			drifts = append(drifts, compareField("port", strconv.Itoa(int(awssdk.ToInt32(resTG.Spec.Port))), strconv.Itoa(int(tgNode.Port)))...)
+		}
+		tgNode.Drifts = drifts
+	})
+	for _, resTG := range resTGs {
+		if !liveTGIDs[resTG.ID()] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resTG.Type(),
+				ID:          resTG.ID(),
+				Description: fmt.Sprintf("%s target group %s", resTG.Spec.TargetType, resTG.Spec.Name),
+			})
+		}
+	}
+}
+
+// detectAcceleratorDrift compares the live accelerator in report against the desired one.
+func detectAcceleratorDrift(desiredStack core.Stack, report *Report) error {
+	var resAccelerators []*agamodel.Accelerator
+	if err := desiredStack.ListResources(&resAccelerators); err != nil {
+		return err
+	}
+	var resListeners []*agamodel.Listener
+	if err := desiredStack.ListResources(&resListeners); err != nil {
+		return err
+	}
+	var resEndpointGroups []*agamodel.EndpointGroup
+	if err := desiredStack.ListResources(&resEndpointGroups); err != nil {
+		return err
+	}
+
+	resEndpointGroupsByListenerID := make(map[string][]*agamodel.EndpointGroup)
+	for _, resEndpointGroup := range resEndpointGroups {
+		listenerID := dependencyID(resEndpointGroup.Spec.ListenerARN)
+		resEndpointGroupsByListenerID[listenerID] = append(resEndpointGroupsByListenerID[listenerID], resEndpointGroup)
+	}
+
+	acceleratorNode := report.Accelerator
+	if acceleratorNode == nil {
+		for _, resAccelerator := range resAccelerators {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resAccelerator.Type(),
+				ID:          resAccelerator.ID(),
+				Description: fmt.Sprintf("accelerator %s", resAccelerator.Spec.Name),
+			})
+		}
+		return nil
+	}
+	if len(resAccelerators) == 0 {
+		acceleratorNode.Drifts = append(acceleratorNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+		return nil
+	}
+	resAccelerator := resAccelerators[0]
+	acceleratorNode.Drifts = append(acceleratorNode.Drifts, compareField("name", resAccelerator.Spec.Name, acceleratorNode.Name)...)
+	if resAccelerator.Spec.Enabled != nil {
+		acceleratorNode.Drifts = append(acceleratorNode.Drifts, compareField("enabled", strconv.FormatBool(*resAccelerator.Spec.Enabled), strconv.FormatBool(acceleratorNode.Enabled))...)
+	}
+
+	resListenerKeys := make([]string, 0, len(resListeners))
+	resListenerByKey := make(map[string]*agamodel.Listener, len(resListeners))
+	for _, resListener := range resListeners {
+		var portRanges []string
+		for _, portRange := range resListener.Spec.PortRanges {
+			portRanges = append(portRanges, formatPortRange(portRange.FromPort, portRange.ToPort))
+		}
+		key := acceleratorListenerKey(string(resListener.Spec.Protocol), portRanges)
+		resListenerKeys = append(resListenerKeys, key)
+		resListenerByKey[key] = resListener
+	}
+	liveListenerKeys := make(map[string]bool)
+	for i := range acceleratorNode.Listeners {
+		listenerNode := &acceleratorNode.Listeners[i]
+		key := acceleratorListenerKey(listenerNode.Protocol, listenerNode.PortRanges)
+		liveListenerKeys[key] = true
+		resListener, exists := resListenerByKey[key]
+		if !exists {
+			listenerNode.Drifts = append(listenerNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		detectEndpointGroupsDrift(resEndpointGroupsByListenerID[resListener.ID()], listenerNode, report)
+	}
+	for i, resListener := range resListeners {
+		key := resListenerKeys[i]
+		if !liveListenerKeys[key] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resListener.Type(),
+				ID:          resListener.ID(),
+				Description: fmt.Sprintf("%s listener", key),
+			})
+		}
+	}
+	return nil
+}
+
+// detectEndpointGroupsDrift compares the live endpoint groups of an accelerator listener against the desired ones, matched by region.
+func detectEndpointGroupsDrift(resEndpointGroups []*agamodel.EndpointGroup, listenerNode *AcceleratorListenerNode, report *Report) {
+	resEndpointGroupByRegion := make(map[string]*agamodel.EndpointGroup, len(resEndpointGroups))
+	for _, resEndpointGroup := range resEndpointGroups {
+		resEndpointGroupByRegion[resEndpointGroup.Spec.Region] = resEndpointGroup
+	}
+	liveRegions := make(map[string]bool)
+	for i := range listenerNode.EndpointGroups {
+		endpointGroupNode := &listenerNode.EndpointGroups[i]
+		liveRegions[endpointGroupNode.Region] = true
+		resEndpointGroup, exists := resEndpointGroupByRegion[endpointGroupNode.Region]
+		if !exists {
+			endpointGroupNode.Drifts = append(endpointGroupNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		if resEndpointGroup.Spec.TrafficDialPercentage != nil {
+			endpointGroupNode.Drifts = append(endpointGroupNode.Drifts, compareField("trafficDialPercentage",
+				strconv.Itoa(int(*resEndpointGroup.Spec.TrafficDialPercentage)), fmt.Sprintf("%g", endpointGroupNode.TrafficDialPercentage))...)
+		}
+		detectEndpointsDrift(resEndpointGroup.Spec.EndpointConfigurations, endpointGroupNode)
+	}
+	for _, resEndpointGroup := range resEndpointGroups {
+		if !liveRegions[resEndpointGroup.Spec.Region] {
+			report.MissingResources = append(report.MissingResources, MissingResource{
+				Type:        resEndpointGroup.Type(),
+				ID:          resEndpointGroup.ID(),
+				Description: fmt.Sprintf("%s endpoint group on %s listener", resEndpointGroup.Spec.Region, acceleratorListenerKey(listenerNode.Protocol, listenerNode.PortRanges)),
+			})
+		}
+	}
+}
+
+// detectEndpointsDrift compares the live endpoints of an endpoint group against the desired ones, matched by endpoint ID.
+// endpoints aren't resources of the stack, so desired endpoints that don't exist are reported as drifts of the endpoint group.
+func detectEndpointsDrift(resEndpoints []agamodel.EndpointConfiguration, endpointGroupNode *EndpointGroupNode) {
+	resEndpointByID := make(map[string]agamodel.EndpointConfiguration, len(resEndpoints))
+	for _, resEndpoint := range resEndpoints {
+		resEndpointByID[resEndpoint.EndpointID] = resEndpoint
+	}
+	liveEndpointIDs := make(map[string]bool)
+	for i := range endpointGroupNode.Endpoints {
+		endpointNode := &endpointGroupNode.Endpoints[i]
+		liveEndpointIDs[endpointNode.ID] = true
+		resEndpoint, exists := resEndpointByID[endpointNode.ID]
+		if !exists {
+			endpointNode.Drifts = append(endpointNode.Drifts, Drift{Field: driftFieldPresence, Desired: driftValueAbsent, Live: driftValuePresent})
+			continue
+		}
+		if resEndpoint.Weight != nil {
+			endpointNode.Drifts = append(endpointNode.Drifts, compareField("weight", strconv.Itoa(int(*resEndpoint.Weight)), strconv.Itoa(int(endpointNode.Weight)))...)
+		}
+	}
+	for _, resEndpoint := range resEndpoints {
+		if !liveEndpointIDs[resEndpoint.EndpointID] {
+			endpointGroupNode.Drifts = append(endpointGroupNode.Drifts, Drift{Field: "endpoint " + resEndpoint.EndpointID, Desired: driftValuePresent, Live: driftValueAbsent})
+		}
+	}
+}
+
+// acceleratorListenerKey identifies an accelerator listener by protocol and port ranges, such as TCP:80,443.
+func acceleratorListenerKey(protocol string, portRanges []string) string {
+	sortedPortRanges := append([]string(nil), portRanges...)
+	sort.Strings(sortedPortRanges)
+	return fmt.Sprintf("%s:%s", protocol, strings.Join(sortedPortRanges, ","))
+}
+
+// visitTargetGroupNodes visits every target group node in report, a target group can appear multiple times.
+func visitTargetGroupNodes(report *Report, visitor func(tgNode *TargetGroupNode)) {
+	for i := range report.LoadBalancers {
+		lbNode := &report.LoadBalancers[i]
+		for j := range lbNode.Listeners {
+			listenerNode := &lbNode.Listeners[j]
+			for k := range listenerNode.DefaultTargetGroups {
+				visitor(&listenerNode.DefaultTargetGroups[k])
+			}
+			for k := range listenerNode.Rules {
+				ruleNode := &listenerNode.Rules[k]
+				for l := range ruleNode.TargetGroups {
+					visitor(&ruleNode.TargetGroups[l])
+				}
+			}
+		}
+	}
+	for i := range report.UnreferencedTargetGroups {
+		visitor(&report.UnreferencedTargetGroups[i])
+	}
+}
+
+// compareField returns a drift if the desired value differs from the live value.
+func compareField(field string, desired string, live string) []Drift {
+	if desired == live {
+		return nil
+	}
+	return []Drift{{Field: field, Desired: desired, Live: live}}
+}
+
+// dependencyID returns the ID of the resource a token refers to, or empty if it refers to no resource in the stack.
+func dependencyID(token core.StringToken) string {
+	if token == nil {
+		return ""
+	}
+	deps := token.Dependencies()
+	if len(deps) == 0 {
+		return ""
+	}
+	return deps[0].ID()
+}
@@ -0,0 +1,374 @@
+package inspect
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	agamodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/aga"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func Test_detectDrift(t *testing.T) {
+	buildDesiredStack := func() core.Stack {
+		stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+		lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+			Name:   "k8s-awesomegroup",
+			Type:   elbv2model.LoadBalancerTypeApplication,
+			Scheme: elbv2model.LoadBalancerSchemeInternetFacing,
+		})
+		ls80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{
+			LoadBalancerARN: lb.LoadBalancerARN(),
+			Port:            80,
+			Protocol:        elbv2model.ProtocolHTTP,
+		})
+		elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+			LoadBalancerARN: lb.LoadBalancerARN(),
+			Port:            443,
+			Protocol:        elbv2model.ProtocolHTTPS,
+		})
+		tg := elbv2model.NewTargetGroup(stack, "ns/ing-svc:80", elbv2model.TargetGroupSpec{
+			Name:       "k8s-ns-svc-abc",
+			TargetType: elbv2model.TargetTypeIP,
+			Port:       awssdk.Int32(8080),
+			Protocol:   elbv2model.ProtocolHTTP,
+		})
+		elbv2model.NewListenerRule(stack, "80:1", elbv2model.ListenerRuleSpec{
+			ListenerARN: ls80.ListenerARN(),
+			Priority:    1,
+			Actions: []elbv2model.Action{
+				{
+					Type: elbv2model.ActionTypeForward,
+					ForwardConfig: &elbv2model.ForwardActionConfig{
+						TargetGroups: []elbv2model.TargetGroupTuple{{TargetGroupARN: tg.TargetGroupARN()}},
+					},
+				},
+			},
+			Conditions: []elbv2model.RuleCondition{
+				{
+					Field:             elbv2model.RuleConditionFieldPathPattern,
+					PathPatternConfig: &elbv2model.PathPatternConditionConfig{Values: []string{"/api"}},
+				},
+			},
+		})
+		return stack
+	}
+	liveTG := func(port int32) TargetGroupNode {
+		return TargetGroupNode{ResourceID: "ns/ing-svc:80", ARN: "tg-arn", Name: "k8s-ns-svc-abc", TargetType: "ip", Protocol: "HTTP", Port: port}
+	}
+
+	tests := []struct {
+		name   string
+		report Report
+		want   Report
+	}{
+		{
+			name:   "nothing exists in AWS",
+			report: Report{},
+			want: Report{
+				MissingResources: []MissingResource{
+					{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", ID: "LoadBalancer", Description: "application load balancer k8s-awesomegroup"},
+					{Type: "AWS::ElasticLoadBalancingV2::TargetGroup", ID: "ns/ing-svc:80", Description: "ip target group k8s-ns-svc-abc"},
+				},
+				DriftDetected: true,
+				DriftStatus:   DriftStatusDrifted,
+			},
+		},
+		{
+			name: "live resources drift from desired stack",
+			report: Report{
+				LoadBalancers: []LoadBalancerNode{
+					{
+						ResourceID: "LoadBalancer",
+						Name:       "k8s-awesomegroup",
+						Type:       "application",
+						Scheme:     "internal",
+						Listeners: []ListenerNode{
+							{
+								Port:     80,
+								Protocol: "HTTP",
+								Rules: []RuleNode{
+									{Priority: "1", Conditions: []string{"path-pattern=/api"}, Actions: []string{"forward"}, TargetGroups: []TargetGroupNode{liveTG(9090)}},
+									{Priority: "2", Conditions: []string{"path-pattern=/legacy"}, Actions: []string{"forward"}},
+								},
+							},
+							{Port: 8080, Protocol: "HTTP"},
+						},
+					},
+				},
+			},
+			want: Report{
+				LoadBalancers: []LoadBalancerNode{
+					{
+						ResourceID: "LoadBalancer",
+						Name:       "k8s-awesomegroup",
+						Type:       "application",
+						Scheme:     "internal",
+						Drifts:     []Drift{{Field: "scheme", Desired: "internet-facing", Live: "internal"}},
+						Listeners: []ListenerNode{
+							{
+								Port:     80,
+								Protocol: "HTTP",
+								Rules: []RuleNode{
+									{
+										Priority:   "1",
+										Conditions: []string{"path-pattern=/api"},
+										Actions:    []string{"forward"},
+										TargetGroups: []TargetGroupNode{
+											func() TargetGroupNode {
+												tgNode := liveTG(9090)
+												tgNode.Drifts = []Drift{{Field: "port", Desired: "8080", Live: "9090"}}
+												return tgNode
+											}(),
+										},
+									},
+									{
+										Priority:   "2",
+										Conditions: []string{"path-pattern=/legacy"},
+										Actions:    []string{"forward"},
+										Drifts:     []Drift{{Field: "presence", Desired: "<absent>", Live: "<present>"}},
+									},
+								},
+							},
+							{
+								Port:     8080,
+								Protocol: "HTTP",
+								Drifts:   []Drift{{Field: "presence", Desired: "<absent>", Live: "<present>"}},
+							},
+						},
+					},
+				},
+				MissingResources: []MissingResource{
+					{Type: "AWS::ElasticLoadBalancingV2::Listener", ID: "443", Description: "HTTPS:443 listener"},
+				},
+				DriftDetected: true,
+				DriftStatus:   DriftStatusDrifted,
+			},
+		},
+		{
+			name: "live resources match desired stack",
+			report: Report{
+				LoadBalancers: []LoadBalancerNode{
+					{
+						ResourceID: "LoadBalancer",
+						Name:       "k8s-awesomegroup",
+						Type:       "application",
+						Scheme:     "internet-facing",
+						Listeners: []ListenerNode{
+							{
+								Port:     80,
+								Protocol: "HTTP",
+								Rules: []RuleNode{
+									{Priority: "1", Conditions: []string{"path-pattern=/api"}, Actions: []string{"forward"}, TargetGroups: []TargetGroupNode{liveTG(8080)}},
+								},
+							},
+							{Port: 443, Protocol: "HTTPS"},
+						},
+					},
+				},
+			},
+			want: Report{
+				LoadBalancers: []LoadBalancerNode{
+					{
+						ResourceID: "LoadBalancer",
+						Name:       "k8s-awesomegroup",
+						Type:       "application",
+						Scheme:     "internet-facing",
+						Listeners: []ListenerNode{
+							{
+								Port:     80,
+								Protocol: "HTTP",
+								Rules: []RuleNode{
+									{Priority: "1", Conditions: []string{"path-pattern=/api"}, Actions: []string{"forward"}, TargetGroups: []TargetGroupNode{liveTG(8080)}},
+								},
+							},
+							{Port: 443, Protocol: "HTTPS"},
+						},
+					},
+				},
+				DriftStatus: DriftStatusInSync,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := tt.report
+			err := detectDrift(buildDesiredStack(), &report)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, report)
+		})
+	}
+}
+
+func Test_detectDrift_accelerator(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/k8s-ns-svc/abc"
+	buildDesiredStack := func() core.Stack {
+		stack := core.NewDefaultStack(core.StackID{Namespace: "ns", Name: "ga"})
+		accelerator := agamodel.NewAccelerator(stack, "GlobalAccelerator", agamodel.AcceleratorSpec{
+			Name:    "k8s-ns-ga",
+			Enabled: awssdk.Bool(true),
+		}, &agaapi.GlobalAccelerator{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ga"}})
+		tcpListener := agamodel.NewListener(stack, "Listener-0", agamodel.ListenerSpec{
+			AcceleratorARN: accelerator.AcceleratorARN(),
+			Protocol:       agamodel.ProtocolTCP,
+			PortRanges:     []agamodel.PortRange{{FromPort: 443, ToPort: 443}, {FromPort: 80, ToPort: 80}},
+		}, accelerator)
+		agamodel.NewListener(stack, "Listener-1", agamodel.ListenerSpec{
+			AcceleratorARN: accelerator.AcceleratorARN(),
+			Protocol:       agamodel.ProtocolUDP,
+			PortRanges:     []agamodel.PortRange{{FromPort: 5000, ToPort: 5010}},
+		}, accelerator)
+		agamodel.NewEndpointGroup(stack, "EndpointGroup-0", agamodel.EndpointGroupSpec{
+			ListenerARN:           tcpListener.ListenerARN(),
+			Region:                "us-west-2",
+			TrafficDialPercentage: awssdk.Int32(100),
+			EndpointConfigurations: []agamodel.EndpointConfiguration{
+				{EndpointID: lbARN, Weight: awssdk.Int32(128)},
+			},
+		}, tcpListener)
+		agamodel.NewEndpointGroup(stack, "EndpointGroup-1", agamodel.EndpointGroupSpec{
+			ListenerARN: tcpListener.ListenerARN(),
+			Region:      "us-east-1",
+		}, tcpListener)
+		return stack
+	}
+
+	tests := []struct {
+		name   string
+		report Report
+		want   Report
+	}{
+		{
+			name:   "accelerator is not provisioned",
+			report: Report{},
+			want: Report{
+				MissingResources: []MissingResource{
+					{Type: "AWS::GlobalAccelerator::Accelerator", ID: "GlobalAccelerator", Description: "accelerator k8s-ns-ga"},
+				},
+				DriftDetected: true,
+				DriftStatus:   DriftStatusDrifted,
+			},
+		},
+		{
+			name: "live accelerator drifts from desired stack",
+			report: Report{
+				Accelerator: &AcceleratorNode{
+					Name:    "k8s-ns-ga",
+					Enabled: false,
+					Listeners: []AcceleratorListenerNode{
+						{
+							Protocol:   "TCP",
+							PortRanges: []string{"80", "443"},
+							EndpointGroups: []EndpointGroupNode{
+								{
+									Region:                "us-west-2",
+									TrafficDialPercentage: 50,
+									Endpoints: []EndpointNode{
+										{ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/legacy/def", Weight: 128},
+									},
+								},
+								{Region: "eu-west-1", TrafficDialPercentage: 100},
+							},
+						},
+						{Protocol: "TCP", PortRanges: []string{"8080"}},
+					},
+				},
+			},
+			want: Report{
+				Accelerator: &AcceleratorNode{
+					Name:    "k8s-ns-ga",
+					Enabled: false,
+					Drifts:  []Drift{{Field: "enabled", Desired: "true", Live: "false"}},
+					Listeners: []AcceleratorListenerNode{
+						{
+							Protocol:   "TCP",
+							PortRanges: []string{"80", "443"},
+							EndpointGroups: []EndpointGroupNode{
+								{
+									Region:                "us-west-2",
+									TrafficDialPercentage: 50,
+									Drifts: []Drift{
+										{Field: "trafficDialPercentage", Desired: "100", Live: "50"},
+										{Field: "endpoint " + lbARN, Desired: "<present>", Live: "<absent>"},
+									},
+									Endpoints: []EndpointNode{
+										{
+											ID:     "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/legacy/def",
+											Weight: 128,
+											Drifts: []Drift{{Field: "presence", Desired: "<absent>", Live: "<present>"}},
+										},
+									},
+								},
+								{
+									Region:                "eu-west-1",
+									TrafficDialPercentage: 100,
+									Drifts:                []Drift{{Field: "presence", Desired: "<absent>", Live: "<present>"}},
+								},
+							},
+						},
+						{
+							Protocol:   "TCP",
+							PortRanges: []string{"8080"},
+							Drifts:     []Drift{{Field: "presence", Desired: "<absent>", Live: "<present>"}},
+						},
+					},
+				},
+				MissingResources: []MissingResource{
+					{Type: "AWS::GlobalAccelerator::EndpointGroup", ID: "EndpointGroup-1", Description: "us-east-1 endpoint group on TCP:443,80 listener"},
+					{Type: "AWS::GlobalAccelerator::Listener", ID: "Listener-1", Description: "UDP:5000-5010 listener"},
+				},
+				DriftDetected: true,
+				DriftStatus:   DriftStatusDrifted,
+			},
+		},
+		{
+			name: "live accelerator matches desired stack",
+			report: Report{
+				Accelerator: &AcceleratorNode{
+					Name:    "k8s-ns-ga",
+					Enabled: true,
+					Listeners: []AcceleratorListenerNode{
+						{
+							Protocol:   "TCP",
+							PortRanges: []string{"80", "443"},
+							EndpointGroups: []EndpointGroupNode{
+								{Region: "us-west-2", TrafficDialPercentage: 100, Endpoints: []EndpointNode{{ID: lbARN, Weight: 128}}},
+								{Region: "us-east-1", TrafficDialPercentage: 100},
+							},
+						},
+						{Protocol: "UDP", PortRanges: []string{"5000-5010"}},
+					},
+				},
+			},
+			want: Report{
+				Accelerator: &AcceleratorNode{
+					Name:    "k8s-ns-ga",
+					Enabled: true,
+					Listeners: []AcceleratorListenerNode{
+						{
+							Protocol:   "TCP",
+							PortRanges: []string{"80", "443"},
+							EndpointGroups: []EndpointGroupNode{
+								{Region: "us-west-2", TrafficDialPercentage: 100, Endpoints: []EndpointNode{{ID: lbARN, Weight: 128}}},
+								{Region: "us-east-1", TrafficDialPercentage: 100},
+							},
+						},
+						{Protocol: "UDP", PortRanges: []string{"5000-5010"}},
+					},
+				},
+				DriftStatus: DriftStatusInSync,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := tt.report
+			err := detectDrift(buildDesiredStack(), &report)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, report)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package inspect
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/gatewayutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// gatewayConfigLoader loads the LoadBalancerConfiguration of a Gateway merged with the one of its GatewayClass,
+// and their default TargetGroupConfiguration. Unlike the gateway controller, it doesn't add finalizers to the configurations.
+type gatewayConfigLoader struct {
+	k8sClient           client.Client
+	configMerger        gateway.LoadBalancerConfigMerger
+	policyEnforcer      gateway.LoadBalancerConfigPolicyEnforcer
+	tgConfigConstructor gateway.TargetGroupConfigConstructor
+}
+
+// newGatewayConfigLoader constructs new gatewayConfigLoader.
+func newGatewayConfigLoader(k8sClient client.Client) *gatewayConfigLoader {
+	return &gatewayConfigLoader{
+		k8sClient:           k8sClient,
+		configMerger:        gateway.NewLoadBalancerConfigMerger(),
+		policyEnforcer:      gateway.NewLoadBalancerConfigPolicyEnforcer(),
+		tgConfigConstructor: gateway.NewTargetGroupConfigConstructor(),
+	}
+}
+
+func (l *gatewayConfigLoader) load(ctx context.Context, gw *gwv1.Gateway, gwClass *gwv1.GatewayClass) (elbv2gw.LoadBalancerConfiguration, *elbv2gw.TargetGroupConfiguration, error) {
+	if !meta.IsStatusConditionTrue(gwClass.Status.Conditions, string(gwv1.GatewayClassConditionStatusAccepted)) {
+		return elbv2gw.LoadBalancerConfiguration{}, nil, errors.Errorf("gatewayClass %v is not accepted", gwClass.Name)
+	}
+	gwClassLBConfig, err := gatewayutils.ResolveLoadBalancerConfig(ctx, l.k8sClient, gwClass.Spec.ParametersRef)
+	if err != nil {
+		return elbv2gw.LoadBalancerConfiguration{}, nil, err
+	}
+	gwLBConfig, err := gatewayutils.ResolveLoadBalancerConfig(ctx, l.k8sClient, gatewayutils.GetNamespacedParamRefForGateway(gw))
+	if err != nil {
+		return elbv2gw.LoadBalancerConfiguration{}, nil, err
+	}
+
+	gwClassDefaultTGC, err := l.loadDefaultTGC(ctx, gwClassLBConfig)
+	if err != nil {
+		return elbv2gw.LoadBalancerConfiguration{}, nil, err
+	}
+	gwDefaultTGC, err := l.loadDefaultTGC(ctx, gwLBConfig)
+	if err != nil {
+		return elbv2gw.LoadBalancerConfiguration{}, nil, err
+	}
+	mergeMode := elbv2gw.MergeModePreferGatewayClass
+	if gwClassLBConfig != nil && gwClassLBConfig.Spec.MergingMode != nil {
+		mergeMode = *gwClassLBConfig.Spec.MergingMode
+	}
+	defaultTGC := l.tgConfigConstructor.MergeDefaultTGCs(gwClassDefaultTGC, gwDefaultTGC, mergeMode)
+
+	var mergedLBConfig elbv2gw.LoadBalancerConfiguration
+	switch {
+	case gwClassLBConfig == nil && gwLBConfig == nil:
+		mergedLBConfig = elbv2gw.LoadBalancerConfiguration{}
+	case gwClassLBConfig == nil:
+		mergedLBConfig = *gwLBConfig
+	case gwLBConfig == nil:
+		mergedLBConfig = *gwClassLBConfig
+	default:
+		mergedLBConfig = l.configMerger.Merge(*gwClassLBConfig, *gwLBConfig)
+	}
+	// policies aren't enforced on a Gateway being deleted, same as the gateway controller.
+	if gwClassLBConfig != nil && gw.DeletionTimestamp.IsZero() {
+		mergedLBConfig, err = l.policyEnforcer.Enforce(*gwClassLBConfig, gwLBConfig, mergedLBConfig)
+		if err != nil {
+			return elbv2gw.LoadBalancerConfiguration{}, nil, err
+		}
+	}
+	return mergedLBConfig, defaultTGC, nil
+}
+
+// loadDefaultTGC loads the default TargetGroupConfiguration referenced by lbConfig, which lives in the namespace of lbConfig.
+func (l *gatewayConfigLoader) loadDefaultTGC(ctx context.Context, lbConfig *elbv2gw.LoadBalancerConfiguration) (*elbv2gw.TargetGroupConfiguration, error) {
+	if lbConfig == nil || lbConfig.Spec.DefaultTargetGroupConfiguration == nil {
+		return nil, nil
+	}
+	key := types.NamespacedName{Namespace: lbConfig.Namespace, Name: lbConfig.Spec.DefaultTargetGroupConfiguration.Name}
+	tgc := &elbv2gw.TargetGroupConfiguration{}
+	if err := l.k8sClient.Get(ctx, key, tgc); err != nil {
+		return nil, errors.Wrapf(err, "failed to get default targetGroupConfiguration %v of loadBalancerConfiguration %v", key, lbConfig.Name)
+	}
+	if tgc.Spec.TargetReference != nil {
+		return nil, errors.Errorf("targetGroupConfiguration %v has targetReference set and cannot be used as a defaultTargetGroupConfiguration", key)
+	}
+	return tgc, nil
+}
@@ -0,0 +1,108 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Inspector inspects the AWS resources behind Kubernetes objects.
+// It never modifies Kubernetes objects or AWS resources.
+type Inspector interface {
+	Inspect(ctx context.Context, ref ObjectRef) (*Report, error)
+}
+
+// NewDefaultInspector constructs new defaultInspector.
+func NewDefaultInspector(cloud services.Cloud, k8sClient client.Client, controllerConfig config.ControllerConfig, logger logr.Logger) *defaultInspector {
+	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	classLoader := ingress.NewDefaultClassLoader(k8sClient, true)
+	classAnnotationMatcher := ingress.NewDefaultClassAnnotationMatcher(controllerConfig.IngressConfig.IngressClass)
+	manageIngressesWithoutIngressClass := controllerConfig.IngressConfig.IngressClass == ""
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, &record.FakeRecorder{}, annotationParser, classLoader, classAnnotationMatcher, manageIngressesWithoutIngressClass)
+	svcAnnotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+	serviceUtils := service.NewServiceUtils(svcAnnotationParser, shared_constants.ServiceFinalizer, controllerConfig.ServiceConfig.LoadBalancerClasses(), controllerConfig.FeatureGates)
+	svcGroupLoader := service.NewDefaultGroupLoader(k8sClient, svcAnnotationParser, serviceUtils)
+
+	return &defaultInspector{
+		clusterName:    controllerConfig.ClusterName,
+		stackResolver:  &stackResolver{k8sClient: k8sClient, groupLoader: groupLoader, svcGroupLoader: svcGroupLoader},
+		desiredBuilder: newDefaultDesiredStackBuilder(cloud, k8sClient, groupLoader, serviceUtils, svcGroupLoader, controllerConfig, logger),
+		liveLoader: &liveResourceLoader{
+			elbv2Client: cloud.ELBV2(),
+			rgtClient:   cloud.RGT(),
+			gaClient:    cloud.GlobalAccelerator(),
+		},
+		logger: logger,
+	}
+}
+
+var _ Inspector = &defaultInspector{}
+
+type defaultInspector struct {
+	clusterName    string
+	stackResolver  *stackResolver
+	desiredBuilder desiredStackBuilder
+	liveLoader     *liveResourceLoader
+	logger         logr.Logger
+}
+
+func (i *defaultInspector) Inspect(ctx context.Context, ref ObjectRef) (*Report, error) {
+	objStack, err := i.stackResolver.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		Object:  ref,
+		StackID: objStack.stackID.String(),
+	}
+
+	if objStack.kind == ObjectKindGlobalAccelerator {
+		if objStack.acceleratorARN == "" {
+			report.Warnings = append(report.Warnings, "accelerator is not provisioned yet, status.acceleratorARN is empty")
+		} else {
+			report.Accelerator, err = i.liveLoader.loadAccelerator(ctx, objStack.acceleratorARN)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		trackingProvider := tracking.NewDefaultProvider(objStack.tagPrefix, i.clusterName)
+		stackTags := trackingProvider.StackTags(core.NewDefaultStack(objStack.stackID))
+		liveStack, err := i.liveLoader.loadELBV2Stack(ctx, stackTags, trackingProvider.ResourceIDTagKey())
+		if err != nil {
+			return nil, err
+		}
+		report.LoadBalancers = liveStack.loadBalancers
+		report.UnreferencedTargetGroups = liveStack.unreferencedTargetGroups
+	}
+
+	desiredStack, err := i.desiredBuilder.build(ctx, ref, objStack)
+	var unsupportedKindErr *unsupportedKindError
+	if errors.As(err, &unsupportedKindErr) {
+		report.DriftStatus = DriftStatusUnsupported
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%v, drift is not reported", err))
+		return report, nil
+	}
+	if err != nil {
+		// the live resources are still useful when the desired stack fails to build, e.g. due to invalid annotations.
+		report.DriftStatus = DriftStatusUnavailable
+		report.Warnings = append(report.Warnings, fmt.Sprintf("failed to build desired stack, drift is not reported: %v", err))
+		return report, nil
+	}
+	if err := detectDrift(desiredStack, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
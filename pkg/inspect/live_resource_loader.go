@@ -0,0 +1,371 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	// describeTargetGroupsChunkSize is the maximum number of target group ARNs per DescribeTargetGroups call.
+	describeTargetGroupsChunkSize = 20
+)
+
+// liveELBV2Stack contains the live ELBV2 resources of a stack.
+type liveELBV2Stack struct {
+	loadBalancers            []LoadBalancerNode
+	unreferencedTargetGroups []TargetGroupNode
+}
+
+// liveResourceLoader loads live AWS resources for inspection.
+type liveResourceLoader struct {
+	elbv2Client services.ELBV2
+	rgtClient   services.RGT
+	gaClient    services.GlobalAccelerator
+}
+
+// loadELBV2Stack loads the live load balancers and target groups tagged with stackTags.
+func (l *liveResourceLoader) loadELBV2Stack(ctx context.Context, stackTags map[string]string, resourceIDTagKey string) (liveELBV2Stack, error) {
+	var tagFilters []rgttypes.TagFilter
+	for _, key := range sets.List(sets.KeySet(stackTags)) {
+		tagFilters = append(tagFilters, rgttypes.TagFilter{Key: awssdk.String(key), Values: []string{stackTags[key]}})
+	}
+	resources, err := l.rgtClient.GetResourcesAsList(ctx, &rgtsdk.GetResourcesInput{
+		TagFilters:          tagFilters,
+		ResourceTypeFilters: []string{services.ResourceTypeELBLoadBalancer, services.ResourceTypeELBTargetGroup},
+	})
+	if err != nil {
+		return liveELBV2Stack{}, err
+	}
+
+	resourceIDByARN := make(map[string]string)
+	var lbARNs, tgARNs []string
+	for _, resource := range resources {
+		resARN := awssdk.ToString(resource.ResourceARN)
+		resourceIDByARN[resARN] = services.ParseRGTTags(resource.Tags)[resourceIDTagKey]
+		if strings.Contains(resARN, ":loadbalancer/") {
+			lbARNs = append(lbARNs, resARN)
+		} else {
+			tgARNs = append(tgARNs, resARN)
+		}
+	}
+	sort.Strings(lbARNs)
+	sort.Strings(tgARNs)
+
+	var lbs []elbv2types.LoadBalancer
+	if len(lbARNs) != 0 {
+		lbs, err = l.elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{LoadBalancerArns: lbARNs})
+		if err != nil {
+			return liveELBV2Stack{}, err
+		}
+	}
+
+	tgLoader := &targetGroupLoader{elbv2Client: l.elbv2Client, resourceIDByARN: resourceIDByARN, nodeByARN: make(map[string]TargetGroupNode)}
+	if err := tgLoader.load(ctx, tgARNs); err != nil {
+		return liveELBV2Stack{}, err
+	}
+
+	referencedTGARNs := make(map[string]bool)
+	stack := liveELBV2Stack{}
+	for _, lb := range lbs {
+		lbNode := LoadBalancerNode{
+			ResourceID: resourceIDByARN[awssdk.ToString(lb.LoadBalancerArn)],
+			ARN:        awssdk.ToString(lb.LoadBalancerArn),
+			Name:       awssdk.ToString(lb.LoadBalancerName),
+			DNSName:    awssdk.ToString(lb.DNSName),
+			Type:       string(lb.Type),
+			Scheme:     string(lb.Scheme),
+		}
+		if lb.State != nil {
+			lbNode.State = string(lb.State.Code)
+		}
+		listeners, err := l.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{LoadBalancerArn: lb.LoadBalancerArn})
+		if err != nil {
+			return liveELBV2Stack{}, err
+		}
+		sort.Slice(listeners, func(i, j int) bool {
+			return awssdk.ToInt32(listeners[i].Port) < awssdk.ToInt32(listeners[j].Port)
+		})
+		for _, listener := range listeners {
+			listenerNode := ListenerNode{
+				ARN:      awssdk.ToString(listener.ListenerArn),
+				Port:     awssdk.ToInt32(listener.Port),
+				Protocol: string(listener.Protocol),
+			}
+			listenerNode.DefaultActions, listenerNode.DefaultTargetGroups, err = tgLoader.resolveActions(ctx, listener.DefaultActions, referencedTGARNs)
+			if err != nil {
+				return liveELBV2Stack{}, err
+			}
+			if lb.Type == elbv2types.LoadBalancerTypeEnumApplication {
+				listenerNode.Rules, err = l.loadRules(ctx, tgLoader, listener, referencedTGARNs)
+				if err != nil {
+					return liveELBV2Stack{}, err
+				}
+			}
+			lbNode.Listeners = append(lbNode.Listeners, listenerNode)
+		}
+		stack.loadBalancers = append(stack.loadBalancers, lbNode)
+	}
+
+	for _, tgARN := range tgARNs {
+		if !referencedTGARNs[tgARN] {
+			stack.unreferencedTargetGroups = append(stack.unreferencedTargetGroups, tgLoader.nodeByARN[tgARN])
+		}
+	}
+	return stack, nil
+}
+
+// loadRules loads the non-default rules of an ALB listener, ordered by priority.
+func (l *liveResourceLoader) loadRules(ctx context.Context, tgLoader *targetGroupLoader, listener elbv2types.Listener, referencedTGARNs map[string]bool) ([]RuleNode, error) {
+	rules, err := l.elbv2Client.DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{ListenerArn: listener.ListenerArn})
+	if err != nil {
+		return nil, err
+	}
+	var ruleNodes []RuleNode
+	for _, rule := range rules {
+		if awssdk.ToBool(rule.IsDefault) {
+			continue
+		}
+		ruleNode := RuleNode{
+			ARN:        awssdk.ToString(rule.RuleArn),
+			Priority:   awssdk.ToString(rule.Priority),
+			Conditions: summarizeRuleConditions(rule.Conditions),
+		}
+		ruleNode.Actions, ruleNode.TargetGroups, err = tgLoader.resolveActions(ctx, rule.Actions, referencedTGARNs)
+		if err != nil {
+			return nil, err
+		}
+		ruleNodes = append(ruleNodes, ruleNode)
+	}
+	sort.Slice(ruleNodes, func(i, j int) bool {
+		return comparePriority(ruleNodes[i].Priority, ruleNodes[j].Priority)
+	})
+	return ruleNodes, nil
+}
+
+// loadAccelerator loads the live accelerator with its listeners and endpoint groups.
+func (l *liveResourceLoader) loadAccelerator(ctx context.Context, acceleratorARN string) (*AcceleratorNode, error) {
+	resp, err := l.gaClient.DescribeAcceleratorWithContext(ctx, &gasdk.DescribeAcceleratorInput{AcceleratorArn: awssdk.String(acceleratorARN)})
+	if err != nil {
+		return nil, err
+	}
+	accelerator := resp.Accelerator
+	acceleratorNode := &AcceleratorNode{
+		ARN:     awssdk.ToString(accelerator.AcceleratorArn),
+		Name:    awssdk.ToString(accelerator.Name),
+		DNSName: awssdk.ToString(accelerator.DnsName),
+		Status:  string(accelerator.Status),
+		Enabled: awssdk.ToBool(accelerator.Enabled),
+	}
+	listeners, err := l.gaClient.ListListenersAsList(ctx, &gasdk.ListListenersInput{AcceleratorArn: accelerator.AcceleratorArn})
+	if err != nil {
+		return nil, err
+	}
+	for _, listener := range listeners {
+		listenerNode := AcceleratorListenerNode{
+			ARN:      awssdk.ToString(listener.ListenerArn),
+			Protocol: string(listener.Protocol),
+		}
+		for _, portRange := range listener.PortRanges {
+			listenerNode.PortRanges = append(listenerNode.PortRanges, formatPortRange(awssdk.ToInt32(portRange.FromPort), awssdk.ToInt32(portRange.ToPort)))
+		}
+		endpointGroups, err := l.gaClient.ListEndpointGroupsAsList(ctx, &gasdk.ListEndpointGroupsInput{ListenerArn: listener.ListenerArn})
+		if err != nil {
+			return nil, err
+		}
+		for _, endpointGroup := range endpointGroups {
+			endpointGroupNode := EndpointGroupNode{
+				ARN:                   awssdk.ToString(endpointGroup.EndpointGroupArn),
+				Region:                awssdk.ToString(endpointGroup.EndpointGroupRegion),
+				TrafficDialPercentage: awssdk.ToFloat32(endpointGroup.TrafficDialPercentage),
+			}
+			for _, endpoint := range endpointGroup.EndpointDescriptions {
+				endpointGroupNode.Endpoints = append(endpointGroupNode.Endpoints, EndpointNode{
+					ID:           awssdk.ToString(endpoint.EndpointId),
+					Weight:       awssdk.ToInt32(endpoint.Weight),
+					HealthState:  string(endpoint.HealthState),
+					HealthReason: awssdk.ToString(endpoint.HealthReason),
+				})
+			}
+			listenerNode.EndpointGroups = append(listenerNode.EndpointGroups, endpointGroupNode)
+		}
+		acceleratorNode.Listeners = append(acceleratorNode.Listeners, listenerNode)
+	}
+	return acceleratorNode, nil
+}
+
+// formatPortRange formats an accelerator listener port range, such as 80 or 8000-8080.
+func formatPortRange(fromPort int32, toPort int32) string {
+	if fromPort == toPort {
+		return fmt.Sprintf("%d", fromPort)
+	}
+	return fmt.Sprintf("%d-%d", fromPort, toPort)
+}
+
+// targetGroupLoader loads target groups with the health of their targets, each target group is loaded once.
+type targetGroupLoader struct {
+	elbv2Client     services.ELBV2
+	resourceIDByARN map[string]string
+	nodeByARN       map[string]TargetGroupNode
+}
+
+// load loads target groups that are not loaded yet.
+func (l *targetGroupLoader) load(ctx context.Context, tgARNs []string) error {
+	var unloadedTGARNs []string
+	for _, tgARN := range tgARNs {
+		if _, loaded := l.nodeByARN[tgARN]; !loaded {
+			unloadedTGARNs = append(unloadedTGARNs, tgARN)
+		}
+	}
+	for _, chunk := range algorithm.ChunkStrings(unloadedTGARNs, describeTargetGroupsChunkSize) {
+		tgs, err := l.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{TargetGroupArns: chunk})
+		if err != nil {
+			return err
+		}
+		for _, tg := range tgs {
+			tgNode := TargetGroupNode{
+				ResourceID: l.resourceIDByARN[awssdk.ToString(tg.TargetGroupArn)],
+				ARN:        awssdk.ToString(tg.TargetGroupArn),
+				Name:       awssdk.ToString(tg.TargetGroupName),
+				TargetType: string(tg.TargetType),
+				Protocol:   string(tg.Protocol),
+				Port:       awssdk.ToInt32(tg.Port),
+			}
+			resp, err := l.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: tg.TargetGroupArn})
+			if err != nil {
+				return err
+			}
+			for _, elem := range resp.TargetHealthDescriptions {
+				targetNode := TargetNode{
+					ID:               awssdk.ToString(elem.Target.Id),
+					Port:             awssdk.ToInt32(elem.Target.Port),
+					AvailabilityZone: awssdk.ToString(elem.Target.AvailabilityZone),
+				}
+				if elem.TargetHealth != nil {
+					targetNode.State = string(elem.TargetHealth.State)
+					targetNode.Reason = string(elem.TargetHealth.Reason)
+					targetNode.Description = awssdk.ToString(elem.TargetHealth.Description)
+				}
+				tgNode.Targets = append(tgNode.Targets, targetNode)
+			}
+			sort.Slice(tgNode.Targets, func(i, j int) bool {
+				if tgNode.Targets[i].ID != tgNode.Targets[j].ID {
+					return tgNode.Targets[i].ID < tgNode.Targets[j].ID
+				}
+				return tgNode.Targets[i].Port < tgNode.Targets[j].Port
+			})
+			l.nodeByARN[tgNode.ARN] = tgNode
+		}
+	}
+	return nil
+}
+
+// resolveActions summarizes actions and resolves the target groups they forward to.
+// target groups outside the stack, such as the ones referenced by ARN in annotations, are loaded as well.
+func (l *targetGroupLoader) resolveActions(ctx context.Context, actions []elbv2types.Action, referencedTGARNs map[string]bool) ([]string, []TargetGroupNode, error) {
+	type weightedTargetGroup struct {
+		arn    string
+		weight *int32
+	}
+	var actionSummaries []string
+	var forwardTGs []weightedTargetGroup
+	for _, action := range actions {
+		actionSummaries = append(actionSummaries, summarizeAction(action))
+		if action.Type != elbv2types.ActionTypeEnumForward {
+			continue
+		}
+		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) != 0 {
+			for _, tgTuple := range action.ForwardConfig.TargetGroups {
+				forwardTGs = append(forwardTGs, weightedTargetGroup{arn: awssdk.ToString(tgTuple.TargetGroupArn), weight: tgTuple.Weight})
+			}
+		} else if action.TargetGroupArn != nil {
+			forwardTGs = append(forwardTGs, weightedTargetGroup{arn: awssdk.ToString(action.TargetGroupArn)})
+		}
+	}
+
+	tgARNs := make([]string, 0, len(forwardTGs))
+	for _, tg := range forwardTGs {
+		tgARNs = append(tgARNs, tg.arn)
+	}
+	if err := l.load(ctx, tgARNs); err != nil {
+		return nil, nil, err
+	}
+	var tgNodes []TargetGroupNode
+	for _, tg := range forwardTGs {
+		tgNode, exists := l.nodeByARN[tg.arn]
+		if !exists {
+			tgNode = TargetGroupNode{ARN: tg.arn}
+		}
+		tgNode.Weight = tg.weight
+		tgNodes = append(tgNodes, tgNode)
+		referencedTGARNs[tg.arn] = true
+	}
+	return actionSummaries, tgNodes, nil
+}
+
+// summarizeAction summarizes an action as its type, with the status code for fixed-response and redirect actions.
+func summarizeAction(action elbv2types.Action) string {
+	switch action.Type {
+	case elbv2types.ActionTypeEnumFixedResponse:
+		if action.FixedResponseConfig != nil {
+			return fmt.Sprintf("%s:%s", action.Type, awssdk.ToString(action.FixedResponseConfig.StatusCode))
+		}
+	case elbv2types.ActionTypeEnumRedirect:
+		if action.RedirectConfig != nil {
+			return fmt.Sprintf("%s:%s", action.Type, action.RedirectConfig.StatusCode)
+		}
+	}
+	return string(action.Type)
+}
+
+// summarizeRuleConditions summarizes rule conditions as field=values.
+func summarizeRuleConditions(conditions []elbv2types.RuleCondition) []string {
+	var summaries []string
+	for _, condition := range conditions {
+		field := awssdk.ToString(condition.Field)
+		var values []string
+		switch {
+		case condition.HostHeaderConfig != nil:
+			values = condition.HostHeaderConfig.Values
+		case condition.PathPatternConfig != nil:
+			values = condition.PathPatternConfig.Values
+		case condition.HttpRequestMethodConfig != nil:
+			values = condition.HttpRequestMethodConfig.Values
+		case condition.SourceIpConfig != nil:
+			values = condition.SourceIpConfig.Values
+		case condition.HttpHeaderConfig != nil:
+			field = fmt.Sprintf("%s[%s]", field, awssdk.ToString(condition.HttpHeaderConfig.HttpHeaderName))
+			values = condition.HttpHeaderConfig.Values
+		case condition.QueryStringConfig != nil:
+			for _, kv := range condition.QueryStringConfig.Values {
+				values = append(values, fmt.Sprintf("%s=%s", awssdk.ToString(kv.Key), awssdk.ToString(kv.Value)))
+			}
+		default:
+			values = condition.Values
+		}
+		summaries = append(summaries, fmt.Sprintf("%s=%s", field, strings.Join(values, ",")))
+	}
+	return summaries
+}
+
+// comparePriority orders rule priorities numerically.
+func comparePriority(lhs string, rhs string) bool {
+	lhsPriority, lhsErr := strconv.Atoi(lhs)
+	rhsPriority, rhsErr := strconv.Atoi(rhs)
+	if lhsErr != nil || rhsErr != nil {
+		return lhs < rhs
+	}
+	return lhsPriority < rhsPriority
+}
@@ -0,0 +1,314 @@
+package inspect
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	gasdk "github.com/aws/aws-sdk-go-v2/service/globalaccelerator"
+	gatypes "github.com/aws/aws-sdk-go-v2/service/globalaccelerator/types"
+	rgtsdk "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+func Test_liveResourceLoader_loadELBV2Stack(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-awesomegroup/abc"
+	listenerARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/k8s-awesomegroup/abc/l80"
+	ruleARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/k8s-awesomegroup/abc/l80/r1"
+	defaultRuleARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/k8s-awesomegroup/abc/l80/default"
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-ns-svc-abc/tg1"
+	externalTGARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/external/tg2"
+	staleTGARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-ns-stale-abc/tg3"
+	stackTags := map[string]string{
+		"elbv2.k8s.aws/cluster": "cluster",
+		"ingress.k8s.aws/stack": "awesome-group",
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(elbv2Client *services.MockELBV2, rgtClient *services.MockRGT)
+		want       liveELBV2Stack
+		wantErr    error
+	}{
+		{
+			name: "no resources in stack",
+			setupMocks: func(elbv2Client *services.MockELBV2, rgtClient *services.MockRGT) {
+				rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			want: liveELBV2Stack{},
+		},
+		{
+			name: "application load balancer with rules and target groups",
+			setupMocks: func(elbv2Client *services.MockELBV2, rgtClient *services.MockRGT) {
+				rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), &rgtsdk.GetResourcesInput{
+					TagFilters: []rgttypes.TagFilter{
+						{Key: awssdk.String("elbv2.k8s.aws/cluster"), Values: []string{"cluster"}},
+						{Key: awssdk.String("ingress.k8s.aws/stack"), Values: []string{"awesome-group"}},
+					},
+					ResourceTypeFilters: []string{services.ResourceTypeELBLoadBalancer, services.ResourceTypeELBTargetGroup},
+				}).Return([]rgttypes.ResourceTagMapping{
+					{ResourceARN: awssdk.String(lbARN), Tags: []rgttypes.Tag{{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("LoadBalancer")}}},
+					{ResourceARN: awssdk.String(tgARN), Tags: []rgttypes.Tag{{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("ns/ing-svc:80")}}},
+					{ResourceARN: awssdk.String(staleTGARN), Tags: []rgttypes.Tag{{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("ns/ing-stale:80")}}},
+				}, nil)
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{LoadBalancerArns: []string{lbARN}}).Return([]elbv2types.LoadBalancer{
+					{
+						LoadBalancerArn:  awssdk.String(lbARN),
+						LoadBalancerName: awssdk.String("k8s-awesomegroup"),
+						DNSName:          awssdk.String("k8s-awesomegroup.elb.amazonaws.com"),
+						Type:             elbv2types.LoadBalancerTypeEnumApplication,
+						Scheme:           elbv2types.LoadBalancerSchemeEnumInternetFacing,
+						State:            &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive},
+					},
+				}, nil)
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{TargetGroupArns: []string{staleTGARN, tgARN}}).Return([]elbv2types.TargetGroup{
+					{TargetGroupArn: awssdk.String(tgARN), TargetGroupName: awssdk.String("k8s-ns-svc-abc"), TargetType: elbv2types.TargetTypeEnumIp, Protocol: elbv2types.ProtocolEnumHttp, Port: awssdk.Int32(8080)},
+					{TargetGroupArn: awssdk.String(staleTGARN), TargetGroupName: awssdk.String("k8s-ns-stale-abc"), TargetType: elbv2types.TargetTypeEnumInstance, Protocol: elbv2types.ProtocolEnumHttp, Port: awssdk.Int32(30080)},
+				}, nil)
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String(tgARN)}).Return(&elbv2sdk.DescribeTargetHealthOutput{
+					TargetHealthDescriptions: []elbv2types.TargetHealthDescription{
+						{
+							Target:       &elbv2types.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int32(8080)},
+							TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumUnhealthy, Reason: elbv2types.TargetHealthReasonEnumTimeout, Description: awssdk.String("Request timed out")},
+						},
+						{
+							Target:       &elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+							TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy},
+						},
+					},
+				}, nil)
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String(staleTGARN)}).Return(&elbv2sdk.DescribeTargetHealthOutput{}, nil)
+				elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String(lbARN)}).Return([]elbv2types.Listener{
+					{
+						ListenerArn: awssdk.String(listenerARN),
+						Port:        awssdk.Int32(80),
+						Protocol:    elbv2types.ProtocolEnumHttp,
+						DefaultActions: []elbv2types.Action{
+							{Type: elbv2types.ActionTypeEnumFixedResponse, FixedResponseConfig: &elbv2types.FixedResponseActionConfig{StatusCode: awssdk.String("404")}},
+						},
+					},
+				}, nil)
+				elbv2Client.EXPECT().DescribeRulesAsList(gomock.Any(), &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(listenerARN)}).Return([]elbv2types.Rule{
+					{RuleArn: awssdk.String(defaultRuleARN), Priority: awssdk.String("default"), IsDefault: awssdk.Bool(true)},
+					{
+						RuleArn:  awssdk.String(ruleARN),
+						Priority: awssdk.String("1"),
+						Conditions: []elbv2types.RuleCondition{
+							{Field: awssdk.String("path-pattern"), PathPatternConfig: &elbv2types.PathPatternConditionConfig{Values: []string{"/api"}}},
+						},
+						Actions: []elbv2types.Action{
+							{
+								Type: elbv2types.ActionTypeEnumForward,
+								ForwardConfig: &elbv2types.ForwardActionConfig{
+									TargetGroups: []elbv2types.TargetGroupTuple{
+										{TargetGroupArn: awssdk.String(tgARN), Weight: awssdk.Int32(90)},
+										{TargetGroupArn: awssdk.String(externalTGARN), Weight: awssdk.Int32(10)},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{TargetGroupArns: []string{externalTGARN}}).Return([]elbv2types.TargetGroup{
+					{TargetGroupArn: awssdk.String(externalTGARN), TargetGroupName: awssdk.String("external"), TargetType: elbv2types.TargetTypeEnumIp, Protocol: elbv2types.ProtocolEnumHttp, Port: awssdk.Int32(80)},
+				}, nil)
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String(externalTGARN)}).Return(&elbv2sdk.DescribeTargetHealthOutput{}, nil)
+			},
+			want: liveELBV2Stack{
+				loadBalancers: []LoadBalancerNode{
+					{
+						ResourceID: "LoadBalancer",
+						ARN:        lbARN,
+						Name:       "k8s-awesomegroup",
+						DNSName:    "k8s-awesomegroup.elb.amazonaws.com",
+						Type:       "application",
+						Scheme:     "internet-facing",
+						State:      "active",
+						Listeners: []ListenerNode{
+							{
+								ARN:            listenerARN,
+								Port:           80,
+								Protocol:       "HTTP",
+								DefaultActions: []string{"fixed-response:404"},
+								Rules: []RuleNode{
+									{
+										ARN:        ruleARN,
+										Priority:   "1",
+										Conditions: []string{"path-pattern=/api"},
+										Actions:    []string{"forward"},
+										TargetGroups: []TargetGroupNode{
+											{
+												ResourceID: "ns/ing-svc:80",
+												ARN:        tgARN,
+												Name:       "k8s-ns-svc-abc",
+												TargetType: "ip",
+												Protocol:   "HTTP",
+												Port:       8080,
+												Weight:     awssdk.Int32(90),
+												Targets: []TargetNode{
+													{ID: "192.168.1.1", Port: 8080, State: "healthy"},
+													{ID: "192.168.1.2", Port: 8080, State: "unhealthy", Reason: "Target.Timeout", Description: "Request timed out"},
+												},
+											},
+											{
+												ARN:        externalTGARN,
+												Name:       "external",
+												TargetType: "ip",
+												Protocol:   "HTTP",
+												Port:       80,
+												Weight:     awssdk.Int32(10),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				unreferencedTargetGroups: []TargetGroupNode{
+					{
+						ResourceID: "ns/ing-stale:80",
+						ARN:        staleTGARN,
+						Name:       "k8s-ns-stale-abc",
+						TargetType: "instance",
+						Protocol:   "HTTP",
+						Port:       30080,
+					},
+				},
+			},
+		},
+		{
+			name: "resource lookup fails",
+			setupMocks: func(elbv2Client *services.MockELBV2, rgtClient *services.MockRGT) {
+				rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), gomock.Any()).Return(nil, errors.New("access denied"))
+			},
+			wantErr: errors.New("access denied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			rgtClient := services.NewMockRGT(ctrl)
+			tt.setupMocks(elbv2Client, rgtClient)
+
+			l := &liveResourceLoader{elbv2Client: elbv2Client, rgtClient: rgtClient}
+			got, err := l.loadELBV2Stack(context.Background(), stackTags, "ingress.k8s.aws/resource")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_liveResourceLoader_loadAccelerator(t *testing.T) {
+	acceleratorARN := "arn:aws:globalaccelerator::123456789012:accelerator/abc"
+	listenerARN := acceleratorARN + "/listener/l1"
+	endpointGroupARN := listenerARN + "/endpoint-group/eg1"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gaClient := services.NewMockGlobalAccelerator(ctrl)
+	gaClient.EXPECT().DescribeAcceleratorWithContext(gomock.Any(), &gasdk.DescribeAcceleratorInput{AcceleratorArn: awssdk.String(acceleratorARN)}).Return(&gasdk.DescribeAcceleratorOutput{
+		Accelerator: &gatypes.Accelerator{
+			AcceleratorArn: awssdk.String(acceleratorARN),
+			Name:           awssdk.String("awesome-ga"),
+			DnsName:        awssdk.String("a1234.awsglobalaccelerator.com"),
+			Status:         gatypes.AcceleratorStatusDeployed,
+			Enabled:        awssdk.Bool(true),
+		},
+	}, nil)
+	gaClient.EXPECT().ListListenersAsList(gomock.Any(), &gasdk.ListListenersInput{AcceleratorArn: awssdk.String(acceleratorARN)}).Return([]gatypes.Listener{
+		{
+			ListenerArn: awssdk.String(listenerARN),
+			Protocol:    gatypes.ProtocolTcp,
+			PortRanges: []gatypes.PortRange{
+				{FromPort: awssdk.Int32(80), ToPort: awssdk.Int32(80)},
+				{FromPort: awssdk.Int32(8000), ToPort: awssdk.Int32(8080)},
+			},
+		},
+	}, nil)
+	gaClient.EXPECT().ListEndpointGroupsAsList(gomock.Any(), &gasdk.ListEndpointGroupsInput{ListenerArn: awssdk.String(listenerARN)}).Return([]gatypes.EndpointGroup{
+		{
+			EndpointGroupArn:      awssdk.String(endpointGroupARN),
+			EndpointGroupRegion:   awssdk.String("us-west-2"),
+			TrafficDialPercentage: awssdk.Float32(100),
+			EndpointDescriptions: []gatypes.EndpointDescription{
+				{EndpointId: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/nlb/abc"), Weight: awssdk.Int32(128), HealthState: gatypes.HealthStateHealthy},
+			},
+		},
+	}, nil)
+
+	l := &liveResourceLoader{gaClient: gaClient}
+	got, err := l.loadAccelerator(context.Background(), acceleratorARN)
+	assert.NoError(t, err)
+	assert.Equal(t, &AcceleratorNode{
+		ARN:     acceleratorARN,
+		Name:    "awesome-ga",
+		DNSName: "a1234.awsglobalaccelerator.com",
+		Status:  "DEPLOYED",
+		Enabled: true,
+		Listeners: []AcceleratorListenerNode{
+			{
+				ARN:        listenerARN,
+				Protocol:   "TCP",
+				PortRanges: []string{"80", "8000-8080"},
+				EndpointGroups: []EndpointGroupNode{
+					{
+						ARN:                   endpointGroupARN,
+						Region:                "us-west-2",
+						TrafficDialPercentage: 100,
+						Endpoints: []EndpointNode{
+							{ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/nlb/abc", Weight: 128, HealthState: "HEALTHY"},
+						},
+					},
+				},
+			},
+		},
+	}, got)
+}
+
+func Test_summarizeRuleConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []elbv2types.RuleCondition
+		want       []string
+	}{
+		{
+			name:       "no conditions",
+			conditions: nil,
+			want:       nil,
+		},
+		{
+			name: "conditions of every kind",
+			conditions: []elbv2types.RuleCondition{
+				{Field: awssdk.String("host-header"), HostHeaderConfig: &elbv2types.HostHeaderConditionConfig{Values: []string{"a.example.com", "b.example.com"}}},
+				{Field: awssdk.String("http-header"), HttpHeaderConfig: &elbv2types.HttpHeaderConditionConfig{HttpHeaderName: awssdk.String("x-env"), Values: []string{"canary"}}},
+				{Field: awssdk.String("query-string"), QueryStringConfig: &elbv2types.QueryStringConditionConfig{Values: []elbv2types.QueryStringKeyValuePair{{Key: awssdk.String("version"), Value: awssdk.String("v2")}}}},
+				{Field: awssdk.String("source-ip"), SourceIpConfig: &elbv2types.SourceIpConditionConfig{Values: []string{"10.0.0.0/8"}}},
+			},
+			want: []string{
+				"host-header=a.example.com,b.example.com",
+				"http-header[x-env]=canary",
+				"query-string=version=v2",
+				"source-ip=10.0.0.0/8",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeRuleConditions(tt.conditions)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	gatewayconstants "sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ObjectKind is the kind of Kubernetes object that can be inspected.
+type ObjectKind string
+
+const (
+	ObjectKindIngress           ObjectKind = "ingress"
+	ObjectKindIngressGroup      ObjectKind = "ingressgroup"
+	ObjectKindService           ObjectKind = "service"
+	ObjectKindGateway           ObjectKind = "gateway"
+	ObjectKindGlobalAccelerator ObjectKind = "globalaccelerator"
+)
+
+// objectKindAliases maps the accepted spellings of object kinds, following kubectl short names.
+var objectKindAliases = map[string]ObjectKind{
+	"ingress":            ObjectKindIngress,
+	"ingresses":          ObjectKindIngress,
+	"ing":                ObjectKindIngress,
+	"ingressgroup":       ObjectKindIngressGroup,
+	"ingressgroups":      ObjectKindIngressGroup,
+	"service":            ObjectKindService,
+	"services":           ObjectKindService,
+	"svc":                ObjectKindService,
+	"gateway":            ObjectKindGateway,
+	"gateways":           ObjectKindGateway,
+	"gtw":                ObjectKindGateway,
+	"globalaccelerator":  ObjectKindGlobalAccelerator,
+	"globalaccelerators": ObjectKindGlobalAccelerator,
+	"ga":                 ObjectKindGlobalAccelerator,
+}
+
+// tag prefixes used by each controller to track its stacks, see tracking.Provider.
+const (
+	tagPrefixIngress           = "ingress.k8s.aws"
+	tagPrefixService           = "service.k8s.aws"
+	tagPrefixGlobalAccelerator = "aga.k8s.aws"
+)
+
+// ParseObjectKind parses the kind of object to inspect.
+func ParseObjectKind(rawKind string) (ObjectKind, error) {
+	kind, ok := objectKindAliases[strings.ToLower(rawKind)]
+	if !ok {
+		return "", errors.Errorf("unsupported kind %q, must be one of: ingress, ingressgroup, service, gateway, globalaccelerator", rawKind)
+	}
+	return kind, nil
+}
+
+// ObjectRef references the Kubernetes object to inspect.
+type ObjectRef struct {
+	Kind ObjectKind `json:"kind"`
+	// Namespace of the object, it's empty for IngressGroups.
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// String returns the kubectl style representation of ObjectRef.
+func (ref ObjectRef) String() string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Namespace, ref.Name)
+}
+
+// objectStack identifies the stack that tracks the AWS resources of an object.
+type objectStack struct {
+	kind      ObjectKind
+	tagPrefix string
+	stackID   core.StackID
+	// acceleratorARN is the ARN reported in GlobalAccelerator status.
+	acceleratorARN string
+}
+
+// stackResolver resolves the stack of Kubernetes objects.
+type stackResolver struct {
+	k8sClient      client.Client
+	groupLoader    ingress.GroupLoader
+	svcGroupLoader service.GroupLoader
+}
+
+// resolve resolves the stack of the object.
+func (r *stackResolver) resolve(ctx context.Context, ref ObjectRef) (objectStack, error) {
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	switch ref.Kind {
+	case ObjectKindIngress:
+		ing := &networking.Ingress{}
+		if err := r.k8sClient.Get(ctx, key, ing); err != nil {
+			return objectStack{}, err
+		}
+		groupID, err := r.groupLoader.LoadGroupIDIfAny(ctx, ing)
+		if err != nil {
+			return objectStack{}, err
+		}
+		if groupID == nil {
+			return objectStack{}, errors.Errorf("ingress %v is not managed by the controller", key)
+		}
+		return objectStack{kind: ObjectKindIngressGroup, tagPrefix: tagPrefixIngress, stackID: core.StackID(*groupID)}, nil
+	case ObjectKindIngressGroup:
+		groupID := ingress.NewGroupIDForExplicitGroup(ref.Name)
+		return objectStack{kind: ObjectKindIngressGroup, tagPrefix: tagPrefixIngress, stackID: core.StackID(groupID)}, nil
+	case ObjectKindService:
+		svc := &corev1.Service{}
+		if err := r.k8sClient.Get(ctx, key, svc); err != nil {
+			return objectStack{}, err
+		}
+		groupName, err := r.svcGroupLoader.LoadGroupNameIfAny(svc)
+		if err != nil {
+			return objectStack{}, err
+		}
+		if groupName != "" {
+			return objectStack{kind: ObjectKindService, tagPrefix: tagPrefixService, stackID: service.NewGroupStackID(groupName)}, nil
+		}
+		return objectStack{kind: ObjectKindService, tagPrefix: tagPrefixService, stackID: core.StackID(key)}, nil
+	case ObjectKindGateway:
+		tagPrefix, err := r.resolveGatewayTagPrefix(ctx, key)
+		if err != nil {
+			return objectStack{}, err
+		}
+		return objectStack{kind: ObjectKindGateway, tagPrefix: tagPrefix, stackID: core.StackID(key)}, nil
+	case ObjectKindGlobalAccelerator:
+		ga := &agaapi.GlobalAccelerator{}
+		if err := r.k8sClient.Get(ctx, key, ga); err != nil {
+			return objectStack{}, err
+		}
+		acceleratorARN := ""
+		if ga.Status.AcceleratorARN != nil {
+			acceleratorARN = *ga.Status.AcceleratorARN
+		}
+		return objectStack{kind: ObjectKindGlobalAccelerator, tagPrefix: tagPrefixGlobalAccelerator, stackID: core.StackID(key), acceleratorARN: acceleratorARN}, nil
+	}
+	return objectStack{}, errors.Errorf("unsupported kind %q", ref.Kind)
+}
+
+// resolveGatewayTagPrefix resolves the tag prefix of a Gateway from the controller of its GatewayClass.
+func (r *stackResolver) resolveGatewayTagPrefix(ctx context.Context, key types.NamespacedName) (string, error) {
+	gw := &gwv1.Gateway{}
+	if err := r.k8sClient.Get(ctx, key, gw); err != nil {
+		return "", err
+	}
+	gwClass := &gwv1.GatewayClass{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gwClass); err != nil {
+		return "", errors.Wrapf(err, "failed to get gatewayClass of gateway %v", key)
+	}
+	switch string(gwClass.Spec.ControllerName) {
+	case gatewayconstants.ALBGatewayController:
+		return gatewayconstants.ALBGatewayTagPrefix, nil
+	case gatewayconstants.NLBGatewayController:
+		return gatewayconstants.NLBGatewayTagPrefix, nil
+	}
+	return "", errors.Errorf("gateway %v is not managed by the controller, gatewayClass %v has controllerName %v",
+		key, gwClass.Name, gwClass.Spec.ControllerName)
+}
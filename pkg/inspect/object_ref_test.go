@@ -0,0 +1,142 @@
+package inspect
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	agaapi "sigs.k8s.io/aws-load-balancer-controller/apis/aga/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestParseObjectKind(t *testing.T) {
+	tests := []struct {
+		rawKind string
+		want    ObjectKind
+		wantErr string
+	}{
+		{rawKind: "ing", want: ObjectKindIngress},
+		{rawKind: "Ingress", want: ObjectKindIngress},
+		{rawKind: "ingressgroup", want: ObjectKindIngressGroup},
+		{rawKind: "svc", want: ObjectKindService},
+		{rawKind: "gateways", want: ObjectKindGateway},
+		{rawKind: "ga", want: ObjectKindGlobalAccelerator},
+		{rawKind: "pod", wantErr: `unsupported kind "pod", must be one of: ingress, ingressgroup, service, gateway, globalaccelerator`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rawKind, func(t *testing.T) {
+			got, err := ParseObjectKind(tt.rawKind)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_stackResolver_resolve(t *testing.T) {
+	acceleratorARN := "arn:aws:globalaccelerator::123456789012:accelerator/abc"
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	agaapi.AddToScheme(k8sSchema)
+	gwv1.Install(k8sSchema)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		ref     ObjectRef
+		want    objectStack
+		wantErr string
+	}{
+		{
+			name: "ingressGroup",
+			ref:  ObjectRef{Kind: ObjectKindIngressGroup, Name: "awesome-group"},
+			want: objectStack{kind: ObjectKindIngressGroup, tagPrefix: "ingress.k8s.aws", stackID: core.StackID{Name: "awesome-group"}},
+		},
+		{
+			name: "service",
+			objects: []runtime.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}},
+			},
+			ref:  ObjectRef{Kind: ObjectKindService, Namespace: "ns", Name: "svc"},
+			want: objectStack{kind: ObjectKindService, tagPrefix: "service.k8s.aws", stackID: core.StackID{Namespace: "ns", Name: "svc"}},
+		},
+		{
+			name: "grouped service",
+			objects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ns",
+						Name:        "svc",
+						Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"},
+					},
+					Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: awssdk.String("service.k8s.aws/nlb")},
+				},
+			},
+			ref:  ObjectRef{Kind: ObjectKindService, Namespace: "ns", Name: "svc"},
+			want: objectStack{kind: ObjectKindService, tagPrefix: "service.k8s.aws", stackID: core.StackID{Name: "shared"}},
+		},
+		{
+			name: "nlb gateway",
+			objects: []runtime.Object{
+				&gwv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nlb"}, Spec: gwv1.GatewayClassSpec{ControllerName: "gateway.k8s.aws/nlb"}},
+				&gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw"}, Spec: gwv1.GatewaySpec{GatewayClassName: "nlb"}},
+			},
+			ref:  ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			want: objectStack{kind: ObjectKindGateway, tagPrefix: "gateway.k8s.aws.nlb", stackID: core.StackID{Namespace: "ns", Name: "gw"}},
+		},
+		{
+			name: "gateway of another controller",
+			objects: []runtime.Object{
+				&gwv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "istio"}, Spec: gwv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"}},
+				&gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gw"}, Spec: gwv1.GatewaySpec{GatewayClassName: "istio"}},
+			},
+			ref:     ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+			wantErr: "gateway ns/gw is not managed by the controller, gatewayClass istio has controllerName istio.io/gateway-controller",
+		},
+		{
+			name: "globalAccelerator",
+			objects: []runtime.Object{
+				&agaapi.GlobalAccelerator{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ga"},
+					Status:     agaapi.GlobalAcceleratorStatus{AcceleratorARN: &acceleratorARN},
+				},
+			},
+			ref:  ObjectRef{Kind: ObjectKindGlobalAccelerator, Namespace: "ns", Name: "ga"},
+			want: objectStack{kind: ObjectKindGlobalAccelerator, tagPrefix: "aga.k8s.aws", stackID: core.StackID{Namespace: "ns", Name: "ga"}, acceleratorARN: acceleratorARN},
+		},
+		{
+			name:    "service not found",
+			ref:     ObjectRef{Kind: ObjectKindService, Namespace: "ns", Name: "svc"},
+			wantErr: `services "svc" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithRuntimeObjects(tt.objects...).Build()
+			svcAnnotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
+			serviceUtils := service.NewServiceUtils(svcAnnotationParser, shared_constants.ServiceFinalizer, []string{"service.k8s.aws/nlb"}, config.NewFeatureGates())
+			r := &stackResolver{k8sClient: k8sClient, svcGroupLoader: service.NewDefaultGroupLoader(k8sClient, svcAnnotationParser, serviceUtils)}
+			got, err := r.resolve(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
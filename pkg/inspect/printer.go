@@ -0,0 +1,213 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OutputFormat is the format to print Report in.
+type OutputFormat string
+
+const (
+	OutputFormatTree OutputFormat = "tree"
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// Printer prints Report.
+type Printer interface {
+	Print(w io.Writer, report *Report) error
+}
+
+// NewPrinter constructs Printer for the output format.
+func NewPrinter(format OutputFormat) (Printer, error) {
+	switch format {
+	case OutputFormatTree:
+		return &treePrinter{}, nil
+	case OutputFormatJSON:
+		return &jsonPrinter{}, nil
+	}
+	return nil, errors.Errorf("unsupported output format %q, must be one of: %s, %s", format, OutputFormatTree, OutputFormatJSON)
+}
+
+// jsonPrinter prints Report as indented JSON for scripting.
+type jsonPrinter struct{}
+
+func (p *jsonPrinter) Print(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// treePrinter prints Report as a tree of load balancer -> listeners -> rules -> target groups -> targets.
+type treePrinter struct{}
+
+// treeNode is a line in the printed tree.
+type treeNode struct {
+	label    string
+	children []treeNode
+}
+
+func (p *treePrinter) Print(w io.Writer, report *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (stack: %s)\n", report.Object, report.StackID)
+	for _, warning := range report.Warnings {
+		fmt.Fprintf(&b, "WARNING: %s\n", warning)
+	}
+	var roots []treeNode
+	for _, lbNode := range report.LoadBalancers {
+		roots = append(roots, buildLoadBalancerTreeNode(lbNode))
+	}
+	if len(report.UnreferencedTargetGroups) != 0 {
+		node := treeNode{label: "Unreferenced TargetGroups"}
+		for _, tgNode := range report.UnreferencedTargetGroups {
+			node.children = append(node.children, buildTargetGroupTreeNode(tgNode))
+		}
+		roots = append(roots, node)
+	}
+	if report.Accelerator != nil {
+		roots = append(roots, buildAcceleratorTreeNode(*report.Accelerator))
+	}
+	if len(report.MissingResources) != 0 {
+		node := treeNode{label: "Missing Resources"}
+		for _, missing := range report.MissingResources {
+			node.children = append(node.children, treeNode{label: fmt.Sprintf("%s %s: %s", missing.Type, missing.ID, missing.Description)})
+		}
+		roots = append(roots, node)
+	}
+	if len(roots) == 0 {
+		b.WriteString("no AWS resources found\n")
+	}
+	for _, root := range roots {
+		b.WriteString(root.label + "\n")
+		writeTreeNodes(&b, root.children, "")
+	}
+	if report.DriftDetected {
+		b.WriteString("drift detected\n")
+	} else if report.DriftStatus == DriftStatusUnsupported {
+		fmt.Fprintf(&b, "drift detection is not supported for %s\n", report.Object.Kind)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTreeNodes writes nodes with box-drawing connectors, prefix is the indentation of their parent.
+func writeTreeNodes(b *strings.Builder, nodes []treeNode, prefix string) {
+	for i, node := range nodes {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+		b.WriteString(prefix + connector + node.label + "\n")
+		writeTreeNodes(b, node.children, prefix+childPrefix)
+	}
+}
+
+func buildLoadBalancerTreeNode(lbNode LoadBalancerNode) treeNode {
+	node := treeNode{
+		label: fmt.Sprintf("LoadBalancer %s (%s, %s, %s)", lbNode.Name, lbNode.Type, lbNode.Scheme, lbNode.State),
+		children: []treeNode{
+			{label: "arn: " + lbNode.ARN},
+			{label: "dns: " + lbNode.DNSName},
+		},
+	}
+	node.children = append(node.children, buildDriftTreeNodes(lbNode.Drifts)...)
+	for _, listenerNode := range lbNode.Listeners {
+		node.children = append(node.children, buildListenerTreeNode(listenerNode))
+	}
+	return node
+}
+
+func buildListenerTreeNode(listenerNode ListenerNode) treeNode {
+	node := treeNode{label: fmt.Sprintf("Listener %s:%d", listenerNode.Protocol, listenerNode.Port)}
+	node.children = append(node.children, buildDriftTreeNodes(listenerNode.Drifts)...)
+	for _, ruleNode := range listenerNode.Rules {
+		ruleTreeNode := treeNode{label: fmt.Sprintf("Rule %s [%s] => %s", ruleNode.Priority,
+			strings.Join(ruleNode.Conditions, " "), strings.Join(ruleNode.Actions, ","))}
+		ruleTreeNode.children = append(ruleTreeNode.children, buildDriftTreeNodes(ruleNode.Drifts)...)
+		for _, tgNode := range ruleNode.TargetGroups {
+			ruleTreeNode.children = append(ruleTreeNode.children, buildTargetGroupTreeNode(tgNode))
+		}
+		node.children = append(node.children, ruleTreeNode)
+	}
+	defaultTreeNode := treeNode{label: fmt.Sprintf("Default => %s", strings.Join(listenerNode.DefaultActions, ","))}
+	for _, tgNode := range listenerNode.DefaultTargetGroups {
+		defaultTreeNode.children = append(defaultTreeNode.children, buildTargetGroupTreeNode(tgNode))
+	}
+	node.children = append(node.children, defaultTreeNode)
+	return node
+}
+
+func buildTargetGroupTreeNode(tgNode TargetGroupNode) treeNode {
+	var attrs []string
+	if tgNode.TargetType != "" {
+		attrs = append(attrs, tgNode.TargetType)
+	}
+	if tgNode.Protocol != "" {
+		attrs = append(attrs, fmt.Sprintf("%s:%d", tgNode.Protocol, tgNode.Port))
+	}
+	if tgNode.Weight != nil {
+		attrs = append(attrs, fmt.Sprintf("weight %d", *tgNode.Weight))
+	}
+	name := tgNode.Name
+	if name == "" {
+		name = tgNode.ARN
+	}
+	node := treeNode{label: fmt.Sprintf("TargetGroup %s (%s)", name, strings.Join(attrs, ", "))}
+	node.children = append(node.children, buildDriftTreeNodes(tgNode.Drifts)...)
+	if len(tgNode.Targets) == 0 {
+		node.children = append(node.children, treeNode{label: "no registered targets"})
+	}
+	for _, targetNode := range tgNode.Targets {
+		label := fmt.Sprintf("%s:%d %s", targetNode.ID, targetNode.Port, targetNode.State)
+		if targetNode.AvailabilityZone != "" {
+			label = fmt.Sprintf("%s:%d (%s) %s", targetNode.ID, targetNode.Port, targetNode.AvailabilityZone, targetNode.State)
+		}
+		if targetNode.Reason != "" {
+			label = fmt.Sprintf("%s - %s: %s", label, targetNode.Reason, targetNode.Description)
+		}
+		node.children = append(node.children, treeNode{label: label})
+	}
+	return node
+}
+
+func buildAcceleratorTreeNode(acceleratorNode AcceleratorNode) treeNode {
+	node := treeNode{
+		label: fmt.Sprintf("Accelerator %s (%s, enabled: %t)", acceleratorNode.Name, acceleratorNode.Status, acceleratorNode.Enabled),
+		children: []treeNode{
+			{label: "arn: " + acceleratorNode.ARN},
+			{label: "dns: " + acceleratorNode.DNSName},
+		},
+	}
+	node.children = append(node.children, buildDriftTreeNodes(acceleratorNode.Drifts)...)
+	for _, listenerNode := range acceleratorNode.Listeners {
+		listenerTreeNode := treeNode{label: fmt.Sprintf("Listener %s:%s", listenerNode.Protocol, strings.Join(listenerNode.PortRanges, ","))}
+		listenerTreeNode.children = append(listenerTreeNode.children, buildDriftTreeNodes(listenerNode.Drifts)...)
+		for _, endpointGroupNode := range listenerNode.EndpointGroups {
+			endpointGroupTreeNode := treeNode{label: fmt.Sprintf("EndpointGroup %s (traffic dial %g%%)", endpointGroupNode.Region, endpointGroupNode.TrafficDialPercentage)}
+			endpointGroupTreeNode.children = append(endpointGroupTreeNode.children, buildDriftTreeNodes(endpointGroupNode.Drifts)...)
+			for _, endpointNode := range endpointGroupNode.Endpoints {
+				label := fmt.Sprintf("%s weight %d %s", endpointNode.ID, endpointNode.Weight, endpointNode.HealthState)
+				if endpointNode.HealthReason != "" {
+					label = fmt.Sprintf("%s - %s", label, endpointNode.HealthReason)
+				}
+				endpointTreeNode := treeNode{label: label, children: buildDriftTreeNodes(endpointNode.Drifts)}
+				endpointGroupTreeNode.children = append(endpointGroupTreeNode.children, endpointTreeNode)
+			}
+			listenerTreeNode.children = append(listenerTreeNode.children, endpointGroupTreeNode)
+		}
+		node.children = append(node.children, listenerTreeNode)
+	}
+	return node
+}
+
+func buildDriftTreeNodes(drifts []Drift) []treeNode {
+	var nodes []treeNode
+	for _, drift := range drifts {
+		nodes = append(nodes, treeNode{label: fmt.Sprintf("DRIFT %s: desired %s, live %s", drift.Field, drift.Desired, drift.Live)})
+	}
+	return nodes
+}
@@ -0,0 +1,124 @@
+package inspect
+
+import (
+	"bytes"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_treePrinter_Print(t *testing.T) {
+	tests := []struct {
+		name   string
+		report Report
+		want   string
+	}{
+		{
+			name: "no resources",
+			report: Report{
+				Object:      ObjectRef{Kind: ObjectKindGateway, Namespace: "ns", Name: "gw"},
+				StackID:     "ns/gw",
+				Warnings:    []string{"failed to build desired stack, drift is not reported: gatewayClass alb is not accepted"},
+				DriftStatus: DriftStatusUnavailable,
+			},
+			want: `gateway/ns/gw (stack: ns/gw)
+WARNING: failed to build desired stack, drift is not reported: gatewayClass alb is not accepted
+no AWS resources found
+`,
+		},
+		{
+			name: "load balancer with drift",
+			report: Report{
+				Object:  ObjectRef{Kind: ObjectKindIngressGroup, Name: "awesome-group"},
+				StackID: "awesome-group",
+				LoadBalancers: []LoadBalancerNode{
+					{
+						Name:    "k8s-awesomegroup",
+						ARN:     "lb-arn",
+						DNSName: "k8s-awesomegroup.elb.amazonaws.com",
+						Type:    "application",
+						Scheme:  "internet-facing",
+						State:   "active",
+						Listeners: []ListenerNode{
+							{
+								Port:           80,
+								Protocol:       "HTTP",
+								DefaultActions: []string{"fixed-response:404"},
+								Rules: []RuleNode{
+									{
+										Priority:   "1",
+										Conditions: []string{"path-pattern=/api"},
+										Actions:    []string{"forward"},
+										TargetGroups: []TargetGroupNode{
+											{
+												Name:       "k8s-ns-svc-abc",
+												TargetType: "ip",
+												Protocol:   "HTTP",
+												Port:       8080,
+												Weight:     awssdk.Int32(100),
+												Drifts:     []Drift{{Field: "port", Desired: "8080", Live: "9090"}},
+												Targets: []TargetNode{
+													{ID: "192.168.1.1", Port: 8080, AvailabilityZone: "us-west-2a", State: "healthy"},
+													{ID: "192.168.1.2", Port: 8080, State: "unhealthy", Reason: "Target.Timeout", Description: "Request timed out"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				MissingResources: []MissingResource{
+					{Type: "AWS::ElasticLoadBalancingV2::Listener", ID: "443", Description: "HTTPS:443 listener"},
+				},
+				DriftDetected: true,
+				DriftStatus:   DriftStatusDrifted,
+			},
+			want: `ingressgroup/awesome-group (stack: awesome-group)
+LoadBalancer k8s-awesomegroup (application, internet-facing, active)
+├── arn: lb-arn
+├── dns: k8s-awesomegroup.elb.amazonaws.com
+└── Listener HTTP:80
+    ├── Rule 1 [path-pattern=/api] => forward
+    │   └── TargetGroup k8s-ns-svc-abc (ip, HTTP:8080, weight 100)
+    │       ├── DRIFT port: desired 8080, live 9090
+    │       ├── 192.168.1.1:8080 (us-west-2a) healthy
+    │       └── 192.168.1.2:8080 unhealthy - Target.Timeout: Request timed out
+    └── Default => fixed-response:404
+Missing Resources
+└── AWS::ElasticLoadBalancingV2::Listener 443: HTTPS:443 listener
+drift detected
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer, err := NewPrinter(OutputFormatTree)
+			assert.NoError(t, err)
+			var buf bytes.Buffer
+			err = printer.Print(&buf, &tt.report)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func Test_jsonPrinter_Print(t *testing.T) {
+	printer, err := NewPrinter(OutputFormatJSON)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = printer.Print(&buf, &Report{
+		Object:      ObjectRef{Kind: ObjectKindService, Namespace: "ns", Name: "svc"},
+		StackID:     "ns/svc",
+		DriftStatus: DriftStatusInSync,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"object":{"kind":"service","namespace":"ns","name":"svc"},"stackID":"ns/svc","driftDetected":false,"driftStatus":"InSync"}`, buf.String())
+}
+
+func Test_NewPrinter(t *testing.T) {
+	_, err := NewPrinter("yaml")
+	assert.EqualError(t, err, `unsupported output format "yaml", must be one of: tree, json`)
+}
@@ -0,0 +1,163 @@
+package inspect
+
+// Report describes the AWS resources behind a Kubernetes object.
+type Report struct {
+	// Object is the inspected Kubernetes object.
+	Object ObjectRef `json:"object"`
+	// StackID is the ID of the stack that tracks the AWS resources of the object.
+	StackID string `json:"stackID"`
+
+	// LoadBalancers are the live load balancers of the stack.
+	LoadBalancers []LoadBalancerNode `json:"loadBalancers,omitempty"`
+	// UnreferencedTargetGroups are the live target groups of the stack that no listener forwards to.
+	UnreferencedTargetGroups []TargetGroupNode `json:"unreferencedTargetGroups,omitempty"`
+	// Accelerator is the live accelerator of a GlobalAccelerator object.
+	Accelerator *AcceleratorNode `json:"accelerator,omitempty"`
+
+	// MissingResources are resources in the desired stack that don't exist in AWS.
+	MissingResources []MissingResource `json:"missingResources,omitempty"`
+	// DriftDetected is whether the live resources differ from the desired stack.
+	// it's always false when the desired stack is not available.
+	DriftDetected bool `json:"driftDetected"`
+	// DriftStatus is the outcome of the drift detection, it tells an unsupported kind of object apart from a stack in sync.
+	DriftStatus DriftStatus `json:"driftStatus"`
+	// Warnings explains the parts of the report that are incomplete.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// DriftStatus is the outcome of the drift detection of a Report.
+type DriftStatus string
+
+const (
+	// DriftStatusInSync means the live resources match the desired stack.
+	DriftStatusInSync DriftStatus = "InSync"
+	// DriftStatusDrifted means the live resources differ from the desired stack.
+	DriftStatusDrifted DriftStatus = "Drifted"
+	// DriftStatusUnsupported means the desired stack isn't rebuilt for this kind of object.
+	DriftStatusUnsupported DriftStatus = "Unsupported"
+	// DriftStatusUnavailable means the desired stack failed to build.
+	DriftStatusUnavailable DriftStatus = "Unavailable"
+)
+
+// LoadBalancerNode describes a live load balancer.
+type LoadBalancerNode struct {
+	ResourceID string  `json:"resourceID,omitempty"`
+	ARN        string  `json:"arn"`
+	Name       string  `json:"name"`
+	DNSName    string  `json:"dnsName"`
+	Type       string  `json:"type"`
+	Scheme     string  `json:"scheme"`
+	State      string  `json:"state"`
+	Drifts     []Drift `json:"drifts,omitempty"`
+
+	Listeners []ListenerNode `json:"listeners,omitempty"`
+}
+
+// ListenerNode describes a live listener.
+type ListenerNode struct {
+	ARN      string  `json:"arn"`
+	Port     int32   `json:"port"`
+	Protocol string  `json:"protocol"`
+	Drifts   []Drift `json:"drifts,omitempty"`
+
+	// DefaultActions summarizes the default actions, such as forward or fixed-response:503.
+	DefaultActions []string `json:"defaultActions,omitempty"`
+	// DefaultTargetGroups are the target groups the default actions forward to.
+	DefaultTargetGroups []TargetGroupNode `json:"defaultTargetGroups,omitempty"`
+	Rules               []RuleNode        `json:"rules,omitempty"`
+}
+
+// RuleNode describes a live listener rule other than the default rule.
+type RuleNode struct {
+	ARN        string   `json:"arn"`
+	Priority   string   `json:"priority"`
+	Conditions []string `json:"conditions,omitempty"`
+	// Actions summarizes the rule actions, such as forward or fixed-response:503.
+	Actions []string `json:"actions,omitempty"`
+	Drifts  []Drift  `json:"drifts,omitempty"`
+
+	TargetGroups []TargetGroupNode `json:"targetGroups,omitempty"`
+}
+
+// TargetGroupNode describes a live target group and the health of its targets.
+type TargetGroupNode struct {
+	ResourceID string `json:"resourceID,omitempty"`
+	ARN        string `json:"arn"`
+	Name       string `json:"name"`
+	TargetType string `json:"targetType"`
+	Protocol   string `json:"protocol,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	// Weight is the weight of the target group in a forward action.
+	Weight *int32  `json:"weight,omitempty"`
+	Drifts []Drift `json:"drifts,omitempty"`
+
+	Targets []TargetNode `json:"targets,omitempty"`
+}
+
+// TargetNode describes a registered target and its health.
+type TargetNode struct {
+	ID               string `json:"id"`
+	Port             int32  `json:"port,omitempty"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	State            string `json:"state"`
+	Reason           string `json:"reason,omitempty"`
+	Description      string `json:"description,omitempty"`
+}
+
+// AcceleratorNode describes a live accelerator.
+type AcceleratorNode struct {
+	ARN     string  `json:"arn"`
+	Name    string  `json:"name"`
+	DNSName string  `json:"dnsName"`
+	Status  string  `json:"status"`
+	Enabled bool    `json:"enabled"`
+	Drifts  []Drift `json:"drifts,omitempty"`
+
+	Listeners []AcceleratorListenerNode `json:"listeners,omitempty"`
+}
+
+// AcceleratorListenerNode describes a live accelerator listener.
+type AcceleratorListenerNode struct {
+	ARN        string   `json:"arn"`
+	Protocol   string   `json:"protocol"`
+	PortRanges []string `json:"portRanges"`
+	Drifts     []Drift  `json:"drifts,omitempty"`
+
+	EndpointGroups []EndpointGroupNode `json:"endpointGroups,omitempty"`
+}
+
+// EndpointGroupNode describes a live accelerator endpoint group.
+type EndpointGroupNode struct {
+	ARN                   string  `json:"arn"`
+	Region                string  `json:"region"`
+	TrafficDialPercentage float32 `json:"trafficDialPercentage"`
+	Drifts                []Drift `json:"drifts,omitempty"`
+
+	Endpoints []EndpointNode `json:"endpoints,omitempty"`
+}
+
+// EndpointNode describes an accelerator endpoint and its health.
+type EndpointNode struct {
+	ID           string  `json:"id"`
+	Weight       int32   `json:"weight"`
+	HealthState  string  `json:"healthState"`
+	HealthReason string  `json:"healthReason,omitempty"`
+	Drifts       []Drift `json:"drifts,omitempty"`
+}
+
+// Drift describes a field whose live value differs from the desired stack.
+type Drift struct {
+	Field   string `json:"field"`
+	Desired string `json:"desired"`
+	Live    string `json:"live"`
+}
+
+// MissingResource describes a resource of the desired stack that doesn't exist in AWS.
+type MissingResource struct {
+	// Type is the resource type, such as AWS::ElasticLoadBalancingV2::Listener.
+	Type string `json:"type"`
+	// ID is the ID of the resource in the stack.
+	ID string `json:"id"`
+	// Description summarizes the desired resource.
+	Description string `json:"description"`
+}
@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ALBTargetControlAgentContainerName is the name of the injected ALB target control agent container.
+	ALBTargetControlAgentContainerName = "alb-target-control-agent"
+
+	// AnnotationKeyALBTargetControlAgentInjected is set to "true" on pods injected with the ALB target control agent.
+	AnnotationKeyALBTargetControlAgentInjected = "elbv2.k8s.aws/alb-target-control-agent-injected"
+	// AnnotationKeyALBTargetControlAgentConfigHash is the hash of the ALBTargetControlConfig the agent was injected from,
+	// set on injected pods and on the pod template of restarted workloads.
+	AnnotationKeyALBTargetControlAgentConfigHash = "elbv2.k8s.aws/alb-target-control-agent-config-hash"
+	// AnnotationKeyALBTargetControlAgentConfigNamespace is the namespace of the ALBTargetControlConfig the agent was injected from.
+	AnnotationKeyALBTargetControlAgentConfigNamespace = "elbv2.k8s.aws/alb-target-control-agent-config-namespace"
+)
+
+// IsALBTargetControlAgentContainerFailed returns whether the agent container fails to start or keeps restarting.
+func IsALBTargetControlAgentContainerFailed(status corev1.ContainerStatus) bool {
+	if status.State.Terminated != nil {
+		return true
+	}
+	if status.State.Waiting == nil {
+		return false
+	}
+	switch status.State.Waiting.Reason {
+	case "", "ContainerCreating", "PodInitializing":
+		return false
+	default:
+		return true
+	}
+}
+
+// FindALBTargetControlAgentContainerStatus returns the status of the agent container of the pod.
+func FindALBTargetControlAgentContainerStatus(pod *corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == ALBTargetControlAgentContainerName {
+			return status, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
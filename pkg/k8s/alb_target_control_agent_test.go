@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_IsALBTargetControlAgentContainerFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.ContainerStatus
+		want   bool
+	}{
+		{
+			name: "running",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			},
+			want: false,
+		},
+		{
+			name: "creating",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+			},
+			want: false,
+		},
+		{
+			name: "crash loop",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			},
+			want: true,
+		},
+		{
+			name: "image pull failure",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			},
+			want: true,
+		},
+		{
+			name: "terminated",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsALBTargetControlAgentContainerFailed(tt.status))
+		})
+	}
+}
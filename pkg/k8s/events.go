@@ -2,14 +2,16 @@ package k8s
 
 const (
 	// Ingress events
-	IngressEventReasonConflictingIngressClass = "ConflictingIngressClass"
-	IngressEventReasonFailedLoadGroupID       = "FailedLoadGroupID"
-	IngressEventReasonFailedAddFinalizer      = "FailedAddFinalizer"
-	IngressEventReasonFailedRemoveFinalizer   = "FailedRemoveFinalizer"
-	IngressEventReasonFailedUpdateStatus      = "FailedUpdateStatus"
-	IngressEventReasonFailedBuildModel        = "FailedBuildModel"
-	IngressEventReasonFailedDeployModel       = "FailedDeployModel"
-	IngressEventReasonSuccessfullyReconciled  = "SuccessfullyReconciled"
+	IngressEventReasonConflictingIngressClass        = "ConflictingIngressClass"
+	IngressEventReasonFailedLoadGroupID              = "FailedLoadGroupID"
+	IngressEventReasonFailedAddFinalizer             = "FailedAddFinalizer"
+	IngressEventReasonFailedRemoveFinalizer          = "FailedRemoveFinalizer"
+	IngressEventReasonFailedUpdateStatus             = "FailedUpdateStatus"
+	IngressEventReasonFailedBuildModel               = "FailedBuildModel"
+	IngressEventReasonFailedDeployModel              = "FailedDeployModel"
+	IngressEventReasonSuccessfullyReconciled         = "SuccessfullyReconciled"
+	IngressEventReasonGroupMemberRejected            = "GroupMemberRejected"
+	IngressEventReasonCertificateDiscoveryIncomplete = "CertificateDiscoveryIncomplete"
 
 	// Service events
 	ServiceEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
@@ -19,6 +21,7 @@ const (
 	ServiceEventReasonFailedBuildModel       = "FailedBuildModel"
 	ServiceEventReasonFailedDeployModel      = "FailedDeployModel"
 	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	ServiceEventReasonConflictingGroupPort   = "ConflictingGroupPort"
 
 	// TargetGroupBinding events
 	TargetGroupBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
@@ -29,6 +32,7 @@ const (
 	TargetGroupBindingEventReasonBackendNotFound        = "BackendNotFound"
 	TargetGroupBindingEventReasonFailedReconcile        = "FailedReconcile"
 	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	TargetGroupBindingEventReasonDuplicateQUICServerID  = "DuplicateQUICServerID"
 
 	// Gateway events
 	GatewayEventReasonFailedAddFinalizer             = "FailedAddFinalizer"
@@ -57,4 +61,30 @@ const (
 	GlobalAcceleratorEventReasonFailedDeploy           = "FailedDeploy"
 	GlobalAcceleratorEventReasonWarningEndpoints       = "WarningEndpoints"
 	GlobalAcceleratorEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+
+	// TrafficRollout events
+	TrafficRolloutEventReasonStarted         = "RolloutStarted"
+	TrafficRolloutEventReasonStepCompleted   = "RolloutStepCompleted"
+	TrafficRolloutEventReasonSucceeded       = "RolloutSucceeded"
+	TrafficRolloutEventReasonCheckFailed     = "RolloutCheckFailed"
+	TrafficRolloutEventReasonRolledBack      = "RolloutRolledBack"
+	TrafficRolloutEventReasonFailedReconcile = "FailedReconcile"
+
+	// WAFv2WebACL events
+	WAFv2WebACLEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
+	WAFv2WebACLEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
+	WAFv2WebACLEventReasonFailedReconcile        = "FailedReconcile"
+	WAFv2WebACLEventReasonFailedCleanup          = "FailedCleanup"
+	WAFv2WebACLEventReasonDeletionBlocked        = "DeletionBlocked"
+	WAFv2WebACLEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+
+	// ALBTargetControlConfig events
+	ALBTargetControlConfigEventReasonRolloutTriggered   = "RolloutTriggered"
+	ALBTargetControlConfigEventReasonFailedRollout      = "FailedRollout"
+	ALBTargetControlConfigEventReasonFailedUpdateStatus = "FailedUpdateStatus"
+
+	// QUICServerIDClaim events
+	QUICServerIDClaimEventReasonCollisionDetected = "CollisionDetected"
+	QUICServerIDClaimEventReasonCollisionRepaired = "CollisionRepaired"
+	QUICServerIDClaimEventReasonFailedRepair      = "FailedRepair"
 )
@@ -16,6 +16,10 @@ import (
 
 const (
 	annotationKeyPodENIInfo = "vpc.amazonaws.com/pod-eni"
+
+	// AnnotationKeyPodTerminationDeleteOptions records the options of a pod deletion held by the termination gate,
+	// so that they are applied when the pod is deleted once its targets are drained.
+	AnnotationKeyPodTerminationDeleteOptions = "elbv2.k8s.aws/pod-termination-delete-options"
 )
 
 // PodInfo contains simplified pod information we care about.
@@ -35,12 +39,45 @@ type PodInfo struct {
 	DefaultQUICServerID *string
 	// PerPortServerIds a mapping of container port to its respective quic server id
 	PerPortServerIds map[int32]string
+	// QUICServerIDs all quic server ids of the pod, including the ones of regular init containers.
+	QUICServerIDs []string
 
 	ENIInfos []PodENIInfo
+
+	// TargetControlAgent the injected ALB target control agent, nil if the pod has none.
+	TargetControlAgent *PodTargetControlAgentInfo
+
+	// TerminationDeleteOptions the options of the pod deletion held by the termination gate, nil if none were recorded.
+	TerminationDeleteOptions *PodDeleteOptions
 }
 
 var _ v1.ObjectMetaAccessor = &PodInfo{}
 
+// PodTargetControlAgentInfo contains the ALB target control agent information of a pod.
+type PodTargetControlAgentInfo struct {
+	// ConfigNamespace is the namespace of the ALBTargetControlConfig the agent was injected from.
+	ConfigNamespace string
+
+	// ConfigHash is the hash of the ALBTargetControlConfig the agent was injected from.
+	ConfigHash string
+
+	// Ready is whether the agent container is ready.
+	Ready bool
+
+	// Failed is whether the agent container fails to start or keeps restarting.
+	Failed bool
+}
+
+// PodDeleteOptions is a json convertible structure that stores the options of a pod deletion
+// which must be preserved when the deletion is retried.
+type PodDeleteOptions struct {
+	// GracePeriodSeconds is the grace period of the deletion.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// PropagationPolicy is the propagation policy of the deletion.
+	PropagationPolicy *v1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+}
+
 // PodENIInfo is a json convertible structure that stores the Branch ENI details that can be
 // used by the CNI plugin or the component consuming the resource
 // This struct is a subset of the fields found here: https://github.com/aws/amazon-vpc-resource-controller-k8s/blob/master/pkg/provider/branch/trunk/trunk.go?#L134
@@ -148,13 +185,17 @@ func (podInfoBuilder *podInfoBuilder) buildPodInfo(pod *corev1.Pod) PodInfo {
 	var containerPorts []corev1.ContainerPort
 	var defaultQUICServerID *string
 	var perPortQUICServerIDs map[int32]string
+	var quicServerIDs []string
 
 	allContainers := make([]corev1.Container, 0)
 	allContainers = append(allContainers, pod.Spec.Containers...)
 	// also support sidecar container (initContainer with restartPolicy=Always)
+	var regularInitContainers []corev1.Container
 	for _, initContainer := range pod.Spec.InitContainers {
 		if initContainer.RestartPolicy != nil && *initContainer.RestartPolicy == corev1.ContainerRestartPolicyAlways {
 			allContainers = append(allContainers, initContainer)
+		} else {
+			regularInitContainers = append(regularInitContainers, initContainer)
 		}
 	}
 
@@ -162,6 +203,7 @@ func (podInfoBuilder *podInfoBuilder) buildPodInfo(pod *corev1.Pod) PodInfo {
 		containerPorts = append(containerPorts, podContainer.Ports...)
 		extractedId := podInfoBuilder.extractQUICServerID(pod, podContainer)
 		if extractedId != nil {
+			quicServerIDs = append(quicServerIDs, *extractedId)
 			if perPortQUICServerIDs == nil {
 				perPortQUICServerIDs = make(map[int32]string)
 			}
@@ -173,6 +215,12 @@ func (podInfoBuilder *podInfoBuilder) buildPodInfo(pod *corev1.Pod) PodInfo {
 			}
 		}
 	}
+	// regular init containers don't serve traffic, but the injector assigns them server ids all the same.
+	for _, initContainer := range regularInitContainers {
+		if extractedId := podInfoBuilder.extractQUICServerID(pod, initContainer); extractedId != nil {
+			quicServerIDs = append(quicServerIDs, *extractedId)
+		}
+	}
 
 	return PodInfo{
 		Key: podKey,
@@ -181,6 +229,7 @@ func (podInfoBuilder *podInfoBuilder) buildPodInfo(pod *corev1.Pod) PodInfo {
 		ContainerPorts:      containerPorts,
 		DefaultQUICServerID: defaultQUICServerID,
 		PerPortServerIds:    perPortQUICServerIDs,
+		QUICServerIDs:       quicServerIDs,
 		ReadinessGates:      pod.Spec.ReadinessGates,
 		Conditions:          pod.Status.Conditions,
 		NodeName:            pod.Spec.NodeName,
@@ -188,7 +237,44 @@ func (podInfoBuilder *podInfoBuilder) buildPodInfo(pod *corev1.Pod) PodInfo {
 		CreationTime:        pod.CreationTimestamp,
 
 		ENIInfos: podENIInfos,
+
+		TargetControlAgent: podInfoBuilder.buildPodTargetControlAgentInfo(pod),
+
+		TerminationDeleteOptions: podInfoBuilder.buildPodTerminationDeleteOptions(pod),
+	}
+}
+
+// buildPodTargetControlAgentInfo will construct PodTargetControlAgentInfo for given pod if the agent was injected.
+func (podInfoBuilder *podInfoBuilder) buildPodTargetControlAgentInfo(pod *corev1.Pod) *PodTargetControlAgentInfo {
+	if pod.Annotations[AnnotationKeyALBTargetControlAgentInjected] != "true" {
+		return nil
+	}
+	agentInfo := &PodTargetControlAgentInfo{
+		// agents injected before the config tracking annotations were added are attributed to the pod namespace.
+		ConfigNamespace: pod.Namespace,
+		ConfigHash:      pod.Annotations[AnnotationKeyALBTargetControlAgentConfigHash],
+	}
+	if configNamespace, ok := pod.Annotations[AnnotationKeyALBTargetControlAgentConfigNamespace]; ok {
+		agentInfo.ConfigNamespace = configNamespace
+	}
+	if status, ok := FindALBTargetControlAgentContainerStatus(pod); ok {
+		agentInfo.Ready = status.Ready
+		agentInfo.Failed = IsALBTargetControlAgentContainerFailed(status)
+	}
+	return agentInfo
+}
+
+// buildPodTerminationDeleteOptions will construct PodDeleteOptions for given pod if recorded, malformed options are ignored.
+func (podInfoBuilder *podInfoBuilder) buildPodTerminationDeleteOptions(pod *corev1.Pod) *PodDeleteOptions {
+	rawAnnotation, ok := pod.Annotations[AnnotationKeyPodTerminationDeleteOptions]
+	if !ok {
+		return nil
+	}
+	var deleteOptions PodDeleteOptions
+	if err := json.Unmarshal([]byte(rawAnnotation), &deleteOptions); err != nil {
+		return nil
 	}
+	return &deleteOptions
 }
 
 // buildPodENIInfo will construct PodENIInfo for given pod if any.
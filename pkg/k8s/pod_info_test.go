@@ -737,6 +737,86 @@ func Test_buildPodInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod with ALB target control agent",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "pod-1",
+						UID:       "podUID",
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/alb-target-control-agent-injected":         "true",
+							"elbv2.k8s.aws/alb-target-control-agent-config-hash":      "hash-1",
+							"elbv2.k8s.aws/alb-target-control-agent-config-namespace": "kube-system",
+						},
+						CreationTimestamp: metav1.Time{Time: timeNow},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name:  "app",
+								Ready: true,
+							},
+							{
+								Name:  "alb-target-control-agent",
+								Ready: false,
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: PodInfo{
+				Key:          types.NamespacedName{Namespace: "my-ns", Name: "pod-1"},
+				UID:          "podUID",
+				CreationTime: metav1.Time{Time: timeNow},
+				TargetControlAgent: &PodTargetControlAgentInfo{
+					ConfigNamespace: "kube-system",
+					ConfigHash:      "hash-1",
+					Ready:           false,
+					Failed:          true,
+				},
+			},
+		},
+		{
+			name: "pod with ALB target control agent injected before config tracking",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "pod-1",
+						UID:       "podUID",
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/alb-target-control-agent-injected": "true",
+						},
+						CreationTimestamp: metav1.Time{Time: timeNow},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name:  "alb-target-control-agent",
+								Ready: true,
+								State: corev1.ContainerState{
+									Running: &corev1.ContainerStateRunning{},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: PodInfo{
+				Key:          types.NamespacedName{Namespace: "my-ns", Name: "pod-1"},
+				UID:          "podUID",
+				CreationTime: metav1.Time{Time: timeNow},
+				TargetControlAgent: &PodTargetControlAgentInfo{
+					ConfigNamespace: "my-ns",
+					Ready:           true,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -746,6 +826,42 @@ func Test_buildPodInfo(t *testing.T) {
 	}
 }
 
+func Test_buildPodInfo_QUICServerIDs(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "pod-1",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-quic-enabled-containers": "setup,server,metrics",
+			},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:  "setup",
+					Ports: []corev1.ContainerPort{{ContainerPort: 8443}},
+					Env:   []corev1.EnvVar{{Name: "AWS_LBC_QUIC_SERVER_ID", Value: "AAAAAAAAAAI="}},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "server",
+					Ports: []corev1.ContainerPort{{ContainerPort: 443}},
+					Env:   []corev1.EnvVar{{Name: "AWS_LBC_QUIC_SERVER_ID", Value: "3q2+78r+ur4="}},
+				},
+				{
+					Name: "metrics",
+					Env:  []corev1.EnvVar{{Name: "AWS_LBC_QUIC_SERVER_ID", Value: "AAAAAAAAAAE="}},
+				},
+			},
+		},
+	}
+	got := newPodInfoBuilder("AWS_LBC_QUIC_SERVER_ID").buildPodInfo(pod)
+	assert.Equal(t, "0xdeadbeefcafebabe", *got.DefaultQUICServerID)
+	assert.Equal(t, map[int32]string{443: "0xdeadbeefcafebabe"}, got.PerPortServerIds)
+	assert.Equal(t, []string{"0xdeadbeefcafebabe", "0x0000000000000001", "0x0000000000000002"}, got.QUICServerIDs)
+}
+
 func Test_buildPodENIInfo(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
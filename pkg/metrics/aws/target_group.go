@@ -9,18 +9,34 @@ import (
 
 const (
 	metricTargetGroupBinding = "target_group_info"
+	metricTargetGroupTargets = "target_group_targets"
 
 	labelNamespace = "namespace"
 	// Name matches label when importing target group metrics using cloudwatch_exporter
-	labelTargetGroup = "target_group"
+	labelTargetGroup        = "target_group"
+	labelTargetGroupBinding = "target_group_binding"
+	labelTargetHealthState  = "state"
+)
+
+const (
+	targetHealthStateHealthy = "healthy"
+	// unhealthy targets include targets in unavailable state.
+	targetHealthStateUnhealthy = "unhealthy"
+	targetHealthStateDraining  = "draining"
+	targetHealthStateInitial   = "initial"
 )
 
 type TargetGroupCollector interface {
 	RegisterTargetGroupBinding(resTGB *elbv2api.TargetGroupBinding)
 	DeRegisterTargetGroupBinding(resTGB *elbv2api.TargetGroupBinding)
+	// ObserveTargetHealth records the number of targets per health state for the TargetGroupBinding.
+	ObserveTargetHealth(resTGB *elbv2api.TargetGroupBinding, targetHealth elbv2api.TargetHealthSummary)
+	// DeleteTargetHealth removes the target health metrics for the TargetGroupBinding.
+	DeleteTargetHealth(resTGB *elbv2api.TargetGroupBinding)
 }
 type collector struct {
-	infoMetric *prometheus.GaugeVec
+	infoMetric    *prometheus.GaugeVec
+	targetsMetric *prometheus.GaugeVec
 }
 
 type noOpCollector struct{}
@@ -29,11 +45,19 @@ func (n noOpCollector) RegisterTargetGroupBinding(_ *elbv2api.TargetGroupBinding
 
 func (n noOpCollector) DeRegisterTargetGroupBinding(_ *elbv2api.TargetGroupBinding) {}
 
+func (n noOpCollector) ObserveTargetHealth(_ *elbv2api.TargetGroupBinding, _ elbv2api.TargetHealthSummary) {
+}
+
+func (n noOpCollector) DeleteTargetHealth(_ *elbv2api.TargetGroupBinding) {}
+
 func NewTargetGroupCollector(registerer prometheus.Registerer) TargetGroupCollector {
 	if registerer == nil {
 		return &noOpCollector{}
 	}
-	return &collector{registerTargetGroupInfoMetric(registerer)}
+	return &collector{
+		infoMetric:    registerTargetGroupInfoMetric(registerer),
+		targetsMetric: registerTargetGroupTargetsMetric(registerer),
+	}
 }
 
 func registerTargetGroupInfoMetric(registerer prometheus.Registerer) *prometheus.GaugeVec {
@@ -48,6 +72,17 @@ func registerTargetGroupInfoMetric(registerer prometheus.Registerer) *prometheus
 	return targetGroupInfo
 }
 
+func registerTargetGroupTargetsMetric(registerer prometheus.Registerer) *prometheus.GaugeVec {
+	targetGroupTargets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubSystem,
+		Name:      metricTargetGroupTargets,
+		Help:      "Number of targets in target group by health state, as observed by TargetGroupBinding",
+	}, []string{labelNamespace, labelTargetGroupBinding, labelTargetGroup, labelTargetHealthState})
+
+	registerer.MustRegister(targetGroupTargets)
+	return targetGroupTargets
+}
+
 func (c *collector) RegisterTargetGroupBinding(resTGB *elbv2api.TargetGroupBinding) {
 	c.infoMetric.With(getLabelsForTargetGroupBinding(resTGB)).Set(1)
 }
@@ -56,14 +91,41 @@ func (c *collector) DeRegisterTargetGroupBinding(resTGB *elbv2api.TargetGroupBin
 	c.infoMetric.Delete(getLabelsForTargetGroupBinding(resTGB))
 }
 
+func (c *collector) ObserveTargetHealth(resTGB *elbv2api.TargetGroupBinding, targetHealth elbv2api.TargetHealthSummary) {
+	countByState := map[string]int32{
+		targetHealthStateHealthy:   targetHealth.Healthy,
+		targetHealthStateUnhealthy: targetHealth.Unhealthy,
+		targetHealthStateDraining:  targetHealth.Draining,
+		targetHealthStateInitial:   targetHealth.Initial,
+	}
+	for state, count := range countByState {
+		c.targetsMetric.With(prometheus.Labels{
+			labelNamespace:          resTGB.Namespace,
+			labelTargetGroupBinding: resTGB.Name,
+			labelTargetGroup:        getTargetGroupDimension(resTGB.Spec.TargetGroupARN),
+			labelTargetHealthState:  state,
+		}).Set(float64(count))
+	}
+}
+
+func (c *collector) DeleteTargetHealth(resTGB *elbv2api.TargetGroupBinding) {
+	c.targetsMetric.DeletePartialMatch(prometheus.Labels{
+		labelNamespace:          resTGB.Namespace,
+		labelTargetGroupBinding: resTGB.Name,
+	})
+}
+
 func getLabelsForTargetGroupBinding(resTGB *elbv2api.TargetGroupBinding) map[string]string {
 
-	// Extracting value of TargetGroup dimension in CloudWatch
-	// https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-cloudwatch-metrics.html#load-balancer-metric-dimensions-alb
-	targetGroup := resTGB.Spec.TargetGroupARN[strings.LastIndex(resTGB.Spec.TargetGroupARN, ":")+1:]
 	return map[string]string{
 		labelNamespace:   resTGB.Namespace,
 		labelService:     resTGB.Spec.ServiceRef.Name,
-		labelTargetGroup: targetGroup,
+		labelTargetGroup: getTargetGroupDimension(resTGB.Spec.TargetGroupARN),
 	}
 }
+
+// getTargetGroupDimension extracts value of TargetGroup dimension in CloudWatch
+// https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-cloudwatch-metrics.html#load-balancer-metric-dimensions-alb
+func getTargetGroupDimension(targetGroupARN string) string {
+	return targetGroupARN[strings.LastIndex(targetGroupARN, ":")+1:]
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_collector_ObserveTargetHealth(t *testing.T) {
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+		},
+	}
+	registry := prometheus.NewRegistry()
+	c := NewTargetGroupCollector(registry)
+
+	c.ObserveTargetHealth(tgb, elbv2api.TargetHealthSummary{Healthy: 3, Unhealthy: 1, Initial: 2})
+	assert.Equal(t, map[string]float64{
+		"draining":  0,
+		"healthy":   3,
+		"initial":   2,
+		"unhealthy": 1,
+	}, gatherTargetsByState(t, registry, tgb))
+
+	c.DeleteTargetHealth(tgb)
+	assert.Empty(t, gatherTargetsByState(t, registry, tgb))
+}
+
+// gatherTargetsByState returns the value of aws_target_group_targets for tgb by health state.
+func gatherTargetsByState(t *testing.T, registry *prometheus.Registry, tgb *elbv2api.TargetGroupBinding) map[string]float64 {
+	metricFamilies, err := registry.Gather()
+	assert.NoError(t, err)
+	targetsByState := make(map[string]float64)
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != "aws_target_group_targets" {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Equal(t, map[string]string{
+				labelNamespace:          tgb.Namespace,
+				labelTargetGroupBinding: tgb.Name,
+				labelTargetGroup:        "targetgroup/my-tg/73e2d6bc24d8a067",
+				labelTargetHealthState:  labels[labelTargetHealthState],
+			}, labels)
+			targetsByState[labels[labelTargetHealthState]] = metric.GetGauge().GetValue()
+		}
+	}
+	return targetsByState
+}
@@ -0,0 +1,57 @@
+package lbc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricCertificateDaysToExpiry tracks the number of days until certificates attached to managed listeners expire.
+	MetricCertificateDaysToExpiry = "certificate_days_to_expiry"
+)
+
+const (
+	labelCertificateARN = "certificate_arn"
+)
+
+// CertificateExpiryMetricsCollector exports metrics about the expiry of certificates attached to managed listeners.
+type CertificateExpiryMetricsCollector interface {
+	// ObserveCertificateDaysToExpiry records the number of days until the certificate expires for every certificateARN,
+	// certificates observed by a previous scan but missing from daysToExpiryByARN are dropped.
+	ObserveCertificateDaysToExpiry(daysToExpiryByARN map[string]float64)
+}
+
+type certificateExpiryMetricsCollector struct {
+	certificateDaysToExpiry *prometheus.GaugeVec
+}
+
+type noOpCertificateExpiryMetricsCollector struct{}
+
+func (n *noOpCertificateExpiryMetricsCollector) ObserveCertificateDaysToExpiry(_ map[string]float64) {
+}
+
+// NewCertificateExpiryMetricsCollector constructs new CertificateExpiryMetricsCollector.
+func NewCertificateExpiryMetricsCollector(registerer prometheus.Registerer) CertificateExpiryMetricsCollector {
+	if registerer == nil {
+		return &noOpCertificateExpiryMetricsCollector{}
+	}
+
+	certificateDaysToExpiry := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricCertificateDaysToExpiry,
+		Help:      "Number of days until certificates attached to managed listeners expire, negative once expired.",
+	}, []string{labelCertificateARN})
+
+	registerer.MustRegister(certificateDaysToExpiry)
+	return &certificateExpiryMetricsCollector{
+		certificateDaysToExpiry: certificateDaysToExpiry,
+	}
+}
+
+func (c *certificateExpiryMetricsCollector) ObserveCertificateDaysToExpiry(daysToExpiryByARN map[string]float64) {
+	c.certificateDaysToExpiry.Reset()
+	for certARN, daysToExpiry := range daysToExpiryByARN {
+		c.certificateDaysToExpiry.With(prometheus.Labels{
+			labelCertificateARN: certARN,
+		}).Set(daysToExpiry)
+	}
+}
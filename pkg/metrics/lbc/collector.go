@@ -25,6 +25,22 @@ type MetricCollector interface {
 	ObserveControllerReconcileLatency(controller string, stage string, fn func())
 	ObserveWebhookValidationError(webhookName string, errorType string)
 	ObserveWebhookMutationError(webhookName string, errorType string)
+	// ObserveCapacityReservation records the scheduled capacity reservation of a load balancer, next values are nil when no transition is scheduled.
+	ObserveCapacityReservation(lbARN string, capacityUnits int32, nextCapacityUnits *int32, nextTransitionTime *time.Time)
+	// DeleteCapacityReservation drops the scheduled capacity reservation of a load balancer.
+	DeleteCapacityReservation(lbARN string)
+	// ObserveALBTargetControlAgentPods records the number of injected, outdated and failed agent pods of an ALBTargetControlConfig.
+	ObserveALBTargetControlAgentPods(namespace string, name string, injected int, outdated int, failed int)
+	// DeleteALBTargetControlAgentPods drops the agent pod counts of an ALBTargetControlConfig.
+	DeleteALBTargetControlAgentPods(namespace string, name string)
+	// ObserveQUICTargetDuplicateServerId records a QUIC target not registered as its server id is already used in the target group.
+	ObserveQUICTargetDuplicateServerId(namespace string, tgbName string)
+	// ObserveQUICServerIDAllocationConflict records a generated QUIC server id discarded as it was already claimed.
+	ObserveQUICServerIDAllocationConflict(namespace string)
+	// ObserveQUICServerIDClaims records the number of QUIC server id claims per phase and the number of colliding pods of a namespace.
+	ObserveQUICServerIDClaims(namespace string, pending int, bound int, retained int, collidingPods int)
+	// DeleteQUICServerIDClaims drops the QUIC server id claim counts of a namespace.
+	DeleteQUICServerIDClaims(namespace string)
 	StartCollectTopTalkers(ctx context.Context)
 	StartCollectCacheSize(ctx context.Context)
 }
@@ -56,6 +72,30 @@ func (n *noOpCollector) ObserveWebhookMutationError(_ string, _ string) {
 func (n *noOpCollector) ObserveControllerCacheSize(_ string, _ int) {
 }
 
+func (n *noOpCollector) ObserveCapacityReservation(_ string, _ int32, _ *int32, _ *time.Time) {
+}
+
+func (n *noOpCollector) DeleteCapacityReservation(_ string) {
+}
+
+func (n *noOpCollector) ObserveALBTargetControlAgentPods(_ string, _ string, _ int, _ int, _ int) {
+}
+
+func (n *noOpCollector) DeleteALBTargetControlAgentPods(_ string, _ string) {
+}
+
+func (n *noOpCollector) ObserveQUICTargetDuplicateServerId(_ string, _ string) {
+}
+
+func (n *noOpCollector) ObserveQUICServerIDAllocationConflict(_ string) {
+}
+
+func (n *noOpCollector) ObserveQUICServerIDClaims(_ string, _ int, _ int, _ int, _ int) {
+}
+
+func (n *noOpCollector) DeleteQUICServerIDClaims(_ string) {
+}
+
 func (n *noOpCollector) ObserveControllerReconcileLatency(_ string, _ string, fn func()) {
 }
 
@@ -125,6 +165,88 @@ func (c *collector) ObserveWebhookMutationError(webhookName string, errorCategor
 	}).Inc()
 }
 
+func (c *collector) ObserveCapacityReservation(lbARN string, capacityUnits int32, nextCapacityUnits *int32, nextTransitionTime *time.Time) {
+	labels := prometheus.Labels{
+		labelLoadBalancer: lbARN,
+	}
+	c.instruments.capacityReservationUnits.With(labels).Set(float64(capacityUnits))
+	if nextCapacityUnits == nil || nextTransitionTime == nil {
+		c.instruments.capacityReservationNextUnits.Delete(labels)
+		c.instruments.capacityReservationNextTime.Delete(labels)
+		return
+	}
+	c.instruments.capacityReservationNextUnits.With(labels).Set(float64(*nextCapacityUnits))
+	c.instruments.capacityReservationNextTime.With(labels).Set(float64(nextTransitionTime.Unix()))
+}
+
+func (c *collector) DeleteCapacityReservation(lbARN string) {
+	labels := prometheus.Labels{
+		labelLoadBalancer: lbARN,
+	}
+	c.instruments.capacityReservationUnits.Delete(labels)
+	c.instruments.capacityReservationNextUnits.Delete(labels)
+	c.instruments.capacityReservationNextTime.Delete(labels)
+}
+
+func (c *collector) ObserveALBTargetControlAgentPods(namespace string, name string, injected int, outdated int, failed int) {
+	for state, count := range map[string]int{
+		ALBTargetControlAgentPodStateInjected: injected,
+		ALBTargetControlAgentPodStateOutdated: outdated,
+		ALBTargetControlAgentPodStateFailed:   failed,
+	} {
+		c.instruments.albTargetControlAgentPods.With(prometheus.Labels{
+			labelNamespace:     namespace,
+			labelName:          name,
+			labelAgentPodState: state,
+		}).Set(float64(count))
+	}
+}
+
+func (c *collector) DeleteALBTargetControlAgentPods(namespace string, name string) {
+	c.instruments.albTargetControlAgentPods.DeletePartialMatch(prometheus.Labels{
+		labelNamespace: namespace,
+		labelName:      name,
+	})
+}
+
+func (c *collector) ObserveQUICTargetDuplicateServerId(namespace string, tgbName string) {
+	c.instruments.quicTargetsDuplicateServerId.With(prometheus.Labels{
+		labelNamespace: namespace,
+		labelName:      tgbName,
+	}).Inc()
+}
+
+func (c *collector) ObserveQUICServerIDAllocationConflict(namespace string) {
+	c.instruments.quicServerIdAllocConflicts.With(prometheus.Labels{
+		labelNamespace: namespace,
+	}).Inc()
+}
+
+func (c *collector) ObserveQUICServerIDClaims(namespace string, pending int, bound int, retained int, collidingPods int) {
+	for phase, count := range map[string]int{
+		QUICServerIDClaimPhasePending:  pending,
+		QUICServerIDClaimPhaseBound:    bound,
+		QUICServerIDClaimPhaseRetained: retained,
+	} {
+		c.instruments.quicServerIdClaims.With(prometheus.Labels{
+			labelNamespace:  namespace,
+			labelClaimPhase: phase,
+		}).Set(float64(count))
+	}
+	c.instruments.quicServerIdCollidingPods.With(prometheus.Labels{
+		labelNamespace: namespace,
+	}).Set(float64(collidingPods))
+}
+
+func (c *collector) DeleteQUICServerIDClaims(namespace string) {
+	c.instruments.quicServerIdClaims.DeletePartialMatch(prometheus.Labels{
+		labelNamespace: namespace,
+	})
+	c.instruments.quicServerIdCollidingPods.Delete(prometheus.Labels{
+		labelNamespace: namespace,
+	})
+}
+
 func (c *collector) ObserveControllerCacheSize(resource string, count int) {
 	c.instruments.controllerCacheObjectCount.With(prometheus.Labels{
 		LabelResource: resource,
@@ -26,6 +26,22 @@ const (
 	MetricControllerTopTalkers = "controller_top_talkers"
 	// MetricQuicTargetMissingServerId tracks the total number of QUIC targets attempted to be registered without a generated server id.
 	MetricQuicTargetMissingServerId = "quic_target_missing_server_id"
+	// MetricCapacityReservationUnits tracks the scheduled capacity reservation in effect per load balancer.
+	MetricCapacityReservationUnits = "capacity_reservation_units"
+	// MetricCapacityReservationNextUnits tracks the scheduled capacity reservation after the next transition per load balancer.
+	MetricCapacityReservationNextUnits = "capacity_reservation_next_units"
+	// MetricCapacityReservationNextTransition tracks the time of the next scheduled capacity reservation transition per load balancer.
+	MetricCapacityReservationNextTransition = "capacity_reservation_next_transition_timestamp_seconds"
+	// MetricALBTargetControlAgentPods tracks the pods running the ALB target control agent per ALBTargetControlConfig and state.
+	MetricALBTargetControlAgentPods = "alb_target_control_agent_pods"
+	// MetricQuicTargetDuplicateServerId tracks the total number of QUIC targets not registered as their server id is already used in the target group.
+	MetricQuicTargetDuplicateServerId = "quic_target_duplicate_server_id"
+	// MetricQuicServerIdAllocationConflicts tracks the total number of generated QUIC server ids discarded as they were already claimed.
+	MetricQuicServerIdAllocationConflicts = "quic_server_id_allocation_conflicts"
+	// MetricQuicServerIdClaims tracks the QUIC server id claims per namespace and phase.
+	MetricQuicServerIdClaims = "quic_server_id_claims"
+	// MetricQuicServerIdCollidingPods tracks the pods sharing their QUIC server id with another pod per namespace.
+	MetricQuicServerIdCollidingPods = "quic_server_id_colliding_pods"
 )
 
 const (
@@ -36,6 +52,23 @@ const (
 	labelReconcileStage = "reconcile_stage"
 	labelWebhookName    = "webhook_name"
 	LabelResource       = "resource"
+	labelLoadBalancer   = "load_balancer_arn"
+	labelAgentPodState  = "state"
+	labelClaimPhase     = "phase"
+)
+
+// ALB target control agent pod states
+const (
+	ALBTargetControlAgentPodStateInjected = "injected"
+	ALBTargetControlAgentPodStateOutdated = "outdated"
+	ALBTargetControlAgentPodStateFailed   = "failed"
+)
+
+// QUIC server ID claim phases
+const (
+	QUICServerIDClaimPhasePending  = "pending"
+	QUICServerIDClaimPhaseBound    = "bound"
+	QUICServerIDClaimPhaseRetained = "retained"
 )
 
 type instruments struct {
@@ -47,6 +80,14 @@ type instruments struct {
 	webhookMutationFailure        *prometheus.CounterVec
 	controllerCacheObjectCount    *prometheus.GaugeVec
 	controllerReconcileTopTalkers *prometheus.GaugeVec
+	capacityReservationUnits      *prometheus.GaugeVec
+	capacityReservationNextUnits  *prometheus.GaugeVec
+	capacityReservationNextTime   *prometheus.GaugeVec
+	albTargetControlAgentPods     *prometheus.GaugeVec
+	quicTargetsDuplicateServerId  *prometheus.CounterVec
+	quicServerIdAllocConflicts    *prometheus.CounterVec
+	quicServerIdClaims            *prometheus.GaugeVec
+	quicServerIdCollidingPods     *prometheus.GaugeVec
 }
 
 // newInstruments allocates and register new metrics to registerer
@@ -101,7 +142,57 @@ func newInstruments(registerer prometheus.Registerer) *instruments {
 		Help:      "Counts the number of reconciliations triggered per resource",
 	}, []string{labelController, labelNamespace, labelName})
 
-	registerer.MustRegister(podReadinessFlipSeconds, controllerReconcileErrors, controllerReconcileStageDuration, webhookValidationFailure, webhookMutationFailure, controllerCacheObjectCount, controllerReconcileTopTalkers)
+	capacityReservationUnits := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricCapacityReservationUnits,
+		Help:      "Scheduled capacity reservation in effect, in capacity units.",
+	}, []string{labelLoadBalancer})
+
+	capacityReservationNextUnits := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricCapacityReservationNextUnits,
+		Help:      "Scheduled capacity reservation after the next transition, in capacity units.",
+	}, []string{labelLoadBalancer})
+
+	capacityReservationNextTime := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricCapacityReservationNextTransition,
+		Help:      "Unix time of the next scheduled capacity reservation transition.",
+	}, []string{labelLoadBalancer})
+
+	albTargetControlAgentPods := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricALBTargetControlAgentPods,
+		Help:      "Number of pods running the ALB target control agent, by ALBTargetControlConfig and state.",
+	}, []string{labelNamespace, labelName, labelAgentPodState})
+
+	quicTargetsDuplicateServerId := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricQuicTargetDuplicateServerId,
+		Help:      "tracks the total number of QUIC targets not registered as their server id is already used in the target group.",
+	}, []string{labelNamespace, labelName})
+
+	quicServerIdAllocConflicts := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricQuicServerIdAllocationConflicts,
+		Help:      "tracks the total number of generated QUIC server ids discarded as they were already claimed.",
+	}, []string{labelNamespace})
+
+	quicServerIdClaims := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricQuicServerIdClaims,
+		Help:      "Number of QUIC server id claims, by namespace and phase.",
+	}, []string{labelNamespace, labelClaimPhase})
+
+	quicServerIdCollidingPods := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricQuicServerIdCollidingPods,
+		Help:      "Number of pods sharing their QUIC server id with another pod, by namespace.",
+	}, []string{labelNamespace})
+
+	registerer.MustRegister(podReadinessFlipSeconds, controllerReconcileErrors, controllerReconcileStageDuration, webhookValidationFailure, webhookMutationFailure, controllerCacheObjectCount, controllerReconcileTopTalkers,
+		capacityReservationUnits, capacityReservationNextUnits, capacityReservationNextTime, albTargetControlAgentPods,
+		controllerQuicTargetMissingServerId, quicTargetsDuplicateServerId, quicServerIdAllocConflicts, quicServerIdClaims, quicServerIdCollidingPods)
 	return &instruments{
 		podReadinessFlipSeconds:       podReadinessFlipSeconds,
 		controllerReconcileErrors:     controllerReconcileErrors,
@@ -111,5 +202,13 @@ func newInstruments(registerer prometheus.Registerer) *instruments {
 		controllerCacheObjectCount:    controllerCacheObjectCount,
 		controllerReconcileTopTalkers: controllerReconcileTopTalkers,
 		quicTargetsMissingServerId:    controllerQuicTargetMissingServerId,
+		capacityReservationUnits:      capacityReservationUnits,
+		capacityReservationNextUnits:  capacityReservationNextUnits,
+		capacityReservationNextTime:   capacityReservationNextTime,
+		albTargetControlAgentPods:     albTargetControlAgentPods,
+		quicTargetsDuplicateServerId:  quicTargetsDuplicateServerId,
+		quicServerIdAllocConflicts:    quicServerIdAllocConflicts,
+		quicServerIdClaims:            quicServerIdClaims,
+		quicServerIdCollidingPods:     quicServerIdCollidingPods,
 	}
 }
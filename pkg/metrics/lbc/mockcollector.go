@@ -36,6 +36,35 @@ type MockCounterMetric struct {
 	errorType          string
 }
 
+// MockCapacityReservationMetric records a scheduled capacity reservation observation.
+type MockCapacityReservationMetric struct {
+	LoadBalancerARN    string
+	CapacityUnits      int32
+	NextCapacityUnits  *int32
+	NextTransitionTime *time.Time
+	Deleted            bool
+}
+
+// MockALBTargetControlAgentPodsMetric records an ALB target control agent pods observation.
+type MockALBTargetControlAgentPodsMetric struct {
+	Namespace string
+	Name      string
+	Injected  int
+	Outdated  int
+	Failed    int
+	Deleted   bool
+}
+
+// MockQUICServerIDClaimsMetric records a QUIC server id claims observation.
+type MockQUICServerIDClaimsMetric struct {
+	Namespace     string
+	Pending       int
+	Bound         int
+	Retained      int
+	CollidingPods int
+	Deleted       bool
+}
+
 func (m *MockCollector) ObservePodReadinessGateReady(namespace string, tgbName string, d time.Duration) {
 	m.recordHistogram(MetricPodReadinessGateReady, namespace, tgbName, d)
 }
@@ -75,6 +104,70 @@ func (m *MockCollector) ObserveWebhookMutationError(webhookName string, errorCat
 	})
 }
 
+func (m *MockCollector) ObserveCapacityReservation(lbARN string, capacityUnits int32, nextCapacityUnits *int32, nextTransitionTime *time.Time) {
+	m.Invocations[MetricCapacityReservationUnits] = append(m.Invocations[MetricCapacityReservationUnits], MockCapacityReservationMetric{
+		LoadBalancerARN:    lbARN,
+		CapacityUnits:      capacityUnits,
+		NextCapacityUnits:  nextCapacityUnits,
+		NextTransitionTime: nextTransitionTime,
+	})
+}
+
+func (m *MockCollector) DeleteCapacityReservation(lbARN string) {
+	m.Invocations[MetricCapacityReservationUnits] = append(m.Invocations[MetricCapacityReservationUnits], MockCapacityReservationMetric{
+		LoadBalancerARN: lbARN,
+		Deleted:         true,
+	})
+}
+
+func (m *MockCollector) ObserveALBTargetControlAgentPods(namespace string, name string, injected int, outdated int, failed int) {
+	m.Invocations[MetricALBTargetControlAgentPods] = append(m.Invocations[MetricALBTargetControlAgentPods], MockALBTargetControlAgentPodsMetric{
+		Namespace: namespace,
+		Name:      name,
+		Injected:  injected,
+		Outdated:  outdated,
+		Failed:    failed,
+	})
+}
+
+func (m *MockCollector) DeleteALBTargetControlAgentPods(namespace string, name string) {
+	m.Invocations[MetricALBTargetControlAgentPods] = append(m.Invocations[MetricALBTargetControlAgentPods], MockALBTargetControlAgentPodsMetric{
+		Namespace: namespace,
+		Name:      name,
+		Deleted:   true,
+	})
+}
+
+func (m *MockCollector) ObserveQUICTargetDuplicateServerId(namespace string, tgbName string) {
+	m.Invocations[MetricQuicTargetDuplicateServerId] = append(m.Invocations[MetricQuicTargetDuplicateServerId], MockCounterMetric{
+		labelNamespace: namespace,
+		labelName:      tgbName,
+	})
+}
+
+func (m *MockCollector) ObserveQUICServerIDAllocationConflict(namespace string) {
+	m.Invocations[MetricQuicServerIdAllocationConflicts] = append(m.Invocations[MetricQuicServerIdAllocationConflicts], MockCounterMetric{
+		labelNamespace: namespace,
+	})
+}
+
+func (m *MockCollector) ObserveQUICServerIDClaims(namespace string, pending int, bound int, retained int, collidingPods int) {
+	m.Invocations[MetricQuicServerIdClaims] = append(m.Invocations[MetricQuicServerIdClaims], MockQUICServerIDClaimsMetric{
+		Namespace:     namespace,
+		Pending:       pending,
+		Bound:         bound,
+		Retained:      retained,
+		CollidingPods: collidingPods,
+	})
+}
+
+func (m *MockCollector) DeleteQUICServerIDClaims(namespace string) {
+	m.Invocations[MetricQuicServerIdClaims] = append(m.Invocations[MetricQuicServerIdClaims], MockQUICServerIDClaimsMetric{
+		Namespace: namespace,
+		Deleted:   true,
+	})
+}
+
 func (m *MockCollector) ObserveControllerCacheSize(resource string, count int) {
 	m.Invocations[MetricControllerCacheObjectCount] = append(m.Invocations[MetricControllerCacheObjectCount], MockCounterMetric{
 		resource: resource,
@@ -166,6 +259,7 @@ func NewMockCollector() MetricCollector {
 	mockInvocations[MetricWebhookMutationFailure] = make([]interface{}, 0)
 	mockInvocations[MetricControllerCacheObjectCount] = make([]interface{}, 0)
 	mockInvocations[MetricControllerTopTalkers] = make([]interface{}, 0)
+	mockInvocations[MetricCapacityReservationUnits] = make([]interface{}, 0)
 
 	return &MockCollector{
 		Invocations: mockInvocations,
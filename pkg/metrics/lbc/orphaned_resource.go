@@ -0,0 +1,74 @@
+package lbc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricOrphanedResources tracks the number of orphaned AWS resources found by the last garbage collection scan.
+	MetricOrphanedResources = "orphaned_resources"
+	// MetricOrphanedStacksDeleted tracks the total number of orphaned stacks deleted by the garbage collector.
+	MetricOrphanedStacksDeleted = "orphaned_stacks_deleted_total"
+)
+
+const (
+	labelStackKind    = "stack_kind"
+	labelResourceType = "resource_type"
+)
+
+// OrphanedResourceMetricsCollector exports metrics about the orphaned AWS resource garbage collector.
+type OrphanedResourceMetricsCollector interface {
+	// ObserveOrphanedResources records the number of orphaned resources of resourceType belonging to stackKind.
+	ObserveOrphanedResources(stackKind string, resourceType string, count int)
+	// ObserveOrphanedStackDeleted records the deletion of an orphaned stack of stackKind.
+	ObserveOrphanedStackDeleted(stackKind string)
+}
+
+type orphanedResourceMetricsCollector struct {
+	orphanedResources     *prometheus.GaugeVec
+	orphanedStacksDeleted *prometheus.CounterVec
+}
+
+type noOpOrphanedResourceMetricsCollector struct{}
+
+func (n *noOpOrphanedResourceMetricsCollector) ObserveOrphanedResources(_ string, _ string, _ int) {}
+
+func (n *noOpOrphanedResourceMetricsCollector) ObserveOrphanedStackDeleted(_ string) {}
+
+// NewOrphanedResourceMetricsCollector constructs new OrphanedResourceMetricsCollector.
+func NewOrphanedResourceMetricsCollector(registerer prometheus.Registerer) OrphanedResourceMetricsCollector {
+	if registerer == nil {
+		return &noOpOrphanedResourceMetricsCollector{}
+	}
+
+	orphanedResources := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricOrphanedResources,
+		Help:      "Number of orphaned AWS resources found by the last garbage collection scan.",
+	}, []string{labelStackKind, labelResourceType})
+
+	orphanedStacksDeleted := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystem,
+		Name:      MetricOrphanedStacksDeleted,
+		Help:      "Counts the number of orphaned stacks deleted by the garbage collector.",
+	}, []string{labelStackKind})
+
+	registerer.MustRegister(orphanedResources, orphanedStacksDeleted)
+	return &orphanedResourceMetricsCollector{
+		orphanedResources:     orphanedResources,
+		orphanedStacksDeleted: orphanedStacksDeleted,
+	}
+}
+
+func (c *orphanedResourceMetricsCollector) ObserveOrphanedResources(stackKind string, resourceType string, count int) {
+	c.orphanedResources.With(prometheus.Labels{
+		labelStackKind:    stackKind,
+		labelResourceType: resourceType,
+	}).Set(float64(count))
+}
+
+func (c *orphanedResourceMetricsCollector) ObserveOrphanedStackDeleted(stackKind string) {
+	c.orphanedStacksDeleted.With(prometheus.Labels{
+		labelStackKind: stackKind,
+	}).Inc()
+}
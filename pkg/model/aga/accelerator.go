@@ -45,6 +45,11 @@ func (a *Accelerator) GetARNFromCRDStatus() string {
 	return ""
 }
 
+// GetCRDAnnotation returns the value of an annotation of the CRD.
+func (a *Accelerator) GetCRDAnnotation(key string) string {
+	return a.crd.Annotations[key]
+}
+
 // GetCRDUID returns the UID of the CRD for use as idempotency token.
 func (a *Accelerator) GetCRDUID() string {
 	return string(a.crd.UID)
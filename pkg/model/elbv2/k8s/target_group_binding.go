@@ -113,6 +113,10 @@ type TargetGroupBindingSpec struct {
 	// +optional
 	MultiClusterTargetGroup bool `json:"multiClusterTargetGroup,omitempty"`
 
+	// weightedRegistration registers a share of the Service endpoints proportional to weight.
+	// +optional
+	WeightedRegistration *elbv2api.WeightedRegistration `json:"weightedRegistration,omitempty"`
+
 	// TargetGroupProtocol is the Protocol of the TargetGroup. If unspecified, it will be automatically inferred.
 	// +optional
 	TargetGroupProtocol *elbv2.Protocol `json:"targetGroupProtocol,omitempty"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 )
 
@@ -149,10 +150,61 @@ const (
 
 // Information about a load balancer capacity reservation.
 type MinimumLoadBalancerCapacity struct {
-	// The Capacity Units Value.
+	// The Capacity Units Value, applies outside of scheduled windows.
+	CapacityUnits int32 `json:"capacityUnits"`
+
+	// Recurring windows during which a different capacity reservation applies.
+	Schedules []CapacityReservationSchedule `json:"schedules,omitempty"`
+
+	// Dated windows during which a different capacity reservation applies.
+	Calendar []CapacityReservationCalendarEntry `json:"calendar,omitempty"`
+
+	// The IANA time zone used to evaluate Schedules and Calendar, defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// CapacityReservationSchedule is a recurring capacity reservation window.
+type CapacityReservationSchedule struct {
+	// The name of the window.
+	Name string `json:"name,omitempty"`
+
+	// The cron expression at which the window opens.
+	Start string `json:"start"`
+
+	// The length of the window.
+	Duration metav1.Duration `json:"duration"`
+
+	// The Capacity Units Value during the window.
+	CapacityUnits int32 `json:"capacityUnits"`
+}
+
+// CapacityReservationCalendarEntry is a dated capacity reservation window.
+type CapacityReservationCalendarEntry struct {
+	// The name of the window.
+	Name string `json:"name,omitempty"`
+
+	// The local date and time at which the window opens, in the 2006-01-02T15:04 format.
+	Start string `json:"start"`
+
+	// The local date and time at which the window closes, in the 2006-01-02T15:04 format.
+	End string `json:"end"`
+
+	// The Capacity Units Value during the window.
 	CapacityUnits int32 `json:"capacityUnits"`
 }
 
+// CapacityReservationStatus is the observed state of a scheduled capacity reservation.
+type CapacityReservationStatus struct {
+	// The Capacity Units Value in effect.
+	CurrentCapacityUnits int32 `json:"currentCapacityUnits"`
+
+	// The Capacity Units Value after the next scheduled transition.
+	NextCapacityUnits *int32 `json:"nextCapacityUnits,omitempty"`
+
+	// The time of the next scheduled transition.
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+}
+
 // LoadBalancerSpec defines the desired state of LoadBalancer
 type LoadBalancerSpec struct {
 	// The name of the load balancer.
@@ -216,4 +268,7 @@ type LoadBalancerStatus struct {
 
 	// The current state of the load balancer (active, provisioning, etc)
 	ProvisioningState *elbv2types.LoadBalancerState `json:"provisioningState"`
+
+	// The scheduled capacity reservation, only set when the capacity reservation has schedules or calendar entries.
+	CapacityReservation *CapacityReservationStatus `json:"capacityReservation,omitempty"`
 }
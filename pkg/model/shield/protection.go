@@ -27,8 +27,58 @@ func (p *Protection) registerDependencies(stack core.Stack) {
 	}
 }
 
+// ProtectionGroupAggregation defines how the metrics of a protection group's members are combined.
+type ProtectionGroupAggregation string
+
+const (
+	ProtectionGroupAggregationSum  ProtectionGroupAggregation = "SUM"
+	ProtectionGroupAggregationMean ProtectionGroupAggregation = "MEAN"
+	ProtectionGroupAggregationMax  ProtectionGroupAggregation = "MAX"
+)
+
+// ApplicationLayerAutomaticResponseAction defines the action of the rules Shield Advanced adds to mitigate application-layer attacks.
+type ApplicationLayerAutomaticResponseAction string
+
+const (
+	ApplicationLayerAutomaticResponseActionBlock ApplicationLayerAutomaticResponseAction = "Block"
+	ApplicationLayerAutomaticResponseActionCount ApplicationLayerAutomaticResponseAction = "Count"
+)
+
+// ProtectionGroup defines a protection group the resource belongs to.
+type ProtectionGroup struct {
+	ID          string                     `json:"id"`
+	Aggregation ProtectionGroupAggregation `json:"aggregation"`
+}
+
+// ProtectionGroups defines the protection groups the resource belongs to.
+// The resource is removed from the protection groups with the ARBITRARY pattern that aren't listed.
+type ProtectionGroups struct {
+	ProtectionGroups []ProtectionGroup `json:"protectionGroups"`
+}
+
+// HealthCheck defines the Route 53 health check associated with the protection, for health-based detection.
+type HealthCheck struct {
+	// HealthCheckARN is the ARN of the Route 53 health check, the health check is disassociated if empty.
+	HealthCheckARN string `json:"healthCheckARN"`
+}
+
+// ApplicationLayerAutomaticResponse defines the automatic application-layer DDoS mitigation of the protection.
+type ApplicationLayerAutomaticResponse struct {
+	Enabled bool                                    `json:"enabled"`
+	Action  ApplicationLayerAutomaticResponseAction `json:"action,omitempty"`
+}
+
 // ProtectionSpec defines the desired state of Protection.
 type ProtectionSpec struct {
 	Enabled     bool             `json:"enabled"`
 	ResourceARN core.StringToken `json:"resourceARN"`
+
+	// The attributes below are left unchanged when nil.
+
+	// +optional
+	ProtectionGroups *ProtectionGroups `json:"protectionGroups,omitempty"`
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+	// +optional
+	ApplicationLayerAutomaticResponse *ApplicationLayerAutomaticResponse `json:"applicationLayerAutomaticResponse,omitempty"`
 }
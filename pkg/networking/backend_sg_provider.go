@@ -83,7 +83,7 @@ func NewBackendSGProvider(clusterName string, backendSG string, vpcID string,
 
 		checkServiceFinalizersFunc: func(finalizers []string) bool {
 			for _, fin := range finalizers {
-				if fin == shared_constants.ServiceFinalizer {
+				if fin == shared_constants.ServiceFinalizer || strings.HasPrefix(fin, shared_constants.ServiceGroupFinalizerPrefix) {
 					return true
 				}
 			}
@@ -12,6 +12,10 @@ type ENIInfo struct {
 
 	// SecurityGroups on ENI
 	SecurityGroups []string
+
+	// IsBranchENI indicates the ENI is a branch ENI, which supports a single pod with its own SecurityGroups
+	// when the SecurityGroups for pods feature of aws-vpc-cni is used.
+	IsBranchENI bool
 }
 
 func buildENIInfoViaENI(eni ec2types.NetworkInterface) ENIInfo {
@@ -22,6 +26,7 @@ func buildENIInfoViaENI(eni ec2types.NetworkInterface) ENIInfo {
 	return ENIInfo{
 		NetworkInterfaceID: awssdk.ToString(eni.NetworkInterfaceId),
 		SecurityGroups:     sgIDs,
+		IsBranchENI:        eni.InterfaceType == ec2types.NetworkInterfaceTypeBranch,
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -165,12 +166,14 @@ func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithPodEndpoint
 		if eniInfo.NetworkInterfaceID == hybridNetworkInterfaceID {
 			continue
 		}
-		sgID, err := m.resolveEndpointSGForENI(ctx, eniInfo)
+		sgIDs, err := m.resolveEndpointSGsForPodENI(ctx, eniInfo)
 		if err != nil {
 			return nil, err
 		}
 		pod := podByPodKey[podKey]
-		podsBySG[sgID] = append(podsBySG[sgID], pod)
+		for _, sgID := range sgIDs {
+			podsBySG[sgID] = append(podsBySG[sgID], pod)
+		}
 	}
 
 	permissionsPerSG := make(map[string][]IPPermissionInfo, len(podsBySG))
@@ -246,6 +249,10 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 		}
 	}
 
+	if err := m.updateTGBEndpointSGsStatus(ctx, tgb, endpointSGs); err != nil {
+		sgReconciliationErrors = append(sgReconciliationErrors, err)
+	}
+
 	if len(sgReconciliationErrors) > 0 {
 		err := libErrors.Join(sgReconciliationErrors...)
 		return err
@@ -254,6 +261,25 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 	return nil
 }
 
+// updateTGBEndpointSGsStatus reports the endpoint SecurityGroups whose ingress rules are managed for the TargetGroupBinding in its status.
+func (m *defaultNetworkingManager) updateTGBEndpointSGsStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpointSGs []string) error {
+	if !tgb.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if len(endpointSGs) == 0 {
+		endpointSGs = nil
+	}
+	if equality.Semantic.DeepEqual(tgb.Status.EndpointSecurityGroups, endpointSGs) {
+		return nil
+	}
+	tgbOld := tgb.DeepCopy()
+	tgb.Status.EndpointSecurityGroups = endpointSGs
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding endpoint securityGroups status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
 // consolidateIngressPermissionsPerSGByTGB will consolidate the ingressPermissionsPerSGByTGB based on all tgbs with networking rules in cluster.
 // returns whether we have all these TargetGroupBinding's ingressPermissionsPerSG computed.
 func (m *defaultNetworkingManager) consolidateIngressPermissionsPerSGByTGB(_ context.Context, tgbsWithNetworking map[types.NamespacedName]*elbv2api.TargetGroupBinding) bool {
@@ -542,6 +568,33 @@ func (m *defaultNetworkingManager) fetchTGBsWithNetworking(ctx context.Context)
 	return tgbWithNetworkingByKey, nil
 }
 
+// resolveEndpointSGsForPodENI will resolve the endpoint SecurityGroups for the ENI supporting a pod.
+// Pods using SecurityGroups for pods are supported by a branch ENI carrying the pod's own securityGroups, which are usually not
+// tagged with the cluster tag. Unless exactly one of them is tagged, all of the pod's securityGroups are endpoint SecurityGroups.
+func (m *defaultNetworkingManager) resolveEndpointSGsForPodENI(ctx context.Context, eniInfo ENIInfo) ([]string, error) {
+	if !eniInfo.IsBranchENI {
+		sgID, err := m.resolveEndpointSGForENI(ctx, eniInfo)
+		if err != nil {
+			return nil, err
+		}
+		return []string{sgID}, nil
+	}
+	if len(eniInfo.SecurityGroups) == 0 {
+		return nil, errors.Errorf("expected at least one securityGroup for branch eni %v", eniInfo.NetworkInterfaceID)
+	}
+	if len(eniInfo.SecurityGroups) == 1 {
+		return eniInfo.SecurityGroups, nil
+	}
+	sgIDsWithMatchingEndpointSGTags, err := m.fetchSGIDsWithMatchingEndpointSGTags(ctx, eniInfo.SecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	if len(sgIDsWithMatchingEndpointSGTags) == 1 {
+		return sgIDsWithMatchingEndpointSGTags.List(), nil
+	}
+	return sets.NewString(eniInfo.SecurityGroups...).List(), nil
+}
+
 // resolveEndpointSGForENI will resolve the endpoint SecurityGroup for specific ENI.
 // If there are only a single securityGroup attached, that one will be the endpoint SecurityGroup.
 // If there are multiple securityGroup attached, we expect one and only one securityGroup is tagged with the cluster tag.
@@ -551,11 +604,32 @@ func (m *defaultNetworkingManager) resolveEndpointSGForENI(ctx context.Context,
 		return sgIDs[0], nil
 	}
 
-	sgInfoByID, err := m.sgManager.FetchSGInfosByID(ctx, sgIDs)
+	sgIDsWithMatchingEndpointSGTags, err := m.fetchSGIDsWithMatchingEndpointSGTags(ctx, sgIDs)
 	if err != nil {
 		return "", err
 	}
 	clusterResourceTagKey := fmt.Sprintf("kubernetes.io/cluster/%s", m.clusterName)
+	if len(sgIDsWithMatchingEndpointSGTags) != 1 {
+		// user may provide incorrect `--cluster-name` at bootstrap or modify the tag key unexpectedly, it is hard to find out if no clusterName included in error message.
+		// having `clusterName` included in error message might be helpful for shorten the troubleshooting time spent.
+		if len(m.serviceTargetENISGTags) == 0 {
+			return "", errors.Errorf("expected exactly one securityGroup tagged with %v for eni %v, got: %v (clusterName: %v)",
+				clusterResourceTagKey, eniInfo.NetworkInterfaceID, sgIDsWithMatchingEndpointSGTags.List(), m.clusterName)
+		}
+		return "", errors.Errorf("expected exactly one securityGroup tagged with %v and %v for eni %v, got: %v (clusterName: %v)",
+			clusterResourceTagKey, m.serviceTargetENISGTags, eniInfo.NetworkInterfaceID, sgIDsWithMatchingEndpointSGTags.List(), m.clusterName)
+	}
+	sgID, _ := sgIDsWithMatchingEndpointSGTags.PopAny()
+	return sgID, nil
+}
+
+// fetchSGIDsWithMatchingEndpointSGTags returns the securityGroups tagged with the cluster tag and the serviceTargetENISGTags.
+func (m *defaultNetworkingManager) fetchSGIDsWithMatchingEndpointSGTags(ctx context.Context, sgIDs []string) (sets.String, error) {
+	sgInfoByID, err := m.sgManager.FetchSGInfosByID(ctx, sgIDs)
+	if err != nil {
+		return nil, err
+	}
+	clusterResourceTagKey := fmt.Sprintf("kubernetes.io/cluster/%s", m.clusterName)
 	sgIDsWithMatchingEndpointSGTags := sets.NewString()
 	for sgID, sgInfo := range sgInfoByID {
 		if _, ok := sgInfo.Tags[clusterResourceTagKey]; ok {
@@ -571,19 +645,7 @@ func (m *defaultNetworkingManager) resolveEndpointSGForENI(ctx context.Context,
 			}
 		}
 	}
-
-	if len(sgIDsWithMatchingEndpointSGTags) != 1 {
-		// user may provide incorrect `--cluster-name` at bootstrap or modify the tag key unexpectedly, it is hard to find out if no clusterName included in error message.
-		// having `clusterName` included in error message might be helpful for shorten the troubleshooting time spent.
-		if len(m.serviceTargetENISGTags) == 0 {
-			return "", errors.Errorf("expected exactly one securityGroup tagged with %v for eni %v, got: %v (clusterName: %v)",
-				clusterResourceTagKey, eniInfo.NetworkInterfaceID, sgIDsWithMatchingEndpointSGTags.List(), m.clusterName)
-		}
-		return "", errors.Errorf("expected exactly one securityGroup tagged with %v and %v for eni %v, got: %v (clusterName: %v)",
-			clusterResourceTagKey, m.serviceTargetENISGTags, eniInfo.NetworkInterfaceID, sgIDsWithMatchingEndpointSGTags.List(), m.clusterName)
-	}
-	sgID, _ := sgIDsWithMatchingEndpointSGTags.PopAny()
-	return sgID, nil
+	return sgIDsWithMatchingEndpointSGTags, nil
 }
 
 // fetchEndpointSGs will return tracked endpoint SecurityGroups.
@@ -593,7 +655,12 @@ func (m *defaultNetworkingManager) fetchEndpointSGs(ctx context.Context) (sets.S
 		if err != nil {
 			return nil, err
 		}
+		tgbEndpointSGs, err := m.fetchEndpointSGsFromTGBStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
 		m.trackEndpointSGs(ctx, endpointSGs...)
+		m.trackEndpointSGs(ctx, tgbEndpointSGs...)
 		m.trackedEndpointSGsInitialized = true
 	}
 	return m.trackedEndpointSGs, nil
@@ -609,6 +676,21 @@ func (m *defaultNetworkingManager) unTrackEndpointSGs(_ context.Context, sgIDs .
 	m.trackedEndpointSGs.Delete(sgIDs...)
 }
 
+// fetchEndpointSGsFromTGBStatus will return the endpoint SecurityGroups reported in TargetGroupBinding status.
+// securityGroups of pods using SecurityGroups for pods are usually not tagged with the cluster tag, so they can only be
+// discovered from the TargetGroupBindings whose ingress rules were added to them.
+func (m *defaultNetworkingManager) fetchEndpointSGsFromTGBStatus(ctx context.Context) ([]string, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := m.k8sClient.List(ctx, tgbList); err != nil {
+		return nil, err
+	}
+	endpointSGs := sets.NewString()
+	for _, tgb := range tgbList.Items {
+		endpointSGs.Insert(tgb.Status.EndpointSecurityGroups...)
+	}
+	return endpointSGs.List(), nil
+}
+
 // fetchEndpointSGsFromAWS will return all endpoint securityGroups from AWS API.
 // we consider a securityGroup as a endpoint securityGroup if it have the cluster tag.
 // note: not all endpoint securityGroup have the cluster Tag(e.g. if a ENI only have a single securityGroup, it will still be used as endpoint securityGroup)
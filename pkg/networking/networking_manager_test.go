@@ -15,10 +15,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_defaultNetworkingManager_computeIngressPermissionsForTGBNetworking(t *testing.T) {
@@ -1777,6 +1780,178 @@ func Test_defaultNetworkingManager_resolveEndpointSGForENI(t *testing.T) {
 	}
 }
 
+func Test_defaultNetworkingManager_resolveEndpointSGsForPodENI(t *testing.T) {
+	type fetchSGInfosByIDCall struct {
+		req  []string
+		resp map[string]SecurityGroupInfo
+		err  error
+	}
+	tests := []struct {
+		name                  string
+		fetchSGInfosByIDCalls []fetchSGInfosByIDCall
+		eniInfo               ENIInfo
+		want                  []string
+		wantErr               string
+	}{
+		{
+			name: "primary or secondary ENI resolves the endpoint securityGroup",
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-a",
+				SecurityGroups:     []string{"sg-a"},
+			},
+			want: []string{"sg-a"},
+		},
+		{
+			name: "branch ENI with a single securityGroup",
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-branch",
+				SecurityGroups:     []string{"sg-pod"},
+				IsBranchENI:        true,
+			},
+			want: []string{"sg-pod"},
+		},
+		{
+			name: "branch ENI with exactly one securityGroup tagged with the cluster tag",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					req: []string{"sg-pod", "sg-cluster"},
+					resp: map[string]SecurityGroupInfo{
+						"sg-pod": {
+							SecurityGroupID: "sg-pod",
+						},
+						"sg-cluster": {
+							SecurityGroupID: "sg-cluster",
+							Tags: map[string]string{
+								"kubernetes.io/cluster/cluster-a": "owned",
+							},
+						},
+					},
+				},
+			},
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-branch",
+				SecurityGroups:     []string{"sg-pod", "sg-cluster"},
+				IsBranchENI:        true,
+			},
+			want: []string{"sg-cluster"},
+		},
+		{
+			name: "branch ENI with the pod's own securityGroups",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					req: []string{"sg-pod-b", "sg-pod-a"},
+					resp: map[string]SecurityGroupInfo{
+						"sg-pod-a": {
+							SecurityGroupID: "sg-pod-a",
+						},
+						"sg-pod-b": {
+							SecurityGroupID: "sg-pod-b",
+						},
+					},
+				},
+			},
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-branch",
+				SecurityGroups:     []string{"sg-pod-b", "sg-pod-a"},
+				IsBranchENI:        true,
+			},
+			want: []string{"sg-pod-a", "sg-pod-b"},
+		},
+		{
+			name: "branch ENI without securityGroups",
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-branch",
+				IsBranchENI:        true,
+			},
+			wantErr: "expected at least one securityGroup for branch eni eni-branch",
+		},
+		{
+			name: "branch ENI fails to fetch securityGroups",
+			fetchSGInfosByIDCalls: []fetchSGInfosByIDCall{
+				{
+					req: []string{"sg-pod-a", "sg-pod-b"},
+					err: errors.New("some error"),
+				},
+			},
+			eniInfo: ENIInfo{
+				NetworkInterfaceID: "eni-branch",
+				SecurityGroups:     []string{"sg-pod-a", "sg-pod-b"},
+				IsBranchENI:        true,
+			},
+			wantErr: "some error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sgManager := NewMockSecurityGroupManager(ctrl)
+			for _, call := range tt.fetchSGInfosByIDCalls {
+				sgManager.EXPECT().FetchSGInfosByID(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultNetworkingManager{
+				sgManager:   sgManager,
+				clusterName: "cluster-a",
+			}
+			got, err := m.resolveEndpointSGsForPodENI(context.Background(), tt.eniInfo)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultNetworkingManager_updateTGBEndpointSGsStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		tgb         *elbv2api.TargetGroupBinding
+		endpointSGs []string
+		want        []string
+	}{
+		{
+			name: "reports endpoint securityGroups",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "tgb-a", Namespace: "ns-a"},
+			},
+			endpointSGs: []string{"sg-a", "sg-pod"},
+			want:        []string{"sg-a", "sg-pod"},
+		},
+		{
+			name: "clears endpoint securityGroups once no longer modified",
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "tgb-a", Namespace: "ns-a"},
+				Status: elbv2api.TargetGroupBindingStatus{
+					EndpointSecurityGroups: []string{"sg-a", "sg-pod"},
+				},
+			},
+			endpointSGs: []string{},
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithStatusSubresource(tt.tgb).WithObjects(tt.tgb).Build()
+
+			m := &defaultNetworkingManager{
+				k8sClient: k8sClient,
+			}
+			assert.NoError(t, m.updateTGBEndpointSGsStatus(context.Background(), tt.tgb, tt.endpointSGs))
+
+			got := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(context.Background(), k8s.NamespacedName(tt.tgb), got))
+			assert.Equal(t, tt.want, got.Status.EndpointSecurityGroups)
+		})
+	}
+}
+
 func Test_AttemptGarbageCollection(t *testing.T) {
 	type fetchSGInfosByRequestCall struct {
 		resp map[string]SecurityGroupInfo
@@ -1871,6 +2046,36 @@ func Test_AttemptGarbageCollection(t *testing.T) {
 			},
 			expectedSgReconciles: sets.Set[string](sets.NewString("sg-a", "sg-b")),
 		},
+		{
+			name: "empty cache, tgb in cluster reports pod securityGroups in status",
+			fetchSGInfosByRequestCall: []fetchSGInfosByRequestCall{
+				{
+					resp: map[string]SecurityGroupInfo{
+						"sg-a": {
+							SecurityGroupID: "sg-a",
+							Tags: map[string]string{
+								"kubernetes.io/cluster/cluster-a": "owned",
+							},
+						},
+					},
+				},
+			},
+			tgbsInCluster: []*elbv2api.TargetGroupBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tgb-a",
+						Namespace: "ns-a",
+					},
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "arn:aws:elasticloadbalancing:us-east-1:565768096483:targetgroup/k8s-servicei-gatewaye-fbb5eb7cdd/de68ffdc8cbd5f76",
+					},
+					Status: elbv2api.TargetGroupBindingStatus{
+						EndpointSecurityGroups: []string{"sg-pod-a", "sg-pod-b"},
+					},
+				},
+			},
+			expectedSgReconciles: sets.Set[string](sets.NewString("sg-a", "sg-pod-a", "sg-pod-b")),
+		},
 		{
 			name: "empty cache, tgbs present in cluster, sg return call has data",
 			tgbsInCluster: []*elbv2api.TargetGroupBinding{
@@ -1219,6 +1219,7 @@ func Test_defaultPodENIInfoResolver_resolveViaPodENIAnnotation(t *testing.T) {
 							},
 							{
 								NetworkInterfaceId: awssdk.String("eni-b"),
+								InterfaceType:      ec2types.NetworkInterfaceTypeBranch,
 								Groups: []ec2types.GroupIdentifier{
 									{
 										GroupId: awssdk.String("sg-b-1"),
@@ -1299,6 +1300,7 @@ func Test_defaultPodENIInfoResolver_resolveViaPodENIAnnotation(t *testing.T) {
 				types.NamespacedName{Namespace: "default", Name: "pod-3"}: {
 					NetworkInterfaceID: "eni-b",
 					SecurityGroups:     []string{"sg-b-1"},
+					IsBranchENI:        true,
 				},
 			},
 		},
@@ -0,0 +1,229 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnalysisOutcome is the outcome of the checks of a rollout step.
+type AnalysisOutcome string
+
+const (
+	// AnalysisOutcomePassed means all checks passed.
+	AnalysisOutcomePassed AnalysisOutcome = "Passed"
+	// AnalysisOutcomeFailed means a check failed.
+	AnalysisOutcomeFailed AnalysisOutcome = "Failed"
+	// AnalysisOutcomeInconclusive means a check couldn't be evaluated yet, such as before canary targets are registered.
+	AnalysisOutcomeInconclusive AnalysisOutcome = "Inconclusive"
+)
+
+// AnalysisResult is the result of the checks of a rollout step.
+type AnalysisResult struct {
+	Outcome AnalysisOutcome
+	Message string
+}
+
+// Analyzer runs the checks gating the steps of a rollout.
+type Analyzer interface {
+	// Analyze runs the checks of rollout against its canary backend.
+	Analyze(ctx context.Context, rollout *elbv2api.TrafficRollout) (AnalysisResult, error)
+}
+
+// NewDefaultAnalyzer constructs new defaultAnalyzer.
+// allowedPrometheusAddresses are the Prometheus servers that rollouts are allowed to query, so that a
+// TrafficRollout can't make the controller send requests to arbitrary endpoints.
+func NewDefaultAnalyzer(k8sClient client.Client, httpClient *http.Client, allowedPrometheusAddresses []string) *defaultAnalyzer {
+	allowedAddresses := sets.NewString()
+	for _, address := range allowedPrometheusAddresses {
+		allowedAddresses.Insert(strings.TrimSuffix(address, "/"))
+	}
+	return &defaultAnalyzer{
+		k8sClient:                  k8sClient,
+		httpClient:                 httpClient,
+		allowedPrometheusAddresses: allowedAddresses,
+	}
+}
+
+var _ Analyzer = &defaultAnalyzer{}
+
+// default implementation for Analyzer.
+type defaultAnalyzer struct {
+	k8sClient                  client.Client
+	httpClient                 *http.Client
+	allowedPrometheusAddresses sets.String
+}
+
+func (a *defaultAnalyzer) Analyze(ctx context.Context, rollout *elbv2api.TrafficRollout) (AnalysisResult, error) {
+	analysis := rollout.Spec.Analysis
+	if analysis == nil {
+		return AnalysisResult{Outcome: AnalysisOutcomePassed}, nil
+	}
+	var messages []string
+	if analysis.TargetHealth != nil {
+		result, err := a.analyzeTargetHealth(ctx, rollout, *analysis.TargetHealth)
+		if err != nil || result.Outcome != AnalysisOutcomePassed {
+			return result, err
+		}
+		messages = append(messages, result.Message)
+	}
+	if analysis.Prometheus != nil {
+		result, err := a.analyzePrometheus(ctx, *analysis.Prometheus)
+		if err != nil || result.Outcome != AnalysisOutcomePassed {
+			return result, err
+		}
+		messages = append(messages, result.Message)
+	}
+	return AnalysisResult{Outcome: AnalysisOutcomePassed, Message: strings.Join(messages, "; ")}, nil
+}
+
+// analyzeTargetHealth checks the target health reported by the TargetGroupBindings of the canary backend.
+// Targets that are still registering or draining are inconclusive, and don't count towards the healthy percentage.
+func (a *defaultAnalyzer) analyzeTargetHealth(ctx context.Context, rollout *elbv2api.TrafficRollout, check elbv2api.TargetHealthAnalysis) (AnalysisResult, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := a.k8sClient.List(ctx, tgbList, client.InNamespace(rollout.Namespace)); err != nil {
+		return AnalysisResult{}, errors.Wrap(err, "failed to list TargetGroupBindings")
+	}
+	var healthy, total, initial int32
+	for _, tgb := range tgbList.Items {
+		if !BackendMatches(rollout.Spec.CanaryBackend, tgb.Spec.ServiceRef.Name, tgb.Spec.ServiceRef.Port) || tgb.Status.TargetHealth == nil {
+			continue
+		}
+		health := tgb.Status.TargetHealth
+		healthy += health.Healthy
+		total += health.Healthy + health.Unhealthy
+		initial += health.Initial
+	}
+	if total == 0 {
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeInconclusive,
+			Message: fmt.Sprintf("waiting for targets of canary backend %v:%v", rollout.Spec.CanaryBackend.Name, rollout.Spec.CanaryBackend.Port.String()),
+		}, nil
+	}
+	healthyPercent := healthy * 100 / total
+	if healthyPercent < check.MinHealthyPercent {
+		// registering targets may still turn healthy, so the check isn't failed until they settle.
+		if initial > 0 {
+			return AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: fmt.Sprintf("%v%% of canary targets are healthy, waiting for %v registering targets", healthyPercent, initial),
+			}, nil
+		}
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeFailed,
+			Message: fmt.Sprintf("%v%% of canary targets are healthy, below %v%%", healthyPercent, check.MinHealthyPercent),
+		}, nil
+	}
+	return AnalysisResult{
+		Outcome: AnalysisOutcomePassed,
+		Message: fmt.Sprintf("%v%% of canary targets are healthy", healthyPercent),
+	}, nil
+}
+
+// prometheusQueryResponse is the response of the Prometheus instant query API.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// analyzePrometheus checks the result of a Prometheus query against its bounds.
+// Failures to query Prometheus are inconclusive, so that an unavailable Prometheus holds the rollout instead of rolling it back.
+func (a *defaultAnalyzer) analyzePrometheus(ctx context.Context, check elbv2api.PrometheusAnalysis) (AnalysisResult, error) {
+	if !a.allowedPrometheusAddresses.Has(strings.TrimSuffix(check.Address, "/")) {
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeFailed,
+			Message: fmt.Sprintf("Prometheus address %v is not allowed by the controller", check.Address),
+		}, nil
+	}
+	value, err := a.queryPrometheus(ctx, check.Address, check.Query)
+	if err != nil {
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeInconclusive,
+			Message: fmt.Sprintf("failed to query Prometheus: %v", err),
+		}, nil
+	}
+	if check.Min != nil && value < check.Min.AsApproximateFloat64() {
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeFailed,
+			Message: fmt.Sprintf("query result %v is below %v", value, check.Min.String()),
+		}, nil
+	}
+	if check.Max != nil && value > check.Max.AsApproximateFloat64() {
+		return AnalysisResult{
+			Outcome: AnalysisOutcomeFailed,
+			Message: fmt.Sprintf("query result %v is above %v", value, check.Max.String()),
+		}, nil
+	}
+	return AnalysisResult{
+		Outcome: AnalysisOutcomePassed,
+		Message: fmt.Sprintf("query result %v is within bounds", value),
+	}, nil
+}
+
+func (a *defaultAnalyzer) queryPrometheus(ctx context.Context, address string, query string) (float64, error) {
+	queryURL := strings.TrimSuffix(address, "/") + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	var queryResp prometheusQueryResponse
+	if err := json.Unmarshal(body, &queryResp); err != nil {
+		return 0, errors.Errorf("unexpected response with status %v", resp.StatusCode)
+	}
+	if queryResp.Status != "success" {
+		return 0, errors.Errorf("query failed: %v", queryResp.Error)
+	}
+
+	// both scalar results and vector samples carry their value as [<timestamp>, "<value>"].
+	var sample []interface{}
+	switch queryResp.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(queryResp.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(queryResp.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) == 0 {
+			return 0, errors.New("query returned no samples")
+		}
+		sample = vector[0].Value
+	default:
+		return 0, errors.Errorf("unsupported result type %v", queryResp.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, errors.New("malformed sample")
+	}
+	rawValue, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("malformed sample value")
+	}
+	return strconv.ParseFloat(rawValue, 64)
+}
@@ -0,0 +1,225 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+)
+
+func Test_defaultAnalyzer_analyzeTargetHealth(t *testing.T) {
+	canaryTGB := func(name string, port intstr.IntOrString, health *elbv2api.TargetHealthSummary) *elbv2api.TargetGroupBinding {
+		return &elbv2api.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: elbv2api.TargetGroupBindingSpec{
+				TargetGroupARN: "arn",
+				ServiceRef:     elbv2api.ServiceReference{Name: "canary", Port: port},
+			},
+			Status: elbv2api.TargetGroupBindingStatus{TargetHealth: health},
+		}
+	}
+	tests := []struct {
+		name    string
+		tgbs    []*elbv2api.TargetGroupBinding
+		want    AnalysisResult
+		wantErr error
+	}{
+		{
+			name: "no targets yet",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), nil),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "waiting for targets of canary backend canary:80",
+			},
+		},
+		{
+			name: "enough healthy targets",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Healthy: 4, Unhealthy: 1}),
+				canaryTGB("tgb-2", intstr.FromInt32(8080), &elbv2api.TargetHealthSummary{Unhealthy: 5}),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomePassed,
+				Message: "80% of canary targets are healthy",
+			},
+		},
+		{
+			name: "not enough healthy targets",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Healthy: 2, Draining: 3}),
+				canaryTGB("tgb-2", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Unhealthy: 1}),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeFailed,
+				Message: "66% of canary targets are healthy, below 80%",
+			},
+		},
+		{
+			name: "registering targets are excluded from healthy percentage",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Healthy: 4, Initial: 6}),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomePassed,
+				Message: "100% of canary targets are healthy",
+			},
+		},
+		{
+			name: "not enough healthy targets while targets are registering",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Healthy: 1, Unhealthy: 1, Initial: 2}),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "50% of canary targets are healthy, waiting for 2 registering targets",
+			},
+		},
+		{
+			name: "only registering and draining targets",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				canaryTGB("tgb-1", intstr.FromInt32(80), &elbv2api.TargetHealthSummary{Initial: 2, Draining: 1}),
+			},
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "waiting for targets of canary backend canary:80",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testutils.GenerateTestClient()
+			for _, tgb := range tt.tgbs {
+				assert.NoError(t, k8sClient.Create(context.Background(), tgb.DeepCopy()))
+			}
+			a := NewDefaultAnalyzer(k8sClient, http.DefaultClient, nil)
+			rollout := &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout"},
+				Spec: elbv2api.TrafficRolloutSpec{
+					CanaryBackend: elbv2api.TrafficRolloutBackend{Name: "canary", Port: intstr.FromInt32(80)},
+				},
+			}
+			got, err := a.analyzeTargetHealth(context.Background(), rollout, elbv2api.TargetHealthAnalysis{MinHealthyPercent: 80})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultAnalyzer_analyzePrometheus(t *testing.T) {
+	tests := []struct {
+		name             string
+		allowedAddresses func(serverURL string) []string
+		responseCode     int
+		responseBody     string
+		min              *resource.Quantity
+		max              *resource.Quantity
+		want             AnalysisResult
+	}{
+		{
+			name:             "address not allowed",
+			allowedAddresses: func(_ string) []string { return []string{"http://prometheus.monitoring:9090"} },
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeFailed,
+				Message: "Prometheus address <server>/ is not allowed by the controller",
+			},
+		},
+		{
+			name:         "scalar within bounds",
+			responseCode: http.StatusOK,
+			responseBody: `{"status":"success","data":{"resultType":"scalar","result":[1700000000.1,"0.995"]}}`,
+			min:          resource.NewMilliQuantity(990, resource.DecimalSI),
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomePassed,
+				Message: "query result 0.995 is within bounds",
+			},
+		},
+		{
+			name:         "vector below min",
+			responseCode: http.StatusOK,
+			responseBody: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"0.9"]}]}}`,
+			min:          resource.NewMilliQuantity(990, resource.DecimalSI),
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeFailed,
+				Message: "query result 0.9 is below 990m",
+			},
+		},
+		{
+			name:         "vector above max",
+			responseCode: http.StatusOK,
+			responseBody: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.1,"12"]}]}}`,
+			max:          resource.NewQuantity(10, resource.DecimalSI),
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeFailed,
+				Message: "query result 12 is above 10",
+			},
+		},
+		{
+			name:         "empty vector",
+			responseCode: http.StatusOK,
+			responseBody: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "failed to query Prometheus: query returned no samples",
+			},
+		},
+		{
+			name:         "query error",
+			responseCode: http.StatusBadRequest,
+			responseBody: `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "failed to query Prometheus: query failed: parse error",
+			},
+		},
+		{
+			name:         "unexpected response",
+			responseCode: http.StatusBadGateway,
+			responseBody: `bad gateway`,
+			want: AnalysisResult{
+				Outcome: AnalysisOutcomeInconclusive,
+				Message: "failed to query Prometheus: unexpected response with status 502",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				assert.Equal(t, "sum(rate(success[1m]))", r.URL.Query().Get("query"))
+				w.WriteHeader(tt.responseCode)
+				fmt.Fprint(w, tt.responseBody)
+			}))
+			defer server.Close()
+
+			allowedAddresses := []string{server.URL}
+			if tt.allowedAddresses != nil {
+				allowedAddresses = tt.allowedAddresses(server.URL)
+			}
+			a := NewDefaultAnalyzer(testutils.GenerateTestClient(), server.Client(), allowedAddresses)
+			got, err := a.analyzePrometheus(context.Background(), elbv2api.PrometheusAnalysis{
+				Address: server.URL + "/",
+				Query:   "sum(rate(success[1m]))",
+				Min:     tt.min,
+				Max:     tt.max,
+			})
+			assert.NoError(t, err)
+			got.Message = strings.ReplaceAll(got.Message, server.URL, "<server>")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultAnalysisInterval is how often checks run when the rollout doesn't define an interval.
+	defaultAnalysisInterval = 30 * time.Second
+)
+
+// Manager progresses TrafficRollouts through their steps.
+type Manager interface {
+	// Reconcile progresses rollout by at most one step, and returns when it should be reconciled again.
+	// A zero duration means the rollout is finished or paused, and only needs to be reconciled again when it changes.
+	Reconcile(ctx context.Context, rollout *elbv2api.TrafficRollout) (time.Duration, error)
+}
+
+// NewDefaultManager constructs new defaultManager.
+func NewDefaultManager(k8sClient client.Client, analyzer Analyzer, eventRecorder record.EventRecorder, logger logr.Logger) *defaultManager {
+	return &defaultManager{
+		k8sClient:     k8sClient,
+		analyzer:      analyzer,
+		eventRecorder: eventRecorder,
+		logger:        logger,
+		clock:         time.Now,
+	}
+}
+
+var _ Manager = &defaultManager{}
+
+// default implementation for Manager.
+type defaultManager struct {
+	k8sClient     client.Client
+	analyzer      Analyzer
+	eventRecorder record.EventRecorder
+	logger        logr.Logger
+	clock         func() time.Time
+}
+
+func (m *defaultManager) Reconcile(ctx context.Context, rollout *elbv2api.TrafficRollout) (time.Duration, error) {
+	rolloutOld := rollout.DeepCopy()
+	requeueAfter, err := m.progress(ctx, rollout)
+	if err != nil {
+		return 0, err
+	}
+	if !equality.Semantic.DeepEqual(rolloutOld.Status, rollout.Status) {
+		if err := m.k8sClient.Status().Patch(ctx, rollout, client.MergeFrom(rolloutOld)); err != nil {
+			return 0, errors.Wrapf(err, "failed to update TrafficRollout status: %v", k8s.NamespacedName(rollout))
+		}
+	}
+	return requeueAfter, nil
+}
+
+// progress computes the next status of rollout in place.
+func (m *defaultManager) progress(ctx context.Context, rollout *elbv2api.TrafficRollout) (time.Duration, error) {
+	now := m.clock()
+	status := &rollout.Status
+	rolloutHash := ComputeRolloutHash(rollout.Spec)
+	// rollouts started before their hash was recorded keep running, unless their spec changed since.
+	if status.Phase != "" && status.RolloutHash == "" && status.ObservedGeneration == rollout.Generation {
+		status.RolloutHash = rolloutHash
+	}
+	status.ObservedGeneration = rollout.Generation
+	if status.Phase == "" || status.RolloutHash != rolloutHash {
+		m.startStep(rollout, 0, now)
+		status.RolloutHash = rolloutHash
+		status.Phase = elbv2api.TrafficRolloutPhaseProgressing
+		status.Message = ""
+		m.eventRecorder.Event(rollout, corev1.EventTypeNormal, k8s.TrafficRolloutEventReasonStarted,
+			fmt.Sprintf("Started rollout with %v%% of traffic to canary backend", status.CanaryWeight))
+		if rollout.Spec.Paused {
+			status.Phase = elbv2api.TrafficRolloutPhasePaused
+			return 0, nil
+		}
+		return firstCheckDelay(rollout, analysisInterval(rollout)), nil
+	}
+
+	switch status.Phase {
+	case elbv2api.TrafficRolloutPhaseSucceeded, elbv2api.TrafficRolloutPhaseRolledBack:
+		return 0, nil
+	case elbv2api.TrafficRolloutPhasePaused:
+		if rollout.Spec.Paused {
+			return 0, nil
+		}
+		status.Phase = elbv2api.TrafficRolloutPhaseProgressing
+		status.StepStartTime = &metav1.Time{Time: now}
+	case elbv2api.TrafficRolloutPhaseProgressing:
+		if rollout.Spec.Paused {
+			status.Phase = elbv2api.TrafficRolloutPhasePaused
+			return 0, nil
+		}
+	}
+
+	interval := analysisInterval(rollout)
+	result, err := m.analyzer.Analyze(ctx, rollout)
+	if err != nil {
+		return 0, err
+	}
+	status.Message = result.Message
+	switch result.Outcome {
+	case AnalysisOutcomeInconclusive:
+		return interval, nil
+	case AnalysisOutcomeFailed:
+		status.FailedChecks++
+		m.eventRecorder.Event(rollout, corev1.EventTypeWarning, k8s.TrafficRolloutEventReasonCheckFailed,
+			fmt.Sprintf("Check failed at step %v: %v", status.CurrentStep, result.Message))
+		if status.FailedChecks > failureLimit(rollout) {
+			status.Phase = elbv2api.TrafficRolloutPhaseRolledBack
+			status.CanaryWeight = 0
+			m.eventRecorder.Event(rollout, corev1.EventTypeWarning, k8s.TrafficRolloutEventReasonRolledBack,
+				fmt.Sprintf("Rolled back to stable backend after %v failed checks: %v", status.FailedChecks, result.Message))
+			return 0, nil
+		}
+		return interval, nil
+	}
+
+	status.FailedChecks = 0
+	step := rollout.Spec.Steps[status.CurrentStep]
+	if step.Pause != nil {
+		elapsed := now.Sub(status.StepStartTime.Time)
+		if remaining := step.Pause.Duration - elapsed; remaining > 0 {
+			return min(remaining, interval), nil
+		}
+	}
+
+	m.eventRecorder.Event(rollout, corev1.EventTypeNormal, k8s.TrafficRolloutEventReasonStepCompleted,
+		fmt.Sprintf("Completed step %v with %v%% of traffic to canary backend", status.CurrentStep, status.CanaryWeight))
+	nextStep := status.CurrentStep + 1
+	if int(nextStep) >= len(rollout.Spec.Steps) {
+		status.Phase = elbv2api.TrafficRolloutPhaseSucceeded
+		m.eventRecorder.Event(rollout, corev1.EventTypeNormal, k8s.TrafficRolloutEventReasonSucceeded,
+			fmt.Sprintf("Rollout succeeded with %v%% of traffic to canary backend", status.CanaryWeight))
+		return 0, nil
+	}
+	m.startStep(rollout, nextStep, now)
+	return firstCheckDelay(rollout, interval), nil
+}
+
+// ComputeRolloutHash computes the hash of the target, backends and steps of a rollout, the rollout restarts from the first step when it changes.
+// Other fields, such as paused or analysis, are excluded so that changing them doesn't reset the canary weight.
+func ComputeRolloutHash(spec elbv2api.TrafficRolloutSpec) string {
+	payload, _ := json.Marshal(elbv2api.TrafficRolloutSpec{
+		TargetRef:     spec.TargetRef,
+		StableBackend: spec.StableBackend,
+		CanaryBackend: spec.CanaryBackend,
+		Steps:         spec.Steps,
+	})
+	return algorithm.ComputeSha256(string(payload))
+}
+
+func (m *defaultManager) startStep(rollout *elbv2api.TrafficRollout, stepIndex int32, now time.Time) {
+	rollout.Status.CurrentStep = stepIndex
+	rollout.Status.CanaryWeight = rollout.Spec.Steps[stepIndex].Weight
+	rollout.Status.StepStartTime = &metav1.Time{Time: now}
+	rollout.Status.FailedChecks = 0
+}
+
+// firstCheckDelay returns when the current step should be checked first.
+// Steps without pause are checked after an interval, so that the new weight is deployed and observed before being analyzed.
+func firstCheckDelay(rollout *elbv2api.TrafficRollout, interval time.Duration) time.Duration {
+	step := rollout.Spec.Steps[rollout.Status.CurrentStep]
+	if step.Pause != nil {
+		return min(step.Pause.Duration, interval)
+	}
+	return interval
+}
+
+func analysisInterval(rollout *elbv2api.TrafficRollout) time.Duration {
+	if rollout.Spec.Analysis != nil && rollout.Spec.Analysis.Interval != nil && rollout.Spec.Analysis.Interval.Duration > 0 {
+		return rollout.Spec.Analysis.Interval.Duration
+	}
+	return defaultAnalysisInterval
+}
+
+func failureLimit(rollout *elbv2api.TrafficRollout) int32 {
+	if rollout.Spec.Analysis == nil {
+		return 0
+	}
+	return rollout.Spec.Analysis.FailureLimit
+}
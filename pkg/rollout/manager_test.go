@@ -0,0 +1,390 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeAnalyzer struct {
+	result AnalysisResult
+	calls  int
+}
+
+func (a *fakeAnalyzer) Analyze(_ context.Context, _ *elbv2api.TrafficRollout) (AnalysisResult, error) {
+	a.calls++
+	return a.result, nil
+}
+
+func Test_defaultManager_Reconcile(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stepStart := metav1.NewTime(now.Add(-2 * time.Minute))
+	spec := elbv2api.TrafficRolloutSpec{
+		TargetRef:     elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindIngress, Name: "ing"},
+		StableBackend: elbv2api.TrafficRolloutBackend{Name: "stable"},
+		CanaryBackend: elbv2api.TrafficRolloutBackend{Name: "canary"},
+		Steps: []elbv2api.TrafficRolloutStep{
+			{Weight: 10, Pause: &metav1.Duration{Duration: 5 * time.Minute}},
+			{Weight: 50, Pause: &metav1.Duration{Duration: time.Minute}},
+			{Weight: 100},
+		},
+		Analysis: &elbv2api.TrafficRolloutAnalysis{
+			Interval:     &metav1.Duration{Duration: time.Minute},
+			FailureLimit: 1,
+		},
+	}
+	specHash := ComputeRolloutHash(spec)
+	tests := []struct {
+		name             string
+		paused           bool
+		status           elbv2api.TrafficRolloutStatus
+		analysis         AnalysisResult
+		wantStatus       elbv2api.TrafficRolloutStatus
+		wantRequeueAfter time.Duration
+		wantAnalyzed     bool
+		wantEvents       int
+	}{
+		{
+			name: "new rollout starts the first step",
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+			wantRequeueAfter: time.Minute,
+			wantEvents:       1,
+		},
+		{
+			name: "changed rollout restarts from the first step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 1,
+				RolloutHash:        "outdated",
+				Phase:              elbv2api.TrafficRolloutPhaseSucceeded,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+				StepStartTime:      &stepStart,
+			},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+			wantRequeueAfter: time.Minute,
+			wantEvents:       1,
+		},
+		{
+			name: "step waits for its pause once analysis passes",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+				FailedChecks:       1,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomePassed, Message: "ok"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+				Message:            "ok",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+		},
+		{
+			name: "step completes after its pause",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomePassed, Message: "ok"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+				StepStartTime:      &metav1.Time{Time: now},
+				Message:            "ok",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+			wantEvents:       1,
+		},
+		{
+			name: "rollout succeeds after the last step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomePassed, Message: "ok"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseSucceeded,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+				StepStartTime:      &stepStart,
+				Message:            "ok",
+			},
+			wantAnalyzed: true,
+			wantEvents:   2,
+		},
+		{
+			name: "inconclusive analysis holds the step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomeInconclusive, Message: "waiting"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+				Message:            "waiting",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+		},
+		{
+			name: "failed analysis within failure limit",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomeFailed, Message: "unhealthy"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+				FailedChecks:       1,
+				Message:            "unhealthy",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+			wantEvents:       1,
+		},
+		{
+			name: "failed analysis beyond failure limit rolls back",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+				FailedChecks:       1,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomeFailed, Message: "unhealthy"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseRolledBack,
+				CurrentStep:        1,
+				StepStartTime:      &stepStart,
+				FailedChecks:       2,
+				Message:            "unhealthy",
+			},
+			wantAnalyzed: true,
+			wantEvents:   2,
+		},
+		{
+			name: "rolled back rollout stays rolled back",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseRolledBack,
+				CurrentStep:        1,
+				StepStartTime:      &stepStart,
+				FailedChecks:       2,
+			},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseRolledBack,
+				CurrentStep:        1,
+				StepStartTime:      &stepStart,
+				FailedChecks:       2,
+			},
+		},
+		{
+			name:   "paused rollout keeps its weight",
+			paused: true,
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+			},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhasePaused,
+				CanaryWeight:       10,
+				StepStartTime:      &stepStart,
+			},
+		},
+		{
+			name:   "pausing a rollout mid-rollout holds its weight",
+			paused: true,
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 1,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhasePaused,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+		},
+		{
+			name: "resuming a rollout mid-rollout continues its step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 1,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhasePaused,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomePassed, Message: "ok"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &metav1.Time{Time: now},
+				Message:            "ok",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+		},
+		{
+			name: "rollout started before its hash was recorded keeps its step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomeInconclusive, Message: "waiting"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+				Message:            "waiting",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+		},
+		{
+			name: "resumed rollout restarts the pause of its step",
+			status: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhasePaused,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &stepStart,
+			},
+			analysis: AnalysisResult{Outcome: AnalysisOutcomePassed, Message: "ok"},
+			wantStatus: elbv2api.TrafficRolloutStatus{
+				ObservedGeneration: 2,
+				RolloutHash:        specHash,
+				Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				StepStartTime:      &metav1.Time{Time: now},
+				Message:            "ok",
+			},
+			wantRequeueAfter: time.Minute,
+			wantAnalyzed:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithStatusSubresource(&elbv2api.TrafficRollout{}).Build()
+			rollout := &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout"},
+				Spec:       *spec.DeepCopy(),
+			}
+			rollout.Spec.Paused = tt.paused
+			assert.NoError(t, k8sClient.Create(context.Background(), rollout))
+			rollout.Status = tt.status
+			assert.NoError(t, k8sClient.Status().Update(context.Background(), rollout))
+			// the fake client doesn't track generations, set it to simulate a spec update.
+			rollout.Generation = 2
+
+			analyzer := &fakeAnalyzer{result: tt.analysis}
+			eventRecorder := record.NewFakeRecorder(10)
+			m := NewDefaultManager(k8sClient, analyzer, eventRecorder, logr.Discard())
+			m.clock = func() time.Time { return now }
+
+			gotRequeueAfter, err := m.Reconcile(context.Background(), rollout)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRequeueAfter, gotRequeueAfter)
+			assert.Equal(t, tt.wantAnalyzed, analyzer.calls > 0)
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+
+			gotRollout := &elbv2api.TrafficRollout{}
+			assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "rollout"}, gotRollout))
+			assert.Equal(t, tt.wantStatus.Phase, gotRollout.Status.Phase)
+			assert.Equal(t, tt.wantStatus.ObservedGeneration, gotRollout.Status.ObservedGeneration)
+			assert.Equal(t, tt.wantStatus.RolloutHash, gotRollout.Status.RolloutHash)
+			assert.Equal(t, tt.wantStatus.CurrentStep, gotRollout.Status.CurrentStep)
+			assert.Equal(t, tt.wantStatus.CanaryWeight, gotRollout.Status.CanaryWeight)
+			assert.Equal(t, tt.wantStatus.FailedChecks, gotRollout.Status.FailedChecks)
+			assert.Equal(t, tt.wantStatus.Message, gotRollout.Status.Message)
+			assert.True(t, tt.wantStatus.StepStartTime.Equal(gotRollout.Status.StepStartTime))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package rollout
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultTotalWeight is the total weight split between stable and canary backends when the stable backend has no explicit weight.
+const DefaultTotalWeight int32 = 100
+
+// ListTrafficRollouts returns the TrafficRollouts targeting the resource of specified kind and name.
+func ListTrafficRollouts(ctx context.Context, k8sClient client.Client, kind elbv2api.TrafficRolloutTargetKind, namespace string, name string) ([]elbv2api.TrafficRollout, error) {
+	rolloutList := &elbv2api.TrafficRolloutList{}
+	if err := k8sClient.List(ctx, rolloutList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var rollouts []elbv2api.TrafficRollout
+	for _, rollout := range rolloutList.Items {
+		if rollout.Spec.TargetRef.Kind == kind && rollout.Spec.TargetRef.Name == name {
+			rollouts = append(rollouts, rollout)
+		}
+	}
+	return rollouts, nil
+}
+
+// CanaryWeight returns the percentage of traffic the rollout currently sends to the canary backend.
+// It returns false when the controller hasn't started the rollout for the current target, backends and steps yet,
+// in which case the target is left untouched.
+func CanaryWeight(rollout *elbv2api.TrafficRollout) (int32, bool) {
+	if rollout.Status.Phase == "" || rollout.Status.RolloutHash != ComputeRolloutHash(rollout.Spec) {
+		return 0, false
+	}
+	return rollout.Status.CanaryWeight, true
+}
+
+// IsHeaderRoutingActive tests whether requests matching the headerRouting of the rollout should be sent to the canary backend.
+func IsHeaderRoutingActive(rollout *elbv2api.TrafficRollout) bool {
+	if rollout.Spec.HeaderRouting == nil {
+		return false
+	}
+	if _, ok := CanaryWeight(rollout); !ok {
+		return false
+	}
+	return rollout.Status.Phase == elbv2api.TrafficRolloutPhaseProgressing || rollout.Status.Phase == elbv2api.TrafficRolloutPhasePaused
+}
+
+// SplitWeight splits the total weight of the stable backend between the stable and canary backends.
+func SplitWeight(total int32, canaryPercent int32) (int32, int32) {
+	canaryWeight := int32(int64(total) * int64(canaryPercent) / 100)
+	return total - canaryWeight, canaryWeight
+}
+
+// BackendMatches tests whether a Service port matches the rollout backend.
+// Ports are compared as written, so a backend referencing a port by name doesn't match a Service port referenced by number.
+func BackendMatches(backend elbv2api.TrafficRolloutBackend, svcName string, svcPort intstr.IntOrString) bool {
+	return backend.Name == svcName && backend.Port.String() == svcPort.String()
+}
@@ -0,0 +1,261 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+)
+
+func Test_ListTrafficRollouts(t *testing.T) {
+	rollouts := []*elbv2api.TrafficRollout{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout-1"},
+			Spec: elbv2api.TrafficRolloutSpec{
+				TargetRef: elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindIngress, Name: "ing"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout-2"},
+			Spec: elbv2api.TrafficRolloutSpec{
+				TargetRef: elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindHTTPRoute, Name: "ing"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rollout-3"},
+			Spec: elbv2api.TrafficRolloutSpec{
+				TargetRef: elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindIngress, Name: "other-ing"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other-ns", Name: "rollout-4"},
+			Spec: elbv2api.TrafficRolloutSpec{
+				TargetRef: elbv2api.TrafficRolloutTargetReference{Kind: elbv2api.TrafficRolloutTargetKindIngress, Name: "ing"},
+			},
+		},
+	}
+	k8sClient := testutils.GenerateTestClient()
+	for _, rollout := range rollouts {
+		assert.NoError(t, k8sClient.Create(context.Background(), rollout.DeepCopy()))
+	}
+
+	got, err := ListTrafficRollouts(context.Background(), k8sClient, elbv2api.TrafficRolloutTargetKindIngress, "ns", "ing")
+	assert.NoError(t, err)
+	var gotNames []string
+	for _, rollout := range got {
+		gotNames = append(gotNames, rollout.Name)
+	}
+	assert.Equal(t, []string{"rollout-1"}, gotNames)
+}
+
+func Test_CanaryWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		rollout    *elbv2api.TrafficRollout
+		wantWeight int32
+		wantOK     bool
+	}{
+		{
+			name: "rollout not started",
+			rollout: &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			},
+			wantOK: false,
+		},
+		{
+			name: "rollout started for an older spec",
+			rollout: &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: elbv2api.TrafficRolloutStatus{
+					ObservedGeneration: 1,
+					RolloutHash:        "outdated",
+					Phase:              elbv2api.TrafficRolloutPhaseSucceeded,
+					CanaryWeight:       100,
+				},
+			},
+			wantOK: false,
+		},
+		{
+			name: "rollout progressing",
+			rollout: &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: elbv2api.TrafficRolloutStatus{
+					ObservedGeneration: 2,
+					RolloutHash:        ComputeRolloutHash(elbv2api.TrafficRolloutSpec{}),
+					Phase:              elbv2api.TrafficRolloutPhaseProgressing,
+					CanaryWeight:       20,
+				},
+			},
+			wantWeight: 20,
+			wantOK:     true,
+		},
+		{
+			name: "rollout rolled back",
+			rollout: &elbv2api.TrafficRollout{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: elbv2api.TrafficRolloutStatus{
+					ObservedGeneration: 2,
+					RolloutHash:        ComputeRolloutHash(elbv2api.TrafficRolloutSpec{}),
+					Phase:              elbv2api.TrafficRolloutPhaseRolledBack,
+				},
+			},
+			wantWeight: 0,
+			wantOK:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWeight, gotOK := CanaryWeight(tt.rollout)
+			assert.Equal(t, tt.wantWeight, gotWeight)
+			assert.Equal(t, tt.wantOK, gotOK)
+		})
+	}
+}
+
+func Test_IsHeaderRoutingActive(t *testing.T) {
+	headerRouting := &elbv2api.TrafficRolloutHeaderRouting{Name: "x-canary", Values: []string{"true"}}
+	tests := []struct {
+		name          string
+		headerRouting *elbv2api.TrafficRolloutHeaderRouting
+		phase         elbv2api.TrafficRolloutPhase
+		want          bool
+	}{
+		{
+			name:  "no headerRouting",
+			phase: elbv2api.TrafficRolloutPhaseProgressing,
+			want:  false,
+		},
+		{
+			name:          "progressing",
+			headerRouting: headerRouting,
+			phase:         elbv2api.TrafficRolloutPhaseProgressing,
+			want:          true,
+		},
+		{
+			name:          "paused",
+			headerRouting: headerRouting,
+			phase:         elbv2api.TrafficRolloutPhasePaused,
+			want:          true,
+		},
+		{
+			name:          "succeeded",
+			headerRouting: headerRouting,
+			phase:         elbv2api.TrafficRolloutPhaseSucceeded,
+			want:          false,
+		},
+		{
+			name:          "rolled back",
+			headerRouting: headerRouting,
+			phase:         elbv2api.TrafficRolloutPhaseRolledBack,
+			want:          false,
+		},
+		{
+			name:          "not started",
+			headerRouting: headerRouting,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := &elbv2api.TrafficRollout{
+				Spec:   elbv2api.TrafficRolloutSpec{HeaderRouting: tt.headerRouting},
+				Status: elbv2api.TrafficRolloutStatus{Phase: tt.phase, RolloutHash: ComputeRolloutHash(elbv2api.TrafficRolloutSpec{})},
+			}
+			assert.Equal(t, tt.want, IsHeaderRoutingActive(rollout))
+		})
+	}
+}
+
+func Test_SplitWeight(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int32
+		canaryPercent int32
+		wantStable    int32
+		wantCanary    int32
+	}{
+		{
+			name:          "no canary traffic",
+			total:         100,
+			canaryPercent: 0,
+			wantStable:    100,
+			wantCanary:    0,
+		},
+		{
+			name:          "split default total",
+			total:         DefaultTotalWeight,
+			canaryPercent: 25,
+			wantStable:    75,
+			wantCanary:    25,
+		},
+		{
+			name:          "split explicit total rounds canary down",
+			total:         30,
+			canaryPercent: 10,
+			wantStable:    27,
+			wantCanary:    3,
+		},
+		{
+			name:          "all canary traffic",
+			total:         999,
+			canaryPercent: 100,
+			wantStable:    0,
+			wantCanary:    999,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStable, gotCanary := SplitWeight(tt.total, tt.canaryPercent)
+			assert.Equal(t, tt.wantStable, gotStable)
+			assert.Equal(t, tt.wantCanary, gotCanary)
+		})
+	}
+}
+
+func Test_BackendMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend elbv2api.TrafficRolloutBackend
+		svcName string
+		svcPort intstr.IntOrString
+		want    bool
+	}{
+		{
+			name:    "matches port number",
+			backend: elbv2api.TrafficRolloutBackend{Name: "svc", Port: intstr.FromInt32(80)},
+			svcName: "svc",
+			svcPort: intstr.FromInt32(80),
+			want:    true,
+		},
+		{
+			name:    "matches port name",
+			backend: elbv2api.TrafficRolloutBackend{Name: "svc", Port: intstr.FromString("http")},
+			svcName: "svc",
+			svcPort: intstr.FromString("http"),
+			want:    true,
+		},
+		{
+			name:    "different Service",
+			backend: elbv2api.TrafficRolloutBackend{Name: "svc", Port: intstr.FromInt32(80)},
+			svcName: "other-svc",
+			svcPort: intstr.FromInt32(80),
+			want:    false,
+		},
+		{
+			name:    "port name doesn't match port number",
+			backend: elbv2api.TrafficRolloutBackend{Name: "svc", Port: intstr.FromString("http")},
+			svcName: "svc",
+			svcPort: intstr.FromInt32(80),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BackendMatches(tt.backend, tt.svcName, tt.svcPort))
+		})
+	}
+}
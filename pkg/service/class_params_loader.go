@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClassParamsLoader loads the ServiceClassParams for Service.
+type ClassParamsLoader interface {
+	// Load loads the ServiceClassParams selecting the spec.loadBalancerClass of Service.
+	// returns nil if the Service has no loadBalancerClass or no ServiceClassParams selects it.
+	Load(ctx context.Context, svc *corev1.Service) (*elbv2api.ServiceClassParams, error)
+}
+
+// NewDefaultClassParamsLoader constructs new defaultClassParamsLoader instance.
+func NewDefaultClassParamsLoader(client client.Client) *defaultClassParamsLoader {
+	return &defaultClassParamsLoader{
+		client: client,
+	}
+}
+
+var _ ClassParamsLoader = &defaultClassParamsLoader{}
+
+// default implementation for ClassParamsLoader
+type defaultClassParamsLoader struct {
+	client client.Client
+}
+
+func (l *defaultClassParamsLoader) Load(ctx context.Context, svc *corev1.Service) (*elbv2api.ServiceClassParams, error) {
+	if svc.Spec.LoadBalancerClass == nil || *svc.Spec.LoadBalancerClass == "" {
+		return nil, nil
+	}
+	lbClass := *svc.Spec.LoadBalancerClass
+	svcClassParamsList := &elbv2api.ServiceClassParamsList{}
+	if err := l.client.List(ctx, svcClassParamsList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ServiceClassParams")
+	}
+	var matched *elbv2api.ServiceClassParams
+	for i := range svcClassParamsList.Items {
+		svcClassParams := &svcClassParamsList.Items[i]
+		if svcClassParams.Spec.LoadBalancerClass != lbClass {
+			continue
+		}
+		if matched != nil {
+			return nil, errors.Errorf("multiple ServiceClassParams select loadBalancerClass %v: %v, %v", lbClass, matched.Name, svcClassParams.Name)
+		}
+		matched = svcClassParams
+	}
+	if matched == nil {
+		return nil, nil
+	}
+	if err := l.validateNamespaceRestriction(ctx, svc, matched); err != nil {
+		return nil, err
+	}
+	return matched, nil
+}
+
+func (l *defaultClassParamsLoader) validateNamespaceRestriction(ctx context.Context, svc *corev1.Service, svcClassParams *elbv2api.ServiceClassParams) error {
+	// when namespaceSelector is empty, it matches every namespace
+	if svcClassParams.Spec.NamespaceSelector == nil {
+		return nil
+	}
+
+	svcNamespace := svc.Namespace
+	// see https://github.com/kubernetes/kubernetes/issues/88282 and https://github.com/kubernetes/kubernetes/issues/76680
+	if admissionReq := webhook.ContextGetAdmissionRequest(ctx); admissionReq != nil {
+		svcNamespace = admissionReq.Namespace
+	}
+	svcNS := &corev1.Namespace{}
+	if err := l.client.Get(ctx, types.NamespacedName{Name: svcNamespace}, svcNS); err != nil {
+		return err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(svcClassParams.Spec.NamespaceSelector)
+	if err != nil {
+		return err
+	}
+	if !selector.Matches(labels.Set(svcNS.Labels)) {
+		return errors.Errorf("namespace %v is not allowed to use loadBalancerClass %v by namespaceSelector of ServiceClassParams %v",
+			svcNamespace, svcClassParams.Spec.LoadBalancerClass, svcClassParams.Name)
+	}
+	return nil
+}
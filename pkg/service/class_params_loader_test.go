@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultClassParamsLoader_Load(t *testing.T) {
+	internalParams := &elbv2api.ServiceClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+		Spec: elbv2api.ServiceClassParamsSpec{
+			LoadBalancerClass: "service.k8s.aws/nlb-internal",
+		},
+	}
+	restrictedParams := &elbv2api.ServiceClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "public"},
+		Spec: elbv2api.ServiceClassParamsSpec{
+			LoadBalancerClass: "service.k8s.aws/nlb-public",
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"exposure": "public"},
+			},
+		},
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "public-ns", Labels: map[string]string{"exposure": "public"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-ns"}},
+	}
+	tests := []struct {
+		name      string
+		params    []client.Object
+		namespace string
+		lbClass   *string
+		want      *elbv2api.ServiceClassParams
+		wantErr   string
+	}{
+		{
+			name:      "no loadBalancerClass",
+			params:    []client.Object{internalParams},
+			namespace: "team-ns",
+		},
+		{
+			name:      "no ServiceClassParams selects the class",
+			params:    []client.Object{internalParams},
+			namespace: "team-ns",
+			lbClass:   awssdk.String("service.k8s.aws/nlb"),
+		},
+		{
+			name:      "ServiceClassParams without namespaceSelector",
+			params:    []client.Object{internalParams, restrictedParams},
+			namespace: "team-ns",
+			lbClass:   awssdk.String("service.k8s.aws/nlb-internal"),
+			want:      internalParams,
+		},
+		{
+			name:      "namespace allowed by namespaceSelector",
+			params:    []client.Object{internalParams, restrictedParams},
+			namespace: "public-ns",
+			lbClass:   awssdk.String("service.k8s.aws/nlb-public"),
+			want:      restrictedParams,
+		},
+		{
+			name:      "namespace not allowed by namespaceSelector",
+			params:    []client.Object{internalParams, restrictedParams},
+			namespace: "team-ns",
+			lbClass:   awssdk.String("service.k8s.aws/nlb-public"),
+			wantErr:   "namespace team-ns is not allowed to use loadBalancerClass service.k8s.aws/nlb-public by namespaceSelector of ServiceClassParams public",
+		},
+		{
+			name: "multiple ServiceClassParams select the class",
+			params: []client.Object{internalParams, &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal-2"},
+				Spec:       elbv2api.ServiceClassParamsSpec{LoadBalancerClass: "service.k8s.aws/nlb-internal"},
+			}},
+			namespace: "team-ns",
+			lbClass:   awssdk.String("service.k8s.aws/nlb-internal"),
+			wantErr:   "multiple ServiceClassParams select loadBalancerClass service.k8s.aws/nlb-internal: internal, internal-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			objects := append([]client.Object{}, namespaces...)
+			for _, params := range tt.params {
+				objects = append(objects, params.DeepCopyObject().(client.Object))
+			}
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(objects...).Build()
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "svc"},
+				Spec: corev1.ServiceSpec{
+					Type:              corev1.ServiceTypeLoadBalancer,
+					LoadBalancerClass: tt.lbClass,
+				},
+			}
+
+			got, err := NewDefaultClassParamsLoader(k8sClient).Load(context.Background(), svc)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+			} else {
+				assert.Equal(t, tt.want.Name, got.Name)
+				assert.Equal(t, tt.want.Spec, got.Spec)
+			}
+		})
+	}
+}
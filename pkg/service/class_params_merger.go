@@ -0,0 +1,140 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+)
+
+// annotationSpecificLBAttributeSuffixes are the annotations that override the corresponding load balancer attribute.
+var annotationSpecificLBAttributeSuffixes = map[string]string{
+	shared_constants.LBAttributeAccessLogsS3Enabled:           annotations.SvcLBSuffixAccessLogEnabled,
+	shared_constants.LBAttributeAccessLogsS3Bucket:            annotations.SvcLBSuffixAccessLogS3BucketName,
+	shared_constants.LBAttributeAccessLogsS3Prefix:            annotations.SvcLBSuffixAccessLogS3BucketPrefix,
+	shared_constants.LBAttributeLoadBalancingCrossZoneEnabled: annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled,
+}
+
+// ClassParamsMerger merges the ServiceClassParams settings into the Service annotations.
+type ClassParamsMerger interface {
+	// Merge returns a copy of Service whose annotations and spec.loadBalancerSourceRanges carry the effective settings.
+	// The subnets of ServiceClassParams are effective when the returned Service has no subnets annotation.
+	Merge(svcClassParams *elbv2api.ServiceClassParams, svc *corev1.Service) (*corev1.Service, error)
+}
+
+// NewDefaultClassParamsMerger constructs new defaultClassParamsMerger instance.
+func NewDefaultClassParamsMerger(annotationPrefix string, annotationParser annotations.Parser) *defaultClassParamsMerger {
+	return &defaultClassParamsMerger{
+		annotationPrefix: annotationPrefix,
+		annotationParser: annotationParser,
+	}
+}
+
+var _ ClassParamsMerger = &defaultClassParamsMerger{}
+
+// default implementation for ClassParamsMerger.
+// settings taking a single value are taken from the preferred side when specified there,
+// tags and load balancer attributes are merged by key with the preferred side winning conflicts.
+type defaultClassParamsMerger struct {
+	annotationPrefix string
+	annotationParser annotations.Parser
+}
+
+func (m *defaultClassParamsMerger) Merge(svcClassParams *elbv2api.ServiceClassParams, svc *corev1.Service) (*corev1.Service, error) {
+	if svcClassParams == nil {
+		return svc, nil
+	}
+	preferParams := svcClassParams.Spec.MergingMode == nil || *svcClassParams.Spec.MergingMode != elbv2api.MergeModePreferService
+	merged := svc.DeepCopy()
+	if merged.Annotations == nil {
+		merged.Annotations = make(map[string]string)
+	}
+	spec := svcClassParams.Spec
+
+	if spec.Scheme != nil && (preferParams || !m.hasAnnotation(merged, annotations.SvcLBSuffixScheme, annotations.SvcLBSuffixInternal)) {
+		m.setAnnotation(merged, annotations.SvcLBSuffixScheme, string(*spec.Scheme))
+		m.deleteAnnotation(merged, annotations.SvcLBSuffixInternal)
+	}
+	if spec.IPAddressType != nil && (preferParams || !m.hasAnnotation(merged, annotations.SvcLBSuffixIPAddressType)) {
+		m.setAnnotation(merged, annotations.SvcLBSuffixIPAddressType, string(*spec.IPAddressType))
+	}
+	if spec.SSLPolicy != nil && (preferParams || !m.hasAnnotation(merged, annotations.SvcLBSuffixSSLNegotiationPolicy)) {
+		m.setAnnotation(merged, annotations.SvcLBSuffixSSLNegotiationPolicy, *spec.SSLPolicy)
+	}
+	if spec.Subnets != nil && preferParams {
+		m.deleteAnnotation(merged, annotations.SvcLBSuffixSubnets)
+	}
+	if len(spec.InboundCIDRs) != 0 && (preferParams || (len(merged.Spec.LoadBalancerSourceRanges) == 0 && !m.hasAnnotation(merged, annotations.SvcLBSuffixSourceRanges))) {
+		merged.Spec.LoadBalancerSourceRanges = append([]string(nil), spec.InboundCIDRs...)
+		m.deleteAnnotation(merged, annotations.SvcLBSuffixSourceRanges)
+	}
+
+	if len(spec.Tags) != 0 {
+		paramsTags := make(map[string]string, len(spec.Tags))
+		for _, tag := range spec.Tags {
+			paramsTags[tag.Key] = tag.Value
+		}
+		if err := m.mergeStringMapAnnotation(merged, annotations.SvcLBSuffixAdditionalTags, paramsTags, preferParams); err != nil {
+			return nil, err
+		}
+	}
+	if len(spec.LoadBalancerAttributes) != 0 {
+		paramsAttributes := make(map[string]string, len(spec.LoadBalancerAttributes))
+		for _, attr := range spec.LoadBalancerAttributes {
+			paramsAttributes[attr.Key] = attr.Value
+			if suffix, ok := annotationSpecificLBAttributeSuffixes[attr.Key]; ok && preferParams {
+				m.deleteAnnotation(merged, suffix)
+			}
+		}
+		if err := m.mergeStringMapAnnotation(merged, annotations.SvcLBSuffixLoadBalancerAttributes, paramsAttributes, preferParams); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeStringMapAnnotation merges the key=value pairs from ServiceClassParams into the map annotation of Service.
+func (m *defaultClassParamsMerger) mergeStringMapAnnotation(svc *corev1.Service, suffix string, paramsValues map[string]string, preferParams bool) error {
+	var svcValues map[string]string
+	if _, err := m.annotationParser.ParseStringMapAnnotation(suffix, &svcValues, svc.Annotations); err != nil {
+		return err
+	}
+	var merged map[string]string
+	if preferParams {
+		merged = algorithm.MergeStringMap(paramsValues, svcValues)
+	} else {
+		merged = algorithm.MergeStringMap(svcValues, paramsValues)
+	}
+	pairs := make([]string, 0, len(merged))
+	for key, value := range merged {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	m.setAnnotation(svc, suffix, strings.Join(pairs, ","))
+	return nil
+}
+
+func (m *defaultClassParamsMerger) hasAnnotation(svc *corev1.Service, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if _, exists := svc.Annotations[m.annotationKey(suffix)]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *defaultClassParamsMerger) setAnnotation(svc *corev1.Service, suffix string, value string) {
+	svc.Annotations[m.annotationKey(suffix)] = value
+}
+
+func (m *defaultClassParamsMerger) deleteAnnotation(svc *corev1.Service, suffix string) {
+	delete(svc.Annotations, m.annotationKey(suffix))
+}
+
+func (m *defaultClassParamsMerger) annotationKey(suffix string) string {
+	return m.annotationPrefix + "/" + suffix
+}
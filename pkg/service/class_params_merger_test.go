@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+)
+
+func Test_defaultClassParamsMerger_Merge(t *testing.T) {
+	internal := elbv2api.LoadBalancerSchemeInternal
+	dualstack := elbv2api.IPAddressTypeDualStack
+	preferService := elbv2api.MergeModePreferService
+	tests := []struct {
+		name            string
+		params          *elbv2api.ServiceClassParams
+		annotations     map[string]string
+		sourceRanges    []string
+		wantAnnotations map[string]string
+		wantRanges      []string
+	}{
+		{
+			name: "no ServiceClassParams",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+			},
+		},
+		{
+			name: "prefer ServiceClassParams by default",
+			params: &elbv2api.ServiceClassParams{
+				Spec: elbv2api.ServiceClassParamsSpec{
+					Scheme:        &internal,
+					IPAddressType: &dualstack,
+					SSLPolicy:     awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					Subnets:       &elbv2api.SubnetSelector{IDs: []elbv2api.SubnetID{"subnet-1"}},
+					InboundCIDRs:  []string{"10.0.0.0/8"},
+					Tags: []elbv2api.Tag{
+						{Key: "team", Value: "platform"},
+						{Key: "cost-center", Value: "42"},
+					},
+					LoadBalancerAttributes: []elbv2api.Attribute{
+						{Key: "load_balancing.cross_zone.enabled", Value: "false"},
+						{Key: "deletion_protection.enabled", Value: "true"},
+					},
+				},
+			},
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":                            "internet-facing",
+				"service.beta.kubernetes.io/aws-load-balancer-internal":                          "false",
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type":                   "ipv4",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-negotiation-policy":            "ELBSecurityPolicy-2016-08",
+				"service.beta.kubernetes.io/aws-load-balancer-subnets":                           "subnet-2",
+				"service.beta.kubernetes.io/load-balancer-source-ranges":                         "0.0.0.0/0",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags":          "team=app,app=web",
+				"service.beta.kubernetes.io/aws-load-balancer-attributes":                        "load_balancing.cross_zone.enabled=true,access_logs.s3.enabled=false",
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+				"service.beta.kubernetes.io/aws-load-balancer-target-type":                       "ip",
+			},
+			sourceRanges: []string{"0.0.0.0/0"},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":                   "internal",
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type":          "dualstack",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-negotiation-policy":   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "app=web,cost-center=42,team=platform",
+				"service.beta.kubernetes.io/aws-load-balancer-attributes":               "access_logs.s3.enabled=false,deletion_protection.enabled=true,load_balancing.cross_zone.enabled=false",
+				"service.beta.kubernetes.io/aws-load-balancer-target-type":              "ip",
+			},
+			wantRanges: []string{"10.0.0.0/8"},
+		},
+		{
+			name: "prefer Service",
+			params: &elbv2api.ServiceClassParams{
+				Spec: elbv2api.ServiceClassParamsSpec{
+					MergingMode:   &preferService,
+					Scheme:        &internal,
+					IPAddressType: &dualstack,
+					Subnets:       &elbv2api.SubnetSelector{IDs: []elbv2api.SubnetID{"subnet-1"}},
+					InboundCIDRs:  []string{"10.0.0.0/8"},
+					Tags: []elbv2api.Tag{
+						{Key: "team", Value: "platform"},
+						{Key: "cost-center", Value: "42"},
+					},
+					LoadBalancerAttributes: []elbv2api.Attribute{
+						{Key: "load_balancing.cross_zone.enabled", Value: "false"},
+					},
+				},
+			},
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal":                          "false",
+				"service.beta.kubernetes.io/aws-load-balancer-subnets":                           "subnet-2",
+				"service.beta.kubernetes.io/load-balancer-source-ranges":                         "0.0.0.0/0",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags":          "team=app",
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal":                          "false",
+				"service.beta.kubernetes.io/aws-load-balancer-ip-address-type":                   "dualstack",
+				"service.beta.kubernetes.io/aws-load-balancer-subnets":                           "subnet-2",
+				"service.beta.kubernetes.io/load-balancer-source-ranges":                         "0.0.0.0/0",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags":          "cost-center=42,team=app",
+				"service.beta.kubernetes.io/aws-load-balancer-attributes":                        "load_balancing.cross_zone.enabled=false",
+				"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "true",
+			},
+		},
+		{
+			name: "prefer Service without Service settings",
+			params: &elbv2api.ServiceClassParams{
+				Spec: elbv2api.ServiceClassParamsSpec{
+					MergingMode:  &preferService,
+					Scheme:       &internal,
+					InboundCIDRs: []string{"10.0.0.0/8"},
+				},
+			},
+			wantAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+			},
+			wantRanges: []string{"10.0.0.0/8"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc", Annotations: tt.annotations},
+				Spec: corev1.ServiceSpec{
+					Type:                     corev1.ServiceTypeLoadBalancer,
+					LoadBalancerSourceRanges: tt.sourceRanges,
+				},
+			}
+			original := svc.DeepCopy()
+			m := NewDefaultClassParamsMerger("service.beta.kubernetes.io", annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"))
+
+			got, err := m.Merge(tt.params, svc)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAnnotations, got.Annotations)
+			assert.Equal(t, tt.wantRanges, got.Spec.LoadBalancerSourceRanges)
+			assert.Equal(t, original, svc)
+		})
+	}
+}
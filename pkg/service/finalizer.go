@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+// FinalizerManager manages finalizer for ServiceGroup members.
+type FinalizerManager interface {
+	// AddGroupFinalizer add ServiceGroup finalizer for active member Services.
+	// Services will be in-place updated.
+	AddGroupFinalizer(ctx context.Context, groupName string, members []*corev1.Service) error
+
+	// RemoveGroupFinalizer remove ServiceGroup finalizer from inactive member Services.
+	// Services will be in-place updated.
+	RemoveGroupFinalizer(ctx context.Context, groupName string, inactiveMembers []*corev1.Service) error
+}
+
+// NewDefaultFinalizerManager constructs new defaultFinalizerManager
+func NewDefaultFinalizerManager(k8sFinalizerManager k8s.FinalizerManager) *defaultFinalizerManager {
+	return &defaultFinalizerManager{
+		k8sFinalizerManager: k8sFinalizerManager,
+	}
+}
+
+var _ FinalizerManager = (*defaultFinalizerManager)(nil)
+
+// default implementation of FinalizerManager
+type defaultFinalizerManager struct {
+	k8sFinalizerManager k8s.FinalizerManager
+}
+
+func (m *defaultFinalizerManager) AddGroupFinalizer(ctx context.Context, groupName string, members []*corev1.Service) error {
+	finalizer := BuildGroupFinalizer(groupName)
+	for _, svc := range members {
+		if err := m.k8sFinalizerManager.AddFinalizers(ctx, svc, finalizer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultFinalizerManager) RemoveGroupFinalizer(ctx context.Context, groupName string, inactiveMembers []*corev1.Service) error {
+	finalizer := BuildGroupFinalizer(groupName)
+	for _, svc := range inactiveMembers {
+		if err := m.k8sFinalizerManager.RemoveFinalizers(ctx, svc, finalizer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
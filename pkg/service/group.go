@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A ServiceGroup is a group of Services that should be hosted by a single NLB.
+// Services join a group via the "aws-load-balancer-group-name" annotation, each member Service contributes
+// the listeners for its ports, while NLB level settings are taken from the first member.
+type ServiceGroup struct {
+	Name string
+
+	// Members are the Services that have listeners on the NLB, sorted by group order and then namespace/name.
+	Members []*corev1.Service
+
+	// ConflictedMembers are the Services that opt into the group but are excluded because
+	// one of their ports is already used by an earlier member.
+	ConflictedMembers []ConflictedServiceGroupMember
+
+	// InactiveMembers are the Services that carry the group finalizer but no longer belong to the group.
+	InactiveMembers []*corev1.Service
+}
+
+// ConflictedServiceGroupMember is a Service excluded from a ServiceGroup due to a port conflict.
+type ConflictedServiceGroupMember struct {
+	Service *corev1.Service
+	// Port is the first port of Service that conflicts with an earlier member.
+	Port int32
+	// ConflictsWith is the earlier member that owns Port.
+	ConflictsWith types.NamespacedName
+}
+
+// NewGroupStackID returns the stack ID of the NLB for the ServiceGroup.
+func NewGroupStackID(groupName string) core.StackID {
+	return core.StackID(types.NamespacedName{Namespace: "", Name: groupName})
+}
+
+// EncodeGroupNameToReconcileRequest encodes a ServiceGroup name into a controller-runtime reconcile request.
+// Services always have a namespace, so requests with an empty namespace refer to ServiceGroups.
+func EncodeGroupNameToReconcileRequest(groupName string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "", Name: groupName}}
+}
+
+// DecodeGroupNameFromReconcileRequest decodes a ServiceGroup name from a controller-runtime reconcile request.
+// It returns false if the request refers to a single Service.
+func DecodeGroupNameFromReconcileRequest(request reconcile.Request) (string, bool) {
+	if request.Namespace != "" {
+		return "", false
+	}
+	return request.Name, true
+}
+
+// BuildGroupFinalizer returns the finalizer for members of the ServiceGroup,
+// the format is "group.service.k8s.aws/awesome-group".
+func BuildGroupFinalizer(groupName string) string {
+	return fmt.Sprintf("%s%s", shared_constants.ServiceGroupFinalizerPrefix, groupName)
+}
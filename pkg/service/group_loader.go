@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/shared_constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultGroupOrder  int32 = 0
+	minGroupOrder      int32 = -1000
+	maxGroupOrder      int32 = 1000
+	maxGroupNameLength int   = 63
+)
+
+// groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character.
+// groupName must be no more than 63 character.
+var groupNameRegex = regexp.MustCompile("^([a-z0-9][-a-z0-9.]*)?[a-z0-9]$")
+
+// GroupLoader loads Service groups.
+type GroupLoader interface {
+	// Load returns the ServiceGroup with the given name.
+	Load(ctx context.Context, groupName string) (ServiceGroup, error)
+
+	// LoadGroupNameIfAny returns the group name if Service is supported and opts into a ServiceGroup.
+	// An empty name is returned for standalone Services.
+	LoadGroupNameIfAny(svc *corev1.Service) (string, error)
+
+	// LoadGroupNamesPendingFinalization returns the group names that have their finalizer on Service.
+	LoadGroupNamesPendingFinalization(svc *corev1.Service) []string
+}
+
+// NewDefaultGroupLoader constructs new defaultGroupLoader.
+func NewDefaultGroupLoader(k8sClient client.Client, annotationParser annotations.Parser, serviceUtils ServiceUtils) *defaultGroupLoader {
+	return &defaultGroupLoader{
+		k8sClient:        k8sClient,
+		annotationParser: annotationParser,
+		serviceUtils:     serviceUtils,
+	}
+}
+
+var _ GroupLoader = &defaultGroupLoader{}
+
+type defaultGroupLoader struct {
+	k8sClient        client.Client
+	annotationParser annotations.Parser
+	serviceUtils     ServiceUtils
+}
+
+func (l *defaultGroupLoader) Load(ctx context.Context, groupName string) (ServiceGroup, error) {
+	svcList := &corev1.ServiceList{}
+	if err := l.k8sClient.List(ctx, svcList); err != nil {
+		return ServiceGroup{}, err
+	}
+	finalizer := BuildGroupFinalizer(groupName)
+	var members []*corev1.Service
+	var inactiveMembers []*corev1.Service
+	for index := range svcList.Items {
+		svc := &svcList.Items[index]
+		svcGroupName, err := l.LoadGroupNameIfAny(svc)
+		if err == nil && svcGroupName == groupName {
+			members = append(members, svc)
+		} else if k8s.HasFinalizer(svc, finalizer) {
+			inactiveMembers = append(inactiveMembers, svc)
+		}
+	}
+	sortedMembers, err := l.sortGroupMembers(members)
+	if err != nil {
+		return ServiceGroup{}, err
+	}
+	activeMembers, conflictedMembers := resolveGroupPortConflicts(sortedMembers)
+	return ServiceGroup{
+		Name:              groupName,
+		Members:           activeMembers,
+		ConflictedMembers: conflictedMembers,
+		InactiveMembers:   inactiveMembers,
+	}, nil
+}
+
+func (l *defaultGroupLoader) LoadGroupNameIfAny(svc *corev1.Service) (string, error) {
+	if !l.serviceUtils.IsServiceSupported(svc) {
+		return "", nil
+	}
+	groupName := ""
+	if exists := l.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixGroupName, &groupName, svc.Annotations); !exists {
+		return "", nil
+	}
+	if err := validateGroupName(groupName); err != nil {
+		return "", errors.Wrapf(err, "invalid service group name %q", groupName)
+	}
+	return groupName, nil
+}
+
+func (l *defaultGroupLoader) LoadGroupNamesPendingFinalization(svc *corev1.Service) []string {
+	var groupNames []string
+	for _, finalizer := range svc.GetFinalizers() {
+		if strings.HasPrefix(finalizer, shared_constants.ServiceGroupFinalizerPrefix) {
+			groupNames = append(groupNames, finalizer[len(shared_constants.ServiceGroupFinalizerPrefix):])
+		}
+	}
+	return groupNames
+}
+
+type groupMemberWithOrder struct {
+	member *corev1.Service
+	order  int32
+}
+
+// sortGroupMembers will sort Services within ServiceGroup in ascending order.
+// the order for a Service can be set explicitly via "aws-load-balancer-group-order" annotation, and defaults to ${defaultGroupOrder}.
+// If two Services are of same order, they are sorted by lexical order of their full-qualified name.
+func (l *defaultGroupLoader) sortGroupMembers(members []*corev1.Service) ([]*corev1.Service, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	groupMemberWithOrderList := make([]groupMemberWithOrder, 0, len(members))
+	for _, member := range members {
+		var order = defaultGroupOrder
+		exists, err := l.annotationParser.ParseInt32Annotation(annotations.SvcLBSuffixGroupOrder, &order, member.Annotations)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load service group order for service: %v", k8s.NamespacedName(member))
+		}
+		if exists && (order < minGroupOrder || order > maxGroupOrder) {
+			return nil, errors.Errorf("explicit service group order must be within [%v:%v], Service: %v, order: %v",
+				minGroupOrder, maxGroupOrder, k8s.NamespacedName(member), order)
+		}
+		groupMemberWithOrderList = append(groupMemberWithOrderList, groupMemberWithOrder{member: member, order: order})
+	}
+
+	sort.Slice(groupMemberWithOrderList, func(i, j int) bool {
+		orderI := groupMemberWithOrderList[i].order
+		orderJ := groupMemberWithOrderList[j].order
+		if orderI != orderJ {
+			return orderI < orderJ
+		}
+		nameI := k8s.NamespacedName(groupMemberWithOrderList[i].member).String()
+		nameJ := k8s.NamespacedName(groupMemberWithOrderList[j].member).String()
+		return nameI < nameJ
+	})
+
+	sortedMembers := make([]*corev1.Service, 0, len(groupMemberWithOrderList))
+	for _, item := range groupMemberWithOrderList {
+		sortedMembers = append(sortedMembers, item.member)
+	}
+	return sortedMembers, nil
+}
+
+// resolveGroupPortConflicts walks the sorted members and excludes every Service that listens on a port already
+// claimed by an earlier member, so the outcome only depends on the member order.
+// Services are excluded as a whole since partially exposing a Service would be surprising.
+func resolveGroupPortConflicts(sortedMembers []*corev1.Service) ([]*corev1.Service, []ConflictedServiceGroupMember) {
+	var activeMembers []*corev1.Service
+	var conflictedMembers []ConflictedServiceGroupMember
+	portOwners := make(map[int32]types.NamespacedName)
+	for _, member := range sortedMembers {
+		conflicted := false
+		for _, port := range member.Spec.Ports {
+			if owner, exists := portOwners[port.Port]; exists {
+				conflictedMembers = append(conflictedMembers, ConflictedServiceGroupMember{
+					Service:       member,
+					Port:          port.Port,
+					ConflictsWith: owner,
+				})
+				conflicted = true
+				break
+			}
+		}
+		if conflicted {
+			continue
+		}
+		for _, port := range member.Spec.Ports {
+			portOwners[port.Port] = k8s.NamespacedName(member)
+		}
+		activeMembers = append(activeMembers, member)
+	}
+	return activeMembers, conflictedMembers
+}
+
+// validateGroupName validates whether service group name is valid
+func validateGroupName(groupName string) error {
+	if !groupNameRegex.MatchString(groupName) {
+		return errors.New("groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character")
+	}
+	if len(groupName) > maxGroupNameLength {
+		return errors.Errorf("groupName must be no more than %v characters", maxGroupNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func buildGroupTestService(namespace string, name string, svcAnnotations map[string]string, finalizers []string, ports ...int32) *corev1.Service {
+	nlbClass := "service.k8s.aws/nlb"
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: svcAnnotations,
+			Finalizers:  finalizers,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:              corev1.ServiceTypeLoadBalancer,
+			LoadBalancerClass: &nlbClass,
+		},
+	}
+	for _, port := range ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Protocol: corev1.ProtocolTCP, Port: port})
+	}
+	return svc
+}
+
+func newTestGroupLoader(objects ...client.Object) *defaultGroupLoader {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(objects...).Build()
+	annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+	serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", []string{"service.k8s.aws/nlb"}, config.NewFeatureGates())
+	return NewDefaultGroupLoader(k8sClient, annotationParser, serviceUtils)
+}
+
+func Test_defaultGroupLoader_Load(t *testing.T) {
+	inGroup := func(order string) map[string]string {
+		svcAnnotations := map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"}
+		if order != "" {
+			svcAnnotations["service.beta.kubernetes.io/aws-load-balancer-group-order"] = order
+		}
+		return svcAnnotations
+	}
+	tests := []struct {
+		name           string
+		services       []client.Object
+		wantMembers    []string
+		wantConflicted []ConflictedServiceGroupMember
+		wantInactive   []string
+		wantErr        string
+	}{
+		{
+			name: "members sorted by order and name",
+			services: []client.Object{
+				buildGroupTestService("ns-b", "svc", inGroup(""), nil, 80),
+				buildGroupTestService("ns-a", "svc", inGroup(""), nil, 443),
+				buildGroupTestService("ns-c", "svc", inGroup("-10"), nil, 22),
+				buildGroupTestService("ns-a", "other-group", map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "other"}, nil, 8080),
+				buildGroupTestService("ns-a", "standalone", nil, nil, 8443),
+			},
+			wantMembers: []string{"ns-c/svc", "ns-a/svc", "ns-b/svc"},
+		},
+		{
+			name: "later members with conflicting ports are excluded",
+			services: []client.Object{
+				buildGroupTestService("ns", "svc-a", inGroup(""), nil, 80, 443),
+				buildGroupTestService("ns", "svc-b", inGroup(""), nil, 8080, 443),
+				buildGroupTestService("ns", "svc-c", inGroup(""), nil, 8080),
+				buildGroupTestService("ns", "svc-d", inGroup("-1"), nil, 53),
+			},
+			wantMembers: []string{"ns/svc-d", "ns/svc-a", "ns/svc-c"},
+			wantConflicted: []ConflictedServiceGroupMember{
+				{Port: 443, ConflictsWith: types.NamespacedName{Namespace: "ns", Name: "svc-a"}},
+			},
+		},
+		{
+			name: "inactive members keep the group finalizer",
+			services: []client.Object{
+				buildGroupTestService("ns", "svc-a", inGroup(""), []string{"group.service.k8s.aws/shared"}, 80),
+				buildGroupTestService("ns", "left", nil, []string{"group.service.k8s.aws/shared"}, 80),
+				buildGroupTestService("ns", "moved", map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "other"}, []string{"group.service.k8s.aws/shared"}, 80),
+				buildGroupTestService("ns", "never-joined", nil, nil, 80),
+			},
+			wantMembers:  []string{"ns/svc-a"},
+			wantInactive: []string{"ns/left", "ns/moved"},
+		},
+		{
+			name: "group order out of range",
+			services: []client.Object{
+				buildGroupTestService("ns", "svc-a", inGroup("1001"), nil, 80),
+			},
+			wantErr: "explicit service group order must be within [-1000:1000], Service: ns/svc-a, order: 1001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := newTestGroupLoader(tt.services...)
+			got, err := loader.Load(context.Background(), "shared")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "shared", got.Name)
+			var members []string
+			for _, svc := range got.Members {
+				members = append(members, types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}.String())
+			}
+			assert.Equal(t, tt.wantMembers, members)
+			var conflicted []ConflictedServiceGroupMember
+			for _, member := range got.ConflictedMembers {
+				conflicted = append(conflicted, ConflictedServiceGroupMember{Port: member.Port, ConflictsWith: member.ConflictsWith})
+			}
+			assert.Equal(t, tt.wantConflicted, conflicted)
+			var inactive []string
+			for _, svc := range got.InactiveMembers {
+				inactive = append(inactive, types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}.String())
+			}
+			assert.ElementsMatch(t, tt.wantInactive, inactive)
+		})
+	}
+}
+
+func Test_defaultGroupLoader_LoadGroupNameIfAny(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		want    string
+		wantErr string
+	}{
+		{
+			name: "standalone service",
+			svc:  buildGroupTestService("ns", "svc", nil, nil, 80),
+		},
+		{
+			name: "grouped service",
+			svc:  buildGroupTestService("ns", "svc", map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"}, nil, 80),
+			want: "shared",
+		},
+		{
+			name: "grouped service being deleted",
+			svc: func() *corev1.Service {
+				svc := buildGroupTestService("ns", "svc", map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "shared"}, nil, 80)
+				svc.DeletionTimestamp = &deletionTimestamp
+				return svc
+			}(),
+		},
+		{
+			name:    "invalid group name",
+			svc:     buildGroupTestService("ns", "svc", map[string]string{"service.beta.kubernetes.io/aws-load-balancer-group-name": "Shared_NLB"}, nil, 80),
+			wantErr: "invalid service group name \"Shared_NLB\": groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := newTestGroupLoader()
+			got, err := loader.LoadGroupNameIfAny(tt.svc)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultGroupLoader_LoadGroupNamesPendingFinalization(t *testing.T) {
+	svc := buildGroupTestService("ns", "svc", nil, []string{"service.k8s.aws/resources", "group.service.k8s.aws/shared", "group.service.k8s.aws/other"}, 80)
+	loader := newTestGroupLoader()
+	assert.Equal(t, []string{"shared", "other"}, loader.LoadGroupNamesPendingFinalization(svc))
+}
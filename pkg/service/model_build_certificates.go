@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	acmModel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/acm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+// buildInferredCertificateARNs returns the certificates for Service hostnames when no certificate ARN is set explicitly.
+// A new certificate is requested if "aws-load-balancer-create-acm-cert" is enabled, otherwise existing certificates are discovered from ACM.
+func (t *defaultModelBuildTask) buildInferredCertificateARNs(ctx context.Context) ([]core.StringToken, error) {
+	createCert := false
+	if t.enableACMCertificates {
+		if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixCreateCertificate, &createCert, t.service.Annotations); err != nil {
+			return nil, err
+		}
+	}
+
+	hosts := t.buildCertificateHosts(ctx, createCert)
+	if len(hosts) == 0 {
+		if createCert {
+			return nil, errors.Errorf("service %v has create-acm-cert enabled but no hostnames defined in the %v or %v annotation — cannot create a certificate without at least one hostname",
+				k8s.NamespacedName(t.service), annotations.SvcLBSuffixSSLCertificateHosts, annotations.ExternalDNSHostname)
+		}
+		return nil, nil
+	}
+
+	if createCert {
+		cert, err := t.buildACMCertificate(ctx, hosts)
+		if err != nil {
+			return nil, err
+		}
+		return []core.StringToken{cert.CertificateARN()}, nil
+	}
+
+	discoveredCertARNs, err := t.certDiscovery.Discover(ctx, hosts, t.trackingProvider.StackTags(t.stack))
+	if err != nil {
+		var partialErr *certs.PartialDiscoveryError
+		if !errors.As(err, &partialErr) {
+			return nil, errors.Wrapf(err, "failed to discover certificates for service %v", k8s.NamespacedName(t.service))
+		}
+		t.logger.Info("certificate discovery incomplete", "service", k8s.NamespacedName(t.service), "unmatchedHosts", partialErr.UnmatchedHosts)
+	}
+	var discoveredCerts []core.StringToken
+	for _, arn := range discoveredCertARNs {
+		discoveredCerts = append(discoveredCerts, acmModel.NewExistingCertificate(arn).CertificateARN())
+	}
+	return discoveredCerts, nil
+}
+
+// buildCertificateHosts returns the hostnames to look up or create certificates for.
+// hostnames are taken from the "aws-load-balancer-ssl-cert-hosts" annotation. When it is absent, the external-dns hostname
+// annotation is used as long as the Service asks for TLS via "aws-load-balancer-ssl-ports" or certificate creation,
+// so Services that only publish DNS records keep their plain listeners.
+func (t *defaultModelBuildTask) buildCertificateHosts(_ context.Context, createCert bool) []string {
+	var rawHosts []string
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSSLCertificateHosts, &rawHosts, t.service.Annotations); !exists {
+		var rawTLSPorts []string
+		tlsPortsExists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSSLPorts, &rawTLSPorts, t.service.Annotations)
+		if !createCert && !tlsPortsExists {
+			return nil
+		}
+		rawHosts = strings.Split(t.service.Annotations[annotations.ExternalDNSHostname], ",")
+	}
+
+	hosts := sets.NewString()
+	for _, rawHost := range rawHosts {
+		host := strings.TrimSuffix(strings.TrimSpace(rawHost), ".")
+		if len(host) != 0 {
+			hosts.Insert(strings.ToLower(host))
+		}
+	}
+	return hosts.List()
+}
+
+func (t *defaultModelBuildTask) buildACMCertificate(ctx context.Context, hosts []string) (*acmModel.Certificate, error) {
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	caArn := t.buildCertificateCAArn(ctx)
+
+	// if we have no reference to a CA it will be a public certificate
+	certType := acmtypes.CertificateTypePrivate
+	if caArn == "" {
+		certType = acmtypes.CertificateTypeAmazonIssued
+	}
+
+	certSpec := acmModel.CertificateSpec{
+		Type:                    certType,
+		CertificateAuthorityARN: caArn,
+		DomainName:              hosts[0],
+		SubjectAlternativeNames: hosts,
+		ValidationMethod:        acmtypes.ValidationMethodDns, // currently we only support DNS based validation for AMAZON_ISSUED certificates
+		Tags:                    tags,
+	}
+	return acmModel.NewCertificate(t.stack, t.buildCertificateResourceID(certSpec), certSpec), nil
+}
+
+// buildCertificateResourceID builds a unique resource ID for a certificate.
+// The ID includes a hash of the Service namespace/name so each member of a ServiceGroup gets its own certificate.
+func (t *defaultModelBuildTask) buildCertificateResourceID(spec acmModel.CertificateSpec) string {
+	svcHash := fmt.Sprintf("%x", sha256.Sum256([]byte(k8s.NamespacedName(t.service).String())))[:8]
+	return fmt.Sprintf("%s/%s-%s", strings.ToLower(string(spec.Type)), spec.DomainName, svcHash)
+}
+
+func (t *defaultModelBuildTask) buildCertificateCAArn(_ context.Context) string {
+	var caArn string
+	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixACMCaARN, &caArn, t.service.Annotations)
+
+	// PCA ARN on the Service takes precedence
+	if caArn != "" {
+		return caArn
+	}
+
+	// otherwise it's the default ARN set on the controller, or no ARN implying amazon issued certificates
+	return t.defaultCAArn
+}
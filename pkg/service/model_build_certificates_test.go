@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	acmModel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/acm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+func Test_defaultModelBuildTask_buildListenerCertificates(t *testing.T) {
+	type discoverCall struct {
+		hosts    []string
+		certARNs []string
+		err      error
+	}
+	tests := []struct {
+		name                  string
+		annotations           map[string]string
+		enableACMCertificates bool
+		defaultCAArn          string
+		discoverCalls         []discoverCall
+		wantCertARNs          []string
+		wantCertSpecs         []acmModel.CertificateSpec
+		wantErr               string
+	}{
+		{
+			name: "explicit certificate takes precedence",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert":        "arn:aws:acm:us-west-2:123456789012:certificate/explicit",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts":  "app.example.com",
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert": "true",
+			},
+			enableACMCertificates: true,
+			wantCertARNs:          []string{"arn:aws:acm:us-west-2:123456789012:certificate/explicit"},
+		},
+		{
+			name: "discover certificates for hosts annotation",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts": "b.example.com, A.example.com",
+			},
+			discoverCalls: []discoverCall{
+				{
+					hosts:    []string{"a.example.com", "b.example.com"},
+					certARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/a", "arn:aws:acm:us-west-2:123456789012:certificate/wildcard"},
+				},
+			},
+			wantCertARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/a", "arn:aws:acm:us-west-2:123456789012:certificate/wildcard"},
+		},
+		{
+			name: "discover certificates for external-dns hostnames when ssl-ports is set",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-ports": "443",
+				"external-dns.alpha.kubernetes.io/hostname":              "app.example.com.,www.example.com",
+			},
+			discoverCalls: []discoverCall{
+				{
+					hosts:    []string{"app.example.com", "www.example.com"},
+					certARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+				},
+			},
+			wantCertARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+		},
+		{
+			name: "external-dns hostnames alone don't enable TLS",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "app.example.com",
+			},
+		},
+		{
+			name: "discovery failure",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts": "app.example.com",
+			},
+			discoverCalls: []discoverCall{
+				{
+					hosts: []string{"app.example.com"},
+					err:   errors.New("no certificate found for host: app.example.com"),
+				},
+			},
+			wantErr: "failed to discover certificates for service awesome-ns/svc: no certificate found for host: app.example.com",
+		},
+		{
+			name: "partial discovery uses the certificates found",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts": "app.example.com,app.example.org",
+			},
+			discoverCalls: []discoverCall{
+				{
+					hosts:    []string{"app.example.com", "app.example.org"},
+					certARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+					err:      &certs.PartialDiscoveryError{UnmatchedHosts: []string{"app.example.org"}},
+				},
+			},
+			wantCertARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+		},
+		{
+			name: "create certificate for external-dns hostnames",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert":          "true",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "team=app",
+				"external-dns.alpha.kubernetes.io/hostname":                             "www.example.com,app.example.com",
+			},
+			enableACMCertificates: true,
+			wantCertSpecs: []acmModel.CertificateSpec{
+				{
+					Type:                    acmtypes.CertificateTypeAmazonIssued,
+					DomainName:              "app.example.com",
+					SubjectAlternativeNames: []string{"app.example.com", "www.example.com"},
+					ValidationMethod:        acmtypes.ValidationMethodDns,
+					Tags:                    map[string]string{"team": "app"},
+				},
+			},
+		},
+		{
+			name: "create private certificate with the controller default CA",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert": "true",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts":  "app.internal.example.com",
+			},
+			enableACMCertificates: true,
+			defaultCAArn:          "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/default",
+			wantCertSpecs: []acmModel.CertificateSpec{
+				{
+					Type:                    acmtypes.CertificateTypePrivate,
+					CertificateAuthorityARN: "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/default",
+					DomainName:              "app.internal.example.com",
+					SubjectAlternativeNames: []string{"app.internal.example.com"},
+					ValidationMethod:        acmtypes.ValidationMethodDns,
+					Tags:                    map[string]string{},
+				},
+			},
+		},
+		{
+			name: "create private certificate with the Service CA",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert": "true",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts":  "app.internal.example.com",
+				"service.beta.kubernetes.io/aws-load-balancer-acm-pca-arn":     "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/svc",
+			},
+			enableACMCertificates: true,
+			defaultCAArn:          "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/default",
+			wantCertSpecs: []acmModel.CertificateSpec{
+				{
+					Type:                    acmtypes.CertificateTypePrivate,
+					CertificateAuthorityARN: "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/svc",
+					DomainName:              "app.internal.example.com",
+					SubjectAlternativeNames: []string{"app.internal.example.com"},
+					ValidationMethod:        acmtypes.ValidationMethodDns,
+					Tags:                    map[string]string{},
+				},
+			},
+		},
+		{
+			name: "create certificate without hostnames",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert": "true",
+			},
+			enableACMCertificates: true,
+			wantErr:               "service awesome-ns/svc has create-acm-cert enabled but no hostnames defined in the aws-load-balancer-ssl-cert-hosts or external-dns.alpha.kubernetes.io/hostname annotation — cannot create a certificate without at least one hostname",
+		},
+		{
+			name: "create certificate ignored when certificate management is disabled",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-create-acm-cert": "true",
+				"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts":  "app.example.com",
+			},
+			discoverCalls: []discoverCall{
+				{
+					hosts:    []string{"app.example.com"},
+					certARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+				},
+			},
+			wantCertARNs: []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stack := core.NewDefaultStack(core.StackID(types.NamespacedName{Namespace: "awesome-ns", Name: "svc"}))
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+			certDiscovery := certs.NewMockCertDiscovery(ctrl)
+			for _, call := range tt.discoverCalls {
+				certDiscovery.EXPECT().Discover(gomock.Any(), call.hosts, trackingProvider.StackTags(stack)).Return(call.certARNs, call.err)
+			}
+			task := &defaultModelBuildTask{
+				annotationParser:      annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				trackingProvider:      trackingProvider,
+				featureGates:          config.NewFeatureGates(),
+				certDiscovery:         certDiscovery,
+				enableACMCertificates: tt.enableACMCertificates,
+				defaultCAArn:          tt.defaultCAArn,
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc",
+						Annotations: tt.annotations,
+					},
+				},
+				stack: stack,
+			}
+
+			got, err := task.buildListenerCertificates(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var resCerts []*acmModel.Certificate
+			assert.NoError(t, stack.ListResources(&resCerts))
+			var certSpecs []acmModel.CertificateSpec
+			for _, cert := range resCerts {
+				certSpecs = append(certSpecs, cert.Spec)
+			}
+			assert.Equal(t, tt.wantCertSpecs, certSpecs)
+			if len(tt.wantCertSpecs) != 0 {
+				// the listener must refer to the new certificate once it is provisioned
+				resCerts[0].SetStatus(&acmModel.CertificateStatus{CertificateARN: "arn:aws:acm:us-west-2:123456789012:certificate/created"})
+				tt.wantCertARNs = []string{"arn:aws:acm:us-west-2:123456789012:certificate/created"}
+			}
+
+			var certARNs []string
+			for _, cert := range got {
+				certARN, err := cert.CertificateARN.Resolve(context.Background())
+				assert.NoError(t, err)
+				certARNs = append(certARNs, certARN)
+			}
+			assert.Equal(t, tt.wantCertARNs, certARNs)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildListenerCertificates_partialDiscovery(t *testing.T) {
+	tests := []struct {
+		name                  string
+		allowPartialDiscovery bool
+		wantCertARNs          []string
+		wantErr               string
+	}{
+		{
+			name:                  "unmatched host fails the build when partial discovery is not allowed",
+			allowPartialDiscovery: false,
+			wantErr:               "failed to discover certificates for service awesome-ns/svc: no certificate found for host: app.example.org",
+		},
+		{
+			name:                  "unmatched host is skipped when partial discovery is allowed",
+			allowPartialDiscovery: true,
+			wantCertARNs:          []string{"arn:aws:acm:us-west-2:123456789012:certificate/app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			certARN := "arn:aws:acm:us-west-2:123456789012:certificate/app"
+			mockACM := services.NewMockACM(ctrl)
+			mockACM.EXPECT().ListCertificatesAsList(gomock.Any(), gomock.Any()).Return([]acmtypes.CertificateSummary{{CertificateArn: awssdk.String(certARN)}}, nil)
+			mockACM.EXPECT().DescribeCertificateWithContext(gomock.Any(), gomock.Any()).Return(&acm.DescribeCertificateOutput{
+				Certificate: &acmtypes.CertificateDetail{
+					CertificateArn:          awssdk.String(certARN),
+					SubjectAlternativeNames: []string{"app.example.com"},
+					Type:                    acmtypes.CertificateTypeAmazonIssued,
+				},
+			}, nil)
+			certDiscovery := certs.NewACMCertDiscovery(mockACM, nil, false, certs.SelectionPolicy{AllowPartialDiscovery: tt.allowPartialDiscovery}, logr.Discard())
+
+			stack := core.NewDefaultStack(core.StackID(types.NamespacedName{Namespace: "awesome-ns", Name: "svc"}))
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				trackingProvider: tracking.NewDefaultProvider("service.k8s.aws", "my-cluster"),
+				featureGates:     config.NewFeatureGates(),
+				certDiscovery:    certDiscovery,
+				logger:           logr.Discard(),
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "svc",
+						Annotations: map[string]string{
+							"service.beta.kubernetes.io/aws-load-balancer-ssl-cert-hosts": "app.example.com,app.example.org",
+						},
+					},
+				},
+				stack: stack,
+			}
+
+			got, err := task.buildListenerCertificates(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var certARNs []string
+			for _, cert := range got {
+				certARN, err := cert.CertificateARN.Resolve(context.Background())
+				assert.NoError(t, err)
+				certARNs = append(certARNs, certARN)
+			}
+			assert.Equal(t, tt.wantCertARNs, certARNs)
+		})
+	}
+}
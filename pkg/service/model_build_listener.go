@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	acmModel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/acm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
@@ -255,15 +256,28 @@ func (t *defaultModelBuildTask) buildSSLNegotiationPolicy(_ context.Context) *st
 	return &t.defaultSSLPolicy
 }
 
-func (t *defaultModelBuildTask) buildListenerCertificates(_ context.Context) []elbv2model.Certificate {
+func (t *defaultModelBuildTask) buildListenerCertificates(ctx context.Context) ([]elbv2model.Certificate, error) {
 	var rawCertificateARNs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSSLCertificate, &rawCertificateARNs, t.service.Annotations)
 
-	var certificates []elbv2model.Certificate
+	var certificateARNs []core.StringToken
 	for _, cert := range rawCertificateARNs {
-		certificates = append(certificates, elbv2model.Certificate{CertificateARN: acmModel.NewExistingCertificate(cert).CertificateARN()})
+		certificateARNs = append(certificateARNs, acmModel.NewExistingCertificate(cert).CertificateARN())
+	}
+	// explicitly set certificates take precedence over discovered or newly created ones
+	if len(certificateARNs) == 0 {
+		inferredCertificateARNs, err := t.buildInferredCertificateARNs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		certificateARNs = inferredCertificateARNs
+	}
+
+	var certificates []elbv2model.Certificate
+	for _, certificateARN := range certificateARNs {
+		certificates = append(certificates, elbv2model.Certificate{CertificateARN: certificateARN})
 	}
-	return certificates
+	return certificates, nil
 }
 
 func validateTLSPortsSet(rawTLSPorts []string, ports []corev1.ServicePort) error {
@@ -347,7 +361,10 @@ type listenerConfig struct {
 }
 
 func (t *defaultModelBuildTask) buildListenerConfig(ctx context.Context, tcpUdpPortsSet sets.Set[int32]) (*listenerConfig, error) {
-	certificates := t.buildListenerCertificates(ctx)
+	certificates, err := t.buildListenerCertificates(ctx)
+	if err != nil {
+		return nil, err
+	}
 	tlsPortsSet, err := t.buildTLSPortsSet(ctx)
 	if err != nil {
 		return nil, err
@@ -12,9 +12,9 @@ import (
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
@@ -57,6 +57,10 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	lbMinimumCapacity, err = t.buildLoadBalancerCapacitySchedule(lbMinimumCapacity)
+	if err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
 	securityGroups, err := t.buildLoadBalancerSecurityGroups(ctx, existingLB, ipAddressType)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
@@ -128,7 +132,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 		if !t.enableBackendSG {
 			t.backendSGIDToken = managedSG.GroupID()
 		} else {
-			backendSGID, err := t.backendSGProvider.Get(ctx, networking.ResourceTypeService, []types.NamespacedName{k8s.NamespacedName(t.service)})
+			backendSGID, err := t.backendSGProvider.Get(ctx, networking.ResourceTypeService, t.memberServiceKeys())
 			if err != nil {
 				return nil, err
 			}
@@ -152,7 +156,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 			if !t.enableBackendSG {
 				return nil, errors.New("backendSG feature is required to manage worker node SG rules when frontendSG is manually specified")
 			}
-			backendSGID, err := t.backendSGProvider.Get(ctx, networking.ResourceTypeService, []types.NamespacedName{k8s.NamespacedName(t.service)})
+			backendSGID, err := t.backendSGProvider.Get(ctx, networking.ResourceTypeService, t.memberServiceKeys())
 			if err != nil {
 				return nil, err
 			}
@@ -441,6 +445,12 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnets(ctx context.Context, sc
 			networking.WithSubnetsResolveLBScheme(scheme),
 		)
 	}
+	if t.svcClassParams != nil && t.svcClassParams.Spec.Subnets != nil {
+		return t.subnetsResolver.ResolveViaSelector(ctx, *t.svcClassParams.Spec.Subnets,
+			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+			networking.WithSubnetsResolveLBScheme(scheme),
+		)
+	}
 
 	existingLB, err := t.fetchExistingLoadBalancer(ctx)
 	if err != nil {
@@ -518,6 +528,41 @@ func (t *defaultModelBuildTask) buildLoadBalancerMinimumCapacity(_ context.Conte
 	return minimumLoadBalancerCapacity, nil
 }
 
+// loadBalancerCapacitySchedule is the format of the capacity reservation schedule annotation.
+type loadBalancerCapacitySchedule struct {
+	Schedules []elbv2model.CapacityReservationSchedule      `json:"schedules,omitempty"`
+	Calendar  []elbv2model.CapacityReservationCalendarEntry `json:"calendar,omitempty"`
+	TimeZone  string                                        `json:"timeZone,omitempty"`
+}
+
+// buildLoadBalancerCapacitySchedule sets the capacity reservation schedules and calendar from the annotation,
+// the capacity reservation annotation defines the capacity reservation outside of the scheduled windows.
+func (t *defaultModelBuildTask) buildLoadBalancerCapacitySchedule(minimumLoadBalancerCapacity *elbv2model.MinimumLoadBalancerCapacity) (*elbv2model.MinimumLoadBalancerCapacity, error) {
+	if !t.featureGates.Enabled(config.LBCapacityReservation) {
+		return minimumLoadBalancerCapacity, nil
+	}
+	var schedule loadBalancerCapacitySchedule
+	exists, err := t.annotationParser.ParseJSONAnnotation(annotations.SvcLBSuffixLoadBalancerCapacitySchedule, &schedule, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return minimumLoadBalancerCapacity, nil
+	}
+	scheduledCapacity := &elbv2model.MinimumLoadBalancerCapacity{
+		Schedules: schedule.Schedules,
+		Calendar:  schedule.Calendar,
+		TimeZone:  schedule.TimeZone,
+	}
+	if minimumLoadBalancerCapacity != nil {
+		scheduledCapacity.CapacityUnits = minimumLoadBalancerCapacity.CapacityUnits
+	}
+	if err := capacityreservation.Validate(*scheduledCapacity); err != nil {
+		return nil, errors.Wrapf(err, "invalid %v annotation", annotations.SvcLBSuffixLoadBalancerCapacitySchedule)
+	}
+	return scheduledCapacity, nil
+}
+
 func (t *defaultModelBuildTask) getLoadBalancerAttributes() (map[string]string, error) {
 	var attributes map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixLoadBalancerAttributes, &attributes, t.service.Annotations); err != nil {
@@ -581,6 +626,13 @@ func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme
 	}
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
+	if t.groupName != "" {
+		_, _ = uuidHash.Write([]byte(t.groupName))
+		_, _ = uuidHash.Write([]byte(scheme))
+		uuid := hex.EncodeToString(uuidHash.Sum(nil))
+		payload := invalidLoadBalancerNamePattern.ReplaceAllString(t.groupName, "")
+		return fmt.Sprintf("k8s-%.17s-%.10s", payload, uuid), nil
+	}
 	_, _ = uuidHash.Write([]byte(t.service.UID))
 	_, _ = uuidHash.Write([]byte(scheme))
 	uuid := hex.EncodeToString(uuidHash.Sum(nil))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
@@ -1892,6 +1893,110 @@ func Test_defaultModelBuilderTask_buildLbCapacity(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuilderTask_buildLbCapacitySchedule(t *testing.T) {
+	tests := []struct {
+		testName  string
+		svc       *corev1.Service
+		capacity  *elbv2.MinimumLoadBalancerCapacity
+		wantError string
+		wantValue *elbv2.MinimumLoadBalancerCapacity
+	}{
+		{
+			testName: "no schedule annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{},
+				},
+			},
+			capacity: &elbv2.MinimumLoadBalancerCapacity{
+				CapacityUnits: 3000,
+			},
+			wantValue: &elbv2.MinimumLoadBalancerCapacity{
+				CapacityUnits: 3000,
+			},
+		},
+		{
+			testName: "schedule along with capacity reservation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity-schedule": `{"timeZone":"America/New_York","schedules":[{"name":"business-hours","start":"0 8 * * mon-fri","duration":"10h","capacityUnits":5000}],"calendar":[{"name":"sale","start":"2026-11-27T00:00","end":"2026-11-28T00:00","capacityUnits":9000}]}`,
+					},
+				},
+			},
+			capacity: &elbv2.MinimumLoadBalancerCapacity{
+				CapacityUnits: 3000,
+			},
+			wantValue: &elbv2.MinimumLoadBalancerCapacity{
+				CapacityUnits: 3000,
+				Schedules: []elbv2.CapacityReservationSchedule{
+					{
+						Name:          "business-hours",
+						Start:         "0 8 * * mon-fri",
+						Duration:      metav1.Duration{Duration: 10 * time.Hour},
+						CapacityUnits: 5000,
+					},
+				},
+				Calendar: []elbv2.CapacityReservationCalendarEntry{
+					{
+						Name:          "sale",
+						Start:         "2026-11-27T00:00",
+						End:           "2026-11-28T00:00",
+						CapacityUnits: 9000,
+					},
+				},
+				TimeZone: "America/New_York",
+			},
+		},
+		{
+			testName: "schedule without capacity reservation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity-schedule": `{"schedules":[{"start":"0 22 * * *","duration":"2h","capacityUnits":5000}]}`,
+					},
+				},
+			},
+			wantValue: &elbv2.MinimumLoadBalancerCapacity{
+				Schedules: []elbv2.CapacityReservationSchedule{
+					{
+						Start:         "0 22 * * *",
+						Duration:      metav1.Duration{Duration: 2 * time.Hour},
+						CapacityUnits: 5000,
+					},
+				},
+			},
+		},
+		{
+			testName: "invalid schedule",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-minimum-load-balancer-capacity-schedule": `{"schedules":[{"name":"nightly","start":"0 25 * * *","duration":"2h","capacityUnits":5000}]}`,
+					},
+				},
+			},
+			wantError: "invalid aws-load-balancer-minimum-load-balancer-capacity-schedule annotation: invalid capacity reservation schedule nightly: invalid cron expression \"0 25 * * *\": value 25 out of range [0, 23] in hour field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			builder := &defaultModelBuildTask{
+				service:          tt.svc,
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				featureGates:     config.NewFeatureGates(),
+			}
+			got, err := builder.buildLoadBalancerCapacitySchedule(tt.capacity)
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantValue, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildManageSecurityGroupRulesFlag(t *testing.T) {
 	tests := []struct {
 		name                       string
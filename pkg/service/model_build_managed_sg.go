@@ -12,6 +12,7 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
@@ -54,6 +55,12 @@ var invalidSecurityGroupNamePtn, _ = regexp.Compile("[[:^alnum:]]")
 func (t *defaultModelBuildTask) buildManagedSecurityGroupName(_ context.Context) string {
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
+	if t.groupName != "" {
+		_, _ = uuidHash.Write([]byte(t.groupName))
+		uuid := hex.EncodeToString(uuidHash.Sum(nil))
+		payload := invalidSecurityGroupNamePtn.ReplaceAllString(t.groupName, "")
+		return fmt.Sprintf("k8s-%.17s-%.10s", payload, uuid)
+	}
 	_, _ = uuidHash.Write([]byte(t.service.Name))
 	_, _ = uuidHash.Write([]byte(t.service.Namespace))
 	_, _ = uuidHash.Write([]byte(t.service.UID))
@@ -72,11 +79,25 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(ctx
 	icmpForPathMtuConfigured := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixEnableIcmpForPathMtuDiscovery, &icmpForPathMtuConfiguredFlag, t.service.Annotations)
 	prefixListsConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSecurityGroupPrefixLists, &prefixListIDs, t.service.Annotations)
 
-	cidrs, err := t.buildCIDRsFromSourceRanges(ctx, ipAddressType, prefixListsConfigured)
+	for _, svc := range t.memberServices() {
+		svcPermissions, err := t.buildManagedSecurityGroupIngressPermissionsForService(ctx, svc, ipAddressType, prefixListIDs, prefixListsConfigured, icmpForPathMtuConfigured && icmpForPathMtuConfiguredFlag == "on")
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, svcPermissions...)
+	}
+	return permissions, nil
+}
+
+// buildManagedSecurityGroupIngressPermissionsForService builds the ingress permissions for the ports of a Service hosted by the load balancer.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissionsForService(ctx context.Context, svc *corev1.Service, ipAddressType elbv2model.IPAddressType,
+	prefixListIDs []string, prefixListsConfigured bool, icmpForPathMtuEnabled bool) ([]ec2model.IPPermission, error) {
+	var permissions []ec2model.IPPermission
+	cidrs, err := t.buildCIDRsFromSourceRangesForService(ctx, svc, ipAddressType, prefixListsConfigured)
 	if err != nil {
 		return nil, err
 	}
-	for _, port := range t.service.Spec.Ports {
+	for _, port := range svc.Spec.Ports {
 		listenPort := int32(port.Port)
 		for _, cidr := range cidrs {
 			if !strings.Contains(cidr, ":") {
@@ -90,7 +111,7 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(ctx
 						},
 					},
 				})
-				if icmpForPathMtuConfigured && icmpForPathMtuConfiguredFlag == "on" {
+				if icmpForPathMtuEnabled {
 					permissions = append(permissions, ec2model.IPPermission{
 						IPProtocol: shared_constants.ICMPV4Protocol,
 						FromPort:   awssdk.Int32(shared_constants.ICMPV4TypeForPathMtu),
@@ -113,7 +134,7 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(ctx
 						},
 					},
 				})
-				if icmpForPathMtuConfigured && icmpForPathMtuConfiguredFlag == "on" {
+				if icmpForPathMtuEnabled {
 					permissions = append(permissions, ec2model.IPPermission{
 						IPProtocol: shared_constants.ICMPV6Protocol,
 						FromPort:   awssdk.Int32(shared_constants.ICMPV6TypeForPathMtu),
@@ -146,13 +167,17 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(ctx
 	return permissions, nil
 }
 
-func (t *defaultModelBuildTask) buildCIDRsFromSourceRanges(_ context.Context, ipAddressType elbv2model.IPAddressType, prefixListsConfigured bool) ([]string, error) {
+func (t *defaultModelBuildTask) buildCIDRsFromSourceRanges(ctx context.Context, ipAddressType elbv2model.IPAddressType, prefixListsConfigured bool) ([]string, error) {
+	return t.buildCIDRsFromSourceRangesForService(ctx, t.service, ipAddressType, prefixListsConfigured)
+}
+
+func (t *defaultModelBuildTask) buildCIDRsFromSourceRangesForService(_ context.Context, svc *corev1.Service, ipAddressType elbv2model.IPAddressType, prefixListsConfigured bool) ([]string, error) {
 	var cidrs []string
-	for _, cidr := range t.service.Spec.LoadBalancerSourceRanges {
+	for _, cidr := range svc.Spec.LoadBalancerSourceRanges {
 		cidrs = append(cidrs, cidr)
 	}
 	if len(cidrs) == 0 {
-		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &cidrs, t.service.Annotations)
+		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &cidrs, svc.Annotations)
 	}
 	for _, cidr := range cidrs {
 		if strings.Contains(cidr, ":") && ipAddressType != elbv2model.IPAddressTypeDualStack {
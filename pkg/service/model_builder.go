@@ -13,8 +13,10 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/certs"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
@@ -38,6 +40,9 @@ const (
 type ModelBuilder interface {
 	// Build model stack for service
 	Build(ctx context.Context, service *corev1.Service, metricsCollector lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error)
+
+	// BuildGroup builds the model stack for the NLB shared by the members of a service group
+	BuildGroup(ctx context.Context, group ServiceGroup, metricsCollector lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error)
 }
 
 // NewDefaultModelBuilder construct a new defaultModelBuilder
@@ -46,7 +51,8 @@ func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver
 	elbv2TaggingManager elbv2deploy.TaggingManager, ec2Client services.EC2, featureGates config.FeatureGates, clusterName string, defaultTags map[string]string,
 	externalManagedTags []string, defaultSSLPolicy string, defaultTargetType string, defaultLoadBalancerScheme string, enableIPTargetType bool, serviceUtils ServiceUtils,
 	backendSGProvider networking.BackendSGProvider, sgResolver networking.SecurityGroupResolver, enableBackendSG bool, defaultEnableManageBackendSGRules bool,
-	disableRestrictedSGRules bool, logger logr.Logger, metricsCollector lbcmetrics.MetricCollector, tcpUdpEnabled bool, enhancedBackendBuilder EnhancedBackendBuilder) *defaultModelBuilder {
+	disableRestrictedSGRules bool, logger logr.Logger, metricsCollector lbcmetrics.MetricCollector, tcpUdpEnabled bool, enhancedBackendBuilder EnhancedBackendBuilder,
+	classParamsLoader ClassParamsLoader, classParamsMerger ClassParamsMerger, certDiscovery certs.CertDiscovery, enableACMCertificates bool, defaultCAArn string) *defaultModelBuilder {
 	return &defaultModelBuilder{
 		annotationParser:           annotationParser,
 		subnetsResolver:            subnetsResolver,
@@ -73,6 +79,11 @@ func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver
 		metricsCollector:           metricsCollector,
 		enableTCPUDPSupport:        tcpUdpEnabled,
 		enhancedBackendBuilder:     enhancedBackendBuilder,
+		classParamsLoader:          classParamsLoader,
+		classParamsMerger:          classParamsMerger,
+		certDiscovery:              certDiscovery,
+		enableACMCertificates:      enableACMCertificates,
+		defaultCAArn:               defaultCAArn,
 	}
 }
 
@@ -105,11 +116,38 @@ type defaultModelBuilder struct {
 	metricsCollector          lbcmetrics.MetricCollector
 	enableTCPUDPSupport       bool
 	enhancedBackendBuilder    EnhancedBackendBuilder
+	classParamsLoader         ClassParamsLoader
+	classParamsMerger         ClassParamsMerger
+	certDiscovery             certs.CertDiscovery
+	enableACMCertificates     bool
+	defaultCAArn              string
 }
 
 func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service, metricsCollector lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error) {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(service)))
-	task := &defaultModelBuildTask{
+	task := b.newModelBuildTask(service, stack)
+	if err := task.run(ctx); err != nil {
+		return nil, nil, false, err
+	}
+	return task.stack, task.loadBalancer, task.backendSGAllocated, nil
+}
+
+func (b *defaultModelBuilder) BuildGroup(ctx context.Context, group ServiceGroup, metricsCollector lbcmetrics.MetricCollector) (core.Stack, *elbv2model.LoadBalancer, bool, error) {
+	stack := core.NewDefaultStack(NewGroupStackID(group.Name))
+	if len(group.Members) == 0 {
+		return stack, nil, false, nil
+	}
+	task := b.newModelBuildTask(group.Members[0], stack)
+	task.groupName = group.Name
+	task.groupMembers = group.Members
+	if err := task.runGroup(ctx); err != nil {
+		return nil, nil, false, err
+	}
+	return task.stack, task.loadBalancer, task.backendSGAllocated, nil
+}
+
+func (b *defaultModelBuilder) newModelBuildTask(service *corev1.Service, stack core.Stack) *defaultModelBuildTask {
+	return &defaultModelBuildTask{
 		clusterName:                b.clusterName,
 		vpcID:                      b.vpcID,
 		annotationParser:           b.annotationParser,
@@ -128,6 +166,11 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		disableRestrictedSGRules:   b.disableRestrictedSGRules,
 		logger:                     b.logger,
 		metricsCollector:           b.metricsCollector,
+		classParamsLoader:          b.classParamsLoader,
+		classParamsMerger:          b.classParamsMerger,
+		certDiscovery:              b.certDiscovery,
+		enableACMCertificates:      b.enableACMCertificates,
+		defaultCAArn:               b.defaultCAArn,
 
 		service:   service,
 		stack:     stack,
@@ -166,11 +209,6 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		enhancedBackendBuilder:                                   b.enhancedBackendBuilder,
 		backendServices:                                          make(map[types.NamespacedName]*corev1.Service),
 	}
-
-	if err := task.run(ctx); err != nil {
-		return nil, nil, false, err
-	}
-	return task.stack, task.loadBalancer, task.backendSGAllocated, nil
 }
 
 type defaultModelBuildTask struct {
@@ -190,8 +228,18 @@ type defaultModelBuildTask struct {
 	ec2Client                  services.EC2
 	logger                     logr.Logger
 	metricsCollector           lbcmetrics.MetricCollector
+	classParamsLoader          ClassParamsLoader
+	classParamsMerger          ClassParamsMerger
+	certDiscovery              certs.CertDiscovery
+	enableACMCertificates      bool
+	defaultCAArn               string
 
-	service *corev1.Service
+	service        *corev1.Service
+	svcClassParams *elbv2api.ServiceClassParams
+	// groupName and groupMembers are only set when building the NLB shared by a service group,
+	// in which case service is the first member.
+	groupName    string
+	groupMembers []*corev1.Service
 
 	stack                    core.Stack
 	loadBalancer             *elbv2model.LoadBalancer
@@ -256,10 +304,53 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 		}
 		return nil
 	}
+	if err := t.applyClassParams(ctx); err != nil {
+		return ctrlerrors.NewErrorWithMetrics(controllerName, "apply_service_class_params_error", err, t.metricsCollector)
+	}
 	err := t.buildModel(ctx)
 	return err
 }
 
+// runGroup builds the NLB shared by the members of a service group.
+// NLB level settings come from the first member, while every member contributes the listeners and target groups for its ports.
+func (t *defaultModelBuildTask) runGroup(ctx context.Context) error {
+	var leaderClassParams *elbv2api.ServiceClassParams
+	members := make([]*corev1.Service, 0, len(t.groupMembers))
+	for i, member := range t.groupMembers {
+		t.service, t.svcClassParams = member, nil
+		if err := t.applyClassParams(ctx); err != nil {
+			return ctrlerrors.NewErrorWithMetrics(controllerName, "apply_service_class_params_error",
+				errors.Wrapf(err, "service: %v", k8s.NamespacedName(member)), t.metricsCollector)
+		}
+		if i == 0 {
+			leaderClassParams = t.svcClassParams
+		}
+		members = append(members, t.service)
+	}
+	t.groupMembers = members
+	t.useService(members[0])
+	t.svcClassParams = leaderClassParams
+	return t.buildModel(ctx)
+}
+
+// applyClassParams replaces the service with the effective settings after merging the ServiceClassParams of its loadBalancerClass.
+func (t *defaultModelBuildTask) applyClassParams(ctx context.Context) error {
+	svcClassParams, err := t.classParamsLoader.Load(ctx, t.service)
+	if err != nil {
+		return err
+	}
+	if svcClassParams == nil {
+		return nil
+	}
+	mergedSvc, err := t.classParamsMerger.Merge(svcClassParams, t.service)
+	if err != nil {
+		return err
+	}
+	t.service = mergedSvc
+	t.svcClassParams = svcClassParams
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
 	scheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
@@ -273,13 +364,53 @@ func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
 	if err != nil {
 		return ctrlerrors.NewErrorWithMetrics(controllerName, "build_load_balancer_error", err, t.metricsCollector)
 	}
-	err = t.buildListeners(ctx, scheme)
+	err = t.buildMemberListeners(ctx, scheme)
 	if err != nil {
 		return ctrlerrors.NewErrorWithMetrics(controllerName, "build_listeners_error", err, t.metricsCollector)
 	}
 	return nil
 }
 
+// buildMemberListeners builds the listeners of every Service hosted by the load balancer.
+func (t *defaultModelBuildTask) buildMemberListeners(ctx context.Context, scheme elbv2model.LoadBalancerScheme) error {
+	if len(t.groupMembers) == 0 {
+		return t.buildListeners(ctx, scheme)
+	}
+	leader := t.service
+	defer t.useService(leader)
+	for _, member := range t.groupMembers {
+		t.useService(member)
+		if err := t.buildListeners(ctx, scheme); err != nil {
+			return errors.Wrapf(err, "service: %v", k8s.NamespacedName(member))
+		}
+	}
+	return nil
+}
+
+// useService switches the Service that listener and target group settings are built from.
+func (t *defaultModelBuildTask) useService(svc *corev1.Service) {
+	t.service = svc
+	t.defaultHealthCheckPortForInstanceModeLocal = strconv.Itoa(int(svc.Spec.HealthCheckNodePort))
+}
+
+// memberServices returns the Services hosted by the load balancer.
+func (t *defaultModelBuildTask) memberServices() []*corev1.Service {
+	if len(t.groupMembers) == 0 {
+		return []*corev1.Service{t.service}
+	}
+	return t.groupMembers
+}
+
+// memberServiceKeys returns the keys of the Services hosted by the load balancer.
+func (t *defaultModelBuildTask) memberServiceKeys() []types.NamespacedName {
+	members := t.memberServices()
+	keys := make([]types.NamespacedName, 0, len(members))
+	for _, member := range members {
+		keys = append(keys, k8s.NamespacedName(member))
+	}
+	return keys
+}
+
 func (t *defaultModelBuildTask) getDeletionProtectionViaAnnotation(svc corev1.Service) (bool, error) {
 	var lbAttributes map[string]string
 	_, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixLoadBalancerAttributes, &lbAttributes, svc.Annotations)
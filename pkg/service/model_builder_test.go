@@ -20,7 +20,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
@@ -28,7 +30,9 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	elbv2modelk8s "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -7114,7 +7118,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 				for _, call := range tt.fetchVPCInfoCalls {
 					vpcInfoProvider.EXPECT().FetchVPCInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(call.wantVPCInfo, call.err).AnyTimes()
 				}
-				serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", "service.k8s.aws/nlb", featureGates)
+				serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", []string{"service.k8s.aws/nlb"}, featureGates)
 				defaultTargetType := tt.defaultTargetType
 				if defaultTargetType == "" {
 					defaultTargetType = "instance"
@@ -7141,11 +7145,13 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 				mockMetricsCollector := lbcmetrics.NewMockCollector()
 				k8sSchema := runtime.NewScheme()
 				clientgoscheme.AddToScheme(k8sSchema)
+				elbv2api.AddToScheme(k8sSchema)
 				k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
 				enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, logr.Logger{})
 				builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, vpcInfoProvider, "vpc-xxx", trackingProvider, elbv2TaggingManager, ec2Client, featureGates,
 					"my-cluster", nil, nil, "ELBSecurityPolicy-2016-08", defaultTargetType, defaultLoadBalancerScheme, enableIPTargetType, serviceUtils,
-					backendSGProvider, sgResolver, tt.enableBackendSG, tt.enableManageBackendSGRules, tt.disableRestrictedSGRules, logr.New(&log.NullLogSink{}), mockMetricsCollector, tcpUdpEnabled, enhancedBackendBuilder)
+					backendSGProvider, sgResolver, tt.enableBackendSG, tt.enableManageBackendSGRules, tt.disableRestrictedSGRules, logr.New(&log.NullLogSink{}), mockMetricsCollector, tcpUdpEnabled, enhancedBackendBuilder,
+					NewDefaultClassParamsLoader(k8sClient), NewDefaultClassParamsMerger("service.beta.kubernetes.io", annotationParser), nil, false, "")
 				ctx := context.Background()
 				stack, _, _, err := builder.Build(ctx, tt.svc, mockMetricsCollector)
 				if tt.wantError {
@@ -7167,3 +7173,123 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 		}
 	}
 }
+
+func Test_defaultModelBuilder_BuildGroup(t *testing.T) {
+	nlbClass := "service.k8s.aws/nlb"
+	groupedSvc := func(name string, uid string, port int32, annotations map[string]string) *corev1.Service {
+		svcAnnotations := map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-group-name":      "shared",
+			"service.beta.kubernetes.io/aws-load-balancer-nlb-target-type": "ip",
+		}
+		for k, v := range annotations {
+			svcAnnotations[k] = v
+		}
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(uid), Annotations: svcAnnotations},
+			Spec: corev1.ServiceSpec{
+				Type:              corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass: &nlbClass,
+				Ports: []corev1.ServicePort{
+					{Protocol: corev1.ProtocolTCP, Port: port, TargetPort: intstr.FromInt(8080)},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		group             ServiceGroup
+		wantLBName        string
+		wantScheme        elbv2model.LoadBalancerScheme
+		wantListenerPorts []int32
+		wantTGBServices   []string
+		wantSGPorts       []int32
+	}{
+		{
+			name:  "group without members",
+			group: ServiceGroup{Name: "shared"},
+		},
+		{
+			name: "members share a single load balancer",
+			group: ServiceGroup{
+				Name: "shared",
+				Members: []*corev1.Service{
+					groupedSvc("svc-a", "uid-a", 80, map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing",
+					}),
+					groupedSvc("svc-b", "uid-b", 443, map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+					}),
+				},
+			},
+			wantLBName:        "k8s-shared-842dfc2449",
+			wantScheme:        elbv2model.LoadBalancerSchemeInternetFacing,
+			wantListenerPorts: []int32{80, 443},
+			wantTGBServices:   []string{"svc-a", "svc-b"},
+			wantSGPorts:       []int32{80, 443},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subnetsResolver := networking.NewMockSubnetsResolver(ctrl)
+			subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return([]ec2types.Subnet{
+				{SubnetId: awssdk.String("subnet-1"), CidrBlock: awssdk.String("192.168.0.0/19")},
+			}, nil).AnyTimes()
+			elbv2TaggingManager := elbv2.NewMockTaggingManager(ctrl)
+			elbv2TaggingManager.EXPECT().ListLoadBalancers(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			vpcInfoProvider := networking.NewMockVPCInfoProvider(ctrl)
+			vpcInfoProvider.EXPECT().FetchVPCInfo(gomock.Any(), gomock.Any(), gomock.Any()).Return(networking.VPCInfo{}, nil).AnyTimes()
+			featureGates := config.NewFeatureGates()
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "my-cluster")
+			serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", []string{nlbClass}, featureGates)
+			mockMetricsCollector := lbcmetrics.NewMockCollector()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, vpcInfoProvider, "vpc-xxx", trackingProvider, elbv2TaggingManager, services.NewMockEC2(ctrl), featureGates,
+				"my-cluster", nil, nil, "ELBSecurityPolicy-2016-08", "instance", string(elbv2model.LoadBalancerSchemeInternal), true, serviceUtils,
+				networking.NewMockBackendSGProvider(ctrl), networking.NewMockSecurityGroupResolver(ctrl), false, false, false, logr.New(&log.NullLogSink{}), mockMetricsCollector, false,
+				NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, logr.Logger{}),
+				NewDefaultClassParamsLoader(k8sClient), NewDefaultClassParamsMerger("service.beta.kubernetes.io", annotationParser), nil, false, "")
+
+			stack, lb, _, err := builder.BuildGroup(context.Background(), tt.group, mockMetricsCollector)
+			assert.NoError(t, err)
+			assert.Equal(t, NewGroupStackID("shared"), stack.StackID())
+			if tt.wantLBName == "" {
+				assert.Nil(t, lb)
+				return
+			}
+			assert.Equal(t, tt.wantLBName, lb.Spec.Name)
+			assert.Equal(t, tt.wantScheme, lb.Spec.Scheme)
+
+			var listeners []*elbv2model.Listener
+			assert.NoError(t, stack.ListResources(&listeners))
+			var listenerPorts []int32
+			for _, ls := range listeners {
+				listenerPorts = append(listenerPorts, ls.Spec.Port)
+			}
+			assert.ElementsMatch(t, tt.wantListenerPorts, listenerPorts)
+
+			var tgbs []*elbv2modelk8s.TargetGroupBindingResource
+			assert.NoError(t, stack.ListResources(&tgbs))
+			var tgbServices []string
+			for _, tgb := range tgbs {
+				tgbServices = append(tgbServices, tgb.Spec.Template.Spec.ServiceRef.Name)
+			}
+			assert.ElementsMatch(t, tt.wantTGBServices, tgbServices)
+
+			var sgs []*ec2model.SecurityGroup
+			assert.NoError(t, stack.ListResources(&sgs))
+			assert.Len(t, sgs, 1)
+			var sgPorts []int32
+			for _, permission := range sgs[0].Spec.Ingress {
+				sgPorts = append(sgPorts, awssdk.ToInt32(permission.FromPort))
+			}
+			assert.ElementsMatch(t, tt.wantSGPorts, sgPorts)
+		})
+	}
+}
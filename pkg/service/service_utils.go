@@ -2,6 +2,7 @@ package service
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
@@ -16,23 +17,23 @@ type ServiceUtils interface {
 	IsServicePendingFinalization(service *corev1.Service) bool
 }
 
-func NewServiceUtils(annotationsParser annotations.Parser, serviceFinalizer string, loadBalancerClass string,
+func NewServiceUtils(annotationsParser annotations.Parser, serviceFinalizer string, loadBalancerClasses []string,
 	featureGates config.FeatureGates) *defaultServiceUtils {
 	return &defaultServiceUtils{
-		annotationParser:  annotationsParser,
-		serviceFinalizer:  serviceFinalizer,
-		loadBalancerClass: loadBalancerClass,
-		featureGates:      featureGates,
+		annotationParser:    annotationsParser,
+		serviceFinalizer:    serviceFinalizer,
+		loadBalancerClasses: sets.New(loadBalancerClasses...),
+		featureGates:        featureGates,
 	}
 }
 
 var _ ServiceUtils = (*defaultServiceUtils)(nil)
 
 type defaultServiceUtils struct {
-	annotationParser  annotations.Parser
-	serviceFinalizer  string
-	loadBalancerClass string
-	featureGates      config.FeatureGates
+	annotationParser    annotations.Parser
+	serviceFinalizer    string
+	loadBalancerClasses sets.Set[string]
+	featureGates        config.FeatureGates
 }
 
 // IsServicePendingFinalization returns true if service has the aws-load-balancer-controller finalizer
@@ -52,11 +53,7 @@ func (u *defaultServiceUtils) IsServiceSupported(service *corev1.Service) bool {
 		return false
 	}
 	if service.Spec.LoadBalancerClass != nil {
-		if *service.Spec.LoadBalancerClass == u.loadBalancerClass {
-			return true
-		} else {
-			return false
-		}
+		return u.loadBalancerClasses.Has(*service.Spec.LoadBalancerClass)
 	}
 	return u.checkAWSLoadBalancerTypeAnnotation(service)
 }
@@ -216,6 +216,39 @@ func Test_defaultServiceUtils_IsServiceSupported(t *testing.T) {
 			restrictToTypeLoadBalancer: true,
 			want:                       true,
 		},
+		{
+			name: "spec.loadBalancerClass of an additional class",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nlb-ip",
+					Namespace: "default",
+				},
+				Spec: corev1.ServiceSpec{
+					Type:              corev1.ServiceTypeLoadBalancer,
+					LoadBalancerClass: awssdk.String("service.k8s.aws/nlb-internal"),
+					Selector:          map[string]string{"app": "hello"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "spec.loadBalancerClass of another controller",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nlb-ip",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:              corev1.ServiceTypeLoadBalancer,
+					LoadBalancerClass: awssdk.String("example.com/lb"),
+					Selector:          map[string]string{"app": "hello"},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -224,7 +257,7 @@ func Test_defaultServiceUtils_IsServiceSupported(t *testing.T) {
 			if tt.restrictToTypeLoadBalancer {
 				featureGates.Enable(config.ServiceTypeLoadBalancerOnly)
 			}
-			serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", "service.k8s.aws/nlb", featureGates)
+			serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", []string{"service.k8s.aws/nlb", "service.k8s.aws/nlb-internal"}, featureGates)
 			got := serviceUtils.IsServiceSupported(tt.svc)
 			assert.Equal(t, tt.want, got)
 		})
@@ -273,7 +306,7 @@ func Test_defaultServiceUtils_IsServicePendingFinalization(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			featureGates := config.NewFeatureGates()
-			serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", "service.k8s.aws/nlb", featureGates)
+			serviceUtils := NewServiceUtils(annotationParser, "service.k8s.aws/resources", []string{"service.k8s.aws/nlb"}, featureGates)
 			got := serviceUtils.IsServicePendingFinalization(tt.svc)
 			assert.Equal(t, tt.want, got)
 		})
@@ -10,6 +10,9 @@ const (
 	// ServiceFinalizer the finalizer used on service resources
 	ServiceFinalizer = "service.k8s.aws/resources"
 
+	// ServiceGroupFinalizerPrefix the prefix for finalizers applied to members of a service group
+	ServiceGroupFinalizerPrefix = "group.service.k8s.aws/"
+
 	// GatewayClassFinalizer the finalizer we attach to an in-use LBC GatewayClass
 	GatewayClassFinalizer = "gateway.k8s.aws/gatewayclass"
 
@@ -30,4 +33,7 @@ const (
 
 	// GlobalAcceleratorFinalizer the finalizer we attach to a global accelerator resource
 	GlobalAcceleratorFinalizer = "aga.k8s.aws/resources"
+
+	// WAFv2WebACLFinalizer the finalizer we attach to a WAFv2WebACL resource
+	WAFv2WebACLFinalizer = "elbv2.k8s.aws/wafv2-web-acl"
 )
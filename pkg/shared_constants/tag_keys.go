@@ -4,6 +4,9 @@ const (
 	// TagKeyK8sCluster AWS TagKey for cluster resources.
 	TagKeyK8sCluster = "elbv2.k8s.aws/cluster"
 
+	// TagKeyK8sClusterRegion AWS TagKey for the region of the cluster, applied on resources of global services.
+	TagKeyK8sClusterRegion = "elbv2.k8s.aws/cluster-region"
+
 	// TagKeyResource AWS TagKey to denote what resource is being represented.
 	TagKeyResource = "elbv2.k8s.aws/resource"
 )
@@ -2,8 +2,12 @@ package aga
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +21,7 @@ const (
 	// Condition type constants
 	ConditionTypeReady                = "Ready"
 	ConditionTypeAcceleratorDisabling = "AcceleratorDisabling"
+	ConditionTypeIPAddressesSynced    = "IPAddressesSynced"
 
 	// Reason constants
 	ReasonAcceleratorReady        = "AcceleratorReady"
@@ -24,6 +29,15 @@ const (
 	ReasonAcceleratorDisabling    = "AcceleratorDisabling"
 	ReasonAcceleratorDeleting     = "AcceleratorDeleting"
 
+	// IP addresses reason constants
+	ReasonIPAddressesMatchSpec         = "IPAddressesMatchSpec"
+	ReasonIPAddressesDifferFromSpec    = "IPAddressesDifferFromSpec"
+	ReasonIPAddressTypeDiffersFromSpec = "IPAddressTypeDiffersFromSpec"
+
+	// IP address families of IP sets
+	ipAddressFamilyIPv4 = "IPv4"
+	ipAddressFamilyIPv6 = "IPv6"
+
 	// Status constants
 	StatusDeployed   = "DEPLOYED"
 	StatusInProgress = "IN_PROGRESS"
@@ -153,6 +167,11 @@ func (u *defaultStatusUpdater) UpdateStatusSuccess(ctx context.Context, ga *v1be
 		needPatch = true
 	}
 
+	// Update IP addresses condition, as the static IP addresses can differ from the spec until the accelerator is replaced
+	if u.updateIPAddressesCondition(ga) {
+		needPatch = true
+	}
+
 	// Skip status update if observed generation already matches and nothing else changed
 	if ga.Status.ObservedGeneration != nil && *ga.Status.ObservedGeneration == ga.Generation && !needPatch {
 		u.logger.V(1).Info("Skipping status update - no changes needed", "globalAccelerator", k8s.NamespacedName(ga))
@@ -196,6 +215,11 @@ func (u *defaultStatusUpdater) UpdateStatusFailure(ctx context.Context, ga *v1be
 		needPatch = true
 	}
 
+	// Update IP addresses condition, the failure may be a change releasing the static IP addresses that isn't confirmed
+	if u.updateIPAddressesCondition(ga) {
+		needPatch = true
+	}
+
 	// Skip status update if observed generation already matches and nothing else changed
 	if ga.Status.ObservedGeneration != nil && *ga.Status.ObservedGeneration == ga.Generation && !needPatch {
 		u.logger.V(1).Info("Skipping status update - no changes needed", "globalAccelerator", k8s.NamespacedName(ga))
@@ -305,6 +329,73 @@ func (u *defaultStatusUpdater) updateCondition(conditions *[]metav1.Condition, n
 	return true
 }
 
+// updateIPAddressesCondition updates the condition comparing the static IP addresses of the accelerator with the spec.
+func (u *defaultStatusUpdater) updateIPAddressesCondition(ga *v1beta1.GlobalAccelerator) bool {
+	// The accelerator has no static IP addresses before its creation
+	if len(ga.Status.IPSets) == 0 {
+		return false
+	}
+	return u.updateCondition(&ga.Status.Conditions, u.buildIPAddressesCondition(ga))
+}
+
+// buildIPAddressesCondition compares the static IP addresses of the accelerator with the IP addresses and IP address type in the spec.
+func (u *defaultStatusUpdater) buildIPAddressesCondition(ga *v1beta1.GlobalAccelerator) metav1.Condition {
+	var ipv4Addresses, ipv6Addresses []string
+	for _, ipSet := range ga.Status.IPSets {
+		if ipSet.IpAddresses == nil {
+			continue
+		}
+		switch awssdk.ToString(ipSet.IpAddressFamily) {
+		case ipAddressFamilyIPv4:
+			ipv4Addresses = append(ipv4Addresses, *ipSet.IpAddresses...)
+		case ipAddressFamilyIPv6:
+			ipv6Addresses = append(ipv6Addresses, *ipSet.IpAddresses...)
+		}
+	}
+
+	var missingIPAddresses []string
+	if ga.Spec.IpAddresses != nil {
+		for _, ipAddress := range *ga.Spec.IpAddresses {
+			if !slices.Contains(ipv4Addresses, ipAddress) {
+				missingIPAddresses = append(missingIPAddresses, ipAddress)
+			}
+		}
+	}
+	if len(missingIPAddresses) > 0 {
+		return metav1.Condition{
+			Type:               ConditionTypeIPAddressesSynced,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonIPAddressesDifferFromSpec,
+			Message: fmt.Sprintf("Accelerator IP addresses %s don't include requested IP addresses %s, the accelerator must be replaced to assign them",
+				strings.Join(ipv4Addresses, ", "), strings.Join(missingIPAddresses, ", ")),
+		}
+	}
+
+	wantDualStack := ga.Spec.IPAddressType == v1beta1.IPAddressTypeDualStack
+	if wantDualStack != (len(ipv6Addresses) > 0) {
+		ipAddressType := ga.Spec.IPAddressType
+		if ipAddressType == "" {
+			ipAddressType = v1beta1.IPAddressTypeIPV4
+		}
+		return metav1.Condition{
+			Type:               ConditionTypeIPAddressesSynced,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonIPAddressTypeDiffersFromSpec,
+			Message:            fmt.Sprintf("Accelerator IP addresses don't match requested IP address type %s", ipAddressType),
+		}
+	}
+
+	return metav1.Condition{
+		Type:               ConditionTypeIPAddressesSynced,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonIPAddressesMatchSpec,
+		Message:            "Accelerator IP addresses match the spec",
+	}
+}
+
 // areIPSetsEqual compares two slices of IPSets for equality
 func (u *defaultStatusUpdater) areIPSetsEqual(existing []v1beta1.IPSet, new []v1beta1.IPSet) bool {
 	return reflect.DeepEqual(existing, new)
@@ -58,11 +58,15 @@ func Test_defaultStatusUpdater_UpdateStatusSuccess(t *testing.T) {
 				assert.Equal(t, "DEPLOYED", *ga.Status.Status)
 
 				// Check that the condition was added correctly
-				assert.Len(t, ga.Status.Conditions, 1)
+				assert.Len(t, ga.Status.Conditions, 2)
 				condition := ga.Status.Conditions[0]
 				assert.Equal(t, ConditionTypeReady, condition.Type)
 				assert.Equal(t, metav1.ConditionTrue, condition.Status)
 				assert.Equal(t, ReasonAcceleratorReady, condition.Reason)
+				ipAddressesCondition := ga.Status.Conditions[1]
+				assert.Equal(t, ConditionTypeIPAddressesSynced, ipAddressesCondition.Type)
+				assert.Equal(t, metav1.ConditionTrue, ipAddressesCondition.Status)
+				assert.Equal(t, ReasonIPAddressesMatchSpec, ipAddressesCondition.Reason)
 			},
 		},
 		{
@@ -101,11 +105,15 @@ func Test_defaultStatusUpdater_UpdateStatusSuccess(t *testing.T) {
 				assert.Equal(t, "IN_PROGRESS", *ga.Status.Status)
 
 				// Check that the condition was added correctly - should be Unknown while provisioning
-				assert.Len(t, ga.Status.Conditions, 1)
+				assert.Len(t, ga.Status.Conditions, 2)
 				condition := ga.Status.Conditions[0]
 				assert.Equal(t, ConditionTypeReady, condition.Type)
 				assert.Equal(t, metav1.ConditionUnknown, condition.Status)
 				assert.Equal(t, ReasonAcceleratorProvisioning, condition.Reason)
+				ipAddressesCondition := ga.Status.Conditions[1]
+				assert.Equal(t, ConditionTypeIPAddressesSynced, ipAddressesCondition.Type)
+				assert.Equal(t, metav1.ConditionTrue, ipAddressesCondition.Status)
+				assert.Equal(t, ReasonIPAddressesMatchSpec, ipAddressesCondition.Reason)
 			},
 		},
 		{
@@ -175,6 +183,13 @@ func Test_defaultStatusUpdater_UpdateStatusSuccess(t *testing.T) {
 							Reason:             ReasonAcceleratorReady,
 							Message:            "GlobalAccelerator is ready and available",
 						},
+						{
+							Type:               ConditionTypeIPAddressesSynced,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.Now(),
+							Reason:             ReasonIPAddressesMatchSpec,
+							Message:            "Accelerator IP addresses match the spec",
+						},
 					},
 					IPSets: []v1beta1.IPSet{
 						{
@@ -343,6 +358,41 @@ func Test_defaultStatusUpdater_UpdateStatusFailure(t *testing.T) {
 				assert.Equal(t, "Reconciliation failed. See events and controller logs for details", condition.Message)
 			},
 		},
+		{
+			name: "Report IP addresses differing from spec",
+			ga: &v1beta1.GlobalAccelerator{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-ga-ip-addresses-differ",
+					Namespace:  "default",
+					Generation: 3,
+				},
+				Spec: v1beta1.GlobalAcceleratorSpec{
+					IpAddresses: &[]string{"198.51.100.10"},
+				},
+				Status: v1beta1.GlobalAcceleratorStatus{
+					ObservedGeneration: func() *int64 { i := int64(2); return &i }(),
+					IPSets: []v1beta1.IPSet{
+						{
+							IpAddressFamily: func() *string { s := "IPv4"; return &s }(),
+							IpAddresses:     func() *[]string { s := []string{"192.0.2.250", "198.51.100.52"}; return &s }(),
+						},
+					},
+				},
+			},
+			reason:  "ReplacementNotConfirmed",
+			message: "Reconciliation failed. See events and controller logs for details",
+			validateStatus: func(t *testing.T, ga *v1beta1.GlobalAccelerator) {
+				assert.Len(t, ga.Status.Conditions, 2)
+				condition := ga.Status.Conditions[0]
+				assert.Equal(t, ConditionTypeReady, condition.Type)
+				assert.Equal(t, "ReplacementNotConfirmed", condition.Reason)
+				ipAddressesCondition := ga.Status.Conditions[1]
+				assert.Equal(t, ConditionTypeIPAddressesSynced, ipAddressesCondition.Type)
+				assert.Equal(t, metav1.ConditionFalse, ipAddressesCondition.Status)
+				assert.Equal(t, ReasonIPAddressesDifferFromSpec, ipAddressesCondition.Reason)
+				assert.Equal(t, "Accelerator IP addresses 192.0.2.250, 198.51.100.52 don't include requested IP addresses 198.51.100.10, the accelerator must be replaced to assign them", ipAddressesCondition.Message)
+			},
+		},
 		{
 			name: "Skip update when already in sync",
 			ga: &v1beta1.GlobalAccelerator{
@@ -412,6 +462,75 @@ func Test_defaultStatusUpdater_UpdateStatusFailure(t *testing.T) {
 	}
 }
 
+func Test_defaultStatusUpdater_buildIPAddressesCondition(t *testing.T) {
+	ipv4IPSet := v1beta1.IPSet{
+		IpAddressFamily: func() *string { s := "IPv4"; return &s }(),
+		IpAddresses:     func() *[]string { s := []string{"198.51.100.10", "99.83.0.1"}; return &s }(),
+	}
+	ipv6IPSet := v1beta1.IPSet{
+		IpAddressFamily: func() *string { s := "IPv6"; return &s }(),
+		IpAddresses:     func() *[]string { s := []string{"2001:db8::1", "2001:db8::2"}; return &s }(),
+	}
+	tests := []struct {
+		name       string
+		spec       v1beta1.GlobalAcceleratorSpec
+		ipSets     []v1beta1.IPSet
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "Amazon IP addresses",
+			spec:       v1beta1.GlobalAcceleratorSpec{IPAddressType: v1beta1.IPAddressTypeIPV4},
+			ipSets:     []v1beta1.IPSet{ipv4IPSet},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ReasonIPAddressesMatchSpec,
+		},
+		{
+			name:       "BYOIP address assigned alongside Amazon IP address",
+			spec:       v1beta1.GlobalAcceleratorSpec{IpAddresses: &[]string{"198.51.100.10"}},
+			ipSets:     []v1beta1.IPSet{ipv4IPSet},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ReasonIPAddressesMatchSpec,
+		},
+		{
+			name:       "BYOIP address not assigned",
+			spec:       v1beta1.GlobalAcceleratorSpec{IpAddresses: &[]string{"198.51.100.10", "203.0.113.10"}},
+			ipSets:     []v1beta1.IPSet{ipv4IPSet},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonIPAddressesDifferFromSpec,
+		},
+		{
+			name:       "Dual stack requested for IPv4 accelerator",
+			spec:       v1beta1.GlobalAcceleratorSpec{IPAddressType: v1beta1.IPAddressTypeDualStack},
+			ipSets:     []v1beta1.IPSet{ipv4IPSet},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonIPAddressTypeDiffersFromSpec,
+		},
+		{
+			name:       "IPv4 requested for dual stack accelerator",
+			spec:       v1beta1.GlobalAcceleratorSpec{IPAddressType: v1beta1.IPAddressTypeIPV4},
+			ipSets:     []v1beta1.IPSet{ipv4IPSet, ipv6IPSet},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonIPAddressTypeDiffersFromSpec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater := &defaultStatusUpdater{
+				logger: logr.Discard(),
+			}
+			ga := &v1beta1.GlobalAccelerator{
+				Spec:   tt.spec,
+				Status: v1beta1.GlobalAcceleratorStatus{IPSets: tt.ipSets},
+			}
+			condition := updater.buildIPAddressesCondition(ga)
+			assert.Equal(t, ConditionTypeIPAddressesSynced, condition.Type)
+			assert.Equal(t, tt.wantStatus, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+		})
+	}
+}
+
 func Test_defaultStatusUpdater_UpdateStatusDeletion(t *testing.T) {
 	// Setup test cases
 	tests := []struct {
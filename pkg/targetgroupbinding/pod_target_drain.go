@@ -0,0 +1,95 @@
+package targetgroupbinding
+
+import (
+	"strings"
+	"time"
+
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+const (
+	// PodTargetDrainReasonPending indicates the pod termination is intercepted and its targets are pending deregistration.
+	PodTargetDrainReasonPending = "DeregistrationPending"
+	// PodTargetDrainReasonDraining indicates the pod's targets are deregistered and draining from the TargetGroup.
+	PodTargetDrainReasonDraining = "Draining"
+	// PodTargetDrainReasonDrained indicates the pod's targets are no longer in use by the TargetGroup.
+	PodTargetDrainReasonDrained = "Drained"
+)
+
+// PodTargetDrainStatus summarizes the targetDrain conditions of a pod.
+type PodTargetDrainStatus struct {
+	// Gated is whether the pod has any targetDrain condition, i.e. its termination is being held.
+	Gated bool
+	// Drained is whether all targetDrain conditions of the pod are true.
+	Drained bool
+	// RequestedAt is the time the pod termination is intercepted, which is the earliest transition time among pending conditions.
+	RequestedAt time.Time
+}
+
+// IsTimedOut returns whether the pod is still draining after timeout.
+func (s PodTargetDrainStatus) IsTimedOut(timeout time.Duration, now time.Time) bool {
+	return s.Gated && !s.Drained && now.Sub(s.RequestedAt) >= timeout
+}
+
+// ComputePodTargetDrainStatus computes the PodTargetDrainStatus from pod conditions.
+func ComputePodTargetDrainStatus(conditions []corev1.PodCondition) PodTargetDrainStatus {
+	var status PodTargetDrainStatus
+	allDrained := true
+	for _, cond := range conditions {
+		if !strings.HasPrefix(string(cond.Type), TargetDrainPodConditionTypePrefix+"/") {
+			continue
+		}
+		status.Gated = true
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		allDrained = false
+		if status.RequestedAt.IsZero() || cond.LastTransitionTime.Time.Before(status.RequestedAt) {
+			status.RequestedAt = cond.LastTransitionTime.Time
+		}
+	}
+	status.Drained = status.Gated && allDrained
+	return status
+}
+
+// partitionPodEndpointsByTargetDrainCondition partitions pod endpoints into endpoints that should be registered,
+// and endpoints whose pod termination is being held for targetDrainCondType.
+func partitionPodEndpointsByTargetDrainCondition(endpoints []backend.PodEndpoint, targetDrainCondType corev1.PodConditionType) ([]backend.PodEndpoint, []backend.PodEndpoint) {
+	var activeEndpoints, terminatingEndpoints []backend.PodEndpoint
+	for _, endpoint := range endpoints {
+		if _, exists := endpoint.Pod.GetPodCondition(targetDrainCondType); exists {
+			terminatingEndpoints = append(terminatingEndpoints, endpoint)
+		} else {
+			activeEndpoints = append(activeEndpoints, endpoint)
+		}
+	}
+	return activeEndpoints, terminatingEndpoints
+}
+
+// needTargetDrainTransition returns whether any terminating pod still has a pending targetDrain condition.
+func needTargetDrainTransition(terminatingEndpoints []backend.PodEndpoint, targetDrainCondType corev1.PodConditionType) bool {
+	for _, endpoint := range terminatingEndpoints {
+		cond, _ := endpoint.Pod.GetPodCondition(targetDrainCondType)
+		if cond.Status != corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// findPodTargetNotDrained returns the first target of pod that is still in use by the TargetGroup.
+func findPodTargetNotDrained(pod k8s.PodInfo, targets []TargetInfo) (TargetInfo, bool) {
+	for _, target := range targets {
+		if target.Target.Id == nil || *target.Target.Id != pod.PodIP {
+			continue
+		}
+		if target.TargetHealth != nil && target.TargetHealth.State == elbv2types.TargetHealthStateEnumUnused {
+			continue
+		}
+		return target, true
+	}
+	return TargetInfo{}, false
+}
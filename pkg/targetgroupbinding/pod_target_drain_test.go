@@ -0,0 +1,195 @@
+package targetgroupbinding
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+func TestComputePodTargetDrainStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		want       PodTargetDrainStatus
+	}{
+		{
+			name: "pod without targetDrain conditions",
+			conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				{Type: "target-health.elbv2.k8s.aws/tgb-1", Status: corev1.ConditionTrue},
+			},
+			want: PodTargetDrainStatus{},
+		},
+		{
+			name: "pod with pending targetDrain conditions",
+			conditions: []corev1.PodCondition{
+				{Type: "target-drain.elbv2.k8s.aws/tgb-1", Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+				{Type: "target-drain.elbv2.k8s.aws/tgb-2", Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute))},
+				{Type: "target-drain.elbv2.k8s.aws/tgb-3", Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute))},
+			},
+			want: PodTargetDrainStatus{
+				Gated:       true,
+				Drained:     false,
+				RequestedAt: now.Add(-2 * time.Minute),
+			},
+		},
+		{
+			name: "pod with all targetDrain conditions true",
+			conditions: []corev1.PodCondition{
+				{Type: "target-drain.elbv2.k8s.aws/tgb-1", Status: corev1.ConditionTrue},
+				{Type: "target-drain.elbv2.k8s.aws/tgb-2", Status: corev1.ConditionTrue},
+			},
+			want: PodTargetDrainStatus{
+				Gated:   true,
+				Drained: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputePodTargetDrainStatus(tt.conditions)
+			assert.Equal(t, tt.want.Gated, got.Gated)
+			assert.Equal(t, tt.want.Drained, got.Drained)
+			assert.True(t, tt.want.RequestedAt.Equal(got.RequestedAt), "want %v, got %v", tt.want.RequestedAt, got.RequestedAt)
+		})
+	}
+}
+
+func TestPodTargetDrainStatus_IsTimedOut(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status PodTargetDrainStatus
+		want   bool
+	}{
+		{
+			name:   "pod not gated",
+			status: PodTargetDrainStatus{},
+			want:   false,
+		},
+		{
+			name:   "pod already drained",
+			status: PodTargetDrainStatus{Gated: true, Drained: true, RequestedAt: now.Add(-time.Hour)},
+			want:   false,
+		},
+		{
+			name:   "pod draining within timeout",
+			status: PodTargetDrainStatus{Gated: true, RequestedAt: now.Add(-time.Minute)},
+			want:   false,
+		},
+		{
+			name:   "pod draining exceeds timeout",
+			status: PodTargetDrainStatus{Gated: true, RequestedAt: now.Add(-10 * time.Minute)},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.IsTimedOut(5*time.Minute, now))
+		})
+	}
+}
+
+func Test_partitionPodEndpointsByTargetDrainCondition(t *testing.T) {
+	activeEndpoint := backend.PodEndpoint{
+		IP:   "192.168.1.1",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		},
+	}
+	terminatingEndpoint := backend.PodEndpoint{
+		IP:   "192.168.1.2",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key: types.NamespacedName{Namespace: "default", Name: "pod-2"},
+			Conditions: []corev1.PodCondition{
+				{Type: "target-drain.elbv2.k8s.aws/my-tgb", Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	otherTGBTerminatingEndpoint := backend.PodEndpoint{
+		IP:   "192.168.1.3",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key: types.NamespacedName{Namespace: "default", Name: "pod-3"},
+			Conditions: []corev1.PodCondition{
+				{Type: "target-drain.elbv2.k8s.aws/other-tgb", Status: corev1.ConditionFalse},
+			},
+		},
+	}
+
+	activeEndpoints, terminatingEndpoints := partitionPodEndpointsByTargetDrainCondition(
+		[]backend.PodEndpoint{activeEndpoint, terminatingEndpoint, otherTGBTerminatingEndpoint},
+		"target-drain.elbv2.k8s.aws/my-tgb")
+	assert.Equal(t, []backend.PodEndpoint{activeEndpoint, otherTGBTerminatingEndpoint}, activeEndpoints)
+	assert.Equal(t, []backend.PodEndpoint{terminatingEndpoint}, terminatingEndpoints)
+	assert.True(t, needTargetDrainTransition(terminatingEndpoints, "target-drain.elbv2.k8s.aws/my-tgb"))
+}
+
+func Test_findPodTargetNotDrained(t *testing.T) {
+	pod := k8s.PodInfo{
+		Key:   types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		PodIP: "192.168.1.1",
+	}
+	tests := []struct {
+		name         string
+		targets      []TargetInfo
+		wantFound    bool
+		wantTargetID string
+	}{
+		{
+			name: "pod target is draining",
+			targets: []TargetInfo{
+				{
+					Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int32(8080)},
+					TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy},
+				},
+				{
+					Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+					TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumDraining},
+				},
+			},
+			wantFound:    true,
+			wantTargetID: "192.168.1.1",
+		},
+		{
+			name: "pod target is unused",
+			targets: []TargetInfo{
+				{
+					Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+					TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumUnused},
+				},
+			},
+			wantFound: false,
+		},
+		{
+			name: "pod target is not registered",
+			targets: []TargetInfo{
+				{
+					Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int32(8080)},
+					TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy},
+				},
+			},
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, found := findPodTargetNotDrained(pod, tt.targets)
+			assert.Equal(t, tt.wantFound, found)
+			if tt.wantFound {
+				assert.Equal(t, tt.wantTargetID, awssdk.ToString(target.Target.Id))
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	ctrlerrors "sigs.k8s.io/aws-load-balancer-controller/pkg/error"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/aws"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +36,9 @@ import (
 
 const (
 	controllerName = "targetGroupBinding"
+
+	// targetHealthReasonTargetControlAgentNotReady is the readiness gate reason of healthy targets whose ALB target control agent isn't ready.
+	targetHealthReasonTargetControlAgentNotReady elbv2types.TargetHealthReasonEnum = "ALBTargetControlAgentNotReady"
 )
 
 // ResourceManager manages the TargetGroupBinding resource.
@@ -46,8 +51,9 @@ type ResourceManager interface {
 func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2,
 	podInfoRepo k8s.PodInfoRepo, networkingManager networking.NetworkingManager,
 	vpcInfoProvider networking.VPCInfoProvider, multiClusterManager MultiClusterManager, metricsCollector lbcmetrics.MetricCollector,
-	vpcID string, failOpenEnabled bool, endpointSliceEnabled bool,
-	eventRecorder record.EventRecorder, logger logr.Logger, maxTargetsPerTargetGroup int, requeueDuration time.Duration) *defaultResourceManager {
+	targetGroupCollector awsmetrics.TargetGroupCollector, vpcID string, failOpenEnabled bool, endpointSliceEnabled bool,
+	eventRecorder record.EventRecorder, logger logr.Logger, maxTargetsPerTargetGroup int, requeueDuration time.Duration,
+	podTerminationGateTimeout time.Duration, targetHealthRefreshInterval time.Duration) *defaultResourceManager {
 
 	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
 	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, failOpenEnabled, endpointSliceEnabled, logger)
@@ -64,6 +70,7 @@ func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELB
 		maxTargetsPerTargetGroup: maxTargetsPerTargetGroup,
 		multiClusterManager:      multiClusterManager,
 		metricsCollector:         metricsCollector,
+		targetGroupCollector:     targetGroupCollector,
 
 		invalidVpcCache:    cache.NewExpiring(),
 		invalidVpcCacheTTL: defaultTargetsCacheTTL,
@@ -74,7 +81,11 @@ func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELB
 		nodeAZCache:    cache.NewExpiring(),
 		nodeAZCacheTTL: defaultNodeAZCacheTTL,
 
-		requeueDuration: requeueDuration,
+		targetHealthRefreshCache:    cache.NewExpiring(),
+		targetHealthRefreshInterval: targetHealthRefreshInterval,
+
+		requeueDuration:           requeueDuration,
+		podTerminationGateTimeout: podTerminationGateTimeout,
 	}
 }
 
@@ -93,6 +104,7 @@ type defaultResourceManager struct {
 	maxTargetsPerTargetGroup int
 	multiClusterManager      MultiClusterManager
 	metricsCollector         lbcmetrics.MetricCollector
+	targetGroupCollector     awsmetrics.TargetGroupCollector
 	vpcID                    string
 
 	invalidVpcCache      *cache.Expiring
@@ -113,7 +125,16 @@ type defaultResourceManager struct {
 	nodeAZCacheTTL   time.Duration
 	nodeAZCacheMutex sync.RWMutex
 
+	// targetHealthRefreshCache tracks TGBs whose target health status was refreshed within targetHealthRefreshInterval.
+	// The target health status is refreshed once the interval elapses even if the reconcile checkpoint is unchanged,
+	// a zero interval disables such refreshes.
+	targetHealthRefreshCache    *cache.Expiring
+	targetHealthRefreshInterval time.Duration
+
 	requeueDuration time.Duration
+
+	// podTerminationGateTimeout is the maximum duration a pod termination is held for its targets to drain.
+	podTerminationGateTimeout time.Duration
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (bool, error) {
@@ -158,6 +179,10 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.Targ
 	if err := m.updatePodAsHealthyForDeletedTGB(ctx, tgb); err != nil {
 		return err
 	}
+	m.targetGroupCollector.DeleteTargetHealth(tgb)
+	if m.targetHealthRefreshInterval > 0 {
+		m.targetHealthRefreshCache.Delete(k8s.NamespacedName(tgb))
+	}
 
 	return nil
 }
@@ -167,6 +192,7 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
 
 	targetHealthCondType := BuildTargetHealthPodConditionType(tgb)
+	targetDrainCondType := BuildTargetDrainPodConditionType(tgb)
 
 	var endpoints []backend.PodEndpoint
 	var err error
@@ -184,6 +210,17 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "resolve_pod_endpoints_error", err, m.metricsCollector)
 	}
 
+	// Pods whose termination is held by the termination gate are excluded, so that their targets get deregistered.
+	endpoints, terminatingEndpoints := partitionPodEndpointsByTargetDrainCondition(endpoints, targetDrainCondType)
+
+	if tgb.Spec.WeightedRegistration != nil {
+		quota, err := m.resolveWeightedRegistrationQuota(ctx, tgb, len(endpoints))
+		if err != nil {
+			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "resolve_weighted_registration_quota_error", err, m.metricsCollector)
+		}
+		endpoints = selectWeightedEndpoints(endpoints, quota)
+	}
+
 	newCheckPoint, err := calculateTGBReconcileCheckpoint(endpoints, tgb)
 
 	if err != nil {
@@ -193,8 +230,11 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 	// Block the checkpoint early-exit if any pod has a pending readiness gate condition in cache.
 	// Only compute when checkpoints match — if they differ the early-exit won't fire anyway.
 	if oldCheckPoint == newCheckPoint {
-		if !needReadinessGateFlip(endpoints, targetHealthCondType) {
+		if !needReadinessGateFlip(endpoints, targetHealthCondType) && !needTargetDrainTransition(terminatingEndpoints, targetDrainCondType) {
 			tgbScopedLogger.Info("Skipping targetgroupbinding reconcile", "calculated hash", newCheckPoint)
+			if err := m.refreshTGBTargetHealthStatusIfDue(ctx, tgb); err != nil {
+				return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+			}
 			return newCheckPoint, oldCheckPoint, true, nil
 		}
 	}
@@ -204,6 +244,10 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "list_targets_error", err, m.metricsCollector)
 	}
 
+	if err := m.updateTGBTargetHealthStatus(ctx, tgb, targets); err != nil {
+		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+	}
+
 	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
 	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(tgb, endpoints, notDrainingTargets)
 
@@ -263,6 +307,10 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tracked_ip_targets_error", err, m.metricsCollector)
 		}
 
+		if tgbProtocolSupportsQuic(tgb) {
+			unmatchedEndpoints = m.filterDuplicateQUICServerIDEndpoints(tgb, unmatchedEndpoints, matchedEndpointAndTargets)
+		}
+
 		if m.maxTargetsPerTargetGroup != 0 {
 			eligibleTargetsCount := m.getMaxNewTargets(len(unmatchedEndpoints), len(targets), tgbScopedLogger)
 			unmatchedEndpoints = unmatchedEndpoints[:eligibleTargetsCount]
@@ -277,16 +325,32 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tracked_ip_targets_error", err, m.metricsCollector)
 	}
 
+	if len(unmatchedEndpoints) > 0 || len(unmatchedTargets) > 0 {
+		if err := m.refreshTGBTargetHealthStatus(ctx, tgb); err != nil {
+			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+		}
+	}
+
 	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints, tgb)
 	if err != nil {
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_target_health_pod_condition_error", err, m.metricsCollector)
 	}
 
+	anyPodDraining, err := m.updateTargetDrainPodCondition(ctx, targetDrainCondType, terminatingEndpoints, targets)
+	if err != nil {
+		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_target_drain_pod_condition_error", err, m.metricsCollector)
+	}
+
 	if anyPodNeedFurtherProbe {
 		tgbScopedLogger.Info("Requeue for target monitor target health")
 		return "", "", false, ctrlerrors.NewRequeueNeededAfter("monitor targetHealth", m.requeueDuration)
 	}
 
+	if anyPodDraining {
+		tgbScopedLogger.Info("Requeue for monitor target drain")
+		return "", "", false, ctrlerrors.NewRequeueNeededAfter("monitor target drain", m.requeueDuration)
+	}
+
 	if needNetworkingRequeue {
 		tgbScopedLogger.Info("Requeue for networking requeue")
 		return "", "", false, ctrlerrors.NewRequeueNeededAfter("networking reconciliation", m.requeueDuration)
@@ -315,6 +379,14 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "resolve_nodeport_endpoints_error", err, m.metricsCollector)
 	}
 
+	if tgb.Spec.WeightedRegistration != nil {
+		quota, err := m.resolveWeightedRegistrationQuota(ctx, tgb, len(endpoints))
+		if err != nil {
+			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "resolve_weighted_registration_quota_error", err, m.metricsCollector)
+		}
+		endpoints = selectWeightedEndpoints(endpoints, quota)
+	}
+
 	newCheckPoint, err := calculateTGBReconcileCheckpoint(endpoints, tgb)
 
 	if err != nil {
@@ -323,6 +395,9 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 
 	if newCheckPoint == oldCheckPoint {
 		tgbScopedLogger.Info("Skipping targetgroupbinding reconcile", "calculated hash", newCheckPoint)
+		if err := m.refreshTGBTargetHealthStatusIfDue(ctx, tgb); err != nil {
+			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+		}
 		return newCheckPoint, oldCheckPoint, true, nil
 	}
 
@@ -331,6 +406,10 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "list_targets_error", err, m.metricsCollector)
 	}
 
+	if err := m.updateTGBTargetHealthStatus(ctx, tgb, targets); err != nil {
+		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+	}
+
 	notDrainingTargets, _ := partitionTargetsByDrainingStatus(targets)
 
 	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
@@ -378,6 +457,12 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tracked_instance_targets_error", err, m.metricsCollector)
 	}
 
+	if len(unmatchedEndpoints) > 0 || len(unmatchedTargets) > 0 {
+		if err := m.refreshTGBTargetHealthStatus(ctx, tgb); err != nil {
+			return "", "", false, ctrlerrors.NewErrorWithMetrics(controllerName, "update_tgb_target_health_status_error", err, m.metricsCollector)
+		}
+	}
+
 	tgbScopedLogger.Info("Successful reconcile", "checkpoint", newCheckPoint)
 	return newCheckPoint, oldCheckPoint, false, nil
 }
@@ -414,7 +499,7 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
 		pod := endpointAndTarget.endpoint.Pod
-		targetHealth := endpointAndTarget.target.TargetHealth
+		targetHealth := withTargetControlAgentReadiness(pod, endpointAndTarget.target.TargetHealth)
 		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType, tgb)
 		if err != nil {
 			return false, err
@@ -512,6 +597,20 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	return needFurtherProbe, nil
 }
 
+// withTargetControlAgentReadiness folds the readiness of the ALB target control agent of the pod into its target health,
+// a healthy target isn't considered ready until the agent proxying its traffic is.
+func withTargetControlAgentReadiness(pod k8s.PodInfo, targetHealth *elbv2types.TargetHealth) *elbv2types.TargetHealth {
+	if pod.TargetControlAgent == nil || pod.TargetControlAgent.Ready ||
+		targetHealth == nil || targetHealth.State != elbv2types.TargetHealthStateEnumHealthy {
+		return targetHealth
+	}
+	return &elbv2types.TargetHealth{
+		State:       elbv2types.TargetHealthStateEnumUnhealthy,
+		Reason:      targetHealthReasonTargetControlAgentNotReady,
+		Description: awssdk.String("ALB target control agent is not ready"),
+	}
+}
+
 func (m *defaultResourceManager) calculateReadinessGateTransition(pod k8s.PodInfo, targetHealthCondType corev1.PodConditionType, targetHealth *elbv2types.TargetHealth) (corev1.ConditionStatus, bool) {
 	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
 		return corev1.ConditionTrue, false
@@ -559,6 +658,130 @@ func (m *defaultResourceManager) updatePodAsHealthyForDeletedTGB(ctx context.Con
 	return nil
 }
 
+// updateTargetDrainPodCondition updates the targetDrain condition for pods whose termination is held,
+// and deletes pods once their targets are drained from all TargetGroups or the termination gate times out.
+// returns whether any pod is still draining from this TargetGroup.
+func (m *defaultResourceManager) updateTargetDrainPodCondition(ctx context.Context, targetDrainCondType corev1.PodConditionType,
+	terminatingEndpoints []backend.PodEndpoint, targets []TargetInfo) (bool, error) {
+	anyPodDraining := false
+	processedPods := sets.New[types.NamespacedName]()
+	for _, endpoint := range terminatingEndpoints {
+		pod := endpoint.Pod
+		if processedPods.Has(pod.Key) {
+			continue
+		}
+		processedPods.Insert(pod.Key)
+
+		newTargetDrainCond := corev1.PodCondition{
+			Type:    targetDrainCondType,
+			Status:  corev1.ConditionTrue,
+			Reason:  PodTargetDrainReasonDrained,
+			Message: "Targets are drained from the TargetGroup",
+		}
+		if target, notDrained := findPodTargetNotDrained(pod, targets); notDrained {
+			newTargetDrainCond.Status = corev1.ConditionFalse
+			newTargetDrainCond.Reason = PodTargetDrainReasonDraining
+			newTargetDrainCond.Message = "Target deregistration is in progress"
+			if target.TargetHealth != nil && target.TargetHealth.Description != nil {
+				newTargetDrainCond.Message = awssdk.ToString(target.TargetHealth.Description)
+			}
+		}
+
+		podConditions, err := m.patchPodCondition(ctx, pod, newTargetDrainCond)
+		if err != nil {
+			return false, err
+		}
+
+		drainStatus := ComputePodTargetDrainStatus(podConditions)
+		if drainStatus.Drained || drainStatus.IsTimedOut(m.podTerminationGateTimeout, time.Now()) {
+			if err := m.deleteTerminatingPod(ctx, pod, drainStatus); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if newTargetDrainCond.Status != corev1.ConditionTrue {
+			anyPodDraining = true
+		}
+	}
+	return anyPodDraining, nil
+}
+
+// patchPodCondition patches a single condition of pod, and returns the pod conditions after patch.
+func (m *defaultResourceManager) patchPodCondition(ctx context.Context, pod k8s.PodInfo, newCond corev1.PodCondition) ([]corev1.PodCondition, error) {
+	podConditions := make([]corev1.PodCondition, 0, len(pod.Conditions)+1)
+	for _, cond := range pod.Conditions {
+		if cond.Type != newCond.Type {
+			podConditions = append(podConditions, cond)
+		}
+	}
+
+	existingCond, hasExistingCond := pod.GetPodCondition(newCond.Type)
+	if hasExistingCond && existingCond.Status == newCond.Status {
+		newCond.LastTransitionTime = existingCond.LastTransitionTime
+	} else {
+		newCond.LastTransitionTime = metav1.Now()
+	}
+	podConditions = append(podConditions, newCond)
+	if hasExistingCond &&
+		existingCond.Status == newCond.Status &&
+		existingCond.Reason == newCond.Reason &&
+		existingCond.Message == newCond.Message {
+		return podConditions, nil
+	}
+
+	podPatchSource := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Key.Namespace,
+			Name:      pod.Key.Name,
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{},
+		},
+	}
+	if hasExistingCond {
+		podPatchSource.Status.Conditions = []corev1.PodCondition{existingCond}
+	}
+	podPatchTarget := podPatchSource.DeepCopy()
+	podPatchTarget.UID = pod.UID // only put the uid in the new object to ensure it appears in the patch as a precondition
+	podPatchTarget.Status.Conditions = []corev1.PodCondition{newCond}
+
+	if err := m.k8sClient.Status().Patch(ctx, podPatchTarget, client.StrategicMergeFrom(podPatchSource)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return podConditions, nil
+		}
+		return nil, err
+	}
+	return podConditions, nil
+}
+
+// deleteTerminatingPod deletes a pod whose termination was held by the termination gate.
+func (m *defaultResourceManager) deleteTerminatingPod(ctx context.Context, pod k8s.PodInfo, drainStatus PodTargetDrainStatus) error {
+	m.logger.Info("deleting pod held by termination gate", "pod", pod.Key, "drained", drainStatus.Drained)
+	podToDelete := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Key.Namespace,
+			Name:      pod.Key.Name,
+		},
+	}
+	// the options of the held deletion are applied, so that e.g. a forced deletion isn't replaced by the default grace period.
+	deleteOpts := []client.DeleteOption{client.Preconditions{UID: &pod.UID}}
+	if pod.TerminationDeleteOptions != nil {
+		if pod.TerminationDeleteOptions.GracePeriodSeconds != nil {
+			deleteOpts = append(deleteOpts, client.GracePeriodSeconds(*pod.TerminationDeleteOptions.GracePeriodSeconds))
+		}
+		if pod.TerminationDeleteOptions.PropagationPolicy != nil {
+			deleteOpts = append(deleteOpts, client.PropagationPolicy(*pod.TerminationDeleteOptions.PropagationPolicy))
+		}
+	}
+	if err := m.k8sClient.Delete(ctx, podToDelete, deleteOpts...); err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
 func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targets []TargetInfo) (bool, error) {
 	filteredTargets, updateTrackedTargets, err := m.multiClusterManager.FilterTargetsForDeregistration(ctx, tgb, targets)
 	if err != nil {
@@ -669,6 +892,34 @@ func (m *defaultResourceManager) prepareRegistrationCall(ctx context.Context, en
 	return sdkTargets, nil
 }
 
+// filterDuplicateQUICServerIDEndpoints drops the endpoints whose QUIC server ID is already used in the target group,
+// as the load balancer routes QUIC connections to the target by server ID.
+func (m *defaultResourceManager) filterDuplicateQUICServerIDEndpoints(tgb *elbv2api.TargetGroupBinding, endpoints []backend.PodEndpoint,
+	registeredEndpointAndTargets []podEndpointAndTargetPair) []backend.PodEndpoint {
+	usedServerIDs := sets.New[string]()
+	for _, endpointAndTarget := range registeredEndpointAndTargets {
+		if endpointAndTarget.target.Target.QuicServerId != nil {
+			usedServerIDs.Insert(*endpointAndTarget.target.Target.QuicServerId)
+		}
+	}
+	filteredEndpoints := make([]backend.PodEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.QuicServerID != nil {
+			if usedServerIDs.Has(*endpoint.QuicServerID) {
+				m.logger.Info("Dropping registration request for QUIC enabled target with duplicate server ID",
+					"tgb", k8s.NamespacedName(tgb), "pod", endpoint.Pod.Key, "serverID", *endpoint.QuicServerID)
+				m.metricsCollector.ObserveQUICTargetDuplicateServerId(tgb.Namespace, tgb.Name)
+				m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonDuplicateQUICServerID,
+					fmt.Sprintf("Pod %s not registered, its QUIC server ID %s is already used in the target group", endpoint.Pod.Key.Name, *endpoint.QuicServerID))
+				continue
+			}
+			usedServerIDs.Insert(*endpoint.QuicServerID)
+		}
+		filteredEndpoints = append(filteredEndpoints, endpoint)
+	}
+	return filteredEndpoints
+}
+
 func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.NodePortEndpoint) error {
 	sdkTargets := make([]elbv2types.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
@@ -694,6 +945,45 @@ func (m *defaultResourceManager) updateTGBCheckPoint(ctx context.Context, tgb *e
 	return nil
 }
 
+// refreshTGBTargetHealthStatusIfDue refreshes the target health status when it hasn't been refreshed within targetHealthRefreshInterval.
+func (m *defaultResourceManager) refreshTGBTargetHealthStatusIfDue(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if m.targetHealthRefreshInterval <= 0 {
+		return nil
+	}
+	if _, refreshed := m.targetHealthRefreshCache.Get(k8s.NamespacedName(tgb)); refreshed {
+		return nil
+	}
+	return m.refreshTGBTargetHealthStatus(ctx, tgb)
+}
+
+// refreshTGBTargetHealthStatus lists the targets again to refresh the target health status, e.g. after targets are registered or deregistered.
+func (m *defaultResourceManager) refreshTGBTargetHealthStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	targets, err := m.targetsManager.ListTargets(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	return m.updateTGBTargetHealthStatus(ctx, tgb, targets)
+}
+
+// updateTGBTargetHealthStatus aggregates the health of targets into TargetGroupBinding status and metrics.
+func (m *defaultResourceManager) updateTGBTargetHealthStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targets []TargetInfo) error {
+	targetHealth := buildTargetHealthSummary(targets)
+	m.targetGroupCollector.ObserveTargetHealth(tgb, targetHealth)
+	if m.targetHealthRefreshInterval > 0 {
+		m.targetHealthRefreshCache.Set(k8s.NamespacedName(tgb), true, m.targetHealthRefreshInterval)
+	}
+	if tgb.Status.TargetHealth != nil && equality.Semantic.DeepEqual(*tgb.Status.TargetHealth, targetHealth) {
+		return nil
+	}
+
+	tgbOld := tgb.DeepCopy()
+	tgb.Status.TargetHealth = &targetHealth
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding target health status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
 func (m *defaultResourceManager) generateOverrideAzFn(ctx context.Context, vpcID string, assumeRole string) (func(addr netip.Addr) bool, error) {
 	// Cross-Account is configured by assuming a role.
 	usingCrossAccount := assumeRole != ""
@@ -11,9 +11,11 @@ import (
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/cache"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/aws"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 
@@ -27,9 +29,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -492,6 +498,64 @@ func Test_containsTargetsInInitialState(t *testing.T) {
 	}
 }
 
+func Test_withTargetControlAgentReadiness(t *testing.T) {
+	healthy := &elbv2types.TargetHealth{
+		State: elbv2types.TargetHealthStateEnumHealthy,
+	}
+	unhealthy := &elbv2types.TargetHealth{
+		State:       elbv2types.TargetHealthStateEnumUnhealthy,
+		Reason:      elbv2types.TargetHealthReasonEnumFailedHealthChecks,
+		Description: awssdk.String("Health checks failed"),
+	}
+	tests := []struct {
+		name         string
+		pod          k8s.PodInfo
+		targetHealth *elbv2types.TargetHealth
+		want         *elbv2types.TargetHealth
+	}{
+		{
+			name:         "pod without agent",
+			pod:          k8s.PodInfo{},
+			targetHealth: healthy,
+			want:         healthy,
+		},
+		{
+			name: "healthy target with ready agent",
+			pod: k8s.PodInfo{
+				TargetControlAgent: &k8s.PodTargetControlAgentInfo{Ready: true},
+			},
+			targetHealth: healthy,
+			want:         healthy,
+		},
+		{
+			name: "healthy target with agent not ready",
+			pod: k8s.PodInfo{
+				TargetControlAgent: &k8s.PodTargetControlAgentInfo{Ready: false},
+			},
+			targetHealth: healthy,
+			want: &elbv2types.TargetHealth{
+				State:       elbv2types.TargetHealthStateEnumUnhealthy,
+				Reason:      targetHealthReasonTargetControlAgentNotReady,
+				Description: awssdk.String("ALB target control agent is not ready"),
+			},
+		},
+		{
+			name: "unhealthy target with agent not ready keeps target health",
+			pod: k8s.PodInfo{
+				TargetControlAgent: &k8s.PodTargetControlAgentInfo{Ready: false},
+			},
+			targetHealth: unhealthy,
+			want:         unhealthy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withTargetControlAgentReadiness(tt.pod, tt.targetHealth)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultResourceManager_GenerateOverrideAzFn(t *testing.T) {
 
 	vpcId := "foo"
@@ -1283,6 +1347,76 @@ func Test_defaultResourceManager_prepareRegistrationCall(t *testing.T) {
 	}
 }
 
+func Test_defaultResourceManager_filterDuplicateQUICServerIDEndpoints(t *testing.T) {
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tgb"},
+	}
+	buildEndpoint := func(ip string, podName string, serverID *string) backend.PodEndpoint {
+		return backend.PodEndpoint{
+			IP:           ip,
+			Port:         8080,
+			Pod:          k8s.PodInfo{Key: types.NamespacedName{Namespace: "default", Name: podName}},
+			QuicServerID: serverID,
+		}
+	}
+	registeredEndpointAndTargets := []podEndpointAndTargetPair{
+		{
+			endpoint: buildEndpoint("172.16.0.1", "pod-1", awssdk.String("0x0000000000000001")),
+			target: TargetInfo{
+				Target: elbv2types.TargetDescription{
+					Id:           awssdk.String("172.16.0.1"),
+					Port:         awssdk.Int32(8080),
+					QuicServerId: awssdk.String("0x0000000000000001"),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		endpoints            []backend.PodEndpoint
+		wantEndpoints        []backend.PodEndpoint
+		wantDuplicateMetrics int
+	}{
+		{
+			name: "unique server IDs are kept",
+			endpoints: []backend.PodEndpoint{
+				buildEndpoint("172.16.0.2", "pod-2", awssdk.String("0x0000000000000002")),
+				buildEndpoint("172.16.0.3", "pod-3", nil),
+			},
+			wantEndpoints: []backend.PodEndpoint{
+				buildEndpoint("172.16.0.2", "pod-2", awssdk.String("0x0000000000000002")),
+				buildEndpoint("172.16.0.3", "pod-3", nil),
+			},
+		},
+		{
+			name: "server IDs of registered targets and earlier endpoints are dropped",
+			endpoints: []backend.PodEndpoint{
+				buildEndpoint("172.16.0.2", "pod-2", awssdk.String("0x0000000000000001")),
+				buildEndpoint("172.16.0.3", "pod-3", awssdk.String("0x0000000000000003")),
+				buildEndpoint("172.16.0.4", "pod-4", awssdk.String("0x0000000000000003")),
+			},
+			wantEndpoints: []backend.PodEndpoint{
+				buildEndpoint("172.16.0.3", "pod-3", awssdk.String("0x0000000000000003")),
+			},
+			wantDuplicateMetrics: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metricsCollector := lbcmetrics.NewMockCollector()
+			m := &defaultResourceManager{
+				eventRecorder:    record.NewFakeRecorder(10),
+				logger:           logr.New(&log.NullLogSink{}),
+				metricsCollector: metricsCollector,
+			}
+			got := m.filterDuplicateQUICServerIDEndpoints(tgb, tt.endpoints, registeredEndpointAndTargets)
+			assert.Equal(t, tt.wantEndpoints, got)
+			mockCollector := metricsCollector.(*lbcmetrics.MockCollector)
+			assert.Len(t, mockCollector.Invocations[lbcmetrics.MetricQuicTargetDuplicateServerId], tt.wantDuplicateMetrics)
+		})
+	}
+}
+
 // Test_needReadinessGateFlip tests that needReadinessGateFlip correctly
 // identifies pods with a written but not-yet-True readiness gate condition.
 func Test_needReadinessGateFlip(t *testing.T) {
@@ -1441,3 +1575,324 @@ func Test_needReadinessGateFlip(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_updateTargetDrainPodCondition(t *testing.T) {
+	condType := corev1.PodConditionType("target-drain.elbv2.k8s.aws/my-tgb")
+	otherCondType := corev1.PodConditionType("target-drain.elbv2.k8s.aws/other-tgb")
+	requestedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-pod",
+			UID:       "my-pod-uuid",
+		},
+		Status: corev1.PodStatus{
+			PodIP: "192.168.1.1",
+		},
+	}
+	drainingTarget := TargetInfo{
+		Target: elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+		TargetHealth: &elbv2types.TargetHealth{
+			State:       elbv2types.TargetHealthStateEnumDraining,
+			Reason:      elbv2types.TargetHealthReasonEnumDeregistrationInProgress,
+			Description: awssdk.String("Target deregistration is in progress"),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		podConditions     []corev1.PodCondition
+		targets           []TargetInfo
+		timeout           time.Duration
+		deleteOptions     *k8s.PodDeleteOptions
+		wantDraining      bool
+		wantPodDeleted    bool
+		wantDeleteOptions *client.DeleteOptions
+		wantPodConditions []corev1.PodCondition
+	}{
+		{
+			name: "pod target still draining",
+			podConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonPending, LastTransitionTime: requestedAt},
+			},
+			targets:      []TargetInfo{drainingTarget},
+			timeout:      5 * time.Minute,
+			wantDraining: true,
+			wantPodConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, Message: "Target deregistration is in progress"},
+			},
+		},
+		{
+			name: "pod target drained from all TargetGroups",
+			podConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, LastTransitionTime: requestedAt},
+				{Type: otherCondType, Status: corev1.ConditionTrue, Reason: PodTargetDrainReasonDrained},
+			},
+			targets:           nil,
+			timeout:           5 * time.Minute,
+			wantDraining:      false,
+			wantPodDeleted:    true,
+			wantDeleteOptions: &client.DeleteOptions{Preconditions: &metav1.Preconditions{UID: ptr.To(types.UID("my-pod-uuid"))}},
+		},
+		{
+			name: "pod target drained with recorded delete options",
+			podConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, LastTransitionTime: requestedAt},
+			},
+			targets: nil,
+			timeout: 5 * time.Minute,
+			deleteOptions: &k8s.PodDeleteOptions{
+				GracePeriodSeconds: awssdk.Int64(0),
+				PropagationPolicy:  ptr.To(metav1.DeletePropagationForeground),
+			},
+			wantDraining:   false,
+			wantPodDeleted: true,
+			wantDeleteOptions: &client.DeleteOptions{
+				Preconditions:      &metav1.Preconditions{UID: ptr.To(types.UID("my-pod-uuid"))},
+				GracePeriodSeconds: awssdk.Int64(0),
+				PropagationPolicy:  ptr.To(metav1.DeletePropagationForeground),
+			},
+		},
+		{
+			name: "pod target drained while other TargetGroup still draining",
+			podConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, LastTransitionTime: requestedAt},
+				{Type: otherCondType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, LastTransitionTime: requestedAt},
+			},
+			targets:      nil,
+			timeout:      5 * time.Minute,
+			wantDraining: false,
+			wantPodConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionTrue, Reason: PodTargetDrainReasonDrained, Message: "Targets are drained from the TargetGroup"},
+				{Type: otherCondType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining},
+			},
+		},
+		{
+			name: "pod target draining exceeds timeout",
+			podConditions: []corev1.PodCondition{
+				{Type: condType, Status: corev1.ConditionFalse, Reason: PodTargetDrainReasonDraining, LastTransitionTime: requestedAt},
+			},
+			targets:        []TargetInfo{drainingTarget},
+			timeout:        30 * time.Second,
+			wantDraining:   false,
+			wantPodDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			var gotDeleteOptions *client.DeleteOptions
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					gotDeleteOptions = (&client.DeleteOptions{}).ApplyOptions(opts)
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).Build()
+			m := &defaultResourceManager{
+				k8sClient:                 k8sClient,
+				logger:                    logr.New(&log.NullLogSink{}),
+				metricsCollector:          lbcmetrics.NewMockCollector(),
+				podTerminationGateTimeout: tt.timeout,
+			}
+
+			ctx := context.Background()
+			existingPod := pod.DeepCopy()
+			existingPod.Status.Conditions = tt.podConditions
+			assert.NoError(t, k8sClient.Create(ctx, existingPod))
+
+			endpoints := []backend.PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod: k8s.PodInfo{
+						Key:        types.NamespacedName{Namespace: "default", Name: "my-pod"},
+						UID:        "my-pod-uuid",
+						PodIP:      "192.168.1.1",
+						Conditions: tt.podConditions,
+
+						TerminationDeleteOptions: tt.deleteOptions,
+					},
+				},
+			}
+			gotDraining, err := m.updateTargetDrainPodCondition(ctx, condType, endpoints, tt.targets)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDraining, gotDraining)
+
+			updatedPod := &corev1.Pod{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "my-pod"}, updatedPod)
+			if tt.wantPodDeleted {
+				assert.True(t, apierrors.IsNotFound(err))
+				if tt.wantDeleteOptions != nil {
+					assert.Equal(t, tt.wantDeleteOptions, gotDeleteOptions)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			opts := cmp.Options{
+				cmpopts.IgnoreTypes(metav1.Time{}),
+				cmpopts.SortSlices(func(lhs, rhs corev1.PodCondition) bool { return lhs.Type < rhs.Type }),
+			}
+			assert.True(t, cmp.Equal(tt.wantPodConditions, updatedPod.Status.Conditions, opts), "diff", cmp.Diff(tt.wantPodConditions, updatedPod.Status.Conditions, opts))
+		})
+	}
+}
+
+func Test_defaultResourceManager_updateTGBTargetHealthStatus(t *testing.T) {
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+		},
+	}
+	targets := []TargetInfo{
+		{
+			Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+			TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy},
+		},
+		{
+			Target: elbv2types.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int32(8080)},
+			TargetHealth: &elbv2types.TargetHealth{
+				State:       elbv2types.TargetHealthStateEnumUnhealthy,
+				Reason:      elbv2types.TargetHealthReasonEnumTimeout,
+				Description: awssdk.String("Request timed out"),
+			},
+		},
+	}
+	wantTargetHealth := &elbv2api.TargetHealthSummary{
+		Healthy:   1,
+		Unhealthy: 1,
+		UnhealthyReasons: []elbv2api.TargetHealthReason{
+			{Reason: "Target.Timeout", Count: 1, Description: "Request timed out"},
+		},
+	}
+	tests := []struct {
+		name               string
+		targetHealth       *elbv2api.TargetHealthSummary
+		wantResourceChange bool
+	}{
+		{
+			name:               "target health not reported yet",
+			targetHealth:       nil,
+			wantResourceChange: true,
+		},
+		{
+			name:               "target health changed",
+			targetHealth:       &elbv2api.TargetHealthSummary{Healthy: 2},
+			wantResourceChange: true,
+		},
+		{
+			name:               "target health unchanged",
+			targetHealth:       wantTargetHealth.DeepCopy(),
+			wantResourceChange: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithStatusSubresource(&elbv2api.TargetGroupBinding{}).Build()
+			m := &defaultResourceManager{
+				k8sClient:            k8sClient,
+				logger:               logr.New(&log.NullLogSink{}),
+				targetGroupCollector: awsmetrics.NewTargetGroupCollector(nil),
+			}
+
+			ctx := context.Background()
+			existingTGB := tgb.DeepCopy()
+			assert.NoError(t, k8sClient.Create(ctx, existingTGB))
+			existingTGB.Status.TargetHealth = tt.targetHealth
+			assert.NoError(t, k8sClient.Status().Update(ctx, existingTGB))
+			resourceVersion := existingTGB.ResourceVersion
+
+			assert.NoError(t, m.updateTGBTargetHealthStatus(ctx, existingTGB, targets))
+
+			updatedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), updatedTGB))
+			assert.Equal(t, wantTargetHealth, updatedTGB.Status.TargetHealth)
+			assert.Equal(t, tt.wantResourceChange, updatedTGB.ResourceVersion != resourceVersion)
+		})
+	}
+}
+
+func Test_defaultResourceManager_refreshTGBTargetHealthStatusIfDue(t *testing.T) {
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-tgb",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+		},
+	}
+	targets := []TargetInfo{
+		{
+			Target:       elbv2types.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int32(8080)},
+			TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy},
+		},
+	}
+	tests := []struct {
+		name              string
+		refreshInterval   time.Duration
+		refreshedRecently bool
+		wantTargetHealth  *elbv2api.TargetHealthSummary
+	}{
+		{
+			name:             "refresh disabled",
+			refreshInterval:  0,
+			wantTargetHealth: nil,
+		},
+		{
+			name:             "refresh due",
+			refreshInterval:  5 * time.Minute,
+			wantTargetHealth: &elbv2api.TargetHealthSummary{Healthy: 1},
+		},
+		{
+			name:              "refreshed within interval",
+			refreshInterval:   5 * time.Minute,
+			refreshedRecently: true,
+			wantTargetHealth:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithStatusSubresource(&elbv2api.TargetGroupBinding{}).Build()
+			mockTargetsManager := NewMockTargetsManager(ctrl)
+			if tt.wantTargetHealth != nil {
+				mockTargetsManager.EXPECT().ListTargets(gomock.Any(), gomock.Any()).Return(targets, nil)
+			}
+			m := &defaultResourceManager{
+				k8sClient:                   k8sClient,
+				targetsManager:              mockTargetsManager,
+				logger:                      logr.New(&log.NullLogSink{}),
+				targetGroupCollector:        awsmetrics.NewTargetGroupCollector(nil),
+				targetHealthRefreshCache:    cache.NewExpiring(),
+				targetHealthRefreshInterval: tt.refreshInterval,
+			}
+			if tt.refreshedRecently {
+				m.targetHealthRefreshCache.Set(k8s.NamespacedName(tgb), true, tt.refreshInterval)
+			}
+
+			ctx := context.Background()
+			existingTGB := tgb.DeepCopy()
+			assert.NoError(t, k8sClient.Create(ctx, existingTGB))
+			assert.NoError(t, m.refreshTGBTargetHealthStatusIfDue(ctx, existingTGB))
+
+			updatedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), updatedTGB))
+			assert.Equal(t, tt.wantTargetHealth, updatedTGB.Status.TargetHealth)
+			if tt.refreshInterval > 0 {
+				_, refreshed := m.targetHealthRefreshCache.Get(k8s.NamespacedName(tgb))
+				assert.True(t, refreshed)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package targetgroupbinding
+
+import (
+	"sort"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+const (
+	// maxTargetHealthUnhealthyReasons is the maximum number of unhealthy reasons reported in TargetGroupBinding status.
+	maxTargetHealthUnhealthyReasons = 3
+)
+
+// buildTargetHealthSummary aggregates the health of targets into TargetHealthSummary.
+// targets that are not registered(unused) are not counted, and targets with unknown health are counted as initial.
+func buildTargetHealthSummary(targets []TargetInfo) elbv2api.TargetHealthSummary {
+	var summary elbv2api.TargetHealthSummary
+	reasonByCode := make(map[string]*elbv2api.TargetHealthReason)
+	reasonDescriptionTargetID := make(map[string]string)
+	for _, target := range targets {
+		if target.TargetHealth == nil {
+			summary.Initial++
+			continue
+		}
+		switch target.TargetHealth.State {
+		case elbv2types.TargetHealthStateEnumHealthy:
+			summary.Healthy++
+		case elbv2types.TargetHealthStateEnumInitial:
+			summary.Initial++
+		case elbv2types.TargetHealthStateEnumDraining, elbv2types.TargetHealthStateEnumUnhealthyDraining:
+			summary.Draining++
+		case elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthStateEnumUnavailable:
+			summary.Unhealthy++
+			reasonCode := string(target.TargetHealth.Reason)
+			reason, exists := reasonByCode[reasonCode]
+			if !exists {
+				reason = &elbv2api.TargetHealthReason{Reason: reasonCode}
+				reasonByCode[reasonCode] = reason
+			}
+			reason.Count++
+			// use the description of the smallest target ID, so that status won't flip among targets between reconciles.
+			targetID := target.GetIdentifier()
+			if describedTargetID, described := reasonDescriptionTargetID[reasonCode]; !described || targetID < describedTargetID {
+				reason.Description = awssdk.ToString(target.TargetHealth.Description)
+				reasonDescriptionTargetID[reasonCode] = targetID
+			}
+		}
+	}
+
+	unhealthyReasons := make([]elbv2api.TargetHealthReason, 0, len(reasonByCode))
+	for _, reason := range reasonByCode {
+		unhealthyReasons = append(unhealthyReasons, *reason)
+	}
+	sort.Slice(unhealthyReasons, func(i, j int) bool {
+		if unhealthyReasons[i].Count != unhealthyReasons[j].Count {
+			return unhealthyReasons[i].Count > unhealthyReasons[j].Count
+		}
+		return unhealthyReasons[i].Reason < unhealthyReasons[j].Reason
+	})
+	if len(unhealthyReasons) > maxTargetHealthUnhealthyReasons {
+		unhealthyReasons = unhealthyReasons[:maxTargetHealthUnhealthyReasons]
+	}
+	if len(unhealthyReasons) != 0 {
+		summary.UnhealthyReasons = unhealthyReasons
+	}
+	return summary
+}
@@ -0,0 +1,86 @@
+package targetgroupbinding
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_buildTargetHealthSummary(t *testing.T) {
+	target := func(id string, state elbv2types.TargetHealthStateEnum, reason elbv2types.TargetHealthReasonEnum, description string) TargetInfo {
+		return TargetInfo{
+			Target: elbv2types.TargetDescription{Id: awssdk.String(id), Port: awssdk.Int32(8080)},
+			TargetHealth: &elbv2types.TargetHealth{
+				State:       state,
+				Reason:      reason,
+				Description: awssdk.String(description),
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		targets []TargetInfo
+		want    elbv2api.TargetHealthSummary
+	}{
+		{
+			name:    "no targets",
+			targets: nil,
+			want:    elbv2api.TargetHealthSummary{},
+		},
+		{
+			name: "targets in every state",
+			targets: []TargetInfo{
+				target("192.168.1.1", elbv2types.TargetHealthStateEnumHealthy, "", ""),
+				target("192.168.1.2", elbv2types.TargetHealthStateEnumHealthy, "", ""),
+				target("192.168.1.3", elbv2types.TargetHealthStateEnumInitial, elbv2types.TargetHealthReasonEnumInitialHealthChecking, "Initial health checks in progress"),
+				{
+					Target: elbv2types.TargetDescription{Id: awssdk.String("192.168.1.4"), Port: awssdk.Int32(8080)},
+				},
+				target("192.168.1.5", elbv2types.TargetHealthStateEnumDraining, elbv2types.TargetHealthReasonEnumDeregistrationInProgress, "Target deregistration is in progress"),
+				target("192.168.1.6", elbv2types.TargetHealthStateEnumUnhealthyDraining, elbv2types.TargetHealthReasonEnumDeregistrationInProgress, "Target deregistration is in progress"),
+				target("192.168.1.7", elbv2types.TargetHealthStateEnumUnused, elbv2types.TargetHealthReasonEnumNotRegistered, "Target is not registered to the target group"),
+				target("192.168.1.8", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumTimeout, "Request timed out"),
+				target("192.168.1.9", elbv2types.TargetHealthStateEnumUnavailable, elbv2types.TargetHealthReasonEnumHealthCheckDisabled, "Health checks disabled"),
+			},
+			want: elbv2api.TargetHealthSummary{
+				Healthy:   2,
+				Unhealthy: 2,
+				Draining:  2,
+				Initial:   2,
+				UnhealthyReasons: []elbv2api.TargetHealthReason{
+					{Reason: "Target.HealthCheckDisabled", Count: 1, Description: "Health checks disabled"},
+					{Reason: "Target.Timeout", Count: 1, Description: "Request timed out"},
+				},
+			},
+		},
+		{
+			name: "only the most frequent unhealthy reasons are reported",
+			targets: []TargetInfo{
+				target("192.168.1.2", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumResponseCodeMismatch, "Health checks failed with these codes: [503]"),
+				target("192.168.1.1", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumResponseCodeMismatch, "Health checks failed with these codes: [502]"),
+				target("192.168.1.3", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumTimeout, "Request timed out"),
+				target("192.168.1.4", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumTimeout, "Request timed out"),
+				target("192.168.1.5", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumTimeout, "Request timed out"),
+				target("192.168.1.6", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumFailedHealthChecks, "Health checks failed"),
+				target("192.168.1.7", elbv2types.TargetHealthStateEnumUnhealthy, elbv2types.TargetHealthReasonEnumIpUnusable, "Target IP is unusable"),
+			},
+			want: elbv2api.TargetHealthSummary{
+				Unhealthy: 7,
+				UnhealthyReasons: []elbv2api.TargetHealthReason{
+					{Reason: "Target.Timeout", Count: 3, Description: "Request timed out"},
+					{Reason: "Target.ResponseCodeMismatch", Count: 2, Description: "Health checks failed with these codes: [502]"},
+					{Reason: "Target.FailedHealthChecks", Count: 1, Description: "Health checks failed"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildTargetHealthSummary(tt.targets)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// weightedRegistrationMember is the weight and available endpoint count of a TargetGroupBinding with weighted registration.
+type weightedRegistrationMember struct {
+	weight    int32
+	available int
+}
+
+// ListWeightedRegistrationPeers returns the other TargetGroupBindings with weighted registration that share the TargetGroup with tgb.
+func ListWeightedRegistrationPeers(ctx context.Context, k8sClient client.Client, tgb *elbv2api.TargetGroupBinding) ([]*elbv2api.TargetGroupBinding, error) {
+	if tgb.Spec.WeightedRegistration == nil {
+		return nil, nil
+	}
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := k8sClient.List(ctx, tgbList); err != nil {
+		return nil, err
+	}
+	var peers []*elbv2api.TargetGroupBinding
+	for i := range tgbList.Items {
+		peer := &tgbList.Items[i]
+		if peer.UID == tgb.UID || peer.Spec.TargetGroupARN != tgb.Spec.TargetGroupARN {
+			continue
+		}
+		if peer.Spec.WeightedRegistration == nil || !peer.DeletionTimestamp.IsZero() {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// computeWeightedRegistrationQuota computes how many endpoints self should register, so that the targets of all members
+// are proportional to their weights.
+// The member with the least available endpoints relative to its weight registers all of them, and the others are scaled down
+// accordingly, rounded to the nearest endpoint count, so a member whose weight is too small to account for one endpoint
+// registers none.
+func computeWeightedRegistrationQuota(self weightedRegistrationMember, peers []weightedRegistrationMember) int {
+	if self.weight <= 0 || self.available <= 0 {
+		return 0
+	}
+	scale := float64(self.available) / float64(self.weight)
+	for _, peer := range peers {
+		if peer.weight <= 0 || peer.available <= 0 {
+			continue
+		}
+		scale = math.Min(scale, float64(peer.available)/float64(peer.weight))
+	}
+	quota := int(math.Round(scale * float64(self.weight)))
+	return max(0, min(quota, self.available))
+}
+
+// selectWeightedEndpoints returns quota endpoints, picked in a stable order so that the registered targets don't churn.
+func selectWeightedEndpoints[V backend.Endpoint](endpoints []V, quota int) []V {
+	if quota >= len(endpoints) {
+		return endpoints
+	}
+	sorted := slices.Clone(endpoints)
+	slices.SortFunc(sorted, func(a, b V) int {
+		return strings.Compare(a.GetIdentifier(false, false), b.GetIdentifier(false, false))
+	})
+	return sorted[:quota]
+}
+
+// resolveWeightedRegistrationQuota computes the endpoints quota of tgb given its available endpoint count.
+func (m *defaultResourceManager) resolveWeightedRegistrationQuota(ctx context.Context, tgb *elbv2api.TargetGroupBinding, available int) (int, error) {
+	peerTGBs, err := ListWeightedRegistrationPeers(ctx, m.k8sClient, tgb)
+	if err != nil {
+		return 0, err
+	}
+	peers := make([]weightedRegistrationMember, 0, len(peerTGBs))
+	for _, peerTGB := range peerTGBs {
+		peerAvailable, err := m.countAvailableEndpoints(ctx, peerTGB)
+		if err != nil {
+			return 0, err
+		}
+		peers = append(peers, weightedRegistrationMember{weight: peerTGB.Spec.WeightedRegistration.Weight, available: peerAvailable})
+	}
+	self := weightedRegistrationMember{weight: tgb.Spec.WeightedRegistration.Weight, available: available}
+	return computeWeightedRegistrationQuota(self, peers), nil
+}
+
+// countAvailableEndpoints counts the endpoints tgb would register without weighted registration.
+func (m *defaultResourceManager) countAvailableEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (int, error) {
+	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
+	if tgb.Spec.TargetType != nil && *tgb.Spec.TargetType == elbv2api.TargetTypeInstance {
+		nodeSelector, err := backend.GetTrafficProxyNodeSelector(tgb)
+		if err != nil {
+			return 0, err
+		}
+		endpoints, err := m.endpointResolver.ResolveNodePortEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, backend.WithNodeSelector(nodeSelector))
+		if err != nil {
+			if errors.Is(err, backend.ErrNotFound) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		return len(endpoints), nil
+	}
+
+	endpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, endpointSliceAddressType(tgb))
+	if err != nil {
+		if errors.Is(err, backend.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	endpoints, _ = partitionPodEndpointsByTargetDrainCondition(endpoints, BuildTargetDrainPodConditionType(tgb))
+	return len(endpoints), nil
+}
@@ -0,0 +1,121 @@
+package targetgroupbinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+)
+
+func Test_computeWeightedRegistrationQuota(t *testing.T) {
+	tests := []struct {
+		name  string
+		self  weightedRegistrationMember
+		peers []weightedRegistrationMember
+		want  int
+	}{
+		{
+			name: "no peers registers all endpoints",
+			self: weightedRegistrationMember{weight: 50, available: 7},
+			want: 7,
+		},
+		{
+			name:  "equal weights limited by the smallest backend",
+			self:  weightedRegistrationMember{weight: 50, available: 10},
+			peers: []weightedRegistrationMember{{weight: 50, available: 4}},
+			want:  4,
+		},
+		{
+			name:  "heavier backend registers all endpoints",
+			self:  weightedRegistrationMember{weight: 90, available: 10},
+			peers: []weightedRegistrationMember{{weight: 10, available: 10}},
+			want:  10,
+		},
+		{
+			name:  "lighter backend is scaled down",
+			self:  weightedRegistrationMember{weight: 10, available: 10},
+			peers: []weightedRegistrationMember{{weight: 90, available: 10}},
+			want:  1,
+		},
+		{
+			name:  "lighter backend rounds down to no endpoints",
+			self:  weightedRegistrationMember{weight: 1, available: 10},
+			peers: []weightedRegistrationMember{{weight: 99, available: 10}},
+			want:  0,
+		},
+		{
+			name:  "lighter backend rounds up to one endpoint",
+			self:  weightedRegistrationMember{weight: 5, available: 10},
+			peers: []weightedRegistrationMember{{weight: 95, available: 10}},
+			want:  1,
+		},
+		{
+			name:  "three backends",
+			self:  weightedRegistrationMember{weight: 20, available: 8},
+			peers: []weightedRegistrationMember{{weight: 60, available: 9}, {weight: 20, available: 20}},
+			want:  3,
+		},
+		{
+			name:  "peers without weight or endpoints are ignored",
+			self:  weightedRegistrationMember{weight: 50, available: 6},
+			peers: []weightedRegistrationMember{{weight: 0, available: 1}, {weight: 50, available: 0}},
+			want:  6,
+		},
+		{
+			name:  "zero weight registers nothing",
+			self:  weightedRegistrationMember{weight: 0, available: 6},
+			peers: []weightedRegistrationMember{{weight: 100, available: 3}},
+			want:  0,
+		},
+		{
+			name:  "no endpoints available",
+			self:  weightedRegistrationMember{weight: 100, available: 0},
+			peers: []weightedRegistrationMember{{weight: 100, available: 3}},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeWeightedRegistrationQuota(tt.self, tt.peers)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_selectWeightedEndpoints(t *testing.T) {
+	endpoints := []backend.PodEndpoint{
+		{IP: "192.168.1.3", Port: 80},
+		{IP: "192.168.1.1", Port: 80},
+		{IP: "192.168.1.2", Port: 80},
+	}
+	tests := []struct {
+		name  string
+		quota int
+		want  []backend.PodEndpoint
+	}{
+		{
+			name:  "quota covers all endpoints",
+			quota: 3,
+			want:  endpoints,
+		},
+		{
+			name:  "endpoints picked in stable order",
+			quota: 2,
+			want: []backend.PodEndpoint{
+				{IP: "192.168.1.1", Port: 80},
+				{IP: "192.168.1.2", Port: 80},
+			},
+		},
+		{
+			name:  "zero quota",
+			quota: 0,
+			want:  []backend.PodEndpoint{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectWeightedEndpoints(endpoints, tt.quota)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,509 @@
+package webacl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2sdk "github.com/aws/aws-sdk-go-v2/service/wafv2"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+const (
+	// TagPrefix is the prefix of the tracking tags of web ACLs and IP sets.
+	TagPrefix = "wafv2.k8s.aws"
+
+	defaultForwardedIPHeaderName = "X-Forwarded-For"
+	defaultEvaluationWindowSec   = 300
+	resourceIDWebACL             = "WebACL"
+	resourceIDIPSetPrefix        = "IPSet/"
+)
+
+// Manager manages the web ACLs and IP sets declared by WAFv2WebACLs.
+type Manager interface {
+	// Reconcile creates or updates the web ACL and IP sets of webACL, and returns the observed status without conditions.
+	Reconcile(ctx context.Context, webACL *elbv2api.WAFv2WebACL) (elbv2api.WAFv2WebACLStatus, error)
+
+	// Delete deletes the web ACL and IP sets of webACL.
+	// Nothing is deleted while load balancers are associated with the web ACL, their ARNs are returned instead.
+	Delete(ctx context.Context, webACL *elbv2api.WAFv2WebACL) ([]string, error)
+}
+
+// NewDefaultManager constructs new defaultManager.
+func NewDefaultManager(wafv2Client services.WAFv2, trackingProvider tracking.Provider, clusterName string,
+	defaultTags map[string]string, externalManagedTags []string, logger logr.Logger) *defaultManager {
+	return &defaultManager{
+		wafv2Client:         wafv2Client,
+		trackingProvider:    trackingProvider,
+		clusterName:         clusterName,
+		defaultTags:         defaultTags,
+		externalManagedTags: externalManagedTags,
+		logger:              logger,
+	}
+}
+
+var _ Manager = &defaultManager{}
+
+// defaultManager implements Manager.
+// The web ACL is updated when the spec changed since the observed generation or the web ACL drifted from the spec,
+// while IP sets and tags are always reconciled.
+type defaultManager struct {
+	wafv2Client         services.WAFv2
+	trackingProvider    tracking.Provider
+	clusterName         string
+	defaultTags         map[string]string
+	externalManagedTags []string
+	logger              logr.Logger
+}
+
+func (m *defaultManager) Reconcile(ctx context.Context, webACL *elbv2api.WAFv2WebACL) (elbv2api.WAFv2WebACLStatus, error) {
+	if err := validateSpec(webACL.Spec); err != nil {
+		return elbv2api.WAFv2WebACLStatus{}, err
+	}
+	name := WebACLName(m.clusterName, webACL)
+	stack := core.NewDefaultStack(core.StackID{Name: webACL.Name})
+
+	ipSetStatuses, err := m.reconcileIPSets(ctx, webACL, name, stack)
+	if err != nil {
+		return elbv2api.WAFv2WebACLStatus{}, err
+	}
+	ipSetARNs := make(map[string]string, len(ipSetStatuses))
+	for _, ipSetStatus := range ipSetStatuses {
+		ipSetARNs[ipSetStatus.Name] = ipSetStatus.ARN
+	}
+
+	current, lockToken, err := m.findWebACL(ctx, webACL, name, stack)
+	if err != nil {
+		return elbv2api.WAFv2WebACLStatus{}, err
+	}
+	desiredTags := m.buildTags(webACL, stack, resourceIDWebACL)
+	if current == nil {
+		current, err = m.createWebACL(ctx, webACL, name, ipSetARNs, desiredTags)
+		if err != nil {
+			return elbv2api.WAFv2WebACLStatus{}, err
+		}
+	} else {
+		if needsUpdate(webACL, current, ipSetARNs) {
+			if current, err = m.updateWebACL(ctx, webACL, current, lockToken, ipSetARNs); err != nil {
+				return elbv2api.WAFv2WebACLStatus{}, err
+			}
+		}
+		if err := m.reconcileTags(ctx, awssdk.ToString(current.ARN), desiredTags); err != nil {
+			return elbv2api.WAFv2WebACLStatus{}, err
+		}
+	}
+
+	// IP sets removed from the spec can only be deleted once the web ACL no longer references them.
+	if err := m.deleteStaleIPSets(ctx, webACL, ipSetStatuses); err != nil {
+		return elbv2api.WAFv2WebACLStatus{}, err
+	}
+	associatedLBs, err := m.listAssociatedLoadBalancers(ctx, awssdk.ToString(current.ARN))
+	if err != nil {
+		return elbv2api.WAFv2WebACLStatus{}, err
+	}
+	return elbv2api.WAFv2WebACLStatus{
+		ObservedGeneration:      awssdk.Int64(webACL.Generation),
+		WebACLARN:               current.ARN,
+		WebACLID:                current.Id,
+		Capacity:                awssdk.Int64(current.Capacity),
+		IPSets:                  ipSetStatuses,
+		AssociatedLoadBalancers: associatedLBs,
+	}, nil
+}
+
+func (m *defaultManager) Delete(ctx context.Context, webACL *elbv2api.WAFv2WebACL) ([]string, error) {
+	name := WebACLName(m.clusterName, webACL)
+	stack := core.NewDefaultStack(core.StackID{Name: webACL.Name})
+	current, lockToken, err := m.findWebACL(ctx, webACL, name, stack)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		associatedLBs, err := m.listAssociatedLoadBalancers(ctx, awssdk.ToString(current.ARN))
+		if err != nil {
+			return nil, err
+		}
+		if len(associatedLBs) != 0 {
+			return associatedLBs, nil
+		}
+		m.logger.Info("deleting web ACL", "webACL", webACL.Name, "arn", awssdk.ToString(current.ARN))
+		if _, err := m.wafv2Client.DeleteWebACLWithContext(ctx, &wafv2sdk.DeleteWebACLInput{
+			Name:      current.Name,
+			Id:        current.Id,
+			Scope:     wafv2types.ScopeRegional,
+			LockToken: awssdk.String(lockToken),
+		}); err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		m.logger.Info("deleted web ACL", "webACL", webACL.Name, "arn", awssdk.ToString(current.ARN))
+	}
+	if err := m.deleteStaleIPSets(ctx, webACL, nil); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// findWebACL finds the web ACL by the ID in status, or else by name. A web ACL found by name must be tagged with the stack of webACL.
+func (m *defaultManager) findWebACL(ctx context.Context, webACL *elbv2api.WAFv2WebACL, name string, stack core.Stack) (*wafv2types.WebACL, string, error) {
+	id := awssdk.ToString(webACL.Status.WebACLID)
+	if id == "" {
+		summary, err := m.findWebACLSummaryByName(ctx, name)
+		if err != nil || summary == nil {
+			return nil, "", err
+		}
+		if err := m.ensureOwnership(ctx, awssdk.ToString(summary.ARN), stack); err != nil {
+			return nil, "", err
+		}
+		id = awssdk.ToString(summary.Id)
+	}
+	resp, err := m.wafv2Client.GetWebACLWithContext(ctx, &wafv2sdk.GetWebACLInput{
+		Name:  awssdk.String(name),
+		Id:    awssdk.String(id),
+		Scope: wafv2types.ScopeRegional,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	return resp.WebACL, awssdk.ToString(resp.LockToken), nil
+}
+
+func (m *defaultManager) findWebACLSummaryByName(ctx context.Context, name string) (*wafv2types.WebACLSummary, error) {
+	req := &wafv2sdk.ListWebACLsInput{Scope: wafv2types.ScopeRegional}
+	for {
+		resp, err := m.wafv2Client.ListWebACLsWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range resp.WebACLs {
+			if awssdk.ToString(summary.Name) == name {
+				return &summary, nil
+			}
+		}
+		if resp.NextMarker == nil {
+			return nil, nil
+		}
+		req.NextMarker = resp.NextMarker
+	}
+}
+
+func (m *defaultManager) createWebACL(ctx context.Context, webACL *elbv2api.WAFv2WebACL, name string, ipSetARNs map[string]string, tags map[string]string) (*wafv2types.WebACL, error) {
+	m.logger.Info("creating web ACL", "webACL", webACL.Name, "name", name)
+	resp, err := m.wafv2Client.CreateWebACLWithContext(ctx, &wafv2sdk.CreateWebACLInput{
+		Name:             awssdk.String(name),
+		Scope:            wafv2types.ScopeRegional,
+		Description:      webACL.Spec.Description,
+		DefaultAction:    buildDefaultAction(webACL.Spec.DefaultAction),
+		VisibilityConfig: buildVisibilityConfig(webACL.Spec, name),
+		Rules:            buildRules(webACL.Spec, ipSetARNs),
+		Tags:             buildSDKTags(tags),
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.logger.Info("created web ACL", "webACL", webACL.Name, "arn", awssdk.ToString(resp.Summary.ARN))
+	return m.getWebACL(ctx, resp.Summary.Name, resp.Summary.Id)
+}
+
+func (m *defaultManager) updateWebACL(ctx context.Context, webACL *elbv2api.WAFv2WebACL, current *wafv2types.WebACL, lockToken string, ipSetARNs map[string]string) (*wafv2types.WebACL, error) {
+	m.logger.Info("updating web ACL", "webACL", webACL.Name, "arn", awssdk.ToString(current.ARN))
+	if _, err := m.wafv2Client.UpdateWebACLWithContext(ctx, &wafv2sdk.UpdateWebACLInput{
+		Name:             current.Name,
+		Id:               current.Id,
+		Scope:            wafv2types.ScopeRegional,
+		LockToken:        awssdk.String(lockToken),
+		Description:      webACL.Spec.Description,
+		DefaultAction:    buildDefaultAction(webACL.Spec.DefaultAction),
+		VisibilityConfig: buildVisibilityConfig(webACL.Spec, awssdk.ToString(current.Name)),
+		Rules:            buildRules(webACL.Spec, ipSetARNs),
+	}); err != nil {
+		return nil, err
+	}
+	m.logger.Info("updated web ACL", "webACL", webACL.Name, "arn", awssdk.ToString(current.ARN))
+	// the capacity of the updated rules is only reported by GetWebACL.
+	return m.getWebACL(ctx, current.Name, current.Id)
+}
+
+func (m *defaultManager) getWebACL(ctx context.Context, name *string, id *string) (*wafv2types.WebACL, error) {
+	resp, err := m.wafv2Client.GetWebACLWithContext(ctx, &wafv2sdk.GetWebACLInput{
+		Name:  name,
+		Id:    id,
+		Scope: wafv2types.ScopeRegional,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.WebACL, nil
+}
+
+// reconcileIPSets creates or updates the IP sets of the spec, and returns their status in spec order.
+func (m *defaultManager) reconcileIPSets(ctx context.Context, webACL *elbv2api.WAFv2WebACL, webACLName string, stack core.Stack) ([]elbv2api.WAFv2IPSetStatus, error) {
+	if len(webACL.Spec.IPSets) == 0 {
+		return nil, nil
+	}
+	knownIPSets := make(map[string]elbv2api.WAFv2IPSetStatus, len(webACL.Status.IPSets))
+	for _, ipSetStatus := range webACL.Status.IPSets {
+		knownIPSets[ipSetStatus.Name] = ipSetStatus
+	}
+	var ipSetSummaries []wafv2types.IPSetSummary
+	ipSetStatuses := make([]elbv2api.WAFv2IPSetStatus, 0, len(webACL.Spec.IPSets))
+	for _, ipSet := range webACL.Spec.IPSets {
+		name := ipSetName(webACLName, ipSet.Name)
+		desiredTags := m.buildTags(webACL, stack, resourceIDIPSetPrefix+ipSet.Name)
+		id := knownIPSets[ipSet.Name].ID
+		if id == "" {
+			if ipSetSummaries == nil {
+				var err error
+				if ipSetSummaries, err = m.listIPSets(ctx); err != nil {
+					return nil, err
+				}
+			}
+			for _, summary := range ipSetSummaries {
+				if awssdk.ToString(summary.Name) != name {
+					continue
+				}
+				if err := m.ensureOwnership(ctx, awssdk.ToString(summary.ARN), stack); err != nil {
+					return nil, err
+				}
+				id = awssdk.ToString(summary.Id)
+			}
+		}
+
+		var current *wafv2sdk.GetIPSetOutput
+		if id != "" {
+			resp, err := m.wafv2Client.GetIPSetWithContext(ctx, &wafv2sdk.GetIPSetInput{
+				Name:  awssdk.String(name),
+				Id:    awssdk.String(id),
+				Scope: wafv2types.ScopeRegional,
+			})
+			if err != nil && !isNotFound(err) {
+				return nil, err
+			}
+			current = resp
+		}
+		if current == nil {
+			m.logger.Info("creating IP set", "webACL", webACL.Name, "name", name)
+			resp, err := m.wafv2Client.CreateIPSetWithContext(ctx, &wafv2sdk.CreateIPSetInput{
+				Name:             awssdk.String(name),
+				Scope:            wafv2types.ScopeRegional,
+				IPAddressVersion: wafv2types.IPAddressVersion(ipAddressVersion(ipSet)),
+				Addresses:        ipSet.Addresses,
+				Tags:             buildSDKTags(desiredTags),
+			})
+			if err != nil {
+				return nil, err
+			}
+			m.logger.Info("created IP set", "webACL", webACL.Name, "arn", awssdk.ToString(resp.Summary.ARN))
+			ipSetStatuses = append(ipSetStatuses, elbv2api.WAFv2IPSetStatus{
+				Name: ipSet.Name,
+				ID:   awssdk.ToString(resp.Summary.Id),
+				ARN:  awssdk.ToString(resp.Summary.ARN),
+			})
+			continue
+		}
+
+		if !sets.New(ipSet.Addresses...).Equal(sets.New(current.IPSet.Addresses...)) {
+			m.logger.Info("updating IP set", "webACL", webACL.Name, "arn", awssdk.ToString(current.IPSet.ARN))
+			if _, err := m.wafv2Client.UpdateIPSetWithContext(ctx, &wafv2sdk.UpdateIPSetInput{
+				Name:        current.IPSet.Name,
+				Id:          current.IPSet.Id,
+				Scope:       wafv2types.ScopeRegional,
+				LockToken:   current.LockToken,
+				Description: current.IPSet.Description,
+				Addresses:   ipSet.Addresses,
+			}); err != nil {
+				return nil, err
+			}
+			m.logger.Info("updated IP set", "webACL", webACL.Name, "arn", awssdk.ToString(current.IPSet.ARN))
+		}
+		if err := m.reconcileTags(ctx, awssdk.ToString(current.IPSet.ARN), desiredTags); err != nil {
+			return nil, err
+		}
+		ipSetStatuses = append(ipSetStatuses, elbv2api.WAFv2IPSetStatus{
+			Name: ipSet.Name,
+			ID:   awssdk.ToString(current.IPSet.Id),
+			ARN:  awssdk.ToString(current.IPSet.ARN),
+		})
+	}
+	return ipSetStatuses, nil
+}
+
+// deleteStaleIPSets deletes the IP sets in the status of webACL that aren't in desiredIPSets.
+func (m *defaultManager) deleteStaleIPSets(ctx context.Context, webACL *elbv2api.WAFv2WebACL, desiredIPSets []elbv2api.WAFv2IPSetStatus) error {
+	desiredIDs := sets.New[string]()
+	for _, ipSetStatus := range desiredIPSets {
+		desiredIDs.Insert(ipSetStatus.ID)
+	}
+	webACLName := WebACLName(m.clusterName, webACL)
+	for _, ipSetStatus := range webACL.Status.IPSets {
+		if desiredIDs.Has(ipSetStatus.ID) {
+			continue
+		}
+		name := ipSetName(webACLName, ipSetStatus.Name)
+		resp, err := m.wafv2Client.GetIPSetWithContext(ctx, &wafv2sdk.GetIPSetInput{
+			Name:  awssdk.String(name),
+			Id:    awssdk.String(ipSetStatus.ID),
+			Scope: wafv2types.ScopeRegional,
+		})
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return err
+		}
+		m.logger.Info("deleting IP set", "webACL", webACL.Name, "arn", ipSetStatus.ARN)
+		if _, err := m.wafv2Client.DeleteIPSetWithContext(ctx, &wafv2sdk.DeleteIPSetInput{
+			Name:      awssdk.String(name),
+			Id:        awssdk.String(ipSetStatus.ID),
+			Scope:     wafv2types.ScopeRegional,
+			LockToken: resp.LockToken,
+		}); err != nil && !isNotFound(err) {
+			return err
+		}
+		m.logger.Info("deleted IP set", "webACL", webACL.Name, "arn", ipSetStatus.ARN)
+	}
+	return nil
+}
+
+func (m *defaultManager) listIPSets(ctx context.Context) ([]wafv2types.IPSetSummary, error) {
+	req := &wafv2sdk.ListIPSetsInput{Scope: wafv2types.ScopeRegional}
+	ipSets := []wafv2types.IPSetSummary{}
+	for {
+		resp, err := m.wafv2Client.ListIPSetsWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		ipSets = append(ipSets, resp.IPSets...)
+		if resp.NextMarker == nil {
+			return ipSets, nil
+		}
+		req.NextMarker = resp.NextMarker
+	}
+}
+
+func (m *defaultManager) listAssociatedLoadBalancers(ctx context.Context, webACLARN string) ([]string, error) {
+	resp, err := m.wafv2Client.ListResourcesForWebACLWithContext(ctx, &wafv2sdk.ListResourcesForWebACLInput{
+		WebACLArn:    awssdk.String(webACLARN),
+		ResourceType: wafv2types.ResourceTypeApplicationLoadBalancer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ResourceArns, nil
+}
+
+// ensureOwnership checks that a resource found by name is tagged with the cluster and stack, so that
+// web ACLs and IP sets managed outside this WAFv2WebACL are never adopted.
+func (m *defaultManager) ensureOwnership(ctx context.Context, arn string, stack core.Stack) error {
+	currentTags, err := m.listTags(ctx, arn)
+	if err != nil {
+		return err
+	}
+	for key, value := range m.trackingProvider.StackTags(stack) {
+		if currentTags[key] != value {
+			return fmt.Errorf("%v already exists and isn't managed by WAFv2WebACL %v", arn, stack.StackID().Name)
+		}
+	}
+	return nil
+}
+
+func (m *defaultManager) reconcileTags(ctx context.Context, arn string, desiredTags map[string]string) error {
+	currentTags, err := m.listTags(ctx, arn)
+	if err != nil {
+		return err
+	}
+	tagsToUpdate, tagsToRemove := algorithm.DiffStringMapIgnoreAWSTags(desiredTags, currentTags)
+	for _, ignoredTagKey := range m.externalManagedTags {
+		delete(tagsToUpdate, ignoredTagKey)
+		delete(tagsToRemove, ignoredTagKey)
+	}
+	if len(tagsToUpdate) > 0 {
+		if _, err := m.wafv2Client.TagResourceWithContext(ctx, &wafv2sdk.TagResourceInput{
+			ResourceARN: awssdk.String(arn),
+			Tags:        buildSDKTags(tagsToUpdate),
+		}); err != nil {
+			return err
+		}
+	}
+	if len(tagsToRemove) > 0 {
+		if _, err := m.wafv2Client.UntagResourceWithContext(ctx, &wafv2sdk.UntagResourceInput{
+			ResourceARN: awssdk.String(arn),
+			TagKeys:     sets.List(sets.KeySet(tagsToRemove)),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *defaultManager) listTags(ctx context.Context, arn string) (map[string]string, error) {
+	req := &wafv2sdk.ListTagsForResourceInput{ResourceARN: awssdk.String(arn)}
+	tags := make(map[string]string)
+	for {
+		resp, err := m.wafv2Client.ListTagsForResourceWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.TagInfoForResource != nil {
+			for _, tag := range resp.TagInfoForResource.TagList {
+				tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+			}
+		}
+		if resp.NextMarker == nil {
+			return tags, nil
+		}
+		req.NextMarker = resp.NextMarker
+	}
+}
+
+// buildTags builds the tags of a resource of webACL, the tracking tags take precedence over the tags of the spec,
+// which take precedence over the default tags.
+func (m *defaultManager) buildTags(webACL *elbv2api.WAFv2WebACL, stack core.Stack, resourceID string) map[string]string {
+	return algorithm.MergeStringMap(
+		m.trackingProvider.StackTags(stack),
+		map[string]string{m.trackingProvider.ResourceIDTagKey(): resourceID},
+		webACL.Spec.Tags,
+		m.defaultTags,
+	)
+}
+
+// needsUpdate checks whether the spec changed since it was applied to the current web ACL,
+// or the current web ACL was changed outside the controller.
+func needsUpdate(webACL *elbv2api.WAFv2WebACL, current *wafv2types.WebACL, ipSetARNs map[string]string) bool {
+	if webACL.Status.ObservedGeneration == nil || *webACL.Status.ObservedGeneration != webACL.Generation ||
+		awssdk.ToString(webACL.Status.WebACLID) != awssdk.ToString(current.Id) {
+		return true
+	}
+	opts := compareOptionForWebACL()
+	return awssdk.ToString(webACL.Spec.Description) != awssdk.ToString(current.Description) ||
+		!cmp.Equal(buildDefaultAction(webACL.Spec.DefaultAction), current.DefaultAction, opts) ||
+		!cmp.Equal(buildVisibilityConfig(webACL.Spec, awssdk.ToString(current.Name)), current.VisibilityConfig, opts) ||
+		!cmp.Equal(buildRules(webACL.Spec, ipSetARNs), current.Rules, opts)
+}
+
+func buildSDKTags(tags map[string]string) []wafv2types.Tag {
+	sdkTags := make([]wafv2types.Tag, 0, len(tags))
+	for _, key := range sets.List(sets.KeySet(tags)) {
+		sdkTags = append(sdkTags, wafv2types.Tag{
+			Key:   awssdk.String(key),
+			Value: awssdk.String(tags[key]),
+		})
+	}
+	return sdkTags
+}
+
+func isNotFound(err error) bool {
+	var nonexistentItemErr *wafv2types.WAFNonexistentItemException
+	return errors.As(err, &nonexistentItemErr)
+}
@@ -0,0 +1,186 @@
+package webacl
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2sdk "github.com/aws/aws-sdk-go-v2/service/wafv2"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services/fake"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const testLBARN = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-1/1234567890abcdef"
+
+func newTestManager(wafv2Client *fake.WAFv2) *defaultManager {
+	return NewDefaultManager(wafv2Client, tracking.NewDefaultProvider(TagPrefix, "my-cluster"), "my-cluster",
+		map[string]string{"team": "security"}, nil, log.Log)
+}
+
+func newTestWebACL() *elbv2api.WAFv2WebACL {
+	return &elbv2api.WAFv2WebACL{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Generation: 1},
+		Spec: elbv2api.WAFv2WebACLSpec{
+			DefaultAction: elbv2api.WAFv2DefaultActionAllow,
+			IPSets: []elbv2api.WAFv2IPSet{
+				{Name: "office", Addresses: []string{"192.0.2.0/24"}},
+			},
+			Rules: []elbv2api.WAFv2Rule{
+				{
+					Name:     "common",
+					Priority: 0,
+					ManagedRuleGroup: &elbv2api.WAFv2ManagedRuleGroup{
+						VendorName: "AWS",
+						Name:       "AWSManagedRulesCommonRuleSet",
+					},
+				},
+				{
+					Name:           "office",
+					Priority:       1,
+					Action:         (*elbv2api.WAFv2RuleAction)(awssdk.String("Allow")),
+					IPSetReference: &elbv2api.WAFv2IPSetReference{Name: "office"},
+				},
+			},
+		},
+	}
+}
+
+func Test_defaultManager_lifecycle(t *testing.T) {
+	ctx := context.Background()
+	wafv2Client := fake.NewWAFv2("us-west-2", "123456789012")
+	m := newTestManager(wafv2Client)
+	webACL := newTestWebACL()
+
+	status, err := m.Reconcile(ctx, webACL)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), awssdk.ToInt64(status.ObservedGeneration))
+	assert.Equal(t, int64(101), awssdk.ToInt64(status.Capacity))
+	assert.Len(t, status.IPSets, 1)
+	current, exists := wafv2Client.WebACL(awssdk.ToString(status.WebACLARN))
+	assert.True(t, exists)
+	assert.Equal(t, "k8s-my-cluster-shop", awssdk.ToString(current.Name))
+	assert.Len(t, current.Rules, 2)
+	assert.Equal(t, map[string]string{
+		"elbv2.k8s.aws/cluster":  "my-cluster",
+		"wafv2.k8s.aws/stack":    "shop",
+		"wafv2.k8s.aws/resource": "WebACL",
+		"team":                   "security",
+	}, wafv2Client.Tags(awssdk.ToString(status.WebACLARN)))
+	ipSet, exists := wafv2Client.IPSet(status.IPSets[0].ARN)
+	assert.True(t, exists)
+	assert.Equal(t, "k8s-my-cluster-shop-office", awssdk.ToString(ipSet.Name))
+	assert.Equal(t, "IPSet/office", wafv2Client.Tags(status.IPSets[0].ARN)["wafv2.k8s.aws/resource"])
+
+	// the IP set is removed once the rule referencing it is removed.
+	webACL.Status = status
+	webACL.Generation = 2
+	webACL.Spec.IPSets = nil
+	webACL.Spec.Rules = webACL.Spec.Rules[:1]
+	status, err = m.Reconcile(ctx, webACL)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), awssdk.ToInt64(status.Capacity))
+	assert.Empty(t, status.IPSets)
+	_, exists = wafv2Client.IPSet(webACL.Status.IPSets[0].ARN)
+	assert.False(t, exists)
+
+	// deletion is blocked while a load balancer is associated.
+	webACL.Status = status
+	wafv2Client.Associate(awssdk.ToString(status.WebACLARN), testLBARN)
+	associatedLBs, err := m.Delete(ctx, webACL)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testLBARN}, associatedLBs)
+	_, exists = wafv2Client.WebACL(awssdk.ToString(status.WebACLARN))
+	assert.True(t, exists)
+
+	_, err = wafv2Client.DisassociateWebACLWithContext(ctx, &wafv2sdk.DisassociateWebACLInput{ResourceArn: awssdk.String(testLBARN)})
+	assert.NoError(t, err)
+	associatedLBs, err = m.Delete(ctx, webACL)
+	assert.NoError(t, err)
+	assert.Empty(t, associatedLBs)
+	_, exists = wafv2Client.WebACL(awssdk.ToString(status.WebACLARN))
+	assert.False(t, exists)
+}
+
+func Test_defaultManager_Reconcile_adoption(t *testing.T) {
+	ctx := context.Background()
+	wafv2Client := fake.NewWAFv2("us-west-2", "123456789012")
+	m := newTestManager(wafv2Client)
+
+	// a web ACL created before its status was recorded is adopted.
+	status, err := m.Reconcile(ctx, newTestWebACL())
+	assert.NoError(t, err)
+	adoptedStatus, err := m.Reconcile(ctx, newTestWebACL())
+	assert.NoError(t, err)
+	assert.Equal(t, status.WebACLARN, adoptedStatus.WebACLARN)
+	assert.Equal(t, status.IPSets, adoptedStatus.IPSets)
+
+	// a web ACL managed outside the controller is never adopted.
+	webACL := newTestWebACL()
+	webACL.Spec.WebACLName = awssdk.String("unmanaged")
+	webACL.Spec.IPSets = nil
+	webACL.Spec.Rules = webACL.Spec.Rules[:1]
+	wafv2Client.AddWebACL("unmanaged")
+	_, err = m.Reconcile(ctx, webACL)
+	assert.ErrorContains(t, err, "isn't managed by WAFv2WebACL shop")
+}
+
+func Test_defaultManager_Reconcile_invalidSpec(t *testing.T) {
+	ctx := context.Background()
+	wafv2Client := fake.NewWAFv2("us-west-2", "123456789012")
+	m := newTestManager(wafv2Client)
+	webACL := newTestWebACL()
+	webACL.Spec.Rules[1].IPSetReference.Name = "unknown"
+
+	_, err := m.Reconcile(ctx, webACL)
+	assert.Error(t, err)
+	resp, err := wafv2Client.ListWebACLsWithContext(ctx, &wafv2sdk.ListWebACLsInput{Scope: wafv2types.ScopeRegional})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.WebACLs)
+}
+
+func Test_defaultManager_Reconcile_drift(t *testing.T) {
+	ctx := context.Background()
+	wafv2Client := fake.NewWAFv2("us-west-2", "123456789012")
+	m := newTestManager(wafv2Client)
+	webACL := newTestWebACL()
+
+	status, err := m.Reconcile(ctx, webACL)
+	assert.NoError(t, err)
+	webACL.Status = status
+
+	// the web ACL is left untouched when it matches the spec.
+	resp, err := wafv2Client.GetWebACLWithContext(ctx, &wafv2sdk.GetWebACLInput{Id: status.WebACLID, Scope: wafv2types.ScopeRegional})
+	assert.NoError(t, err)
+	lockToken := awssdk.ToString(resp.LockToken)
+	_, err = m.Reconcile(ctx, webACL)
+	assert.NoError(t, err)
+	resp, err = wafv2Client.GetWebACLWithContext(ctx, &wafv2sdk.GetWebACLInput{Id: status.WebACLID, Scope: wafv2types.ScopeRegional})
+	assert.NoError(t, err)
+	assert.Equal(t, lockToken, awssdk.ToString(resp.LockToken))
+
+	// rules, default action and visibility config changed outside the controller are reverted.
+	_, err = wafv2Client.UpdateWebACLWithContext(ctx, &wafv2sdk.UpdateWebACLInput{
+		Name:          resp.WebACL.Name,
+		Id:            resp.WebACL.Id,
+		Scope:         wafv2types.ScopeRegional,
+		LockToken:     resp.LockToken,
+		DefaultAction: &wafv2types.DefaultAction{Block: &wafv2types.BlockAction{}},
+		VisibilityConfig: &wafv2types.VisibilityConfig{
+			MetricName: resp.WebACL.Name,
+		},
+		Rules: resp.WebACL.Rules[:1],
+	})
+	assert.NoError(t, err)
+	_, err = m.Reconcile(ctx, webACL)
+	assert.NoError(t, err)
+	current, exists := wafv2Client.WebACL(awssdk.ToString(status.WebACLARN))
+	assert.True(t, exists)
+	assert.Equal(t, buildDefaultAction(webACL.Spec.DefaultAction), current.DefaultAction)
+	assert.Equal(t, buildVisibilityConfig(webACL.Spec, awssdk.ToString(current.Name)), current.VisibilityConfig)
+	assert.Len(t, current.Rules, 2)
+}
@@ -0,0 +1,44 @@
+package webacl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+const (
+	// maximum length of web ACL and IP set names.
+	nameMaxLength = 128
+	// length of the hash suffix of truncated names.
+	nameHashLength = 10
+)
+
+// invalidNameCharPattern matches the characters WAFv2 doesn't allow in names.
+var invalidNameCharPattern = regexp.MustCompile(`[^\w-]`)
+
+// WebACLName returns the name of the web ACL of a WAFv2WebACL in AWS.
+func WebACLName(clusterName string, webACL *elbv2api.WAFv2WebACL) string {
+	if webACL.Spec.WebACLName != nil {
+		return *webACL.Spec.WebACLName
+	}
+	return buildName("k8s", clusterName, webACL.Name)
+}
+
+// ipSetName returns the name of an IP set of the web ACL in AWS.
+func ipSetName(webACLName string, ipSet string) string {
+	return buildName(webACLName, ipSet)
+}
+
+// buildName joins parts with "-", replacing characters WAFv2 doesn't allow in names.
+// Names exceeding the maximum length are truncated and suffixed with a hash of the full name, so they stay unique.
+func buildName(parts ...string) string {
+	name := invalidNameCharPattern.ReplaceAllString(strings.Join(parts, "-"), "-")
+	if len(name) <= nameMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	return name[:nameMaxLength-nameHashLength-1] + "-" + hex.EncodeToString(hash[:])[:nameHashLength]
+}
@@ -0,0 +1,45 @@
+package webacl
+
+import (
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_WebACLName(t *testing.T) {
+	tests := []struct {
+		name   string
+		webACL *elbv2api.WAFv2WebACL
+		want   string
+	}{
+		{
+			name:   "derived from cluster and object name",
+			webACL: &elbv2api.WAFv2WebACL{ObjectMeta: metav1.ObjectMeta{Name: "shop.example"}},
+			want:   "k8s-my-cluster-shop-example",
+		},
+		{
+			name: "explicit name",
+			webACL: &elbv2api.WAFv2WebACL{
+				ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+				Spec:       elbv2api.WAFv2WebACLSpec{WebACLName: awssdk.String("shop-acl")},
+			},
+			want: "shop-acl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WebACLName("my-cluster", tt.webACL))
+		})
+	}
+}
+
+func Test_buildName(t *testing.T) {
+	longName := buildName(strings.Repeat("a", 100), strings.Repeat("b", 100))
+	assert.Len(t, longName, nameMaxLength)
+	assert.True(t, strings.HasPrefix(longName, strings.Repeat("a", 100)+"-b"))
+	assert.NotEqual(t, longName, buildName(strings.Repeat("a", 100), strings.Repeat("b", 101)))
+}
@@ -0,0 +1,26 @@
+package webacl
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveWebACLARN returns the ARN of the web ACL of the WAFv2WebACL with name.
+// WAFv2WebACLs that are being deleted or not provisioned yet can't be referenced.
+func ResolveWebACLARN(ctx context.Context, k8sClient client.Client, name string) (string, error) {
+	webACL := &elbv2api.WAFv2WebACL{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, webACL); err != nil {
+		return "", errors.Wrapf(err, "failed to get WAFv2WebACL %v", name)
+	}
+	if !webACL.DeletionTimestamp.IsZero() {
+		return "", errors.Errorf("WAFv2WebACL %v is being deleted", name)
+	}
+	if webACL.Status.WebACLARN == nil {
+		return "", errors.Errorf("WAFv2WebACL %v isn't provisioned yet", name)
+	}
+	return *webACL.Status.WebACLARN, nil
+}
@@ -0,0 +1,225 @@
+package webacl
+
+import (
+	"go/token"
+	"net/netip"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+// validateSpec validates the rules and IP sets of spec, before any web ACL is changed.
+func validateSpec(spec elbv2api.WAFv2WebACLSpec) error {
+	ipSetNames := sets.New[string]()
+	for _, ipSet := range spec.IPSets {
+		if ipSetNames.Has(ipSet.Name) {
+			return errors.Errorf("duplicate IP set %v", ipSet.Name)
+		}
+		ipSetNames.Insert(ipSet.Name)
+		version := ipAddressVersion(ipSet)
+		for _, address := range ipSet.Addresses {
+			prefix, err := netip.ParsePrefix(address)
+			if err != nil {
+				return errors.Wrapf(err, "invalid address %v of IP set %v", address, ipSet.Name)
+			}
+			if prefix.Addr().Is4() != (version == elbv2api.WAFv2IPAddressVersionIPV4) {
+				return errors.Errorf("address %v of IP set %v doesn't match IP address version %v", address, ipSet.Name, version)
+			}
+		}
+	}
+
+	ruleNames := sets.New[string]()
+	rulePriorities := sets.New[int32]()
+	for _, rule := range spec.Rules {
+		if ruleNames.Has(rule.Name) {
+			return errors.Errorf("duplicate rule %v", rule.Name)
+		}
+		ruleNames.Insert(rule.Name)
+		if rulePriorities.Has(rule.Priority) {
+			return errors.Errorf("duplicate priority %v of rule %v", rule.Priority, rule.Name)
+		}
+		rulePriorities.Insert(rule.Priority)
+
+		statements := 0
+		for _, specified := range []bool{rule.ManagedRuleGroup != nil, rule.RateBased != nil, rule.IPSetReference != nil} {
+			if specified {
+				statements++
+			}
+		}
+		if statements != 1 {
+			return errors.Errorf("rule %v must specify exactly one of managedRuleGroup, rateBased and ipSetReference", rule.Name)
+		}
+		if rule.ManagedRuleGroup != nil && rule.Action != nil {
+			return errors.Errorf("rule %v: action isn't supported for managed rule groups, use overrideAction or ruleActionOverrides instead", rule.Name)
+		}
+		if rule.RateBased != nil {
+			if rule.RateBased.ScopeDownIPSet != nil && !ipSetNames.Has(*rule.RateBased.ScopeDownIPSet) {
+				return errors.Errorf("rule %v references unknown IP set %v", rule.Name, *rule.RateBased.ScopeDownIPSet)
+			}
+			if rule.RateBased.ForwardedIPHeaderName != nil && rateBasedAggregateKeyType(*rule.RateBased) != elbv2api.WAFv2RateBasedAggregateKeyTypeForwardedIP {
+				return errors.Errorf("rule %v: forwardedIPHeaderName requires aggregateKeyType %v", rule.Name, elbv2api.WAFv2RateBasedAggregateKeyTypeForwardedIP)
+			}
+		}
+		if rule.IPSetReference != nil && !ipSetNames.Has(rule.IPSetReference.Name) {
+			return errors.Errorf("rule %v references unknown IP set %v", rule.Name, rule.IPSetReference.Name)
+		}
+	}
+	return nil
+}
+
+// buildRules builds the web ACL rules of spec, ipSetARNs are the ARNs of the IP sets by name.
+func buildRules(spec elbv2api.WAFv2WebACLSpec, ipSetARNs map[string]string) []wafv2types.Rule {
+	rules := make([]wafv2types.Rule, 0, len(spec.Rules))
+	for _, rule := range spec.Rules {
+		sdkRule := wafv2types.Rule{
+			Name:             awssdk.String(rule.Name),
+			Priority:         rule.Priority,
+			VisibilityConfig: buildVisibilityConfig(spec, rule.Name),
+		}
+		switch {
+		case rule.ManagedRuleGroup != nil:
+			sdkRule.Statement = &wafv2types.Statement{ManagedRuleGroupStatement: buildManagedRuleGroupStatement(*rule.ManagedRuleGroup)}
+			sdkRule.OverrideAction = buildOverrideAction(rule.ManagedRuleGroup.OverrideAction)
+		case rule.RateBased != nil:
+			sdkRule.Statement = &wafv2types.Statement{RateBasedStatement: buildRateBasedStatement(*rule.RateBased, ipSetARNs)}
+			sdkRule.Action = buildRuleAction(rule.Action)
+		case rule.IPSetReference != nil:
+			sdkRule.Statement = buildIPSetReferenceStatement(ipSetARNs[rule.IPSetReference.Name])
+			sdkRule.Action = buildRuleAction(rule.Action)
+		}
+		rules = append(rules, sdkRule)
+	}
+	return rules
+}
+
+func buildManagedRuleGroupStatement(ruleGroup elbv2api.WAFv2ManagedRuleGroup) *wafv2types.ManagedRuleGroupStatement {
+	statement := &wafv2types.ManagedRuleGroupStatement{
+		VendorName: awssdk.String(ruleGroup.VendorName),
+		Name:       awssdk.String(ruleGroup.Name),
+		Version:    ruleGroup.Version,
+	}
+	for _, override := range ruleGroup.RuleActionOverrides {
+		action := override.Action
+		statement.RuleActionOverrides = append(statement.RuleActionOverrides, wafv2types.RuleActionOverride{
+			Name:        awssdk.String(override.Name),
+			ActionToUse: buildRuleAction(&action),
+		})
+	}
+	return statement
+}
+
+func buildRateBasedStatement(rateBased elbv2api.WAFv2RateBasedRule, ipSetARNs map[string]string) *wafv2types.RateBasedStatement {
+	statement := &wafv2types.RateBasedStatement{
+		Limit:            awssdk.Int64(rateBased.Limit),
+		AggregateKeyType: wafv2types.RateBasedStatementAggregateKeyType(rateBasedAggregateKeyType(rateBased)),
+	}
+	if rateBased.EvaluationWindowSec != nil {
+		statement.EvaluationWindowSec = *rateBased.EvaluationWindowSec
+	}
+	if statement.AggregateKeyType == wafv2types.RateBasedStatementAggregateKeyTypeForwardedIp {
+		headerName := defaultForwardedIPHeaderName
+		if rateBased.ForwardedIPHeaderName != nil {
+			headerName = *rateBased.ForwardedIPHeaderName
+		}
+		statement.ForwardedIPConfig = &wafv2types.ForwardedIPConfig{
+			HeaderName:       awssdk.String(headerName),
+			FallbackBehavior: wafv2types.FallbackBehaviorMatch,
+		}
+	}
+	if rateBased.ScopeDownIPSet != nil {
+		statement.ScopeDownStatement = buildIPSetReferenceStatement(ipSetARNs[*rateBased.ScopeDownIPSet])
+	}
+	return statement
+}
+
+func buildIPSetReferenceStatement(ipSetARN string) *wafv2types.Statement {
+	return &wafv2types.Statement{
+		IPSetReferenceStatement: &wafv2types.IPSetReferenceStatement{
+			ARN: awssdk.String(ipSetARN),
+		},
+	}
+}
+
+// buildRuleAction builds the action of a rule, which defaults to Block.
+func buildRuleAction(action *elbv2api.WAFv2RuleAction) *wafv2types.RuleAction {
+	if action == nil {
+		return &wafv2types.RuleAction{Block: &wafv2types.BlockAction{}}
+	}
+	switch *action {
+	case elbv2api.WAFv2RuleActionAllow:
+		return &wafv2types.RuleAction{Allow: &wafv2types.AllowAction{}}
+	case elbv2api.WAFv2RuleActionCount:
+		return &wafv2types.RuleAction{Count: &wafv2types.CountAction{}}
+	case elbv2api.WAFv2RuleActionCaptcha:
+		return &wafv2types.RuleAction{Captcha: &wafv2types.CaptchaAction{}}
+	case elbv2api.WAFv2RuleActionChallenge:
+		return &wafv2types.RuleAction{Challenge: &wafv2types.ChallengeAction{}}
+	default:
+		return &wafv2types.RuleAction{Block: &wafv2types.BlockAction{}}
+	}
+}
+
+// buildOverrideAction builds the override action of a managed rule group, which defaults to None.
+func buildOverrideAction(action *elbv2api.WAFv2OverrideAction) *wafv2types.OverrideAction {
+	if action != nil && *action == elbv2api.WAFv2OverrideActionCount {
+		return &wafv2types.OverrideAction{Count: &wafv2types.CountAction{}}
+	}
+	return &wafv2types.OverrideAction{None: &wafv2types.NoneAction{}}
+}
+
+func buildDefaultAction(action elbv2api.WAFv2DefaultAction) *wafv2types.DefaultAction {
+	if action == elbv2api.WAFv2DefaultActionBlock {
+		return &wafv2types.DefaultAction{Block: &wafv2types.BlockAction{}}
+	}
+	return &wafv2types.DefaultAction{Allow: &wafv2types.AllowAction{}}
+}
+
+func buildVisibilityConfig(spec elbv2api.WAFv2WebACLSpec, metricName string) *wafv2types.VisibilityConfig {
+	return &wafv2types.VisibilityConfig{
+		CloudWatchMetricsEnabled: spec.CloudWatchMetricsEnabled == nil || *spec.CloudWatchMetricsEnabled,
+		SampledRequestsEnabled:   spec.SampledRequestsEnabled == nil || *spec.SampledRequestsEnabled,
+		MetricName:               awssdk.String(metricName),
+	}
+}
+
+func rateBasedAggregateKeyType(rateBased elbv2api.WAFv2RateBasedRule) elbv2api.WAFv2RateBasedAggregateKeyType {
+	if rateBased.AggregateKeyType == nil {
+		return elbv2api.WAFv2RateBasedAggregateKeyTypeIP
+	}
+	return *rateBased.AggregateKeyType
+}
+
+func ipAddressVersion(ipSet elbv2api.WAFv2IPSet) elbv2api.WAFv2IPAddressVersion {
+	if ipSet.IPAddressVersion == nil {
+		return elbv2api.WAFv2IPAddressVersionIPV4
+	}
+	return *ipSet.IPAddressVersion
+}
+
+// compareOptionForWebACL compares the rules, default action and visibility config built from the spec with the ones returned by GetWebACL.
+// Rules are compared regardless of their order, and defaults filled in by WAFv2 are ignored.
+func compareOptionForWebACL() cmp.Option {
+	return cmp.Options{
+		cmp.FilterPath(func(p cmp.Path) bool {
+			sf, ok := p.Last().(cmp.StructField)
+			return ok && !token.IsExported(sf.Name())
+		}, cmp.Ignore()),
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(lhs wafv2types.Rule, rhs wafv2types.Rule) bool {
+			return lhs.Priority < rhs.Priority
+		}),
+		cmpopts.AcyclicTransformer("normalizeRateBasedStatement", func(statement *wafv2types.RateBasedStatement) *wafv2types.RateBasedStatement {
+			if statement == nil || statement.EvaluationWindowSec != 0 {
+				return statement
+			}
+			normalizedStatement := *statement
+			normalizedStatement.EvaluationWindowSec = defaultEvaluationWindowSec
+			return &normalizedStatement
+		}),
+	}
+}
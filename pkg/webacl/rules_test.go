@@ -0,0 +1,195 @@
+package webacl
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/stretchr/testify/assert"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+func Test_validateSpec(t *testing.T) {
+	ipv6 := elbv2api.WAFv2IPAddressVersionIPV6
+	forwardedIP := elbv2api.WAFv2RateBasedAggregateKeyTypeForwardedIP
+	block := elbv2api.WAFv2RuleActionBlock
+	officeIPSet := elbv2api.WAFv2IPSet{Name: "office", Addresses: []string{"192.0.2.0/24"}}
+	managedRule := elbv2api.WAFv2Rule{
+		Name:             "common",
+		Priority:         0,
+		ManagedRuleGroup: &elbv2api.WAFv2ManagedRuleGroup{VendorName: "AWS", Name: "AWSManagedRulesCommonRuleSet"},
+	}
+	tests := []struct {
+		name    string
+		spec    elbv2api.WAFv2WebACLSpec
+		wantErr string
+	}{
+		{
+			name: "valid spec",
+			spec: elbv2api.WAFv2WebACLSpec{
+				IPSets: []elbv2api.WAFv2IPSet{officeIPSet, {Name: "office-v6", IPAddressVersion: &ipv6, Addresses: []string{"2001:db8::/32"}}},
+				Rules: []elbv2api.WAFv2Rule{
+					managedRule,
+					{Name: "office", Priority: 1, IPSetReference: &elbv2api.WAFv2IPSetReference{Name: "office-v6"}},
+					{Name: "rate", Priority: 2, RateBased: &elbv2api.WAFv2RateBasedRule{
+						Limit:                 100,
+						AggregateKeyType:      &forwardedIP,
+						ForwardedIPHeaderName: awssdk.String("X-Client-IP"),
+						ScopeDownIPSet:        awssdk.String("office"),
+					}},
+				},
+			},
+		},
+		{
+			name: "duplicate IP set",
+			spec: elbv2api.WAFv2WebACLSpec{
+				IPSets: []elbv2api.WAFv2IPSet{officeIPSet, officeIPSet},
+			},
+			wantErr: "duplicate IP set office",
+		},
+		{
+			name: "invalid address",
+			spec: elbv2api.WAFv2WebACLSpec{
+				IPSets: []elbv2api.WAFv2IPSet{{Name: "office", Addresses: []string{"192.0.2.1"}}},
+			},
+			wantErr: "invalid address 192.0.2.1 of IP set office",
+		},
+		{
+			name: "address doesn't match IP address version",
+			spec: elbv2api.WAFv2WebACLSpec{
+				IPSets: []elbv2api.WAFv2IPSet{{Name: "office", IPAddressVersion: &ipv6, Addresses: []string{"192.0.2.0/24"}}},
+			},
+			wantErr: "address 192.0.2.0/24 of IP set office doesn't match IP address version IPV6",
+		},
+		{
+			name: "duplicate priority",
+			spec: elbv2api.WAFv2WebACLSpec{
+				IPSets: []elbv2api.WAFv2IPSet{officeIPSet},
+				Rules: []elbv2api.WAFv2Rule{
+					managedRule,
+					{Name: "office", Priority: 0, IPSetReference: &elbv2api.WAFv2IPSetReference{Name: "office"}},
+				},
+			},
+			wantErr: "duplicate priority 0 of rule office",
+		},
+		{
+			name: "rule without statement",
+			spec: elbv2api.WAFv2WebACLSpec{
+				Rules: []elbv2api.WAFv2Rule{{Name: "empty", Priority: 0}},
+			},
+			wantErr: "rule empty must specify exactly one of managedRuleGroup, rateBased and ipSetReference",
+		},
+		{
+			name: "action of managed rule group",
+			spec: elbv2api.WAFv2WebACLSpec{
+				Rules: []elbv2api.WAFv2Rule{{
+					Name:             "common",
+					Priority:         0,
+					Action:           &block,
+					ManagedRuleGroup: managedRule.ManagedRuleGroup,
+				}},
+			},
+			wantErr: "rule common: action isn't supported for managed rule groups",
+		},
+		{
+			name: "unknown IP set",
+			spec: elbv2api.WAFv2WebACLSpec{
+				Rules: []elbv2api.WAFv2Rule{{Name: "office", Priority: 0, IPSetReference: &elbv2api.WAFv2IPSetReference{Name: "office"}}},
+			},
+			wantErr: "rule office references unknown IP set office",
+		},
+		{
+			name: "forwarded IP header without FORWARDED_IP aggregation",
+			spec: elbv2api.WAFv2WebACLSpec{
+				Rules: []elbv2api.WAFv2Rule{{Name: "rate", Priority: 0, RateBased: &elbv2api.WAFv2RateBasedRule{
+					Limit:                 100,
+					ForwardedIPHeaderName: awssdk.String("X-Client-IP"),
+				}}},
+			},
+			wantErr: "rule rate: forwardedIPHeaderName requires aggregateKeyType FORWARDED_IP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpec(tt.spec)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_buildRules(t *testing.T) {
+	count := elbv2api.WAFv2OverrideActionCount
+	forwardedIP := elbv2api.WAFv2RateBasedAggregateKeyTypeForwardedIP
+	captcha := elbv2api.WAFv2RuleActionCaptcha
+	spec := elbv2api.WAFv2WebACLSpec{
+		SampledRequestsEnabled: awssdk.Bool(false),
+		Rules: []elbv2api.WAFv2Rule{
+			{
+				Name:     "common",
+				Priority: 0,
+				ManagedRuleGroup: &elbv2api.WAFv2ManagedRuleGroup{
+					VendorName:          "AWS",
+					Name:                "AWSManagedRulesCommonRuleSet",
+					OverrideAction:      &count,
+					RuleActionOverrides: []elbv2api.WAFv2RuleActionOverride{{Name: "SizeRestrictions_BODY", Action: captcha}},
+				},
+			},
+			{
+				Name:     "rate",
+				Priority: 1,
+				RateBased: &elbv2api.WAFv2RateBasedRule{
+					Limit:               100,
+					EvaluationWindowSec: awssdk.Int64(60),
+					AggregateKeyType:    &forwardedIP,
+					ScopeDownIPSet:      awssdk.String("office"),
+				},
+			},
+		},
+	}
+	visibilityConfig := func(metricName string) *wafv2types.VisibilityConfig {
+		return &wafv2types.VisibilityConfig{
+			CloudWatchMetricsEnabled: true,
+			SampledRequestsEnabled:   false,
+			MetricName:               awssdk.String(metricName),
+		}
+	}
+	officeARN := "arn:aws:wafv2:us-west-2:123456789012:regional/ipset/office/1"
+	assert.Equal(t, []wafv2types.Rule{
+		{
+			Name:     awssdk.String("common"),
+			Priority: 0,
+			Statement: &wafv2types.Statement{ManagedRuleGroupStatement: &wafv2types.ManagedRuleGroupStatement{
+				VendorName: awssdk.String("AWS"),
+				Name:       awssdk.String("AWSManagedRulesCommonRuleSet"),
+				RuleActionOverrides: []wafv2types.RuleActionOverride{{
+					Name:        awssdk.String("SizeRestrictions_BODY"),
+					ActionToUse: &wafv2types.RuleAction{Captcha: &wafv2types.CaptchaAction{}},
+				}},
+			}},
+			OverrideAction:   &wafv2types.OverrideAction{Count: &wafv2types.CountAction{}},
+			VisibilityConfig: visibilityConfig("common"),
+		},
+		{
+			Name:     awssdk.String("rate"),
+			Priority: 1,
+			Statement: &wafv2types.Statement{RateBasedStatement: &wafv2types.RateBasedStatement{
+				Limit:               awssdk.Int64(100),
+				EvaluationWindowSec: 60,
+				AggregateKeyType:    wafv2types.RateBasedStatementAggregateKeyTypeForwardedIp,
+				ForwardedIPConfig: &wafv2types.ForwardedIPConfig{
+					HeaderName:       awssdk.String("X-Forwarded-For"),
+					FallbackBehavior: wafv2types.FallbackBehaviorMatch,
+				},
+				ScopeDownStatement: &wafv2types.Statement{IPSetReferenceStatement: &wafv2types.IPSetReferenceStatement{
+					ARN: awssdk.String(officeARN),
+				}},
+			}},
+			Action:           &wafv2types.RuleAction{Block: &wafv2types.BlockAction{}},
+			VisibilityConfig: visibilityConfig("rate"),
+		},
+	}, buildRules(spec, map[string]string{"office": officeARN}))
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject/pod_termination"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	apiPathValidatePodTerminationGate = "/validate-v1-pod"
+	subResourceEviction               = "eviction"
+)
+
+// NewPodTerminationGateValidator returns a pod termination gate validator.
+func NewPodTerminationGateValidator(podTerminationGate *pod_termination.PodTerminationGate, apiReader client.Reader, metricsCollector lbcmetrics.MetricCollector) *podTerminationGateValidator {
+	return &podTerminationGateValidator{
+		podTerminationGate: podTerminationGate,
+		apiReader:          apiReader,
+		metricsCollector:   metricsCollector,
+	}
+}
+
+var _ webhook.Validator = &podTerminationGateValidator{}
+
+type podTerminationGateValidator struct {
+	podTerminationGate *pod_termination.PodTerminationGate
+	// apiReader is used to read pods for eviction requests, so that we don't cache all pods in the cluster.
+	apiReader        client.Reader
+	metricsCollector lbcmetrics.MetricCollector
+}
+
+func (v *podTerminationGateValidator) Prototype(req admission.Request) (runtime.Object, error) {
+	if req.SubResource == subResourceEviction {
+		return &policyv1.Eviction{}, nil
+	}
+	return &corev1.Pod{}, nil
+}
+
+func (v *podTerminationGateValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	eviction, ok := obj.(*policyv1.Eviction)
+	if !ok {
+		return nil
+	}
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	pod := &corev1.Pod{}
+	if err := v.apiReader.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: eviction.Name}, pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return v.checkDeletion(ctx, pod, eviction.DeleteOptions)
+}
+
+func (v *podTerminationGateValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	return nil
+}
+
+func (v *podTerminationGateValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	pod := obj.(*corev1.Pod)
+	var deleteOptions *metav1.DeleteOptions
+	if req := webhook.ContextGetAdmissionRequest(ctx); req != nil && len(req.Options.Raw) != 0 {
+		deleteOptions = &metav1.DeleteOptions{}
+		if err := json.Unmarshal(req.Options.Raw, deleteOptions); err != nil {
+			return err
+		}
+	}
+	return v.checkDeletion(ctx, pod, deleteOptions)
+}
+
+func (v *podTerminationGateValidator) checkDeletion(ctx context.Context, pod *corev1.Pod, deleteOptions *metav1.DeleteOptions) error {
+	if err := v.podTerminationGate.CheckDeletion(ctx, pod, deleteOptions); err != nil {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidatePodTerminationGate, "checkDeletion")
+		return err
+	}
+	return nil
+}
+
+// The manifests split this marker into two rules, so that only DELETE of pods and CREATE of pods/eviction are intercepted.
+// +kubebuilder:webhook:path=/validate-v1-pod,mutating=false,failurePolicy=ignore,groups="",resources=pods;pods/eviction,verbs=create;delete,versions=v1,name=vpod.elbv2.k8s.aws,sideEffects=NoneOnDryRun,webhookVersions=v1,admissionReviewVersions=v1
+
+func (v *podTerminationGateValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidatePodTerminationGate, webhook.ValidatingWebhookForValidator(v, mgr.GetScheme()))
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -17,20 +19,25 @@ const (
 )
 
 // NewServiceMutator returns a mutator for Service.
-func NewServiceMutator(lbClass string, logger logr.Logger, metricsCollector lbcmetrics.MetricCollector) *serviceMutator {
+func NewServiceMutator(lbClass string, additionalLBClasses []string, classParamsLoader service.ClassParamsLoader,
+	logger logr.Logger, metricsCollector lbcmetrics.MetricCollector) *serviceMutator {
 	return &serviceMutator{
-		logger:            logger,
-		loadBalancerClass: lbClass,
-		metricsCollector:  metricsCollector,
+		logger:              logger,
+		loadBalancerClass:   lbClass,
+		loadBalancerClasses: sets.New(append([]string{lbClass}, additionalLBClasses...)...),
+		classParamsLoader:   classParamsLoader,
+		metricsCollector:    metricsCollector,
 	}
 }
 
 var _ webhook.Mutator = &serviceMutator{}
 
 type serviceMutator struct {
-	logger            logr.Logger
-	loadBalancerClass string
-	metricsCollector  lbcmetrics.MetricCollector
+	logger              logr.Logger
+	loadBalancerClass   string
+	loadBalancerClasses sets.Set[string]
+	classParamsLoader   service.ClassParamsLoader
+	metricsCollector    lbcmetrics.MetricCollector
 }
 
 func (m *serviceMutator) Prototype(_ admission.Request) (runtime.Object, error) {
@@ -45,12 +52,14 @@ func (m *serviceMutator) MutateCreate(ctx context.Context, obj runtime.Object) (
 
 	if svc.Spec.LoadBalancerClass != nil && *svc.Spec.LoadBalancerClass != "" {
 		m.logger.Info("service already has loadBalancerClass, skipping", "service", svc.Name, "loadBalancerClass", *svc.Spec.LoadBalancerClass)
-		return svc, nil
+	} else {
+		svc.Spec.LoadBalancerClass = &m.loadBalancerClass
+		m.logger.Info("setting service loadBalancerClass", "service", svc.Name, "loadBalancerClass", m.loadBalancerClass)
 	}
 
-	svc.Spec.LoadBalancerClass = &m.loadBalancerClass
-	m.logger.Info("setting service loadBalancerClass", "service", svc.Name, "loadBalancerClass", m.loadBalancerClass)
-
+	if err := m.checkServiceClassParams(ctx, svc); err != nil {
+		return nil, err
+	}
 	return svc, nil
 }
 
@@ -81,17 +90,32 @@ func (m *serviceMutator) MutateUpdate(ctx context.Context, obj runtime.Object, o
 			newSvc.Spec.LoadBalancerClass = oldSvc.Spec.LoadBalancerClass
 
 			m.logger.Info("preserved loadBalancerClass", "service", newSvc.Name, "loadBalancerClass", *newSvc.Spec.LoadBalancerClass)
-			return newSvc, nil
+		} else {
+			m.logger.Info("service already has loadBalancerClass, skipping", "service", newSvc.Name, "loadBalancerClass", *newSvc.Spec.LoadBalancerClass)
 		}
-
-		m.logger.Info("service already has loadBalancerClass, skipping", "service", newSvc.Name, "loadBalancerClass", *newSvc.Spec.LoadBalancerClass)
-		return newSvc, nil
+	} else {
+		m.logger.Info("service did not originally have a loadBalancerClass, skipping", "service", newSvc.Name)
 	}
 
-	m.logger.Info("service did not originally have a loadBalancerClass, skipping", "service", newSvc.Name)
+	if err := m.checkServiceClassParams(ctx, newSvc); err != nil {
+		return nil, err
+	}
 	return newSvc, nil
 }
 
+// checkServiceClassParams denies Services of a loadBalancerClass reconciled by this controller
+// when the ServiceClassParams selecting that class doesn't allow the Service namespace.
+func (m *serviceMutator) checkServiceClassParams(ctx context.Context, svc *corev1.Service) error {
+	if svc.Spec.LoadBalancerClass == nil || !m.loadBalancerClasses.Has(*svc.Spec.LoadBalancerClass) {
+		return nil
+	}
+	if _, err := m.classParamsLoader.Load(ctx, svc); err != nil {
+		m.metricsCollector.ObserveWebhookMutationError(apiPathMutateService, "checkServiceClassParams")
+		return err
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/mutate-v1-service,mutating=true,failurePolicy=fail,groups="",resources=services,verbs=create,versions=v1,name=mservice.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1
 
 func (m *serviceMutator) SetupWithManager(mgr ctrl.Manager) {
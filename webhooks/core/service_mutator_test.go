@@ -4,8 +4,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestMutateUpdate_WhenServiceIsNotLoadBalancer(t *testing.T) {
@@ -45,6 +53,70 @@ func TestMutateUpdate_WhenOldServiceDoesNotHaveLoadBalancerClass(t *testing.T) {
 	assert.Nil(t, newSvc.Spec.LoadBalancerClass)
 }
 
+func newServiceMutatorWithClassParams() *serviceMutator {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "public-ns", Labels: map[string]string{"exposure": "public"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-ns"}},
+		&elbv2api.ServiceClassParams{
+			ObjectMeta: metav1.ObjectMeta{Name: "public"},
+			Spec: elbv2api.ServiceClassParamsSpec{
+				LoadBalancerClass: "service.k8s.aws/nlb-public",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"exposure": "public"}},
+			},
+		},
+	).Build()
+	return NewServiceMutator("service.k8s.aws/nlb", []string{"service.k8s.aws/nlb-public"},
+		service.NewDefaultClassParamsLoader(k8sClient), logr.Discard(), lbcmetrics.NewMockCollector())
+}
+
+func TestMutateCreate_WhenServiceClassParamsAllowsNamespace(t *testing.T) {
+	m := newServiceMutatorWithClassParams()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "public-ns", Name: "svc"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: stringPtr("service.k8s.aws/nlb-public")},
+	}
+	_, err := m.MutateCreate(context.Background(), svc)
+	assert.NoError(t, err)
+}
+
+func TestMutateCreate_WhenServiceClassParamsDeniesNamespace(t *testing.T) {
+	m := newServiceMutatorWithClassParams()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-ns", Name: "svc"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: stringPtr("service.k8s.aws/nlb-public")},
+	}
+	_, err := m.MutateCreate(context.Background(), svc)
+	assert.EqualError(t, err, "namespace team-ns is not allowed to use loadBalancerClass service.k8s.aws/nlb-public by namespaceSelector of ServiceClassParams public")
+}
+
+func TestMutateCreate_WhenServiceUsesDefaultClassWithoutServiceClassParams(t *testing.T) {
+	m := newServiceMutatorWithClassParams()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-ns", Name: "svc"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+	_, err := m.MutateCreate(context.Background(), svc)
+	assert.NoError(t, err)
+	assert.Equal(t, "service.k8s.aws/nlb", *svc.Spec.LoadBalancerClass)
+}
+
+func TestMutateUpdate_WhenServiceClassParamsDeniesNamespace(t *testing.T) {
+	m := newServiceMutatorWithClassParams()
+	oldSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-ns", Name: "svc"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: stringPtr("service.k8s.aws/nlb")},
+	}
+	newSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-ns", Name: "svc"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: stringPtr("service.k8s.aws/nlb-public")},
+	}
+	_, err := m.MutateUpdate(context.Background(), newSvc, oldSvc)
+	assert.EqualError(t, err, "namespace team-ns is not allowed to use loadBalancerClass service.k8s.aws/nlb-public by namespaceSelector of ServiceClassParams public")
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
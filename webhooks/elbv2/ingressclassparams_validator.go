@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -45,6 +46,10 @@ func (v *ingressClassParamsValidator) ValidateCreate(ctx context.Context, obj ru
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2IngressClassParams, "checkSubnetSelectors")
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := v.checkMinimumLoadBalancerCapacity(icp); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2IngressClassParams, "checkMinimumLoadBalancerCapacity")
+		allErrs = append(allErrs, errs...)
+	}
 	return allErrs.ToAggregate()
 }
 
@@ -59,6 +64,10 @@ func (v *ingressClassParamsValidator) ValidateUpdate(ctx context.Context, obj ru
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2IngressClassParams, "checkSubnetSelectors")
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := v.checkMinimumLoadBalancerCapacity(icp); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2IngressClassParams, "checkMinimumLoadBalancerCapacity")
+		allErrs = append(allErrs, errs...)
+	}
 	return allErrs.ToAggregate()
 }
 
@@ -104,10 +113,13 @@ func validateCIDR(cidr string, fieldPath *field.Path) field.ErrorList {
 }
 
 // checkSubnetSelectors will check for valid SubnetSelectors
-func (v *ingressClassParamsValidator) checkSubnetSelectors(icp *elbv2api.IngressClassParams) (allErrs field.ErrorList) {
-	if icp.Spec.Subnets != nil {
-		subnets := icp.Spec.Subnets
-		fieldPath := field.NewPath("spec", "subnets")
+func (v *ingressClassParamsValidator) checkSubnetSelectors(icp *elbv2api.IngressClassParams) field.ErrorList {
+	return validateSubnetSelector(icp.Spec.Subnets, field.NewPath("spec", "subnets"))
+}
+
+// validateSubnetSelector will check for a valid SubnetSelector.
+func validateSubnetSelector(subnets *elbv2api.SubnetSelector, fieldPath *field.Path) (allErrs field.ErrorList) {
+	if subnets != nil {
 		if subnets.IDs == nil && subnets.Tags == nil {
 			allErrs = append(allErrs, field.Required(fieldPath, "must have either `ids` or `tags`"))
 			return allErrs
@@ -146,6 +158,18 @@ func (v *ingressClassParamsValidator) checkSubnetSelectors(icp *elbv2api.Ingress
 	return allErrs
 }
 
+// checkMinimumLoadBalancerCapacity will check for valid capacity reservation schedules, calendar and time zone.
+func (v *ingressClassParamsValidator) checkMinimumLoadBalancerCapacity(icp *elbv2api.IngressClassParams) field.ErrorList {
+	capacity := capacityreservation.FromIngressClassParams(icp.Spec.MinimumLoadBalancerCapacity)
+	if capacity == nil {
+		return nil
+	}
+	if err := capacityreservation.Validate(*capacity); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "minimumLoadBalancerCapacity"), field.OmitValueType{}, err.Error())}
+	}
+	return nil
+}
+
 // +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-ingressclassparams,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=create;update,versions=v1beta1,name=vingressclassparams.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1
 
 func (v *ingressClassParamsValidator) SetupWithManager(mgr ctrl.Manager) {
@@ -3,8 +3,10 @@ package elbv2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
 )
@@ -177,6 +179,43 @@ func Test_ingressClassParamsValidator_ValidateCreate(t *testing.T) {
 			wantErr:    "spec.subnets.tags: Required value: must have at least one tag key",
 			wantMetric: true,
 		},
+		{
+			name: "minimumLoadBalancerCapacity with schedules",
+			obj: &elbv2api.IngressClassParams{
+				Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+						CapacityUnits: 100,
+						Schedules: []elbv2api.CapacityReservationSchedule{
+							{
+								Name:          "business-hours",
+								Start:         "0 8 * * mon-fri",
+								Duration:      metav1.Duration{Duration: 10 * time.Hour},
+								CapacityUnits: 400,
+							},
+						},
+						TimeZone: "Europe/Paris",
+					},
+				},
+			},
+		},
+		{
+			name: "minimumLoadBalancerCapacity with invalid schedule",
+			obj: &elbv2api.IngressClassParams{
+				Spec: elbv2api.IngressClassParamsSpec{
+					MinimumLoadBalancerCapacity: &elbv2api.MinimumLoadBalancerCapacity{
+						Schedules: []elbv2api.CapacityReservationSchedule{
+							{
+								Name:     "business-hours",
+								Start:    "0 8 * *",
+								Duration: metav1.Duration{Duration: 10 * time.Hour},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    "spec.minimumLoadBalancerCapacity: Invalid value: invalid capacity reservation schedule business-hours: invalid cron expression \"0 8 * *\": expected 5 fields, got 4",
+			wantMetric: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,103 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiPathValidateELBv2ServiceClassParams = "/validate-elbv2-k8s-aws-v1beta1-serviceclassparams"
+
+// NewServiceClassParamsValidator returns a validator for the ServiceClassParams CRD.
+func NewServiceClassParamsValidator(k8sClient client.Client, metricsCollector lbcmetrics.MetricCollector) *serviceClassParamsValidator {
+	return &serviceClassParamsValidator{
+		k8sClient:        k8sClient,
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ webhook.Validator = &serviceClassParamsValidator{}
+
+type serviceClassParamsValidator struct {
+	k8sClient        client.Client
+	metricsCollector lbcmetrics.MetricCollector
+}
+
+func (v *serviceClassParamsValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &elbv2api.ServiceClassParams{}, nil
+}
+
+func (v *serviceClassParamsValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	scp := obj.(*elbv2api.ServiceClassParams)
+	return v.validate(ctx, scp)
+}
+
+func (v *serviceClassParamsValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	scp := obj.(*elbv2api.ServiceClassParams)
+	return v.validate(ctx, scp)
+}
+
+func (v *serviceClassParamsValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *serviceClassParamsValidator) validate(ctx context.Context, scp *elbv2api.ServiceClassParams) error {
+	allErrs := field.ErrorList{}
+	errs, err := v.checkLoadBalancerClassUniqueness(ctx, scp)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2ServiceClassParams, "checkLoadBalancerClassUniqueness")
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := v.checkInboundCIDRs(scp); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2ServiceClassParams, "checkInboundCIDRs")
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateSubnetSelector(scp.Spec.Subnets, field.NewPath("spec", "subnets")); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2ServiceClassParams, "checkSubnetSelectors")
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs.ToAggregate()
+}
+
+// checkLoadBalancerClassUniqueness will check that no other ServiceClassParams selects the same loadBalancerClass.
+func (v *serviceClassParamsValidator) checkLoadBalancerClassUniqueness(ctx context.Context, scp *elbv2api.ServiceClassParams) (field.ErrorList, error) {
+	scpList := &elbv2api.ServiceClassParamsList{}
+	if err := v.k8sClient.List(ctx, scpList); err != nil {
+		return nil, fmt.Errorf("failed to list ServiceClassParams: %w", err)
+	}
+	allErrs := field.ErrorList{}
+	for _, other := range scpList.Items {
+		if other.Name == scp.Name || other.Spec.LoadBalancerClass != scp.Spec.LoadBalancerClass {
+			continue
+		}
+		allErrs = append(allErrs, field.Duplicate(field.NewPath("spec", "loadBalancerClass"),
+			fmt.Sprintf("%v is already selected by ServiceClassParams %v", scp.Spec.LoadBalancerClass, other.Name)))
+	}
+	return allErrs, nil
+}
+
+// checkInboundCIDRs will check for valid inboundCIDRs.
+func (v *serviceClassParamsValidator) checkInboundCIDRs(scp *elbv2api.ServiceClassParams) (allErrs field.ErrorList) {
+	for idx, cidr := range scp.Spec.InboundCIDRs {
+		fieldPath := field.NewPath("spec", "inboundCIDRs").Index(idx)
+		allErrs = append(allErrs, validateCIDR(cidr, fieldPath)...)
+	}
+	return allErrs
+}
+
+// +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-serviceclassparams,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=serviceclassparams,verbs=create;update,versions=v1beta1,name=vserviceclassparams.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1
+
+func (v *serviceClassParamsValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateELBv2ServiceClassParams, webhook.ValidatingWebhookForValidator(v, mgr.GetScheme()))
+}
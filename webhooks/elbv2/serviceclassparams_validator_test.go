@@ -0,0 +1,112 @@
+package elbv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_serviceClassParamsValidator_ValidateCreate(t *testing.T) {
+	existing := &elbv2api.ServiceClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+		Spec:       elbv2api.ServiceClassParamsSpec{LoadBalancerClass: "service.k8s.aws/nlb-internal"},
+	}
+	tests := []struct {
+		name       string
+		obj        *elbv2api.ServiceClassParams
+		wantErr    string
+		wantMetric bool
+	}{
+		{
+			name: "valid",
+			obj: &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "public"},
+				Spec: elbv2api.ServiceClassParamsSpec{
+					LoadBalancerClass: "service.k8s.aws/nlb-public",
+					InboundCIDRs:      []string{"10.0.0.0/8", "2001:DB8::/32"},
+					Subnets: &elbv2api.SubnetSelector{
+						Tags: map[string][]string{"kubernetes.io/role/elb": {"1"}},
+					},
+				},
+			},
+		},
+		{
+			name: "same object selecting its own class",
+			obj: &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+				Spec:       elbv2api.ServiceClassParamsSpec{LoadBalancerClass: "service.k8s.aws/nlb-internal"},
+			},
+		},
+		{
+			name: "loadBalancerClass already selected",
+			obj: &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal-2"},
+				Spec:       elbv2api.ServiceClassParamsSpec{LoadBalancerClass: "service.k8s.aws/nlb-internal"},
+			},
+			wantErr:    "spec.loadBalancerClass: Duplicate value: \"service.k8s.aws/nlb-internal is already selected by ServiceClassParams internal\"",
+			wantMetric: true,
+		},
+		{
+			name: "inboundCIDRs IPv4 no length",
+			obj: &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "public"},
+				Spec: elbv2api.ServiceClassParamsSpec{
+					LoadBalancerClass: "service.k8s.aws/nlb-public",
+					InboundCIDRs:      []string{"192.168.0.1"},
+				},
+			},
+			wantErr:    "spec.inboundCIDRs[0]: Invalid value: \"192.168.0.1\": Could not be parsed as a CIDR (did you mean \"192.168.0.1/32\")",
+			wantMetric: true,
+		},
+		{
+			name: "subnet with both ids and tags",
+			obj: &elbv2api.ServiceClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "public"},
+				Spec: elbv2api.ServiceClassParamsSpec{
+					LoadBalancerClass: "service.k8s.aws/nlb-public",
+					Subnets: &elbv2api.SubnetSelector{
+						IDs:  []elbv2api.SubnetID{"subnet-1"},
+						Tags: map[string][]string{"key": {"value"}},
+					},
+				},
+			},
+			wantErr:    "spec.subnets.tags: Forbidden: may not have both `ids` and `tags` set",
+			wantMetric: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(existing.DeepCopy()).Build()
+			mockMetricsCollector := lbcmetrics.NewMockCollector()
+			v := NewServiceClassParamsValidator(k8sClient, mockMetricsCollector)
+			t.Run("create", func(t *testing.T) {
+				err := v.ValidateCreate(context.Background(), tt.obj)
+				if tt.wantErr != "" {
+					assert.EqualError(t, err, tt.wantErr)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+			t.Run("update", func(t *testing.T) {
+				err := v.ValidateUpdate(context.Background(), tt.obj, &elbv2api.ServiceClassParams{})
+				if tt.wantErr != "" {
+					assert.EqualError(t, err, tt.wantErr)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+			mockCollector := mockMetricsCollector.(*lbcmetrics.MockCollector)
+			assert.Equal(t, tt.wantMetric, len(mockCollector.Invocations[lbcmetrics.MetricWebhookValidationFailure]) == 2)
+		})
+	}
+}
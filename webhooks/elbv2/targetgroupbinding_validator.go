@@ -80,6 +80,10 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkNodeSelector")
 		return err
 	}
+	if err := v.checkWeightedRegistration(tgb); err != nil {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkWeightedRegistration")
+		return err
+	}
 	if err := v.checkExistingTargetGroups(tgb); err != nil {
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkExistingTargetGroups")
 		return err
@@ -121,6 +125,10 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkNodeSelector")
 		return err
 	}
+	if err := v.checkWeightedRegistration(tgb); err != nil {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkWeightedRegistration")
+		return err
+	}
 	if err := v.checkAssumeRoleConfig(tgb); err != nil {
 		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateELBv2TargetGroupBinding, "checkAssumeRoleConfig")
 		return err
@@ -235,6 +243,15 @@ func (v *targetGroupBindingValidator) checkNodeSelector(tgb *elbv2api.TargetGrou
 	return nil
 }
 
+// checkWeightedRegistration ensures that WeightedRegistration is only set on multi-cluster TargetGroupBindings,
+// as the TargetGroup is shared with the other weighted TargetGroupBindings.
+func (v *targetGroupBindingValidator) checkWeightedRegistration(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.WeightedRegistration != nil && !tgb.Spec.MultiClusterTargetGroup {
+		return errors.Errorf("TargetGroupBinding must set MultiClusterTargetGroup when WeightedRegistration is set")
+	}
+	return nil
+}
+
 // checkTargetGroupIPAddressType ensures IP address type matches with that on the AWS target group
 func (v *targetGroupBindingValidator) checkTargetGroupIPAddressType(tgb *elbv2api.TargetGroupBinding, tgCache func() tgCacheObject) error {
 	targetGroupIPAddressType, err := v.getTargetGroupIPAddressTypeFromAWS(tgCache)
@@ -1171,6 +1171,57 @@ func Test_targetGroupBindingValidator_checkNodeSelector(t *testing.T) {
 	}
 }
 
+func Test_targetGroupBindingValidator_checkWeightedRegistration(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "[ok] weightedRegistration is nil",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+		},
+		{
+			name: "[ok] weightedRegistration is set on multi-cluster TargetGroupBinding",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:          "tg-1",
+					MultiClusterTargetGroup: true,
+					WeightedRegistration:    &elbv2api.WeightedRegistration{Weight: 20},
+				},
+			},
+		},
+		{
+			name: "[err] weightedRegistration is set without multiClusterTargetGroup",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:       "tg-1",
+					WeightedRegistration: &elbv2api.WeightedRegistration{Weight: 20},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding must set MultiClusterTargetGroup when WeightedRegistration is set"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger:           logr.New(&log.NullLogSink{}),
+				metricsCollector: lbcmetrics.NewMockCollector(),
+			}
+			err := v.checkWeightedRegistration(tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_targetGroupBindingValidator_checkExistingTargetGroups(t *testing.T) {
 
 	type env struct {
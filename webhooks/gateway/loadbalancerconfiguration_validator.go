@@ -0,0 +1,161 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/capacityreservation"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/gatewayutils"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const apiPathValidateGatewayLoadBalancerConfiguration = "/validate-gateway-k8s-aws-v1beta1-loadbalancerconfiguration"
+
+// NewLoadBalancerConfigurationValidator returns a validator for the LoadBalancerConfiguration CRD.
+// The configurations attached to Gateways of gwControllers are checked against the policies of their GatewayClass.
+func NewLoadBalancerConfigurationValidator(k8sClient client.Client, gwControllers sets.Set[string], metricsCollector lbcmetrics.MetricCollector) *loadBalancerConfigurationValidator {
+	return &loadBalancerConfigurationValidator{
+		k8sClient:        k8sClient,
+		gwControllers:    gwControllers,
+		configMerger:     gateway.NewLoadBalancerConfigMerger(),
+		policyEnforcer:   gateway.NewLoadBalancerConfigPolicyEnforcer(),
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ webhook.Validator = &loadBalancerConfigurationValidator{}
+
+type loadBalancerConfigurationValidator struct {
+	k8sClient        client.Client
+	gwControllers    sets.Set[string]
+	configMerger     gateway.LoadBalancerConfigMerger
+	policyEnforcer   gateway.LoadBalancerConfigPolicyEnforcer
+	metricsCollector lbcmetrics.MetricCollector
+}
+
+func (v *loadBalancerConfigurationValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &elbv2gw.LoadBalancerConfiguration{}, nil
+}
+
+func (v *loadBalancerConfigurationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	lbConfig := obj.(*elbv2gw.LoadBalancerConfiguration)
+	return v.validate(ctx, lbConfig)
+}
+
+func (v *loadBalancerConfigurationValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	lbConfig := obj.(*elbv2gw.LoadBalancerConfiguration)
+	return v.validate(ctx, lbConfig)
+}
+
+func (v *loadBalancerConfigurationValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *loadBalancerConfigurationValidator) validate(ctx context.Context, lbConfig *elbv2gw.LoadBalancerConfiguration) error {
+	allErrs := field.ErrorList{}
+	if errs := gateway.ValidateLoadBalancerConfigPolicies(lbConfig.Spec.Policies, field.NewPath("spec", "policies")); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateGatewayLoadBalancerConfiguration, "checkPolicies")
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := v.checkMinimumLoadBalancerCapacity(lbConfig); len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateGatewayLoadBalancerConfiguration, "checkMinimumLoadBalancerCapacity")
+		allErrs = append(allErrs, errs...)
+	}
+	errs, err := v.checkGatewayClassPolicies(ctx, lbConfig)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		v.metricsCollector.ObserveWebhookValidationError(apiPathValidateGatewayLoadBalancerConfiguration, "checkGatewayClassPolicies")
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs.ToAggregate()
+}
+
+// checkMinimumLoadBalancerCapacity checks the capacity reservation schedules, calendar and time zone.
+func (v *loadBalancerConfigurationValidator) checkMinimumLoadBalancerCapacity(lbConfig *elbv2gw.LoadBalancerConfiguration) field.ErrorList {
+	capacity := capacityreservation.FromLoadBalancerConfiguration(lbConfig.Spec.MinimumLoadBalancerCapacity)
+	if capacity == nil {
+		return nil
+	}
+	if err := capacityreservation.Validate(*capacity); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "minimumLoadBalancerCapacity"), field.OmitValueType{}, err.Error())}
+	}
+	return nil
+}
+
+// checkGatewayClassPolicies checks that the configuration complies with the policies of the GatewayClasses of the Gateways it's attached to.
+func (v *loadBalancerConfigurationValidator) checkGatewayClassPolicies(ctx context.Context, lbConfig *elbv2gw.LoadBalancerConfiguration) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	checkedGwClasses := sets.New[string]()
+	for _, gwController := range sets.List(v.gwControllers) {
+		gws, err := gatewayutils.GetImpactedGatewaysFromLbConfig(ctx, v.k8sClient, lbConfig, gwController)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Gateways: %w", err)
+		}
+		for _, gw := range gws {
+			gwClassName := string(gw.Spec.GatewayClassName)
+			if checkedGwClasses.Has(gwClassName) {
+				continue
+			}
+			checkedGwClasses.Insert(gwClassName)
+			gwClassLbConfig, err := v.resolveGatewayClassLbConfig(ctx, gwClassName)
+			if err != nil {
+				return nil, err
+			}
+			if gwClassLbConfig == nil || len(gwClassLbConfig.Spec.Policies) == 0 {
+				continue
+			}
+			if gwClassLbConfig.Namespace == lbConfig.Namespace && gwClassLbConfig.Name == lbConfig.Name {
+				continue
+			}
+			mergedLbConfig := v.configMerger.Merge(*gwClassLbConfig, *lbConfig)
+			if _, err := v.policyEnforcer.Enforce(*gwClassLbConfig, lbConfig, mergedLbConfig); err != nil {
+				var violationErr *gateway.PolicyViolationError
+				if !errors.As(err, &violationErr) {
+					return nil, err
+				}
+				for _, violation := range violationErr.Violations {
+					allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+						fmt.Sprintf("%s, per the policies of GatewayClass %s", violation, gwClassName)))
+				}
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+func (v *loadBalancerConfigurationValidator) resolveGatewayClassLbConfig(ctx context.Context, gwClassName string) (*elbv2gw.LoadBalancerConfiguration, error) {
+	gwClass := &gwv1.GatewayClass{}
+	if err := v.k8sClient.Get(ctx, types.NamespacedName{Name: gwClassName}, gwClass); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	paramsRef := gwClass.Spec.ParametersRef
+	if paramsRef == nil || paramsRef.Namespace == nil || string(paramsRef.Kind) != constants.LoadBalancerConfiguration {
+		return nil, nil
+	}
+	gwClassLbConfig, err := gatewayutils.ResolveLoadBalancerConfig(ctx, v.k8sClient, paramsRef)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return gwClassLbConfig, nil
+}
+
+// +kubebuilder:webhook:path=/validate-gateway-k8s-aws-v1beta1-loadbalancerconfiguration,mutating=false,failurePolicy=fail,groups=gateway.k8s.aws,resources=loadbalancerconfigurations,verbs=create;update,versions=v1beta1,name=vloadbalancerconfiguration.gateway.k8s.aws,sideEffects=None,webhookVersions=v1,admissionReviewVersions=v1
+
+func (v *loadBalancerConfigurationValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateGatewayLoadBalancerConfiguration, webhook.ValidatingWebhookForValidator(v, mgr.GetScheme()))
+}
@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2gw "sigs.k8s.io/aws-load-balancer-controller/apis/gateway/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gateway/constants"
+	lbcmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/metrics/lbc"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_loadBalancerConfigurationValidator_ValidateCreate(t *testing.T) {
+	internalScheme := elbv2gw.LoadBalancerSchemeInternal
+	internetFacingScheme := elbv2gw.LoadBalancerSchemeInternetFacing
+	platformNamespace := gwv1.Namespace("platform")
+	gwClassLbConfig := &elbv2gw.LoadBalancerConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "guardrails"},
+		Spec: elbv2gw.LoadBalancerConfigurationSpec{
+			Scheme: &internalScheme,
+			Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+				{Field: elbv2gw.PolicyFieldScheme, Locked: true},
+				{Field: elbv2gw.PolicyFieldTags, RequiredKeys: []string{"team"}},
+			},
+		},
+	}
+	gwClass := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "alb"},
+		Spec: gwv1.GatewayClassSpec{
+			ControllerName: constants.ALBGatewayController,
+			ParametersRef: &gwv1.ParametersReference{
+				Group:     constants.ControllerCRDGroupVersion,
+				Kind:      constants.LoadBalancerConfiguration,
+				Name:      "guardrails",
+				Namespace: &platformNamespace,
+			},
+		},
+	}
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "checkout", Name: "gw"},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: "alb",
+			Infrastructure: &gwv1.GatewayInfrastructure{
+				ParametersRef: &gwv1.LocalParametersReference{
+					Group: constants.ControllerCRDGroupVersion,
+					Kind:  constants.LoadBalancerConfiguration,
+					Name:  "gw-config",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		obj        *elbv2gw.LoadBalancerConfiguration
+		wantErr    string
+		wantMetric bool
+	}{
+		{
+			name: "compliant gateway configuration",
+			obj: &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "checkout", Name: "gw-config"},
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Scheme: &internalScheme,
+					Tags:   &map[string]string{"team": "checkout"},
+				},
+			},
+		},
+		{
+			name: "unattached configuration",
+			obj: &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "checkout", Name: "other"},
+				Spec:       elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacingScheme},
+			},
+		},
+		{
+			name: "gateway configuration violates policies",
+			obj: &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "checkout", Name: "gw-config"},
+				Spec:       elbv2gw.LoadBalancerConfigurationSpec{Scheme: &internetFacingScheme},
+			},
+			wantErr: "[spec: Forbidden: scheme is locked and cannot be set to [internet-facing], per the policies of GatewayClass alb, " +
+				"spec: Forbidden: tag \"team\" is required, per the policies of GatewayClass alb]",
+			wantMetric: true,
+		},
+		{
+			name: "invalid policies",
+			obj: &elbv2gw.LoadBalancerConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "guardrails"},
+				Spec: elbv2gw.LoadBalancerConfigurationSpec{
+					Policies: []elbv2gw.LoadBalancerConfigurationPolicy{
+						{Field: elbv2gw.PolicyFieldScheme, Default: awssdk.String("internet-facing"), AllowedValues: []string{"internal"}},
+					},
+				},
+			},
+			wantErr:    "spec.policies[0].default: Invalid value: \"internet-facing\": default must be one of allowedValues",
+			wantMetric: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2gw.AddToScheme(k8sSchema)
+			gwv1.Install(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithObjects(gwClassLbConfig.DeepCopy(), gwClass.DeepCopy(), gw.DeepCopy()).Build()
+			mockMetricsCollector := lbcmetrics.NewMockCollector()
+			v := NewLoadBalancerConfigurationValidator(k8sClient, sets.New(constants.ALBGatewayController), mockMetricsCollector)
+			err := v.ValidateCreate(context.Background(), tt.obj)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockCollector := mockMetricsCollector.(*lbcmetrics.MockCollector)
+			assert.Equal(t, tt.wantMetric, len(mockCollector.Invocations[lbcmetrics.MetricWebhookValidationFailure]) == 1)
+		})
+	}
+}